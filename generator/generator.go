@@ -21,7 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"math"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -34,17 +34,6 @@ import (
 )
 
 const (
-	// enumMapFn is the filename to be used for the enum map when Go code is output to a directory.
-	enumMapFn = "enum_map.go"
-	// enumFn is the filename to be used for the enum code when Go code is output to a directory.
-	enumFn = "enum.go"
-	// schemaFn is the filename to be used for the schema code when outputting to a directory.
-	schemaFn = "schema.go"
-	// interfaceFn is the filename to be used for interface code when outputting to a directory.
-	interfaceFn = "union.go"
-	// structsFileFmt is the format string filename (missing index) to be
-	// used for files containing structs when outputting to a directory.
-	structsFileFmt = "structs-%d.go"
 	// pathStructsFileFmt is the format string filename (missing index) to
 	// be used for the path structs when path struct code is output to a directory.
 	pathStructsFileFmt = "path_structs-%d.go"
@@ -67,10 +56,15 @@ var (
 	ignoreCircDeps                       = flag.Bool("ignore_circdeps", false, "If set to true, circular dependencies between submodules are ignored.")
 	fakeRootName                         = flag.String("fakeroot_name", "", "The name of the fake root entity.")
 	excludeState                         = flag.Bool("exclude_state", false, "If set to true, state (config false) fields in the YANG schema are not included in the generated Go code.")
+	includeNotificationNodes             = flag.Bool("include_notification_nodes", false, "If set to true, the contents of notifications, and the input and output of rpcs and actions, are mapped to GoStructs, such that payloads for these operations can be constructed.")
+	ignoreDeviations                     = flag.Bool("ignore_deviations", false, "If set to true, deviation statements within the input YANG modules are not applied to the schema prior to code generation.")
+	includeOnlyPaths                     = flag.String("include_only_paths", "", "Comma separated set of schema paths (e.g., /interfaces/interface) to which GoStruct generation is restricted. When set, only the ancestors and descendants of the specified paths are included in the generated code.")
 	skipEnumDedup                        = flag.Bool("skip_enum_deduplication", false, "If set to true, all leaves of type enumeration will have a unique enum output for them, rather than sharing a common type (default behaviour).")
 	preferOperationalState               = flag.Bool("prefer_operational_state", false, "If set to true, state (config false) fields in the YANG schema are preferred over intended config leaves in the generated Go code with compressed schema paths. This flag is only valid for compress_paths=true and exclude_state=false.")
 	ignoreShadowSchemaPaths              = flag.Bool("ignore_shadow_schema_paths", false, "If set to true when compress_paths=true, the shadowed schema path will be ignored while unmarshalling instead of causing an error. A shadow schema path is a config or state path which is selected over the other during schema compression when both config and state versions of the node exist.")
 	shortenEnumLeafNames                 = flag.Bool("shorten_enum_leaf_names", false, "If also set to true when compress_paths=true, all leaves of type enumeration will by default not be prefixed with the name of its residing module.")
+	errorOnCompressionCollision          = flag.Bool("error_on_compression_collision", false, "If set to true when compress_paths=true, generation fails if two schema paths compress to the same path.")
+	trackChoiceMembership                = flag.Bool("track_choice_membership", false, "If set to true, a choice struct tag containing the names of the choice and case statements within which each field is defined in the YANG schema is added to the field.")
 	useDefiningModuleForTypedefEnumNames = flag.Bool("typedef_enum_with_defmod", false, "If set to true, all typedefs of type enumeration or identity will be prefixed with the name of its module of definition instead of its residing module.")
 	appendEnumSuffixForSimpleUnionEnums  = flag.Bool("enum_suffix_for_simple_union_enums", false, "If set to true when typedef_enum_with_defmod is also true, all inlined enumerations within unions will be suffixed with \"Enum\", instead of adding the suffix only for inlined enumerations within typedef unions.")
 	ygotImportPath                       = flag.String("ygot_path", genutil.GoDefaultYgotImportPath, "The import path to use for ygot.")
@@ -80,12 +74,16 @@ var (
 
 	// Flags used for GoStruct generation only.
 	generateFakeRoot        = flag.Bool("generate_fakeroot", false, "If set to true, a fake element at the root of the data tree is generated. By default the fake root entity is named Device, its name can be controlled with the fakeroot_name flag.")
+	generateRootHelpers     = flag.Bool("generate_root_helpers", false, "If set to true when generate_fakeroot=false, package-level helper functions, such as EmitAllJSON, that operate over a set of instances of the structs at the root of the data tree are generated in place of the fake root.")
 	generateSchema          = flag.Bool("include_schema", true, "If set to true, the YANG schema will be encoded as JSON and stored in the generated code artefact.")
+	schemaPerModule         = flag.Bool("schema_per_module", false, "If set to true when include_schema=true, the YANG schema is stored as one JSON document per YANG module, which are merged when the schema is unzipped.")
 	ytypesImportPath        = flag.String("ytypes_path", genutil.GoDefaultYtypesImportPath, "The import path to use for ytypes.")
 	goyangImportPath        = flag.String("goyang_path", genutil.GoDefaultGoyangImportPath, "The import path to use for goyang's yang package.")
+	protomapImportPath      = flag.String("protomap_path", genutil.GoDefaultProtomapImportPath, "The import path to use for protomap, when generate_proto_bridge is set.")
 	generateRename          = flag.Bool("generate_rename", false, "If set to true, rename methods are generated for lists within the Go code.")
 	addAnnotations          = flag.Bool("annotations", false, "If set to true, metadata annotations are added within the generated structs.")
 	annotationPrefix        = flag.String("annotation_prefix", ygen.DefaultAnnotationPrefix, "String to be appended to each metadata field within the generated structs if annoations is set to true.")
+	annotationFieldType     = flag.String("annotation_field_type", "", "If set, the Go type that is used for each metadata field within the generated structs if annotations is set to true, in place of []ygot.Annotation. The type must implement ygot.AnnotationContainer.")
 	addYangPresence         = flag.Bool("yangpresence", false, "If set to true, a tag will be added to the field of a generated Go struct to indicate when a YANG presence container is being used.")
 	generateAppend          = flag.Bool("generate_append", false, "If set to true, append methods are generated for YANG lists (Go maps) within the Go code.")
 	generateGetters         = flag.Bool("generate_getters", false, "If set to true, getter methdos that retrieve or create an element are generated for YANG container (Go struct pointer) or list (Go map) fields within the generated code.")
@@ -95,6 +93,49 @@ var (
 	includeModelData        = flag.Bool("include_model_data", false, "If set to true, a slice of gNMI ModelData messages are included in the generated Go code containing the details of the input schemas from which the code was generated.")
 	generatePopulateDefault = flag.Bool("generate_populate_defaults", false, "If set to true, a PopulateDefault method will be generated for all GoStructs which recursively populates default values.")
 	generateValidateFnName  = flag.String("validate_fn_name", "Validate", "The Name of the proxy function for the Validate functionality.")
+	defaultEnumAsZero       = flag.Bool("default_enum_as_zero", false, "If set to true, enumerated types that have a default value in the YANG schema use the default as their zero value in place of UNSET. Leaves set to their default value are then not included in rendered output.")
+	generatePathTypeMap     = flag.Bool("generate_path_type_registry", false, "If set to true, a map from the schema path of each generated GoStruct to its reflect.Type is generated within the Go code.")
+	generateInterfaceChecks = flag.Bool("generate_interface_checks", false, "If set to true, compile-time assertions that each generated GoStruct implements the ygot interfaces that it is expected to are generated within the Go code.")
+	addJSONTags             = flag.Bool("add_json_tags", false, "If set to true, a json tag containing the RFC7951 name of the field is added to each field of the generated GoStructs, such that they can be marshalled using encoding/json.")
+	generateEqualMethod     = flag.Bool("generate_equal_method", false, "If set to true, an Equal method that compares two instances of the struct without the use of reflection is generated for all GoStructs.")
+	generatePoolReset       = flag.Bool("generate_pool_reset", false, "If set to true, a ΛReset method that recursively clears the struct in place without the use of reflection, such that it can be reused from a sync.Pool, is generated for all GoStructs.")
+	generateChildPaths      = flag.Bool("generate_child_paths", false, "If set to true, a ΛPopulatedChildren method that returns the schema paths of the populated children of the struct, including the keys of list members, without the use of reflection is generated for all GoStructs.")
+	nonPtrMandatoryLeaves   = flag.Bool("non_pointer_mandatory_leaves", false, "If set to true, scalar leaves that are mandatory, and list keys, are generated as value types rather than pointers within the GoStructs.")
+	generateSetters         = flag.Bool("generate_setters", false, "If set to true, setters for YANG leaves are generated within the Go code. The setters for integer leaves with range restrictions return an error if the value supplied is outside of the range.")
+	generateHasMethods      = flag.Bool("generate_has_methods", false, "If set to true, HasXXX methods that return whether each field of a GoStruct is populated are generated within the Go code.")
+	genericUnions           = flag.Bool("generic_unions", false, "If set to true, a type constraint and a generic constructor are generated for each union, along with a generic UnionValue type. Requires generate_simple_unions, and Go 1.18 or later to compile the generated code.")
+	fixedArrays             = flag.Bool("fixed_arrays_for_bounded_lists", false, "If set to true, leaf-lists whose min-elements and max-elements are equal, or whose max-elements does not exceed fixed_array_max_elements, are generated as fixed-size arrays along with a field storing the number of populated elements.")
+	fixedArrayMaxElements   = flag.Uint64("fixed_array_max_elements", 0, "The largest max-elements of a leaf-list with differing min-elements and max-elements that is generated as a fixed-size array when fixed_arrays_for_bounded_lists is set.")
+	validateWithContext     = flag.Bool("validate_with_context", false, "If set to true, a ΛValidateContext method which accepts a context.Context that can be used to cancel validation is generated for each GoStruct.")
+	keyStructHelpers        = flag.Bool("generate_key_struct_helpers", false, "If set to true, String and Fields methods are generated for the key struct of each multi-keyed list within the Go code.")
+	listKeyConstants        = flag.Bool("generate_list_key_constants", false, "If set to true, a variable containing the YANG names of the keys of each keyed list, in schema order, is generated within the Go code.")
+	generateEnumRegistry    = flag.Bool("generate_global_enum_registry", false, "If set to true, a map from the name of each generated enumerated type to the ordered list of its values is generated within the Go code.")
+	enumFromString          = flag.Bool("generate_enum_from_string", false, "If set to true, a function that returns the value of each generated enumerated type that is represented by a string, which may be prefixed by the name of the module that defines the value, is generated within the Go code.")
+	embedMetadataType       = flag.String("embed_metadata_type", "", "If set, the named type is embedded as a ΛMetadata field within each generated GoStruct. The type must be defined within the generated package.")
+	customTypes             = flag.String("custom_types", "", "Comma separated set of typedef=type pairs specifying the Go type that leaves of the named YANG typedef, qualified by its defining module, should be output as, e.g., ietf-inet-types:ipv4-address=net/netip.Addr.")
+	binaryTypeName          = flag.String("binary_type_name", "", "If set, the package-qualified name of a Go type, e.g., keys.Key, which is defined as a []byte and implements ygot.Base64Marshaler, that is used for YANG binary leaves in place of the generated Binary type.")
+	binaryTypeImport        = flag.String("binary_type_import", "", "The import path of the package defining the type named by binary_type_name.")
+	buildTags               = flag.String("build_tags", "", "Comma separated set of build tags that must be satisfied for the generated Go files to be compiled. Each tag may be negated by prefixing it with '!'.")
+	unionAccessors          = flag.Bool("generate_union_accessors", false, "If set to true, methods that return the value of a union leaf as each of the types within the union, along with whether the union holds a value of that type, are generated within the Go code.")
+	validationHooks         = flag.Bool("generate_validation_hooks", false, "If set to true, each generated Go struct has a ΛValidateFieldHooks field, which stores functions, keyed by field name, that are called to validate the value of the field when the struct is validated.")
+	orderedLists            = flag.Bool("generate_ordered_lists", false, "If set to true, keyed lists that are ordered-by user are represented in the generated Go code by an ordered map type, which retains the order in which list members are appended, rather than a map.")
+	runtimeCompatVersion    = flag.String("runtime_compat_version", "", "The release of the ygot runtime libraries, e.g., v0.12, that the generated Go code must be compatible with. Methods that are not supported by the release are not generated. If unset, code is generated for the current release.")
+	emptyLeafAsBool         = flag.Bool("empty_leaf_as_bool", false, "If set to true, leaves of the YANG empty type are represented in the generated Go code as *bool fields, which are output as JSON booleans, rather than as YANGEmpty fields, which are output as [null] in RFC7951 JSON.")
+	trackFieldPresence      = flag.Bool("track_field_presence", false, "If set to true, each generated Go struct records which of its leaves have been set using their setter methods, which can be queried using its WasSet method. Requires generate_setters to be set.")
+	subtreeValidate         = flag.Bool("generate_subtree_validate", false, "If set to true, a ΛValidateAt method which validates only the subtree at the supplied gNMI path is generated for each GoStruct.")
+	generateCBORMethods     = flag.Bool("generate_cbor_methods", false, "If set to true, ΛMarshalCBOR and ΛUnmarshalCBOR methods, which take a ygot.CBORCodec and encode the RFC7951 structure of the data tree rather than RFC9254 YANG-CBOR, are generated for the fake root. generate_fakeroot and include_schema must be set.")
+	generateProtoBridge     = flag.Bool("generate_proto_bridge", false, "If set to true, ToProto and FromProto methods, which map the data tree to and from the protobuf generated for the root of the same YANG schema, are generated for the fake root. Leaf-lists, decimal64 leaves and protobufs generated with use_proto_maps are not supported. generate_fakeroot and include_schema must be set.")
+	generateRootGetByPath   = flag.Bool("generate_root_get_by_path", false, "If set to true, a GetByPath method, which returns the value found at a supplied gNMI path, is generated for the fake root. generate_fakeroot and include_schema must be set.")
+	enumOutputFile          = flag.String("enum_output_file", "", "If set along with output_file, the generated enumerated types, and the maps describing them, are written to this file rather than output_file, such that changes to one file do not require the other to be recompiled. Both files are within the same package.")
+	openAPISchemaOutputFile = flag.String("openapi_schema_output_file", "", "If set, a JSON Schema (draft 2020-12) document describing the RFC7951 JSON encoding of the data tree, suitable for use by OpenAPI documentation generators, is written to this file.")
+	constraintComments      = flag.Bool("include_constraint_comments", false, "If set to true, the must, when, range, length and pattern statements that apply to each leaf are output as a comment above its field within the generated Go structs.")
+	markDeprecated          = flag.Bool("mark_deprecated", false, "If set to true, a Deprecated paragraph is output within the comment of each generated struct and field that corresponds to a YANG node whose status is deprecated or obsolete.")
+	valueEntryLists         = flag.Bool("value_entry_lists", false, "If set to true, keyed lists whose members contain only leaves are stored in the generated Go code as a map of struct values, rather than struct pointers.")
+	listKeyStructSuffix     = flag.String("list_key_struct_suffix", "", "The suffix appended to the name of a multi-keyed list's struct to form the name of the struct generated for its key. If unset, _Key is used.")
+	leafrefValidation       = flag.Bool("generate_leafref_validation", false, "If set to true, the fake root's Validate method checks that the value of each leafref exists at its target using generated code, rather than by traversing the schema at run time. Leafref path predicates are not evaluated. generate_fakeroot and include_schema must be set.")
+	typedLeafrefs           = flag.Bool("typed_leafrefs", false, "If set to true, a union leaf whose members are all leafrefs to the same union leaf is represented by the union type generated for its target, rather than by a separate union type.")
+	defaultConstants        = flag.Bool("generate_default_constants", false, "If set to true, a package-level variable containing the YANG default value of each leaf and leaf-list that has a default is generated within the Go code.")
+	fileHeaderPath          = flag.String("file_header_path", "", "If set, the contents of the file at the specified path, such as a licence, are output as a comment at the top of each generated Go file.")
 
 	// Flags used for PathStruct generation only.
 	schemaStructPath        = flag.String("schema_struct_path", "", "The Go import path for the schema structs package. This should be specified if and only if schema structs are not being generated at the same time as path structs.")
@@ -112,33 +153,8 @@ var (
 // snippets contained within it to the io.Writer, w, provided as an argument.
 // The output includes a package header which is generated.
 func writeGoCodeSingleFile(w io.Writer, goCode *ygen.GeneratedGoCode) error {
-	// Write the package header to the supplier writer.
-	fmt.Fprint(w, goCode.CommonHeader)
-	fmt.Fprint(w, goCode.OneOffHeader)
-
-	// Write the returned Go code out. First the Structs - which is the struct
-	// definitions for the generated YANG entity, followed by the enumerations.
-	for _, snippet := range goCode.Structs {
-		fmt.Fprintln(w, snippet.String())
-	}
-
-	for _, snippet := range goCode.Enums {
-		fmt.Fprintln(w, snippet)
-	}
-
-	// Write the generated enumeration map out.
-	fmt.Fprintln(w, goCode.EnumMap)
-
-	// Write the schema out if it was received.
-	if len(goCode.JSONSchemaCode) > 0 {
-		fmt.Fprintln(w, goCode.JSONSchemaCode)
-	}
-
-	if len(goCode.EnumTypeMap) > 0 {
-		fmt.Fprintln(w, goCode.EnumTypeMap)
-	}
-
-	return nil
+	_, err := io.WriteString(w, goCode.String())
+	return err
 }
 
 // writeGoPathCodeSingleFile takes a ypathgen.GeneratedPathCode struct and writes
@@ -149,78 +165,6 @@ func writeGoPathCodeSingleFile(w io.Writer, pathCode *ypathgen.GeneratedPathCode
 	return err
 }
 
-// splitCodeByFileN generates a map, keyed by filename, to a string containing
-// the code to be output to that filename. It allows division of a
-// ygen.GeneratedGoCode struct into a set of source files. It divides the
-// methods, interfaces, and enumeration code snippets into their own files.
-// Structs are output into files by splitting them evenly among the input split
-// number.
-func splitCodeByFileN(goCode *ygen.GeneratedGoCode, fileN int) (map[string]string, error) {
-	structN := len(goCode.Structs)
-	if fileN < 1 || fileN > structN {
-		return nil, fmt.Errorf("requested %d files, but must be between 1 and %d (number of schema structs)", fileN, structN)
-	}
-
-	out := map[string]string{
-		schemaFn: goCode.JSONSchemaCode,
-		enumFn:   strings.Join(goCode.Enums, "\n"),
-	}
-
-	var structFiles []string
-	var code, interfaceCode strings.Builder
-	structsPerFile := int(math.Ceil(float64(structN) / float64(fileN)))
-	// Empty files could appear with certain structN/fileN combinations due
-	// to the ceiling numbers being used for structsPerFile.
-	// e.g. 4/3 gives two files of two structs.
-	// This is a little more complex, but spreads out the structs more evenly.
-	// If we instead use the floor number, and put all remainder structs in
-	// the last file, we might double the last file's number of structs if we get unlucky.
-	// e.g. 99/10 assigns 18 structs to the last file.
-	emptyFiles := fileN - int(math.Ceil(float64(structN)/float64(structsPerFile)))
-	code.WriteString(goCode.OneOffHeader)
-	for i, s := range goCode.Structs {
-		code.WriteString(s.StructDef)
-		code.WriteString(s.ListKeys)
-		code.WriteString("\n")
-		code.WriteString(s.Methods)
-		if s.Methods != "" {
-			code.WriteString("\n")
-		}
-		interfaceCode.WriteString(s.Interfaces)
-		if s.Interfaces != "" {
-			interfaceCode.WriteString("\n")
-		}
-		// The last file contains the remainder of the structs.
-		if i == structN-1 || (i+1)%structsPerFile == 0 {
-			structFiles = append(structFiles, code.String())
-			code.Reset()
-		}
-	}
-	for i := 0; i != emptyFiles; i++ {
-		structFiles = append(structFiles, "")
-	}
-
-	for i, structFile := range structFiles {
-		out[fmt.Sprintf(structsFileFmt, i)] = structFile
-	}
-
-	code.Reset()
-	code.WriteString(goCode.EnumMap)
-	if code.Len() != 0 {
-		code.WriteString("\n")
-	}
-	code.WriteString(goCode.EnumTypeMap)
-
-	out[enumMapFn] = code.String()
-	out[interfaceFn] = interfaceCode.String()
-
-	for name, code := range out {
-		out[name] = goCode.CommonHeader + code
-	}
-
-	return out, nil
-}
-
 // writeFiles creates or truncates files in a given base directory and writes
 // to them. Keys of the contents map are file names, and values are the
 // contents to be written. An error is returned if the base directory does not
@@ -308,6 +252,40 @@ func main() {
 		}
 	}
 
+	// Determine which schema paths the user has requested code generation
+	// to be restricted to.
+	var pathsIncluded []string
+	if len(*includeOnlyPaths) > 0 {
+		pathsIncluded = strings.Split(*includeOnlyPaths, ",")
+	}
+
+	// Determine which YANG typedefs are to be mapped to custom Go types.
+	customTypeMap := map[string]string{}
+	if len(*customTypes) > 0 {
+		for _, m := range strings.Split(*customTypes, ",") {
+			parts := strings.SplitN(m, "=", 2)
+			if len(parts) != 2 {
+				log.Exitf("ERROR Generating Code: invalid custom type mapping %s, must be of the form typedef=type\n", m)
+			}
+			customTypeMap[parts[0]] = parts[1]
+		}
+	}
+
+	// Determine the build tags and header text that are to be output at the
+	// top of each generated Go file.
+	var goBuildTags []string
+	if len(*buildTags) > 0 {
+		goBuildTags = strings.Split(*buildTags, ",")
+	}
+	var fileHeaderText string
+	if *fileHeaderPath != "" {
+		b, err := ioutil.ReadFile(*fileHeaderPath)
+		if err != nil {
+			log.Exitf("ERROR Generating Code: cannot read file header %s: %v\n", *fileHeaderPath, err)
+		}
+		fileHeaderText = string(b)
+	}
+
 	if *generateGoStructs {
 		generateGoStructsSingleFile := *ocStructsOutputFile != ""
 		generateGoStructsMultipleFiles := *outputDir != ""
@@ -317,6 +295,9 @@ func main() {
 		if !generateGoStructsSingleFile && !generateGoStructsMultipleFiles {
 			log.Exitf("Error: Go struct generation requires a specified output file or output directory.")
 		}
+		if *enumOutputFile != "" && !generateGoStructsSingleFile {
+			log.Exitf("Error: enum_output_file (%s) requires output_file to be specified.", *enumOutputFile)
+		}
 
 		compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
 		if err != nil {
@@ -326,8 +307,11 @@ func main() {
 		// Perform the code generation.
 		cg := ygen.NewYANGCodeGenerator(&ygen.GeneratorConfig{
 			ParseOptions: ygen.ParseOpts{
-				ExcludeModules:        modsExcluded,
-				SkipEnumDeduplication: *skipEnumDedup,
+				ExcludeModules:           modsExcluded,
+				SkipEnumDeduplication:    *skipEnumDedup,
+				IgnoreDeviations:         *ignoreDeviations,
+				IncludeNotificationNodes: *includeNotificationNodes,
+				IncludeOnlyPaths:         pathsIncluded,
 				YANGParseOptions: yang.Options{
 					IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
 				},
@@ -341,17 +325,25 @@ func main() {
 				EnumOrgPrefixesToTrim:                enumOrgPrefixesToTrim,
 				UseDefiningModuleForTypedefEnumNames: *useDefiningModuleForTypedefEnumNames,
 				EnumerationsUseUnderscores:           true,
+				ErrorOnCompressionCollision:          *errorOnCompressionCollision,
+				TrackChoiceMembership:                *trackChoiceMembership,
+				GenerateRootHelpersOnly:              *generateRootHelpers,
+				ListKeyStructSuffix:                  *listKeyStructSuffix,
 			},
-			PackageName:         *packageName,
-			GenerateJSONSchema:  *generateSchema,
-			IncludeDescriptions: *includeDescriptions,
+			PackageName:                 *packageName,
+			GenerateJSONSchema:          *generateSchema,
+			GenerateJSONSchemaPerModule: *schemaPerModule,
+			IncludeDescriptions:         *includeDescriptions,
+			GenerateOpenAPISchema:       *openAPISchemaOutputFile != "",
 			GoOptions: ygen.GoOpts{
 				YgotImportPath:                      *ygotImportPath,
 				YtypesImportPath:                    *ytypesImportPath,
 				GoyangImportPath:                    *goyangImportPath,
+				ProtomapImportPath:                  *protomapImportPath,
 				GenerateRenameMethod:                *generateRename,
 				AddAnnotationFields:                 *addAnnotations,
 				AnnotationPrefix:                    *annotationPrefix,
+				AnnotationFieldType:                 *annotationFieldType,
 				AddYangPresence:                     *addYangPresence,
 				GenerateGetters:                     *generateGetters,
 				GenerateDeleteMethod:                *generateDelete,
@@ -362,6 +354,47 @@ func main() {
 				GenerateSimpleUnions:                *generateSimpleUnions,
 				IncludeModelData:                    *includeModelData,
 				AppendEnumSuffixForSimpleUnionEnums: *appendEnumSuffixForSimpleUnionEnums,
+				GeneratePathTypeRegistry:            *generatePathTypeMap,
+				GenerateInterfaceChecks:             *generateInterfaceChecks,
+				EmbedMetadataType:                   *embedMetadataType,
+				GenerateEqualMethod:                 *generateEqualMethod,
+				GeneratePoolReset:                   *generatePoolReset,
+				GenerateChildPaths:                  *generateChildPaths,
+				AddJSONTags:                         *addJSONTags,
+				DefaultEnumAsZero:                   *defaultEnumAsZero,
+				NonPointerMandatoryLeaves:           *nonPtrMandatoryLeaves,
+				GenerateSetters:                     *generateSetters,
+				GenerateGlobalEnumRegistry:          *generateEnumRegistry,
+				GenerateEnumFromString:              *enumFromString,
+				GenerateHasMethods:                  *generateHasMethods,
+				GenericUnions:                       *genericUnions,
+				FixedArraysForBoundedLists:          *fixedArrays,
+				FixedArrayMaxElements:               *fixedArrayMaxElements,
+				ValidateWithContext:                 *validateWithContext,
+				GenerateListKeyConstants:            *listKeyConstants,
+				GenerateKeyStructHelpers:            *keyStructHelpers,
+				FileHeaderText:                      fileHeaderText,
+				BuildTags:                           goBuildTags,
+				GenerateUnionAccessors:              *unionAccessors,
+				GenerateValidationHooks:             *validationHooks,
+				GenerateOrderedListSupport:          *orderedLists,
+				RuntimeCompatVersion:                *runtimeCompatVersion,
+				EmptyLeafAsBool:                     *emptyLeafAsBool,
+				TrackFieldPresence:                  *trackFieldPresence,
+				GenerateSubtreeValidate:             *subtreeValidate,
+				GenerateCBORMethods:                 *generateCBORMethods,
+				GenerateRootGetByPath:               *generateRootGetByPath,
+				GenerateProtoBridge:                 *generateProtoBridge,
+				SeparateEnumFile:                    *enumOutputFile != "",
+				IncludeConstraintComments:           *constraintComments,
+				MarkDeprecated:                      *markDeprecated,
+				ValueEntryLists:                     *valueEntryLists,
+				GenerateDefaultConstants:            *defaultConstants,
+				GenerateLeafrefValidation:           *leafrefValidation,
+				TypedLeafrefs:                       *typedLeafrefs,
+				CustomTypeMap:                       customTypeMap,
+				BinaryTypeName:                      *binaryTypeName,
+				BinaryTypeImport:                    *binaryTypeImport,
 			},
 		})
 
@@ -386,16 +419,28 @@ func main() {
 			}
 
 			writeGoCodeSingleFile(outfh, generatedGoCode)
+
+			if *enumOutputFile != "" {
+				enumfh := genutil.OpenFile(*enumOutputFile)
+				defer genutil.SyncFile(enumfh)
+				if _, err := io.WriteString(enumfh, generatedGoCode.EnumFile); err != nil {
+					log.Exitf("Error while writing enum file: %v", err)
+				}
+			}
 		case generateGoStructsMultipleFiles:
 			// Write the Go code to a series of output files.
-			out, err := splitCodeByFileN(generatedGoCode, *structsFileN)
-			if err != nil {
-				log.Exitf("ERROR writing split GoStruct Code: %v\n", err)
-			}
-			if err := writeFiles(*outputDir, out); err != nil {
+			if err := generatedGoCode.WriteGoCodeFiles(*outputDir, *structsFileN); err != nil {
 				log.Exitf("Error while writing schema struct files: %v", err)
 			}
 		}
+
+		if *openAPISchemaOutputFile != "" {
+			openAPIfh := genutil.OpenFile(*openAPISchemaOutputFile)
+			defer genutil.SyncFile(openAPIfh)
+			if _, err := openAPIfh.Write(generatedGoCode.OpenAPISchema); err != nil {
+				log.Exitf("Error while writing OpenAPI schema file: %v", err)
+			}
+		}
 	}
 
 	// Generate PathStructs.
@@ -15,13 +15,11 @@
 package main
 
 import (
-	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kylelemons/godebug/pretty"
-	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/ygot/ygen"
 	"github.com/openconfig/ygot/ypathgen"
 )
@@ -76,6 +74,22 @@ foo
 		},
 		wantCode: `
 map
+`,
+	}, {
+		name: "path type map",
+		inGoCode: &ygen.GeneratedGoCode{
+			PathTypeMap: "pathmap",
+		},
+		wantCode: `
+pathmap
+`,
+	}, {
+		name: "interface checks",
+		inGoCode: &ygen.GeneratedGoCode{
+			InterfaceChecks: "checks",
+		},
+		wantCode: `
+checks
 `,
 	}}
 
@@ -92,263 +106,6 @@ map
 	}
 }
 
-func TestSplitCodeByFileN(t *testing.T) {
-	tests := []struct {
-		name             string
-		in               *ygen.GeneratedGoCode
-		inFileN          int
-		want             map[string]string
-		wantErrSubstring string
-	}{{
-		name: "simple struct with all only structs populated",
-		in: &ygen.GeneratedGoCode{
-			CommonHeader: "common_header\n",
-			OneOffHeader: "oneoff_header\n",
-			Structs: []ygen.GoStructCodeSnippet{{
-				StructName: "name",
-				StructDef:  "def\n",
-				ListKeys:   "name_key",
-				Methods:    "methods",
-				Interfaces: "interfaces",
-			}},
-		},
-		inFileN: 1,
-		want: map[string]string{
-			enumMapFn:                      "common_header\n",
-			enumFn:                         "common_header\n",
-			schemaFn:                       "common_header\n",
-			interfaceFn:                    "common_header\ninterfaces\n",
-			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ndef\nname_key\nmethods\n",
-		},
-	}, {
-		name: "less than 1 file requested for splitting",
-		in: &ygen.GeneratedGoCode{
-			CommonHeader: "common_header\n",
-			OneOffHeader: "oneoff_header\n",
-			Structs: []ygen.GoStructCodeSnippet{{
-				StructName: "name",
-				StructDef:  "def\n",
-				ListKeys:   "name_key",
-				Methods:    "methods",
-				Interfaces: "interfaces",
-			}},
-		},
-		inFileN:          0,
-		wantErrSubstring: "requested 0 files",
-	}, {
-		name: "more than # of structs files requested for splitting",
-		in: &ygen.GeneratedGoCode{
-			CommonHeader: "common_header\n",
-			OneOffHeader: "oneoff_header\n",
-			Structs: []ygen.GoStructCodeSnippet{{
-				StructName: "name",
-				StructDef:  "def\n",
-				ListKeys:   "name_key",
-				Methods:    "methods",
-				Interfaces: "interfaces",
-			}},
-		},
-		inFileN:          2,
-		wantErrSubstring: "requested 2 files",
-	}, {
-		name: "two structs with enums populated",
-		in: &ygen.GeneratedGoCode{
-			CommonHeader: "common_header\n",
-			OneOffHeader: "oneoff_header\n",
-			Structs: []ygen.GoStructCodeSnippet{{
-				StructName: "s1",
-				StructDef:  "s1def\n",
-				ListKeys:   "s1key",
-				Methods:    "s1methods",
-				Interfaces: "s1interfaces",
-			}, {
-				StructName: "s2",
-				StructDef:  "s2def\n",
-				ListKeys:   "s2key",
-				Methods:    "s2methods",
-				Interfaces: "s2interfaces",
-			}},
-			Enums:   []string{"enum1", "enum2"},
-			EnumMap: "enummap",
-		},
-		inFileN: 1,
-		want: map[string]string{
-			enumMapFn:                      "common_header\nenummap\n",
-			enumFn:                         "common_header\nenum1\nenum2",
-			schemaFn:                       "common_header\n",
-			interfaceFn:                    "common_header\ns1interfaces\ns2interfaces\n",
-			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ns1def\ns1key\ns1methods\ns2def\ns2key\ns2methods\n",
-		},
-	}, {
-		name: "two structs, separated into two files",
-		in: &ygen.GeneratedGoCode{
-			CommonHeader: "common_header\n",
-			OneOffHeader: "oneoff_header\n",
-			Structs: []ygen.GoStructCodeSnippet{{
-				StructName: "s1",
-				StructDef:  "s1def\n",
-				ListKeys:   "s1key",
-				Methods:    "s1methods",
-				Interfaces: "s1interfaces",
-			}, {
-				StructName: "q2",
-				StructDef:  "q2def\n",
-				ListKeys:   "q2key",
-				Methods:    "q2methods",
-				Interfaces: "q2interfaces",
-			}},
-			JSONSchemaCode: "schema",
-		},
-		inFileN: 2,
-		want: map[string]string{
-			enumMapFn:                      "common_header\n",
-			enumFn:                         "common_header\n",
-			schemaFn:                       "common_header\nschema",
-			interfaceFn:                    "common_header\ns1interfaces\nq2interfaces\n",
-			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ns1def\ns1key\ns1methods\n",
-			fmt.Sprintf(structsFileFmt, 1): "common_header\nq2def\nq2key\nq2methods\n",
-		},
-	}, {
-		name: "five structs, separated into four files",
-		in: &ygen.GeneratedGoCode{
-			CommonHeader: "common_header\n",
-			OneOffHeader: "oneoff_header\n",
-			Structs: []ygen.GoStructCodeSnippet{{
-				StructName: "s1",
-				StructDef:  "s1def\n",
-				ListKeys:   "s1key",
-				Methods:    "s1methods",
-				Interfaces: "s1interfaces",
-			}, {
-				StructName: "s2",
-				StructDef:  "s2def\n",
-				ListKeys:   "s2key",
-				Methods:    "s2methods",
-				Interfaces: "s2interfaces",
-			}, {
-				StructName: "s3",
-				StructDef:  "s3def\n",
-				ListKeys:   "s3key",
-			}, {
-				StructName: "s4",
-				StructDef:  "s4def\n",
-				ListKeys:   "s4key",
-			}, {
-				StructName: "s5",
-				StructDef:  "s5def\n",
-				ListKeys:   "s5key",
-			}},
-			JSONSchemaCode: "schema",
-		},
-		inFileN: 4,
-		want: map[string]string{
-			enumMapFn:                      "common_header\n",
-			enumFn:                         "common_header\n",
-			schemaFn:                       "common_header\nschema",
-			interfaceFn:                    "common_header\ns1interfaces\ns2interfaces\n",
-			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ns1def\ns1key\ns1methods\ns2def\ns2key\ns2methods\n",
-			fmt.Sprintf(structsFileFmt, 1): "common_header\ns3def\ns3key\ns4def\ns4key\n",
-			fmt.Sprintf(structsFileFmt, 2): "common_header\ns5def\ns5key\n",
-			fmt.Sprintf(structsFileFmt, 3): "common_header\n",
-		},
-	}, {
-		name: "five structs, separated into three files",
-		in: &ygen.GeneratedGoCode{
-			CommonHeader: "common_header\n",
-			OneOffHeader: "oneoff_header\n",
-			Structs: []ygen.GoStructCodeSnippet{{
-				StructName: "s1",
-				StructDef:  "s1def\n",
-				ListKeys:   "s1key",
-				Methods:    "s1methods",
-				Interfaces: "s1interfaces",
-			}, {
-				StructName: "s2",
-				StructDef:  "s2def\n",
-				ListKeys:   "s2key",
-				Methods:    "s2methods",
-				Interfaces: "s2interfaces",
-			}, {
-				StructName: "s3",
-				StructDef:  "s3def\n",
-				ListKeys:   "s3key",
-			}, {
-				StructName: "s4",
-				StructDef:  "s4def\n",
-				ListKeys:   "s4key",
-			}, {
-				StructName: "s5",
-				StructDef:  "s5def\n",
-				ListKeys:   "s5key",
-			}},
-			JSONSchemaCode: "schema",
-		},
-		inFileN: 3,
-		want: map[string]string{
-			enumMapFn:                      "common_header\n",
-			enumFn:                         "common_header\n",
-			schemaFn:                       "common_header\nschema",
-			interfaceFn:                    "common_header\ns1interfaces\ns2interfaces\n",
-			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ns1def\ns1key\ns1methods\ns2def\ns2key\ns2methods\n",
-			fmt.Sprintf(structsFileFmt, 1): "common_header\ns3def\ns3key\ns4def\ns4key\n",
-			fmt.Sprintf(structsFileFmt, 2): "common_header\ns5def\ns5key\n",
-		},
-	}, {
-		name: "five structs, separated into two files",
-		in: &ygen.GeneratedGoCode{
-			CommonHeader: "common_header\n",
-			OneOffHeader: "oneoff_header\n",
-			Structs: []ygen.GoStructCodeSnippet{{
-				StructName: "s1",
-				StructDef:  "s1def\n",
-				ListKeys:   "s1key",
-				Methods:    "s1methods",
-				Interfaces: "s1interfaces",
-			}, {
-				StructName: "s2",
-				StructDef:  "s2def\n",
-				ListKeys:   "s2key",
-				Methods:    "s2methods",
-				Interfaces: "s2interfaces",
-			}, {
-				StructName: "s3",
-				StructDef:  "s3def\n",
-				ListKeys:   "s3key",
-			}, {
-				StructName: "s4",
-				StructDef:  "s4def\n",
-				ListKeys:   "s4key",
-			}, {
-				StructName: "s5",
-				StructDef:  "s5def\n",
-				ListKeys:   "s5key",
-			}},
-			JSONSchemaCode: "schema",
-		},
-		inFileN: 2,
-		want: map[string]string{
-			enumMapFn:                      "common_header\n",
-			enumFn:                         "common_header\n",
-			schemaFn:                       "common_header\nschema",
-			interfaceFn:                    "common_header\ns1interfaces\ns2interfaces\n",
-			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ns1def\ns1key\ns1methods\ns2def\ns2key\ns2methods\ns3def\ns3key\n",
-			fmt.Sprintf(structsFileFmt, 1): "common_header\ns4def\ns4key\ns5def\ns5key\n",
-		},
-	}}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := splitCodeByFileN(tt.in, tt.inFileN)
-			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
-				t.Fatalf("did not get expected error, %v", diff)
-			}
-			if diff := pretty.Compare(got, tt.want); diff != "" {
-				t.Errorf("splitCodeByFileN(%v): did not get expected output, diff (-got,+want):\n%s", tt.in, diff)
-			}
-		})
-	}
-}
-
 func TestWritePathCode(t *testing.T) {
 	tests := []struct {
 		name string
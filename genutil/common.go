@@ -37,6 +37,9 @@ const (
 	// GoDefaultGoyangImportPath is the default path for the goyang/pkg/yang library that
 	// is used in the generated code.
 	GoDefaultGoyangImportPath = "github.com/openconfig/goyang/pkg/yang"
+	// GoDefaultProtomapImportPath is the default import path used for the protomap
+	// library in the generated code.
+	GoDefaultProtomapImportPath = "github.com/openconfig/ygot/protomap"
 	// GoDefaultGNMIImportPath is the default import path that is used for the gNMI generated
 	// Go protobuf code in the generated output.
 	GoDefaultGNMIImportPath = "github.com/openconfig/gnmi/proto/gnmi"
@@ -126,8 +129,42 @@ const (
 	// (i.e. config false), including their children, from the generated
 	// code output.
 	ExcludeDerivedState
+	// PreferIntendedConfigKeepState compresses the "config" and "state"
+	// containers of a YANG model in the same way as PreferIntendedConfig,
+	// but where a field exists under both containers, the "state" version
+	// of the field is retained as a distinct field rather than being
+	// dropped. This allows the intended and applied values of a field to
+	// be tracked separately within the same generated struct.
+	PreferIntendedConfigKeepState
+	// PreferIntendedConfigKeepListContainers compresses the "config" and
+	// "state" containers of a YANG model in the same way as
+	// PreferIntendedConfig, but retains the containers that surround lists,
+	// such that the generated code follows the container hierarchy of the
+	// YANG model other than the "config" and "state" containers.
+	PreferIntendedConfigKeepListContainers
 )
 
+// keptStateKeyPrefix is prepended to the name of a field that exists under
+// both the "config" and "state" containers of its parent to form the key of
+// the "state" version of the field when PreferIntendedConfigKeepState is
+// used. Since "/" is not valid within a YANG identifier, the key cannot
+// clash with the name of any other field.
+const keptStateKeyPrefix = "state/"
+
+// KeptStateFieldKey returns the key used within the set of children returned
+// by FindAllChildren for the "state" version of the field with the supplied
+// name when PreferIntendedConfigKeepState is used.
+func KeptStateFieldKey(name string) string {
+	return keptStateKeyPrefix + name
+}
+
+// IsKeptStateFieldKey returns true if the supplied key of the set of
+// children returned by FindAllChildren corresponds to the "state" version of
+// a field retained by PreferIntendedConfigKeepState.
+func IsKeptStateFieldKey(key string) bool {
+	return strings.HasPrefix(key, keptStateKeyPrefix)
+}
+
 // CompressEnabled is a helper to query whether compression is on.
 func (c CompressBehaviour) String() string {
 	switch c {
@@ -141,6 +178,10 @@ func (c CompressBehaviour) String() string {
 		return "PreferOperationalState"
 	case ExcludeDerivedState:
 		return "ExcludeDerivedState"
+	case PreferIntendedConfigKeepState:
+		return "PreferIntendedConfigKeepState"
+	case PreferIntendedConfigKeepListContainers:
+		return "PreferIntendedConfigKeepListContainers"
 	}
 	return fmt.Sprintf("%d", c)
 }
@@ -154,6 +195,12 @@ func (c CompressBehaviour) CompressEnabled() bool {
 	return true
 }
 
+// ListContainersCompressed is a helper to query whether the containers that
+// surround lists are removed by compression.
+func (c CompressBehaviour) ListContainersCompressed() bool {
+	return c.CompressEnabled() && c != PreferIntendedConfigKeepListContainers
+}
+
 // StateExcluded is a helper to query whether derived state is excluded.
 func (c CompressBehaviour) StateExcluded() bool {
 	switch c {
@@ -248,7 +295,8 @@ func TranslateToCompressBehaviour(compressPaths, excludeState, preferOperational
 //     it. This is due to implementation requirements when it is supported on vendor devices.
 //     However, to a developer this looks like stuttering, and hence we remove this - by checking
 //     that for each directory that would be a child of e, if it has only one child, which is
-//     a list, then we skip over it.
+//     a list, then we skip over it. This rule is not implemented when the
+//     PreferIntendedConfigKeepListContainers compress behaviour is used.
 //
 // Implementing these two rules means that the schema is simplified, such that the tree described
 // becomes:
@@ -295,7 +343,7 @@ func FindAllChildren(e *yang.Entry, compBehaviour CompressBehaviour) (map[string
 		// nodes, so return simply the first level direct children (other than choice or case).
 		directChildren, errs := findAllChildrenWithoutCompression(e, compBehaviour.StateExcluded())
 		return directChildren, nil, errs
-	case PreferIntendedConfig, ExcludeDerivedState:
+	case PreferIntendedConfig, ExcludeDerivedState, PreferIntendedConfigKeepState, PreferIntendedConfigKeepListContainers:
 		prioData, deprioData = "config", "state"
 	case PreferOperationalState:
 		prioData, deprioData = "state", "config"
@@ -371,6 +419,13 @@ func FindAllChildren(e *yang.Entry, compBehaviour CompressBehaviour) (map[string
 					for _, n := range ch {
 						childrenList := directChildren
 						// If the name is duplicate to one that's already in the
+						// prioritized container, and both versions are being kept,
+						// then the entry is added as a distinct child.
+						if prioNames[n.Name] && compBehaviour == PreferIntendedConfigKeepState {
+							errs = addNewChild(directChildren, KeptStateFieldKey(n.Name), n, errs)
+							continue
+						}
+						// If the name is duplicate to one that's already in the
 						// prioritized container, we must put the entry in the shadow list.
 						if prioNames[n.Name] {
 							childrenList = shadowChildren
@@ -418,8 +473,9 @@ func FindAllChildren(e *yang.Entry, compBehaviour CompressBehaviour) (map[string
 			eGrandChildren := util.Children(e.Dir[currChild])
 			switch {
 			// Implement rule 2 - remove surrounding containers for lists and consider
-			// the list under the surrounding container a direct child.
-			case len(eGrandChildren) == 1 && eGrandChildren[0].IsList():
+			// the list under the surrounding container a direct child, unless
+			// the surrounding containers are being retained.
+			case compBehaviour.ListContainersCompressed() && len(eGrandChildren) == 1 && eGrandChildren[0].IsList():
 				if !util.IsConfig(eGrandChildren[0]) && compBehaviour.StateExcluded() {
 					// If the list child is read-only, then it is not a valid child.
 					continue
@@ -419,11 +419,19 @@ func TestFindChildren(t *testing.T) {
 					Type:   &yang.YangType{},
 				},
 			},
+			PreferIntendedConfigKeepListContainers: []yang.Entry{
+				{
+					Name:   "plural",
+					Config: yang.TSTrue,
+					Type:   &yang.YangType{},
+				},
+			},
 		},
 		wantShadow: map[CompressBehaviour][]yang.Entry{
-			PreferIntendedConfig:   nil,
-			PreferOperationalState: nil,
-			Uncompressed:           nil,
+			PreferIntendedConfig:                   nil,
+			PreferOperationalState:                 nil,
+			Uncompressed:                           nil,
+			PreferIntendedConfigKeepListContainers: nil,
 		}}, {
 		name: "duplicate-elements-in-config",
 		inElement: &yang.Entry{
@@ -675,6 +683,7 @@ func TestFindChildren(t *testing.T) {
 			PreferIntendedConfig,
 			PreferOperationalState,
 			ExcludeDerivedState,
+			PreferIntendedConfigKeepListContainers,
 		} {
 			// If this isn't a test case that has anything to test, we skip it.
 			wantErr, ok := tt.wantErr[c]
@@ -747,6 +756,78 @@ func TestFindChildren(t *testing.T) {
 	}
 }
 
+func TestFindChildrenKeepState(t *testing.T) {
+	in := &yang.Entry{
+		Name:     "interface",
+		ListAttr: &yang.ListAttr{},
+		Dir: map[string]*yang.Entry{
+			"config": {
+				Name:   "config",
+				Type:   &yang.YangType{},
+				Config: yang.TSTrue,
+				Dir: map[string]*yang.Entry{
+					"mtu": {
+						Name:   "mtu",
+						Config: yang.TSTrue,
+						Type:   &yang.YangType{Kind: yang.Yuint16},
+					},
+				},
+			},
+			"state": {
+				Name:   "state",
+				Type:   &yang.YangType{},
+				Config: yang.TSFalse,
+				Dir: map[string]*yang.Entry{
+					"mtu": {
+						Name:   "mtu",
+						Config: yang.TSFalse,
+						Type:   &yang.YangType{Kind: yang.Yuint16},
+					},
+					"oper-status": {
+						Name:   "oper-status",
+						Config: yang.TSFalse,
+						Type:   &yang.YangType{Kind: yang.Ystring},
+					},
+				},
+			},
+		},
+	}
+
+	elems, shadowElems, errs := FindAllChildren(in, PreferIntendedConfigKeepState)
+	if errs != nil {
+		t.Fatalf("FindAllChildren(%s, PreferIntendedConfigKeepState): got unexpected errors: %v", in.Name, errs)
+	}
+	if len(shadowElems) != 0 {
+		t.Errorf("FindAllChildren(%s, PreferIntendedConfigKeepState): got unexpected shadow children: %v", in.Name, shadowElems)
+	}
+
+	wantConfig := map[string]yang.TriState{
+		"mtu":                    yang.TSTrue,
+		KeptStateFieldKey("mtu"): yang.TSFalse,
+		"oper-status":            yang.TSFalse,
+	}
+	if len(elems) != len(wantConfig) {
+		t.Errorf("FindAllChildren(%s, PreferIntendedConfigKeepState): did not get expected number of children, got: %v, want: %v", in.Name, elems, wantConfig)
+	}
+	for k, want := range wantConfig {
+		e, ok := elems[k]
+		if !ok {
+			t.Errorf("FindAllChildren(%s, PreferIntendedConfigKeepState): could not find expected child %s", in.Name, k)
+			continue
+		}
+		if e.Config != want {
+			t.Errorf("FindAllChildren(%s, PreferIntendedConfigKeepState): child %s had wrong config status, got: %v, want: %v", in.Name, k, e.Config, want)
+		}
+	}
+
+	if !IsKeptStateFieldKey(KeptStateFieldKey("mtu")) {
+		t.Errorf("IsKeptStateFieldKey(%q): got false, want true", KeptStateFieldKey("mtu"))
+	}
+	if IsKeptStateFieldKey("mtu") {
+		t.Errorf("IsKeptStateFieldKey(%q): got true, want false", "mtu")
+	}
+}
+
 func TestTransformEntry(t *testing.T) {
 	inSchemaTemplate := &yang.Entry{
 		Name:     "interface",
@@ -0,0 +1,119 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cbor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/cbor/cborschema"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// stubCodec is a ygot.CBORCodec that stores its data items as JSON, but
+// decodes them to the types that a CBOR library would return - maps keyed by
+// interface{}, and integers decoded as uint64 or int64.
+type stubCodec struct{}
+
+func (stubCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+func (stubCodec) Unmarshal(data []byte, v interface{}) error {
+	p, ok := v.(*interface{})
+	if !ok {
+		return fmt.Errorf("cannot unmarshal into %T", v)
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var j interface{}
+	if err := d.Decode(&j); err != nil {
+		return err
+	}
+	*p = cborTypes(j)
+	return nil
+}
+
+// cborTypes converts the value v decoded by a JSON decoder to the types that
+// a CBOR library would use for the same value.
+func cborTypes(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := map[interface{}]interface{}{}
+		for k, e := range v {
+			m[k] = cborTypes(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = cborTypes(e)
+		}
+		return v
+	case json.Number:
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return u
+		}
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return v
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *cborschema.Device
+	}{{
+		desc: "empty device",
+		in:   &cborschema.Device{},
+	}, {
+		desc: "populated device",
+		in: &cborschema.Device{
+			Parent: &cborschema.Parent{
+				Child: &cborschema.Parent_Child{
+					One:   ygot.String("one"),
+					Two:   ygot.String("two"),
+					Three: cborschema.Child_Three_ONE,
+					Four:  cborschema.Binary{0x42, 0x43},
+				},
+			},
+			RemoteContainer: &cborschema.RemoteContainer{
+				ALeaf: ygot.String("a-leaf"),
+			},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			data, err := tt.in.ΛMarshalCBOR(stubCodec{})
+			if err != nil {
+				t.Fatalf("ΛMarshalCBOR: got unexpected error: %v", err)
+			}
+
+			got := &cborschema.Device{}
+			if err := got.ΛUnmarshalCBOR(stubCodec{}, data); err != nil {
+				t.Fatalf("ΛUnmarshalCBOR: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.in, got); diff != "" {
+				t.Errorf("ΛUnmarshalCBOR: did not get expected struct after round trip, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
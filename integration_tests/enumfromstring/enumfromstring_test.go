@@ -0,0 +1,97 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enumfromstring
+
+import (
+	"testing"
+
+	"github.com/openconfig/ygot/integration_tests/enumfromstring/enumschema"
+)
+
+func TestIdentityFromString(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   enumschema.E_EnumTypes_ID
+		wantOK bool
+	}{{
+		in:     "FORTY_TWO",
+		want:   enumschema.EnumTypes_ID_FORTY_TWO,
+		wantOK: true,
+	}, {
+		in:     "enum-module:FORTY_TWO",
+		want:   enumschema.EnumTypes_ID_FORTY_TWO,
+		wantOK: true,
+	}, {
+		in:     "SO_LONG_AND_THANKS_FOR_ALL_THE_FISH",
+		want:   enumschema.EnumTypes_ID_SO_LONG_AND_THANKS_FOR_ALL_THE_FISH,
+		wantOK: true,
+	}, {
+		in:     "enum-module:SO_LONG_AND_THANKS_FOR_ALL_THE_FISH",
+		want:   enumschema.EnumTypes_ID_SO_LONG_AND_THANKS_FOR_ALL_THE_FISH,
+		wantOK: true,
+	}, {
+		in:   "enum-types:FORTY_TWO",
+		want: enumschema.EnumTypes_ID_UNSET,
+	}, {
+		in:   "forty_two",
+		want: enumschema.EnumTypes_ID_UNSET,
+	}, {
+		in:   "UNSET",
+		want: enumschema.EnumTypes_ID_UNSET,
+	}}
+
+	for _, tt := range tests {
+		got, ok := enumschema.E_EnumTypes_IDFromString(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("E_EnumTypes_IDFromString(%q): got (%v, %v), want (%v, %v)", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestEnumerationFromString(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   enumschema.E_EnumTypes_TdEnum
+		wantOK bool
+	}{{
+		in:     "ALPHA",
+		want:   enumschema.EnumTypes_TdEnum_ALPHA,
+		wantOK: true,
+	}, {
+		in:     "CHARLIE",
+		want:   enumschema.EnumTypes_TdEnum_CHARLIE,
+		wantOK: true,
+	}, {
+		// Values of enumerations are not defined by a module, so module
+		// prefixed names are not valid.
+		in:   "enum-types:ALPHA",
+		want: enumschema.EnumTypes_TdEnum_UNSET,
+	}, {
+		in:   "DELTA",
+		want: enumschema.EnumTypes_TdEnum_UNSET,
+	}}
+
+	for _, tt := range tests {
+		got, ok := enumschema.E_EnumTypes_TdEnumFromString(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("E_EnumTypes_TdEnumFromString(%q): got (%v, %v), want (%v, %v)", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	got, ok := enumschema.E_Child_InlineEnumFromString("GUANINE")
+	if got != enumschema.Child_InlineEnum_GUANINE || !ok {
+		t.Errorf("E_Child_InlineEnumFromString(%q): got (%v, %v), want (%v, true)", "GUANINE", got, ok, enumschema.Child_InlineEnum_GUANINE)
+	}
+}
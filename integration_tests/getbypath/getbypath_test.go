@@ -0,0 +1,93 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package getbypath
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/getbypath/gbpschema"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+func TestGetByPath(t *testing.T) {
+	d := &gbpschema.Device{
+		Parent: &gbpschema.Parent{
+			Child: &gbpschema.Parent_Child{
+				One:   ygot.String("hello"),
+				Three: gbpschema.Child_Three_ONE,
+			},
+		},
+	}
+
+	tests := []struct {
+		desc             string
+		inPath           string
+		want             interface{}
+		wantErrSubstring string
+	}{{
+		desc:   "string leaf",
+		inPath: "/parent/child/config/one",
+		want:   ygot.String("hello"),
+	}, {
+		desc:   "enumerated leaf",
+		inPath: "/parent/child/config/three",
+		want:   gbpschema.Child_Three_ONE,
+	}, {
+		desc:   "container",
+		inPath: "/parent/child",
+		want:   d.Parent.Child,
+	}, {
+		desc:             "unset leaf",
+		inPath:           "/parent/child/state/two",
+		wantErrSubstring: "no value found",
+	}, {
+		desc:             "unset container",
+		inPath:           "/remote-container",
+		wantErrSubstring: "no value found",
+	}, {
+		desc:             "leaf within unset container",
+		inPath:           "/remote-container/config/a-leaf",
+		wantErrSubstring: "could not find children",
+	}, {
+		desc:             "path not in schema",
+		inPath:           "/parent/sibling",
+		wantErrSubstring: "no match found",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := d.GetByPath(mustPath(t, tt.inPath))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GetByPath(%s): did not get expected error, %s", tt.inPath, diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetByPath(%s): did not get expected value, diff(-want, +got):\n%s", tt.inPath, diff)
+			}
+		})
+	}
+}
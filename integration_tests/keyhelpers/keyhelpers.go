@@ -0,0 +1,19 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keyhelpers is an integration test for ygot that tests the helper
+// methods that are generated for the key structs of multi-keyed lists.
+package keyhelpers
+
+//go:generate sh -c "go run ../../generator/generator.go -path=../../testdata/modules -output_file=mkschema/structs.go -package_name=mkschema -compress_paths -generate_fakeroot -generate_simple_unions -generate_key_struct_helpers ../../testdata/modules/openconfig-multikey-list-name-conflict.yang"
@@ -0,0 +1,62 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyhelpers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/keyhelpers/mkschema"
+)
+
+func TestKeyStructHelpers(t *testing.T) {
+	m := &mkschema.Model{}
+	if _, err := m.NewMultiKey(42, 84); err != nil {
+		t.Fatalf("NewMultiKey: got unexpected error: %v", err)
+	}
+	if _, err := m.NewMultiKey(1, 18446744073709551615); err != nil {
+		t.Fatalf("NewMultiKey: got unexpected error: %v", err)
+	}
+
+	gotStrings := map[string]bool{}
+	for k := range m.MultiKey {
+		gotStrings[k.String()] = true
+
+		wantFields := map[string]interface{}{
+			"key1": k.Key1,
+			"key2": k.Key2,
+		}
+		if diff := cmp.Diff(wantFields, k.Fields()); diff != "" {
+			t.Errorf("%s.Fields(): did not get expected fields, (-want, +got):\n%s", k, diff)
+		}
+	}
+
+	wantStrings := map[string]bool{
+		"[key1=42][key2=84]":                  true,
+		"[key1=1][key2=18446744073709551615]": true,
+	}
+	if diff := cmp.Diff(wantStrings, gotStrings); diff != "" {
+		t.Errorf("String(): did not get expected key strings, (-want, +got):\n%s", diff)
+	}
+
+	k := mkschema.Model_MultiKey_YANGListKey{Key1: 42, Key2: 84}
+	wantFields := map[string]interface{}{
+		"key1": uint32(42),
+		"key2": uint64(84),
+	}
+	if diff := cmp.Diff(wantFields, k.Fields()); diff != "" {
+		t.Errorf("Fields(): did not get expected fields with concrete types, (-want, +got):\n%s", diff)
+	}
+}
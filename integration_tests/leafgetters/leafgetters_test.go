@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leafgetters
+
+import (
+	"testing"
+
+	"github.com/openconfig/ygot/integration_tests/leafgetters/lgschema"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	// The results of the getters are stored in package-level variables
+	// such that the compiler cannot eliminate the calls being measured.
+	sinkString string
+	sinkEnum   lgschema.E_Child_Three
+	sinkBinary lgschema.Binary
+)
+
+// populatedDevice returns a fake root whose openconfig-simple leaves are
+// populated.
+func populatedDevice() *lgschema.Device {
+	return &lgschema.Device{
+		Parent: &lgschema.Parent{
+			Child: &lgschema.Parent_Child{
+				One:   ygot.String("hello"),
+				Three: lgschema.Child_Three_ONE,
+				Four:  lgschema.Binary{0x42},
+			},
+		},
+	}
+}
+
+// getLeaves retrieves each leaf of the openconfig-simple child container
+// of d via the generated getters.
+func getLeaves(d *lgschema.Device) {
+	c := d.GetParent().GetChild()
+	sinkString = c.GetOne()
+	sinkEnum = c.GetThree()
+	sinkBinary = c.GetFour()
+}
+
+func TestLeafGettersDoNotAllocate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *lgschema.Device
+	}{{
+		name: "populated leaves",
+		in:   populatedDevice(),
+	}, {
+		name: "unpopulated leaves",
+		in:   &lgschema.Device{Parent: &lgschema.Parent{Child: &lgschema.Parent_Child{}}},
+	}, {
+		name: "nil containers",
+		in:   &lgschema.Device{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testing.AllocsPerRun(100, func() { getLeaves(tt.in) }); got != 0 {
+				t.Errorf("getLeaves(%v): got %v allocations per run, want 0", tt.in, got)
+			}
+		})
+	}
+}
+
+func BenchmarkLeafGetters(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		in   *lgschema.Device
+	}{{
+		name: "populated leaves",
+		in:   populatedDevice(),
+	}, {
+		name: "nil containers",
+		in:   &lgschema.Device{},
+	}}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				getLeaves(bm.in)
+			}
+		})
+	}
+}
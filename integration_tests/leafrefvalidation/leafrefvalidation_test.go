@@ -0,0 +1,113 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leafrefvalidation
+
+import (
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/leafrefvalidation/lrvschema"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+)
+
+func mustDevice(t *testing.T, interfaces []string, vlans []uint16) *lrvschema.Device {
+	t.Helper()
+	d := &lrvschema.Device{}
+	for _, i := range interfaces {
+		if _, err := d.NewInterface(i); err != nil {
+			t.Fatalf("cannot create interface %s: %v", i, err)
+		}
+	}
+	for _, v := range vlans {
+		if _, err := d.NewVlan(v); err != nil {
+			t.Fatalf("cannot create VLAN %d: %v", v, err)
+		}
+	}
+	return d
+}
+
+func TestValidateLeafrefs(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inDevice         func(*testing.T) *lrvschema.Device
+		inOpts           []ygot.ValidationOption
+		wantErrSubstring string
+	}{{
+		desc: "empty device",
+		inDevice: func(t *testing.T) *lrvschema.Device {
+			return &lrvschema.Device{}
+		},
+	}, {
+		desc: "all leafrefs resolve",
+		inDevice: func(t *testing.T) *lrvschema.Device {
+			d := mustDevice(t, []string{"eth0", "eth1"}, []uint16{10})
+			d.Vlan[10].MemberInterface = []string{"eth0", "eth1"}
+			d.Routing = &lrvschema.Routing{
+				RouterIdInterface: ygot.String("eth1"),
+				DefaultVlan:       ygot.Uint16(10),
+			}
+			return d
+		},
+	}, {
+		desc: "missing leaf-list member",
+		inDevice: func(t *testing.T) *lrvschema.Device {
+			d := mustDevice(t, []string{"eth0"}, []uint16{10})
+			d.Vlan[10].MemberInterface = []string{"eth0", "eth2"}
+			return d
+		},
+		wantErrSubstring: "leafref /vlans/vlan/config/member-interface value eth2 does not exist at /interfaces/interface/config/name",
+	}, {
+		desc: "missing string leaf",
+		inDevice: func(t *testing.T) *lrvschema.Device {
+			d := mustDevice(t, []string{"eth0"}, nil)
+			d.Routing = &lrvschema.Routing{
+				RouterIdInterface: ygot.String("lo0"),
+			}
+			return d
+		},
+		wantErrSubstring: "leafref /routing/config/router-id-interface value lo0 does not exist at /interfaces/interface/config/name",
+	}, {
+		desc: "missing integer leaf",
+		inDevice: func(t *testing.T) *lrvschema.Device {
+			d := mustDevice(t, nil, []uint16{10})
+			d.Routing = &lrvschema.Routing{
+				DefaultVlan: ygot.Uint16(20),
+			}
+			return d
+		},
+		wantErrSubstring: "leafref /routing/config/default-vlan value 20 does not exist at /vlans/vlan/config/id",
+	}, {
+		desc: "missing data ignored",
+		inDevice: func(t *testing.T) *lrvschema.Device {
+			d := mustDevice(t, nil, []uint16{10})
+			d.Vlan[10].MemberInterface = []string{"eth0"}
+			d.Routing = &lrvschema.Routing{
+				DefaultVlan: ygot.Uint16(20),
+			}
+			return d
+		},
+		inOpts: []ygot.ValidationOption{&ytypes.LeafrefOptions{IgnoreMissingData: true}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.inDevice(t).Validate(tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package orderedlists is an integration test for ygot that tests the
+// handling of the generated types that represent "ordered-by user" lists by
+// the ygot and ytypes libraries.
+package orderedlists
+
+//go:generate sh -c "go run ../../generator/generator.go -path=../../testdata/modules -output_file=olschema/structs.go -package_name=olschema -generate_fakeroot -generate_ordered_lists ../../testdata/modules/openconfig-ordered-list.yang"
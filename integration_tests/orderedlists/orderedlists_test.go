@@ -0,0 +1,215 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orderedlists
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/orderedlists/olschema"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// newDevice returns a device whose single-key list contains members with the
+// supplied keys, appended in order, and whose multi-key list contains two
+// members.
+func newDevice(t *testing.T, keys ...string) *olschema.Device {
+	t.Helper()
+	a := &olschema.OpenconfigOrderedList_Model_A{}
+	for _, k := range keys {
+		m, err := a.NewSingleKey(k)
+		if err != nil {
+			t.Fatalf("NewSingleKey(%s): got unexpected error: %v", k, err)
+		}
+		m.Config = &olschema.OpenconfigOrderedList_Model_A_SingleKey_Config{
+			Key:   ygot.String(k),
+			Value: ygot.String("value-" + k),
+		}
+	}
+
+	b := &olschema.OpenconfigOrderedList_Model_B{}
+	for _, k := range []olschema.OpenconfigOrderedList_Model_B_MultiKey_Key{{Key1: 2, Key2: "x"}, {Key1: 1, Key2: "y"}} {
+		m, err := b.NewMultiKey(k.Key1, k.Key2)
+		if err != nil {
+			t.Fatalf("NewMultiKey(%v): got unexpected error: %v", k, err)
+		}
+		m.Config = &olschema.OpenconfigOrderedList_Model_B_MultiKey_Config{
+			Key1: ygot.Uint32(k.Key1),
+			Key2: ygot.String(k.Key2),
+		}
+	}
+
+	return &olschema.Device{Model: &olschema.OpenconfigOrderedList_Model{A: a, B: b}}
+}
+
+// singleKeys returns the keys of the single-key list of d, in order.
+func singleKeys(d *olschema.Device) []string {
+	return d.Model.A.SingleKey.Keys()
+}
+
+func TestValidate(t *testing.T) {
+	if err := newDevice(t, "c", "a", "b").Validate(); err != nil {
+		t.Errorf("Validate: got unexpected error: %v", err)
+	}
+
+	// The key leaf of a member is a leafref to the key within its config
+	// container, and hence is checked when validating leafrefs.
+	d := newDevice(t, "c")
+	d.Model.A.SingleKey.Get("c").Config.Key = ygot.String("d")
+	if err := d.Validate(); err == nil {
+		t.Errorf("Validate: did not get expected error for mismatched key leafref")
+	}
+}
+
+func TestEmitJSON(t *testing.T) {
+	d := newDevice(t, "c", "a", "b")
+
+	if _, err := ygot.EmitJSON(d, nil); err != nil {
+		t.Errorf("EmitJSON: got unexpected error with default config: %v", err)
+	}
+
+	js, err := ygot.EmitJSON(d, &ygot.EmitJSONConfig{Format: ygot.RFC7951})
+	if err != nil {
+		t.Fatalf("EmitJSON: got unexpected error with RFC7951 config: %v", err)
+	}
+	var got struct {
+		Model struct {
+			A struct {
+				SingleKey []struct {
+					Key string `json:"key"`
+				} `json:"single-key"`
+			} `json:"a"`
+		} `json:"openconfig-ordered-list:model"`
+	}
+	if err := json.Unmarshal([]byte(js), &got); err != nil {
+		t.Fatalf("json.Unmarshal: got unexpected error: %v", err)
+	}
+	var gotKeys []string
+	for _, m := range got.Model.A.SingleKey {
+		gotKeys = append(gotKeys, m.Key)
+	}
+	if diff := cmp.Diff([]string{"c", "a", "b"}, gotKeys); diff != "" {
+		t.Errorf("EmitJSON: did not get members in order, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	d := newDevice(t, "c", "a", "b")
+
+	c, err := ygot.DeepCopy(d)
+	if err != nil {
+		t.Fatalf("DeepCopy: got unexpected error: %v", err)
+	}
+	got := c.(*olschema.Device)
+	if diff := cmp.Diff(singleKeys(d), singleKeys(got)); diff != "" {
+		t.Errorf("DeepCopy: did not get members in order, diff(-want, +got):\n%s", diff)
+	}
+	if got.Model.A.SingleKey.Get("a") == d.Model.A.SingleKey.Get("a") {
+		t.Errorf("DeepCopy: member of copy is shared with the source")
+	}
+
+	n, err := ygot.Diff(d, got)
+	if err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	if len(n.GetUpdate()) != 0 || len(n.GetDelete()) != 0 {
+		t.Errorf("DeepCopy: copy differs from the source, got diff: %v", n)
+	}
+}
+
+func TestMergeStructs(t *testing.T) {
+	a := newDevice(t, "c", "a")
+	b := newDevice(t, "a", "d")
+	b.Model.A.SingleKey.Get("a").State = &olschema.OpenconfigOrderedList_Model_A_SingleKey_State{
+		Key: ygot.String("a"),
+	}
+
+	m, err := ygot.MergeStructs(a, b)
+	if err != nil {
+		t.Fatalf("MergeStructs: got unexpected error: %v", err)
+	}
+	got := m.(*olschema.Device)
+	if diff := cmp.Diff([]string{"c", "a", "d"}, singleKeys(got)); diff != "" {
+		t.Errorf("MergeStructs: did not get members in order, diff(-want, +got):\n%s", diff)
+	}
+	if s := got.Model.A.SingleKey.Get("a").State; s == nil || s.Key == nil || *s.Key != "a" {
+		t.Errorf("MergeStructs: did not merge existing member, got state: %v", s)
+	}
+	if diff := cmp.Diff([]string{"c", "a"}, singleKeys(a)); diff != "" {
+		t.Errorf("MergeStructs: modified source, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	orig := newDevice(t, "c", "a")
+	mod := newDevice(t, "c", "a")
+	mod.Model.A.SingleKey.Get("a").Config.Value = ygot.String("new")
+
+	got, err := ygot.Diff(orig, mod)
+	if err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	want := &gpb.Notification{
+		Update: []*gpb.Update{{
+			Path: &gpb.Path{Elem: []*gpb.PathElem{
+				{Name: "model"},
+				{Name: "a"},
+				{Name: "single-key", Key: map[string]string{"key": "a"}},
+				{Name: "config"},
+				{Name: "value"},
+			}},
+			Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{"new"}},
+		}},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Diff: did not get expected notification, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	in := newDevice(t, "c", "a", "b")
+	js, err := ygot.EmitJSON(in, &ygot.EmitJSONConfig{Format: ygot.RFC7951})
+	if err != nil {
+		t.Fatalf("EmitJSON: got unexpected error: %v", err)
+	}
+
+	got := &olschema.Device{}
+	if err := olschema.Unmarshal([]byte(js), got); err != nil {
+		t.Fatalf("Unmarshal: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(singleKeys(in), singleKeys(got)); diff != "" {
+		t.Errorf("Unmarshal: did not get members in order, diff(-want, +got):\n%s", diff)
+	}
+	n, err := ygot.Diff(in, got)
+	if err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	if len(n.GetUpdate()) != 0 || len(n.GetDelete()) != 0 {
+		t.Errorf("Unmarshal: unmarshalled device differs from the input, got diff: %v", n)
+	}
+
+	// Members are appended to the list, such that a member whose key is
+	// already present cannot be unmarshalled.
+	err = olschema.Unmarshal([]byte(js), got)
+	if diff := errdiff.Substring(err, "duplicate key"); diff != "" {
+		t.Errorf("Unmarshal: did not get expected error for existing member, %s", diff)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poolreset
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/poolreset/prschema"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// populate fills d with a data tree containing each type of field within the
+// schema, with values derived from i.
+func populate(t testing.TB, d *prschema.Device, i int) {
+	t.Helper()
+	name := fmt.Sprintf("eth%d", i)
+	if _, err := d.NewInterface(name); err != nil {
+		t.Fatalf("cannot create interface %s: %v", name, err)
+	}
+	v, err := d.NewVlan(uint16(i))
+	if err != nil {
+		t.Fatalf("cannot create VLAN %d: %v", i, err)
+	}
+	v.MemberInterface = []string{name}
+	d.Routing = &prschema.Routing{
+		RouterIdInterface: ygot.String(name),
+		DefaultVlan:       ygot.Uint16(uint16(i)),
+	}
+}
+
+func TestReset(t *testing.T) {
+	d := &prschema.Device{}
+	populate(t, d, 1)
+
+	routing := d.Routing
+	vlan := d.Vlan[1]
+	d.ΛReset()
+
+	if diff := cmp.Diff(&prschema.Device{}, d); diff != "" {
+		t.Errorf("ΛReset did not clear the device, (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(&prschema.Routing{}, routing); diff != "" {
+		t.Errorf("ΛReset did not clear the child container, (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(&prschema.Vlan{}, vlan); diff != "" {
+		t.Errorf("ΛReset did not clear the list member, (-want +got):\n%s", diff)
+	}
+
+	var nilDevice *prschema.Device
+	nilDevice.ΛReset()
+}
+
+func TestResetPoolReuse(t *testing.T) {
+	pool := sync.Pool{
+		New: func() interface{} { return &prschema.Device{} },
+	}
+
+	for i := 1; i <= 10; i++ {
+		d := pool.Get().(*prschema.Device)
+		if diff := cmp.Diff(&prschema.Device{}, d); diff != "" {
+			t.Fatalf("iteration %d: struct retrieved from pool was not cleared, (-want +got):\n%s", i, diff)
+		}
+		populate(t, d, i)
+		if err := d.Validate(); err != nil {
+			t.Fatalf("iteration %d: populated struct is not valid, %v", i, err)
+		}
+		d.ΛReset()
+		pool.Put(d)
+	}
+}
+
+func BenchmarkResetPoolReuse(b *testing.B) {
+	pool := sync.Pool{
+		New: func() interface{} { return &prschema.Device{} },
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		d := pool.Get().(*prschema.Device)
+		populate(b, d, i%4096)
+		d.ΛReset()
+		pool.Put(d)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roothelpers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/roothelpers/rhschema"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestEmitAllJSON(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inStructs        []ygot.GoStruct
+		inOpts           *ygot.EmitJSONConfig
+		want             string
+		wantErrSubstring string
+	}{{
+		desc: "RFC7951 with module names",
+		inStructs: []ygot.GoStruct{
+			&rhschema.Interface{Name: ygot.String("eth1")},
+			&rhschema.System{
+				Hostname: ygot.String("dut"),
+				NtpServer: map[uint32]*rhschema.System_NtpServer{
+					1: {Name: ygot.Uint32(1)},
+				},
+			},
+			&rhschema.Interface{Name: ygot.String("eth0")},
+		},
+		inOpts: &ygot.EmitJSONConfig{
+			Format:        ygot.RFC7951,
+			RFC7951Config: &ygot.RFC7951JSONConfig{AppendModuleName: true},
+		},
+		want: `{
+			"openconfig-fakeroot:interfaces": {
+				"interface": [
+					{"name": "eth0", "config": {"name": "eth0"}},
+					{"name": "eth1", "config": {"name": "eth1"}}
+				]
+			},
+			"openconfig-fakeroot:system": {
+				"config": {"hostname": "dut"},
+				"ntp-servers": {
+					"ntp-server": [{"name": 1, "config": {"name": 1}}]
+				}
+			}
+		}`,
+	}, {
+		desc: "internal format",
+		inStructs: []ygot.GoStruct{
+			&rhschema.Interface{Name: ygot.String("eth0")},
+		},
+		want: `{
+			"interfaces": {
+				"interface": {
+					"eth0": {"name": "eth0", "config": {"name": "eth0"}}
+				}
+			}
+		}`,
+	}, {
+		desc:      "no structs",
+		inStructs: nil,
+		want:      `{}`,
+	}, {
+		desc: "duplicate list member",
+		inStructs: []ygot.GoStruct{
+			&rhschema.Interface{Name: ygot.String("eth0")},
+			&rhschema.Interface{Name: ygot.String("eth0")},
+		},
+		wantErrSubstring: `duplicate list member with key "eth0"`,
+	}, {
+		desc: "duplicate container",
+		inStructs: []ygot.GoStruct{
+			&rhschema.System{Hostname: ygot.String("a")},
+			&rhschema.System{Hostname: ygot.String("b")},
+		},
+		wantErrSubstring: "2 instances of container supplied",
+	}, {
+		desc: "struct that is not at the root",
+		inStructs: []ygot.GoStruct{
+			&rhschema.System_NtpServer{Name: ygot.Uint32(1)},
+		},
+		wantErrSubstring: "is not an entity at the root of the schema",
+	}, {
+		desc: "list member without key",
+		inStructs: []ygot.GoStruct{
+			&rhschema.Interface{},
+		},
+		wantErrSubstring: "cannot retrieve keys",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := rhschema.EmitAllJSON(tt.inOpts, tt.inStructs...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("EmitAllJSON: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Fatalf("cannot unmarshal output JSON: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Fatalf("cannot unmarshal expected JSON: %v", err)
+			}
+			if diff := cmp.Diff(wantJSON, gotJSON); diff != "" {
+				t.Errorf("EmitAllJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeAll(t *testing.T) {
+	got, err := rhschema.MergeAll(
+		&rhschema.Interface{Name: ygot.String("eth1")},
+		&rhschema.System{Hostname: ygot.String("dut")},
+		&rhschema.Interface{Name: ygot.String("eth0")},
+		&rhschema.System{
+			NtpServer: map[uint32]*rhschema.System_NtpServer{
+				1: {Name: ygot.Uint32(1)},
+			},
+		},
+		&rhschema.Interface{Name: ygot.String("eth1")},
+	)
+	if err != nil {
+		t.Fatalf("MergeAll: got unexpected error: %v", err)
+	}
+
+	want := []ygot.GoStruct{
+		&rhschema.Interface{Name: ygot.String("eth1")},
+		&rhschema.Interface{Name: ygot.String("eth0")},
+		&rhschema.System{
+			Hostname: ygot.String("dut"),
+			NtpServer: map[uint32]*rhschema.System_NtpServer{
+				1: {Name: ygot.Uint32(1)},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MergeAll: did not get expected structs, diff(-want, +got):\n%s", diff)
+	}
+
+	if _, err := rhschema.MergeAll(
+		&rhschema.System{Hostname: ygot.String("a")},
+		&rhschema.System{Hostname: ygot.String("b")},
+	); err == nil {
+		t.Errorf("MergeAll: did not get expected error for conflicting containers")
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package subtreevalidate is an integration test for ygot that tests the
+// methods that are generated to validate a subtree of a GoStruct.
+package subtreevalidate
+
+//go:generate sh -c "go run ../../generator/generator.go -path=../../testdata/modules -output_file=simpleschema/structs.go -package_name=simpleschema -generate_fakeroot -fakeroot_name=device -compress_paths -shorten_enum_leaf_names -typedef_enum_with_defmod -generate_simple_unions -generate_validation_hooks -generate_subtree_validate ../../testdata/modules/openconfig-simple.yang"
@@ -0,0 +1,115 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subtreevalidate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/integration_tests/subtreevalidate/simpleschema"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("cannot parse path %s: %v", s, err)
+	}
+	return p
+}
+
+// rejectInvalid is a validation hook for a string leaf that rejects the
+// value "invalid", since openconfig-simple has no leaves that have
+// restrictions on their values.
+func rejectInvalid(v interface{}) error {
+	if s, ok := v.(string); ok && s == "invalid" {
+		return errors.New("leaf has invalid value")
+	}
+	return nil
+}
+
+func newDevice(childOne, remoteLeaf string) *simpleschema.Device {
+	return &simpleschema.Device{
+		Parent: &simpleschema.Parent{
+			Child: &simpleschema.Parent_Child{
+				One:                 ygot.String(childOne),
+				ΛValidateFieldHooks: map[string]func(interface{}) error{"One": rejectInvalid},
+			},
+		},
+		RemoteContainer: &simpleschema.RemoteContainer{
+			ALeaf:               ygot.String(remoteLeaf),
+			ΛValidateFieldHooks: map[string]func(interface{}) error{"ALeaf": rejectInvalid},
+		},
+	}
+}
+
+func TestValidateAt(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inDevice         *simpleschema.Device
+		inPath           string
+		wantErrSubstring string
+	}{{
+		desc:     "valid /parent/child subtree",
+		inDevice: newDevice("one", "a"),
+		inPath:   "/parent/child",
+	}, {
+		desc:     "valid /parent/child subtree with invalid /remote-container",
+		inDevice: newDevice("one", "invalid"),
+		inPath:   "/parent/child",
+	}, {
+		desc:             "invalid /parent/child subtree",
+		inDevice:         newDevice("invalid", "a"),
+		inPath:           "/parent/child",
+		wantErrSubstring: "leaf has invalid value",
+	}, {
+		desc:             "invalid /parent/child subtree within /parent",
+		inDevice:         newDevice("invalid", "a"),
+		inPath:           "/parent",
+		wantErrSubstring: "leaf has invalid value",
+	}, {
+		desc:     "invalid /parent/child subtree not within /remote-container",
+		inDevice: newDevice("invalid", "a"),
+		inPath:   "/remote-container",
+	}, {
+		desc:             "path not in schema",
+		inDevice:         newDevice("one", "a"),
+		inPath:           "/parent/sibling",
+		wantErrSubstring: "cannot find subtree to validate",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.inDevice.ΛValidateAt(mustPath(t, tt.inPath))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("ΛValidateAt(%s): did not get expected error, %s", tt.inPath, diff)
+			}
+		})
+	}
+}
+
+func TestValidateAtNonRoot(t *testing.T) {
+	d := newDevice("invalid", "a")
+	if err := d.Parent.ΛValidateAt(mustPath(t, "/child")); err == nil {
+		t.Errorf("ΛValidateAt(/child): got nil error, want error for invalid leaf value")
+	}
+	if err := d.Parent.ΛValidateAt(mustPath(t, "/child/config/three")); err != nil {
+		t.Errorf("ΛValidateAt(/child/config/three): got unexpected error: %v", err)
+	}
+}
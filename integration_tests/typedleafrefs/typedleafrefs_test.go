@@ -0,0 +1,115 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typedleafrefs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/typedleafrefs/tlrschema"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc     string
+		in       *tlrschema.Parent
+		wantJSON string
+	}{{
+		desc: "leafref to an enumerated leaf",
+		in: &tlrschema.Parent{
+			Mode:    tlrschema.Parent_Mode_UP,
+			ModeRef: tlrschema.Parent_Mode_UP,
+		},
+		wantJSON: `{
+   "config": {
+      "mode": "UP",
+      "mode-ref": "UP"
+   }
+}`,
+	}, {
+		desc: "leafrefs to a union leaf holding an enumerated value",
+		in: &tlrschema.Parent{
+			Value:         tlrschema.Parent_Value_AUTO,
+			ValueRef:      tlrschema.Parent_Value_AUTO,
+			ValueUnionRef: tlrschema.Parent_Value_AUTO,
+		},
+		wantJSON: `{
+   "config": {
+      "value": "AUTO",
+      "value-ref": "AUTO",
+      "value-union-ref": "AUTO"
+   }
+}`,
+	}, {
+		desc: "leafrefs to a union leaf holding a string value",
+		in: &tlrschema.Parent{
+			Value:         tlrschema.UnionString("eth0"),
+			ValueRef:      tlrschema.UnionString("eth0"),
+			ValueUnionRef: tlrschema.UnionString("eth0"),
+		},
+		wantJSON: `{
+   "config": {
+      "value": "eth0",
+      "value-ref": "eth0",
+      "value-union-ref": "eth0"
+   }
+}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			gotJSON, err := ygot.EmitJSON(tt.in, &ygot.EmitJSONConfig{Format: ygot.RFC7951})
+			if err != nil {
+				t.Fatalf("EmitJSON: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantJSON, gotJSON); diff != "" {
+				t.Errorf("EmitJSON: did not get expected JSON, (-want, +got):\n%s", diff)
+			}
+
+			got := &tlrschema.Parent{}
+			if err := tlrschema.Unmarshal([]byte(gotJSON), got); err != nil {
+				t.Fatalf("Unmarshal: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.in, got); diff != "" {
+				t.Errorf("Unmarshal: did not get expected struct after round trip, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestAssignFromTarget checks that the value of each leafref's target can be
+// assigned to the leafref without conversion, since each has the generated
+// type of its target.
+func TestAssignFromTarget(t *testing.T) {
+	p := &tlrschema.Parent{
+		Mode:  tlrschema.Parent_Mode_DOWN,
+		Value: tlrschema.Parent_Value_NONE,
+	}
+	p.ModeRef = p.Mode
+	p.ValueRef = p.Value
+	p.ValueUnionRef = p.Value
+
+	want := &tlrschema.Parent{
+		Mode:          tlrschema.Parent_Mode_DOWN,
+		ModeRef:       tlrschema.Parent_Mode_DOWN,
+		Value:         tlrschema.Parent_Value_NONE,
+		ValueRef:      tlrschema.Parent_Value_NONE,
+		ValueUnionRef: tlrschema.Parent_Value_NONE,
+	}
+	if diff := cmp.Diff(want, p); diff != "" {
+		t.Errorf("did not get expected struct, (-want, +got):\n%s", diff)
+	}
+}
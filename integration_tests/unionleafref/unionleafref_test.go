@@ -0,0 +1,97 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unionleafref
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/unionleafref/ulrschema"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc     string
+		in       *ulrschema.Parent
+		wantJSON string
+	}{{
+		desc: "leafref subtype holding an enumerated value",
+		in: &ulrschema.Parent{
+			Value:     ulrschema.Parent_Value_AUTO,
+			Reference: ulrschema.Parent_Value_AUTO,
+		},
+		wantJSON: `{
+   "config": {
+      "reference": "AUTO",
+      "value": "AUTO"
+   }
+}`,
+	}, {
+		desc: "leafref subtype holding a string value",
+		in: &ulrschema.Parent{
+			Value:     ulrschema.UnionString("eth0"),
+			Reference: ulrschema.UnionString("eth0"),
+		},
+		wantJSON: `{
+   "config": {
+      "reference": "eth0",
+      "value": "eth0"
+   }
+}`,
+	}, {
+		desc: "leafref subtype holding a uint32 value",
+		in: &ulrschema.Parent{
+			Value:     ulrschema.UnionUint32(42),
+			Reference: ulrschema.UnionUint32(42),
+		},
+		wantJSON: `{
+   "config": {
+      "reference": 42,
+      "value": 42
+   }
+}`,
+	}, {
+		desc: "non-leafref subtype",
+		in: &ulrschema.Parent{
+			Reference: ulrschema.UnionInt8(-1),
+		},
+		wantJSON: `{
+   "config": {
+      "reference": -1
+   }
+}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			gotJSON, err := ygot.EmitJSON(tt.in, &ygot.EmitJSONConfig{Format: ygot.RFC7951})
+			if err != nil {
+				t.Fatalf("EmitJSON: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantJSON, gotJSON); diff != "" {
+				t.Errorf("EmitJSON: did not get expected JSON, (-want, +got):\n%s", diff)
+			}
+
+			got := &ulrschema.Parent{}
+			if err := ulrschema.Unmarshal([]byte(gotJSON), got); err != nil {
+				t.Fatalf("Unmarshal: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.in, got); diff != "" {
+				t.Errorf("Unmarshal: did not get expected struct after round trip, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
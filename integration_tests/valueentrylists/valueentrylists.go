@@ -0,0 +1,20 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valueentrylists is an integration test for ygot that tests the
+// handling of keyed lists whose members are stored by value by the ygot and
+// ytypes libraries.
+package valueentrylists
+
+//go:generate sh -c "go run ../../generator/generator.go -path=../../testdata/modules -output_file=velschema/structs.go -package_name=velschema -generate_fakeroot -generate_simple_unions -generate_getters -generate_delete -generate_append -generate_populate_defaults -generate_equal_method -value_entry_lists ../../testdata/modules/value-entry-lists.yang"
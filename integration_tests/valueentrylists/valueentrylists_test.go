@@ -0,0 +1,509 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valueentrylists
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/integration_tests/valueentrylists/velschema"
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// newDevice returns a device whose route list contains n members.
+func newDevice(t testing.TB, n int) *velschema.Device {
+	d := &velschema.Device{}
+	r := d.GetOrCreateRoutes()
+	for i := 0; i < n; i++ {
+		if err := r.AppendRoute(velschema.ValueEntryLists_Routes_Route{
+			Prefix:  ygot.String(fmt.Sprintf("10.0.%d.%d/32", i/256, i%256)),
+			Metric:  ygot.Uint32(uint32(i)),
+			NextHop: ygot.String("192.0.2.1"),
+		}); err != nil {
+			t.Fatalf("AppendRoute: got unexpected error: %v", err)
+		}
+	}
+	return d
+}
+
+// routePath returns the path of the leaf of the member of the route list with
+// the key prefix.
+func routePath(prefix, leaf string) *gpb.Path {
+	return &gpb.Path{Elem: []*gpb.PathElem{
+		{Name: "routes"},
+		{Name: "route", Key: map[string]string{"prefix": prefix}},
+		{Name: leaf},
+	}}
+}
+
+func TestListMethods(t *testing.T) {
+	r := &velschema.ValueEntryLists_Routes{}
+	v, err := r.NewRoute("192.0.2.0/24")
+	if err != nil {
+		t.Fatalf("NewRoute: got unexpected error: %v", err)
+	}
+	if _, err := r.NewRoute("192.0.2.0/24"); err == nil {
+		t.Errorf("NewRoute: did not get expected error for duplicate key")
+	}
+
+	// The returned member is a copy, such that it must be stored again.
+	v.NextHop = ygot.String("198.51.100.1")
+	if got, _ := r.GetRoute("192.0.2.0/24"); got.NextHop != nil {
+		t.Errorf("GetRoute: got next-hop %v before member was stored, want nil", *got.NextHop)
+	}
+	r.Route["192.0.2.0/24"] = v
+	got, ok := r.GetRoute("192.0.2.0/24")
+	if !ok || got.NextHop == nil || *got.NextHop != "198.51.100.1" {
+		t.Errorf("GetRoute: got (%v, %v), want member with next-hop 198.51.100.1", got, ok)
+	}
+
+	if err := r.AppendRoute(velschema.ValueEntryLists_Routes_Route{}); err == nil {
+		t.Errorf("AppendRoute: did not get expected error for nil key")
+	}
+
+	r.DeleteRoute("192.0.2.0/24")
+	if _, ok := r.GetRoute("192.0.2.0/24"); ok {
+		t.Errorf("GetRoute: found member after it was deleted")
+	}
+
+	var nilRoutes *velschema.ValueEntryLists_Routes
+	if _, ok := nilRoutes.GetRoute("192.0.2.0/24"); ok {
+		t.Errorf("GetRoute: found member in nil receiver")
+	}
+}
+
+func TestEmitJSON(t *testing.T) {
+	d := newDevice(t, 2)
+	if _, err := d.Routes.NewNeighbor("192.0.2.2", 179); err != nil {
+		t.Fatalf("NewNeighbor: got unexpected error: %v", err)
+	}
+	d.Routes.GetOrCreatePolicy("p1").GetOrCreateConfig().Enabled = ygot.Bool(true)
+
+	tests := []struct {
+		desc     string
+		inFormat ygot.JSONFormat
+		want     string
+	}{{
+		desc:     "RFC7951",
+		inFormat: ygot.RFC7951,
+		want: `{
+  "routes": {
+    "neighbor": [{"address": "192.0.2.2", "port": 179}],
+    "policy": [{"name": "p1", "config": {"enabled": true}}],
+    "route": [
+      {"prefix": "10.0.0.0/32", "metric": 0, "next-hop": "192.0.2.1"},
+      {"prefix": "10.0.0.1/32", "metric": 1, "next-hop": "192.0.2.1"}
+    ]
+  }
+}`,
+	}, {
+		desc:     "internal",
+		inFormat: ygot.Internal,
+		want: `{
+  "routes": {
+    "neighbor": {"192.0.2.2 179": {"address": "192.0.2.2", "port": 179}},
+    "policy": {"p1": {"name": "p1", "config": {"enabled": true}}},
+    "route": {
+      "10.0.0.0/32": {"prefix": "10.0.0.0/32", "metric": 0, "next-hop": "192.0.2.1"},
+      "10.0.0.1/32": {"prefix": "10.0.0.1/32", "metric": 1, "next-hop": "192.0.2.1"}
+    }
+  }
+}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			js, err := ygot.EmitJSON(d, &ygot.EmitJSONConfig{Format: tt.inFormat})
+			if err != nil {
+				t.Fatalf("EmitJSON: got unexpected error: %v", err)
+			}
+			var got, want interface{}
+			if err := json.Unmarshal([]byte(js), &got); err != nil {
+				t.Fatalf("cannot unmarshal emitted JSON: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatalf("cannot unmarshal expected JSON: %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("EmitJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	d := newDevice(t, 1)
+	if err := d.Validate(); err != nil {
+		t.Fatalf("Validate: got unexpected error: %v", err)
+	}
+
+	// Store a member under a key that does not match its key leaf.
+	d.Routes.Route["192.0.2.0/24"] = velschema.ValueEntryLists_Routes_Route{Prefix: ygot.String("198.51.100.0/24")}
+	if err := d.Validate(); err == nil {
+		t.Errorf("Validate: did not get expected error for mismatched key")
+	}
+}
+
+func TestPopulateDefaults(t *testing.T) {
+	d := &velschema.Device{}
+	if _, err := d.GetOrCreateRoutes().NewRoute("192.0.2.0/24"); err != nil {
+		t.Fatalf("NewRoute: got unexpected error: %v", err)
+	}
+	d.PopulateDefaults()
+	if got := d.Routes.Route["192.0.2.0/24"].Metric; got == nil || *got != 10 {
+		t.Errorf("PopulateDefaults: did not populate default metric, got: %v", got)
+	}
+}
+
+func TestMergeStructs(t *testing.T) {
+	a := &velschema.Device{}
+	if _, err := a.GetOrCreateRoutes().NewRoute("192.0.2.0/24"); err != nil {
+		t.Fatalf("NewRoute: got unexpected error: %v", err)
+	}
+
+	b := &velschema.Device{}
+	for _, r := range []velschema.ValueEntryLists_Routes_Route{{
+		Prefix:  ygot.String("192.0.2.0/24"),
+		NextHop: ygot.String("198.51.100.1"),
+	}, {
+		Prefix: ygot.String("203.0.113.0/24"),
+	}} {
+		if err := b.GetOrCreateRoutes().AppendRoute(r); err != nil {
+			t.Fatalf("AppendRoute: got unexpected error: %v", err)
+		}
+	}
+
+	got, err := ygot.MergeStructs(a, b)
+	if err != nil {
+		t.Fatalf("MergeStructs: got unexpected error: %v", err)
+	}
+
+	want := &velschema.Device{
+		Routes: &velschema.ValueEntryLists_Routes{
+			Route: map[string]velschema.ValueEntryLists_Routes_Route{
+				"192.0.2.0/24": {
+					Prefix:  ygot.String("192.0.2.0/24"),
+					NextHop: ygot.String("198.51.100.1"),
+				},
+				"203.0.113.0/24": {
+					Prefix: ygot.String("203.0.113.0/24"),
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MergeStructs: did not get expected result, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	d := newDevice(t, 2)
+
+	got, err := ygot.DeepCopy(d)
+	if err != nil {
+		t.Fatalf("DeepCopy: got unexpected error: %v", err)
+	}
+	into := newDevice(t, 5)
+	if err := ygot.DeepCopyInto(into, d); err != nil {
+		t.Fatalf("DeepCopyInto: got unexpected error: %v", err)
+	}
+
+	for _, c := range []ygot.GoStruct{got, into} {
+		if diff := cmp.Diff(d, c); diff != "" {
+			t.Errorf("did not get expected copy, diff(-want, +got):\n%s", diff)
+		}
+	}
+
+	// The leaves of the members must not be shared with the original.
+	*d.Routes.Route["10.0.0.0/32"].NextHop = "198.51.100.1"
+	for _, c := range []*velschema.Device{got.(*velschema.Device), into} {
+		if nh := c.Routes.Route["10.0.0.0/32"].NextHop; *nh != "192.0.2.1" {
+			t.Errorf("copy shares leaves with the original, got next-hop: %s", *nh)
+		}
+	}
+}
+
+func TestCopiesShareLeaves(t *testing.T) {
+	r := &velschema.ValueEntryLists_Routes{}
+	if _, err := r.NewRoute("192.0.2.0/24"); err != nil {
+		t.Fatalf("NewRoute: got unexpected error: %v", err)
+	}
+
+	// The copy returned by GetRoute is shallow, such that assigning to a
+	// leaf through its pointer modifies the stored member, including its
+	// key leaf, whereas replacing the pointer does not.
+	got, _ := r.GetRoute("192.0.2.0/24")
+	*got.Prefix = "198.51.100.0/24"
+	got.NextHop = ygot.String("203.0.113.1")
+
+	stored := r.Route["192.0.2.0/24"]
+	if *stored.Prefix != "198.51.100.0/24" {
+		t.Errorf("stored member does not share key leaf with copy, got prefix: %s", *stored.Prefix)
+	}
+	if stored.NextHop != nil {
+		t.Errorf("stored member has replaced leaf of copy, got next-hop: %s", *stored.NextHop)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	in := `{
+  "routes": {
+    "neighbor": [{"address": "192.0.2.2", "port": 179, "description": "peer"}],
+    "route": [
+      {"prefix": "10.0.0.0/32", "metric": 0, "next-hop": "192.0.2.1"},
+      {"prefix": "10.0.0.1/32", "metric": 1, "next-hop": "192.0.2.1"}
+    ]
+  }
+}`
+
+	got := &velschema.Device{}
+	if err := velschema.Unmarshal([]byte(in), got); err != nil {
+		t.Fatalf("Unmarshal: got unexpected error: %v", err)
+	}
+
+	want := newDevice(t, 2)
+	if err := want.Routes.AppendNeighbor(velschema.ValueEntryLists_Routes_Neighbor{
+		Address:     ygot.String("192.0.2.2"),
+		Port:        ygot.Uint16(179),
+		Description: ygot.String("peer"),
+	}); err != nil {
+		t.Fatalf("AppendNeighbor: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unmarshal: did not get expected device, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestNodes(t *testing.T) {
+	schema, err := velschema.Schema()
+	if err != nil {
+		t.Fatalf("Schema: got unexpected error: %v", err)
+	}
+	d := newDevice(t, 1)
+
+	// Members that are stored by value are stored in the list again once
+	// they have been modified by SetNode.
+	if err := ytypes.SetNode(schema.RootSchema(), d, routePath("10.0.0.0/32", "next-hop"), &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{"198.51.100.1"}}); err != nil {
+		t.Fatalf("SetNode: got unexpected error: %v", err)
+	}
+	if nh := d.Routes.Route["10.0.0.0/32"].NextHop; *nh != "198.51.100.1" {
+		t.Errorf("SetNode: did not modify stored member, got next-hop: %s", *nh)
+	}
+
+	if err := ytypes.SetNode(schema.RootSchema(), d, routePath("192.0.2.0/24", "metric"), &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{42}}, &ytypes.InitMissingElements{}); err != nil {
+		t.Fatalf("SetNode: got unexpected error creating member: %v", err)
+	}
+	want := velschema.ValueEntryLists_Routes_Route{
+		Prefix: ygot.String("192.0.2.0/24"),
+		Metric: ygot.Uint32(42),
+	}
+	if diff := cmp.Diff(want, d.Routes.Route["192.0.2.0/24"]); diff != "" {
+		t.Errorf("SetNode: did not create expected member, diff(-want, +got):\n%s", diff)
+	}
+
+	nodes, err := ytypes.GetNode(schema.RootSchema(), d, routePath("10.0.0.0/32", "next-hop"))
+	if err != nil {
+		t.Fatalf("GetNode: got unexpected error: %v", err)
+	}
+	if len(nodes) != 1 {
+		t.Fatalf("GetNode: got %d nodes, want 1", len(nodes))
+	}
+	if nh, ok := nodes[0].Data.(*string); !ok || *nh != "198.51.100.1" {
+		t.Errorf("GetNode: got %v, want next-hop 198.51.100.1", nodes[0].Data)
+	}
+
+	if err := ytypes.DeleteNode(schema.RootSchema(), d, routePath("10.0.0.0/32", "next-hop")); err != nil {
+		t.Fatalf("DeleteNode: got unexpected error: %v", err)
+	}
+	if nh := d.Routes.Route["10.0.0.0/32"].NextHop; nh != nil {
+		t.Errorf("DeleteNode: did not modify stored member, got next-hop: %s", *nh)
+	}
+}
+
+func TestMergeNotification(t *testing.T) {
+	schema, err := velschema.Schema()
+	if err != nil {
+		t.Fatalf("Schema: got unexpected error: %v", err)
+	}
+	d := newDevice(t, 1)
+
+	n := &gpb.Notification{
+		Update: []*gpb.Update{{
+			Path: routePath("10.0.0.0/32", "next-hop"),
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{"198.51.100.1"}},
+		}, {
+			Path: &gpb.Path{Elem: []*gpb.PathElem{
+				{Name: "routes"},
+				{Name: "neighbor", Key: map[string]string{"address": "192.0.2.2", "port": "179"}},
+				{Name: "description"},
+			}},
+			Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{"peer"}},
+		}},
+	}
+	if err := ygot.MergeNotification(schema.RootSchema(), d, n, &ygot.MergeOverwriteExistingFields{}); err != nil {
+		t.Fatalf("MergeNotification: got unexpected error: %v", err)
+	}
+
+	want := newDevice(t, 1)
+	want.Routes.Route["10.0.0.0/32"] = velschema.ValueEntryLists_Routes_Route{
+		Prefix:  ygot.String("10.0.0.0/32"),
+		Metric:  ygot.Uint32(0),
+		NextHop: ygot.String("198.51.100.1"),
+	}
+	if err := want.Routes.AppendNeighbor(velschema.ValueEntryLists_Routes_Neighbor{
+		Address:     ygot.String("192.0.2.2"),
+		Port:        ygot.Uint16(179),
+		Description: ygot.String("peer"),
+	}); err != nil {
+		t.Fatalf("AppendNeighbor: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, d); diff != "" {
+		t.Errorf("MergeNotification: did not get expected device, diff(-want, +got):\n%s", diff)
+	}
+
+	del := &gpb.Notification{Delete: []*gpb.Path{routePath("10.0.0.0/32", "next-hop")}}
+	if err := ygot.MergeNotification(schema.RootSchema(), d, del); err != nil {
+		t.Fatalf("MergeNotification: got unexpected error deleting leaf: %v", err)
+	}
+	if nh := d.Routes.Route["10.0.0.0/32"].NextHop; nh != nil {
+		t.Errorf("MergeNotification: did not delete leaf of stored member, got next-hop: %s", *nh)
+	}
+}
+
+func TestUnmarshalJSONStream(t *testing.T) {
+	schema, err := velschema.Schema()
+	if err != nil {
+		t.Fatalf("Schema: got unexpected error: %v", err)
+	}
+	in := `{
+  "routes": {
+    "neighbor": [{"address": "192.0.2.2", "port": 179, "description": "peer"}],
+    "route": [
+      {"prefix": "10.0.0.0/32", "next-hop": "198.51.100.1"},
+      {"prefix": "10.0.0.1/32", "metric": 1, "next-hop": "192.0.2.1"}
+    ]
+  }
+}`
+
+	// The existing member of the route list is merged with that within
+	// the document.
+	got := newDevice(t, 1)
+	if err := ygot.UnmarshalJSONStream(schema.RootSchema(), strings.NewReader(in), got); err != nil {
+		t.Fatalf("UnmarshalJSONStream: got unexpected error: %v", err)
+	}
+
+	want := newDevice(t, 2)
+	want.Routes.Route["10.0.0.0/32"] = velschema.ValueEntryLists_Routes_Route{
+		Prefix:  ygot.String("10.0.0.0/32"),
+		Metric:  ygot.Uint32(0),
+		NextHop: ygot.String("198.51.100.1"),
+	}
+	if err := want.Routes.AppendNeighbor(velschema.ValueEntryLists_Routes_Neighbor{
+		Address:     ygot.String("192.0.2.2"),
+		Port:        ygot.Uint16(179),
+		Description: ygot.String("peer"),
+	}); err != nil {
+		t.Fatalf("AppendNeighbor: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("UnmarshalJSONStream: did not get expected device, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestGetNodes(t *testing.T) {
+	schema, err := velschema.Schema()
+	if err != nil {
+		t.Fatalf("Schema: got unexpected error: %v", err)
+	}
+	d := newDevice(t, 2)
+
+	nodes, _, err := util.GetNodes(schema.RootSchema(), d, routePath("10.0.0.1/32", "metric"))
+	if err != nil {
+		t.Fatalf("GetNodes: got unexpected error: %v", err)
+	}
+	if len(nodes) != 1 {
+		t.Fatalf("GetNodes: got %d nodes, want 1", len(nodes))
+	}
+	if m, ok := nodes[0].(*uint32); !ok || *m != 1 {
+		t.Errorf("GetNodes: got %v, want metric 1", nodes[0])
+	}
+
+	all, _, err := util.GetNodes(schema.RootSchema(), d, &gpb.Path{Elem: []*gpb.PathElem{{Name: "routes"}, {Name: "route"}, {Name: "prefix"}}})
+	if err != nil {
+		t.Fatalf("GetNodes: got unexpected error for all members: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("GetNodes: got %d nodes for all members, want 2", len(all))
+	}
+}
+
+func TestPopulatedChildren(t *testing.T) {
+	d := newDevice(t, 1)
+	if err := d.Routes.AppendNeighbor(velschema.ValueEntryLists_Routes_Neighbor{
+		Address: ygot.String("192.0.2.2"),
+		Port:    ygot.Uint16(179),
+	}); err != nil {
+		t.Fatalf("AppendNeighbor: got unexpected error: %v", err)
+	}
+
+	got, err := ygot.PopulatedChildren(velschema.SchemaTree["ValueEntryLists_Routes"], d.Routes)
+	if err != nil {
+		t.Fatalf("PopulatedChildren: got unexpected error: %v", err)
+	}
+	want := []string{
+		"neighbor[address=192.0.2.2][port=179]",
+		"route[prefix=10.0.0.0/32]",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PopulatedChildren: did not get expected paths, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func BenchmarkEmitJSON(b *testing.B) {
+	d := newDevice(b, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ygot.EmitJSON(d, &ygot.EmitJSONConfig{Format: ygot.RFC7951}); err != nil {
+			b.Fatalf("EmitJSON: got unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkMergeStructs(b *testing.B) {
+	d, o := newDevice(b, 1000), newDevice(b, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ygot.MergeStructs(d, o); err != nil {
+			b.Fatalf("MergeStructs: got unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkDeepCopy(b *testing.B) {
+	d := newDevice(b, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ygot.DeepCopy(d); err != nil {
+			b.Fatalf("DeepCopy: got unexpected error: %v", err)
+		}
+	}
+}
@@ -50,8 +50,17 @@ var (
 	callerName             = flag.String("caller_name", "proto_generator", "The name of the generator binary that should be recorded in output files.")
 	excludeState           = flag.Bool("exclude_state", false, "If set to true, state (config false) fields in the YANG schema are not included in the generated Protobuf messages.")
 	preferOperationalState = flag.Bool("prefer_operational_state", false, "If set to true, state (config false) fields in the YANG schema are preferred over intended config leaves in the generated messages with compressed schema paths. This flag is only valid for compress_paths=true and exclude_state=false.")
+	ignoreDeviations       = flag.Bool("ignore_deviations", false, "If set to true, deviation statements within the input YANG modules are not applied to the schema prior to code generation.")
 	skipEnumDedup          = flag.Bool("skip_enum_deduplication", false, "If set to true, all leaves of type enumeration will have a unique enum output for them, rather than sharing a common type (default behaviour).")
 	goPackageBase          = flag.String("go_package_base", "", "Base name for the Go packages that are to be generated - this value is included in the go_package option of the generated protobufs - and has generated packages' names appended to it.")
+	singleFileOutput       = flag.Bool("single_file_output", false, "If set to true, all generated messages and enumerations are output to a single file for the base package, with child packages output as messages within it. This flag is not valid when package_hierarchy=true.")
+	useProtoMaps           = flag.Bool("use_proto_maps", false, "If set to true, YANG lists with a single key of a string, integer or boolean type are output as protobuf map fields keyed by the list key, rather than as repeated key messages.")
+	wellKnownTypes         = flag.String("well_known_types", "", "Comma separated set of typedef=type pairs specifying the google.protobuf well-known type (Timestamp or Duration) that leaves of the named YANG typedef should be output as, e.g., date-and-time=Timestamp.")
+	annotateModuleInfo     = flag.Bool("annotate_module_info", false, "If set to true, each output message is preceded by a comment indicating the YANG module, and its most recent revision, that defines the corresponding schema element.")
+	useProto3Optional      = flag.Bool("use_proto3_optional", false, "If set to true, scalar leaves are output as native protobuf scalar fields marked with the proto3 optional keyword rather than as ywrapper messages. decimal64 leaves continue to use the ywrapper Decimal64Value message.")
+	emitValidateRules      = flag.Bool("emit_validate_rules", false, "If set to true, the range, length and pattern restrictions of YANG leaves are output as protoc-gen-validate (validate.rules) field options. Constraints are only output for leaves that are native protobuf scalar fields, such as when use_proto3_optional is set.")
+	narrowIntegers         = flag.Bool("narrow_integers", false, "If set to true, YANG integer types of 32 bits or narrower are output as sint32 or uint32 rather than sint64 or uint64 where a native protobuf scalar type is used, such as within list keys and unions.")
+	prefixEnumValues       = flag.Bool("prefix_enum_values", false, "If set to true, the values of enumerations output in the enum package are prefixed with the name of the enumeration in upper snake case (e.g., FOO_VALUE_ONE) rather than in upper case (e.g., FOOVALUE_ONE).")
 )
 
 // main parses command-line flags to determine the set of YANG modules for
@@ -94,6 +103,18 @@ func main() {
 		}
 	}
 
+	// Determine which YANG typedefs are to be mapped to well-known types.
+	wellKnownTypeMap := map[string]string{}
+	if len(*wellKnownTypes) > 0 {
+		for _, m := range strings.Split(*wellKnownTypes, ",") {
+			parts := strings.SplitN(m, "=", 2)
+			if len(parts) != 2 {
+				log.Exitf("ERROR Generating Proto Code: invalid well-known type mapping %s, must be of the form typedef=type\n", m)
+			}
+			wellKnownTypeMap[parts[0]] = parts[1]
+		}
+	}
+
 	compressBehaviour, err := genutil.TranslateToCompressBehaviour(*compressPaths, *excludeState, *preferOperationalState)
 	if err != nil {
 		log.Exitf("ERROR Generating Proto Code: %s\n", err)
@@ -104,6 +125,7 @@ func main() {
 		ParseOptions: ygen.ParseOpts{
 			ExcludeModules:        modsExcluded,
 			SkipEnumDeduplication: *skipEnumDedup,
+			IgnoreDeviations:      *ignoreDeviations,
 			YANGParseOptions: yang.Options{
 				IgnoreSubmoduleCircularDependencies: *ignoreCircDeps,
 			},
@@ -124,6 +146,14 @@ func main() {
 			NestedMessages:      !*packageHierarchy,
 			EnumPackageName:     *enumPackageName,
 			GoPackageBase:       *goPackageBase,
+			SingleFileOutput:    *singleFileOutput,
+			UseProtoMaps:        *useProtoMaps,
+			WellKnownTypeMap:    wellKnownTypeMap,
+			AnnotateModuleInfo:  *annotateModuleInfo,
+			UseProto3Optional:   *useProto3Optional,
+			PrefixEnumValues:    *prefixEnumValues,
+			EmitValidateRules:   *emitValidateRules,
+			NarrowIntegers:      *narrowIntegers,
 		},
 	})
 
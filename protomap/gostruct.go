@@ -0,0 +1,404 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protomap
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/gnmi/value"
+	"github.com/openconfig/goyang/pkg/yang"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	wpb "github.com/openconfig/ygot/proto/ywrapper"
+)
+
+// GoStructToProto populates the ygen-generated protobuf p with the values of
+// the leaves that are populated within the ygen-generated GoStruct s. Both s
+// and p must describe the root of the same YANG schema. Leaves of s that do
+// not have a corresponding field within p are ignored, such that a GoStruct
+// generated with path compression can be mapped to a protobuf generated
+// without it. Leaf-lists, decimal64 leaves, and the map fields of protobufs
+// generated with proto maps are not supported, and an error is returned if
+// such a field of p would be populated.
+func GoStructToProto(s ygot.GoStruct, p proto.Message) error {
+	if p == nil {
+		return errors.New("nil protobuf supplied")
+	}
+
+	ns, err := ygot.TogNMINotifications(s, 0, ygot.GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		return fmt.Errorf("cannot render GoStruct to paths, %v", err)
+	}
+
+	vals := &leafValues{vals: map[string]*gpb.TypedValue{}}
+	for _, n := range ns {
+		for _, u := range n.GetUpdate() {
+			path := &gpb.Path{Elem: append(append([]*gpb.PathElem{}, n.GetPrefix().GetElem()...), u.GetPath().GetElem()...)}
+			ps, err := ygot.PathToString(path)
+			if err != nil {
+				return fmt.Errorf("cannot convert path %s to string, %v", path, err)
+			}
+			vals.vals[ps] = u.GetVal()
+			vals.paths = append(vals.paths, path)
+		}
+	}
+
+	_, err = messageFromValues(p.ProtoReflect(), vals, nil)
+	return err
+}
+
+// GoStructFromProto populates the ygen-generated GoStruct s, whose schema is
+// supplied, with the values of the fields that are populated within the
+// ygen-generated protobuf p. Both s and p must describe the root of the same
+// YANG schema. Leaf-lists, decimal64 leaves, and the map fields of protobufs
+// generated with proto maps are not supported, and an error is returned if
+// such a field is populated within p.
+func GoStructFromProto(p proto.Message, schema *yang.Entry, s ygot.GoStruct) error {
+	if p == nil {
+		return errors.New("nil protobuf supplied")
+	}
+
+	if err := checkSupportedFields(p.ProtoReflect()); err != nil {
+		return err
+	}
+
+	vals, err := PathsFromProto(p)
+	if err != nil {
+		return err
+	}
+
+	var errs errlist.List
+	for path, v := range vals {
+		tv, err := value.FromScalar(v)
+		if err != nil {
+			errs.Add(fmt.Errorf("cannot convert value %v at %s to a TypedValue, %v", v, path, err))
+			continue
+		}
+		if err := ytypes.SetNode(schema, s, path, tv, &ytypes.InitMissingElements{}); err != nil {
+			errs.Add(fmt.Errorf("cannot set value at %s, %v", path, err))
+		}
+	}
+	return errs.Err()
+}
+
+// leafValues stores the values of the populated leaves of a GoStruct, keyed
+// by the string form of their data tree path, along with the set of paths.
+type leafValues struct {
+	vals  map[string]*gpb.TypedValue
+	paths []*gpb.Path
+}
+
+// lookup returns the value of the leaf at the supplied data tree path, and
+// whether it was found.
+func (l *leafValues) lookup(p *gpb.Path) (*gpb.TypedValue, bool, error) {
+	ps, err := ygot.PathToString(p)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot convert path %s to string, %v", p, err)
+	}
+	v, ok := l.vals[ps]
+	return v, ok, nil
+}
+
+// populated returns true if the values of any leaves at or below the supplied
+// data tree path are stored. Keys that are not specified within the path match
+// any list member.
+func (l *leafValues) populated(p *gpb.Path) bool {
+	for _, vp := range l.paths {
+		if util.PathMatchesQuery(vp, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// listMembers returns the data tree paths of the members of the list at the
+// supplied path, the final element of which must not have keys specified. The
+// paths are sorted such that members are output in a deterministic order.
+func (l *leafValues) listMembers(listPath *gpb.Path) ([]*gpb.Path, error) {
+	members := map[string]*gpb.Path{}
+	n := len(listPath.GetElem())
+	for _, p := range l.paths {
+		if len(p.GetElem()) <= n || !util.PathMatchesPathElemPrefix(p, &gpb.Path{Elem: listPath.GetElem()[:n-1]}) {
+			continue
+		}
+		e := p.GetElem()[n-1]
+		if e.GetName() != listPath.GetElem()[n-1].GetName() || len(e.GetKey()) == 0 {
+			continue
+		}
+		mp := &gpb.Path{Elem: append(append([]*gpb.PathElem{}, listPath.GetElem()[:n-1]...), e)}
+		ms, err := ygot.PathToString(mp)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert path %s to string, %v", mp, err)
+		}
+		members[ms] = mp
+	}
+
+	var keys []string
+	for k := range members {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var paths []*gpb.Path
+	for _, k := range keys {
+		paths = append(paths, members[k])
+	}
+	return paths, nil
+}
+
+// messageFromValues populates the fields of the protobuf message m with the
+// supplied leaf values. The basePath is the data tree path of the message,
+// which is used to resolve the keys of the lists that the message is within.
+// It returns whether any field of the message was populated.
+func messageFromValues(m protoreflect.Message, vals *leafValues, basePath *gpb.Path) (bool, error) {
+	var populated bool
+	fds := m.Descriptor().Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		annotatedPath, err := annotatedSchemaPath(fd)
+		if err != nil {
+			return false, err
+		}
+
+		if fd.IsMap() {
+			for _, ap := range annotatedPath {
+				if vals.populated(resolvedPath(basePath, ap)) {
+					return false, fmt.Errorf("unsupported map field %s, protobufs generated with proto maps are not supported", fd.FullName())
+				}
+			}
+			continue
+		}
+
+		if fd.IsList() {
+			set, err := listFromValues(m, fd, vals, basePath, annotatedPath)
+			if err != nil {
+				return false, err
+			}
+			populated = populated || set
+			continue
+		}
+
+		if fd.Kind() == protoreflect.MessageKind && !isWrapperMessage(m.NewField(fd).Message()) {
+			if len(annotatedPath) != 1 {
+				return false, fmt.Errorf("invalid container, maps to >1 schema path, field: %s", fd.FullName())
+			}
+			child := m.NewField(fd).Message()
+			set, err := messageFromValues(child, vals, basePath)
+			if err != nil {
+				return false, err
+			}
+			if set {
+				m.Set(fd, protoreflect.ValueOfMessage(child))
+				populated = true
+			}
+			continue
+		}
+
+		for _, ap := range annotatedPath {
+			tv, ok, err := vals.lookup(resolvedPath(basePath, ap))
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				continue
+			}
+			var v protoreflect.Value
+			switch fd.Kind() {
+			case protoreflect.MessageKind:
+				if _, ok := m.NewField(fd).Message().Interface().(*wpb.Decimal64Value); ok {
+					return false, fmt.Errorf("unsupported decimal64 field %s", fd.FullName())
+				}
+				wv, ok, err := makeWrapper(m, fd, tv)
+				if err != nil {
+					return false, err
+				}
+				if !ok {
+					return false, fmt.Errorf("unsupported field type %s for %s", m.NewField(fd).Message().Descriptor().FullName(), fd.FullName())
+				}
+				v = protoreflect.ValueOfMessage(wv)
+			case protoreflect.EnumKind:
+				if v, err = enumValue(fd, tv); err != nil {
+					return false, err
+				}
+			default:
+				return false, fmt.Errorf("unknown field kind %s for %s", fd.Kind(), fd.FullName())
+			}
+			m.Set(fd, v)
+			populated = true
+			break
+		}
+	}
+	return populated, nil
+}
+
+// listFromValues populates the repeated field fd of the message m, which
+// corresponds to the YANG list at the annotated path, with a member for each
+// list entry found within the supplied leaf values. It returns whether any
+// members were added to the list. Leaf-lists are not supported, and an error
+// is returned if the leaf-list that fd corresponds to is populated.
+func listFromValues(m protoreflect.Message, fd protoreflect.FieldDescriptor, vals *leafValues, basePath *gpb.Path, annotatedPath []*gpb.Path) (bool, error) {
+	if len(annotatedPath) != 1 {
+		return false, fmt.Errorf("invalid list, does not map to 1 schema path, field: %s", fd.FullName())
+	}
+	if isLeafListField(fd, m.NewField(fd).List()) {
+		if vals.populated(resolvedPath(basePath, annotatedPath[0])) {
+			return false, fmt.Errorf("unsupported leaf-list field %s", fd.FullName())
+		}
+		return false, nil
+	}
+
+	members, err := vals.listMembers(resolvedPath(basePath, annotatedPath[0]))
+	if err != nil {
+		return false, err
+	}
+	if len(members) == 0 {
+		return false, nil
+	}
+
+	l := m.Mutable(fd).List()
+	for _, mp := range members {
+		keys := mp.GetElem()[len(mp.GetElem())-1].GetKey()
+		km := l.NewElement().Message()
+		kfds := km.Descriptor().Fields()
+		for i := 0; i < kfds.Len(); i++ {
+			kfd := kfds.Get(i)
+			if kfd.Kind() == protoreflect.MessageKind {
+				member := km.NewField(kfd).Message()
+				if _, err := messageFromValues(member, vals, mp); err != nil {
+					return false, err
+				}
+				km.Set(kfd, protoreflect.ValueOfMessage(member))
+				continue
+			}
+
+			keyPaths, err := annotatedSchemaPath(kfd)
+			if err != nil {
+				return false, err
+			}
+			kn, err := fieldName(keyPaths[0])
+			if err != nil {
+				return false, err
+			}
+			kv, ok := keys[kn]
+			if !ok {
+				return false, fmt.Errorf("key %s of list %s is not specified in path %s", kn, fd.FullName(), mp)
+			}
+			v, err := keyValue(kfd, kv)
+			if err != nil {
+				return false, err
+			}
+			km.Set(kfd, v)
+		}
+		l.Append(protoreflect.ValueOfMessage(km))
+	}
+	return true, nil
+}
+
+// keyValue returns the value of the list key field fd, parsed from the string
+// form of the key used within gNMI paths.
+func keyValue(fd protoreflect.FieldDescriptor, kv string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(kv), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(kv)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid bool key %s for field %s, %v", kv, fd.FullName(), err)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		u, err := strconv.ParseUint(kv, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid uint32 key %s for field %s, %v", kv, fd.FullName(), err)
+		}
+		return protoreflect.ValueOfUint32(uint32(u)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		u, err := strconv.ParseUint(kv, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid uint64 key %s for field %s, %v", kv, fd.FullName(), err)
+		}
+		return protoreflect.ValueOfUint64(u), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := strconv.ParseInt(kv, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid int32 key %s for field %s, %v", kv, fd.FullName(), err)
+		}
+		return protoreflect.ValueOfInt32(int32(i)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := strconv.ParseInt(kv, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid int64 key %s for field %s, %v", kv, fd.FullName(), err)
+		}
+		return protoreflect.ValueOfInt64(i), nil
+	case protoreflect.EnumKind:
+		return enumValue(fd, kv)
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported list key type %s for field %s", fd.Kind(), fd.FullName())
+	}
+}
+
+// isLeafListField returns true if the repeated field fd, whose value is l,
+// corresponds to a YANG leaf-list rather than a keyed list.
+func isLeafListField(fd protoreflect.FieldDescriptor, l protoreflect.List) bool {
+	return fd.Kind() != protoreflect.MessageKind || isWrapperMessage(l.NewElement().Message())
+}
+
+// checkSupportedFields returns an error if a map, leaf-list or decimal64
+// field is populated within the message m, or within any message that it
+// contains, since such fields cannot be mapped to a GoStruct.
+func checkSupportedFields(m protoreflect.Message) error {
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			err = fmt.Errorf("unsupported map field %s, protobufs generated with proto maps are not supported", fd.FullName())
+		case fd.IsList() && isLeafListField(fd, v.List()):
+			err = fmt.Errorf("unsupported leaf-list field %s", fd.FullName())
+		case fd.IsList():
+			l := v.List()
+			for i := 0; i < l.Len() && err == nil; i++ {
+				err = checkSupportedFields(l.Get(i).Message())
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			if _, ok := v.Message().Interface().(*wpb.Decimal64Value); ok {
+				err = fmt.Errorf("unsupported decimal64 field %s", fd.FullName())
+			} else if !isWrapperMessage(v.Message()) {
+				err = checkSupportedFields(v.Message())
+			}
+		}
+		return err == nil
+	})
+	return err
+}
+
+// isWrapperMessage returns true if the supplied message is one of the
+// ywrapper messages that are used to represent scalar YANG leaves.
+func isWrapperMessage(m protoreflect.Message) bool {
+	switch m.Interface().(type) {
+	case *wpb.BoolValue, *wpb.BytesValue, *wpb.Decimal64Value, *wpb.IntValue, *wpb.StringValue, *wpb.UintValue:
+		return true
+	}
+	return false
+}
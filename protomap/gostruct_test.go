@@ -0,0 +1,420 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protomap
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	wpb "github.com/openconfig/ygot/proto/ywrapper"
+	epb "github.com/openconfig/ygot/protomap/testdata/exschemapath"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// exampleRoot is a GoStruct that corresponds to the ExampleMessage protobuf
+// within the exschemapath test package.
+type exampleRoot struct {
+	Bool    *bool                   `path:"bool"`
+	Int     *int64                  `path:"int"`
+	String  *string                 `path:"string"`
+	Uint    *uint64                 `path:"uint"`
+	Message *exampleMessage         `path:"message"`
+	List    map[string]*exampleList `path:"list-name"`
+}
+
+func (*exampleRoot) IsYANGGoStruct() {}
+
+type exampleMessage struct {
+	Str *string `path:"str"`
+}
+
+func (*exampleMessage) IsYANGGoStruct() {}
+
+type exampleList struct {
+	SingleKey    *string            `path:"single-key"`
+	AnotherField *string            `path:"another-field"`
+	Config       *exampleListConfig `path:"config"`
+}
+
+func (*exampleList) IsYANGGoStruct() {}
+
+func (e *exampleList) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"single-key": *e.SingleKey,
+	}, nil
+}
+
+type exampleListConfig struct {
+	SingleKey *string `path:"single-key"`
+}
+
+func (*exampleListConfig) IsYANGGoStruct() {}
+
+// badBoolRoot is a GoStruct whose bool leaf has a type that does not match
+// the ExampleMessage protobuf.
+type badBoolRoot struct {
+	Bool *string `path:"bool"`
+}
+
+func (*badBoolRoot) IsYANGGoStruct() {}
+
+// decimalRoot is a GoStruct whose decimal64 leaf corresponds to the
+// Decimal64Value field of the ExampleMessage protobuf.
+type decimalRoot struct {
+	Decimal *float64 `path:"decimal"`
+}
+
+func (*decimalRoot) IsYANGGoStruct() {}
+
+// mapFieldRoot is a GoStruct whose leaf corresponds to the map field of the
+// InvalidMessage protobuf.
+type mapFieldRoot struct {
+	Field *string `path:"an/invalid/field"`
+}
+
+func (*mapFieldRoot) IsYANGGoStruct() {}
+
+func exampleSchema() *yang.Entry {
+	s := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"bool": {
+				Name: "bool",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Ybool},
+			},
+			"int": {
+				Name: "int",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Yint64},
+			},
+			"string": {
+				Name: "string",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			"uint": {
+				Name: "uint",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Yuint64},
+			},
+			"message": {
+				Name: "message",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"str": {
+						Name: "str",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+				},
+			},
+			"list-name": {
+				Name:     "list-name",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "single-key",
+				Dir: map[string]*yang.Entry{
+					"single-key": {
+						Name: "single-key",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"another-field": {
+						Name: "another-field",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"config": {
+						Name: "config",
+						Kind: yang.DirectoryEntry,
+						Dir: map[string]*yang.Entry{
+							"single-key": {
+								Name: "single-key",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(s)
+	return s
+}
+
+func addParents(e *yang.Entry) {
+	for _, c := range e.Dir {
+		c.Parent = e
+		addParents(c)
+	}
+}
+
+func TestGoStructToProto(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inStruct         ygot.GoStruct
+		inProto          proto.Message
+		wantProto        proto.Message
+		wantErrSubstring string
+	}{{
+		desc: "scalar fields",
+		inStruct: &exampleRoot{
+			Bool:   ygot.Bool(true),
+			Int:    ygot.Int64(-42),
+			String: ygot.String("hello"),
+			Uint:   ygot.Uint64(42),
+		},
+		inProto: &epb.ExampleMessage{},
+		wantProto: &epb.ExampleMessage{
+			Bo:  &wpb.BoolValue{Value: true},
+			In:  &wpb.IntValue{Value: -42},
+			Str: &wpb.StringValue{Value: "hello"},
+			Ui:  &wpb.UintValue{Value: 42},
+		},
+	}, {
+		desc: "child container",
+		inStruct: &exampleRoot{
+			Message: &exampleMessage{Str: ygot.String("child")},
+		},
+		inProto: &epb.ExampleMessage{},
+		wantProto: &epb.ExampleMessage{
+			Ex: &epb.ExampleMessageChild{
+				Str: &wpb.StringValue{Value: "child"},
+			},
+		},
+	}, {
+		desc: "unpopulated child container",
+		inStruct: &exampleRoot{
+			Message: &exampleMessage{},
+			String:  ygot.String("hello"),
+		},
+		inProto: &epb.ExampleMessage{},
+		wantProto: &epb.ExampleMessage{
+			Str: &wpb.StringValue{Value: "hello"},
+		},
+	}, {
+		desc: "list with multiple members",
+		inStruct: &exampleRoot{
+			List: map[string]*exampleList{
+				"b": {
+					SingleKey:    ygot.String("b"),
+					AnotherField: ygot.String("bar"),
+					Config:       &exampleListConfig{SingleKey: ygot.String("b")},
+				},
+				"a": {
+					SingleKey:    ygot.String("a"),
+					AnotherField: ygot.String("foo"),
+					Config:       &exampleListConfig{SingleKey: ygot.String("a")},
+				},
+			},
+		},
+		inProto: &epb.ExampleMessage{},
+		wantProto: &epb.ExampleMessage{
+			Em: []*epb.ExampleMessageKey{{
+				SingleKey: "a",
+				Member: &epb.ExampleMessageListMember{
+					Str: &wpb.StringValue{Value: "foo"},
+				},
+			}, {
+				SingleKey: "b",
+				Member: &epb.ExampleMessageListMember{
+					Str: &wpb.StringValue{Value: "bar"},
+				},
+			}},
+		},
+	}, {
+		desc: "list member with only key populated",
+		inStruct: &exampleRoot{
+			List: map[string]*exampleList{
+				"a": {SingleKey: ygot.String("a")},
+			},
+		},
+		inProto: &epb.ExampleMessage{},
+		wantProto: &epb.ExampleMessage{
+			Em: []*epb.ExampleMessageKey{{
+				SingleKey: "a",
+				Member:    &epb.ExampleMessageListMember{},
+			}},
+		},
+	}, {
+		desc:             "wrong type for wrapper field",
+		inStruct:         &badBoolRoot{Bool: ygot.String("true")},
+		inProto:          &epb.ExampleMessage{},
+		wantErrSubstring: "got non-bool value for bool field",
+	}, {
+		desc:             "decimal64 field",
+		inStruct:         &decimalRoot{Decimal: ygot.Float64(4.2)},
+		inProto:          &epb.ExampleMessage{},
+		wantErrSubstring: "unsupported decimal64 field exschemapath.ExampleMessage.de",
+	}, {
+		desc:             "map field",
+		inStruct:         &mapFieldRoot{Field: ygot.String("value")},
+		inProto:          &epb.InvalidMessage{},
+		wantErrSubstring: "unsupported map field exschemapath.InvalidMessage.map_field",
+	}, {
+		desc:             "nil protobuf",
+		inStruct:         &exampleRoot{},
+		wantErrSubstring: "nil protobuf supplied",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := GoStructToProto(tt.inStruct, tt.inProto)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GoStructToProto(%#v, %T): did not get expected error, %s", tt.inStruct, tt.inProto, diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tt.inProto, tt.wantProto, protocmp.Transform()); diff != "" {
+				t.Fatalf("GoStructToProto(%#v, %T): did not get expected protobuf, diff(-got,+want):\n%s", tt.inStruct, tt.inProto, diff)
+			}
+		})
+	}
+}
+
+func TestGoStructFromProto(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inProto          proto.Message
+		wantStruct       ygot.GoStruct
+		wantErrSubstring string
+	}{{
+		desc: "scalar fields and child container",
+		inProto: &epb.ExampleMessage{
+			Bo:  &wpb.BoolValue{Value: true},
+			In:  &wpb.IntValue{Value: -42},
+			Str: &wpb.StringValue{Value: "hello"},
+			Ui:  &wpb.UintValue{Value: 42},
+			Ex: &epb.ExampleMessageChild{
+				Str: &wpb.StringValue{Value: "child"},
+			},
+		},
+		wantStruct: &exampleRoot{
+			Bool:    ygot.Bool(true),
+			Int:     ygot.Int64(-42),
+			String:  ygot.String("hello"),
+			Uint:    ygot.Uint64(42),
+			Message: &exampleMessage{Str: ygot.String("child")},
+		},
+	}, {
+		desc: "list",
+		inProto: &epb.ExampleMessage{
+			Em: []*epb.ExampleMessageKey{{
+				SingleKey: "a",
+				Member: &epb.ExampleMessageListMember{
+					Str: &wpb.StringValue{Value: "foo"},
+				},
+			}},
+		},
+		wantStruct: &exampleRoot{
+			List: map[string]*exampleList{
+				"a": {
+					SingleKey:    ygot.String("a"),
+					AnotherField: ygot.String("foo"),
+					Config:       &exampleListConfig{SingleKey: ygot.String("a")},
+				},
+			},
+		},
+	}, {
+		desc: "field not in schema",
+		inProto: &epb.ExampleMessage{
+			Compress: &wpb.StringValue{Value: "hello"},
+		},
+		wantErrSubstring: "cannot set value at",
+	}, {
+		desc: "decimal64 field",
+		inProto: &epb.ExampleMessage{
+			De: &wpb.Decimal64Value{Digits: 42, Precision: 1},
+		},
+		wantErrSubstring: "unsupported decimal64 field exschemapath.ExampleMessage.de",
+	}, {
+		desc: "leaf-list field",
+		inProto: &epb.InvalidMessage{
+			Ke: []string{"a", "b"},
+		},
+		wantErrSubstring: "unsupported leaf-list field exschemapath.InvalidMessage.ke",
+	}, {
+		desc: "map field",
+		inProto: &epb.InvalidMessage{
+			MapField: map[string]string{"a": "b"},
+		},
+		wantErrSubstring: "unsupported map field exschemapath.InvalidMessage.map_field",
+	}, {
+		desc:             "nil protobuf",
+		wantErrSubstring: "nil protobuf supplied",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &exampleRoot{}
+			err := GoStructFromProto(tt.inProto, exampleSchema(), got)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GoStructFromProto(%v): did not get expected error, %s", tt.inProto, diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(got, tt.wantStruct); diff != "" {
+				t.Fatalf("GoStructFromProto(%v): did not get expected GoStruct, diff(-got,+want):\n%s", tt.inProto, diff)
+			}
+		})
+	}
+}
+
+func TestGoStructProtoRoundTrip(t *testing.T) {
+	in := &exampleRoot{
+		Bool:    ygot.Bool(false),
+		Int:     ygot.Int64(1),
+		String:  ygot.String("hello"),
+		Uint:    ygot.Uint64(0),
+		Message: &exampleMessage{Str: ygot.String("child")},
+		List: map[string]*exampleList{
+			"a": {
+				SingleKey:    ygot.String("a"),
+				AnotherField: ygot.String("foo"),
+				Config:       &exampleListConfig{SingleKey: ygot.String("a")},
+			},
+			"b": {
+				SingleKey: ygot.String("b"),
+				Config:    &exampleListConfig{SingleKey: ygot.String("b")},
+			},
+		},
+	}
+
+	p := &epb.ExampleMessage{}
+	if err := GoStructToProto(in, p); err != nil {
+		t.Fatalf("GoStructToProto(%#v): got unexpected error, %v", in, err)
+	}
+
+	got := &exampleRoot{}
+	if err := GoStructFromProto(p, exampleSchema(), got); err != nil {
+		t.Fatalf("GoStructFromProto(%v): got unexpected error, %v", p, err)
+	}
+
+	if diff := cmp.Diff(got, in); diff != "" {
+		t.Fatalf("did not get same GoStruct after round trip, diff(-got,+want):\n%s", diff)
+	}
+}
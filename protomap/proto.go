@@ -607,6 +607,26 @@ func makeWrapper(msg protoreflect.Message, fd protoreflect.FieldDescriptor, val
 		}
 
 		return (&wpb.UintValue{Value: nsv}).ProtoReflect(), true, nil
+	case *wpb.IntValue:
+		var nsv int64
+		switch {
+		case wasTypedVal:
+			nsv = val.(int64)
+		default:
+			iv, ok := val.(int)
+			if !ok {
+				return nil, false, fmt.Errorf("got non-int value for int field, field: %s, value: %v", fd.FullName(), val)
+			}
+			nsv = int64(iv)
+		}
+
+		return (&wpb.IntValue{Value: nsv}).ProtoReflect(), true, nil
+	case *wpb.BoolValue:
+		bv, ok := val.(bool)
+		if !ok {
+			return nil, false, fmt.Errorf("got non-bool value for bool field, field: %s, value: %v", fd.FullName(), val)
+		}
+		return (&wpb.BoolValue{Value: bv}).ProtoReflect(), true, nil
 	case *wpb.BytesValue:
 		bv, ok := val.([]byte)
 		if !ok {
@@ -133,6 +133,79 @@ func IsValueMap(v reflect.Value) bool {
 	return v.Kind() == reflect.Map
 }
 
+// ListMemberPtr returns a struct pointer for v, which is a member of a keyed
+// list stored within the map that represents the list. If the members of the
+// list are stored by value, a pointer to a copy of v is returned, otherwise v
+// is returned unchanged.
+func ListMemberPtr(v reflect.Value) reflect.Value {
+	if !IsValueStruct(v) {
+		return v
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+// orderedListType is the reflect.Type of the marker method of the types that
+// ygen generates to store "ordered-by user" lists. Such types implement the
+// ygot.GoOrderedList interface, which cannot be referenced here since the ygot
+// package depends on util.
+var orderedListType = reflect.TypeOf((*interface{ IsYANGOrderedList() })(nil)).Elem()
+
+// IsTypeOrderedList reports whether t is the type of an ordered list that is
+// generated by ygen. Such types are struct pointers whose fields are not part
+// of the data tree, and are instead handled as keyed lists whose members are
+// accessed through their methods.
+func IsTypeOrderedList(t reflect.Type) bool {
+	return t.Implements(orderedListType)
+}
+
+// OrderedListMembers returns the keys and the members of the ordered list v,
+// whose type must satisfy IsTypeOrderedList, in the order in which they are
+// stored. Each member is a struct pointer.
+func OrderedListMembers(v reflect.Value) ([]reflect.Value, []reflect.Value) {
+	if IsNilOrInvalidValue(v) {
+		return nil, nil
+	}
+	ks := v.MethodByName("ΛKeys").Call(nil)[0]
+	ms := v.MethodByName("ΛValues").Call(nil)[0]
+	keys := make([]reflect.Value, 0, ks.Len())
+	members := make([]reflect.Value, 0, ms.Len())
+	for i := 0; i < ks.Len(); i++ {
+		keys = append(keys, ks.Index(i).Elem())
+		members = append(members, ms.Index(i).Elem())
+	}
+	return keys, members
+}
+
+// OrderedListMemberType returns the type of the members of the ordered list
+// type t, which is a struct pointer type.
+func OrderedListMemberType(t reflect.Type) reflect.Type {
+	m, ok := t.MethodByName("Append")
+	if !ok {
+		return nil
+	}
+	// The receiver is the first input of a method obtained from its type.
+	return m.Type.In(1)
+}
+
+// AppendToOrderedList appends the member v, which is a struct pointer, to the
+// end of the ordered list l, whose type must satisfy IsTypeOrderedList. An
+// error is returned if v is not of the type of the members of l, or if l
+// already contains a member with the same key.
+func AppendToOrderedList(l, v reflect.Value) error {
+	if IsNilOrInvalidValue(l) {
+		return fmt.Errorf("cannot append to nil ordered list of type %v", l.Type())
+	}
+	if mt := OrderedListMemberType(l.Type()); v.Type() != mt {
+		return fmt.Errorf("cannot append %v to ordered list of type %v, expect %v", v.Type(), l.Type(), mt)
+	}
+	if err, _ := l.MethodByName("Append").Call([]reflect.Value{v})[0].Interface().(error); err != nil {
+		return err
+	}
+	return nil
+}
+
 // IsValueSlice reports whether v is a slice type.
 func IsValueSlice(v reflect.Value) bool {
 	return v.Kind() == reflect.Slice
@@ -230,6 +303,9 @@ func UpdateField(parentStruct interface{}, fieldName string, fieldValue interfac
 	if ft.Type.Kind() == reflect.Slice {
 		return InsertIntoSliceStructField(parentStruct, fieldName, fieldValue)
 	}
+	if _, ok := YgotArrayLenField(ft); ok {
+		return InsertIntoSliceStructField(parentStruct, fieldName, fieldValue)
+	}
 
 	return InsertIntoStruct(parentStruct, fieldName, fieldValue)
 }
@@ -292,7 +368,9 @@ func InsertIntoStruct(parentStruct interface{}, fieldName string, fieldValue int
 }
 
 // InsertIntoSliceStructField inserts fieldValue into a field of type slice in
-// parentStruct called fieldName (which must exist, but may be nil).
+// parentStruct called fieldName (which must exist, but may be nil). If the
+// field is a leaf-list that is represented as a fixed-size array, fieldValue
+// is stored in its first unpopulated element.
 func InsertIntoSliceStructField(parentStruct interface{}, fieldName string, fieldValue interface{}) error {
 	DbgPrint("InsertIntoSliceStructField field %s of parent type %T with value %v", fieldName, parentStruct, ValueStrDebug(fieldValue))
 
@@ -306,7 +384,8 @@ func InsertIntoSliceStructField(parentStruct interface{}, fieldName string, fiel
 	if !ok {
 		return fmt.Errorf("parent type %T does not have a field name %s", parentStruct, fieldName)
 	}
-	if ft.Type.Kind() != reflect.Slice {
+	_, isArray := YgotArrayLenField(ft)
+	if ft.Type.Kind() != reflect.Slice && !isArray {
 		return fmt.Errorf("parent type %T, field name %s is type %s, must be a slice", parentStruct, fieldName, ft.Type)
 	}
 	et := ft.Type.Elem()
@@ -323,12 +402,99 @@ func InsertIntoSliceStructField(parentStruct interface{}, fieldName string, fiel
 		return fmt.Errorf("cannot assign value %v (type %T) to struct field %s (type %v) in struct %T", fieldValue, fieldValue, fieldName, et, parentStruct)
 	}
 
+	if isArray {
+		return appendToFixedArray(pv.Elem(), ft, n)
+	}
+
 	nl := reflect.Append(pv.Elem().FieldByName(fieldName), n)
 	pv.Elem().FieldByName(fieldName).Set(nl)
 
 	return nil
 }
 
+// FixedArrayElems returns the populated elements of the field sf of the struct
+// value sv as a slice, where sf is a leaf-list that is represented as a
+// fixed-size array. The number of populated elements is read from the field of
+// sv named by the ygotArrayLen tag of sf. A nil slice is returned if no
+// elements are populated.
+func FixedArrayElems(sv reflect.Value, sf reflect.StructField) (reflect.Value, error) {
+	n, err := fixedArrayLen(sv, sf)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	st := reflect.SliceOf(sf.Type.Elem())
+	if n == 0 {
+		return reflect.Zero(st), nil
+	}
+	elems := reflect.MakeSlice(st, n, n)
+	reflect.Copy(elems, sv.FieldByIndex(sf.Index))
+	return elems, nil
+}
+
+// fixedArrayLen returns the number of populated elements of the field sf of
+// the struct value sv, where sf is a leaf-list that is represented as a
+// fixed-size array. It returns an error if sf is not such a field, or the
+// number of populated elements is not within the bounds of the array.
+func fixedArrayLen(sv reflect.Value, sf reflect.StructField) (int, error) {
+	lenName, ok := YgotArrayLenField(sf)
+	if !ok || sf.Type.Kind() != reflect.Array {
+		return 0, fmt.Errorf("field %s is not a fixed-size array", sf.Name)
+	}
+	lv := sv.FieldByName(lenName)
+	if !lv.IsValid() {
+		return 0, fmt.Errorf("length field %s of field %s does not exist", lenName, sf.Name)
+	}
+	var n int64
+	switch lv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = lv.Int()
+	default:
+		return 0, fmt.Errorf("length field %s of field %s has type %v, must be an integer", lenName, sf.Name, lv.Type())
+	}
+	if n < 0 || n > int64(sf.Type.Len()) {
+		return 0, fmt.Errorf("length %d of field %s is not within the bounds of its array of %d elements", n, sf.Name, sf.Type.Len())
+	}
+	return int(n), nil
+}
+
+// appendToFixedArray sets the first unpopulated element of the field sf of
+// the struct value sv to v, and increments the number of populated elements.
+// sf must be a leaf-list that is represented as a fixed-size array, and sv
+// must be addressable. It returns an error if the array is full.
+func appendToFixedArray(sv reflect.Value, sf reflect.StructField, v reflect.Value) error {
+	n, err := fixedArrayLen(sv, sf)
+	if err != nil {
+		return err
+	}
+	if n == sf.Type.Len() {
+		return fmt.Errorf("cannot append to field %s, all %d elements are populated", sf.Name, n)
+	}
+	sv.FieldByIndex(sf.Index).Index(n).Set(v)
+	lenName, _ := YgotArrayLenField(sf)
+	sv.FieldByName(lenName).SetInt(int64(n + 1))
+	return nil
+}
+
+// SetFixedArrayElems sets the populated elements of the field sf of the struct
+// value sv to those of the slice elems, and sets the remaining elements to
+// their zero value. sf must be a leaf-list that is represented as a fixed-size
+// array, and sv must be addressable. It returns an error if elems has more
+// elements than the array.
+func SetFixedArrayElems(sv reflect.Value, sf reflect.StructField, elems reflect.Value) error {
+	if _, err := fixedArrayLen(sv, sf); err != nil {
+		return err
+	}
+	if elems.Len() > sf.Type.Len() {
+		return fmt.Errorf("cannot set %d elements of field %s, which has %d elements", elems.Len(), sf.Name, sf.Type.Len())
+	}
+	fv := sv.FieldByIndex(sf.Index)
+	fv.Set(reflect.Zero(sf.Type))
+	reflect.Copy(fv, elems)
+	lenName, _ := YgotArrayLenField(sf)
+	sv.FieldByName(lenName).SetInt(int64(elems.Len()))
+	return nil
+}
+
 // InsertIntoMapStructField inserts fieldValue into a field of type map in
 // parentStruct called fieldName (which must exist, but may be nil), using the
 // given key. If the key already exists in the map, the corresponding value is
@@ -644,9 +810,9 @@ func forEachFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction FieldI
 		return nil
 	}
 
-	// If the field is an annotation, then we do not process it any further, including
-	// skipping running the iterFunction.
-	if IsYgotAnnotation(ni.StructField) {
+	// If the field is an annotation or embedded metadata, then we do not process
+	// it any further, including skipping running the iterFunction.
+	if IsYgotAnnotation(ni.StructField) || IsYgotMetadata(ni.StructField) || IsYgotArrayLen(ni.StructField) {
 		return nil
 	}
 
@@ -667,6 +833,43 @@ func forEachFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction FieldI
 	t := v.Type()
 
 	switch {
+	case IsTypeOrderedList(t):
+		// Ordered lists are handled in the same manner as keyed lists that
+		// are stored in maps, with their members traversed in order.
+		schema := *(ni.Schema)
+		schema.ListAttr = nil
+		if IsNilOrInvalidValue(v) {
+			nn := &NodeInfo{
+				Parent:         ni,
+				PathFromParent: []string{schema.Name},
+				Schema:         &schema,
+				FieldValue:     reflect.Zero(OrderedListMemberType(t)),
+			}
+			switch in.(type) {
+			case *PathQueryNodeMemo: // Memoization of path queries requested.
+				errs = AppendErrs(errs, forEachFieldInternal(nn, newPathQueryMemo(), out, iterFunction))
+			default:
+				errs = AppendErrs(errs, forEachFieldInternal(nn, in, out, iterFunction))
+			}
+		} else {
+			keys, members := OrderedListMembers(v)
+			for i, m := range members {
+				nn := *ni
+				nn.Schema = &schema
+				nn.Parent = ni
+				nn.PathFromParent = []string{schema.Name}
+				nn.FieldValue = m
+				nn.FieldKey = keys[i]
+				nn.FieldKeys = keys
+				switch in.(type) {
+				case *PathQueryNodeMemo: // Memoization of path queries requested.
+					errs = AppendErrs(errs, forEachFieldInternal(&nn, newPathQueryMemo(), out, iterFunction))
+				default:
+					errs = AppendErrs(errs, forEachFieldInternal(&nn, in, out, iterFunction))
+				}
+			}
+		}
+
 	case IsTypeStructPtr(t):
 		t = t.Elem()
 		if !IsNilOrInvalidValue(v) {
@@ -677,8 +880,8 @@ func forEachFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction FieldI
 		for i := 0; i < t.NumField(); i++ {
 			sf := t.Field(i)
 
-			// Do not handle annotation fields, since they have no schema.
-			if IsYgotAnnotation(sf) {
+			// Do not handle annotation or metadata fields, since they have no schema.
+			if IsYgotAnnotation(sf) || IsYgotMetadata(sf) || IsYgotArrayLen(sf) {
 				continue
 			}
 
@@ -826,6 +1029,18 @@ func forEachDataFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction Fi
 	// a leaf or leaf-list, which are not recursed into when traversing the
 	// data tree.
 	switch {
+	case IsTypeOrderedList(t):
+		// Handle the case of an ordered list, which is a YANG list whose
+		// members are stored in order, rather than in a map.
+		keys, members := OrderedListMembers(v)
+		for i, m := range members {
+			nn := *ni
+			nn.Parent = ni
+			nn.FieldValue = m
+			nn.FieldKey = keys[i]
+			nn.FieldKeys = keys
+			errs = AppendErrs(errs, forEachDataFieldInternal(&nn, in, out, iterFunction))
+		}
 	case IsTypeStructPtr(t):
 		// A struct pointer in a GoStruct is a pointer to another container within
 		// the YANG, therefore we dereference the pointer and then recurse. If the
@@ -838,6 +1053,10 @@ func forEachDataFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction Fi
 		// Handle non-pointer structs by recursing into each field of the struct.
 		for i := 0; i < t.NumField(); i++ {
 			sf := t.Field(i)
+			// Embedded metadata fields are not part of the data tree.
+			if IsYgotMetadata(sf) || IsYgotArrayLen(sf) {
+				continue
+			}
 			nn := &NodeInfo{
 				Parent:      ni,
 				StructField: sf,
@@ -856,7 +1075,7 @@ func forEachDataFieldInternal(ni *NodeInfo, in, out interface{}, iterFunction Fi
 			// fields.
 			for _, p := range ps {
 				nn.PathFromParent = p
-				if IsTypeSlice(sf.Type) || IsTypeMap(sf.Type) {
+				if IsTypeSlice(sf.Type) || IsTypeMap(sf.Type) || IsTypeOrderedList(sf.Type) {
 					// Since lists can have path compression - where the path contains more
 					// than one element, ensure that the schema path we received is only two
 					// elements long. This protects against compression errors where there are
@@ -939,6 +1158,9 @@ func getNodesInternal(schema *yang.Entry, root interface{}, path *gpb.Path) ([]i
 	DbgPrint("GetNode next path %v, value %v", path.GetElem()[0], ValueStrDebug(root))
 
 	switch {
+	case schema.IsList() && IsTypeOrderedList(reflect.TypeOf(root)):
+		// A list schema with an ordered list as the root.
+		return getNodesList(schema, root, path)
 	case schema.IsContainer() || (schema.IsList() && IsTypeStructPtr(reflect.TypeOf(root))):
 		// Either a container or list schema with struct data node (which could
 		// be an element of a list).
@@ -968,8 +1190,8 @@ func getNodesContainer(schema *yang.Entry, root interface{}, path *gpb.Path) ([]
 		f := v.Field(i)
 		ft := v.Type().Field(i)
 
-		// Skip annotation fields, since they do not have a schema.
-		if IsYgotAnnotation(ft) {
+		// Skip annotation and metadata fields, since they do not have a schema.
+		if IsYgotAnnotation(ft) || IsYgotMetadata(ft) || IsYgotArrayLen(ft) {
 			continue
 		}
 
@@ -991,7 +1213,7 @@ func getNodesContainer(schema *yang.Entry, root interface{}, path *gpb.Path) ([]
 				// don't trim whole prefix  for keyed list since name and key
 				// are a in the same element.
 				to := len(p)
-				if IsTypeMap(ft.Type) {
+				if IsTypeMap(ft.Type) || IsTypeOrderedList(ft.Type) {
 					to--
 				}
 				return getNodesInternal(cschema, f.Interface(), TrimGNMIPathPrefix(path, p[0:to]))
@@ -1003,9 +1225,10 @@ func getNodesContainer(schema *yang.Entry, root interface{}, path *gpb.Path) ([]
 }
 
 // getNodesList traverses the list root, which must be a map of struct
-// type and matches each map key against the keys specified in the first
-// PathElem of the Path. If the key matches, it recurses into that field with
-// the remaining path. If empty key is specified, all list elements match.
+// type, or an ordered list, and matches each key against the keys specified
+// in the first PathElem of the Path. If the key matches, it recurses into
+// that field with the remaining path. If empty key is specified, all list
+// elements match.
 func getNodesList(schema *yang.Entry, root interface{}, path *gpb.Path) ([]interface{}, []*yang.Entry, error) {
 	DbgPrint("getNodesList: schema %s, next path %v, value %v", schema.Name, path.GetElem()[0], ValueStrDebug(root))
 
@@ -1013,7 +1236,19 @@ func getNodesList(schema *yang.Entry, root interface{}, path *gpb.Path) ([]inter
 	if schema.Key == "" {
 		return nil, nil, fmt.Errorf("getNodesList: path %v cannot traverse unkeyed list type %T", path, root)
 	}
-	if !IsValueMap(rv) {
+
+	// The members of the list may be stored by value within the map, in
+	// which case each is traversed using a pointer to a copy.
+	var keys, members []reflect.Value
+	switch {
+	case IsValueMap(rv):
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, k)
+			members = append(members, ListMemberPtr(rv.MapIndex(k)))
+		}
+	case IsTypeOrderedList(rv.Type()):
+		keys, members = OrderedListMembers(rv)
+	default:
 		// Only keyed lists can be traversed with a path.
 		return nil, nil, fmt.Errorf("getNodesList: root has type %T, expect map", root)
 	}
@@ -1023,15 +1258,13 @@ func getNodesList(schema *yang.Entry, root interface{}, path *gpb.Path) ([]inter
 		emptyKey = true
 	}
 
-	listElementType := rv.Type().Elem().Elem()
-	listKeyType := rv.Type().Key()
-
 	var matchNodes []interface{}
 	var matchSchemas []*yang.Entry
 
-	// Iterate through all the map keys to see if any match the path.
-	for _, k := range rv.MapKeys() {
-		ev := rv.MapIndex(k)
+	// Iterate through all the keys to see if any match the path.
+	for j, k := range keys {
+		ev := members[j]
+		listElementType, listKeyType := ev.Type().Elem(), k.Type()
 		DbgPrint("checking key %v, value %v", k.Interface(), ValueStrDebug(ev.Interface()))
 		match := true
 		if !emptyKey { // empty key matches everything.
@@ -1127,6 +1360,9 @@ func pathStructTagKey(f reflect.StructField) string {
 func getKeyValue(structVal reflect.Value, key string) (interface{}, error) {
 	for i := 0; i < structVal.NumField(); i++ {
 		f := structVal.Type().Field(i)
+		if IsYgotMetadata(f) || IsYgotArrayLen(f) {
+			continue
+		}
 		p, err := RelativeSchemaPath(f)
 		if err != nil {
 			return nil, err
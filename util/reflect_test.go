@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/testutil"
 
@@ -221,6 +222,28 @@ func TestIsValueFuncs(t *testing.T) {
 	}
 }
 
+func TestListMemberPtr(t *testing.T) {
+	type member struct {
+		Name *string
+	}
+	name := "eth0"
+
+	m := map[string]member{"eth0": {Name: &name}}
+	got := ListMemberPtr(reflect.ValueOf(m).MapIndex(reflect.ValueOf("eth0")))
+	if !IsValueStructPtr(got) {
+		t.Fatalf("ListMemberPtr(struct value): got %v, want struct pointer", got.Type())
+	}
+	if got.Interface().(*member).Name != &name {
+		t.Errorf("ListMemberPtr(struct value): did not get copy of member, got: %v", got.Interface())
+	}
+
+	pm := map[string]*member{"eth0": {Name: &name}}
+	pv := reflect.ValueOf(pm).MapIndex(reflect.ValueOf("eth0"))
+	if got := ListMemberPtr(pv); got.Interface() != pv.Interface() {
+		t.Errorf("ListMemberPtr(struct pointer): got %v, want %v", got.Interface(), pv.Interface())
+	}
+}
+
 func TestValuesAreSameType(t *testing.T) {
 	type EnumType int64
 
@@ -653,6 +676,8 @@ func TestInsertIntoSliceStructField(t *testing.T) {
 		IntPtrSliceField    []*int8
 		InterfaceSliceField []testInterface
 		NonSliceField       int
+		IntArrayField       [2]int `ygotArrayLen:"IntArrayFieldLen"`
+		IntArrayFieldLen    int    `ygotArrayLenOf:"IntArrayField"`
 	}
 
 	tests := []struct {
@@ -739,6 +764,20 @@ func TestInsertIntoSliceStructField(t *testing.T) {
 			fieldValue:   "forty-two",
 			wantErr:      "cannot assign value forty-two (type string) to struct field IntSliceField (type int) in struct *util.BasicStruct",
 		},
+		{
+			desc:         "fixed-size array of int",
+			parentStruct: &BasicStruct{IntArrayField: [2]int{42}, IntArrayFieldLen: 1},
+			fieldName:    "IntArrayField",
+			fieldValue:   43,
+			wantVal:      &BasicStruct{IntArrayField: [2]int{42, 43}, IntArrayFieldLen: 2},
+		},
+		{
+			desc:         "fixed-size array of int, array is full",
+			parentStruct: &BasicStruct{IntArrayField: [2]int{42, 43}, IntArrayFieldLen: 2},
+			fieldName:    "IntArrayField",
+			fieldValue:   44,
+			wantErr:      "cannot append to field IntArrayField, all 2 elements are populated",
+		},
 	}
 
 	for _, tt := range tests {
@@ -755,6 +794,109 @@ func TestInsertIntoSliceStructField(t *testing.T) {
 	}
 }
 
+type fixedArrayTestStruct struct {
+	Array    [3]string `ygotArrayLen:"ArrayLen"`
+	ArrayLen int       `ygotArrayLenOf:"Array"`
+	NoLen    [3]string `ygotArrayLen:"Missing"`
+	BadLen   [3]string `ygotArrayLen:"Str"`
+	Str      string
+}
+
+func TestFixedArrayElems(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      *fixedArrayTestStruct
+		inField string
+		want    []string
+		wantErr string
+	}{{
+		desc:    "populated elements",
+		in:      &fixedArrayTestStruct{Array: [3]string{"a", "b", "unused"}, ArrayLen: 2},
+		inField: "Array",
+		want:    []string{"a", "b"},
+	}, {
+		desc:    "no populated elements",
+		in:      &fixedArrayTestStruct{Array: [3]string{"unused"}},
+		inField: "Array",
+	}, {
+		desc:    "length out of bounds",
+		in:      &fixedArrayTestStruct{ArrayLen: 4},
+		inField: "Array",
+		wantErr: "length 4 of field Array is not within the bounds of its array of 3 elements",
+	}, {
+		desc:    "missing length field",
+		in:      &fixedArrayTestStruct{},
+		inField: "NoLen",
+		wantErr: "length field Missing of field NoLen does not exist",
+	}, {
+		desc:    "non-integer length field",
+		in:      &fixedArrayTestStruct{},
+		inField: "BadLen",
+		wantErr: "length field Str of field BadLen has type string, must be an integer",
+	}, {
+		desc:    "not a fixed-size array",
+		in:      &fixedArrayTestStruct{},
+		inField: "Str",
+		wantErr: "field Str is not a fixed-size array",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			sf, _ := reflect.TypeOf(tt.in).Elem().FieldByName(tt.inField)
+			got, err := FixedArrayElems(reflect.ValueOf(tt.in).Elem(), sf)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("FixedArrayElems: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got.Interface()); diff != "" {
+				t.Errorf("FixedArrayElems: did not get expected elements, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetFixedArrayElems(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      *fixedArrayTestStruct
+		inElems []string
+		want    *fixedArrayTestStruct
+		wantErr string
+	}{{
+		desc:    "set elements",
+		in:      &fixedArrayTestStruct{Array: [3]string{"x", "y", "z"}, ArrayLen: 3},
+		inElems: []string{"a", "b"},
+		want:    &fixedArrayTestStruct{Array: [3]string{"a", "b"}, ArrayLen: 2},
+	}, {
+		desc: "clear elements",
+		in:   &fixedArrayTestStruct{Array: [3]string{"x"}, ArrayLen: 1},
+		want: &fixedArrayTestStruct{},
+	}, {
+		desc:    "too many elements",
+		in:      &fixedArrayTestStruct{},
+		inElems: []string{"a", "b", "c", "d"},
+		wantErr: "cannot set 4 elements of field Array, which has 3 elements",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			sf, _ := reflect.TypeOf(tt.in).Elem().FieldByName("Array")
+			err := SetFixedArrayElems(reflect.ValueOf(tt.in).Elem(), sf, reflect.ValueOf(tt.inElems))
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("SetFixedArrayElems: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.in); diff != "" {
+				t.Errorf("SetFixedArrayElems: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestInsertIntoMapStructField(t *testing.T) {
 	type KeyStruct struct {
 		IntField int
@@ -111,6 +111,20 @@ func IsAnydata(e *yang.Entry) bool {
 	return e.Kind == yang.AnyDataEntry
 }
 
+// IsOperationPayload returns true if the entry is a notification, or the input
+// or output of an rpc or action. Such entries are not data nodes, but are
+// otherwise handled in the same way as containers.
+func IsOperationPayload(e *yang.Entry) bool {
+	if e == nil {
+		return false
+	}
+	switch e.Kind {
+	case yang.NotificationEntry, yang.InputEntry, yang.OutputEntry:
+		return true
+	}
+	return false
+}
+
 // IsLeafRef reports whether schema is a leafref schema node type.
 func IsLeafRef(schema *yang.Entry) bool {
 	if schema == nil || schema.Type == nil {
@@ -150,8 +164,22 @@ func IsUnkeyedList(e *yang.Entry) bool {
 // IsOCCompressedValidElement returns true if the element would be output in the
 // compressed YANG code.
 func IsOCCompressedValidElement(e *yang.Entry) bool {
+	return isOCCompressedValidElement(e, false)
+}
+
+// IsOCCompressedValidElementKeepListContainers returns true if the element
+// would be output in compressed YANG code within which the containers that
+// surround lists are retained.
+func IsOCCompressedValidElementKeepListContainers(e *yang.Entry) bool {
+	return isOCCompressedValidElement(e, true)
+}
+
+// isOCCompressedValidElement returns true if the element would be output in
+// the compressed YANG code. If keepListContainers is set to true, containers
+// that surround lists are considered to be output.
+func isOCCompressedValidElement(e *yang.Entry, keepListContainers bool) bool {
 	switch {
-	case HasOnlyChild(e) && Children(e)[0].IsList():
+	case !keepListContainers && HasOnlyChild(e) && Children(e)[0].IsList():
 		// This is a surrounding container for a list which is removed from the
 		// structure.
 		return false
@@ -188,6 +216,37 @@ func IsYgotAnnotation(s reflect.StructField) bool {
 	return ok
 }
 
+// IsYgotMetadata reports whether struct field s is an embedded metadata
+// field, which does not correspond to a schema node.
+func IsYgotMetadata(s reflect.StructField) bool {
+	_, ok := s.Tag.Lookup("ygotMetadata")
+	return ok
+}
+
+// IsYgotValueLeaf reports whether struct field s is a leaf that is stored as
+// a value rather than a pointer, such as a mandatory leaf or a list key. Such
+// leaves are always considered to be set, regardless of their value.
+func IsYgotValueLeaf(s reflect.StructField) bool {
+	_, ok := s.Tag.Lookup("ygotValueLeaf")
+	return ok
+}
+
+// YgotArrayLenField returns the name of the field that stores the number of
+// populated elements of struct field s, in the case that s is a leaf-list
+// that is represented as a fixed-size array. ok is false if s is not such a
+// field.
+func YgotArrayLenField(s reflect.StructField) (name string, ok bool) {
+	return s.Tag.Lookup("ygotArrayLen")
+}
+
+// IsYgotArrayLen reports whether struct field s stores the number of
+// populated elements of a leaf-list that is represented as a fixed-size
+// array. Such fields do not correspond to a schema node.
+func IsYgotArrayLen(s reflect.StructField) bool {
+	_, ok := s.Tag.Lookup("ygotArrayLenOf")
+	return ok
+}
+
 // IsYangPresence reports whether struct field s is a YANG presence container.
 func IsYangPresence(s reflect.StructField) bool {
 	_, ok := s.Tag.Lookup("yangPresence")
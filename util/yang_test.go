@@ -275,6 +275,65 @@ func TestYangHelperChecks(t *testing.T) {
 // TestYangChildren checks the helper functions from yanghelpers.go that extract
 // the children of a particular YANG directory (container, list) node, along
 // with those that extract only a particular subset of the children.
+func TestIsOCCompressedValidElementKeepListContainers(t *testing.T) {
+	tests := []struct {
+		name    string
+		inEntry *yang.Entry
+		want    bool
+	}{{
+		name: "surrounding container",
+		inEntry: &yang.Entry{
+			Name:   "plural",
+			Parent: &yang.Entry{},
+			Dir: map[string]*yang.Entry{
+				"singular": {
+					Name:     "singular",
+					ListAttr: &yang.ListAttr{},
+					Dir:      map[string]*yang.Entry{},
+				},
+			},
+		},
+		want: true,
+	}, {
+		name: "config container",
+		inEntry: &yang.Entry{
+			Name:   "config",
+			Parent: &yang.Entry{},
+			Dir: map[string]*yang.Entry{
+				"child": {},
+			},
+		},
+		want: false,
+	}, {
+		name: "root entry with only a list child",
+		inEntry: &yang.Entry{
+			Name: "module",
+			Dir: map[string]*yang.Entry{
+				"singular": {
+					Name:     "singular",
+					ListAttr: &yang.ListAttr{},
+					Dir:      map[string]*yang.Entry{},
+				},
+			},
+		},
+		want: false,
+	}, {
+		name: "choice node",
+		inEntry: &yang.Entry{
+			Name:   "choice",
+			Kind:   yang.ChoiceEntry,
+			Parent: &yang.Entry{},
+		},
+		want: false,
+	}}
+
+	for _, tt := range tests {
+		if got := IsOCCompressedValidElementKeepListContainers(tt.inEntry); got != tt.want {
+			t.Errorf("%s: IsOCCompressedValidElementKeepListContainers: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
 func TestYangChildren(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -895,6 +954,60 @@ func TestIsChoiceOrCase(t *testing.T) {
 	}
 }
 
+func TestIsOperationPayload(t *testing.T) {
+	tests := []struct {
+		desc   string
+		schema *yang.Entry
+		want   bool
+	}{
+		{
+			desc:   "nil schema",
+			schema: nil,
+			want:   false,
+		},
+		{
+			desc: "container",
+			schema: &yang.Entry{
+				Kind: yang.DirectoryEntry,
+				Dir:  map[string]*yang.Entry{},
+			},
+			want: false,
+		},
+		{
+			desc: "notification",
+			schema: &yang.Entry{
+				Kind: yang.NotificationEntry,
+				Dir:  map[string]*yang.Entry{},
+			},
+			want: true,
+		},
+		{
+			desc: "input",
+			schema: &yang.Entry{
+				Kind: yang.InputEntry,
+				Dir:  map[string]*yang.Entry{},
+			},
+			want: true,
+		},
+		{
+			desc: "output",
+			schema: &yang.Entry{
+				Kind: yang.OutputEntry,
+				Dir:  map[string]*yang.Entry{},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got, want := IsOperationPayload(tt.schema), tt.want; got != want {
+				t.Errorf("got: %v want: %v", got, want)
+			}
+		})
+	}
+}
+
 func TestIsFakeRoot(t *testing.T) {
 	tests := []struct {
 		desc   string
@@ -1019,6 +1132,92 @@ func TestIsYgotAnnotation(t *testing.T) {
 	}
 }
 
+func TestIsYgotMetadata(t *testing.T) {
+	type testStruct struct {
+		Yes int64 `ygotMetadata:"true"`
+		No  *string
+	}
+
+	tests := []struct {
+		name string
+		in   reflect.StructField
+		want bool
+	}{{
+		name: "metadata field",
+		in:   reflect.TypeOf(testStruct{}).Field(0),
+		want: true,
+	}, {
+		name: "standard field",
+		in:   reflect.TypeOf(testStruct{}).Field(1),
+		want: false,
+	}}
+
+	for _, tt := range tests {
+		if got := IsYgotMetadata(tt.in); got != tt.want {
+			t.Errorf("%s: IsYgotMetadata(%#v): did not get expected result, got: %v, want: %v", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsYgotValueLeaf(t *testing.T) {
+	type testStruct struct {
+		Yes string  `path:"yes" ygotValueLeaf:"true"`
+		No  *string `path:"no"`
+	}
+
+	tests := []struct {
+		name string
+		in   reflect.StructField
+		want bool
+	}{{
+		name: "value leaf field",
+		in:   reflect.TypeOf(testStruct{}).Field(0),
+		want: true,
+	}, {
+		name: "standard field",
+		in:   reflect.TypeOf(testStruct{}).Field(1),
+		want: false,
+	}}
+
+	for _, tt := range tests {
+		if got := IsYgotValueLeaf(tt.in); got != tt.want {
+			t.Errorf("%s: IsYgotValueLeaf(%#v): did not get expected result, got: %v, want: %v", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestYgotArrayLenField(t *testing.T) {
+	type testStruct struct {
+		Arr    [2]string `path:"arr" ygotArrayLen:"ArrLen"`
+		ArrLen int       `ygotArrayLenOf:"Arr"`
+	}
+
+	tests := []struct {
+		name     string
+		in       reflect.StructField
+		wantName string
+		wantOK   bool
+	}{{
+		name:     "fixed-size array field",
+		in:       reflect.TypeOf(testStruct{}).Field(0),
+		wantName: "ArrLen",
+		wantOK:   true,
+	}, {
+		name: "length field",
+		in:   reflect.TypeOf(testStruct{}).Field(1),
+	}}
+
+	for _, tt := range tests {
+		gotName, gotOK := YgotArrayLenField(tt.in)
+		if gotName != tt.wantName || gotOK != tt.wantOK {
+			t.Errorf("%s: YgotArrayLenField(%#v): did not get expected result, got: (%q, %v), want: (%q, %v)", tt.name, tt.in, gotName, gotOK, tt.wantName, tt.wantOK)
+		}
+		if got, want := IsYgotArrayLen(tt.in), !tt.wantOK; got != want {
+			t.Errorf("%s: IsYgotArrayLen(%#v): did not get expected result, got: %v, want: %v", tt.name, tt.in, got, want)
+		}
+	}
+}
+
 func TestIsYangPresence(t *testing.T) {
 	type testStruct struct {
 		Yes *string `yangPresence:"true"`
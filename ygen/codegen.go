@@ -20,6 +20,7 @@ package ygen
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -52,6 +53,13 @@ type GeneratorConfig struct {
 	// the JSON corresponding to the YANG schema parsed to generate the
 	// output code.
 	GenerateJSONSchema bool
+	// GenerateJSONSchemaPerModule specifies that the JSON schema that is
+	// generated when GenerateJSONSchema is set should be split into one
+	// document per YANG module, keyed by module name, rather than being
+	// stored as a single document. The generated schema helpers merge the
+	// per-module documents such that augmented nodes are stitched back into
+	// the tree of the module that they augment.
+	GenerateJSONSchemaPerModule bool
 	// StoreRawSchema the raw JSON schema should be returned by the code
 	// generation function, such that it can be handled by an external
 	// library.
@@ -70,6 +78,79 @@ type GeneratorConfig struct {
 	// IncludeDescriptions specifies that YANG entry descriptions are added
 	// to the JSON schema. Is false by default, to reduce the size of generated schema
 	IncludeDescriptions bool
+	// GenerateOpenAPISchema specifies whether a JSON Schema (draft 2020-12)
+	// document describing the RFC7951 JSON encoding of the data tree,
+	// including the types, enumerated values, ranges and descriptions of
+	// its leaves, should be returned in the OpenAPISchema field of the
+	// generated code, such that it can be used by API documentation
+	// generators. The document is distinct from the JSON schema that is
+	// generated for use by the ygot libraries.
+	GenerateOpenAPISchema bool
+	// JSONSchemaIndent, if set, is the string used to indent each level of
+	// the JSON schema documents returned in the RawJSONSchema and
+	// RawJSONSchemaPerModule fields of the generated code, such that they
+	// are more readable when stored separately from the generated code. The
+	// schema that is embedded within the generated code is unaffected.
+	JSONSchemaIndent string
+	// NameTransform, if non-nil, is called with the name that is generated
+	// for each struct, field and enumerated type, after any other naming
+	// options have been applied. The name that it returns is used in place
+	// of the proposed name, and is made unique in the same manner as any
+	// other generated name, such that the function need not ensure that
+	// the names it returns are unique. The yangPath supplied is the schema
+	// path of the struct or field being named, or the key used to identify
+	// the enumerated type. Currently only applied to generated Go code.
+	NameTransform func(kind NameKind, yangPath, proposed string) string
+}
+
+// NameKind specifies the kind of identifier that is being named when a
+// NameTransform function is called.
+type NameKind int64
+
+const (
+	// UnknownNameKind indicates that the kind of identifier is unknown.
+	UnknownNameKind NameKind = iota
+	// StructNameKind indicates that the name is that of a struct generated
+	// for a YANG container or list.
+	StructNameKind
+	// FieldNameKind indicates that the name is that of a field within a
+	// generated struct.
+	FieldNameKind
+	// EnumNameKind indicates that the name is that of an enumerated type
+	// generated for a YANG enumeration, identity or enumerated typedef.
+	EnumNameKind
+)
+
+// EnumDedupScope specifies the scope within which enumerated leaves that
+// are defined once but instantiated multiple times in the schema (e.g.,
+// through a grouping) share a common generated type.
+type EnumDedupScope int64
+
+const (
+	// GlobalEnumDedup indicates that all instantiations of an enumerated
+	// leaf share a single type, regardless of the module in which they are
+	// instantiated.
+	GlobalEnumDedup EnumDedupScope = iota
+	// PerModuleEnumDedup indicates that instantiations of an enumerated leaf
+	// share a single type only within the module in which they are
+	// instantiated, such that a grouping used by two modules results in
+	// one type per module.
+	PerModuleEnumDedup
+	// NoEnumDedup indicates that every instantiation of an enumerated leaf
+	// has its own type.
+	NoEnumDedup
+)
+
+// skipEnumDedup returns true if de-duplication of enumerated leaves is
+// disabled by the parse options.
+func (p ParseOpts) skipEnumDedup() bool {
+	return p.SkipEnumDeduplication || p.EnumDedupScope == NoEnumDedup
+}
+
+// perModuleEnumDedup returns true if de-duplication of enumerated leaves
+// is to be scoped to the module in which each leaf is instantiated.
+func (p ParseOpts) perModuleEnumDedup() bool {
+	return !p.skipEnumDedup() && p.EnumDedupScope == PerModuleEnumDedup
 }
 
 // DirectoryGenConfig contains the configuration necessary to generate a set of
@@ -127,6 +208,31 @@ type ParseOpts struct {
 	// When it is disabled, two different enumerations (ModuleName_(State|Config)_Enabled)
 	// will be output in the generated code.
 	SkipEnumDeduplication bool
+	// EnumDedupScope specifies the scope within which identical enumerated
+	// leaves are de-duplicated into a single generated type. The default
+	// (GlobalEnumDedup) de-duplicates across the entire schema. Setting
+	// SkipEnumDeduplication to true is equivalent to NoEnumDedup.
+	EnumDedupScope EnumDedupScope
+	// IgnoreDeviations specifies whether deviation statements within the
+	// input YANG modules should be ignored. By default (false), deviations
+	// are applied to the schema prior to code generation, such that nodes
+	// marked as "deviate not-supported" are not output, and deviated
+	// defaults and types are reflected in the generated code.
+	IgnoreDeviations bool
+	// IncludeNotificationNodes specifies whether the contents of
+	// notifications, and the input and output of rpcs and actions, should
+	// be mapped to entities within the generated code, such that payloads
+	// for these operations can be constructed. By default (false), such
+	// nodes are skipped.
+	IncludeNotificationNodes bool
+	// IncludeOnlyPaths specifies a set of schema paths (e.g., /parent/child)
+	// to which code generation should be restricted. When set, only the
+	// ancestors and descendants of the specified paths are mapped to
+	// entities within the generated code. Leafrefs that reference leaves
+	// outside of the included set are mapped to the type of their target,
+	// unless the target is an enumerated leaf, in which case an error is
+	// returned, since no type is generated for the enumeration.
+	IncludeOnlyPaths []string
 }
 
 // TransformationOpts specifies transformations to the generated code with
@@ -165,8 +271,59 @@ type TransformationOpts struct {
 	// EnumerationsUseUnderscores specifies whether enumeration names
 	// should use underscores between path segments.
 	EnumerationsUseUnderscores bool
+	// TypeNameAbbreviations is a map, keyed by the CamelCase name of a
+	// YANG schema path element (e.g., "Interface"), of the abbreviation
+	// that should be used for that element (e.g., "Intf") within the names
+	// of generated structs and union types. Names are made unique after
+	// the abbreviations are applied, such that where an abbreviation causes
+	// two generated names to collide, the collision is resolved
+	// deterministically in the same way as any other naming conflict. The
+	// names of enumerated types, which are derived from the schema by
+	// separate rules, are not abbreviated. Currently only applied to
+	// generated Go code.
+	TypeNameAbbreviations map[string]string
+	// IdentifierSanitizer, if non-nil, is called with the name of each
+	// YANG schema element that is used to generate the name of a Go
+	// struct or field, or a protobuf message, field or package. The
+	// returned name is used in place of the YANG name prior to it being
+	// converted to the generated language's naming conventions and made
+	// unique. It allows a transliteration to be defined for YANG
+	// identifiers that contain non-ASCII characters.
+	IdentifierSanitizer func(string) string
+	// ErrorOnCompressionCollision specifies that, when compression is
+	// enabled, an error listing the original schema paths should be
+	// returned where two distinct directories in the schema have the same
+	// compressed path, rather than their generated names being silently
+	// made unique. For example, a container that exists under both the
+	// "config" and "state" containers of its parent.
+	ErrorOnCompressionCollision bool
+	// TrackChoiceMembership specifies that the choice and case statements
+	// within which each field is defined in the YANG schema should be
+	// recorded, such that tooling can enforce that at most one case of a
+	// choice is populated. In generated Go code the membership is output
+	// as a choice struct tag containing the choice and case names
+	// separated by "/" (e.g., `choice:"addr-type/ipv4"`), which is ignored
+	// when rendering the structs.
+	TrackChoiceMembership bool
+	// GenerateRootHelpersOnly specifies that, rather than a fake root
+	// struct, package-level helper functions that operate over a set of
+	// instances of the entities at the root of the schema tree are
+	// generated - such as EmitAllJSON, which outputs them as a single JSON
+	// document. It is only valid when GenerateFakeRoot is false. Currently
+	// only applied to generated Go code.
+	GenerateRootHelpersOnly bool
+	// ListKeyStructSuffix specifies the suffix that is appended to the name
+	// of a list's struct to form the name of the struct that is generated
+	// to represent the key of a list with multiple keys. If unset,
+	// DefaultListKeyStructSuffix is used. Currently only applied to
+	// generated Go code.
+	ListKeyStructSuffix string
 }
 
+// DefaultListKeyStructSuffix is the default suffix that is appended to the
+// name of a multi-keyed list's struct to form the name of its key struct.
+const DefaultListKeyStructSuffix = "_Key"
+
 // GoOpts stores Go specific options for the code generation library.
 type GoOpts struct {
 	// SchemaVarName is the name for the variable which stores the compressed
@@ -183,6 +340,10 @@ type GoOpts struct {
 	// YtypesImportPath specifies the path to ytypes library that should be used
 	// in the generated code.
 	YtypesImportPath string
+	// ProtomapImportPath specifies the path to the protomap library that
+	// should be used in the generated code. It is used only when
+	// GenerateProtoBridge is set.
+	ProtomapImportPath string
 	// GenerateRenameMethod specifies whether methods for renaming list entries
 	// should be generated in the output Go code.
 	GenerateRenameMethod bool
@@ -194,6 +355,14 @@ type GoOpts struct {
 	// AnnotationPrefix specifies the string which is prefixed to the name of
 	// annotation fields. It defaults to Λ.
 	AnnotationPrefix string
+	// AnnotationFieldType specifies the Go type of the annotation fields
+	// that are generated when AddAnnotationFields is set. It defaults to
+	// []ygot.Annotation. A user-provided type must implement the
+	// ygot.AnnotationContainer interface, such that the annotations that it
+	// stores can be rendered. The type name is output verbatim, and hence
+	// must be defined within, or otherwise be resolvable from, the
+	// generated package.
+	AnnotationFieldType string
 	// AddYangPresence specifies whether tags should be added to the generated
 	// fields of a struct. When set to true, a struct tag will be added to the field
 	// when a YANG container is a presence container
@@ -201,6 +370,13 @@ type GoOpts struct {
 	// a field tag of `yangPresence="true"` will only be added if the container is
 	// a YANG presence container, and will be omitted if this is not the case.
 	AddYangPresence bool
+	// AddJSONTags specifies whether a json struct tag should be added to
+	// each data field of the generated structs, in addition to the path and
+	// module tags. The name within the tag follows RFC7951, such that it is
+	// qualified with the name of the module defining the field when it
+	// differs from the module of the struct. This allows the generated
+	// structs to be marshalled using the encoding/json library.
+	AddJSONTags bool
 	// GenerateGetters specifies whether GetOrCreate* methods should be created
 	// for struct pointer (YANG container) and map (YANG list) fields of generated
 	// structs.
@@ -245,6 +421,332 @@ type GoOpts struct {
 	// only applies when useDefiningModuleForTypedefEnumNames is also set
 	// to true.
 	AppendEnumSuffixForSimpleUnionEnums bool
+	// GeneratePathTypeRegistry specifies whether a package-level map,
+	// SchemaPathToType, should be generated. The map is keyed by the
+	// absolute schema path (without module names) of each generated
+	// struct, with values of the reflect.Type of the struct. It allows
+	// callers that receive a schema path (e.g., from a gNMI subscription)
+	// to instantiate the corresponding GoStruct.
+	GeneratePathTypeRegistry bool
+	// DefaultEnumAsZero specifies that, for enumerated types (other than
+	// identityrefs) that have a default value specified in the YANG
+	// schema, the default value should be assigned the numeric value 0
+	// in place of the UNSET value. Since the ygot library treats the zero
+	// value of an enumeration as not being set, this means that a leaf
+	// that is explicitly set to its default value is not included in
+	// rendered output (e.g., JSON or gNMI notifications), and an unset
+	// leaf is indistinguishable from one that is set to the default.
+	DefaultEnumAsZero bool
+	// GenerateInterfaceChecks specifies whether compile-time assertions
+	// that each generated struct implements the ygot.GoStruct interface
+	// should be generated. Where the GenerateJSONSchema option is set,
+	// the struct is also asserted to implement ygot.ValidatedGoStruct.
+	GenerateInterfaceChecks bool
+	// EmbedMetadataType specifies the name of a user-provided type which
+	// is embedded as a field named ΛMetadata within every generated
+	// struct, allowing callers to attach per-node bookkeeping (e.g., the
+	// time of the last change to the node) to the generated structs. The
+	// type name is output verbatim, and hence must be defined within, or
+	// otherwise be resolvable from, the generated package. The field is
+	// not a schema node, and is hence ignored when the struct is rendered,
+	// validated or unmarshalled. It cannot be used alongside
+	// AddAnnotationFields where the default AnnotationPrefix is used.
+	EmbedMetadataType string
+	// GenerateEqualMethod specifies whether an Equal method should be
+	// generated for every GoStruct, which compares the struct to another
+	// instance of the same type field-by-field without the use of
+	// reflection, recursing into child containers and lists.
+	GenerateEqualMethod bool
+	// GeneratePoolReset specifies whether a ΛReset method should be
+	// generated for every GoStruct, which clears the struct, and
+	// recursively its child containers and list members, in place without
+	// the use of reflection, such that it can be returned to a sync.Pool
+	// and reused.
+	GeneratePoolReset bool
+	// GenerateChildPaths specifies whether a ΛPopulatedChildren method
+	// should be generated for every GoStruct, which returns the schema
+	// paths, relative to the struct, of its populated children, including
+	// each member of its keyed lists with its keys, without the use of
+	// reflection.
+	GenerateChildPaths bool
+	// NonPointerMandatoryLeaves specifies that scalar leaves that are
+	// marked as mandatory within the YANG schema, along with the keys of
+	// lists, should be output as value types rather than pointers, since
+	// they must always be present within valid data. The ygot library
+	// treats such fields as always being set.
+	NonPointerMandatoryLeaves bool
+	// GenerateSetters specifies whether Set* methods should be created for
+	// leaf fields of a struct. For integer leaves whose values are restricted
+	// by a range within the YANG schema, the generated method returns an
+	// error if the supplied value is outside of the range, such that invalid
+	// values are detected when they are set rather than when the struct is
+	// validated.
+	GenerateSetters bool
+	// GenerateGlobalEnumRegistry specifies whether a map, keyed by the name
+	// of each generated enumerated type, of the ordered set of values of
+	// the type should be generated alongside the ΛEnum map. Unlike ΛEnum,
+	// the values of each type can be enumerated in a stable order without
+	// the caller sorting the keys of the map.
+	GenerateGlobalEnumRegistry bool
+	// GenerateEnumFromString specifies whether a map, keyed by the name of
+	// each generated enumerated type, of the int64 value of each of its
+	// values keyed by the string that represents the value in the YANG
+	// schema should be generated alongside the ΛEnum map, along with an
+	// E_<Name>FromString function for each enumerated type that looks up
+	// its values. Values that are defined by a module, such as identities,
+	// can be looked up with or without the name of the module as a prefix.
+	GenerateEnumFromString bool
+	// GenerateHasMethods specifies whether Has* methods should be created
+	// for each field of a struct, which return whether the field is
+	// populated - i.e., that a pointer field is non-nil, or a leaf-list or
+	// list field is non-empty.
+	GenerateHasMethods bool
+	// GenericUnions specifies whether, for each multi-type union, a type
+	// constraint that is satisfied by the types within the union, and a
+	// generic constructor for values of the union, should be generated,
+	// along with a generic UnionValue wrapper type. The constraint uses
+	// approximation (~) elements where the underlying type of a subtype of
+	// the union is unambiguous, such that values of types defined by the
+	// caller can be stored within the union. The generated code requires
+	// Go 1.18 or later. It can only be used alongside GenerateSimpleUnions.
+	GenericUnions bool
+	// FixedArraysForBoundedLists specifies that leaf-lists whose number of
+	// elements is bounded should be output as a fixed-size Go array, along
+	// with a field storing the number of populated elements, rather than
+	// as a slice. Leaf-lists are considered to be bounded where their
+	// min-elements and max-elements are equal, or where their max-elements
+	// does not exceed FixedArrayMaxElements. Leaf-lists of union or binary
+	// type are always output as slices.
+	FixedArraysForBoundedLists bool
+	// FixedArrayMaxElements specifies the largest max-elements value of a
+	// leaf-list with differing min-elements and max-elements values that is
+	// output as a fixed-size array when FixedArraysForBoundedLists is set.
+	FixedArrayMaxElements uint64
+	// CustomTypeMap maps the names of YANG typedefs, qualified by the name
+	// of the module that defines them (e.g., ietf-inet-types:ipv4-address),
+	// to the Go type that leaves of the typedef should be output as. Each
+	// Go type is specified as the import path of its package, followed by a
+	// dot and the name of the type (e.g., net/netip.Addr), where the last
+	// element of the import path must be the package name. The import path
+	// is omitted for built-in types and types defined within the generated
+	// package. Only leaves that directly reference a typedef are mapped,
+	// custom types cannot be used within unions that have other subtypes,
+	// and YANG default values are not output for leaves of custom types.
+	// The ygot and ytypes libraries do not handle custom types, such that
+	// marshalling and validating them is the responsibility of the user.
+	CustomTypeMap map[string]string
+	// BinaryTypeName specifies a Go type, qualified by the name of its
+	// package (e.g., keys.Key), that is used for YANG binary leaves in place
+	// of the []byte type that is generated by default. The generated type
+	// is output as an alias of the specified type. The type must be defined
+	// as a []byte, and must implement ygot.Base64Marshaler, which is used
+	// when the leaves are marshalled to JSON. Binary subtypes of unions are
+	// not supported when GenerateSimpleUnions is set, since methods cannot
+	// be defined on a type from another package.
+	BinaryTypeName string
+	// BinaryTypeImport specifies the import path of the package that
+	// defines the type named by BinaryTypeName. It is omitted if the type
+	// is defined within the generated package.
+	BinaryTypeImport string
+	// ValidateWithContext specifies whether a ΛValidateContext method, which
+	// accepts a context.Context that is checked as the data tree is traversed
+	// such that validation can be cancelled, should be generated for each
+	// GoStruct. It has no effect unless the schema is generated.
+	ValidateWithContext bool
+	// GenerateListKeyConstants specifies whether a variable named
+	// <StructName>ListKeys, containing the YANG names of the keys of the list
+	// in the order in which they are declared in the schema, should be
+	// generated for each GoStruct that represents a keyed YANG list. It
+	// cannot be used alongside a ListKeyStructSuffix of ListKeys, since the
+	// names of the key structs would conflict with those of the variables.
+	GenerateListKeyConstants bool
+	// GenerateKeyStructHelpers specifies whether String and Fields methods
+	// should be generated for each struct that is used as the key of a
+	// multi-keyed list. String formats the key as it is within a gNMI path,
+	// and Fields returns the key values keyed by their YANG names.
+	GenerateKeyStructHelpers bool
+	// FileHeaderText is text, such as a licence, that is output as a
+	// comment at the top of each generated Go file, before the package
+	// clause. Each line of the text is prefixed with the "//" comment
+	// marker.
+	FileHeaderText string
+	// BuildTags is the set of build tags that must be satisfied for the
+	// generated Go files to be compiled. Each tag may be negated by
+	// prefixing it with "!". Where tags are specified, both the //go:build
+	// and // +build forms of the build constraint are output before the
+	// package clause of each generated file.
+	BuildTags []string
+	// GenerateUnionAccessors specifies whether, for each leaf of a
+	// multi-type union, a method should be generated for each type within
+	// the union that returns the value of the leaf as that type, along with
+	// whether the union holds a value of the type. The methods are named
+	// by suffixing the name of the field with the name of the type, e.g.,
+	// FooString and FooUint64, such that values can be extracted from the
+	// union without a type switch.
+	GenerateUnionAccessors bool
+	// GenerateValidationHooks specifies whether a ΛValidateFieldHooks
+	// field should be generated within each struct. The field is a map,
+	// keyed by the name of a field of the struct, of functions that are
+	// called with the value of the field when the struct is validated,
+	// such that custom validation can be supplied at runtime. Errors
+	// returned by the functions are returned as validation errors.
+	GenerateValidationHooks bool
+	// GenerateOrderedListSupport specifies whether keyed lists that are
+	// "ordered-by user" should be represented by a generated ordered map
+	// type, rather than a Go map, such that the order in which members are
+	// appended to the list is retained. The ordered map type stores the
+	// keys of the list in a slice, along with a map of the list's members,
+	// and implements the ygot.GoOrderedList interface. Ordered lists are
+	// emitted in order by EmitJSON and rendered to gNMI notifications, are
+	// validated and unmarshalled by ytypes, and are handled by Diff,
+	// DeepCopy and MergeStructs. Unmarshal appends members in the order of
+	// the JSON array, and returns an error for a member whose key is
+	// already present in the list. Ordered lists are not supported by the
+	// GetNode and SetNode functions of ytypes, nor by MergeNotification,
+	// and methods to rename their members are not generated.
+	GenerateOrderedListSupport bool
+	// RuntimeCompatVersion specifies the release of the ygot runtime
+	// libraries that the generated code must be compatible with, such that
+	// only the methods that are understood by that release are generated.
+	// The supported values are "v0.12" and "v0.13". When unset, code is
+	// generated for the current release.
+	RuntimeCompatVersion string
+	// EmptyLeafAsBool specifies whether leaves of the YANG empty type
+	// should be generated as *bool fields, rather than as fields of the
+	// generated YANGEmpty type. YANGEmpty fields are output as [null] in
+	// RFC7951 JSON, as described in RFC7951 section 6.9, whereas *bool
+	// fields are output as JSON booleans.
+	EmptyLeafAsBool bool
+	// TrackFieldPresence specifies whether each generated struct should
+	// record which of its leaves have been set using their setter methods,
+	// such that a leaf that has been explicitly set to its default value can
+	// be distinguished from one that has not been set. Each struct has a
+	// ΛPresent field, which is updated by the setters, and a WasSet method
+	// that queries it. GenerateSetters must be set.
+	TrackFieldPresence bool
+	// GenerateSubtreeValidate specifies whether a ΛValidateAt method, which
+	// validates only the subtree of the GoStruct found at the supplied gNMI
+	// path, should be generated for each GoStruct. It has no effect unless
+	// the schema is generated.
+	GenerateSubtreeValidate bool
+	// GenerateCBORMethods specifies whether ΛMarshalCBOR and ΛUnmarshalCBOR
+	// methods should be generated for the fake root, which encode and
+	// decode the data tree to and from CBOR using a caller-supplied
+	// ygot.CBORCodec. The CBOR data item has the structure of the RFC7951
+	// JSON representation of the data tree, and hence is not RFC9254
+	// YANG-CBOR. It has no effect unless the fake root and the schema are
+	// generated.
+	GenerateCBORMethods bool
+	// GenerateRootGetByPath specifies whether a GetByPath method, which
+	// returns the value found at a supplied gNMI path within the data tree,
+	// should be generated for the fake root. The value returned is either
+	// that of a leaf, or the struct representing a container or list
+	// member. It has no effect unless the fake root and the schema are
+	// generated.
+	GenerateRootGetByPath bool
+	// GenerateProtoBridge specifies whether ToProto and FromProto methods
+	// should be generated for the fake root, which map the data tree to
+	// and from the protobuf messages that the proto generator produces for
+	// the same YANG schema, such that the Go structs can be exchanged with
+	// peers that use the protobuf wire format. The protobuf message must be
+	// the one generated for the root of the schema. Only containers, keyed
+	// lists, and leaves of string, integer, boolean, binary and enumerated
+	// types are mapped; the methods return an error if a leaf-list or a
+	// decimal64 leaf is populated, or if the protobuf was generated with
+	// UseProtoMaps and one of its map fields is populated. It has no effect
+	// unless the fake root and the schema are generated.
+	GenerateProtoBridge bool
+	// SeparateEnumFile specifies whether the enumerated type definitions,
+	// the ΛEnum map, and the ΛEnumTypes map should be returned as a
+	// separate Go source file, in the EnumFile field of GeneratedGoCode,
+	// such that changes to the generated structs do not require the
+	// enumerated types to be recompiled, and vice versa.
+	SeparateEnumFile bool
+	// IncludeConstraintComments specifies whether the must, when, range,
+	// length and pattern statements that apply to each leaf and leaf-list
+	// should be output as a comment above its field within the generated
+	// struct, such that the constraints can be understood without
+	// consulting the YANG schema.
+	IncludeConstraintComments bool
+	// MarkDeprecated specifies whether a "Deprecated:" paragraph should be
+	// output within the comment of each generated struct and field that
+	// corresponds to a YANG node with a status of deprecated or obsolete,
+	// such that linters and IDEs warn about their use.
+	MarkDeprecated bool
+	// ValueEntryLists specifies whether keyed lists whose members contain
+	// only leaves should be stored in a map of struct values, rather than a
+	// map of struct pointers, reducing the memory used by large lists.
+	// Since pointers to the members of such lists cannot be retained, their
+	// New and Get methods return copies of the members, and GetOrCreate and
+	// Rename methods are not generated. The leaves of the members are
+	// generated in the same manner as those of other structs, and hence
+	// are pointers unless NonPointerMandatoryLeaves applies to them: the
+	// copies are shallow, and share their leaf values with the members
+	// stored in the list. Lists that are ordered-by user are unaffected
+	// when GenerateOrderedListSupport is set. Such lists are handled by the
+	// ygot and ytypes libraries, including by Unmarshal and the
+	// GetNode and SetNode functions, which store modified members in the
+	// list again. The nodes returned by GetNode within such lists are
+	// copies, which must be stored using SetNode once modified.
+	ValueEntryLists bool
+	// GenerateDefaultConstants specifies whether a package-level variable
+	// named <StructName>_<FieldName>Default, containing the default value
+	// specified in the YANG schema, should be generated for each leaf and
+	// leaf-list field that has a default. The variable has the type of the
+	// field, such that the default of an enumerated leaf is the generated
+	// enumeration constant, and that of a leaf-list is a slice.
+	GenerateDefaultConstants bool
+	// GenerateLeafrefValidation specifies whether the ΛValidate method of
+	// the fake root should check that each value of each leafref within the
+	// data tree exists at the leafref's target using generated code that
+	// navigates the tree, rather than the reflection-based leafref
+	// validation of ytypes. A method returning the set of values of each
+	// leafref target is generated for the fake root. The predicates of
+	// leafref paths are not evaluated, such that a value is considered to
+	// exist if any instance of the target leaf has the same value. Leafrefs
+	// whose target is not within the generated code, or whose type is a
+	// union where GenerateSimpleUnions is not set, are not checked. It has
+	// no effect unless the fake root and the schema are generated.
+	GenerateLeafrefValidation bool
+	// TypedLeafrefs specifies whether a union leaf whose members are all
+	// leafrefs to the same union leaf should be mapped to the generated
+	// union type of that target, rather than to a separate union type with
+	// the same subtypes. Leafref leaves are always mapped to the generated
+	// type of the leaf that they reference, such that this allows values to
+	// be assigned between such a leaf and its target without conversion.
+	TypedLeafrefs bool
+}
+
+// runtimeCompat describes the set of generated methods that are supported
+// by a particular release of the ygot runtime libraries.
+type runtimeCompat struct {
+	// belongingModule indicates that the ΛBelongingModule method, which is
+	// used to determine the module name used to prefix RFC7951 JSON, is
+	// generated for each struct.
+	belongingModule bool
+}
+
+// runtimeCompatVersions maps the releases of the ygot runtime libraries
+// that generated code can be made compatible with, using
+// GoOpts.RuntimeCompatVersion, to the methods that they support. Releases
+// prior to v0.13 do not support the ΛBelongingModule method.
+var runtimeCompatVersions = map[string]runtimeCompat{
+	"v0.12": {},
+	"v0.13": {belongingModule: true},
+}
+
+// runtimeCompatFor returns the set of methods that are supported by the
+// ygot runtime release version. The empty string corresponds to the
+// current release, which supports all generated methods.
+func runtimeCompatFor(version string) (runtimeCompat, error) {
+	if version == "" {
+		return runtimeCompat{belongingModule: true}, nil
+	}
+	rc, ok := runtimeCompatVersions[version]
+	if !ok {
+		return runtimeCompat{}, fmt.Errorf("unsupported ygot runtime compatibility version %q", version)
+	}
+	return rc, nil
 }
 
 // ProtoOpts stores Protobuf specific options for the code generation library.
@@ -285,6 +787,65 @@ type ProtoOpts struct {
 	// package identifiers are appended to the go_package - such that
 	// the format <base>/<path>/<to>/<package> is used.
 	GoPackageBase string
+	// SingleFileOutput specifies that the generated packages should be
+	// combined into a single package, named for the base package, such
+	// that the protobuf schema can be written to a single file. Child
+	// packages are output as messages within the base package. It is
+	// only valid when NestedMessages is set to true.
+	SingleFileOutput bool
+	// UseProtoMaps specifies that keyed YANG lists that have a single key
+	// of a type that can be used as a protobuf map key (i.e., a string,
+	// integer or boolean) should be output as a protobuf map field, keyed
+	// by the list key, rather than as a repeated key message. Lists with
+	// multiple keys, or keys of other types, continue to be output as
+	// repeated messages.
+	UseProtoMaps bool
+	// WellKnownTypeMap maps the names of YANG typedefs (e.g.,
+	// date-and-time) to the name of the google.protobuf well-known type
+	// that leaves of the typedef should be output as. The supported
+	// well-known types are Timestamp and Duration. Where a well-known type
+	// is used, the generated file imports its definition.
+	WellKnownTypeMap map[string]string
+	// AnnotateModuleInfo specifies whether each generated message should be
+	// preceded by a comment which indicates the name of the YANG module
+	// that defines the schema element that it represents, along with the
+	// most recent revision date of the module.
+	AnnotateModuleInfo bool
+	// UseProto3Optional specifies that leaves of scalar types should be
+	// output as native protobuf scalar fields marked with the proto3
+	// optional keyword, such that field presence is tracked by the
+	// generated code, rather than as ywrapper wrapper messages. Leaf-lists
+	// of scalar types are output as repeated native scalar fields. Leaves
+	// of type decimal64 have no native protobuf equivalent and hence
+	// continue to use the ywrapper Decimal64Value message, and leaves of
+	// type binary are output as optional bytes fields. The generated
+	// protobufs require protoc 3.15 or later.
+	UseProto3Optional bool
+	// PrefixEnumValues specifies that the values of enumerations that are
+	// defined at the root of the enum package should be prefixed with the
+	// name of the enumeration converted to upper snake case (e.g., the
+	// values of FooValue are output as FOO_VALUE_ONE), following the
+	// protobuf style guide, rather than with the upper case form of the
+	// name (e.g., FOOVALUE_ONE). Enumerations within messages are scoped
+	// by their message, and hence are not affected.
+	PrefixEnumValues bool
+	// EmitValidateRules specifies that the range, length and pattern
+	// restrictions of YANG leaves should be output as protoc-gen-validate
+	// (validate.rules) field options, such that they can be enforced by
+	// the code generated by protoc-gen-validate. Constraints are only
+	// output for leaves that are represented by native protobuf scalar
+	// fields, and hence are generally used alongside UseProto3Optional.
+	// Restrictions that consist of more than one range, or more than one
+	// pattern, cannot be represented and are not output.
+	EmitValidateRules bool
+	// NarrowIntegers specifies that YANG integer types that are 32 bits
+	// wide or narrower are output as the 32-bit protobuf sint32 and uint32
+	// types, rather than as sint64 and uint64, where a native protobuf
+	// scalar type is used - such as within list keys and unions. The
+	// ywrapper messages are 64-bit, such that leaves that are output as
+	// wrapper messages, or as the native type that replaces them when
+	// UseProto3Optional is set, are unaffected.
+	NarrowIntegers bool
 }
 
 // NewYANGCodeGenerator returns a new instance of the YANGCodeGenerator
@@ -345,8 +906,38 @@ type GeneratedGoCode struct {
 	// RawJSONSchema stores the JSON document which is serialised and stored in JSONSchemaCode.
 	// It is populated only if the StoreRawSchema YANGCodeGenerator boolean is set to true.
 	RawJSONSchema []byte
+	// RawJSONSchemaPerModule stores the JSON documents, keyed by YANG module
+	// name, which are serialised and stored in JSONSchemaCode. It is
+	// populated, in place of RawJSONSchema, only if the
+	// GenerateJSONSchemaPerModule GeneratorConfig boolean is set to true.
+	RawJSONSchemaPerModule map[string][]byte
+	// OpenAPISchema stores a JSON Schema (draft 2020-12) document describing
+	// the RFC7951 JSON encoding of the data tree. It is populated only if the
+	// GenerateOpenAPISchema GeneratorConfig boolean is set to true.
+	OpenAPISchema []byte
 	// EnumTypeMap is a Go map that allows YANG schemapaths to be mapped to reflect.Type values.
 	EnumTypeMap string
+	// PathTypeMap is a Go map that allows the schema path of each generated
+	// struct to be mapped to its reflect.Type. It is populated only if the
+	// GeneratePathTypeRegistry GoOpts field is set to true.
+	PathTypeMap string
+	// InterfaceChecks contains compile-time assertions that each generated
+	// struct implements the ygot interfaces that it is expected to. It is
+	// populated only if the GenerateInterfaceChecks GoOpts field is set to
+	// true, and should be output after the generated structs.
+	InterfaceChecks string
+	// RootHelpers contains the package-level helper functions that
+	// operate over the set of structs at the root of the schema tree, along
+	// with the ΛRootEntities map that describes them. It is populated only
+	// if the GenerateRootHelpersOnly TransformationOpts field is set to
+	// true.
+	RootHelpers string
+	// EnumFile contains a complete Go source file, within the same package
+	// as the remainder of the generated code, containing the code within
+	// Enums, EnumMap and EnumTypeMap. It is populated only if the
+	// SeparateEnumFile GoOpts field is set to true, in which case the
+	// String method omits the enumerated type code from its output.
+	EnumFile string
 }
 
 // GeneratedProto3 stores a set of generated Protobuf packages.
@@ -355,6 +946,12 @@ type GeneratedProto3 struct {
 	// messages defined within the package. The calling application can write out the defined packages to the
 	// files expected by the protoc tool.
 	Packages map[string]Proto3Package
+	// DependencyGraph stores a map, keyed by the Protobuf package name, of the
+	// generated packages that each generated package imports. Each package that
+	// is within Packages has an entry in the map, such that the calling
+	// application can use it to determine the order in which the packages should
+	// be compiled.
+	DependencyGraph map[string][]string
 }
 
 // Proto3Package stores the code for a generated protobuf3 package.
@@ -365,6 +962,7 @@ type Proto3Package struct {
 	Enums              []string // Enums is a slice of string containing the generated set of enumerations within the package.
 	UsesYwrapperImport bool     // UsesYwrapperImport indicates whether the ywrapper proto package is used within the generated package.
 	UsesYextImport     bool     // UsesYextImport indicates whether the yext proto package is used within the generated package.
+	Imports            []string // Imports is the sorted set of paths of the proto files, other than ywrapper and yext, that are imported by the generated package.
 }
 
 const (
@@ -431,10 +1029,45 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 		NestedDirectories:                   false,
 		AbsoluteMapPaths:                    false,
 		AppendEnumSuffixForSimpleUnionEnums: cg.Config.GoOptions.AppendEnumSuffixForSimpleUnionEnums,
+		NameTransform:                       cg.Config.NameTransform,
 	}
 
 	var codegenErr util.Errors
-	ir, err := GenerateIR(yangFiles, includePaths, NewGoLangMapper(cg.Config.GoOptions.GenerateSimpleUnions), opts)
+	if goOpts := cg.Config.GoOptions; goOpts.GenericUnions && !goOpts.GenerateSimpleUnions {
+		return nil, util.AppendErr(codegenErr, errors.New("generic unions can only be generated alongside simple unions"))
+	}
+	if goOpts := cg.Config.GoOptions; goOpts.EmbedMetadataType != "" && goOpts.AddAnnotationFields && (goOpts.AnnotationPrefix == "" || goOpts.AnnotationPrefix == DefaultAnnotationPrefix) {
+		return nil, util.AppendErr(codegenErr, fmt.Errorf("cannot embed metadata type %s when annotation fields with the default prefix %s are generated", goOpts.EmbedMetadataType, DefaultAnnotationPrefix))
+	}
+
+	if _, err := runtimeCompatFor(cg.Config.GoOptions.RuntimeCompatVersion); err != nil {
+		return nil, util.AppendErr(codegenErr, err)
+	}
+	if cg.Config.TransformationOptions.GenerateRootHelpersOnly && cg.Config.TransformationOptions.GenerateFakeRoot {
+		return nil, util.AppendErr(codegenErr, errors.New("root helpers can only be generated when the fake root is not generated"))
+	}
+	if cg.Config.GoOptions.TrackFieldPresence && !cg.Config.GoOptions.GenerateSetters {
+		return nil, util.AppendErr(codegenErr, fmt.Errorf("tracking field presence requires setters to be generated"))
+	}
+
+	if cg.Config.GoOptions.GenerateListKeyConstants && cg.Config.TransformationOptions.ListKeyStructSuffix == "ListKeys" {
+		return nil, util.AppendErr(codegenErr, errors.New("list key struct suffix ListKeys conflicts with the names of the generated list key constants"))
+	}
+
+	if cg.Config.GoOptions.BinaryTypeImport != "" && cg.Config.GoOptions.BinaryTypeName == "" {
+		return nil, util.AppendErr(codegenErr, errors.New("a binary type import path requires a binary type name"))
+	}
+
+	langMapper := NewGoLangMapper(cg.Config.GoOptions.GenerateSimpleUnions)
+	langMapper.SetTypeNameAbbreviations(cg.Config.TransformationOptions.TypeNameAbbreviations)
+	langMapper.SetIdentifierSanitizer(cg.Config.TransformationOptions.IdentifierSanitizer)
+	langMapper.SetNameTransform(cg.Config.NameTransform)
+	langMapper.SetEmptyLeafAsBool(cg.Config.GoOptions.EmptyLeafAsBool)
+	langMapper.SetTypedLeafrefs(cg.Config.GoOptions.TypedLeafrefs)
+	if err := langMapper.SetCustomTypeMap(cg.Config.GoOptions.CustomTypeMap); err != nil {
+		return nil, util.AppendErr(codegenErr, err)
+	}
+	ir, err := GenerateIR(yangFiles, includePaths, langMapper, opts)
 	if err != nil {
 		return nil, util.AppendErr(codegenErr, err)
 	}
@@ -449,7 +1082,14 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 			rootName = r.Name
 		}
 	}
-	commonHeader, oneoffHeader, err := writeGoHeader(yangFiles, includePaths, cg.Config, rootName, ir.ModelData)
+	customTypeImports := usedCustomTypeImports(ir, langMapper.customTypeImports)
+	if p := cg.Config.GoOptions.BinaryTypeImport; p != "" {
+		if i := sort.SearchStrings(customTypeImports, p); i == len(customTypeImports) || customTypeImports[i] != p {
+			customTypeImports = append(customTypeImports, p)
+			sort.Strings(customTypeImports)
+		}
+	}
+	commonHeader, oneoffHeader, err := writeGoHeader(yangFiles, includePaths, cg.Config, rootName, ir.ModelData, usesUnionSubtype(ir, ygot.InstanceIdentifierTypeName), customTypeImports)
 	if err != nil {
 		return nil, util.AppendErr(codegenErr, err)
 	}
@@ -461,6 +1101,9 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 	// a leafref to a union) then it is output only once in the generated code.
 	generatedUnions := map[string]bool{}
 	enumTypeMap := map[string][]string{}
+	// pathTypeMap stores the name of the struct that is generated for
+	// each schema path, keyed by the schema path.
+	pathTypeMap := map[string]string{}
 	structSnippets := []GoStructCodeSnippet{}
 
 	isBuiltInType := func(fType string) bool {
@@ -479,18 +1122,20 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 			codegenErr = util.AppendErrs(codegenErr, errs)
 			continue
 		}
-		structOut, errs := writeGoStruct(dir, ir.Directories, generatedUnions, opts.TransformationOptions.IgnoreShadowSchemaPaths, cg.Config.GoOptions, cg.Config.GenerateJSONSchema)
+		structOut, errs := writeGoStruct(dir, ir.Directories, generatedUnions, opts.TransformationOptions.IgnoreShadowSchemaPaths, opts.TransformationOptions.ListKeyStructSuffix, cg.Config.GoOptions, cg.Config.GenerateJSONSchema)
 		if errs != nil {
 			codegenErr = util.AppendErrs(codegenErr, errs)
 			continue
 		}
 		structSnippets = append(structSnippets, structOut)
 
-		// Record down all the enum types we encounter in each field.
+		if cg.Config.GoOptions.GeneratePathTypeRegistry {
+			pathTypeMap[dirSchemaPath(dir)] = dir.Name
+		}
 
-		// definedUnionTypes keeps track of which unions we have
-		// already processed to avoid processing the same one twice.
-		definedUnionTypes := map[string]bool{}
+		// Record down all the enum types we encounter in each field. Fields
+		// that share a union type (e.g., a leafref and its target) are each
+		// recorded, since the map is keyed by the schema path of the field.
 		for _, fn := range dir.OrderedFieldNames() {
 			field := dir.Fields[fn]
 
@@ -504,11 +1149,6 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 				usedEnumeratedTypes[field.LangType.NativeType] = true
 				enumTypeMap[schemaPath] = []string{field.LangType.NativeType}
 			case len(field.LangType.UnionTypes) > 1:
-				if definedUnionTypes[field.LangType.NativeType] {
-					continue
-				}
-				definedUnionTypes[field.LangType.NativeType] = true
-
 				for ut := range field.LangType.UnionTypes {
 					if !isBuiltInType(ut) {
 						// non-builtin union types are always enumerated types.
@@ -527,29 +1167,56 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 		}
 	}
 
-	processedEnums, err := genGoEnumeratedTypes(ir.Enums)
+	var enumDefaults map[string]string
+	if cg.Config.GoOptions.DefaultEnumAsZero {
+		enumDefaults = enumDefaultValues(ir)
+	}
+	processedEnums, err := genGoEnumeratedTypes(ir.Enums, enumDefaults)
 	if err != nil {
 		return nil, append(codegenErr, err)
 	}
 
-	genum, err := writeGoEnumeratedTypes(processedEnums, usedEnumeratedTypes)
+	genum, err := writeGoEnumeratedTypes(processedEnums, usedEnumeratedTypes, cg.Config.GoOptions.GenerateGlobalEnumRegistry, cg.Config.GoOptions.GenerateEnumFromString)
 	if err != nil {
 		return nil, append(codegenErr, err)
 	}
 
 	var rawSchema []byte
+	var rawSchemaPerModule map[string][]byte
 	var jsonSchema string
 	var enumTypeMapCode string
 	if cg.Config.GenerateJSONSchema {
 		var err error
-		rawSchema, err = ir.SchemaTree(cg.Config.IncludeDescriptions)
-		if err != nil {
-			codegenErr = util.AppendErr(codegenErr, fmt.Errorf("error marshalling JSON schema: %v", err))
-		}
+		switch {
+		case cg.Config.GenerateJSONSchemaPerModule:
+			rawSchemaPerModule, err = ir.SchemaTreePerModule(cg.Config.IncludeDescriptions)
+			if err != nil {
+				codegenErr = util.AppendErr(codegenErr, fmt.Errorf("error marshalling per-module JSON schema: %v", err))
+			}
 
-		if rawSchema != nil {
-			if jsonSchema, err = writeGoSchema(rawSchema, cg.Config.GoOptions.SchemaVarName); err != nil {
-				codegenErr = util.AppendErr(codegenErr, err)
+			if rawSchemaPerModule != nil {
+				if jsonSchema, err = writeGoSchemaPerModule(rawSchemaPerModule, cg.Config.GoOptions.SchemaVarName); err != nil {
+					codegenErr = util.AppendErr(codegenErr, err)
+				}
+				for m, js := range rawSchemaPerModule {
+					if rawSchemaPerModule[m], err = indentJSONSchema(js, cg.Config.JSONSchemaIndent); err != nil {
+						codegenErr = util.AppendErr(codegenErr, fmt.Errorf("error indenting JSON schema for module %s: %v", m, err))
+					}
+				}
+			}
+		default:
+			rawSchema, err = ir.SchemaTree(cg.Config.IncludeDescriptions)
+			if err != nil {
+				codegenErr = util.AppendErr(codegenErr, fmt.Errorf("error marshalling JSON schema: %v", err))
+			}
+
+			if rawSchema != nil {
+				if jsonSchema, err = writeGoSchema(rawSchema, cg.Config.GoOptions.SchemaVarName); err != nil {
+					codegenErr = util.AppendErr(codegenErr, err)
+				}
+				if rawSchema, err = indentJSONSchema(rawSchema, cg.Config.JSONSchemaIndent); err != nil {
+					codegenErr = util.AppendErr(codegenErr, fmt.Errorf("error indenting JSON schema: %v", err))
+				}
 			}
 		}
 
@@ -558,20 +1225,70 @@ func (cg *YANGCodeGenerator) GenerateGoCode(yangFiles, includePaths []string) (*
 		}
 	}
 
+	var openAPISchema []byte
+	if cg.Config.GenerateOpenAPISchema {
+		var err error
+		if openAPISchema, err = ir.OpenAPISchema(); err != nil {
+			codegenErr = util.AppendErr(codegenErr, fmt.Errorf("error generating OpenAPI schema: %v", err))
+		}
+	}
+
+	var pathTypeMapCode string
+	if cg.Config.GoOptions.GeneratePathTypeRegistry {
+		var err error
+		if pathTypeMapCode, err = generatePathTypeMap(pathTypeMap); err != nil {
+			codegenErr = util.AppendErr(codegenErr, err)
+		}
+	}
+
+	var interfaceChecksCode string
+	if cg.Config.GoOptions.GenerateInterfaceChecks {
+		structNames := make([]string, 0, len(structSnippets))
+		for _, snippet := range structSnippets {
+			structNames = append(structNames, snippet.StructName)
+		}
+		var err error
+		if interfaceChecksCode, err = generateInterfaceChecks(structNames, cg.Config.GenerateJSONSchema); err != nil {
+			codegenErr = util.AppendErr(codegenErr, err)
+		}
+	}
+
+	var rootHelpersCode string
+	if cg.Config.TransformationOptions.GenerateRootHelpersOnly {
+		var err error
+		if rootHelpersCode, err = generateRootHelpers(ir.Directories, cg.Config.TransformationOptions.CompressBehaviour.CompressEnabled()); err != nil {
+			codegenErr = util.AppendErr(codegenErr, err)
+		}
+	}
+
 	// Return any errors that were encountered during code generation.
 	if len(codegenErr) != 0 {
 		return nil, codegenErr
 	}
 
+	var enumFile string
+	if cg.Config.GoOptions.SeparateEnumFile {
+		var err error
+		if enumFile, err = writeGoEnumFile(cg.Config, genum, enumTypeMapCode); err != nil {
+			return nil, append(codegenErr, err)
+		}
+	}
+
 	return &GeneratedGoCode{
-		CommonHeader:   commonHeader,
-		OneOffHeader:   oneoffHeader,
-		Structs:        structSnippets,
-		Enums:          genum.enums,
-		EnumMap:        genum.valMap,
-		JSONSchemaCode: jsonSchema,
-		RawJSONSchema:  rawSchema,
-		EnumTypeMap:    enumTypeMapCode,
+		CommonHeader:           commonHeader,
+		OneOffHeader:           oneoffHeader,
+		Structs:                structSnippets,
+		Enums:                  genum.enums,
+		EnumMap:                genum.valMap,
+		JSONSchemaCode:         jsonSchema,
+		RawJSONSchema:          rawSchema,
+		RawJSONSchemaPerModule: rawSchemaPerModule,
+		OpenAPISchema:          openAPISchema,
+		EnumTypeMap:            enumTypeMapCode,
+		PathTypeMap:            pathTypeMapCode,
+		InterfaceChecks:        interfaceChecksCode,
+		RootHelpers:            rootHelpersCode,
+		EnumFile:               enumFile,
 	}, nil
 }
 
@@ -581,6 +1298,9 @@ type goEnumeratedType struct {
 	Name       string
 	CodeValues map[int64]string
 	YANGValues map[int64]ygot.EnumDefinition
+	// DefaultIsZero indicates that the value 0 of the enumerated type is
+	// the YANG default value, rather than UNSET.
+	DefaultIsZero bool
 }
 
 // enumGeneratedCode contains generated Go code for enumerated types.
@@ -589,11 +1309,73 @@ type enumGeneratedCode struct {
 	valMap string
 }
 
+// enumDefaultValues returns a map, keyed by the key of an enumerated type
+// within the IR's Enums map, of the YANG default value of the enumerated type.
+// An enumerated type is only included if every leaf that uses it has the same
+// default, which is a value of the enumeration, such that the default can be
+// used as the zero value of the type without changing the meaning of any
+// leaf. Identityrefs are never included.
+func enumDefaultValues(ir *IR) map[string]string {
+	defaults := map[string]string{}
+	conflicts := map[string]bool{}
+
+	addDefault := func(key string, field *NodeDetails) {
+		e := ir.Enums[key]
+		if e.Kind == IdentityType {
+			return
+		}
+		var def string
+		if field.Type == LeafNode && len(field.YANGDetails.Defaults) == 1 {
+			for _, v := range e.ValToYANGDetails {
+				if v.Name == field.YANGDetails.Defaults[0] {
+					def = v.Name
+					break
+				}
+			}
+		}
+		if existing, ok := defaults[key]; (ok && existing != def) || def == "" {
+			conflicts[key] = true
+		}
+		defaults[key] = def
+	}
+
+	// Map the generated type names back to the keys of the enumerated types,
+	// such that union subtypes can be resolved.
+	typeKeys := map[string]string{}
+	for key, e := range ir.Enums {
+		typeKeys[fmt.Sprintf("%s%s", goEnumPrefix, e.Name)] = key
+	}
+
+	for _, dir := range ir.Directories {
+		for _, field := range dir.Fields {
+			if field.LangType == nil {
+				continue
+			}
+			if key, ok := typeKeys[field.LangType.NativeType]; ok && field.LangType.IsEnumeratedValue {
+				addDefault(key, field)
+			}
+			for t := range field.LangType.UnionTypes {
+				if key, ok := typeKeys[t]; ok && t != field.LangType.NativeType {
+					addDefault(key, field)
+				}
+			}
+		}
+	}
+
+	for key := range conflicts {
+		delete(defaults, key)
+	}
+	return defaults
+}
+
 // genGoEnumeratedTypes converts the input map of EnumeratedYANGType objects to
-// another intermediate representation suitable for Go code generation.
-func genGoEnumeratedTypes(enums map[string]*EnumeratedYANGType) (map[string]*goEnumeratedType, error) {
+// another intermediate representation suitable for Go code generation. The
+// defaults map, keyed by the same key as enums, specifies the YANG default
+// value of those enumerated types whose default should be assigned the value 0
+// in place of UNSET.
+func genGoEnumeratedTypes(enums map[string]*EnumeratedYANGType, defaults map[string]string) (map[string]*goEnumeratedType, error) {
 	et := map[string]*goEnumeratedType{}
-	for _, e := range enums {
+	for key, e := range enums {
 		// initialised to be UNSET, such that it is possible to determine that the enumerated value
 		// was not modified.
 		values := map[int64]string{
@@ -608,9 +1390,18 @@ func genGoEnumeratedTypes(enums map[string]*EnumeratedYANGType) (map[string]*goE
 		// module within which the identity was defined.
 		origValues := map[int64]ygot.EnumDefinition{}
 
+		var defaultIsZero bool
 		switch e.Kind {
 		case IdentityType, SimpleEnumerationType, DerivedEnumerationType, UnionEnumerationType, DerivedUnionEnumerationType:
 			for i, v := range e.ValToYANGDetails {
+				if def, ok := defaults[key]; ok && v.Name == def {
+					// The default value replaces UNSET, such that the Go zero
+					// value of the enumeration corresponds to the YANG default.
+					values[0] = safeGoEnumeratedValueName(v.Name)
+					origValues[0] = v
+					defaultIsZero = true
+					continue
+				}
 				values[int64(i)+1] = safeGoEnumeratedValueName(v.Name)
 				origValues[int64(i)+1] = v
 			}
@@ -619,17 +1410,21 @@ func genGoEnumeratedTypes(enums map[string]*EnumeratedYANGType) (map[string]*goE
 		}
 
 		et[e.Name] = &goEnumeratedType{
-			Name:       e.Name,
-			CodeValues: values,
-			YANGValues: origValues,
+			Name:          e.Name,
+			CodeValues:    values,
+			YANGValues:    origValues,
+			DefaultIsZero: defaultIsZero,
 		}
 	}
 	return et, nil
 }
 
 // writeGoEnumeratedTypes generates Go code for the input enumerations if they
-// are present in the usedEnums map.
-func writeGoEnumeratedTypes(enums map[string]*goEnumeratedType, usedEnums map[string]bool) (*enumGeneratedCode, error) {
+// are present in the usedEnums map. If genRegistry is set to true, the
+// ΛEnumRegistry map is output alongside the ΛEnum map. If genFromString is set
+// to true, the ΛEnumFromString map is output alongside the ΛEnum map, along
+// with a function for each enumeration that looks up its values by name.
+func writeGoEnumeratedTypes(enums map[string]*goEnumeratedType, usedEnums map[string]bool, genRegistry, genFromString bool) (*enumGeneratedCode, error) {
 	orderedEnumNames := []string{}
 	for _, e := range enums {
 		orderedEnumNames = append(orderedEnumNames, e.Name)
@@ -651,6 +1446,13 @@ func writeGoEnumeratedTypes(enums map[string]*goEnumeratedType, usedEnums map[st
 		if err != nil {
 			return nil, err
 		}
+		if genFromString {
+			fromString, err := writeGoEnumFromString(e)
+			if err != nil {
+				return nil, err
+			}
+			enumOut += fromString
+		}
 		enumSnippets = append(enumSnippets, enumOut)
 		enumValMap[e.Name] = e.YANGValues
 	}
@@ -661,6 +1463,22 @@ func writeGoEnumeratedTypes(enums map[string]*goEnumeratedType, usedEnums map[st
 		return nil, err
 	}
 
+	if genRegistry {
+		reg, err := writeGoEnumRegistry(enumValMap)
+		if err != nil {
+			return nil, err
+		}
+		vmap += reg
+	}
+
+	if genFromString {
+		fromString, err := writeGoEnumFromStringMap(enumValMap)
+		if err != nil {
+			return nil, err
+		}
+		vmap += fromString
+	}
+
 	return &enumGeneratedCode{
 		enums:  enumSnippets,
 		valMap: vmap,
@@ -702,7 +1520,7 @@ func (dcg *DirectoryGenConfig) GetDirectoriesAndLeafTypes(yangFiles, includePath
 		return nil, nil, errs
 	}
 
-	enumSet, _, errs := findEnumSet(mdef.enumEntries, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.ParseOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.AppendEnumSuffixForSimpleUnionEnums, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+	enumSet, _, errs := findEnumSet(mdef.enumEntries, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.ParseOptions.skipEnumDedup(), opts.ParseOptions.perModuleEnumDedup(), opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.AppendEnumSuffixForSimpleUnionEnums, opts.TransformationOptions.EnumOrgPrefixesToTrim)
 	if errs != nil {
 		return nil, nil, errs
 	}
@@ -711,6 +1529,13 @@ func (dcg *DirectoryGenConfig) GetDirectoriesAndLeafTypes(yangFiles, includePath
 	gogen := NewGoLangMapper(cg.GoOptions.GenerateSimpleUnions)
 	gogen.SetEnumSet(enumSet)
 	gogen.SetSchemaTree(mdef.schematree)
+	gogen.SetTypeNameAbbreviations(opts.TransformationOptions.TypeNameAbbreviations)
+	gogen.SetIdentifierSanitizer(opts.TransformationOptions.IdentifierSanitizer)
+	gogen.SetEmptyLeafAsBool(cg.GoOptions.EmptyLeafAsBool)
+	gogen.SetTypedLeafrefs(cg.GoOptions.TypedLeafrefs)
+	if err := gogen.SetCustomTypeMap(cg.GoOptions.CustomTypeMap); err != nil {
+		return nil, nil, util.NewErrs(err)
+	}
 
 	directoryMap, errs := buildDirectoryDefinitions(gogen, mdef.directoryEntries, opts)
 	if errs != nil {
@@ -733,7 +1558,7 @@ func (dcg *DirectoryGenConfig) GetDirectoriesAndLeafTypes(yangFiles, includePath
 		for _, fieldName := range GetOrderedFieldNames(dir) {
 			field := dir.Fields[fieldName]
 			if isLeaf := field.IsLeaf() || field.IsLeafList(); isLeaf {
-				mtype, err := gogen.yangTypeToGoType(resolveTypeArgs{yangType: field.Type, contextEntry: field}, dcg.TransformationOptions.CompressBehaviour.CompressEnabled(), cg.ParseOptions.SkipEnumDeduplication, cg.TransformationOptions.ShortenEnumLeafNames, cg.TransformationOptions.UseDefiningModuleForTypedefEnumNames, cg.TransformationOptions.EnumOrgPrefixesToTrim)
+				mtype, err := gogen.yangTypeToGoType(resolveTypeArgs{yangType: field.Type, contextEntry: field}, dcg.TransformationOptions.CompressBehaviour.CompressEnabled(), cg.ParseOptions.skipEnumDedup(), cg.TransformationOptions.ShortenEnumLeafNames, cg.TransformationOptions.UseDefiningModuleForTypedefEnumNames, cg.TransformationOptions.EnumOrgPrefixesToTrim)
 				if err != nil {
 					errs = util.AppendErr(errs, err)
 					continue
@@ -774,6 +1599,10 @@ func (cg *YANGCodeGenerator) GenerateProto3(yangFiles, includePaths []string) (*
 		yextPath = DefaultYextPath
 	}
 
+	if cg.Config.ProtoOptions.SingleFileOutput && !cg.Config.ProtoOptions.NestedMessages {
+		return nil, util.NewErrs(fmt.Errorf("single file output is only supported when nested messages are generated"))
+	}
+
 	// This flag is always true for proto generation.
 	cg.Config.TransformationOptions.UseDefiningModuleForTypedefEnumNames = true
 	opts := IROptions{
@@ -784,12 +1613,18 @@ func (cg *YANGCodeGenerator) GenerateProto3(yangFiles, includePaths []string) (*
 		AppendEnumSuffixForSimpleUnionEnums: true,
 	}
 
-	ir, err := GenerateIR(yangFiles, includePaths, NewProtoLangMapper(basePackageName, enumPackageName), opts)
+	langMapper := NewProtoLangMapper(basePackageName, enumPackageName)
+	langMapper.SetIdentifierSanitizer(cg.Config.TransformationOptions.IdentifierSanitizer)
+	if err := langMapper.SetWellKnownTypeMap(cg.Config.ProtoOptions.WellKnownTypeMap); err != nil {
+		return nil, util.NewErrs(err)
+	}
+	langMapper.SetNarrowIntegers(cg.Config.ProtoOptions.NarrowIntegers)
+	ir, err := GenerateIR(yangFiles, includePaths, langMapper, opts)
 	if err != nil {
 		return nil, util.NewErrs(err)
 	}
 
-	protoEnums, err := writeProtoEnums(ir.Enums, cg.Config.ProtoOptions.AnnotateEnumNames)
+	protoEnums, err := writeProtoEnums(ir.Enums, cg.Config.ProtoOptions.AnnotateEnumNames, cg.Config.ProtoOptions.PrefixEnumValues)
 	if err != nil {
 		return nil, util.NewErrs(err)
 	}
@@ -831,6 +1666,10 @@ func (cg *YANGCodeGenerator) GenerateProto3(yangFiles, includePaths []string) (*
 			annotateSchemaPaths: cg.Config.ProtoOptions.AnnotateSchemaPaths,
 			annotateEnumNames:   cg.Config.ProtoOptions.AnnotateEnumNames,
 			nestedMessages:      cg.Config.ProtoOptions.NestedMessages,
+			useProtoMaps:        cg.Config.ProtoOptions.UseProtoMaps,
+			annotateModuleInfo:  cg.Config.ProtoOptions.AnnotateModuleInfo,
+			useProto3Optional:   cg.Config.ProtoOptions.UseProto3Optional,
+			emitValidateRules:   cg.Config.ProtoOptions.EmitValidateRules,
 		})
 
 		if errs != nil {
@@ -877,6 +1716,19 @@ func (cg *YANGCodeGenerator) GenerateProto3(yangFiles, includePaths []string) (*
 		genProto.Packages[genMsg.PackageName] = tp
 	}
 
+	if yerr != nil {
+		return nil, yerr
+	}
+
+	if cg.Config.ProtoOptions.SingleFileOutput {
+		pkg, imports, err := combineProto3Packages(genProto.Packages, pkgImports, basePackageName, cg.Config.ProtoOptions.BaseImportPath)
+		if err != nil {
+			return nil, util.NewErrs(err)
+		}
+		genProto.Packages = map[string]Proto3Package{basePackageName: pkg}
+		pkgImports = map[string]map[string]interface{}{basePackageName: imports}
+	}
+
 	for n, pkg := range genProto.Packages {
 		var gpn string
 		if cg.Config.ProtoOptions.GoPackageBase != "" {
@@ -890,9 +1742,11 @@ func (cg *YANGCodeGenerator) GenerateProto3(yangFiles, includePaths []string) (*
 		if !pkg.UsesYextImport {
 			yextPath = ""
 		}
+		imports := stringKeys(pkgImports[n])
+		sort.Strings(imports)
 		h, err := writeProto3Header(proto3Header{
 			PackageName:            n,
-			Imports:                stringKeys(pkgImports[n]),
+			Imports:                imports,
 			SourceYANGFiles:        yangFiles,
 			SourceYANGIncludePaths: includePaths,
 			CompressPaths:          cg.Config.TransformationOptions.CompressBehaviour.CompressEnabled(),
@@ -906,6 +1760,7 @@ func (cg *YANGCodeGenerator) GenerateProto3(yangFiles, includePaths []string) (*
 			continue
 		}
 		pkg.Header = h
+		pkg.Imports = imports
 		genProto.Packages[n] = pkg
 	}
 
@@ -913,15 +1768,41 @@ func (cg *YANGCodeGenerator) GenerateProto3(yangFiles, includePaths []string) (*
 		return nil, yerr
 	}
 
+	genProto.DependencyGraph = protoDependencyGraph(genProto.Packages, cg.Config.ProtoOptions.BaseImportPath)
+
 	return genProto, nil
 }
 
+// protoDependencyGraph returns a map, keyed by package name, of the names of
+// the packages within pkgs that are imported by each package in pkgs. The
+// baseImportPath is the path that is prepended to the file path of each
+// package when it is imported. Imports of packages that were not generated,
+// such as the protobuf well-known types, are not included.
+func protoDependencyGraph(pkgs map[string]Proto3Package, baseImportPath string) map[string][]string {
+	pkgForPath := map[string]string{}
+	for n, pkg := range pkgs {
+		pkgForPath[filepath.Join(append([]string{baseImportPath}, pkg.FilePath...)...)] = n
+	}
+
+	deps := map[string][]string{}
+	for n, pkg := range pkgs {
+		deps[n] = []string{}
+		for _, i := range pkg.Imports {
+			if dep, ok := pkgForPath[i]; ok && dep != n {
+				deps[n] = append(deps[n], dep)
+			}
+		}
+		sort.Strings(deps[n])
+	}
+	return deps
+}
+
 // processModules takes a list of the filenames of YANG modules (yangFiles),
 // and a list of paths in which included modules or submodules may be found,
 // and returns a processed set of yang.Entry pointers which correspond to the
 // generated code for the modules. If errors are returned during the Goyang
 // processing of the modules, these errors are returned.
-func processModules(yangFiles, includePaths []string, options yang.Options) ([]*yang.Entry, util.Errors) {
+func processModules(yangFiles, includePaths []string, options yang.Options, ignoreDeviations bool) ([]*yang.Entry, util.Errors) {
 	// Initialise the set of YANG modules within the Goyang parsing package.
 	moduleSet := yang.NewModules()
 	// Propagate the options for the YANG library through to the parsing
@@ -944,6 +1825,17 @@ func processModules(yangFiles, includePaths []string, options yang.Options) ([]*
 		return nil, errs
 	}
 
+	// Deviations are applied by Goyang when the modules are processed, such
+	// that where they are to be ignored, they must be removed from the parsed
+	// modules beforehand.
+	if ignoreDeviations {
+		for _, ms := range []map[string]*yang.Module{moduleSet.Modules, moduleSet.SubModules} {
+			for _, m := range ms {
+				m.Deviation = nil
+			}
+		}
+	}
+
 	if errs := moduleSet.Process(); errs != nil {
 		return nil, errs
 	}
@@ -1000,7 +1892,7 @@ type mappedYANGDefinitions struct {
 // It returns a mappedYANGDefinitions struct populated with the directory, enum
 // entries in the input schemas as well as the calculated schema tree.
 func mappedDefinitions(yangFiles, includePaths []string, cfg *GeneratorConfig) (*mappedYANGDefinitions, util.Errors) {
-	modules, errs := processModules(yangFiles, includePaths, cfg.ParseOptions.YANGParseOptions)
+	modules, errs := processModules(yangFiles, includePaths, cfg.ParseOptions.YANGParseOptions, cfg.ParseOptions.IgnoreDeviations)
 	if errs != nil {
 		return nil, errs
 	}
@@ -1017,19 +1909,13 @@ func mappedDefinitions(yangFiles, includePaths []string, cfg *GeneratorConfig) (
 	enums := map[string]*yang.Entry{}
 	var rootElems, treeElems []*yang.Entry
 	for _, module := range modules {
-		// Need to transform the AST based on compression behaviour.
-		genutil.TransformEntry(module, cfg.TransformationOptions.CompressBehaviour)
-
-		errs = append(errs, findMappableEntities(module, dirs, enums, cfg.ParseOptions.ExcludeModules, cfg.TransformationOptions.CompressBehaviour.CompressEnabled(), modules)...)
 		if module == nil {
 			errs = append(errs, errors.New("found a nil module in the returned module set"))
 			continue
 		}
-
+		// Need to transform the AST based on compression behaviour.
+		genutil.TransformEntry(module, cfg.TransformationOptions.CompressBehaviour)
 		for _, e := range module.Dir {
-			if !excluded[module.Name] {
-				rootElems = append(rootElems, e)
-			}
 			treeElems = append(treeElems, e)
 		}
 	}
@@ -1038,17 +1924,39 @@ func mappedDefinitions(yangFiles, includePaths []string, cfg *GeneratorConfig) (
 	}
 
 	// Build the schematree for the modules provided - we build for all of the
-	// root elements, since we might need to reference a part of the schema that
-	// we are not outputting for leafref lookups.
+	// root elements, and prior to restricting the schema to the included
+	// paths, since we might need to reference a part of the schema that we
+	// are not outputting for leafref lookups.
 	st, err := buildSchemaTree(treeElems)
 	if err != nil {
 		return nil, []error{err}
 	}
 
+	if len(cfg.ParseOptions.IncludeOnlyPaths) != 0 {
+		if errs := pruneToIncludedPaths(modules, cfg.ParseOptions.IncludeOnlyPaths, st); errs != nil {
+			return nil, errs
+		}
+	}
+
+	for _, module := range modules {
+		errs = append(errs, findMappableEntities(module, dirs, enums, cfg.ParseOptions.ExcludeModules, cfg.TransformationOptions.CompressBehaviour, cfg.ParseOptions.IncludeNotificationNodes, modules)...)
+		if excluded[module.Name] {
+			continue
+		}
+		for _, e := range module.Dir {
+			rootElems = append(rootElems, e)
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
 	// If we were asked to generate a fake root entity, then go and find the top-level entities that
 	// we were asked for.
 	if cfg.TransformationOptions.GenerateFakeRoot {
-		if err := createFakeRoot(dirs, rootElems, cfg.TransformationOptions.FakeRootName, cfg.TransformationOptions.CompressBehaviour.CompressEnabled()); err != nil {
+		// Lists within surrounding containers at the root are only direct
+		// children of the fake root when such containers are compressed out.
+		if err := createFakeRoot(dirs, rootElems, cfg.TransformationOptions.FakeRootName, cfg.TransformationOptions.CompressBehaviour.ListContainersCompressed()); err != nil {
 			return nil, []error{err}
 		}
 	}
@@ -1076,6 +1984,175 @@ func mappedDefinitions(yangFiles, includePaths []string, cfg *GeneratorConfig) (
 	}, nil
 }
 
+// pruneToIncludedPaths removes all entries from the supplied modules that are
+// neither an ancestor nor a descendant of one of the schema paths specified in
+// paths. The schema paths are specified without the module name, and without
+// choice and case nodes (e.g., /parent/child). The supplied schemaTree, which
+// must be built from the unpruned modules, is used to resolve leafrefs within
+// the retained entries - an error is returned if a leafref references an
+// enumerated leaf that is not retained, or if a path does not exist within the
+// schema.
+func pruneToIncludedPaths(modules []*yang.Entry, paths []string, st *schemaTree) util.Errors {
+	var include [][]string
+	for _, p := range paths {
+		include = append(include, strings.Split(util.StripModulePrefixesStr(strings.Trim(p, "/")), "/"))
+	}
+
+	found := map[int]bool{}
+	for _, m := range modules {
+		pruneEntry(m, nil, include, found)
+	}
+
+	var errs util.Errors
+	for i, p := range paths {
+		if !found[i] {
+			errs = append(errs, fmt.Errorf("included path %s does not exist in the schema", p))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	for _, m := range modules {
+		errs = util.AppendErrs(errs, checkIncludedLeafrefs(m, st))
+	}
+	return errs
+}
+
+// pruneEntry removes the children of the yang.Entry e, whose data tree path
+// is specified by path, that are neither an ancestor nor a descendant of one
+// of the paths in include. The indexes of the paths in include that are found
+// within the schema are set to true in the found map.
+func pruneEntry(e *yang.Entry, path []string, include [][]string, found map[int]bool) {
+	for name, ch := range e.Dir {
+		if util.IsChoiceOrCase(ch) {
+			// Choice and case nodes do not appear in the data tree
+			// path, such that they are retained only if one of
+			// their children is.
+			pruneEntry(ch, path, include, found)
+			if len(ch.Dir) == 0 {
+				delete(e.Dir, name)
+			}
+			continue
+		}
+
+		chPath := append(append([]string{}, path...), ch.Name)
+		var descendant, ancestor bool
+		for i, p := range include {
+			switch {
+			case isPathPrefix(p, chPath):
+				descendant = true
+				if len(chPath) == len(p) {
+					found[i] = true
+				}
+			case isPathPrefix(chPath, p):
+				ancestor = true
+			}
+		}
+
+		switch {
+		case descendant:
+			// The entire subtree of the child is retained.
+		case ancestor:
+			pruneEntry(ch, chPath, append(include[:len(include):len(include)], listKeyPaths(ch, chPath)...), found)
+		default:
+			delete(e.Dir, name)
+		}
+	}
+}
+
+// checkIncludedLeafrefs checks that each leafref within the descendants of
+// the yang.Entry e, which are those retained after pruning the schema, can be
+// mapped to a type within the generated code. Leafrefs that reference an
+// enumerated leaf that was not retained cannot be mapped, since no type is
+// generated for the enumeration.
+func checkIncludedLeafrefs(e *yang.Entry, st *schemaTree) util.Errors {
+	var errs util.Errors
+	for _, ch := range e.Dir {
+		if ch.IsDir() || util.IsChoiceOrCase(ch) {
+			errs = util.AppendErrs(errs, checkIncludedLeafrefs(ch, st))
+			continue
+		}
+		if ch.Type == nil {
+			continue
+		}
+		for _, p := range leafrefPaths(ch.Type) {
+			target, err := st.resolveLeafrefTarget(p, ch)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if mappableLeaf(target) != nil && !isRetained(target) {
+				errs = append(errs, fmt.Errorf("leafref %s references enumerated leaf %s, which is not within the included paths", ch.Path(), target.Path()))
+			}
+		}
+	}
+	return errs
+}
+
+// listKeyPaths returns the data tree paths of the key leaves of the yang.Entry
+// e, whose data tree path is specified by path, if it is a list. Where a key
+// leaf is a leafref to a leaf within the list (e.g., ../config/name), the path
+// of the referenced leaf is also returned, such that the key can be resolved
+// after the schema is pruned.
+func listKeyPaths(e *yang.Entry, path []string) [][]string {
+	if !e.IsList() {
+		return nil
+	}
+	var paths [][]string
+	for _, k := range strings.Fields(e.Key) {
+		paths = append(paths, append(append([]string{}, path...), k))
+		kl, ok := e.Dir[k]
+		if !ok || kl.Type == nil || kl.Type.Kind != yang.Yleafref || !strings.HasPrefix(kl.Type.Path, "../") {
+			continue
+		}
+		target := strings.Split(util.StripModulePrefixesStr(strings.TrimPrefix(kl.Type.Path, "../")), "/")
+		paths = append(paths, append(append([]string{}, path...), target...))
+	}
+	return paths
+}
+
+// isPathPrefix returns true if the path elements in prefix are a prefix of
+// those in path.
+func isPathPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, e := range prefix {
+		if path[i] != e {
+			return false
+		}
+	}
+	return true
+}
+
+// leafrefPaths returns the paths referenced by the YANG type t if it is a
+// leafref, or by any of its subtypes if it is a union.
+func leafrefPaths(t *yang.YangType) []string {
+	switch t.Kind {
+	case yang.Yleafref:
+		return []string{t.Path}
+	case yang.Yunion:
+		var paths []string
+		for _, st := range t.Type {
+			paths = append(paths, leafrefPaths(st)...)
+		}
+		return paths
+	}
+	return nil
+}
+
+// isRetained returns true if the yang.Entry e is still a descendant of its
+// module after the schema has been pruned.
+func isRetained(e *yang.Entry) bool {
+	for ; e.Parent != nil; e = e.Parent {
+		if e.Parent.Dir[e.Name] != e {
+			return false
+		}
+	}
+	return true
+}
+
 // mappableLeaf determines whether the yang.Entry e is leaf with an
 // enumerated value, such that the referenced enumerated type (enumeration or
 // identity) should have code generated for it. If it is an enumerated type
@@ -1116,11 +2193,14 @@ func mappableLeaf(e *yang.Entry) *yang.Entry {
 // map (keyed by the schema path). Those that represent enumerated types (identityref, enumeration,
 // unions containing these types, or typedefs containing these types) are appended to the
 // enums map, which is again keyed by schema path. If any child of the entry is in a module
-// defined in excludeModules, it is skipped. If compressPaths is set to true, then names are
-// mapped with path compression enabled. The set of modules that the current code generation
+// defined in excludeModules, it is skipped. The compressBehaviour specifies whether names are
+// mapped with path compression enabled, and whether containers that surround lists are
+// compressed out of the schema. If includeNotifications is set to true, then
+// notifications, and the input and output of rpcs and actions, are mapped as directories,
+// otherwise they are skipped. The set of modules that the current code generation
 // is processing is specified by the modules slice. This function returns slice of errors
 // encountered during processing.
-func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[string]*yang.Entry, excludeModules []string, compressPaths bool, modules []*yang.Entry) util.Errors {
+func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[string]*yang.Entry, excludeModules []string, compressBehaviour genutil.CompressBehaviour, includeNotifications bool, modules []*yang.Entry) util.Errors {
 	// Skip entities who are defined within a module that we have been instructed
 	// not to generate code for.
 	for _, s := range excludeModules {
@@ -1141,16 +2221,16 @@ func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[
 			if e := mappableLeaf(ch); e != nil {
 				enums[ch.Path()] = e
 			}
-		case util.IsConfigState(ch) && compressPaths:
+		case util.IsConfigState(ch) && compressBehaviour.CompressEnabled():
 			// If this is a config or state container and we are compressing paths
 			// then we do not want to map this container - but we do want to map its
 			// children.
-			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, modules))
-		case util.HasOnlyChild(ch) && util.Children(ch)[0].IsList() && compressPaths:
+			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressBehaviour, includeNotifications, modules))
+		case util.HasOnlyChild(ch) && util.Children(ch)[0].IsList() && compressBehaviour.ListContainersCompressed():
 			// This is a surrounding container for a list, and we are compressing
-			// paths, so we don't want to map it but again we do want to map its
-			// children.
-			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, modules))
+			// paths without retaining such containers, so we don't want to map it
+			// but again we do want to map its children.
+			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressBehaviour, includeNotifications, modules))
 		case util.IsChoiceOrCase(ch):
 			// Don't map for a choice or case node itself, and rather skip over it.
 			// However, we must walk each branch to find the first container that
@@ -1170,18 +2250,44 @@ func findMappableEntities(e *yang.Entry, dirs map[string]*yang.Entry, enums map[
 				if gch.IsContainer() || gch.IsList() {
 					dirs[fmt.Sprintf("%s/%s", ch.Parent.Path(), gch.Name)] = gch
 				}
-				errs = util.AppendErrs(errs, findMappableEntities(gch, dirs, enums, excludeModules, compressPaths, modules))
+				errs = util.AppendErrs(errs, findMappableEntities(gch, dirs, enums, excludeModules, compressBehaviour, includeNotifications, modules))
 			}
 		case ch.IsContainer(), ch.IsList():
 			dirs[ch.Path()] = ch
 			// Recurse down the tree.
-			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressPaths, modules))
+			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressBehaviour, includeNotifications, modules))
+		case ch.Kind == yang.NotificationEntry:
+			if !includeNotifications {
+				continue
+			}
+			dirs[ch.Path()] = ch
+			errs = util.AppendErrs(errs, findMappableEntities(ch, dirs, enums, excludeModules, compressBehaviour, includeNotifications, modules))
 		case ch.Kind == yang.AnyDataEntry:
 			continue
 		default:
 			errs = util.AppendErr(errs, fmt.Errorf("unknown type of entry %v in findMappableEntities for %s", ch.Kind, ch.Path()))
 		}
 	}
+
+	if !includeNotifications {
+		return errs
+	}
+
+	// The rpcs and actions defined within e are not returned by util.Children
+	// since they are not data nodes, their input and output are mapped
+	// individually.
+	for _, ch := range e.Dir {
+		if ch.RPC == nil {
+			continue
+		}
+		for _, io := range []*yang.Entry{ch.RPC.Input, ch.RPC.Output} {
+			if io == nil {
+				continue
+			}
+			dirs[io.Path()] = io
+			errs = util.AppendErrs(errs, findMappableEntities(io, dirs, enums, excludeModules, compressBehaviour, includeNotifications, modules))
+		}
+	}
 	return errs
 }
 
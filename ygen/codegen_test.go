@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -30,6 +31,7 @@ import (
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/genutil"
 	"github.com/openconfig/ygot/testutil"
+	"github.com/openconfig/ygot/ygot"
 )
 
 const (
@@ -47,11 +49,64 @@ const datapath = "../testdata/modules"
 // TestFindMappableEntities tests the extraction of elements that are to be mapped
 // into Go code from a YANG schema.
 func TestFindMappableEntities(t *testing.T) {
+	// operationsModule returns a module containing an rpc, with an input
+	// and output, and a notification.
+	operationsModule := func() *yang.Entry {
+		m := &yang.Entry{
+			Name: "module",
+			Kind: yang.DirectoryEntry,
+			Dir:  map[string]*yang.Entry{},
+		}
+		rpc := &yang.Entry{
+			Name:   "rpc",
+			Kind:   yang.DirectoryEntry,
+			Dir:    map[string]*yang.Entry{},
+			Parent: m,
+		}
+		rpc.RPC = &yang.RPCEntry{
+			Input: &yang.Entry{
+				Name:   "input",
+				Kind:   yang.InputEntry,
+				Dir:    map[string]*yang.Entry{},
+				Parent: rpc,
+			},
+			Output: &yang.Entry{
+				Name:   "output",
+				Kind:   yang.OutputEntry,
+				Dir:    map[string]*yang.Entry{},
+				Parent: rpc,
+			},
+		}
+		rpc.RPC.Output.Dir["result"] = &yang.Entry{
+			Name:   "result",
+			Kind:   yang.DirectoryEntry,
+			Dir:    map[string]*yang.Entry{},
+			Parent: rpc.RPC.Output,
+		}
+		notif := &yang.Entry{
+			Name:   "notification",
+			Kind:   yang.NotificationEntry,
+			Dir:    map[string]*yang.Entry{},
+			Parent: m,
+		}
+		notif.Dir["enumleaf"] = &yang.Entry{
+			Name:   "enumleaf",
+			Type:   &yang.YangType{Kind: yang.Yenum},
+			Parent: notif,
+		}
+		m.Dir["rpc"] = rpc
+		m.Dir["notification"] = notif
+		return m
+	}
+
 	tests := []struct {
 		name          string        // name is an identifier for the test.
 		in            *yang.Entry   // in is the yang.Entry corresponding to the YANG root element.
 		inSkipModules []string      // inSkipModules is a slice of strings indicating modules to be skipped.
 		inModules     []*yang.Entry // inModules is the set of modules that the code generation is for.
+		// inIncludeNotifications specifies whether notifications, and the
+		// input and output of rpcs, should be mapped.
+		inIncludeNotifications bool
 		// wantCompressed is a map keyed by the string "structs" or "enums" which contains a slice
 		// of the YANG identifiers for the corresponding mappable entities that should be
 		// found. wantCompressed is the set that are expected when compression is enabled.
@@ -59,6 +114,10 @@ func TestFindMappableEntities(t *testing.T) {
 		// wantUncompressed is a map of the same form as wantCompressed. It is the expected
 		// result when compression is disabled.
 		wantUncompressed map[string][]string
+		// wantKeepListContainers is a map of the same form as wantCompressed. It is the
+		// expected result when compression retains the containers surrounding lists, and
+		// is only checked when set.
+		wantKeepListContainers map[string][]string
 	}{{
 		name: "base-test",
 		in: &yang.Entry{
@@ -205,6 +264,9 @@ func TestFindMappableEntities(t *testing.T) {
 		wantUncompressed: map[string][]string{
 			"structs": {"surrounding-container", "child-list"},
 		},
+		wantKeepListContainers: map[string][]string{
+			"structs": {"surrounding-container", "child-list"},
+		},
 	}, {
 		name: "choice/case at root",
 		in: &yang.Entry{
@@ -346,21 +408,47 @@ func TestFindMappableEntities(t *testing.T) {
 		wantUncompressed: map[string][]string{
 			"structs": {"container"},
 			"enums":   {"choice-case-container-leaf", "choice-case2-leaf", "direct"}},
+	}, {
+		name: "rpc and notification skipped",
+		in:   operationsModule(),
+		wantCompressed: map[string][]string{
+			"structs": {},
+			"enums":   {},
+		},
+		wantUncompressed: map[string][]string{
+			"structs": {},
+			"enums":   {},
+		},
+	}, {
+		name:                   "rpc and notification included",
+		in:                     operationsModule(),
+		inIncludeNotifications: true,
+		wantCompressed: map[string][]string{
+			"structs": {"input", "output", "result", "notification"},
+			"enums":   {"enumleaf"},
+		},
+		wantUncompressed: map[string][]string{
+			"structs": {"input", "output", "result", "notification"},
+			"enums":   {"enumleaf"},
+		},
 	}}
 
 	for _, tt := range tests {
-		testSpec := map[bool]map[string][]string{
-			true:  tt.wantCompressed,
-			false: tt.wantUncompressed,
+		testSpec := map[genutil.CompressBehaviour]map[string][]string{
+			genutil.PreferIntendedConfig: tt.wantCompressed,
+			genutil.Uncompressed:         tt.wantUncompressed,
+		}
+		if tt.wantKeepListContainers != nil {
+			testSpec[genutil.PreferIntendedConfigKeepListContainers] = tt.wantKeepListContainers
 		}
 
 		for compress, expected := range testSpec {
 			structs := make(map[string]*yang.Entry)
 			enums := make(map[string]*yang.Entry)
 
-			errs := findMappableEntities(tt.in, structs, enums, tt.inSkipModules, compress, tt.inModules)
+			errs := findMappableEntities(tt.in, structs, enums, tt.inSkipModules, compress, tt.inIncludeNotifications, tt.inModules)
 			if errs != nil {
-				t.Errorf("%s: findMappableEntities(compressEnabled: %v): got unexpected error, got: %v, want: nil", tt.name, compress, errs)
+				t.Errorf("%s: findMappableEntities(compressBehaviour: %v): got unexpected error, got: %v, want: nil", tt.name, compress, errs)
 			}
 
 			entityNames := func(m map[string]bool) []string {
@@ -381,22 +469,22 @@ func TestFindMappableEntities(t *testing.T) {
 			}
 
 			if len(expected["structs"]) != len(structOut) {
-				t.Errorf("%s: findMappableEntities(compressEnabled: %v): did not get expected number of structs, got: %v, want: %v", tt.name, compress, entityNames(structOut), expected["structs"])
+				t.Errorf("%s: findMappableEntities(compressBehaviour: %v): did not get expected number of structs, got: %v, want: %v", tt.name, compress, entityNames(structOut), expected["structs"])
 			}
 
 			for _, e := range expected["structs"] {
 				if !structOut[e] {
-					t.Errorf("%s: findMappableEntities(compressEnabled: %v): struct %s was not found in %v\n", tt.name, compress, e, structOut)
+					t.Errorf("%s: findMappableEntities(compressBehaviour: %v): struct %s was not found in %v\n", tt.name, compress, e, structOut)
 				}
 			}
 
 			if len(expected["enums"]) != len(enumOut) {
-				t.Errorf("%s: findMappableEntities(compressEnabled: %v): did not get expected number of enums, got: %v, want: %v", tt.name, compress, entityNames(enumOut), expected["enums"])
+				t.Errorf("%s: findMappableEntities(compressBehaviour: %v): did not get expected number of enums, got: %v, want: %v", tt.name, compress, entityNames(enumOut), expected["enums"])
 			}
 
 			for _, e := range expected["enums"] {
 				if !enumOut[e] {
-					t.Errorf("%s: findMappableEntities(compressEnabled: %v): enum %s was not found in %v\n", tt.name, compress, e, enumOut)
+					t.Errorf("%s: findMappableEntities(compressBehaviour: %v): enum %s was not found in %v\n", tt.name, compress, e, enumOut)
 				}
 			}
 		}
@@ -446,71 +534,66 @@ func TestSimpleStructs(t *testing.T) {
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.formatted-txt"),
 	}, {
-		name:    "simple openconfig test, with excluded state, with compression, with enum org name trimming",
+		name:    "simple openconfig test, with a custom binary type",
 		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
-				GenerateSimpleUnions: true,
-				GenerateLeafGetters:  true,
+				GenerateSimpleUnions:    true,
+				GenerateLeafGetters:     true,
+				GeneratePopulateDefault: true,
+				BinaryTypeName:          "keys.Key",
+				BinaryTypeImport:        "example.com/keys",
 			},
 			TransformationOptions: TransformationOpts{
-				CompressBehaviour:                    genutil.ExcludeDerivedState,
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
 				ShortenEnumLeafNames:                 true,
 				EnumOrgPrefixesToTrim:                []string{"openconfig"},
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple-excludestate.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.custom-binary.formatted-txt"),
 	}, {
-		name:    "simple openconfig test, with no compression",
+		name:    "simple openconfig test, with only /parent/child included",
 		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				IncludeOnlyPaths: []string{"/parent/child"},
+			},
 			GoOptions: GoOpts{
 				GenerateSimpleUnions:    true,
 				GenerateLeafGetters:     true,
 				GeneratePopulateDefault: true,
 			},
-			TransformationOptions: TransformationOpts{
-				ShortenEnumLeafNames:                 true,
-				UseDefiningModuleForTypedefEnumNames: true,
-				EnumerationsUseUnderscores:           true,
-			},
-		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple-no-compress.formatted-txt"),
-	}, {
-		name:    "simple openconfig test, with compression, without shortened enum leaf names, with enum org name trimming",
-		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
-		inConfig: GeneratorConfig{
-			GoOptions: GoOpts{
-				GenerateSimpleUnions: true,
-			},
 			TransformationOptions: TransformationOpts{
 				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
 				EnumOrgPrefixesToTrim:                []string{"openconfig"},
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.long-enum-names.trimmed-enum.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.include-only-paths.formatted-txt"),
 	}, {
-		name:    "simple openconfig test, with no compression, with enum org name trimming",
+		name:    "simple openconfig test, with excluded state, with compression, with enum org name trimming",
 		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
 				GenerateSimpleUnions: true,
+				GenerateLeafGetters:  true,
 			},
 			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.ExcludeDerivedState,
 				ShortenEnumLeafNames:                 true,
 				EnumOrgPrefixesToTrim:                []string{"openconfig"},
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple-no-compress.trimmed-enum.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple-excludestate.formatted-txt"),
 	}, {
-		name:    "OpenConfig leaf-list defaults test, with compression",
-		inFiles: []string{filepath.Join(datapath, "openconfig-leaflist-default.yang")},
+		name:    "simple openconfig test, with no compression",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
 				GenerateSimpleUnions:    true,
@@ -518,142 +601,129 @@ func TestSimpleStructs(t *testing.T) {
 				GeneratePopulateDefault: true,
 			},
 			TransformationOptions: TransformationOpts{
-				CompressBehaviour:                    genutil.PreferIntendedConfig,
 				ShortenEnumLeafNames:                 true,
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-leaflist-default.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple-no-compress.formatted-txt"),
 	}, {
-		name:    "OpenConfig schema test - with annotations",
+		name:    "simple openconfig test, with compression, with path type registry",
 		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
-				AddAnnotationFields:  true,
-				AnnotationPrefix:     "ᗩ",
-				GenerateSimpleUnions: true,
+				GenerateSimpleUnions:     true,
+				GeneratePathTypeRegistry: true,
 			},
 			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				GenerateFakeRoot:                     true,
 				ShortenEnumLeafNames:                 true,
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-simple-annotations.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.path-type-registry.formatted-txt"),
 	}, {
-		name:    "OpenConfig schema test - list and associated method (rename, new)",
-		inFiles: []string{filepath.Join(datapath, "openconfig-withlist.yang")},
+		name:    "simple openconfig test, with no compression, with path type registry",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
-			TransformationOptions: TransformationOpts{
-				CompressBehaviour:                    genutil.PreferIntendedConfig,
-				ShortenEnumLeafNames:                 true,
-				UseDefiningModuleForTypedefEnumNames: true,
-				EnumerationsUseUnderscores:           true,
-			},
 			GoOptions: GoOpts{
-				GenerateRenameMethod: true,
-				GenerateSimpleUnions: true,
+				GenerateSimpleUnions:     true,
+				GeneratePathTypeRegistry: true,
 			},
-		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-withlist.formatted-txt"),
-	}, {
-		name:    "OpenConfig schema test - list and associated method (rename, new) - using operational state",
-		inFiles: []string{filepath.Join(datapath, "openconfig-withlist.yang")},
-		inConfig: GeneratorConfig{
 			TransformationOptions: TransformationOpts{
-				CompressBehaviour:                    genutil.PreferOperationalState,
+				GenerateFakeRoot:                     true,
 				ShortenEnumLeafNames:                 true,
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
-			GoOptions: GoOpts{
-				GenerateRenameMethod: true,
-				GenerateSimpleUnions: true,
-			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-withlist-opstate.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple-no-compress.path-type-registry.formatted-txt"),
 	}, {
-		name:    "OpenConfig schema test - multi-keyed list key struct name conflict and associated method (rename, new)",
-		inFiles: []string{filepath.Join(datapath, "openconfig-multikey-list-name-conflict.yang")},
+		name:    "simple openconfig test, with compression, with interface checks",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:    true,
+				GenerateInterfaceChecks: true,
+			},
 			TransformationOptions: TransformationOpts{
 				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				GenerateFakeRoot:                     true,
 				ShortenEnumLeafNames:                 true,
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
-			GoOptions: GoOpts{
-				GenerateRenameMethod: true,
-				GenerateSimpleUnions: true,
-			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-multikey-list-name-conflict.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.interface-checks.formatted-txt"),
 	}, {
-		name:    "simple openconfig test, with a list that has an enumeration key",
-		inFiles: []string{filepath.Join(datapath, "openconfig-list-enum-key.yang")},
+		name:    "simple openconfig test, with compression, keeping state fields",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
 				GenerateSimpleUnions: true,
 			},
 			TransformationOptions: TransformationOpts{
-				CompressBehaviour:                    genutil.PreferIntendedConfig,
-				IgnoreShadowSchemaPaths:              true,
+				CompressBehaviour:                    genutil.PreferIntendedConfigKeepState,
+				GenerateFakeRoot:                     true,
 				ShortenEnumLeafNames:                 true,
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-list-enum-key.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.keep-state.formatted-txt"),
 	}, {
-		name:    "simple openconfig test, with a list that has an enumeration key, with enum org name trimming",
-		inFiles: []string{filepath.Join(datapath, "openconfig-list-enum-key.yang")},
+		name:    "simple openconfig test, with compression, with embedded metadata type",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
 				GenerateSimpleUnions: true,
+				EmbedMetadataType:    "NodeMetadata",
 			},
 			TransformationOptions: TransformationOpts{
 				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				GenerateFakeRoot:                     true,
 				ShortenEnumLeafNames:                 true,
-				EnumOrgPrefixesToTrim:                []string{"openconfig"},
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-list-enum-key.trimmed-enum.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.embedded-metadata.formatted-txt"),
 	}, {
-		name:    "openconfig test with a identityref union",
-		inFiles: []string{filepath.Join(datapath, "openconfig-unione.yang")},
+		name:    "simple openconfig test, with compression, with validation hooks",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
-				GenerateSimpleUnions: true,
+				GenerateSimpleUnions:    true,
+				GenerateValidationHooks: true,
 			},
 			TransformationOptions: TransformationOpts{
 				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				GenerateFakeRoot:                     true,
 				ShortenEnumLeafNames:                 true,
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-unione.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.validation-hooks.formatted-txt"),
 	}, {
-		name:    "openconfig test with a identityref union (wrapper unions)",
-		inFiles: []string{filepath.Join(datapath, "openconfig-unione.yang")},
+		name:    "simple openconfig test, with embedded metadata type and default annotation fields",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
-			TransformationOptions: TransformationOpts{
-				CompressBehaviour:                    genutil.PreferIntendedConfig,
-				ShortenEnumLeafNames:                 true,
-				UseDefiningModuleForTypedefEnumNames: true,
-				EnumerationsUseUnderscores:           true,
+			GoOptions: GoOpts{
+				EmbedMetadataType:   "NodeMetadata",
+				AddAnnotationFields: true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-unione.wrapper-unions.formatted-txt"),
+		wantErrSubstring: "cannot embed metadata type NodeMetadata",
 	}, {
-		name:    "openconfig tests with fakeroot",
-		inFiles: []string{filepath.Join(datapath, "openconfig-fakeroot.yang")},
+		name:    "simple openconfig test, with compression, with json tags",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
 				GenerateSimpleUnions: true,
+				AddJSONTags:          true,
 			},
 			TransformationOptions: TransformationOpts{
 				CompressBehaviour:                    genutil.PreferIntendedConfig,
@@ -663,59 +733,67 @@ func TestSimpleStructs(t *testing.T) {
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-fakeroot.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.json-tags.formatted-txt"),
 	}, {
-		name:    "openconfig noncompressed tests with fakeroot",
-		inFiles: []string{filepath.Join(datapath, "openconfig-fakeroot.yang")},
+		name: "module with augments, with json tags",
+		inFiles: []string{
+			filepath.Join(datapath, "openconfig-simple-target.yang"),
+			filepath.Join(datapath, "openconfig-simple-augment.yang"),
+		},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
 				GenerateSimpleUnions: true,
+				AddJSONTags:          true,
 			},
 			TransformationOptions: TransformationOpts{
-				GenerateFakeRoot:                     true,
-				ShortenEnumLeafNames:                 true,
-				UseDefiningModuleForTypedefEnumNames: true,
-				EnumerationsUseUnderscores:           true,
+				CompressBehaviour:          genutil.PreferIntendedConfig,
+				GenerateFakeRoot:           true,
+				EnumerationsUseUnderscores: true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-fakeroot-nc.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-augmented.json-tags.formatted-txt"),
 	}, {
-		name:    "schema test with compression",
-		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		name: "module with deviations, with compression",
+		inFiles: []string{
+			filepath.Join(datapath, "openconfig-deviated.yang"),
+			filepath.Join(datapath, "openconfig-deviations.yang"),
+		},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
-				GenerateSimpleUnions: true,
+				GeneratePopulateDefault: true,
 			},
 			TransformationOptions: TransformationOpts{
-				CompressBehaviour:                    genutil.PreferIntendedConfig,
-				ShortenEnumLeafNames:                 true,
-				UseDefiningModuleForTypedefEnumNames: true,
-				EnumerationsUseUnderscores:           true,
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
 			},
-			GenerateJSONSchema: true,
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress.formatted-txt"),
-		wantSchemaFile:      filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress-schema.json"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-deviations.formatted-txt"),
 	}, {
-		name:    "schema test without compression",
-		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		name: "module with deviations, with compression, ignoring deviations",
+		inFiles: []string{
+			filepath.Join(datapath, "openconfig-deviated.yang"),
+			filepath.Join(datapath, "openconfig-deviations.yang"),
+		},
 		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				IgnoreDeviations: true,
+			},
 			GoOptions: GoOpts{
-				GenerateSimpleUnions: true,
+				GeneratePopulateDefault: true,
 			},
 			TransformationOptions: TransformationOpts{
-				EnumerationsUseUnderscores: true,
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
 			},
-			GenerateJSONSchema: true,
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-nocompress.formatted-txt"),
-		wantSchemaFile:      filepath.Join(TestRoot, "testdata/schema/openconfig-options-nocompress-schema.json"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-deviations.ignore-deviations.formatted-txt"),
 	}, {
-		name:    "schema test with fakeroot",
-		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		name:    "simple openconfig test, with compression, with Equal method generation",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
 				GenerateSimpleUnions: true,
+				GenerateEqualMethod:  true,
 			},
 			TransformationOptions: TransformationOpts{
 				CompressBehaviour:                    genutil.PreferIntendedConfig,
@@ -724,70 +802,93 @@ func TestSimpleStructs(t *testing.T) {
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
-			GenerateJSONSchema: true,
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress-fakeroot.formatted-txt"),
-		wantSchemaFile:      filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress-fakeroot-schema.json"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.equal-method.formatted-txt"),
 	}, {
-		name:    "schema test with fakeroot and no compression",
-		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		name:    "simple openconfig test, with compression, with pool reset generation",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
 				GenerateSimpleUnions: true,
+				GeneratePoolReset:    true,
 			},
 			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
 				GenerateFakeRoot:                     true,
+				ShortenEnumLeafNames:                 true,
 				UseDefiningModuleForTypedefEnumNames: true,
 				EnumerationsUseUnderscores:           true,
 			},
-			GenerateJSONSchema: true,
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-nocompress-fakeroot.formatted-txt"),
-		wantSchemaFile:      filepath.Join(TestRoot, "testdata/schema/openconfig-options-nocompress-fakeroot-schema.json"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.pool-reset.formatted-txt"),
 	}, {
-		name:    "schema test with camelcase annotations",
-		inFiles: []string{filepath.Join(datapath, "openconfig-camelcase.yang")},
+		name:    "openconfig test with mandatory leaves, with non-pointer mandatory leaves",
+		inFiles: []string{filepath.Join(datapath, "openconfig-mandatory.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
-				GenerateSimpleUnions: true,
+				GenerateSimpleUnions:      true,
+				GenerateAppendMethod:      true,
+				GenerateGetters:           true,
+				GenerateLeafGetters:       true,
+				GenerateRenameMethod:      true,
+				NonPointerMandatoryLeaves: true,
 			},
 			TransformationOptions: TransformationOpts{
-				CompressBehaviour:                    genutil.PreferIntendedConfig,
-				GenerateFakeRoot:                     true,
-				ShortenEnumLeafNames:                 true,
-				UseDefiningModuleForTypedefEnumNames: true,
-				EnumerationsUseUnderscores:           true,
+				CompressBehaviour:          genutil.PreferIntendedConfig,
+				GenerateFakeRoot:           true,
+				EnumerationsUseUnderscores: true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-camelcase-compress.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-mandatory.non-pointer-mandatory.formatted-txt"),
 	}, {
-		name:    "structs test with camelcase annotations",
-		inFiles: []string{filepath.Join(datapath, "openconfig-enumcamelcase.yang")},
+		name:    "openconfig test with typedefs mapped to custom types",
+		inFiles: []string{filepath.Join(datapath, "openconfig-custom-types.yang"), filepath.Join(datapath, "openconfig-custom-inet-types.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
-				GenerateSimpleUnions: true,
+				GenerateSimpleUnions:    true,
+				GenerateLeafGetters:     true,
+				GeneratePopulateDefault: true,
+				CustomTypeMap: map[string]string{
+					"openconfig-custom-inet-types:ip-address":   "net/netip.Addr",
+					"openconfig-custom-inet-types:ipv4-address": "net/netip.Addr",
+					"openconfig-custom-inet-types:ipv6-address": "net/netip.Addr",
+					"openconfig-custom-inet-types:port-number":  "PortNumber",
+				},
 			},
 			TransformationOptions: TransformationOpts{
-				CompressBehaviour:                    genutil.PreferIntendedConfig,
-				ShortenEnumLeafNames:                 true,
-				UseDefiningModuleForTypedefEnumNames: true,
-				EnumerationsUseUnderscores:           true,
+				CompressBehaviour:          genutil.PreferIntendedConfig,
+				GenerateFakeRoot:           true,
+				EnumerationsUseUnderscores: true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-enumcamelcase-compress.formatted-txt"),
-	}, {
-		name:                "structs test with choices and cases",
-		inFiles:             []string{filepath.Join(datapath, "choice-case-example.yang")},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/choice-case-example.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-custom-types.formatted-txt"),
 	}, {
-		name: "module with augments",
-		inFiles: []string{
-			filepath.Join(datapath, "openconfig-simple-target.yang"),
-			filepath.Join(datapath, "openconfig-simple-augment.yang"),
+		name:    "openconfig test with bounded leaf-lists, with fixed-size arrays",
+		inFiles: []string{filepath.Join(datapath, "openconfig-bounded-leaflist.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:       true,
+				GenerateLeafGetters:        true,
+				GenerateHasMethods:         true,
+				GenerateEqualMethod:        true,
+				GeneratePopulateDefault:    true,
+				FixedArraysForBoundedLists: true,
+				FixedArrayMaxElements:      4,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:          genutil.PreferIntendedConfig,
+				GenerateFakeRoot:           true,
+				EnumerationsUseUnderscores: true,
+			},
 		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-bounded-leaflist.fixed-arrays.formatted-txt"),
+	}, {
+		name:    "openconfig test with range restricted leaves, with setters",
+		inFiles: []string{filepath.Join(datapath, "openconfig-ranges.yang")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
 				GenerateSimpleUnions: true,
+				GenerateSetters:      true,
 			},
 			TransformationOptions: TransformationOpts{
 				CompressBehaviour:          genutil.PreferIntendedConfig,
@@ -795,13 +896,643 @@ func TestSimpleStructs(t *testing.T) {
 				EnumerationsUseUnderscores: true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-augmented.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-ranges.setters.formatted-txt"),
 	}, {
-		name:    "variable and import explicitly specified",
-		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		name:    "openconfig test with constraints, with constraint comments",
+		inFiles: []string{filepath.Join(datapath, "openconfig-constraints.yang")},
 		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:      true,
+				IncludeConstraintComments: true,
+			},
 			TransformationOptions: TransformationOpts{
-				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				CompressBehaviour:          genutil.PreferIntendedConfig,
+				EnumerationsUseUnderscores: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-constraints.constraint-comments.formatted-txt"),
+	}, {
+		name:    "openconfig test with deprecated and obsolete nodes, with deprecation markers",
+		inFiles: []string{filepath.Join(datapath, "openconfig-status.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				MarkDeprecated: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:          genutil.PreferIntendedConfig,
+				EnumerationsUseUnderscores: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-status.mark-deprecated.formatted-txt"),
+	}, {
+		name:    "simple openconfig test, with setters and field presence tracking",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+				GenerateSetters:      true,
+				TrackFieldPresence:   true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:          genutil.PreferIntendedConfig,
+				EnumerationsUseUnderscores: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.field-presence.formatted-txt"),
+	}, {
+		name:    "field presence tracking without setters",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				TrackFieldPresence: true,
+			},
+		},
+		wantErrSubstring: "tracking field presence requires setters to be generated",
+	}, {
+		name:    "openconfig test with rpc, action and notification, with notification nodes included",
+		inFiles: []string{filepath.Join(datapath, "openconfig-rpcs.yang")},
+		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				IncludeNotificationNodes: true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:          genutil.PreferIntendedConfig,
+				GenerateFakeRoot:           true,
+				EnumerationsUseUnderscores: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-rpcs.notification-nodes.formatted-txt"),
+	}, {
+		name:    "simple openconfig test, with compression, without shortened enum leaf names, with enum org name trimming",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				EnumOrgPrefixesToTrim:                []string{"openconfig"},
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple.long-enum-names.trimmed-enum.formatted-txt"),
+	}, {
+		name:    "simple openconfig test, with no compression, with enum org name trimming",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				ShortenEnumLeafNames:                 true,
+				EnumOrgPrefixesToTrim:                []string{"openconfig"},
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-simple-no-compress.trimmed-enum.formatted-txt"),
+	}, {
+		name:    "OpenConfig leaf-list defaults test, with compression",
+		inFiles: []string{filepath.Join(datapath, "openconfig-leaflist-default.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:    true,
+				GenerateLeafGetters:     true,
+				GeneratePopulateDefault: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-leaflist-default.formatted-txt"),
+	}, {
+		name:    "OpenConfig leaf-list defaults test, with default constants",
+		inFiles: []string{filepath.Join(datapath, "openconfig-leaflist-default.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:     true,
+				GenerateDefaultConstants: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-leaflist-default.default-constants.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - with annotations",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				AddAnnotationFields:  true,
+				AnnotationPrefix:     "ᗩ",
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-simple-annotations.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - with annotations of a custom type",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				AddAnnotationFields:  true,
+				AnnotationFieldType:  "*NodeAnnotations",
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-simple-annotations.custom-type.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - list and associated method (rename, new)",
+		inFiles: []string{filepath.Join(datapath, "openconfig-withlist.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+			GoOptions: GoOpts{
+				GenerateRenameMethod: true,
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-withlist.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - list with child path generation",
+		inFiles: []string{filepath.Join(datapath, "openconfig-withlist.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+				GenerateChildPaths:   true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-withlist.child-paths.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - ordered-by user lists with ordered list support",
+		inFiles: []string{filepath.Join(datapath, "openconfig-ordered-list.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+			GoOptions: GoOpts{
+				GenerateOrderedListSupport: true,
+				GenerateGetters:            true,
+				GenerateDeleteMethod:       true,
+				GenerateAppendMethod:       true,
+				GenerateHasMethods:         true,
+				GenerateEqualMethod:        true,
+				GenerateSimpleUnions:       true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-ordered-list.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - list and associated method (rename, new) - using operational state",
+		inFiles: []string{filepath.Join(datapath, "openconfig-withlist.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferOperationalState,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+			GoOptions: GoOpts{
+				GenerateRenameMethod: true,
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-withlist-opstate.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - list and associated method (rename, new) - keeping list containers",
+		inFiles: []string{filepath.Join(datapath, "openconfig-withlist.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfigKeepListContainers,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+				GenerateFakeRoot:                     true,
+			},
+			GoOptions: GoOpts{
+				GenerateRenameMethod: true,
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-withlist.keep-list-containers.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - multi-keyed list key struct name conflict and associated method (rename, new)",
+		inFiles: []string{filepath.Join(datapath, "openconfig-multikey-list-name-conflict.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+			GoOptions: GoOpts{
+				GenerateRenameMethod: true,
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-multikey-list-name-conflict.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - multi-keyed list key struct with custom suffix",
+		inFiles: []string{filepath.Join(datapath, "openconfig-multikey-list-name-conflict.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+				ListKeyStructSuffix:                  "_ListKey",
+			},
+			GoOptions: GoOpts{
+				GenerateRenameMethod: true,
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-multikey-list-name-conflict.list-key-suffix.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - multi-keyed list key struct with custom suffix and list key constants",
+		inFiles: []string{filepath.Join(datapath, "openconfig-multikey-list-name-conflict.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+				ListKeyStructSuffix:                  "_ListKey",
+			},
+			GoOptions: GoOpts{
+				GenerateRenameMethod:     true,
+				GenerateSimpleUnions:     true,
+				GenerateListKeyConstants: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-multikey-list-name-conflict.list-key-suffix-constants.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - list key struct suffix conflicting with list key constants",
+		inFiles: []string{filepath.Join(datapath, "openconfig-multikey-list-name-conflict.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:   genutil.PreferIntendedConfig,
+				ListKeyStructSuffix: "ListKeys",
+			},
+			GoOptions: GoOpts{
+				GenerateListKeyConstants: true,
+			},
+		},
+		wantErrSubstring: "list key struct suffix ListKeys conflicts",
+	}, {
+		name:    "OpenConfig schema test - multi-keyed list with list key constants",
+		inFiles: []string{filepath.Join(datapath, "openconfig-multikey-list-name-conflict.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:          genutil.PreferIntendedConfig,
+				EnumerationsUseUnderscores: true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:     true,
+				GenerateListKeyConstants: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-multikey-list-name-conflict.list-key-constants.formatted-txt"),
+	}, {
+		name:    "OpenConfig schema test - multi-keyed list with key struct helpers",
+		inFiles: []string{filepath.Join(datapath, "openconfig-multikey-list-name-conflict.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:          genutil.PreferIntendedConfig,
+				EnumerationsUseUnderscores: true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:     true,
+				GenerateKeyStructHelpers: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-multikey-list-name-conflict.key-struct-helpers.formatted-txt"),
+	}, {
+		name:    "simple schema test - lists whose members are stored by value",
+		inFiles: []string{filepath.Join(datapath, "value-entry-lists.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot: true,
+			},
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:    true,
+				GenerateGetters:         true,
+				GenerateDeleteMethod:    true,
+				GenerateAppendMethod:    true,
+				GeneratePopulateDefault: true,
+				GenerateEqualMethod:     true,
+				ValueEntryLists:         true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/value-entry-lists.formatted-txt"),
+	}, {
+		name:    "simple openconfig test, with a list that has an enumeration key",
+		inFiles: []string{filepath.Join(datapath, "openconfig-list-enum-key.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				IgnoreShadowSchemaPaths:              true,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-list-enum-key.formatted-txt"),
+	}, {
+		name:    "simple openconfig test, with a list that has an enumeration key, with enum org name trimming",
+		inFiles: []string{filepath.Join(datapath, "openconfig-list-enum-key.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				EnumOrgPrefixesToTrim:                []string{"openconfig"},
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-list-enum-key.trimmed-enum.formatted-txt"),
+	}, {
+		name:    "openconfig test with a identityref union",
+		inFiles: []string{filepath.Join(datapath, "openconfig-unione.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-unione.formatted-txt"),
+	}, {
+		name:    "openconfig test with a identityref union (wrapper unions)",
+		inFiles: []string{filepath.Join(datapath, "openconfig-unione.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-unione.wrapper-unions.formatted-txt"),
+	}, {
+		name:    "openconfig test with a union containing a leafref to a union",
+		inFiles: []string{filepath.Join(datapath, "openconfig-union-leafref.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-union-leafref.formatted-txt"),
+	}, {
+		name:    "openconfig test with leafrefs to an enumerated leaf and a union leaf, with typed leafrefs",
+		inFiles: []string{filepath.Join(datapath, "openconfig-typed-leafrefs.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+				TypedLeafrefs:        true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-typed-leafrefs.formatted-txt"),
+	}, {
+		name:    "instance-identifier leaves, leaf-lists and unions",
+		inFiles: []string{filepath.Join(datapath, "openconfig-instance-identifier.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+				GenerateLeafGetters:  true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-instance-identifier.formatted-txt"),
+	}, {
+		name:    "instance-identifier leaves, leaf-lists and unions (wrapper unions)",
+		inFiles: []string{filepath.Join(datapath, "openconfig-instance-identifier.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-instance-identifier.wrapper-unions.formatted-txt"),
+	}, {
+		name:    "openconfig tests with fakeroot",
+		inFiles: []string{filepath.Join(datapath, "openconfig-fakeroot.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				GenerateFakeRoot:                     true,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-fakeroot.formatted-txt"),
+	}, {
+		name:    "openconfig noncompressed tests with fakeroot",
+		inFiles: []string{filepath.Join(datapath, "openconfig-fakeroot.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:                     true,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-fakeroot-nc.formatted-txt"),
+	}, {
+		name:    "schema test with compression",
+		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+			GenerateJSONSchema: true,
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress.formatted-txt"),
+		wantSchemaFile:      filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress-schema.json"),
+	}, {
+		name:    "schema test without compression",
+		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				EnumerationsUseUnderscores: true,
+			},
+			GenerateJSONSchema: true,
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-nocompress.formatted-txt"),
+		wantSchemaFile:      filepath.Join(TestRoot, "testdata/schema/openconfig-options-nocompress-schema.json"),
+	}, {
+		name:    "schema test with fakeroot",
+		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				GenerateFakeRoot:                     true,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+			GenerateJSONSchema: true,
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress-fakeroot.formatted-txt"),
+		wantSchemaFile:      filepath.Join(TestRoot, "testdata/schema/openconfig-options-compress-fakeroot-schema.json"),
+	}, {
+		name:    "schema test with fakeroot and no compression",
+		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:                     true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+			GenerateJSONSchema: true,
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/schema/openconfig-options-nocompress-fakeroot.formatted-txt"),
+		wantSchemaFile:      filepath.Join(TestRoot, "testdata/schema/openconfig-options-nocompress-fakeroot-schema.json"),
+	}, {
+		name:    "schema test with camelcase annotations",
+		inFiles: []string{filepath.Join(datapath, "openconfig-camelcase.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				GenerateFakeRoot:                     true,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-camelcase-compress.formatted-txt"),
+	}, {
+		name:    "structs test with camelcase annotations",
+		inFiles: []string{filepath.Join(datapath, "openconfig-enumcamelcase.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-enumcamelcase-compress.formatted-txt"),
+	}, {
+		name:                "structs test with choices and cases",
+		inFiles:             []string{filepath.Join(datapath, "choice-case-example.yang")},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/choice-case-example.formatted-txt"),
+	}, {
+		name:    "structs test with choices and cases, with choice membership tracked",
+		inFiles: []string{filepath.Join(datapath, "choice-case-example.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				TrackChoiceMembership: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/choice-case-example.choice-membership.formatted-txt"),
+	}, {
+		name: "module with augments",
+		inFiles: []string{
+			filepath.Join(datapath, "openconfig-simple-target.yang"),
+			filepath.Join(datapath, "openconfig-simple-augment.yang"),
+		},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:          genutil.PreferIntendedConfig,
+				GenerateFakeRoot:           true,
+				EnumerationsUseUnderscores: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/openconfig-augmented.formatted-txt"),
+	}, {
+		name:    "variable and import explicitly specified",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
 				GenerateFakeRoot:                     true,
 				ShortenEnumLeafNames:                 true,
 				UseDefiningModuleForTypedefEnumNames: true,
@@ -836,10 +1567,43 @@ func TestSimpleStructs(t *testing.T) {
 			Caller: "testcase",
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/root-entities.formatted-txt"),
+	}, {
+		name:    "module with entities at the root, with root helpers in place of the fake root",
+		inFiles: []string{filepath.Join(datapath, "root-entities.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				GenerateRootHelpersOnly:    true,
+				EnumerationsUseUnderscores: true,
+			},
+			Caller: "testcase",
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/root-entities.root-helpers.formatted-txt"),
+	}, {
+		name:    "root helpers with fake root",
+		inFiles: []string{filepath.Join(datapath, "root-entities.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:        true,
+				GenerateRootHelpersOnly: true,
+			},
+		},
+		wantErrSubstring: "root helpers can only be generated when the fake root is not generated",
 	}, {
 		name:                "module with empty leaf",
 		inFiles:             []string{filepath.Join(datapath, "empty.yang")},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/empty.formatted-txt"),
+	}, {
+		name:    "module with empty leaf, represented as bool",
+		inFiles: []string{filepath.Join(datapath, "empty.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				EmptyLeafAsBool: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata/structs/empty.bool.formatted-txt"),
 	}, {
 		name:             "module with excluded modules",
 		inFiles:          []string{filepath.Join(datapath, "excluded-module.yang")},
@@ -899,6 +1663,20 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-list-enum-key.getters-append.formatted-txt"),
+	}, {
+		name:    "module with has methods",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-list-enum-key.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:           true,
+				EnumerationsUseUnderscores: true,
+			},
+			GoOptions: GoOpts{
+				GenerateHasMethods:   true,
+				GenerateSimpleUnions: true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-list-enum-key.has-methods.formatted-txt"),
 	}, {
 		name:    "module with excluded state, with RO list, path compression on",
 		inFiles: []string{filepath.Join(datapath, "", "exclude-state-ro-list.yang")},
@@ -930,6 +1708,91 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-union.formatted-txt"),
+	}, {
+		name:           "different union enumeration types with generic unions",
+		inFiles:        []string{filepath.Join(datapath, "", "enum-union.yang")},
+		inIncludePaths: []string{filepath.Join(datapath, "modules")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+				GenerateLeafGetters:  true,
+				GenericUnions:        true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-union.generic-unions.formatted-txt"),
+	}, {
+		name:           "different union enumeration types with union accessors",
+		inFiles:        []string{filepath.Join(datapath, "", "enum-union.yang")},
+		inIncludePaths: []string{filepath.Join(datapath, "modules")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:   true,
+				GenerateUnionAccessors: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-union.union-accessors.formatted-txt"),
+	}, {
+		name:           "different union enumeration types with union accessors and wrapper unions",
+		inFiles:        []string{filepath.Join(datapath, "", "enum-union.yang")},
+		inIncludePaths: []string{filepath.Join(datapath, "modules")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateUnionAccessors: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-union.union-accessors-wrapper-unions.formatted-txt"),
+	}, {
+		name:    "containers that collide when compressed",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-compress-collision.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "openconfig-compress-collision.formatted-txt"),
+	}, {
+		name:    "containers that collide when compressed, with collision errors",
+		inFiles: []string{filepath.Join(datapath, "", "openconfig-compress-collision.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:           genutil.PreferIntendedConfig,
+				ErrorOnCompressionCollision: true,
+			},
+		},
+		wantErrSubstring: "schema paths /openconfig-compress-collision/top/config/settings, /openconfig-compress-collision/top/state/settings collide at compressed path /top/settings",
+	}, {
+		name:    "generic unions without simple unions",
+		inFiles: []string{filepath.Join(datapath, "", "enum-union.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenericUnions: true,
+			},
+		},
+		wantErrSubstring: "generic unions can only be generated alongside simple unions",
 	}, {
 		name:           "different union enumeration types with consistent naming for union-inlined enums",
 		inFiles:        []string{filepath.Join(datapath, "", "enum-union.yang")},
@@ -954,10 +1817,83 @@ func TestSimpleStructs(t *testing.T) {
 		inIncludePaths: []string{filepath.Join(datapath, "modules")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
-				GenerateSimpleUnions:                true,
-				GenerateLeafGetters:                 true,
-				GeneratePopulateDefault:             true,
-				AppendEnumSuffixForSimpleUnionEnums: true,
+				GenerateSimpleUnions:                true,
+				GenerateLeafGetters:                 true,
+				GeneratePopulateDefault:             true,
+				AppendEnumSuffixForSimpleUnionEnums: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-union-with-enum-defaults.formatted-txt"),
+	}, {
+		name:           "different union enumeration types with default enum values, with default enum as zero",
+		inFiles:        []string{filepath.Join(datapath, "", "enum-union-with-enum-defaults.yang")},
+		inIncludePaths: []string{filepath.Join(datapath, "modules")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:                true,
+				GenerateLeafGetters:                 true,
+				GeneratePopulateDefault:             true,
+				AppendEnumSuffixForSimpleUnionEnums: true,
+				DefaultEnumAsZero:                   true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-union-with-enum-defaults.default-enum-as-zero.formatted-txt"),
+	}, {
+		name:           "different union enumeration types with default enum values, with default constants",
+		inFiles:        []string{filepath.Join(datapath, "", "enum-union-with-enum-defaults.yang")},
+		inIncludePaths: []string{filepath.Join(datapath, "modules")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions:                true,
+				AppendEnumSuffixForSimpleUnionEnums: true,
+				GenerateDefaultConstants:            true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-union-with-enum-defaults.default-constants.formatted-txt"),
+	}, {
+		name:           "different union enumeration types with default enum values (wrapper union)",
+		inFiles:        []string{filepath.Join(datapath, "", "enum-union-with-enum-defaults.yang")},
+		inIncludePaths: []string{filepath.Join(datapath, "modules")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateLeafGetters:                 true,
+				GeneratePopulateDefault:             true,
+				AppendEnumSuffixForSimpleUnionEnums: true,
+			},
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+		},
+		wantErrSubstring: "default value not supported for wrapper union values, please generate using simplified union leaves",
+	}, {
+		name:           "enumeration behaviour - resolution across submodules and grouping re-use within union",
+		inFiles:        []string{filepath.Join(datapath, "", "enum-module.yang")},
+		inIncludePaths: []string{filepath.Join(datapath, "modules")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+				GenerateLeafGetters:  true,
 			},
 			TransformationOptions: TransformationOpts{
 				CompressBehaviour:                    genutil.PreferIntendedConfig,
@@ -966,16 +1902,16 @@ func TestSimpleStructs(t *testing.T) {
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-union-with-enum-defaults.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-module.formatted-txt"),
 	}, {
-		name:           "different union enumeration types with default enum values (wrapper union)",
-		inFiles:        []string{filepath.Join(datapath, "", "enum-union-with-enum-defaults.yang")},
+		name:           "enumeration behaviour - global enum registry",
+		inFiles:        []string{filepath.Join(datapath, "", "enum-module.yang")},
 		inIncludePaths: []string{filepath.Join(datapath, "modules")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
-				GenerateLeafGetters:                 true,
-				GeneratePopulateDefault:             true,
-				AppendEnumSuffixForSimpleUnionEnums: true,
+				GenerateSimpleUnions:       true,
+				GenerateLeafGetters:        true,
+				GenerateGlobalEnumRegistry: true,
 			},
 			TransformationOptions: TransformationOpts{
 				CompressBehaviour:                    genutil.PreferIntendedConfig,
@@ -984,15 +1920,15 @@ func TestSimpleStructs(t *testing.T) {
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantErrSubstring: "default value not supported for wrapper union values, please generate using simplified union leaves",
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-module.enum-registry.formatted-txt"),
 	}, {
-		name:           "enumeration behaviour - resolution across submodules and grouping re-use within union",
+		name:           "enumeration behaviour - enum from string functions",
 		inFiles:        []string{filepath.Join(datapath, "", "enum-module.yang")},
 		inIncludePaths: []string{filepath.Join(datapath, "modules")},
 		inConfig: GeneratorConfig{
 			GoOptions: GoOpts{
-				GenerateSimpleUnions: true,
-				GenerateLeafGetters:  true,
+				GenerateSimpleUnions:   true,
+				GenerateEnumFromString: true,
 			},
 			TransformationOptions: TransformationOpts{
 				CompressBehaviour:                    genutil.PreferIntendedConfig,
@@ -1001,7 +1937,7 @@ func TestSimpleStructs(t *testing.T) {
 				EnumerationsUseUnderscores:           true,
 			},
 		},
-		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-module.formatted-txt"),
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-module.enum-from-string.formatted-txt"),
 	}, {
 		name:           "enumeration behaviour (wrapper unions) - resolution across submodules and grouping re-use within union",
 		inFiles:        []string{filepath.Join(datapath, "", "enum-module.yang")},
@@ -1175,6 +2111,22 @@ func TestSimpleStructs(t *testing.T) {
 			},
 		},
 		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-duplication-dup.formatted-txt"),
+	}, {
+		name:    "model with enums that are in the same grouping deduplicated per module",
+		inFiles: []string{filepath.Join(datapath, "enum-duplication.yang")},
+		inConfig: GeneratorConfig{
+			GoOptions: GoOpts{
+				GenerateSimpleUnions: true,
+			},
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:           true,
+				EnumerationsUseUnderscores: true,
+			},
+			ParseOptions: ParseOpts{
+				EnumDedupScope: PerModuleEnumDedup,
+			},
+		},
+		wantStructsCodeFile: filepath.Join(TestRoot, "testdata", "structs", "enum-duplication-permodule.formatted-txt"),
 	}, {
 		name:    "OpenConfig schema test - list with binary key",
 		inFiles: []string{filepath.Join(datapath, "openconfig-binary-list.yang")},
@@ -1284,6 +2236,14 @@ func TestSimpleStructs(t *testing.T) {
 				// Write generated enumeration map out.
 				fmt.Fprint(&gotCode, gotGeneratedCode.EnumMap)
 
+				// Write the schema path to type map out, this is empty
+				// unless it was requested.
+				fmt.Fprint(&gotCode, gotGeneratedCode.PathTypeMap)
+
+				// Write the root helpers out, this is empty unless
+				// they were requested.
+				fmt.Fprint(&gotCode, gotGeneratedCode.RootHelpers)
+
 				var gotJSON map[string]interface{}
 				if tt.inConfig.GenerateJSONSchema {
 					// Write the schema byte array out.
@@ -1294,6 +2254,11 @@ func TestSimpleStructs(t *testing.T) {
 						t.Fatalf("%s: json.Unmarshal(..., %v), could not unmarshal received JSON: %v", tt.name, gotGeneratedCode.RawJSONSchema, err)
 					}
 				}
+
+				// Write the interface assertions out, this is empty
+				// unless they were requested.
+				fmt.Fprint(&gotCode, gotGeneratedCode.InterfaceChecks)
+
 				return gotGeneratedCode, gotCode.String(), gotJSON, nil
 			}
 
@@ -1378,6 +2343,46 @@ func TestGenerateErrs(t *testing.T) {
 		inPath:      []string{filepath.Join(TestRoot, "testdata", "errors", "subdir")},
 		wantGoOK:    true,
 		wantProtoOK: true,
+	}, {
+		name:    "included path that does not exist",
+		inFiles: []string{filepath.Join(datapath, "include-paths.yang")},
+		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				IncludeOnlyPaths: []string{"/b", "/z"},
+			},
+		},
+		wantGoErrSubstring:   "included path /z does not exist in the schema",
+		wantSameErrSubstring: true,
+	}, {
+		name:    "leafref to enumerated leaf outside of included paths",
+		inFiles: []string{filepath.Join(datapath, "include-paths.yang")},
+		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				IncludeOnlyPaths: []string{"/c"},
+			},
+		},
+		wantGoErrSubstring:   "leafref /include-paths/c/mode-ref references enumerated leaf /include-paths/a/mode, which is not within the included paths",
+		wantSameErrSubstring: true,
+	}, {
+		name:    "leafref to enumerated leaf within included paths",
+		inFiles: []string{filepath.Join(datapath, "include-paths.yang")},
+		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				IncludeOnlyPaths: []string{"/a/mode", "/c"},
+			},
+		},
+		wantGoOK:    true,
+		wantProtoOK: true,
+	}, {
+		name:    "included path within a list retains the list key",
+		inFiles: []string{filepath.Join(datapath, "include-paths.yang")},
+		inConfig: GeneratorConfig{
+			ParseOptions: ParseOpts{
+				IncludeOnlyPaths: []string{"/d/e/extra"},
+			},
+		},
+		wantGoOK:    true,
+		wantProtoOK: true,
 	}}
 
 	for _, tt := range tests {
@@ -1902,6 +2907,90 @@ func TestGetDirectoriesAndLeafTypes(t *testing.T) {
 		wantTypeMap: map[string]map[string]*MappedType{
 			"/device": {},
 		},
+	}, {
+		name:           "simple openconfig test with only /parent/child included with fakeroot",
+		inFiles:        []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inIncludePaths: []string{filepath.Join(TestRoot, "testdata", "structs")},
+		inConfig: &DirectoryGenConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour:                    genutil.PreferIntendedConfig,
+				GenerateFakeRoot:                     true,
+				ShortenEnumLeafNames:                 true,
+				UseDefiningModuleForTypedefEnumNames: true,
+				EnumerationsUseUnderscores:           true,
+			},
+			ParseOptions: ParseOpts{
+				IncludeOnlyPaths: []string{"/parent/child"},
+			},
+		},
+		wantDirMap: map[string]*Directory{
+			"/device": {
+				Name: "Device",
+				Fields: map[string]*yang.Entry{
+					"parent": {Name: "parent", Type: nil},
+				},
+				Path: []string{"", "device"},
+			},
+			"/openconfig-simple/parent": {
+				Name: "Parent",
+				Fields: map[string]*yang.Entry{
+					"child": {Name: "child", Type: nil},
+				},
+				Path: []string{"", "openconfig-simple", "parent"},
+			},
+			"/openconfig-simple/parent/child": {
+				Name: "Parent_Child",
+				Fields: map[string]*yang.Entry{
+					"one":   {Name: "one", Type: &yang.YangType{Kind: yang.Ystring}},
+					"two":   {Name: "two", Type: &yang.YangType{Kind: yang.Ystring}},
+					"three": {Name: "three", Type: &yang.YangType{Kind: yang.Yenum}},
+					"four":  {Name: "four", Type: &yang.YangType{Kind: yang.Ybinary}},
+				},
+				Path: []string{"", "openconfig-simple", "parent", "child"},
+			},
+		},
+		wantTypeMap: map[string]map[string]*MappedType{
+			"/device": {
+				"parent": nil,
+			},
+			"/openconfig-simple/parent": {
+				"child": nil,
+			},
+			"/openconfig-simple/parent/child": {
+				"one":   {NativeType: "string"},
+				"two":   {NativeType: "string"},
+				"three": {NativeType: "E_Child_Three", IsEnumeratedValue: true},
+				"four":  {NativeType: "Binary"},
+			},
+		},
+	}, {
+		name:    "included path with leafref to a leaf outside of the included paths",
+		inFiles: []string{filepath.Join(datapath, "include-paths.yang")},
+		inConfig: &DirectoryGenConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+			ParseOptions: ParseOpts{
+				IncludeOnlyPaths: []string{"/b"},
+			},
+		},
+		wantDirMap: map[string]*Directory{
+			"/include-paths/b": {
+				Name: "B",
+				Fields: map[string]*yang.Entry{
+					"name-ref": {Name: "name-ref", Type: &yang.YangType{Kind: yang.Yleafref}},
+				},
+				Path: []string{"", "include-paths", "b"},
+			},
+		},
+		wantTypeMap: map[string]map[string]*MappedType{
+			"/include-paths/b": {
+				"name-ref": {
+					NativeType:          "string",
+					ResolvedLeafrefType: &MappedType{NativeType: "string", ZeroValue: `""`},
+				},
+			},
+		},
 	}}
 
 	// Simple helper function for error messages
@@ -2079,7 +3168,10 @@ func TestGenerateProto3(t *testing.T) {
 		// wantOutputFiles is a map keyed on protobuf package name with a path
 		// to the file that is expected for each package.
 		wantOutputFiles map[string]string
-		wantErr         bool
+		// wantDependencyGraph is the dependency graph of the generated
+		// packages, it is only compared when it is non-nil.
+		wantDependencyGraph map[string][]string
+		wantErr             bool
 	}{{
 		name:    "simple protobuf test with compression",
 		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.yang")},
@@ -2092,6 +3184,63 @@ func TestGenerateProto3(t *testing.T) {
 			"openconfig":        filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.compress.parent.formatted-txt"),
 			"openconfig.parent": filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.compress.parent.child.formatted-txt"),
 		},
+	}, {
+		name:    "simple protobuf test with compression and proto3 optional fields",
+		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+			ProtoOptions: ProtoOpts{
+				UseProto3Optional: true,
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig":        filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.compress.parent.formatted-txt"),
+			"openconfig.parent": filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.compress.parent.child.proto3-optional.formatted-txt"),
+		},
+	}, {
+		name:    "protobuf test with protoc-gen-validate constraints",
+		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-validate-rules.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+			ProtoOptions: ProtoOpts{
+				UseProto3Optional: true,
+				EmitValidateRules: true,
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig": filepath.Join(TestRoot, "testdata", "proto", "proto-validate-rules.formatted-txt"),
+		},
+	}, {
+		name:    "simple protobuf test without compression and with proto3 optional fields",
+		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.yang")},
+		inConfig: GeneratorConfig{
+			ProtoOptions: ProtoOpts{
+				UseProto3Optional: true,
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig.proto_test_a":              filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.nocompress.formatted-txt"),
+			"openconfig.proto_test_a.parent":       filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.nocompress.parent.formatted-txt"),
+			"openconfig.proto_test_a.parent.child": filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.nocompress.parent.child.proto3-optional.formatted-txt"),
+		},
+	}, {
+		name:    "protobuf test with module information annotations",
+		inFiles: []string{filepath.Join(datapath, "openconfig-versioned-mod.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+			ProtoOptions: ProtoOpts{
+				AnnotateModuleInfo: true,
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig": filepath.Join(TestRoot, "testdata", "proto", "openconfig-versioned-mod.module-info.formatted-txt"),
+		},
 	}, {
 		name:    "simple protobuf test without compression",
 		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-test-a.yang")},
@@ -2119,6 +3268,17 @@ func TestGenerateProto3(t *testing.T) {
 			"openconfig":       filepath.Join(TestRoot, "testdata", "proto", "enum-union.compress.formatted-txt"),
 			"openconfig.enums": filepath.Join(TestRoot, "testdata", "proto", "enum-union.compress.enums.formatted-txt"),
 		},
+	}, {
+		name:    "instance-identifier types with compression",
+		inFiles: []string{filepath.Join(datapath, "openconfig-instance-identifier.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig": filepath.Join(TestRoot, "testdata", "proto", "openconfig-instance-identifier.compress.formatted-txt"),
+		},
 	}, {
 		name:     "yang schema with a list",
 		inFiles:  []string{filepath.Join(TestRoot, "testdata", "proto", "proto-test-b.yang")},
@@ -2127,6 +3287,57 @@ func TestGenerateProto3(t *testing.T) {
 			"openconfig":        filepath.Join(TestRoot, "testdata", "proto", "proto-test-b.compress.formatted-txt"),
 			"openconfig.device": filepath.Join(TestRoot, "testdata", "proto", "proto-test-b.compress.device.formatted-txt"),
 		},
+	}, {
+		name:    "yang schema with a list output as a proto map",
+		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-test-b.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{CompressBehaviour: genutil.PreferIntendedConfig},
+			ProtoOptions:          ProtoOpts{UseProtoMaps: true},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig":        filepath.Join(TestRoot, "testdata", "proto", "proto-test-b.compress.maps.formatted-txt"),
+			"openconfig.device": filepath.Join(TestRoot, "testdata", "proto", "proto-test-b.compress.device.formatted-txt"),
+		},
+	}, {
+		name:    "yang schema with typedefs mapped to well-known types",
+		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-well-known-types.yang")},
+		inConfig: GeneratorConfig{
+			ProtoOptions: ProtoOpts{
+				WellKnownTypeMap: map[string]string{
+					"date-and-time": "Timestamp",
+					"timeticks64":   "google.protobuf.Duration",
+				},
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig.proto_well_known_types": filepath.Join(TestRoot, "testdata", "proto", "proto-well-known-types.formatted-txt"),
+		},
+	}, {
+		name:    "yang schema with typedefs mapped to well-known types, with nested messages",
+		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-well-known-types.yang")},
+		inConfig: GeneratorConfig{
+			ProtoOptions: ProtoOpts{
+				NestedMessages: true,
+				WellKnownTypeMap: map[string]string{
+					"date-and-time": "Timestamp",
+					"timeticks64":   "Duration",
+				},
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig.proto_well_known_types": filepath.Join(TestRoot, "testdata", "proto", "proto-well-known-types.nested.formatted-txt"),
+		},
+	}, {
+		name:    "yang schema with typedef mapped to unsupported well-known type",
+		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-well-known-types.yang")},
+		inConfig: GeneratorConfig{
+			ProtoOptions: ProtoOpts{
+				WellKnownTypeMap: map[string]string{
+					"date-and-time": "Struct",
+				},
+			},
+		},
+		wantErr: true,
 	}, {
 		name:    "yang schema with simple enumerations",
 		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-test-c.yang")},
@@ -2247,6 +3458,37 @@ func TestGenerateProto3(t *testing.T) {
 			"openconfig.enums":       filepath.Join(TestRoot, "testdata", "proto", "proto-enums.enums.formatted-txt"),
 			"openconfig.proto_enums": filepath.Join(TestRoot, "testdata", "proto", "proto-enums.formatted-txt"),
 		},
+	}, {
+		name:    "enums: yang schema with various types of enums with prefixed values",
+		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-enums.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				UseDefiningModuleForTypedefEnumNames: true,
+			},
+			ProtoOptions: ProtoOpts{
+				AnnotateEnumNames: true,
+				PrefixEnumValues:  true,
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig.enums":       filepath.Join(TestRoot, "testdata", "proto", "proto-enums.prefix-enum-values.enums.formatted-txt"),
+			"openconfig.proto_enums": filepath.Join(TestRoot, "testdata", "proto", "proto-enums.prefix-enum-values.formatted-txt"),
+		},
+	}, {
+		name:    "enums: yang schema with various types of enums with narrow integers",
+		inFiles: []string{filepath.Join(TestRoot, "testdata", "proto", "proto-enums.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				UseDefiningModuleForTypedefEnumNames: true,
+			},
+			ProtoOptions: ProtoOpts{
+				NarrowIntegers: true,
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig.enums":       filepath.Join(TestRoot, "testdata", "proto", "proto-enums.enums.formatted-txt"),
+			"openconfig.proto_enums": filepath.Join(TestRoot, "testdata", "proto", "proto-enums.narrow-integers.formatted-txt"),
+		},
 	}, {
 		name: "enums: yang schema with identity that adds to previous module",
 		inFiles: []string{
@@ -2262,11 +3504,37 @@ func TestGenerateProto3(t *testing.T) {
 			},
 		},
 		wantOutputFiles: map[string]string{
-			"openconfig.enums":       filepath.Join(TestRoot, "testdata", "proto", "proto-enums-addid.enums.formatted-txt"),
-			"openconfig.proto_enums": filepath.Join(TestRoot, "testdata", "proto", "proto-enums-addid.formatted-txt"),
+			"openconfig.enums":       filepath.Join(TestRoot, "testdata", "proto", "proto-enums-addid.enums.formatted-txt"),
+			"openconfig.proto_enums": filepath.Join(TestRoot, "testdata", "proto", "proto-enums-addid.formatted-txt"),
+		},
+	}, {
+		name: "yang schema with nested messages requested - uncompressed with fakeroot",
+		inFiles: []string{
+			filepath.Join(TestRoot, "testdata", "proto", "nested-messages.yang"),
+		},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot:                     true,
+				UseDefiningModuleForTypedefEnumNames: true,
+			},
+			ProtoOptions: ProtoOpts{
+				AnnotateEnumNames:   true,
+				AnnotateSchemaPaths: true,
+				NestedMessages:      true,
+			},
+		},
+		wantOutputFiles: map[string]string{
+			"openconfig":                 filepath.Join(TestRoot, "testdata", "proto", "nested-messages.openconfig.formatted-txt"),
+			"openconfig.enums":           filepath.Join(TestRoot, "testdata", "proto", "nested-messages.enums.formatted-txt"),
+			"openconfig.nested_messages": filepath.Join(TestRoot, "testdata", "proto", "nested-messages.nested_messages.formatted-txt"),
+		},
+		wantDependencyGraph: map[string][]string{
+			"openconfig":                 {"openconfig.nested_messages"},
+			"openconfig.enums":           {},
+			"openconfig.nested_messages": {"openconfig.enums"},
 		},
 	}, {
-		name: "yang schema with nested messages requested - uncompressed with fakeroot",
+		name: "yang schema with nested messages output to a single file - uncompressed with fakeroot",
 		inFiles: []string{
 			filepath.Join(TestRoot, "testdata", "proto", "nested-messages.yang"),
 		},
@@ -2279,13 +3547,23 @@ func TestGenerateProto3(t *testing.T) {
 				AnnotateEnumNames:   true,
 				AnnotateSchemaPaths: true,
 				NestedMessages:      true,
+				SingleFileOutput:    true,
 			},
 		},
 		wantOutputFiles: map[string]string{
-			"openconfig":                 filepath.Join(TestRoot, "testdata", "proto", "nested-messages.openconfig.formatted-txt"),
-			"openconfig.enums":           filepath.Join(TestRoot, "testdata", "proto", "nested-messages.enums.formatted-txt"),
-			"openconfig.nested_messages": filepath.Join(TestRoot, "testdata", "proto", "nested-messages.nested_messages.formatted-txt"),
+			"openconfig": filepath.Join(TestRoot, "testdata", "proto", "nested-messages.single-file.formatted-txt"),
+		},
+	}, {
+		name: "yang schema with single file output requested without nested messages",
+		inFiles: []string{
+			filepath.Join(TestRoot, "testdata", "proto", "nested-messages.yang"),
+		},
+		inConfig: GeneratorConfig{
+			ProtoOptions: ProtoOpts{
+				SingleFileOutput: true,
+			},
 		},
+		wantErr: true,
 	}, {
 		name: "yang schema with nested messages - compressed with fakeroot",
 		inFiles: []string{
@@ -2441,6 +3719,9 @@ func TestGenerateProto3(t *testing.T) {
 			}
 
 			gotProto := genCode()
+			if tt.wantErr {
+				return
+			}
 
 			allCode := bytes.Buffer{}
 
@@ -2505,6 +3786,12 @@ func TestGenerateProto3(t *testing.T) {
 				}
 			}
 
+			if tt.wantDependencyGraph != nil {
+				if diff := cmp.Diff(tt.wantDependencyGraph, gotProto.DependencyGraph); diff != "" {
+					t.Errorf("%s: cg.GenerateProto3(%v, %v) did not get expected dependency graph, diff(-want, +got):\n%s", tt.name, tt.inFiles, tt.inIncludePaths, diff)
+				}
+			}
+
 			for i := 0; i < deflakeRuns; i++ {
 				got := genCode()
 				var gotCodeBuf bytes.Buffer
@@ -2533,6 +3820,294 @@ func TestGenerateProto3(t *testing.T) {
 	}
 }
 
+// TestGenerateProto3SingleFile checks that the messages and enumerations that
+// are output when SingleFileOutput is set are the same as those that are output
+// in the per-package files when it is not.
+func TestGenerateProto3SingleFile(t *testing.T) {
+	inFiles := []string{filepath.Join(TestRoot, "testdata", "proto", "nested-messages.yang")}
+	genCode := func(singleFile bool) *GeneratedProto3 {
+		cg := NewYANGCodeGenerator(&GeneratorConfig{
+			Caller: "codegen-tests",
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot: true,
+			},
+			ProtoOptions: ProtoOpts{
+				AnnotateEnumNames:   true,
+				AnnotateSchemaPaths: true,
+				NestedMessages:      true,
+				SingleFileOutput:    singleFile,
+			},
+		})
+		got, err := cg.GenerateProto3(inFiles, nil)
+		if err != nil {
+			t.Fatalf("cg.GenerateProto3(%v, nil), SingleFileOutput: %v: got unexpected error: %v", inFiles, singleFile, err)
+		}
+		return got
+	}
+
+	split, single := genCode(false), genCode(true)
+	if len(single.Packages) != 1 {
+		t.Fatalf("did not get a single package in combined output, got: %d packages", len(single.Packages))
+	}
+	combined, ok := single.Packages["openconfig"]
+	if !ok {
+		t.Fatalf("did not get package openconfig in combined output, got: %v", single.Packages)
+	}
+	combinedCode := strings.Join(append(append([]string{}, combined.Messages...), combined.Enums...), "\n")
+
+	for n, pkg := range split.Packages {
+		for _, code := range append(append([]string{}, pkg.Messages...), pkg.Enums...) {
+			if n != "openconfig" {
+				code = indentProtoCode(code)
+			}
+			if !strings.Contains(combinedCode, strings.Trim(code, "\n")) {
+				t.Errorf("combined output did not contain code from package %s:\n%s", n, code)
+			}
+		}
+		if strings.Contains(combined.Header, fmt.Sprintf("import \"%s\";", filepath.Join(pkg.FilePath...))) {
+			t.Errorf("combined output imports package %s, which is included in the combined file", n)
+		}
+	}
+}
+
+// TestIdentifierSanitizer checks that the IdentifierSanitizer transformation
+// option is applied to YANG names containing non-ASCII characters in both Go
+// and protobuf code generation.
+func TestIdentifierSanitizer(t *testing.T) {
+	inFiles := []string{filepath.Join(datapath, "unicode-names.yang")}
+	transliterate := strings.NewReplacer("ö", "oe", "ß", "ss").Replace
+
+	tests := []struct {
+		name        string
+		inSanitizer func(string) string
+		// wantGoStructs is the set of struct names expected in Go output.
+		wantGoStructs []string
+		// wantGoSnippets are snippets expected in the Go struct definitions.
+		wantGoSnippets []string
+		// wantProtoSnippets are snippets expected in the proto messages.
+		wantProtoSnippets []string
+	}{{
+		name:          "no sanitizer",
+		wantGoStructs: []string{"UnicodeNames_Größe"},
+		wantGoSnippets: []string{
+			"Höhe\t*string\t`path:\"höhe\"",
+		},
+		wantProtoSnippets: []string{
+			"message Größe {",
+			"ywrapper.StringValue h_he = ",
+		},
+	}, {
+		name:          "transliterating sanitizer",
+		inSanitizer:   transliterate,
+		wantGoStructs: []string{"UnicodeNames_Groesse"},
+		wantGoSnippets: []string{
+			"Hoehe\t*string\t`path:\"höhe\"",
+		},
+		wantProtoSnippets: []string{
+			"message Groesse {",
+			"ywrapper.StringValue hoehe = ",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewYANGCodeGenerator(&GeneratorConfig{
+				TransformationOptions: TransformationOpts{
+					IdentifierSanitizer: tt.inSanitizer,
+				},
+			})
+
+			gotGo, errs := cg.GenerateGoCode(inFiles, nil)
+			if errs != nil {
+				t.Fatalf("GenerateGoCode(%v, nil): got unexpected errors: %v", inFiles, errs)
+			}
+			var gotStructs []string
+			var gotGoCode strings.Builder
+			for _, s := range gotGo.Structs {
+				gotStructs = append(gotStructs, s.StructName)
+				gotGoCode.WriteString(s.StructDef)
+			}
+			if diff := cmp.Diff(tt.wantGoStructs, gotStructs); diff != "" {
+				t.Errorf("GenerateGoCode(%v, nil): did not get expected structs, diff(-want, +got):\n%s", inFiles, diff)
+			}
+			for _, want := range tt.wantGoSnippets {
+				if !strings.Contains(gotGoCode.String(), want) {
+					t.Errorf("GenerateGoCode(%v, nil): did not find %q in generated code:\n%s", inFiles, want, gotGoCode.String())
+				}
+			}
+
+			gotProto, errs := cg.GenerateProto3(inFiles, nil)
+			if errs != nil {
+				t.Fatalf("GenerateProto3(%v, nil): got unexpected errors: %v", inFiles, errs)
+			}
+			var gotProtoCode strings.Builder
+			for _, pkg := range gotProto.Packages {
+				for _, m := range pkg.Messages {
+					gotProtoCode.WriteString(m)
+				}
+			}
+			for _, want := range tt.wantProtoSnippets {
+				if !strings.Contains(gotProtoCode.String(), want) {
+					t.Errorf("GenerateProto3(%v, nil): did not find %q in generated code:\n%s", inFiles, want, gotProtoCode.String())
+				}
+			}
+		})
+	}
+}
+
+// TestTypeNameAbbreviations checks that type name abbreviations are applied
+// to the names of generated structs and unions, but not to the names of
+// enumerated types.
+func TestTypeNameAbbreviations(t *testing.T) {
+	inFiles := []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")}
+
+	cg := NewYANGCodeGenerator(&GeneratorConfig{
+		GoOptions: GoOpts{
+			GenerateSimpleUnions: true,
+		},
+		TransformationOptions: TransformationOpts{
+			CompressBehaviour:                    genutil.PreferIntendedConfig,
+			ShortenEnumLeafNames:                 true,
+			UseDefiningModuleForTypedefEnumNames: true,
+			EnumerationsUseUnderscores:           true,
+			TypeNameAbbreviations: map[string]string{
+				"Neighbor":             "Nbr",
+				"EnabledAddressFamily": "Afs",
+			},
+		},
+	})
+
+	gotGo, errs := cg.GenerateGoCode(inFiles, nil)
+	if errs != nil {
+		t.Fatalf("GenerateGoCode(%v, nil): got unexpected errors: %v", inFiles, errs)
+	}
+	var gotStructs []string
+	var gotGoCode strings.Builder
+	for _, s := range gotGo.Structs {
+		gotStructs = append(gotStructs, s.StructName)
+		gotGoCode.WriteString(s.StructDef)
+		gotGoCode.WriteString(s.Interfaces)
+	}
+	if diff := cmp.Diff([]string{"Bgp", "Bgp_Nbr"}, gotStructs); diff != "" {
+		t.Errorf("GenerateGoCode(%v, nil): did not get expected structs, diff(-want, +got):\n%s", inFiles, diff)
+	}
+	for _, want := range []string{
+		"EnabledAddressFamily\t[]Bgp_Nbr_Afs_Union\t",
+		"type Bgp_Nbr_Afs_Union interface {",
+		"SessionState\tE_Neighbor_SessionState\t",
+	} {
+		if !strings.Contains(gotGoCode.String(), want) {
+			t.Errorf("GenerateGoCode(%v, nil): did not find %q in generated code:\n%s", inFiles, want, gotGoCode.String())
+		}
+	}
+}
+
+// TestNameTransform checks that the NameTransform function is applied to
+// the names of generated structs, fields and enumerated types, and that the
+// names that it returns are made unique.
+func TestNameTransform(t *testing.T) {
+	inFiles := []string{filepath.Join(datapath, "openconfig-simple.yang")}
+
+	tests := []struct {
+		name        string
+		inTransform func(NameKind, string, string) string
+		// wantGoStructs is the set of struct names expected in Go output.
+		wantGoStructs []string
+		// wantGoSnippets are snippets expected in the generated Go code.
+		wantGoSnippets []string
+	}{{
+		name:          "no transform",
+		wantGoStructs: []string{"Device", "Parent", "Parent_Child", "RemoteContainer"},
+		wantGoSnippets: []string{
+			"ALeaf\t*string\t`path:\"config/a-leaf\"",
+			"Three\tE_Child_Three\t`path:\"config/three\"",
+			"type E_Child_Three int64",
+		},
+	}, {
+		name: "lowercase a single struct name",
+		inTransform: func(kind NameKind, _, proposed string) string {
+			if kind == StructNameKind && proposed == "RemoteContainer" {
+				return strings.ToLower(proposed)
+			}
+			return proposed
+		},
+		wantGoStructs: []string{"Device", "Parent", "Parent_Child", "remotecontainer"},
+		wantGoSnippets: []string{
+			"RemoteContainer\t*remotecontainer\t`path:\"remote-container\"",
+		},
+	}, {
+		name: "struct name that collides is made unique",
+		inTransform: func(kind NameKind, yangPath, proposed string) string {
+			if kind == StructNameKind && yangPath == "/openconfig-simple/remote-container" {
+				return "Parent"
+			}
+			return proposed
+		},
+		wantGoStructs: []string{"Device", "Parent", "Parent_", "Parent_Child"},
+		wantGoSnippets: []string{
+			"// Parent_ represents the /openconfig-simple/remote-container YANG schema element.",
+			"Parent\t*Parent\t`path:\"parent\"",
+			"RemoteContainer\t*Parent_\t`path:\"remote-container\"",
+		},
+	}, {
+		name: "field and enumerated type names",
+		inTransform: func(kind NameKind, yangPath, proposed string) string {
+			switch {
+			case kind == FieldNameKind && strings.HasSuffix(yangPath, "/a-leaf"):
+				return "LeafA"
+			case kind == EnumNameKind:
+				return strings.ReplaceAll(proposed, "_", "")
+			}
+			return proposed
+		},
+		wantGoStructs: []string{"Device", "Parent", "Parent_Child", "RemoteContainer"},
+		wantGoSnippets: []string{
+			"LeafA\t*string\t`path:\"config/a-leaf\"",
+			"Three\tE_ChildThree\t`path:\"config/three\"",
+			"type E_ChildThree int64",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewYANGCodeGenerator(&GeneratorConfig{
+				TransformationOptions: TransformationOpts{
+					CompressBehaviour:          genutil.PreferIntendedConfig,
+					GenerateFakeRoot:           true,
+					ShortenEnumLeafNames:       true,
+					EnumerationsUseUnderscores: true,
+				},
+				GoOptions: GoOpts{
+					GenerateSimpleUnions: true,
+				},
+				NameTransform: tt.inTransform,
+			})
+
+			gotGo, errs := cg.GenerateGoCode(inFiles, nil)
+			if errs != nil {
+				t.Fatalf("GenerateGoCode(%v, nil): got unexpected errors: %v", inFiles, errs)
+			}
+			var gotStructs []string
+			var gotGoCode strings.Builder
+			for _, s := range gotGo.Structs {
+				gotStructs = append(gotStructs, s.StructName)
+				gotGoCode.WriteString(s.String())
+			}
+			for _, e := range gotGo.Enums {
+				gotGoCode.WriteString(e)
+			}
+			if diff := cmp.Diff(tt.wantGoStructs, gotStructs); diff != "" {
+				t.Errorf("GenerateGoCode(%v, nil): did not get expected structs, diff(-want, +got):\n%s", inFiles, diff)
+			}
+			for _, want := range tt.wantGoSnippets {
+				if !strings.Contains(gotGoCode.String(), want) {
+					t.Errorf("GenerateGoCode(%v, nil): did not find %q in generated code:\n%s", inFiles, want, gotGoCode.String())
+				}
+			}
+		})
+	}
+}
+
 func TestMakeFakeRoot(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -2675,3 +4250,255 @@ func TestCreateFakeRoot(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateJSONSchemaPerModule(t *testing.T) {
+	tests := []struct {
+		name        string
+		inFiles     []string
+		inConfig    GeneratorConfig
+		wantModules []string
+	}{{
+		name:    "openconfig-options with compression and fakeroot",
+		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+		},
+		wantModules: []string{"openconfig-options"},
+	}, {
+		name:    "openconfig-options without compression",
+		inFiles: []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot: true,
+			},
+		},
+		wantModules: []string{"openconfig-options"},
+	}, {
+		name: "augmented module stitched into target",
+		inFiles: []string{
+			filepath.Join(datapath, "openconfig-simple-target.yang"),
+			filepath.Join(datapath, "openconfig-simple-augment.yang"),
+		},
+		inConfig: GeneratorConfig{
+			TransformationOptions: TransformationOpts{
+				GenerateFakeRoot: true,
+			},
+		},
+		wantModules: []string{"openconfig-simple-augment", "openconfig-simple-target"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monoConfig := tt.inConfig
+			monoConfig.GenerateJSONSchema = true
+			gotMono, errs := NewYANGCodeGenerator(&monoConfig).GenerateGoCode(tt.inFiles, nil)
+			if errs != nil {
+				t.Fatalf("GenerateGoCode(%v, nil): got unexpected errors generating monolithic schema: %v", tt.inFiles, errs)
+			}
+
+			perModConfig := tt.inConfig
+			perModConfig.GenerateJSONSchema = true
+			perModConfig.GenerateJSONSchemaPerModule = true
+			gotPerMod, errs := NewYANGCodeGenerator(&perModConfig).GenerateGoCode(tt.inFiles, nil)
+			if errs != nil {
+				t.Fatalf("GenerateGoCode(%v, nil): got unexpected errors generating per-module schema: %v", tt.inFiles, errs)
+			}
+
+			if gotPerMod.RawJSONSchema != nil {
+				t.Errorf("GenerateGoCode(%v, nil): got unexpected monolithic schema when generating per-module schema", tt.inFiles)
+			}
+			var gotModules []string
+			for m := range gotPerMod.RawJSONSchemaPerModule {
+				gotModules = append(gotModules, m)
+			}
+			sort.Strings(gotModules)
+			if diff := cmp.Diff(tt.wantModules, gotModules); diff != "" {
+				t.Errorf("GenerateGoCode(%v, nil): did not get expected schema modules, diff(-want, +got):\n%s", tt.inFiles, diff)
+			}
+			if !strings.Contains(gotPerMod.OneOffHeader, "ygot.GzipToSchemaModules(ySchema)") {
+				t.Errorf("GenerateGoCode(%v, nil): generated header did not unzip per-module schema:\n%s", tt.inFiles, gotPerMod.OneOffHeader)
+			}
+
+			monoGzip, err := WriteGzippedByteSlice(gotMono.RawJSONSchema)
+			if err != nil {
+				t.Fatalf("cannot gzip monolithic schema: %v", err)
+			}
+			wantSchema, err := ygot.GzipToSchema(monoGzip)
+			if err != nil {
+				t.Fatalf("cannot unzip monolithic schema: %v", err)
+			}
+
+			perModGzip := map[string][]byte{}
+			for m, js := range gotPerMod.RawJSONSchemaPerModule {
+				if perModGzip[m], err = WriteGzippedByteSlice(js); err != nil {
+					t.Fatalf("cannot gzip schema for module %s: %v", m, err)
+				}
+			}
+			gotSchema, err := ygot.GzipToSchemaModules(perModGzip)
+			if err != nil {
+				t.Fatalf("cannot unzip per-module schema: %v", err)
+			}
+
+			// The Parent field of each yang.Entry is not serialised, and
+			// hence JSON is used to compare the stitched schema trees.
+			wantJSON, err := json.MarshalIndent(wantSchema, "", "  ")
+			if err != nil {
+				t.Fatalf("cannot marshal monolithic schema: %v", err)
+			}
+			gotJSON, err := json.MarshalIndent(gotSchema, "", "  ")
+			if err != nil {
+				t.Fatalf("cannot marshal stitched schema: %v", err)
+			}
+			if diff := cmp.Diff(string(wantJSON), string(gotJSON)); diff != "" {
+				t.Errorf("stitched per-module schema did not match monolithic schema, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJSONSchemaIndent(t *testing.T) {
+	inFiles := []string{filepath.Join(TestRoot, "testdata/schema/openconfig-options.yang")}
+	tests := []struct {
+		name     string
+		inConfig GeneratorConfig
+	}{{
+		name: "monolithic schema",
+		inConfig: GeneratorConfig{
+			GenerateJSONSchema: true,
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+		},
+	}, {
+		name: "per-module schema",
+		inConfig: GeneratorConfig{
+			GenerateJSONSchema:          true,
+			GenerateJSONSchemaPerModule: true,
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.PreferIntendedConfig,
+				GenerateFakeRoot:  true,
+			},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compact, errs := NewYANGCodeGenerator(&tt.inConfig).GenerateGoCode(inFiles, nil)
+			if errs != nil {
+				t.Fatalf("GenerateGoCode(%v, nil): got unexpected errors: %v", inFiles, errs)
+			}
+
+			indentConfig := tt.inConfig
+			indentConfig.JSONSchemaIndent = "\t"
+			indented, errs := NewYANGCodeGenerator(&indentConfig).GenerateGoCode(inFiles, nil)
+			if errs != nil {
+				t.Fatalf("GenerateGoCode(%v, nil): got unexpected errors with JSONSchemaIndent: %v", inFiles, errs)
+			}
+
+			if compact.JSONSchemaCode != indented.JSONSchemaCode {
+				t.Errorf("GenerateGoCode(%v, nil): JSONSchemaIndent changed the embedded schema", inFiles)
+			}
+
+			wantSchemas := map[string][]byte{"": compact.RawJSONSchema}
+			gotSchemas := map[string][]byte{"": indented.RawJSONSchema}
+			if tt.inConfig.GenerateJSONSchemaPerModule {
+				wantSchemas, gotSchemas = compact.RawJSONSchemaPerModule, indented.RawJSONSchemaPerModule
+			}
+			if len(gotSchemas) != len(wantSchemas) {
+				t.Fatalf("GenerateGoCode(%v, nil): got %d schemas with JSONSchemaIndent, want %d", inFiles, len(gotSchemas), len(wantSchemas))
+			}
+			for m, want := range wantSchemas {
+				got := gotSchemas[m]
+				if !bytes.Contains(got, []byte("\n\t\"")) {
+					t.Errorf("schema %q was not indented using JSONSchemaIndent:\n%s", m, got)
+				}
+
+				var wantJSON, gotJSON map[string]interface{}
+				if err := json.Unmarshal(want, &wantJSON); err != nil {
+					t.Fatalf("cannot unmarshal schema %q: %v", m, err)
+				}
+				if err := json.Unmarshal(got, &gotJSON); err != nil {
+					t.Fatalf("cannot unmarshal schema %q generated with JSONSchemaIndent: %v", m, err)
+				}
+				if diff := cmp.Diff(wantJSON, gotJSON); diff != "" {
+					t.Errorf("schema %q generated with JSONSchemaIndent did not match, diff(-want, +got):\n%s", m, diff)
+				}
+
+				gz, err := WriteGzippedByteSlice(got)
+				if err != nil {
+					t.Fatalf("cannot gzip schema %q: %v", m, err)
+				}
+				if _, err := ygot.GzipToSchema(gz); err != nil {
+					t.Errorf("cannot unmarshal schema %q generated with JSONSchemaIndent: %v", m, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRuntimeCompatVersion(t *testing.T) {
+	inFiles := []string{filepath.Join(datapath, "openconfig-simple.yang")}
+	tests := []struct {
+		name                string
+		inVersion           string
+		wantBelongingModule bool
+		wantErrSubstring    string
+	}{{
+		name:                "current release",
+		wantBelongingModule: true,
+	}, {
+		name:                "v0.13",
+		inVersion:           "v0.13",
+		wantBelongingModule: true,
+	}, {
+		name:      "v0.12",
+		inVersion: "v0.12",
+	}, {
+		name:             "unsupported version",
+		inVersion:        "v0.1",
+		wantErrSubstring: `unsupported ygot runtime compatibility version "v0.1"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewYANGCodeGenerator(&GeneratorConfig{
+				GenerateJSONSchema: true,
+				GoOptions: GoOpts{
+					GenerateSimpleUnions: true,
+					RuntimeCompatVersion: tt.inVersion,
+				},
+				TransformationOptions: TransformationOpts{
+					CompressBehaviour: genutil.PreferIntendedConfig,
+					GenerateFakeRoot:  true,
+				},
+			})
+			got, errs := cg.GenerateGoCode(inFiles, nil)
+			var err error
+			if errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GenerateGoCode(%v, nil): %v", inFiles, diff)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(got.Structs) == 0 {
+				t.Fatalf("GenerateGoCode(%v, nil): got no structs", inFiles)
+			}
+			for _, s := range got.Structs {
+				if gotBM := strings.Contains(s.Methods, "ΛBelongingModule()"); gotBM != tt.wantBelongingModule {
+					t.Errorf("struct %s: got ΛBelongingModule method: %v, want: %v", s.StructName, gotBM, tt.wantBelongingModule)
+				}
+				if !strings.Contains(s.Methods, "ΛEnumTypeMap()") {
+					t.Errorf("struct %s: did not get ΛEnumTypeMap method", s.StructName)
+				}
+			}
+		})
+	}
+}
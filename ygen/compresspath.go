@@ -0,0 +1,96 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/util"
+)
+
+// CompressPath takes an uncompressed data tree path, expressed as a slice of
+// path element names (e.g., []string{"interfaces", "interface", "config",
+// "mtu"}), and returns the path of the same element within code generated
+// with the supplied compression behaviour. The path is resolved against the
+// schema, which is the module (or other root entry) within which the first
+// element of the path is a child, such that the containers that are removed
+// by compression (config and state containers, and the containers
+// surrounding lists) can be identified. The same rules are applied as are used
+// to determine the paths of elements during code generation.
+//
+// An error is returned if the path does not exist within the schema, or if the
+// element it identifies is not output within the generated code, for example,
+// since it is a config container that is removed by compression.
+func CompressPath(schema *yang.Entry, path []string, behaviour genutil.CompressBehaviour) ([]string, error) {
+	if schema == nil {
+		return nil, errors.New("nil schema supplied")
+	}
+	if len(path) == 0 {
+		return nil, errors.New("empty path supplied")
+	}
+
+	var elems []*yang.Entry
+	e := schema
+	for _, p := range path {
+		ch := findDataTreeChild(e, util.StripModulePrefix(p))
+		if ch == nil {
+			return nil, fmt.Errorf("cannot find element %s within %s", p, e.Path())
+		}
+		if behaviour.StateExcluded() && ch.ReadOnly() {
+			return nil, fmt.Errorf("element %s is config false, and is excluded from the generated code", ch.Path())
+		}
+		elems = append(elems, ch)
+		e = ch
+	}
+
+	if !behaviour.CompressEnabled() {
+		var uncompressed []string
+		for _, ch := range elems {
+			uncompressed = append(uncompressed, ch.Name)
+		}
+		return uncompressed, nil
+	}
+
+	valid := util.IsOCCompressedValidElement
+	if !behaviour.ListContainersCompressed() {
+		valid = util.IsOCCompressedValidElementKeepListContainers
+	}
+
+	if !valid(e) {
+		return nil, fmt.Errorf("element %s is removed from the compressed schema", e.Path())
+	}
+	var compressed []string
+	for _, ch := range elems {
+		if valid(ch) {
+			compressed = append(compressed, ch.Name)
+		}
+	}
+	return compressed, nil
+}
+
+// findDataTreeChild returns the child of the yang.Entry e with the supplied
+// name, skipping any choice and case nodes, which do not appear in data tree
+// paths. It returns nil if no such child exists.
+func findDataTreeChild(e *yang.Entry, name string) *yang.Entry {
+	for _, ch := range util.FindFirstNonChoiceOrCase(e) {
+		if ch.Name == name {
+			return ch
+		}
+	}
+	return nil
+}
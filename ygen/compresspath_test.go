@@ -0,0 +1,150 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+)
+
+func TestCompressPath(t *testing.T) {
+	tests := []struct {
+		name             string
+		inFile           string
+		inModule         string
+		inPath           []string
+		inBehaviour      genutil.CompressBehaviour
+		want             []string
+		wantErrSubstring string
+	}{{
+		name:        "config leaf with compression",
+		inFile:      "openconfig-simple.yang",
+		inModule:    "openconfig-simple",
+		inPath:      []string{"parent", "child", "config", "one"},
+		inBehaviour: genutil.PreferIntendedConfig,
+		want:        []string{"parent", "child", "one"},
+	}, {
+		name:        "state leaf with compression",
+		inFile:      "openconfig-simple.yang",
+		inModule:    "openconfig-simple",
+		inPath:      []string{"parent", "child", "state", "two"},
+		inBehaviour: genutil.PreferOperationalState,
+		want:        []string{"parent", "child", "two"},
+	}, {
+		name:        "path with module prefixes",
+		inFile:      "openconfig-simple.yang",
+		inModule:    "openconfig-simple",
+		inPath:      []string{"ocs:remote-container", "ocs:config", "ocs:a-leaf"},
+		inBehaviour: genutil.PreferIntendedConfig,
+		want:        []string{"remote-container", "a-leaf"},
+	}, {
+		name:        "container with compression",
+		inFile:      "openconfig-simple.yang",
+		inModule:    "openconfig-simple",
+		inPath:      []string{"parent", "child"},
+		inBehaviour: genutil.PreferIntendedConfig,
+		want:        []string{"parent", "child"},
+	}, {
+		name:        "uncompressed",
+		inFile:      "openconfig-simple.yang",
+		inModule:    "openconfig-simple",
+		inPath:      []string{"parent", "child", "config", "one"},
+		inBehaviour: genutil.Uncompressed,
+		want:        []string{"parent", "child", "config", "one"},
+	}, {
+		name:             "config container removed by compression",
+		inFile:           "openconfig-simple.yang",
+		inModule:         "openconfig-simple",
+		inPath:           []string{"parent", "child", "config"},
+		inBehaviour:      genutil.PreferIntendedConfig,
+		wantErrSubstring: "element /openconfig-simple/parent/child/config is removed from the compressed schema",
+	}, {
+		name:             "state leaf with state excluded",
+		inFile:           "openconfig-simple.yang",
+		inModule:         "openconfig-simple",
+		inPath:           []string{"parent", "child", "state", "two"},
+		inBehaviour:      genutil.ExcludeDerivedState,
+		wantErrSubstring: "is config false",
+	}, {
+		name:             "path not in schema",
+		inFile:           "openconfig-simple.yang",
+		inModule:         "openconfig-simple",
+		inPath:           []string{"parent", "nonexistent"},
+		inBehaviour:      genutil.PreferIntendedConfig,
+		wantErrSubstring: "cannot find element nonexistent within /openconfig-simple/parent",
+	}, {
+		name:             "empty path",
+		inFile:           "openconfig-simple.yang",
+		inModule:         "openconfig-simple",
+		inBehaviour:      genutil.PreferIntendedConfig,
+		wantErrSubstring: "empty path supplied",
+	}, {
+		name:        "leaf within list with surrounding container",
+		inFile:      "enum-module.yang",
+		inModule:    "enum-module",
+		inPath:      []string{"a-lists", "a-list", "state", "value"},
+		inBehaviour: genutil.PreferOperationalState,
+		want:        []string{"a-list", "value"},
+	}, {
+		name:        "list key with surrounding container",
+		inFile:      "enum-module.yang",
+		inModule:    "enum-module",
+		inPath:      []string{"b-lists", "b-list", "value"},
+		inBehaviour: genutil.PreferIntendedConfig,
+		want:        []string{"b-list", "value"},
+	}, {
+		name:        "leaf within list with surrounding container retained",
+		inFile:      "enum-module.yang",
+		inModule:    "enum-module",
+		inPath:      []string{"a-lists", "a-list", "state", "value"},
+		inBehaviour: genutil.PreferIntendedConfigKeepListContainers,
+		want:        []string{"a-lists", "a-list", "value"},
+	}, {
+		name:             "surrounding container removed by compression",
+		inFile:           "enum-module.yang",
+		inModule:         "enum-module",
+		inPath:           []string{"a-lists"},
+		inBehaviour:      genutil.PreferIntendedConfig,
+		wantErrSubstring: "element /enum-module/a-lists is removed from the compressed schema",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modules, errs := processModules([]string{filepath.Join(datapath, tt.inFile)}, nil, yang.Options{}, false)
+			if errs != nil {
+				t.Fatalf("processModules: could not parse %s, got errors: %v", tt.inFile, errs)
+			}
+			var schema *yang.Entry
+			for _, m := range modules {
+				if m.Name == tt.inModule {
+					schema = m
+				}
+			}
+
+			got, err := CompressPath(schema, tt.inPath, tt.inBehaviour)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("CompressPath(%v, %v): did not get expected error, %s", tt.inPath, tt.inBehaviour, diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CompressPath(%v, %v): did not get expected path, (-want +got):\n%s", tt.inPath, tt.inBehaviour, diff)
+			}
+		})
+	}
+}
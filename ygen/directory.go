@@ -20,6 +20,7 @@ package ygen
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 	"strings"
@@ -175,19 +176,22 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 			}
 			rootModule = util.SchemaTreeRoot(dir.Entry).Name
 		}
-		var definingModuleName string
+		var definingModuleName, definingModuleRevision string
 		if definingModule := yang.RootNode(dir.Entry.Node); definingModule != nil {
 			definingModuleName = definingModule.Name
+			definingModuleRevision = latestRevision(definingModule)
 		}
 		pd := &ParsedDirectory{
-			Name:              dir.Name,
-			Path:              util.SlicePathToString(dir.Path),
-			PackageName:       packageName,
-			IsFakeRoot:        dir.IsFakeRoot,
-			BelongingModule:   belongingModule,
-			DefiningModule:    definingModuleName,
-			RootElementModule: rootModule,
-			ConfigFalse:       !util.IsConfig(dir.Entry),
+			Name:                   dir.Name,
+			Path:                   util.SlicePathToString(dir.Path),
+			PackageName:            packageName,
+			IsFakeRoot:             dir.IsFakeRoot,
+			BelongingModule:        belongingModule,
+			DefiningModule:         definingModuleName,
+			DefiningModuleRevision: definingModuleRevision,
+			RootElementModule:      rootModule,
+			ConfigFalse:            !util.IsConfig(dir.Entry),
+			Status:                 statusStatement(dir.Entry),
 		}
 		switch {
 		case dir.Entry.IsList():
@@ -227,7 +231,17 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 				}
 			}
 
-			name, err := langMapper.FieldName(field)
+			nameEntry := field
+			if genutil.IsKeptStateFieldKey(fn) {
+				// The "state" version of a field that also exists under
+				// "config" is named with a "state" suffix, such that it
+				// is distinct from the "config" version of the field.
+				stateEntry := *field
+				stateEntry.Name = fmt.Sprintf("%s-state", field.Name)
+				stateEntry.Exts = nil
+				nameEntry = &stateEntry
+			}
+			name, err := langMapper.FieldName(nameEntry)
 			if err != nil {
 				return nil, err
 			}
@@ -248,12 +262,28 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 					SchemaPath:        util.SchemaTreePathNoModule(field),
 					LeafrefTargetPath: target.Path(),
 					Description:       field.Description,
+					Mandatory:         field.Mandatory == yang.TSTrue,
 				},
 				MappedPaths:             mp,
 				MappedPathModules:       mm,
 				ShadowMappedPaths:       smp,
 				ShadowMappedPathModules: smm,
 			}
+			if opts.TransformationOptions.TrackChoiceMembership {
+				nd.YANGDetails.ChoiceMembership = choiceMembership(field)
+			}
+			nd.YANGDetails.Must = mustStatements(field)
+			nd.YANGDetails.Status = statusStatement(field)
+			if when, ok := field.GetWhenXPath(); ok {
+				nd.YANGDetails.When = when
+			}
+			if la := field.ListAttr; la != nil {
+				nd.YANGDetails.MinElements = la.MinElements
+				if la.MaxElements != math.MaxUint64 {
+					nd.YANGDetails.MaxElements = la.MaxElements
+				}
+				nd.YANGDetails.OrderedByUser = la.OrderedBy != nil && la.OrderedBy.Name == "user"
+			}
 			if hasShadowField {
 				nd.YANGDetails.ShadowSchemaPath = util.SchemaTreePathNoModule(shadowField)
 			}
@@ -271,8 +301,15 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 
 				nd.Type = t
 				nd.LangType = mtype
+				rangeType := field.Type
+				if target != nil {
+					rangeType = target.Type
+				}
 				nd.YANGDetails.Type = &YANGType{
-					Name: field.Type.Name,
+					Name:    field.Type.Name,
+					Range:   restrictedIntegerRange(rangeType),
+					Length:  restrictedLength(rangeType),
+					Pattern: restrictedPattern(rangeType),
 				}
 			case field.IsList():
 				nd.Type = ListNode
@@ -300,6 +337,107 @@ func getOrderedDirDetails(langMapper LangMapper, directory map[string]*Directory
 	return dirDets, nil
 }
 
+// mustStatements returns the XPath expressions of the must statements of the
+// entry e, in the order in which they are specified in the schema.
+func mustStatements(e *yang.Entry) []string {
+	var musts []string
+	for _, m := range e.Extra["must"] {
+		if must, ok := m.(*yang.Must); ok {
+			musts = append(musts, must.Name)
+		}
+	}
+	return musts
+}
+
+// statusStatement returns the argument of the status statement of the entry
+// e, or the empty string if it does not have a status statement.
+func statusStatement(e *yang.Entry) string {
+	for _, s := range e.Extra["status"] {
+		if v, ok := s.(*yang.Value); ok && v != nil {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+// choiceMembership returns the names of the choice and case statements that
+// directly enclose the entry e in the schema, from outermost to innermost,
+// separated by "/". It returns the empty string if e is not within a choice.
+func choiceMembership(e *yang.Entry) string {
+	var names []string
+	for p := e.Parent; p != nil && (p.IsChoice() || p.IsCase()); p = p.Parent {
+		names = append([]string{p.Name}, names...)
+	}
+	return strings.Join(names, "/")
+}
+
+// latestRevision returns the most recent of the dates specified by the
+// revision statements of the module m, or the empty string if it has no
+// revision statements.
+func latestRevision(m *yang.Module) string {
+	var latest string
+	for _, r := range m.Revision {
+		// Revision dates are of the form YYYY-MM-DD, and hence can be
+		// compared lexically.
+		if r.Name > latest {
+			latest = r.Name
+		}
+	}
+	return latest
+}
+
+// builtinIntegerRanges maps the YANG integer types to the range of values
+// of the built-in type.
+var builtinIntegerRanges = map[yang.TypeKind]yang.YangRange{
+	yang.Yint8:   yang.Int8Range,
+	yang.Yint16:  yang.Int16Range,
+	yang.Yint32:  yang.Int32Range,
+	yang.Yint64:  yang.Int64Range,
+	yang.Yuint8:  yang.Uint8Range,
+	yang.Yuint16: yang.Uint16Range,
+	yang.Yuint32: yang.Uint32Range,
+	yang.Yuint64: yang.Uint64Range,
+}
+
+// restrictedIntegerRange returns the range of the YANG type t if it is an
+// integer type whose range is restricted beyond that of its built-in type,
+// and nil otherwise.
+func restrictedIntegerRange(t *yang.YangType) yang.YangRange {
+	if t == nil {
+		return nil
+	}
+	br, ok := builtinIntegerRanges[t.Kind]
+	if !ok || len(t.Range) == 0 || t.Range.Equal(br) {
+		return nil
+	}
+	return t.Range
+}
+
+// restrictedLength returns the length restriction of the YANG type t if it is
+// a string or binary type whose length is restricted, and nil otherwise.
+func restrictedLength(t *yang.YangType) yang.YangRange {
+	if t == nil || (t.Kind != yang.Ystring && t.Kind != yang.Ybinary) {
+		return nil
+	}
+	if len(t.Length) == 0 || t.Length.Equal(yang.Uint64Range) {
+		return nil
+	}
+	return t.Length
+}
+
+// restrictedPattern returns the anchored patterns of the YANG type t if it is
+// a string type whose values are restricted by a pattern, and nil otherwise.
+func restrictedPattern(t *yang.YangType) []string {
+	if t == nil || t.Kind != yang.Ystring {
+		return nil
+	}
+	pats, _ := util.SanitizedPattern(t)
+	if len(pats) == 0 {
+		return nil
+	}
+	return pats
+}
+
 // FindSchemaPath finds the relative or absolute schema path of a given field
 // of a Directory. The Field is specified as a name in order to guarantee its
 // existence before processing.
@@ -702,3 +702,68 @@ func TestFindMapPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestChoiceMembership(t *testing.T) {
+	container := &yang.Entry{
+		Name: "container",
+		Kind: yang.DirectoryEntry,
+	}
+	outerChoice := &yang.Entry{
+		Name:   "addr-type",
+		Kind:   yang.ChoiceEntry,
+		Parent: container,
+	}
+	outerCase := &yang.Entry{
+		Name:   "ipv4",
+		Kind:   yang.CaseEntry,
+		Parent: outerChoice,
+	}
+	innerChoice := &yang.Entry{
+		Name:   "source",
+		Kind:   yang.ChoiceEntry,
+		Parent: outerCase,
+	}
+	innerCase := &yang.Entry{
+		Name:   "static",
+		Kind:   yang.CaseEntry,
+		Parent: innerChoice,
+	}
+
+	tests := []struct {
+		name string
+		in   *yang.Entry
+		want string
+	}{{
+		name: "not within a choice",
+		in: &yang.Entry{
+			Name:   "leaf",
+			Kind:   yang.LeafEntry,
+			Parent: container,
+		},
+		want: "",
+	}, {
+		name: "within a choice",
+		in: &yang.Entry{
+			Name:   "leaf",
+			Kind:   yang.LeafEntry,
+			Parent: outerCase,
+		},
+		want: "addr-type/ipv4",
+	}, {
+		name: "within nested choices",
+		in: &yang.Entry{
+			Name:   "leaf",
+			Kind:   yang.LeafEntry,
+			Parent: innerCase,
+		},
+		want: "addr-type/ipv4/source/static",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := choiceMembership(tt.in); got != tt.want {
+				t.Errorf("choiceMembership(%s): got %q, want %q", tt.in.Name, got, tt.want)
+			}
+		})
+	}
+}
@@ -65,6 +65,10 @@ type enumSet struct {
 	// a module such as openconfig-bgp which defines /bgp and is also used at
 	// /network-instances/network-instance/protocols/protocol/bgp.
 	uniqueEnumeratedLeafNames map[string]string
+	// perModuleDedup specifies whether the keys used for enumerated leaves
+	// include the module in which the leaf is instantiated, such that
+	// de-duplication only occurs within a single module.
+	perModuleDedup bool
 }
 
 // newEnumSet initializes a new empty enumSet instance.
@@ -322,6 +326,12 @@ func (s *enumSet) enumLeafKey(e *yang.Entry, compressPaths, noUnderscores, skipD
 		// name, and instead find the unique identifier that may de-dup
 		// due to compression or multiple usages of a definition.
 		uniqueIdentifier = enumIdentifier(e, compressPaths)
+		if s.perModuleDedup {
+			// Scope the identifier to the module in which the leaf is
+			// instantiated such that usages in different modules are
+			// not de-duplicated.
+			uniqueIdentifier = fmt.Sprintf("%s@%s", uniqueIdentifier, instantiatingModuleName(e))
+		}
 	}
 
 	var compressName string
@@ -356,6 +366,14 @@ func (s *enumSet) enumLeafKey(e *yang.Entry, compressPaths, noUnderscores, skipD
 	return uniqueIdentifier, compressName
 }
 
+// instantiatingModuleName returns the name of the module at the root of the
+// data tree in which the input entry is instantiated.
+func instantiatingModuleName(e *yang.Entry) string {
+	for ; e.Parent != nil; e = e.Parent {
+	}
+	return e.Name
+}
+
 // enumIdentifier takes in an enum entry and returns a unique identifier for
 // that enum constructed using its path. This identifier would be the same for
 // an enum that's used in two different places in the schema.
@@ -414,10 +432,11 @@ func enumIdentifier(e *yang.Entry, compressPaths bool) string {
 // enumerated type name is compliant with language styles where underscores are
 // not allowed in names. If skipEnumDedup is set to true, we do not attempt to
 // deduplicate enumerated leaves that are used more than once in the schema
-// into a common type.
+// into a common type. If perModuleEnumDedup is set to true, such leaves are
+// only deduplicated when they are instantiated within the same module.
 // The returned enumSet can be used to query for enum/identity names.
 // The returned map is the set of generated enums to be used for enum code generation.
-func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums bool, enumOrgPrefixesToTrim []string) (*enumSet, map[string]*yangEnum, []error) {
+func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, skipEnumDedup, perModuleEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, appendEnumSuffixForSimpleUnionEnums bool, enumOrgPrefixesToTrim []string) (*enumSet, map[string]*yangEnum, []error) {
 	validEnums := make(map[string]*yang.Entry)
 	var enumPaths []string
 	var errs []error
@@ -471,6 +490,7 @@ func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, s
 	sort.Strings(enumPaths)
 
 	s := newEnumGenState()
+	s.enumSet.perModuleDedup = perModuleEnumDedup
 
 	// This is the first of two passes over the input enum entries.
 	// The purpose of this pass is to establish what the default name of
@@ -607,6 +627,51 @@ func findEnumSet(entries map[string]*yang.Entry, compressPaths, noUnderscores, s
 	return s.enumSet, genEnums, errs
 }
 
+// transformNames applies the supplied transform to the name of each of the
+// enumerated types in genEnums, which is keyed by the name of each type, and
+// returns a map of the types keyed by their new names. The name sets within
+// the enumSet are updated such that the enumerated types referenced by
+// leaves reflect the new names. Names are made unique after the transform is
+// applied, with the types processed in the order of their keys such that the
+// output is deterministic.
+func (s *enumSet) transformNames(genEnums map[string]*yangEnum, transform func(kind NameKind, yangPath, proposed string) string) map[string]*yangEnum {
+	nameSets := []map[string]string{s.uniqueIdentityNames, s.uniqueEnumeratedTypedefNames, s.uniqueEnumeratedLeafNames}
+	definedNames := map[string]bool{}
+	for _, ns := range nameSets {
+		for _, n := range ns {
+			definedNames[n] = true
+		}
+	}
+
+	var enums []*yangEnum
+	for _, e := range genEnums {
+		definedNames[e.name] = true
+		enums = append(enums, e)
+	}
+	sort.Slice(enums, func(i, j int) bool { return enums[i].id < enums[j].id })
+
+	renamed := map[string]string{}
+	newEnums := make(map[string]*yangEnum, len(genEnums))
+	for _, e := range enums {
+		if n := transform(EnumNameKind, e.id, e.name); n != e.name {
+			delete(definedNames, e.name)
+			n = genutil.MakeNameUnique(n, definedNames)
+			renamed[e.name] = n
+			e.name = n
+		}
+		newEnums[e.name] = e
+	}
+
+	for _, ns := range nameSets {
+		for k, n := range ns {
+			if nn, ok := renamed[n]; ok {
+				ns[k] = nn
+			}
+		}
+	}
+	return newEnums
+}
+
 // enumGenState contains the state and functionality for generating enum names
 // that seeks to be compatible in all supported languages. It assumes that
 // enums are all in the same output namespace (within the generated code), a
@@ -53,6 +53,11 @@ type IROptions struct {
 	// to true.
 	// NOTE: This flag will be removed by v1 release.
 	AppendEnumSuffixForSimpleUnionEnums bool
+
+	// NameTransform, if non-nil, is called with the name generated for
+	// each enumerated type, and returns the name that should be used in
+	// its place. The returned names are made unique.
+	NameTransform func(kind NameKind, yangPath, proposed string) string
 }
 
 // GenerateIR creates the ygen intermediate representation for a set of
@@ -79,10 +84,13 @@ func GenerateIR(yangFiles, includePaths []string, langMapper LangMapper, opts IR
 		return nil, errs
 	}
 
-	enumSet, genEnums, errs := findEnumSet(mdef.enumEntries, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.ParseOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.AppendEnumSuffixForSimpleUnionEnums, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+	enumSet, genEnums, errs := findEnumSet(mdef.enumEntries, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.ParseOptions.skipEnumDedup(), opts.ParseOptions.perModuleEnumDedup(), opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.AppendEnumSuffixForSimpleUnionEnums, opts.TransformationOptions.EnumOrgPrefixesToTrim)
 	if errs != nil {
 		return nil, errs
 	}
+	if opts.NameTransform != nil {
+		genEnums = enumSet.transformNames(genEnums, opts.NameTransform)
+	}
 
 	langMapper.SetEnumSet(enumSet)
 	langMapper.SetSchemaTree(mdef.schematree)
@@ -21,6 +21,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/openconfig/ygot/genutil"
 	"github.com/openconfig/ygot/ygot"
@@ -137,13 +138,14 @@ func protoIR(nestedDirectories bool) *IR {
 							SchemaPath:        "/model/dateref",
 							LeafrefTargetPath: "/openconfig-complex/model/a/single-key/config/dates",
 							Description:       "",
-							Type:              &YANGType{Name: "leafref"},
+							Type:              &YANGType{Name: "leafref", Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(5)}}},
 						},
 						Type: LeafNode,
 						LangType: &MappedType{
-							NativeType:   "ywrapper.UintValue",
-							ZeroValue:    "",
-							DefaultValue: nil,
+							NativeType:          "ywrapper.UintValue",
+							ZeroValue:           "",
+							DefaultValue:        nil,
+							ResolvedLeafrefType: &MappedType{NativeType: "ywrapper.UintValue"},
 						},
 						MappedPaths:             [][]string{{"", "model", "dateref"}},
 						MappedPathModules:       [][]string{{"", "openconfig-complex", "openconfig-complex"}},
@@ -231,7 +233,7 @@ func protoIR(nestedDirectories bool) *IR {
 							ShadowSchemaPath:  "/model/a/single-key/state/dates",
 							LeafrefTargetPath: "",
 							Description:       "",
-							Type:              &YANGType{Name: "weekday"},
+							Type:              &YANGType{Name: "weekday", Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(5)}}},
 						},
 						Type: LeafListNode,
 						LangType: &MappedType{
@@ -257,7 +259,7 @@ func protoIR(nestedDirectories bool) *IR {
 							ShadowSchemaPath:  "/model/a/single-key/state/dates-with-defaults",
 							LeafrefTargetPath: "",
 							Description:       "",
-							Type:              &YANGType{Name: "weekday"},
+							Type:              &YANGType{Name: "weekday", Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(5)}}},
 						},
 						Type: LeafListNode,
 						LangType: &MappedType{
@@ -2240,13 +2242,18 @@ func TestGenerateIR(t *testing.T) {
 								SchemaPath:        "/model/dateref",
 								LeafrefTargetPath: "/openconfig-complex/model/a/single-key/config/dates",
 								Description:       "",
-								Type:              &YANGType{Name: "leafref"},
+								Type:              &YANGType{Name: "leafref", Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(5)}}},
 							},
 							Type: LeafNode,
 							LangType: &MappedType{
 								NativeType:   "uint8",
 								ZeroValue:    "0",
 								DefaultValue: ygot.String("5"),
+								ResolvedLeafrefType: &MappedType{
+									NativeType:   "uint8",
+									ZeroValue:    "0",
+									DefaultValue: ygot.String("5"),
+								},
 							},
 							MappedPaths:             [][]string{{"dateref"}},
 							MappedPathModules:       [][]string{{"openconfig-complex"}},
@@ -2334,7 +2341,7 @@ func TestGenerateIR(t *testing.T) {
 								ShadowSchemaPath:  "/model/a/single-key/state/dates",
 								LeafrefTargetPath: "",
 								Description:       "",
-								Type:              &YANGType{Name: "weekday"},
+								Type:              &YANGType{Name: "weekday", Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(5)}}},
 							},
 							Type:                    LeafListNode,
 							LangType:                &MappedType{NativeType: "uint8", ZeroValue: "0", DefaultValue: ygot.String("[]uint8{5}")},
@@ -2356,7 +2363,7 @@ func TestGenerateIR(t *testing.T) {
 								ShadowSchemaPath:  "/model/a/single-key/state/dates-with-defaults",
 								LeafrefTargetPath: "",
 								Description:       "",
-								Type:              &YANGType{Name: "weekday"},
+								Type:              &YANGType{Name: "weekday", Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(5)}}},
 							},
 							Type:                    LeafListNode,
 							LangType:                &MappedType{NativeType: "uint8", ZeroValue: "0", DefaultValue: ygot.String("[]uint8{1, 2}")},
@@ -3383,3 +3390,67 @@ func TestGenerateIR(t *testing.T) {
 		})
 	}
 }
+
+// TestGenerateIRResolvedLeafrefListKey checks that the type of a list key
+// that is a leafref to a union is resolved to the type of the union.
+func TestGenerateIRResolvedLeafrefListKey(t *testing.T) {
+	tests := []struct {
+		desc         string
+		inLangMapper LangMapper
+		inOpts       IROptions
+		wantKey      *ListKey
+	}{{
+		desc:         "go leafref key to union of strings",
+		inLangMapper: NewGoLangMapper(true),
+		inOpts: IROptions{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.Uncompressed,
+			},
+		},
+		wantKey: &ListKey{
+			Name: "PolicyName",
+			LangType: &MappedType{
+				NativeType: "string",
+				UnionTypes: map[string]int{"string": 0},
+				ZeroValue:  `""`,
+				ResolvedLeafrefType: &MappedType{
+					NativeType: "string",
+					UnionTypes: map[string]int{"string": 0},
+					ZeroValue:  `""`,
+				},
+			},
+		},
+	}, {
+		desc:         "proto leafref key to union of strings",
+		inLangMapper: NewProtoLangMapper(DefaultBasePackageName, DefaultEnumPackageName),
+		inOpts: IROptions{
+			TransformationOptions: TransformationOpts{
+				CompressBehaviour: genutil.Uncompressed,
+			},
+			AbsoluteMapPaths: true,
+		},
+		wantKey: &ListKey{
+			Name: "policy_name",
+			LangType: &MappedType{
+				NativeType:          "string",
+				ResolvedLeafrefType: &MappedType{NativeType: "string"},
+			},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := GenerateIR([]string{filepath.Join(TestRoot, "testdata", "proto", "proto-union-list-key.yang")}, nil, tt.inLangMapper, tt.inOpts)
+			if err != nil {
+				t.Fatalf("GenerateIR: got unexpected error: %v", err)
+			}
+			dir, ok := got.Directories["/proto-union-list-key/routing-policy/policies/policy"]
+			if !ok {
+				t.Fatalf("GenerateIR: did not get policy directory, got: %v", got.OrderedDirectoryPaths())
+			}
+			if diff := cmp.Diff(dir.ListKeys["policy-name"], tt.wantKey); diff != "" {
+				t.Errorf("did not get expected list key, diff(-got,+want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -68,6 +68,25 @@ type MappedType struct {
 	// It is represented as a string pointer to ensure that default values
 	// of the empty string can be distinguished from unset defaults.
 	DefaultValue *string
+	// ResolvedLeafrefType stores the type of the leaf that is ultimately
+	// referenced when the mapped entity is a leafref. Where a leafref
+	// refers to another leafref, the type of the final non-leafref
+	// target is stored. It is nil for entities that are not leafrefs,
+	// including unions whose subtypes are leafrefs, since the subtypes
+	// within UnionTypes are already resolved to their targets' types.
+	ResolvedLeafrefType *MappedType
+}
+
+// setResolvedLeafrefType populates the ResolvedLeafrefType field of the
+// MappedType t, which has been mapped from the target of a leafref. If t was
+// itself resolved from a leafref, its existing resolved type is retained such
+// that the final target of a chain of leafrefs is stored.
+func setResolvedLeafrefType(t *MappedType) {
+	if t.ResolvedLeafrefType != nil {
+		return
+	}
+	resolved := *t
+	t.ResolvedLeafrefType = &resolved
 }
 
 // MappedUnionSubtype stores information associated with a union subtype within
@@ -139,6 +158,12 @@ func buildDirectoryDefinitions(langMapper LangMapper, entries map[string]*yang.E
 	var errs []error
 	mappedStructs := make(map[string]*Directory)
 
+	// compressedPaths stores the schema paths of the entries that are
+	// mapped, keyed by their compressed path, such that collisions can be
+	// detected.
+	compressedPaths := map[string][]string{}
+	checkCollisions := opts.TransformationOptions.ErrorOnCompressionCollision && opts.TransformationOptions.CompressBehaviour.CompressEnabled()
+
 	for _, entryKey := range genutil.GetOrderedEntryKeys(entries) {
 		e := entries[entryKey]
 		// If we are excluding config false (state entries) then skip processing
@@ -146,6 +171,10 @@ func buildDirectoryDefinitions(langMapper LangMapper, entries map[string]*yang.E
 		if opts.TransformationOptions.CompressBehaviour.StateExcluded() && !util.IsConfig(e) {
 			continue
 		}
+		if checkCollisions && !IsFakeRoot(e) {
+			cp := compressedSchemaPath(e, !opts.TransformationOptions.CompressBehaviour.ListContainersCompressed())
+			compressedPaths[cp] = append(compressedPaths[cp], e.Path())
+		}
 		if e.IsList() || e.IsDir() || util.IsRoot(e) {
 			// This should be mapped to a struct in the generated code since it has
 			// child elements in the YANG schema.
@@ -195,9 +224,38 @@ func buildDirectoryDefinitions(langMapper LangMapper, entries map[string]*yang.E
 		}
 	}
 
+	var cps []string
+	for cp, paths := range compressedPaths {
+		if len(paths) > 1 {
+			cps = append(cps, cp)
+		}
+	}
+	sort.Strings(cps)
+	for _, cp := range cps {
+		errs = append(errs, fmt.Errorf("schema paths %s collide at compressed path %s", strings.Join(compressedPaths[cp], ", "), cp))
+	}
+
 	return mappedStructs, errs
 }
 
+// compressedSchemaPath returns the path of the entry e within the compressed
+// schema, which consists of the names of the elements of its path that are
+// retained when the schema is compressed. If keepListContainers is set,
+// containers that surround lists are retained.
+func compressedSchemaPath(e *yang.Entry, keepListContainers bool) string {
+	isValid := util.IsOCCompressedValidElement
+	if keepListContainers {
+		isValid = util.IsOCCompressedValidElementKeepListContainers
+	}
+	var elems []string
+	for ; e != nil; e = e.Parent {
+		if isValid(e) {
+			elems = append([]string{e.Name}, elems...)
+		}
+	}
+	return "/" + strings.Join(elems, "/")
+}
+
 // buildListKey takes a yang.Entry, e, corresponding to a list and extracts the definition
 // of the list key, returning a YangListAttr struct describing the key element(s). If
 // errors are encountered during the extraction, they are returned as a slice of errors.
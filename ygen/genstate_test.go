@@ -1028,8 +1028,9 @@ func TestBuildDirectoryDefinitions(t *testing.T) {
 						"key": {
 							Name: "Key",
 							LangType: &MappedType{
-								NativeType: "string",
-								ZeroValue:  `""`,
+								NativeType:          "string",
+								ZeroValue:           `""`,
+								ResolvedLeafrefType: &MappedType{NativeType: "string", ZeroValue: `""`},
 							},
 						},
 					},
@@ -1373,7 +1374,7 @@ func TestBuildDirectoryDefinitions(t *testing.T) {
 				for _, inc := range tt.in {
 					// Always provide a nil set of modules to findMappableEntities since this
 					// is only used to skip elements.
-					errs = append(errs, findMappableEntities(inc, structs, enums, []string{}, c.compressBehaviour.CompressEnabled(), []*yang.Entry{})...)
+					errs = append(errs, findMappableEntities(inc, structs, enums, []string{}, c.compressBehaviour, false, []*yang.Entry{})...)
 				}
 				if errs != nil {
 					t.Fatalf("findMappableEntities(%v, %v, %v, nil, %v, nil): got unexpected error, want: nil, got: %v", tt.in, structs, enums, c.compressBehaviour.CompressEnabled(), errs)
@@ -2259,7 +2260,7 @@ func TestBuildListKey(t *testing.T) {
 			}
 			enumMap := enumMapFromEntries(tt.inEnumEntries)
 			addEnumsToEnumMap(tt.in, enumMap)
-			enumSet, _, errs := findEnumSet(enumMap, tt.inCompress, false, tt.inSkipEnumDedup, true, true, true, nil)
+			enumSet, _, errs := findEnumSet(enumMap, tt.inCompress, false, tt.inSkipEnumDedup, false, true, true, true, nil)
 			if errs != nil {
 				if !tt.wantErr {
 					t.Errorf("findEnumSet failed: %v", errs)
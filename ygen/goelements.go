@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
@@ -68,6 +69,8 @@ var (
 		"interface{}":       true,
 		ygot.BinaryTypeName: true,
 		ygot.EmptyTypeName:  true,
+
+		ygot.InstanceIdentifierTypeName: true,
 	}
 
 	// simpleUnionConversionsFromKind stores the simple union conversion
@@ -84,6 +87,8 @@ var (
 		yang.Ydecimal64: "UnionFloat64",
 		yang.Ystring:    "UnionString",
 		yang.Ybool:      "UnionBool",
+
+		yang.YinstanceIdentifier: ygot.UnionInstanceIdentifierTypeName,
 	}
 
 	// goZeroValues stores the defined zero value for the Go types that can
@@ -104,6 +109,8 @@ var (
 		"interface{}":       "nil",
 		ygot.BinaryTypeName: "nil",
 		ygot.EmptyTypeName:  "false",
+
+		ygot.InstanceIdentifierTypeName: `""`,
 	}
 
 	// unionConversionSnippets stores the valid primitive types that the Go
@@ -124,6 +131,8 @@ var (
 		"interface{}":       {PrimitiveType: "interface{}", ConversionSnippet: "&UnionUnsupported{v}"},
 		ygot.BinaryTypeName: {PrimitiveType: "[]byte", ConversionSnippet: ygot.BinaryTypeName + "(v)"},
 		ygot.EmptyTypeName:  {PrimitiveType: "bool", ConversionSnippet: ygot.EmptyTypeName + "(v)"},
+
+		ygot.InstanceIdentifierTypeName: {PrimitiveType: ygot.InstanceIdentifierTypeName, ConversionSnippet: ygot.UnionInstanceIdentifierTypeName + "(v)"},
 	}
 )
 
@@ -154,6 +163,47 @@ type GoLangMapper struct {
 	// union subtypes in the generated code instead of using wrapper types.
 	// NOTE: This flag will be removed as part of ygot's v1 release.
 	simpleUnions bool
+
+	// typeNameAbbreviations is a map, keyed by the CamelCase name of a path
+	// element, of the abbreviation that should be used for that element when
+	// generating directory and union names.
+	typeNameAbbreviations map[string]string
+	// abbreviatedUnionNames is a map, keyed by the unabbreviated name of a
+	// generated union type, of the unique name that the union was mapped to
+	// once typeNameAbbreviations were applied.
+	abbreviatedUnionNames map[string]string
+	// definedUnionNames specifies the union names that have been generated
+	// when typeNameAbbreviations are applied, such that unions whose names
+	// collide once abbreviated can be made unique.
+	definedUnionNames map[string]bool
+
+	// identifierSanitizer, if non-nil, is used to sanitize the YANG name
+	// of each element prior to it being converted to a Go name.
+	identifierSanitizer func(string) string
+
+	// nameTransform, if non-nil, is called with each generated struct and
+	// field name, and returns the name that should be used in its place.
+	nameTransform func(kind NameKind, yangPath, proposed string) string
+
+	// customTypes is a map, keyed by the name of a YANG typedef qualified
+	// by the module that defines it (e.g., ietf-inet-types:ipv4-address), of
+	// the package-qualified Go type that leaves of the typedef are mapped to.
+	customTypes map[string]string
+	// customTypeImports is a map, keyed by the package-qualified name of
+	// each custom Go type, of the import path of the package that defines it.
+	// Custom types that are defined within the generated package, or which
+	// are Go built-in types, are not included.
+	customTypeImports map[string]string
+
+	// emptyLeafAsBool specifies whether leaves of the YANG empty type are
+	// mapped to the bool type, rather than to the type named by
+	// ygot.EmptyTypeName.
+	emptyLeafAsBool bool
+
+	// typedLeafrefs specifies whether a union whose members are all
+	// leafrefs to the same union leaf is mapped to the generated union type
+	// of that leaf.
+	typedLeafrefs bool
 }
 
 // NewGoLangMapper creates a new GoLangMapper instance, initialised with the
@@ -189,8 +239,13 @@ type resolveTypeArgs struct {
 
 // pathToCamelCaseName takes an input yang.Entry and outputs its name as a Go
 // compatible name in the form PathElement1_PathElement2, performing schema
-// compression if required. The name is not checked for uniqueness.
-func pathToCamelCaseName(e *yang.Entry, compressOCPaths bool) string {
+// compression if required. If keepListContainers is set, containers that
+// surround lists are retained when compressing the schema. If abbreviations
+// is non-nil, each path element whose CamelCase name is a key of the map is
+// replaced by the corresponding value. If sanitize is non-nil, it is applied
+// to the YANG name of each path element before it is converted to CamelCase.
+// The name is not checked for uniqueness.
+func pathToCamelCaseName(e *yang.Entry, compressOCPaths, keepListContainers bool, abbreviations map[string]string, sanitize func(string) string) string {
 	var pathElements []*yang.Entry
 
 	if IsFakeRoot(e) {
@@ -203,9 +258,18 @@ func pathToCamelCaseName(e *yang.Entry, compressOCPaths bool) string {
 		for element != nil {
 			// If the CompressOCPaths option is set to true, then only append the
 			// element to the path if the element itself would have code generated
-			// for it - this compresses out surrounding containers, config/state
-			// containers and root modules.
-			if compressOCPaths && util.IsOCCompressedValidElement(element) || !compressOCPaths && !util.IsChoiceOrCase(element) {
+			// for it - this compresses out surrounding containers (unless they are
+			// being kept), config/state containers and root modules.
+			var valid bool
+			switch {
+			case compressOCPaths && keepListContainers:
+				valid = util.IsOCCompressedValidElementKeepListContainers(element)
+			case compressOCPaths:
+				valid = util.IsOCCompressedValidElement(element)
+			default:
+				valid = !util.IsChoiceOrCase(element)
+			}
+			if valid {
 				pathElements = append(pathElements, element)
 			}
 			element = element.Parent
@@ -217,7 +281,11 @@ func pathToCamelCaseName(e *yang.Entry, compressOCPaths bool) string {
 	var buf bytes.Buffer
 	for i := range pathElements {
 		idx := len(pathElements) - 1 - i
-		buf.WriteString(genutil.EntryCamelCaseName(pathElements[idx]))
+		name := entryCamelCaseName(pathElements[idx], sanitize)
+		if abbr, ok := abbreviations[name]; ok {
+			name = abbr
+		}
+		buf.WriteString(name)
 		if idx != 0 {
 			buf.WriteRune('_')
 		}
@@ -226,16 +294,38 @@ func pathToCamelCaseName(e *yang.Entry, compressOCPaths bool) string {
 	return buf.String()
 }
 
+// entryCamelCaseName returns the CamelCase name of the supplied entry per
+// genutil.EntryCamelCaseName. If sanitize is non-nil, it is applied to the
+// YANG name of the entry prior to it being converted to CamelCase. Names that
+// are explicitly specified using the "camelcase-name" extension are not
+// sanitized.
+func entryCamelCaseName(e *yang.Entry, sanitize func(string) string) string {
+	if sanitize == nil {
+		return genutil.EntryCamelCaseName(e)
+	}
+	if name, ok := genutil.CamelCaseNameExt(e.Exts); ok {
+		return name
+	}
+	return yang.CamelCase(sanitize(e.Name))
+}
+
 // DirectoryName generates the final name to be used for a particular YANG
 // schema element in the generated Go code. If path compressing is active,
 // schemapaths are compressed, otherwise the name is returned simply as camel
 // case.
 // Although name conversion is lossy, name uniquification occurs at this stage
-// since all generated struct names reside in the package namespace.
+// since all generated struct names reside in the package namespace. Any type
+// name abbreviations supplied to the mapper are applied prior to the name being
+// made unique, such that an abbreviation which causes two names to collide is
+// resolved in the same manner as any other name collision.
 func (s *GoLangMapper) DirectoryName(e *yang.Entry, compressBehaviour genutil.CompressBehaviour) (string, error) {
 	// TODO(wenbli): Do not uniquify at this step -- rather do this in a
 	// later pass to avoid non-idempotent behaviour in GoLangMapper.
-	uniqName := genutil.MakeNameUnique(pathToCamelCaseName(e, compressBehaviour.CompressEnabled()), s.definedGlobals)
+	name := pathToCamelCaseName(e, compressBehaviour.CompressEnabled(), !compressBehaviour.ListContainersCompressed(), s.typeNameAbbreviations, s.identifierSanitizer)
+	if s.nameTransform != nil {
+		name = s.nameTransform(StructNameKind, e.Path(), name)
+	}
+	uniqName := genutil.MakeNameUnique(name, s.definedGlobals)
 
 	// Record the name of the struct that was unique such that it can be referenced
 	// by path.
@@ -248,18 +338,28 @@ func (s *GoLangMapper) DirectoryName(e *yang.Entry, compressBehaviour genutil.Co
 // Since this conversion is lossy, a later step should resolve any naming
 // conflicts between different fields.
 func (s *GoLangMapper) FieldName(e *yang.Entry) (string, error) {
-	return genutil.EntryCamelCaseName(e), nil
+	name := entryCamelCaseName(e, s.identifierSanitizer)
+	if s.nameTransform != nil {
+		name = s.nameTransform(FieldNameKind, e.Path(), name)
+	}
+	return name, nil
 }
 
 // LeafType maps the input leaf entry to a MappedType object containing the
 // type information about the field.
 func (s *GoLangMapper) LeafType(e *yang.Entry, opts IROptions) (*MappedType, error) {
-	mtype, err := s.yangTypeToGoType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.ParseOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+	mtype, err := s.yangTypeToGoType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.ParseOptions.skipEnumDedup(), opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.TransformationOptions.EnumOrgPrefixesToTrim)
 	if err != nil {
 		return nil, err
 	}
 
-	defaultValue, err := generateGoDefaultValue(e, mtype, s, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.ParseOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.TransformationOptions.EnumOrgPrefixesToTrim, s.simpleUnions)
+	// Default values of leaves that are mapped to a custom type cannot be
+	// represented in the generated code.
+	if s.isCustomType(mtype.NativeType) {
+		return mtype, nil
+	}
+
+	defaultValue, err := generateGoDefaultValue(e, mtype, s, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.ParseOptions.skipEnumDedup(), opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.TransformationOptions.EnumOrgPrefixesToTrim, s.simpleUnions)
 	if err != nil {
 		return nil, err
 	}
@@ -271,7 +371,7 @@ func (s *GoLangMapper) LeafType(e *yang.Entry, opts IROptions) (*MappedType, err
 // LeafType maps the input list key entry to a MappedType object containing the
 // type information about the key field.
 func (s *GoLangMapper) KeyLeafType(e *yang.Entry, opts IROptions) (*MappedType, error) {
-	return s.yangTypeToGoType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.ParseOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+	return s.yangTypeToGoType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), opts.ParseOptions.skipEnumDedup(), opts.TransformationOptions.ShortenEnumLeafNames, opts.TransformationOptions.UseDefiningModuleForTypedefEnumNames, opts.TransformationOptions.EnumOrgPrefixesToTrim)
 }
 
 // PackageName is not used by Go generation.
@@ -292,6 +392,103 @@ func (s *GoLangMapper) SetSchemaTree(st *schemaTree) {
 	s.schematree = st
 }
 
+// SetTypeNameAbbreviations is used to supply a map, keyed by the CamelCase
+// name of a path element, of the abbreviation to be used for that element
+// within the names of generated directories and unions. The names of
+// enumerated types are not abbreviated.
+func (s *GoLangMapper) SetTypeNameAbbreviations(abbreviations map[string]string) {
+	s.typeNameAbbreviations = abbreviations
+}
+
+// SetIdentifierSanitizer is used to supply a function that is applied to
+// the YANG name of each schema element prior to it being used to generate
+// the name of a generated struct or field.
+func (s *GoLangMapper) SetIdentifierSanitizer(sanitize func(string) string) {
+	s.identifierSanitizer = sanitize
+}
+
+// SetNameTransform is used to supply a function that is called with the
+// name generated for each struct and field, which returns the name that
+// should be used in its place. Struct names are made unique after the
+// function is applied, and field names are made unique within their
+// parent struct.
+func (s *GoLangMapper) SetNameTransform(transform func(kind NameKind, yangPath, proposed string) string) {
+	s.nameTransform = transform
+}
+
+// SetEmptyLeafAsBool is used to specify whether leaves of the YANG empty
+// type are mapped to the bool type rather than to the type named by
+// ygot.EmptyTypeName, such that they are output as JSON booleans rather than
+// as [null].
+func (s *GoLangMapper) SetEmptyLeafAsBool(b bool) {
+	s.emptyLeafAsBool = b
+}
+
+// SetTypedLeafrefs is used to specify whether a union leaf whose members are
+// all leafrefs to the same union leaf is mapped to the generated union type of
+// the target, rather than to a union type generated for the leaf itself.
+func (s *GoLangMapper) SetTypedLeafrefs(b bool) {
+	s.typedLeafrefs = b
+}
+
+// SetCustomTypeMap is used to supply a map, keyed by the name of a YANG
+// typedef qualified by the name of the module that defines it (e.g.,
+// ietf-inet-types:ipv4-address), of the Go type that leaves of the typedef
+// should be mapped to. Each Go type is specified as the import path of the
+// package that defines it, followed by a dot and the name of the type (e.g.,
+// net/netip.Addr). The import path is omitted for Go built-in types and types
+// that are defined within the generated package. The last element of the
+// import path is assumed to be the name of the package. An error is returned
+// if any of the types are not in this form.
+func (s *GoLangMapper) SetCustomTypeMap(m map[string]string) error {
+	customTypes := map[string]string{}
+	customTypeImports := map[string]string{}
+	for typedef, t := range m {
+		if !strings.Contains(typedef, ":") {
+			return fmt.Errorf("custom type typedef name %q is not qualified by the name of its defining module", typedef)
+		}
+		pkgPath, name := "", t
+		if i := strings.LastIndex(t, "."); i != -1 {
+			pkgPath, name = t[:i], t[i+1:]
+		}
+		if name == "" || (pkgPath == "" && strings.Contains(t, ".")) || strings.ContainsAny(name, "/ ") {
+			return fmt.Errorf("invalid custom Go type %q for typedef %s", t, typedef)
+		}
+		goType := name
+		if pkgPath != "" {
+			goType = fmt.Sprintf("%s.%s", path.Base(pkgPath), name)
+			customTypeImports[goType] = pkgPath
+		}
+		customTypes[typedef] = goType
+	}
+	s.customTypes = customTypes
+	s.customTypeImports = customTypeImports
+	return nil
+}
+
+// customType returns the Go type that has been specified for the YANG typedef
+// t using SetCustomTypeMap, and whether such a type was found. Only the
+// typedef that is directly referenced by t is considered, such that a
+// typedef that is derived from a mapped typedef is not mapped.
+func (s *GoLangMapper) customType(t *yang.YangType) (string, bool) {
+	if len(s.customTypes) == 0 || t == nil || t.Base == nil || util.IsYANGBaseType(t) {
+		return "", false
+	}
+	goType, ok := s.customTypes[fmt.Sprintf("%s:%s", genutil.ParentModuleName(t.Base), t.Name)]
+	return goType, ok
+}
+
+// isCustomType reports whether the Go type goType was supplied using
+// SetCustomTypeMap.
+func (s *GoLangMapper) isCustomType(goType string) bool {
+	for _, t := range s.customTypes {
+		if t == goType {
+			return true
+		}
+	}
+	return false
+}
+
 // yangTypeToGoType takes a yang.YangType (YANG type definition) and maps it
 // to the type that should be used to represent it in the generated Go code.
 // A resolveTypeArgs structure is used as the input argument which specifies a
@@ -304,6 +501,13 @@ func (s *GoLangMapper) SetSchemaTree(st *schemaTree) {
 // used more than once in the schema should share a common type. By default, a single
 // type for each leaf is created.
 func (s *GoLangMapper) yangTypeToGoType(args resolveTypeArgs, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) (*MappedType, error) {
+	// Typedefs that are mapped to a custom Go type take precedence over the
+	// mapping of their underlying type. Since YANG default values cannot be
+	// converted to an arbitrary Go type, no default value is set.
+	if goType, ok := s.customType(args.yangType); ok {
+		return &MappedType{NativeType: goType, ZeroValue: fmt.Sprintf("*new(%s)", goType)}, nil
+	}
+
 	defVal := genutil.TypeDefaultValue(args.yangType)
 	// Handle the case of a typedef which is actually an enumeration.
 	mtype, err := s.enumSet.enumeratedTypedefTypeName(args, goEnumPrefix, false, useDefiningModuleForTypedefEnumNames)
@@ -349,7 +553,11 @@ func (s *GoLangMapper) yangTypeToGoType(args resolveTypeArgs, compressOCPaths, s
 		// Empty is a YANG type that either exists or doesn't, therefore
 		// map it to a boolean to indicate its presence or not. The empty
 		// type name uses a specific name in the generated code, such that
-		// it can be identified for marshalling.
+		// it can be identified for marshalling, unless a plain bool has
+		// been requested.
+		if s.emptyLeafAsBool {
+			return &MappedType{NativeType: "bool", ZeroValue: goZeroValues["bool"]}, nil
+		}
 		return &MappedType{NativeType: ygot.EmptyTypeName, ZeroValue: goZeroValues[ygot.EmptyTypeName]}, nil
 	case yang.Ystring:
 		return &MappedType{NativeType: "string", ZeroValue: goZeroValues["string"], DefaultValue: defVal}, nil
@@ -404,12 +612,18 @@ func (s *GoLangMapper) yangTypeToGoType(args resolveTypeArgs, compressOCPaths, s
 		if err != nil {
 			return nil, err
 		}
+		setResolvedLeafrefType(mtype)
 		return mtype, nil
 	case yang.Ybinary:
 		// Map binary fields to the Binary type defined in the output code,
 		// this is used to ensure that we can distinguish a binary field from
 		// a leaf-list of uint8s which is not possible if mapping to []byte.
 		return &MappedType{NativeType: ygot.BinaryTypeName, ZeroValue: goZeroValues[ygot.BinaryTypeName], DefaultValue: defVal}, nil
+	case yang.YinstanceIdentifier:
+		// Map instance-identifier fields to the InstanceIdentifier type
+		// defined in the ygot package, such that they can be distinguished
+		// from string fields, and converted to gNMI paths.
+		return &MappedType{NativeType: ygot.InstanceIdentifierTypeName, ZeroValue: goZeroValues[ygot.InstanceIdentifierTypeName], DefaultValue: defVal}, nil
 	default:
 		// Return an empty interface for the types that we do not currently
 		// support. Back-end validation is required for these types.
@@ -454,6 +668,16 @@ func (s *GoLangMapper) yangTypeToGoType(args resolveTypeArgs, compressOCPaths, s
 //
 // goUnionType returns an error if mapping is not possible.
 func (s *GoLangMapper) goUnionType(args resolveTypeArgs, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames bool, enumOrgPrefixesToTrim []string) (*MappedType, error) {
+	if s.typedLeafrefs {
+		target, err := s.unionLeafrefTarget(args)
+		if err != nil {
+			return nil, err
+		}
+		if target != nil {
+			return s.yangTypeToGoType(resolveTypeArgs{yangType: target.Type, contextEntry: target}, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+		}
+	}
+
 	var errs []error
 	unionMappedTypes := make(map[int]*MappedType)
 
@@ -471,15 +695,28 @@ func (s *GoLangMapper) goUnionType(args resolveTypeArgs, compressOCPaths, skipEn
 	}
 
 	resolvedType := &MappedType{
-		NativeType: fmt.Sprintf("%s_Union", pathToCamelCaseName(args.contextEntry, compressOCPaths)),
+		NativeType: s.unionName(args.contextEntry, compressOCPaths),
 		// Zero value is set to nil, other than in cases where there is
 		// a single type in the union.
 		ZeroValue:    "nil",
 		DefaultValue: genutil.TypeDefaultValue(args.yangType),
 	}
+	// Custom types cannot implement the interface that is generated for a
+	// union, and hence can only be used in unions that have a single subtype.
+	if len(unionMappedTypes) > 1 {
+		for t := range unionTypes {
+			if s.isCustomType(t) {
+				return nil, fmt.Errorf("custom type %s cannot be used within union %s, which has multiple subtypes", t, args.contextEntry.Path())
+			}
+		}
+	}
+
 	// If there is only one type inside the union, then promote it to replace the union type.
 	if len(unionMappedTypes) == 1 {
 		resolvedType = unionMappedTypes[0]
+		// The union itself is not a leafref, even where its single
+		// subtype is.
+		resolvedType.ResolvedLeafrefType = nil
 	}
 
 	resolvedType.UnionTypes = unionTypes
@@ -487,9 +724,60 @@ func (s *GoLangMapper) goUnionType(args resolveTypeArgs, compressOCPaths, skipEn
 	return resolvedType, nil
 }
 
+// unionLeafrefTarget returns the union leaf that is referenced by each member
+// of the union described by args, where every member is a leafref to that
+// same leaf. It returns nil if the union has any other member, or its members
+// reference different leaves, or the leaf referenced is not a union.
+func (s *GoLangMapper) unionLeafrefTarget(args resolveTypeArgs) (*yang.Entry, error) {
+	var target *yang.Entry
+	for _, subtype := range args.yangType.Type {
+		if subtype.Kind != yang.Yleafref {
+			return nil, nil
+		}
+		t, err := s.schematree.resolveLeafrefTarget(subtype.Path, args.contextEntry)
+		if err != nil {
+			return nil, err
+		}
+		if target != nil && t != target {
+			return nil, nil
+		}
+		target = t
+	}
+	if target == nil || target.Type.Kind != yang.Yunion {
+		return nil, nil
+	}
+	return target, nil
+}
+
+// unionName returns the name of the type that is generated for the union
+// leaf e, in the form Bar_Foo_Union, where Bar_Foo is the schema path to e.
+// Leaves whose paths map to the same name - for example, the config and state
+// leaves of a compressed schema - share a union type. Where abbreviations are
+// supplied to the mapper, they are applied to each path element, and the
+// abbreviated name is then made unique, such that unions whose unabbreviated
+// names differ are not mapped to the same type.
+func (s *GoLangMapper) unionName(e *yang.Entry, compressOCPaths bool) string {
+	name := fmt.Sprintf("%s_Union", pathToCamelCaseName(e, compressOCPaths, false, nil, s.identifierSanitizer))
+	if len(s.typeNameAbbreviations) == 0 {
+		return name
+	}
+	if n, ok := s.abbreviatedUnionNames[name]; ok {
+		return n
+	}
+	if s.abbreviatedUnionNames == nil {
+		s.abbreviatedUnionNames = map[string]string{}
+		s.definedUnionNames = map[string]bool{}
+	}
+	n := genutil.MakeNameUnique(fmt.Sprintf("%s_Union", pathToCamelCaseName(e, compressOCPaths, false, s.typeNameAbbreviations, s.identifierSanitizer)), s.definedUnionNames)
+	s.abbreviatedUnionNames[name] = n
+	return n
+}
+
 // goUnionSubTypes extracts all the possible subtypes of a YANG union leaf,
 // returning any errors that occur. In case of nested unions, the entire union
-// is flattened, and identical types are de-duped. currentTypes keeps track of
+// is flattened, and identical types are de-duped. Leafrefs whose target is a
+// union are resolved, and the subtypes of the target union are flattened in
+// the same manner. currentTypes keeps track of
 // this unique set of types, along with the order they're seen, and
 // unionMappedTypes records the entire type information for each. The
 // compressOCPaths argument specifies whether OpenConfig path compression is
@@ -512,6 +800,22 @@ func (s *GoLangMapper) goUnionSubTypes(subtype *yang.YangType, ctx *yang.Entry,
 
 	var mtype *MappedType
 	switch subtype.Kind {
+	case yang.Yleafref:
+		// Where a leafref member of the union refers to a leaf that is
+		// itself a union (or to another leafref), the subtypes of the
+		// target are flattened into this union, using the target as the
+		// context entry such that enumerated types are named consistently
+		// with the target leaf.
+		target, err := s.schematree.resolveLeafrefTarget(subtype.Path, ctx)
+		if err != nil {
+			return append(errs, err)
+		}
+		if target.Type.Kind == yang.Yunion || target.Type.Kind == yang.Yleafref {
+			return s.goUnionSubTypes(target.Type, target, currentTypes, unionMappedTypes, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim)
+		}
+		if mtype, err = s.yangTypeToGoType(resolveTypeArgs{yangType: contextType, contextEntry: ctx}, compressOCPaths, skipEnumDedup, shortenEnumLeafNames, useDefiningModuleForTypedefEnumNames, enumOrgPrefixesToTrim); err != nil {
+			return append(errs, err)
+		}
 	case yang.Yidentityref:
 		// Handle the specific case that the context entry is now not the correct entry
 		// to map enumerated types to their module. This occurs in the case that the subtype
@@ -694,6 +998,12 @@ func (s *GoLangMapper) yangDefaultValueToGo(value string, args resolveTypeArgs,
 		}
 		value := fmt.Sprintf("%q", value)
 		return value, ykind, nil
+	case yang.YinstanceIdentifier:
+		if _, err := ygot.InstanceIdentifier(value).Path(); err != nil {
+			return "", yang.Ynone, fmt.Errorf("default value conversion: %q is not a valid instance identifier: %v", value, err)
+		}
+		value := fmt.Sprintf(ygot.InstanceIdentifierTypeName+"(%q)", value)
+		return value, ykind, nil
 	case yang.Ybool:
 		switch value {
 		case "true", "false":
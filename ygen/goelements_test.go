@@ -440,7 +440,7 @@ func TestUnionSubTypes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			enumSet, _, errs := findEnumSet(enumMapFromEntry(tt.inCtxEntry), false, false, false, true, true, true, nil)
+			enumSet, _, errs := findEnumSet(enumMapFromEntry(tt.inCtxEntry), false, false, false, false, true, true, true, nil)
 			if errs != nil {
 				t.Fatal(errs)
 			}
@@ -489,6 +489,38 @@ func TestUnionSubTypes(t *testing.T) {
 // TestYangTypeToGoType tests the resolution of a particular YangType to the
 // corresponding Go type.
 func TestYangTypeToGoType(t *testing.T) {
+	// enumLeafrefTree is a schema tree containing an enumerated leaf, c,
+	// that is the target of a leafref.
+	enumLeafrefTree := &yang.Entry{
+		Name: "a",
+		Dir: map[string]*yang.Entry{
+			"b": {
+				Name: "b",
+				Dir: map[string]*yang.Entry{
+					"c": {
+						Name: "c",
+						Type: &yang.YangType{Name: "enumeration", Kind: yang.Yenum, Enum: &yang.EnumType{}},
+						Node: &yang.Enum{
+							Parent: &yang.Module{Name: "base-module"},
+						},
+						Parent: &yang.Entry{
+							Name: "b",
+							Parent: &yang.Entry{
+								Name:   "a",
+								Parent: &yang.Entry{Name: "module"},
+							},
+						},
+					},
+				},
+				Parent: &yang.Entry{
+					Name:   "a",
+					Parent: &yang.Entry{Name: "module"},
+				},
+			},
+		},
+		Parent: &yang.Entry{Name: "module"},
+	}
+
 	tests := []struct {
 		name            string
 		in              *yang.YangType
@@ -517,8 +549,12 @@ func TestYangTypeToGoType(t *testing.T) {
 		want: &MappedType{NativeType: "Binary", ZeroValue: "nil"},
 	}, {
 		name: "unknown lookup resolution",
-		in:   &yang.YangType{Kind: yang.YinstanceIdentifier, Name: "instanceIdentifier"},
+		in:   &yang.YangType{Kind: yang.Ybits, Name: "bits"},
 		want: &MappedType{NativeType: "interface{}", ZeroValue: "nil"},
+	}, {
+		name: "instance-identifier resolution",
+		in:   &yang.YangType{Kind: yang.YinstanceIdentifier, Name: "instance-identifier"},
+		want: &MappedType{NativeType: "ygot.InstanceIdentifier", ZeroValue: `""`},
 	}, {
 		name: "simple empty resolution",
 		in:   &yang.YangType{Kind: yang.Yempty, Name: "empty"},
@@ -958,7 +994,143 @@ func TestYangTypeToGoType(t *testing.T) {
 				Parent: &yang.Entry{Name: "module"},
 			},
 		},
-		want: &MappedType{NativeType: "uint32", ZeroValue: "0"},
+		want: &MappedType{
+			NativeType:          "uint32",
+			ZeroValue:           "0",
+			ResolvedLeafrefType: &MappedType{NativeType: "uint32", ZeroValue: "0"},
+		},
+	}, {
+		name: "leafref to leafref",
+		ctx: &yang.Entry{
+			Name: "d",
+			Parent: &yang.Entry{
+				Name: "b",
+				Parent: &yang.Entry{
+					Name:   "a",
+					Parent: &yang.Entry{Name: "module"},
+				},
+			},
+			Type: &yang.YangType{Kind: yang.Yleafref, Name: "leafref", Path: "../c"},
+		},
+		inEntries: []*yang.Entry{
+			{
+				Name: "a",
+				Dir: map[string]*yang.Entry{
+					"b": {
+						Name: "b",
+						Dir: map[string]*yang.Entry{
+							"c": {
+								Name: "c",
+								Type: &yang.YangType{Kind: yang.Yleafref, Name: "leafref", Path: "../e"},
+								Parent: &yang.Entry{
+									Name: "b",
+									Parent: &yang.Entry{
+										Name:   "a",
+										Parent: &yang.Entry{Name: "module"},
+									},
+								},
+							},
+							"e": {
+								Name: "e",
+								Type: &yang.YangType{Kind: yang.Ystring},
+								Parent: &yang.Entry{
+									Name: "b",
+									Parent: &yang.Entry{
+										Name:   "a",
+										Parent: &yang.Entry{Name: "module"},
+									},
+								},
+							},
+						},
+						Parent: &yang.Entry{
+							Name:   "a",
+							Parent: &yang.Entry{Name: "module"},
+						},
+					},
+				},
+				Parent: &yang.Entry{Name: "module"},
+			},
+		},
+		want: &MappedType{
+			NativeType:          "string",
+			ZeroValue:           `""`,
+			ResolvedLeafrefType: &MappedType{NativeType: "string", ZeroValue: `""`},
+		},
+	}, {
+		name: "leafref to enumeration",
+		ctx: &yang.Entry{
+			Name: "d",
+			Parent: &yang.Entry{
+				Name: "b",
+				Parent: &yang.Entry{
+					Name:   "a",
+					Parent: &yang.Entry{Name: "module"},
+				},
+			},
+			Type: &yang.YangType{Kind: yang.Yleafref, Name: "leafref", Path: "../c"},
+		},
+		inEntries:      []*yang.Entry{enumLeafrefTree},
+		inEnumEntries:  []*yang.Entry{enumLeafrefTree},
+		inCompressPath: true,
+		want: &MappedType{
+			NativeType:          "E_A_C",
+			IsEnumeratedValue:   true,
+			ZeroValue:           "0",
+			ResolvedLeafrefType: &MappedType{NativeType: "E_A_C", IsEnumeratedValue: true, ZeroValue: "0"},
+		},
+	}, {
+		name: "union containing a leafref",
+		ctx: &yang.Entry{
+			Name: "d",
+			Parent: &yang.Entry{
+				Name: "b",
+				Parent: &yang.Entry{
+					Name:   "a",
+					Parent: &yang.Entry{Name: "module"},
+				},
+			},
+			Type: &yang.YangType{
+				Kind: yang.Yunion,
+				Name: "union",
+				Type: []*yang.YangType{
+					{Kind: yang.Yleafref, Name: "leafref", Path: "../c"},
+					{Kind: yang.Yuint32, Name: "uint32"},
+				},
+			},
+		},
+		inEntries: []*yang.Entry{
+			{
+				Name: "a",
+				Dir: map[string]*yang.Entry{
+					"b": {
+						Name: "b",
+						Dir: map[string]*yang.Entry{
+							"c": {
+								Name: "c",
+								Type: &yang.YangType{Kind: yang.Yuint32},
+								Parent: &yang.Entry{
+									Name: "b",
+									Parent: &yang.Entry{
+										Name:   "a",
+										Parent: &yang.Entry{Name: "module"},
+									},
+								},
+							},
+						},
+						Parent: &yang.Entry{
+							Name:   "a",
+							Parent: &yang.Entry{Name: "module"},
+						},
+					},
+				},
+				Parent: &yang.Entry{Name: "module"},
+			},
+		},
+		want: &MappedType{
+			NativeType: "uint32",
+			UnionTypes: map[string]int{"uint32": 0},
+			ZeroValue:  "0",
+		},
 	}, {
 		name: "enumeration from grouping used in multiple places - skip deduplication",
 		ctx: &yang.Entry{
@@ -1069,7 +1241,7 @@ func TestYangTypeToGoType(t *testing.T) {
 
 			enumMap := enumMapFromEntries(tt.inEnumEntries)
 			addEnumsToEnumMap(tt.ctx, enumMap)
-			enumSet, _, errs := findEnumSet(enumMap, tt.inCompressPath, false, tt.inSkipEnumDedup, true, true, true, nil)
+			enumSet, _, errs := findEnumSet(enumMap, tt.inCompressPath, false, tt.inSkipEnumDedup, false, true, true, true, nil)
 			if errs != nil {
 				if !tt.wantErr {
 					t.Errorf("findEnumSet failed: %v", errs)
@@ -1258,6 +1430,177 @@ func TestStructName(t *testing.T) {
 	}
 }
 
+// TestStructNameAbbreviations tests that type name abbreviations supplied to
+// the GoLangMapper are applied to directory names, and that collisions that
+// result from an abbreviation are resolved deterministically.
+func TestStructNameAbbreviations(t *testing.T) {
+	module := &yang.Entry{
+		Name: "openconfig-interfaces",
+		Dir:  map[string]*yang.Entry{},
+	}
+	interfaces := &yang.Entry{
+		Name:   "interfaces",
+		Dir:    map[string]*yang.Entry{},
+		Parent: module,
+	}
+	iface := &yang.Entry{
+		Name:     "interface",
+		Dir:      map[string]*yang.Entry{},
+		ListAttr: &yang.ListAttr{},
+		Parent:   interfaces,
+	}
+	interfaces.Dir["interface"] = iface
+	subinterfaces := &yang.Entry{
+		Name:   "subinterfaces",
+		Dir:    map[string]*yang.Entry{},
+		Parent: iface,
+	}
+	subiface := &yang.Entry{
+		Name:     "subinterface",
+		Dir:      map[string]*yang.Entry{},
+		ListAttr: &yang.ListAttr{},
+		Parent:   subinterfaces,
+	}
+	subinterfaces.Dir["subinterface"] = subiface
+	intf := &yang.Entry{
+		Name:   "intf",
+		Dir:    map[string]*yang.Entry{},
+		Parent: module,
+	}
+	module.Dir["interfaces"] = interfaces
+	module.Dir["intf"] = intf
+
+	tests := []struct {
+		name            string
+		inAbbreviations map[string]string
+		inElements      []*yang.Entry
+		want            []string
+	}{{
+		name:       "no abbreviations",
+		inElements: []*yang.Entry{iface, subiface},
+		want:       []string{"Interface", "Interface_Subinterface"},
+	}, {
+		name: "abbreviations applied to each path element",
+		inAbbreviations: map[string]string{
+			"Interface":    "Intf",
+			"Subinterface": "Subintf",
+		},
+		inElements: []*yang.Entry{iface, subiface},
+		want:       []string{"Intf", "Intf_Subintf"},
+	}, {
+		name: "abbreviation causes collision with existing name",
+		inAbbreviations: map[string]string{
+			"Interface": "Intf",
+		},
+		inElements: []*yang.Entry{intf, iface, subiface},
+		want:       []string{"Intf", "Intf_", "Intf_Subinterface"},
+	}, {
+		name: "abbreviation causes collision with later name",
+		inAbbreviations: map[string]string{
+			"Interface": "Intf",
+		},
+		inElements: []*yang.Entry{iface, intf},
+		want:       []string{"Intf", "Intf_"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Run twice to ensure that the names generated are stable.
+			for i := 0; i < 2; i++ {
+				s := NewGoLangMapper(true)
+				s.SetTypeNameAbbreviations(tt.inAbbreviations)
+				var got []string
+				for _, e := range tt.inElements {
+					name, err := s.DirectoryName(e, genutil.PreferIntendedConfig)
+					if err != nil {
+						t.Fatalf("DirectoryName(%s): got unexpected error: %v", e.Path(), err)
+					}
+					got = append(got, name)
+				}
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("DirectoryName: did not get expected names, (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+// TestUnionNameAbbreviations tests that type name abbreviations supplied to
+// the GoLangMapper are applied to the names of unions, that unions whose
+// unabbreviated names are equal share a name, and that unions whose names
+// collide once abbreviated are made unique.
+func TestUnionNameAbbreviations(t *testing.T) {
+	unionLeaf := func(container string) *yang.Entry {
+		return &yang.Entry{
+			Name: "leaf",
+			Type: &yang.YangType{
+				Kind: yang.Yunion,
+				Type: []*yang.YangType{
+					{Kind: yang.Ystring, Name: "string"},
+					{Kind: yang.Yint8, Name: "int8"},
+				},
+			},
+			Parent: &yang.Entry{
+				Name:   container,
+				Parent: &yang.Entry{Name: "module"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		inAbbreviations map[string]string
+		inLeaves        []*yang.Entry
+		want            []string
+	}{{
+		name:     "no abbreviations",
+		inLeaves: []*yang.Entry{unionLeaf("interface"), unionLeaf("intf")},
+		want:     []string{"Module_Interface_Leaf_Union", "Module_Intf_Leaf_Union"},
+	}, {
+		name: "abbreviation applied",
+		inAbbreviations: map[string]string{
+			"Interface": "If",
+		},
+		inLeaves: []*yang.Entry{unionLeaf("interface"), unionLeaf("intf")},
+		want:     []string{"Module_If_Leaf_Union", "Module_Intf_Leaf_Union"},
+	}, {
+		name: "leaves with the same unabbreviated name share a union",
+		inAbbreviations: map[string]string{
+			"Interface": "If",
+		},
+		inLeaves: []*yang.Entry{unionLeaf("interface"), unionLeaf("interface")},
+		want:     []string{"Module_If_Leaf_Union", "Module_If_Leaf_Union"},
+	}, {
+		name: "abbreviation causes collision",
+		inAbbreviations: map[string]string{
+			"Interface": "Intf",
+		},
+		inLeaves: []*yang.Entry{unionLeaf("interface"), unionLeaf("intf"), unionLeaf("interface")},
+		want:     []string{"Module_Intf_Leaf_Union", "Module_Intf_Leaf_Union_", "Module_Intf_Leaf_Union"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Run twice to ensure that the names generated are stable.
+			for i := 0; i < 2; i++ {
+				s := NewGoLangMapper(true)
+				s.SetTypeNameAbbreviations(tt.inAbbreviations)
+				var got []string
+				for _, e := range tt.inLeaves {
+					mtype, err := s.yangTypeToGoType(resolveTypeArgs{yangType: e.Type, contextEntry: e}, false, false, false, false, nil)
+					if err != nil {
+						t.Fatalf("yangTypeToGoType(%s): got unexpected error: %v", e.Path(), err)
+					}
+					got = append(got, mtype.NativeType)
+				}
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("yangTypeToGoType: did not get expected union names, (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
 // TestTypeResolutionManyToOne tests cases where there can be many leaves that target the
 // same underlying typedef or identity, ensuring that generated names are reused where required.
 func TestTypeResolutionManyToOne(t *testing.T) {
@@ -1448,7 +1791,7 @@ func TestTypeResolutionManyToOne(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			enumSet, _, errs := findEnumSet(enumMapFromEntries(tt.inLeaves), tt.inCompressOCPaths, false, tt.inSkipEnumDedup, true, true, true, nil)
+			enumSet, _, errs := findEnumSet(enumMapFromEntries(tt.inLeaves), tt.inCompressOCPaths, false, tt.inSkipEnumDedup, false, true, true, true, nil)
 			if errs != nil {
 				t.Fatalf("findEnumSet failed: %v", errs)
 			}
@@ -1594,11 +1937,24 @@ func TestYangDefaultValueToGo(t *testing.T) {
 		wantKind:      yang.Ystring,
 		wantUnionName: `UnionString("foo")`,
 	}, {
-		name:     "unknown lookup resolution",
+		name:          "instance-identifier",
+		inType:        &yang.YangType{Kind: yang.YinstanceIdentifier},
+		inValue:       "/ex:a/ex:b[ex:c='d']",
+		want:          `ygot.InstanceIdentifier("/ex:a/ex:b[ex:c='d']")`,
+		wantKind:      yang.YinstanceIdentifier,
+		wantUnionName: `UnionInstanceIdentifier(ygot.InstanceIdentifier("/ex:a/ex:b[ex:c='d']"))`,
+	}, {
+		name:     "invalid instance-identifier",
 		inType:   &yang.YangType{Kind: yang.YinstanceIdentifier},
 		inValue:  "foo",
 		wantErr:  true,
 		wantKind: yang.Ynone,
+	}, {
+		name:     "unknown lookup resolution",
+		inType:   &yang.YangType{Kind: yang.Ybits},
+		inValue:  "foo",
+		wantErr:  true,
+		wantKind: yang.Ynone,
 	}, {
 		name:     "empty is not allowed to have a default value",
 		inType:   &yang.YangType{Kind: yang.Yempty},
@@ -2454,7 +2810,7 @@ func TestYangDefaultValueToGo(t *testing.T) {
 			t.Run(tt.name, func(t *testing.T) {
 				enumMap := enumMapFromEntries(tt.inEnumEntries)
 				addEnumsToEnumMap(tt.inCtx, enumMap)
-				enumSet, _, errs := findEnumSet(enumMap, tt.inCompressPath, false, tt.inSkipEnumDedup, true, true, true, nil)
+				enumSet, _, errs := findEnumSet(enumMap, tt.inCompressPath, false, tt.inSkipEnumDedup, false, true, true, true, nil)
 				if errs != nil {
 					if !tt.wantErr {
 						t.Errorf("findEnumSet failed: %v", errs)
@@ -2600,8 +2956,14 @@ func TestYangDefaultValueToGo(t *testing.T) {
 		want:     `"foo"`,
 		wantKind: yang.Ystring,
 	}, {
-		name:     "unknown lookup resolution",
+		name:     "instance-identifier",
 		inType:   &yang.YangType{Kind: yang.YinstanceIdentifier},
+		inValue:  "/a/b",
+		want:     `ygot.InstanceIdentifier("/a/b")`,
+		wantKind: yang.YinstanceIdentifier,
+	}, {
+		name:     "unknown lookup resolution",
+		inType:   &yang.YangType{Kind: yang.Ybits},
 		inValue:  "foo",
 		wantErr:  true,
 		wantKind: yang.Ynone,
@@ -2815,7 +3177,7 @@ func TestYangDefaultValueToGo(t *testing.T) {
 		t.Run("singleton union "+tt.name, func(t *testing.T) {
 			enumMap := enumMapFromEntries(tt.inEnumEntries)
 			addEnumsToEnumMap(tt.inCtx, enumMap)
-			enumSet, _, errs := findEnumSet(enumMap, tt.inCompressPath, false, tt.inSkipEnumDedup, true, true, true, nil)
+			enumSet, _, errs := findEnumSet(enumMap, tt.inCompressPath, false, tt.inSkipEnumDedup, false, true, true, true, nil)
 			if errs != nil {
 				if !tt.wantErr {
 					t.Errorf("findEnumSet failed: %v", errs)
@@ -2860,3 +3222,71 @@ func TestYangDefaultValueToGo(t *testing.T) {
 		}
 	}
 }
+
+// TestSetCustomTypeMap tests the parsing of the mapping of YANG typedefs to
+// custom Go types.
+func TestSetCustomTypeMap(t *testing.T) {
+	tests := []struct {
+		name            string
+		in              map[string]string
+		wantTypes       map[string]string
+		wantTypeImports map[string]string
+		wantErr         bool
+	}{{
+		name: "type within imported package",
+		in: map[string]string{
+			"ietf-inet-types:ipv4-address": "net/netip.Addr",
+		},
+		wantTypes: map[string]string{
+			"ietf-inet-types:ipv4-address": "netip.Addr",
+		},
+		wantTypeImports: map[string]string{
+			"netip.Addr": "net/netip",
+		},
+	}, {
+		name: "type within generated package",
+		in: map[string]string{
+			"ietf-inet-types:port-number": "PortNumber",
+		},
+		wantTypes: map[string]string{
+			"ietf-inet-types:port-number": "PortNumber",
+		},
+		wantTypeImports: map[string]string{},
+	}, {
+		name: "unqualified typedef name",
+		in: map[string]string{
+			"port-number": "PortNumber",
+		},
+		wantErr: true,
+	}, {
+		name: "missing type name",
+		in: map[string]string{
+			"ietf-inet-types:ipv4-address": "net/netip.",
+		},
+		wantErr: true,
+	}, {
+		name: "missing package path",
+		in: map[string]string{
+			"ietf-inet-types:ipv4-address": ".Addr",
+		},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewGoLangMapper(true)
+			if err := s.SetCustomTypeMap(tt.in); (err != nil) != tt.wantErr {
+				t.Fatalf("SetCustomTypeMap(%v): got error %v, wantErr: %v", tt.in, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(s.customTypes, tt.wantTypes); diff != "" {
+				t.Errorf("SetCustomTypeMap(%v): did not get expected custom types, diff(-got,+want):\n%s", tt.in, diff)
+			}
+			if diff := cmp.Diff(s.customTypeImports, tt.wantTypeImports); diff != "" {
+				t.Errorf("SetCustomTypeMap(%v): did not get expected custom type imports, diff(-got,+want):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
 	"strings"
 	"text/template"
@@ -28,6 +29,7 @@ import (
 	"github.com/openconfig/ygot/genutil"
 	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
+	"github.com/openconfig/ygot/ytypes"
 )
 
 const (
@@ -173,6 +175,9 @@ type goStructField struct {
 	// types.
 	IsScalarField bool
 	Tags          string // Tags specifies the tags that should be used to annotate the field.
+	// Comments is the set of lines of the comment that is output above
+	// the field, each without the leading comment marker.
+	Comments []string
 	// IsYANGContainer stores whether the field is a YANG container. This value
 	// is used in templates to determine whether GetOrCreate methods should be
 	// created.
@@ -194,6 +199,22 @@ type goUnionInterface struct {
 	ConversionSpecs      []*unionConversionSpec // ConversionSpecs contains information on how to convert primitive types to their own union-satisfying types.
 	HasUnsupported       bool                   // HasUnsupported indicates that at least one of the union's subtypes is unsupported.
 	SubtypeDocumentation string                 // SubtypeDocumentation gives a documentation-style string on the subtypes of the union.
+	GenericTerms         []*genericUnionTerm    // GenericTerms contains the terms of the type constraint generated for the union when generic unions are enabled.
+}
+
+// genericUnionTerm describes an element of the type constraint that is
+// generated for a union when generic unions are enabled.
+type genericUnionTerm struct {
+	// Term is the text of the element within the constraint.
+	Term string
+	// Type is the name of the type within the union that the element
+	// corresponds to.
+	Type string
+	// Kind is the name of the reflect.Kind of the underlying type of Type,
+	// populated only where Term is an approximation element, such that
+	// values of other types with the same underlying type can be converted
+	// to Type.
+	Kind string
 }
 
 // generatedGoStruct is used to repesent a Go structure to be handed to a template for output.
@@ -202,6 +223,11 @@ type generatedGoStruct struct {
 	YANGPath        string           // YANGPath is the schema path of the struct being output.
 	Fields          []*goStructField // Fields is the slice of fields of the struct, described as goStructField structs.
 	BelongingModule string           // BelongingModule is the module in which namespace the GoStruct belongs.
+	Deprecated      string           // Deprecated is the deprecation notice output in the comment of the struct, if any.
+	// ValidateLeafrefs indicates that the leafrefs within the data tree
+	// rooted at the struct are checked by the generated validateLeafrefs
+	// method, rather than by ytypes, when the struct is validated.
+	ValidateLeafrefs bool
 }
 
 // generatedGoMultiKeyListStruct is used to represent a struct used as a key of a YANG list that has multiple
@@ -211,6 +237,7 @@ type generatedGoMultiKeyListStruct struct {
 	Keys          []goStructField // Keys is a slice of goStructFields that are contained in the key struct.
 	ParentPath    string          // ParentPath is the path to the list's parent in the YANG schema.
 	ListName      string          // ListName is the name of the list itself in the YANG schema.
+	KeyYANGNames  []string        // KeyYANGNames is the YANG name of each of Keys, in the same order.
 }
 
 // generatedGoListMethod contains the fields required for generating the methods
@@ -221,6 +248,17 @@ type generatedGoListMethod struct {
 	Keys      []goStructField // Keys of the list that is being generated (length = 1 if the list is single keyed).
 	KeyStruct string          // KeyStruct is the name of the struct used as a key for a multi-keyed list.
 	Receiver  string          // Receiver is the name of the parent struct of the list, which is the receiver for the generated method.
+	// OrderedMap is the name of the ordered map type that stores the list,
+	// where the list is ordered-by user and ordered list support is
+	// enabled. It is empty otherwise.
+	OrderedMap string
+	// KeyType is the type of the key of the list within the ordered map.
+	// It is only populated when OrderedMap is populated.
+	KeyType string
+	// ValueEntries indicates that the members of the list are stored by
+	// value, rather than as pointers, within the map that represents the
+	// list.
+	ValueEntries bool
 }
 
 // generatedGoKeyHelper contains the fields required for generating a method
@@ -257,6 +295,9 @@ type generatedGoEnumeration struct {
 	// enumerated type. The numeric value may be explicitly assigned by the schema,
 	// or populated by goyang during the parsing of the module.
 	Values map[int64]string
+	// DefaultIsZero indicates that the value 0 of the enumeration is its
+	// YANG default value, rather than UNSET.
+	DefaultIsZero bool
 }
 
 // generatedLeafGetter is used to represent the parameters required to generate a
@@ -275,10 +316,119 @@ type generatedLeafGetter struct {
 	// IsPtr stores whether the value is a pointer, such that it can be checked
 	// against nil, or against the zero value.
 	IsPtr bool
+	// LenField is the name of the field storing the number of populated
+	// elements of the field, where it is a leaf-list that is output as a
+	// fixed-size array. The getter returns the populated elements as a slice.
+	LenField string
 	// Receiver is the name of the receiver for the getter method.
 	Receiver string
 }
 
+// generatedDefaultConstant is used to represent the parameters required to
+// generate a package-level variable storing the YANG default value of a leaf
+// or leaf-list within the generated Go code.
+type generatedDefaultConstant struct {
+	// Name is the name of the variable.
+	Name string
+	// FieldName is the name of the field whose default is stored.
+	FieldName string
+	// Receiver is the name of the struct within which the field is defined.
+	Receiver string
+	// Type is the type of the variable. It is empty where the type is
+	// implied by the value, as is the case for the slice literals that are
+	// used for leaf-lists.
+	Type string
+	// Value is the Go literal for the default value.
+	Value string
+}
+
+// generatedLeafSetter is used to represent the parameters required to generate a
+// setter for a leaf within the generated Go code.
+type generatedLeafSetter struct {
+	// Name is the name of the field. It is used as a suffix to Set to generate
+	// the setter.
+	Name string
+	// YANGName is the name of the leaf within the YANG schema.
+	YANGName string
+	// Type is the type of the field, which is the type of the argument of the
+	// generated method.
+	Type string
+	// IsPtr stores whether the field is a pointer, such that the address of
+	// the argument is stored.
+	IsPtr bool
+	// Range is the YANG representation of the range to which the value of the
+	// leaf is restricted. It is empty where the leaf is not restricted.
+	Range string
+	// RangeConditions is a set of Go boolean expressions, of which one must
+	// be true for the argument of the generated method to be within Range.
+	RangeConditions []string
+	// Receiver is the name of the receiver for the setter method.
+	Receiver string
+	// PresenceField is the name of the field of the receiver within which
+	// the setter records that the leaf has been set. It is empty when the
+	// presence of fields is not tracked.
+	PresenceField string
+}
+
+// generatedWasSetMethod is used to represent the parameters required to
+// generate a method that determines whether a field of a struct within the
+// generated Go code has been set using its setter.
+type generatedWasSetMethod struct {
+	// Receiver is the name of the receiver for the method.
+	Receiver string
+	// PresenceField is the name of the field of the receiver within which
+	// setters record the fields that have been set.
+	PresenceField string
+}
+
+// generatedHasMethod is used to represent the parameters required to generate
+// a method that determines whether a field within the generated Go code is
+// populated.
+type generatedHasMethod struct {
+	// Name is the name of the field. It is used as a suffix to Has to
+	// generate the method.
+	Name string
+	// Zero is the value of the field when it is not populated. It is unused
+	// where the field is a collection, or is always set.
+	Zero string
+	// IsCollection stores whether the field is a slice or map, such that it
+	// is populated when it is non-empty.
+	IsCollection bool
+	// IsOrderedList stores whether the field is an ordered list, such that
+	// it is populated when it has members.
+	IsOrderedList bool
+	// AlwaysSet stores whether the field is a leaf that is output as a value
+	// type, which is always considered to be populated.
+	AlwaysSet bool
+	// LenField is the name of the field storing the number of populated
+	// elements of the field, where it is a leaf-list that is output as a
+	// fixed-size array.
+	LenField string
+	// Receiver is the name of the receiver for the method.
+	Receiver string
+}
+
+// generatedUnionAccessor is used to represent the parameters required to
+// generate a method that returns the value of a union field as one of the
+// types within the union.
+type generatedUnionAccessor struct {
+	// Name is the name of the method.
+	Name string
+	// FieldName is the name of the union field.
+	FieldName string
+	// Type is the Go type that the method returns the value of the field
+	// as.
+	Type string
+	// AssertType is the type within the union that values of Type are
+	// stored as.
+	AssertType string
+	// Value is the expression that converts v, a value of AssertType, to
+	// Type.
+	Value string
+	// Receiver is the name of the receiver for the method.
+	Receiver string
+}
+
 // generatedDefaultMethod is used to represent parameters required to generate
 // a PopulateDefaults method for a GoStruct that recursively populates default
 // values within the subtree.
@@ -289,14 +439,104 @@ type generatedDefaultMethod struct {
 	ChildContainerNames []string
 	// ChildContainerNames are the names of the list fields of the GoStruct.
 	ChildListNames []string
+	// ChildOrderedListNames are the names of the ordered list fields of
+	// the GoStruct.
+	ChildOrderedListNames []string
+	// ChildValueListNames are the names of the list fields of the GoStruct
+	// whose members are stored by value.
+	ChildValueListNames []string
 	// Leaves represent the leaf fields of the GoStruct.
 	Leaves []*generatedLeafGetter
 }
 
+// generatedEqualMethod is used to represent parameters required to generate
+// an Equal method for a GoStruct.
+type generatedEqualMethod struct {
+	// Receiver is the name of the receiver for the Equal method.
+	Receiver string
+	// Fields are the fields of the GoStruct that are compared by the
+	// method, in the order that they are output.
+	Fields []*equalMethodField
+}
+
+// equalMethodField describes a field of a GoStruct that is compared within
+// its generated Equal method.
+type equalMethodField struct {
+	// Name is the name of the field.
+	Name string
+	// IsContainer indicates that the field is a YANG container, and hence
+	// is compared using the child struct's Equal method.
+	IsContainer bool
+	// IsKeyedList indicates that the field is a keyed YANG list, which is
+	// represented as a map.
+	IsKeyedList bool
+	// IsUnkeyedList indicates that the field is an unkeyed YANG list, which
+	// is represented as a slice.
+	IsUnkeyedList bool
+	// IsOrderedList indicates that the field is a keyed YANG list that is
+	// ordered-by user, which is represented as an ordered map.
+	IsOrderedList bool
+	// IsValueEntryList indicates that the field is a keyed YANG list whose
+	// members are stored by value within the map that represents it.
+	IsValueEntryList bool
+	// IsLeafList indicates that the field is a YANG leaf-list.
+	IsLeafList bool
+	// IsPtr indicates that the field is a pointer to a scalar value.
+	IsPtr bool
+	// IsBinary indicates that the field (or element of a leaf-list) is of
+	// the binary type, which is represented as a byte slice.
+	IsBinary bool
+	// IsUnion indicates that the field (or element of a leaf-list) is a
+	// union interface type.
+	IsUnion bool
+	// LenField is the name of the field storing the number of populated
+	// elements of a leaf-list that is output as a fixed-size array, such
+	// that only the populated elements are compared.
+	LenField string
+}
+
+// generatedPopulatedChildrenMethod is used to represent the parameters
+// required to generate a ΛPopulatedChildren method for a GoStruct, which
+// returns the schema paths of its populated children.
+type generatedPopulatedChildrenMethod struct {
+	// Receiver is the name of the receiver for the method.
+	Receiver string
+	// Fields are the fields of the GoStruct that correspond to schema
+	// nodes, in the order that they are output.
+	Fields []*populatedChildField
+}
+
+// populatedChildField describes a field of a GoStruct whose schema paths are
+// returned by its generated ΛPopulatedChildren method when it is populated.
+type populatedChildField struct {
+	// Has describes how to determine whether the field is populated.
+	Has *generatedHasMethod
+	// Paths are the schema paths that the field is mapped to, relative
+	// to the GoStruct.
+	Paths []string
+	// Keys are the keys of the list where the field is a keyed list, in
+	// the order in which they are specified in the YANG schema.
+	Keys []*populatedChildKey
+	// ValueEntries indicates that the members of the keyed list are
+	// stored by value within the map that represents it.
+	ValueEntries bool
+}
+
+// populatedChildKey describes a key of a keyed list within a generated
+// ΛPopulatedChildren method.
+type populatedChildKey struct {
+	// Name is the YANG name of the key.
+	Name string
+	// Value is the expression that returns the value of the key from k,
+	// the key of a member within the map that represents the list.
+	Value string
+}
+
 var (
 	// goCommonHeaderTemplate is populated and output at the top of the generated code package
 	goCommonHeaderTemplate = mustMakeTemplate("commonHeader", `
 {{- /**/ -}}
+{{ .Preamble -}}
 /*
 Package {{ .PackageName }} is a generated package which contains definitions
 of structs which represent a YANG schema. The generated schema can be
@@ -316,9 +556,18 @@ Imported modules were sourced from:
 package {{ .PackageName }}
 
 import (
+{{- if and .GenerateSchema .GoOptions.ValidateWithContext }}
+	"context"
+{{- end }}
 	"encoding/json"
 	"fmt"
 	"reflect"
+{{- if .GoOptions.GenerateChildPaths }}
+	"sort"
+{{- end }}
+{{- if .LeafrefValidation }}
+	"strings"
+{{- end }}
 
 	"{{ .GoOptions.YgotImportPath }}"
 
@@ -326,20 +575,52 @@ import (
 	"{{ .GoOptions.GoyangImportPath }}"
 	"{{ .GoOptions.YtypesImportPath }}"
 {{- end }}
-{{- if .GoOptions.IncludeModelData }}
+{{- if or .GoOptions.IncludeModelData (and .GenerateSchema .GoOptions.GenerateSubtreeValidate) .RootGetByPath }}
 	gpb "{{ .GoOptions.GNMIProtoPath }}"
 {{- end }}
+{{- if .RootProtoBridge }}
+	"{{ .GoOptions.ProtomapImportPath }}"
+	"google.golang.org/protobuf/proto"
+{{- end }}
+{{- if .CustomTypeImports }}
+{{ range $importPath := .CustomTypeImports }}
+	"{{ $importPath }}"
+{{- end }}
+{{- end }}
+)
+`)
+
+	// goEnumFileHeaderTemplate defines the header of the separate file that
+	// enumerated types are output to when GoOpts.SeparateEnumFile is set. It
+	// imports only the packages that are used by the enumerated type code.
+	goEnumFileHeaderTemplate = mustMakeTemplate("enumFileHeader", `
+{{- /**/ -}}
+{{ .Preamble -}}
+package {{ .PackageName }}
+
+import (
+{{- if .UsesReflect }}
+	"reflect"
+{{ end }}
+	"{{ .YgotImportPath }}"
 )
 `)
 
 	// goOneOffHeaderTemplate defines the template for package code that should
 	// be output in only one file.
 	goOneOffHeaderTemplate = mustMakeTemplate("oneoffHeader", `
+{{ if .CustomBinaryType -}}
+// {{ .BinaryTypeName }} is the type that is used for fields that have a YANG type of
+// binary. It is an alias of {{ .CustomBinaryType }}, which is used in place of the
+// generated type.
+type {{ .BinaryTypeName }} = {{ .CustomBinaryType }}
+{{- else -}}
 // {{ .BinaryTypeName }} is a type that is used for fields that have a YANG type of
 // binary. It is used such that binary fields can be distinguished from
 // leaf-lists of uint8s (which are mapped to []uint8, equivalent to
 // []byte in reflection).
 type {{ .BinaryTypeName }} []byte
+{{- end }}
 
 // {{ .EmptyTypeName }} is a type that is used for fields that have a YANG type of
 // empty. It is used such that empty fields can be distinguished from boolean fields
@@ -380,6 +661,12 @@ type UnionString string
 
 // UnionBool is a bool type assignable to unions of which it is a subtype.
 type UnionBool bool
+{{- if .InstanceIdentifierUnions }}
+
+// {{ .UnionInstanceIdentifierTypeName }} is an instance-identifier type assignable to unions
+// of which it is a subtype.
+type {{ .UnionInstanceIdentifierTypeName }} ygot.InstanceIdentifier
+{{- end }}
 
 // UnionUnsupported is an interface{} wrapper type for unsupported types. It is
 // assignable to unions of which it is a subtype.
@@ -387,6 +674,22 @@ type UnionUnsupported struct {
 	Value interface{}
 }
 
+{{- if .GoOptions.GenericUnions }}
+
+// UnionValue wraps a value of type T, which is stored within a union, such
+// that the values of unions can be handled generically.
+type UnionValue[T any] struct {
+	Value T
+}
+
+// UnionValueOf returns the value stored within the union u as a UnionValue[T].
+// It returns false if the value stored within u is not of type T.
+func UnionValueOf[T any](u interface{}) (UnionValue[T], bool) {
+	v, ok := u.(T)
+	return UnionValue[T]{Value: v}, ok
+}
+{{- end }}
+
 {{- end }}
 
 {{- if .GenerateSchema }}
@@ -423,7 +726,11 @@ func Schema() (*ytypes.Schema, error) {
 func UnzipSchema() (map[string]*yang.Entry, error) {
 	var schemaTree map[string]*yang.Entry
 	var err error
+{{- if .SchemaPerModule }}
+	if schemaTree, err = ygot.GzipToSchemaModules(ySchema); err != nil {
+{{- else }}
 	if schemaTree, err = ygot.GzipToSchema(ySchema); err != nil {
+{{- end }}
 		return nil, fmt.Errorf("could not unzip the schema; %v", err)
 	}
 	return schemaTree, nil
@@ -477,8 +784,15 @@ var ΓModelData = []*gpb.ModelData{
 	// structs; and containers are mapped into structs.
 	goStructTemplate = mustMakeTemplate("struct", `
 // {{ .StructName }} represents the {{ .YANGPath }} YANG schema element.
+{{- with .Deprecated }}
+//
+// Deprecated: {{ . }}
+{{- end }}
 type {{ .StructName }} struct {
 {{- range $idx, $field := .Fields }}
+	{{- range $field.Comments }}
+	//{{ if . }} {{ . }}{{ end }}
+	{{- end }}
 	{{- if $field.IsScalarField }}
 	{{ $field.Name }}	*{{ $field.Type }}	`+"`"+`{{ $field.Tags }}`+"`"+`
 	{{- else }}
@@ -499,10 +813,124 @@ func (*{{ .StructName }}) IsYANGGoStruct() {}
 	goStructValidatorTemplate = mustMakeTemplate("structValidator", `
 // Validate validates s against the YANG schema corresponding to its type.
 func (t *{{ .StructName }}) ΛValidate(opts ...ygot.ValidationOption) error {
+{{- if .ValidateLeafrefs }}
+	if err := ytypes.Validate(SchemaTree["{{ .StructName }}"], t, append(opts[:len(opts):len(opts)], &ytypes.LeafrefOptions{IgnoreMissingData: true})...); err != nil {
+		return err
+	}
+	return t.validateLeafrefs(opts...)
+{{- else }}
 	if err := ytypes.Validate(SchemaTree["{{ .StructName }}"], t, opts...); err != nil {
 		return err
 	}
 	return nil
+{{- end }}
+}
+`)
+
+	// goStructContextValidatorTemplate takes an input generatedGoStruct and
+	// generates a validation method that can be cancelled using the supplied
+	// context.
+	goStructContextValidatorTemplate = mustMakeTemplate("structContextValidator", `
+// ΛValidateContext validates s against the YANG schema corresponding to its
+// type, returning ctx.Err() if ctx is done before validation completes.
+func (t *{{ .StructName }}) ΛValidateContext(ctx context.Context, opts ...ygot.ValidationOption) error {
+	if err := ytypes.ValidateContext(ctx, SchemaTree["{{ .StructName }}"], t, opts...); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	return nil
+}
+`)
+
+	// goStructSubtreeValidatorTemplate takes an input generatedGoStruct and
+	// generates a validation method that validates only the subtree of the
+	// struct at the supplied path.
+	goStructSubtreeValidatorTemplate = mustMakeTemplate("structSubtreeValidator", `
+// ΛValidateAt validates the subtree of s found at path against the YANG
+// schema corresponding to its type, without validating the rest of s.
+func (t *{{ .StructName }}) ΛValidateAt(path *gpb.Path, opts ...ygot.ValidationOption) error {
+	if err := ytypes.ValidateAt(SchemaTree["{{ .StructName }}"], t, path, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+`)
+
+	// goCBORMethodsTemplate takes an input generatedGoStruct, which must be
+	// the fake root, and generates methods that marshal and unmarshal the
+	// data tree rooted at the struct to and from CBOR.
+	goCBORMethodsTemplate = mustMakeTemplate("cborMethods", `
+// ΛMarshalCBOR marshals the data tree rooted at t to a CBOR data item, which
+// has the structure of the RFC7951 JSON representation of t, using the
+// supplied codec. The data item is not encoded according to RFC9254
+// YANG-CBOR: values such as 64-bit integers and binary leaves are encoded
+// as text strings, as they are within RFC7951 JSON.
+func (t *{{ .StructName }}) ΛMarshalCBOR(codec ygot.CBORCodec) ([]byte, error) {
+	return ygot.MarshalCBOR(t, codec, &ygot.RFC7951JSONConfig{AppendModuleName: true})
+}
+
+// ΛUnmarshalCBOR unmarshals the CBOR data item in data, which has the
+// structure of the RFC7951 JSON representation of the data tree, such as is
+// output by ΛMarshalCBOR, into t using the supplied codec. The supplied
+// options (opts) are used to control the behaviour of the unmarshal function.
+func (t *{{ .StructName }}) ΛUnmarshalCBOR(codec ygot.CBORCodec, data []byte, opts ...ytypes.UnmarshalOpt) error {
+	tree, err := ygot.UnmarshalCBORTree(codec, data)
+	if err != nil {
+		return err
+	}
+	return ytypes.Unmarshal(SchemaTree["{{ .StructName }}"], t, tree, opts...)
+}
+`)
+
+	// goRootGetByPathTemplate takes an input generatedGoStruct, which must be
+	// the fake root, and generates a method that returns the value found at
+	// a gNMI path within the data tree rooted at the struct.
+	goRootGetByPathTemplate = mustMakeTemplate("rootGetByPath", `
+// GetByPath returns the value found at path within the data tree rooted at
+// t. The value returned is either that of a leaf, or a pointer to the struct
+// representing a container or list member. The keys of any lists along the
+// path must be specified. An error is returned if no populated node is found
+// at path.
+func (t *{{ .StructName }}) GetByPath(path *gpb.Path) (interface{}, error) {
+	nodes, err := ytypes.GetNode(SchemaTree["{{ .StructName }}"], t, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) != 1 {
+		return nil, fmt.Errorf("path %v matched %d nodes, expected 1", path, len(nodes))
+	}
+	switch v := reflect.ValueOf(nodes[0].Data); v.Kind() {
+	case reflect.Invalid:
+		return nil, fmt.Errorf("no value found at path %v", path)
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		if v.IsNil() {
+			return nil, fmt.Errorf("no value found at path %v", path)
+		}
+	}
+	return nodes[0].Data, nil
+}
+`)
+
+	// goRootProtoBridgeTemplate takes an input generatedGoStruct, which must
+	// be the fake root, and generates methods that map the data tree to and
+	// from the protobuf generated for the root of the same YANG schema.
+	goRootProtoBridgeTemplate = mustMakeTemplate("rootProtoBridge", `
+// ToProto populates p, which must be the protobuf message generated for the
+// root of the YANG schema, with the contents of the data tree rooted at t.
+// Leaf-lists, decimal64 leaves, and protobufs generated with proto maps are
+// not supported, and an error is returned if such a field is populated.
+func (t *{{ .StructName }}) ToProto(p proto.Message) error {
+	return protomap.GoStructToProto(t, p)
+}
+
+// FromProto populates the data tree rooted at t with the contents of p, which
+// must be the protobuf message generated for the root of the YANG schema.
+// Leaf-lists, decimal64 leaves, and protobufs generated with proto maps are
+// not supported, and an error is returned if such a field is populated.
+func (t *{{ .StructName }}) FromProto(p proto.Message) error {
+	return protomap.GoStructFromProto(p, SchemaTree["{{ .StructName }}"], t)
 }
 `)
 
@@ -572,17 +1000,52 @@ type {{ .KeyStructName }} struct {
 	{{ $key.Name }}	{{ $key.Type }}	`+"`{{ $key.Tags }}`"+`
 {{- end }}
 }
+`)
+
+	// goListKeyHelpersTemplate takes an input generatedGoMultiKeyListStruct
+	// and generates helper methods for the struct used as the key of the
+	// multi-keyed list. The String method formats the key in the form used
+	// for keys within a gNMI path, and the Fields method returns the values
+	// of the keys keyed by their YANG name.
+	goListKeyHelpersTemplate = mustMakeTemplate("listKeyHelpers", `
+// String returns a string representation of the {{ .KeyStructName }} key, in the
+// form used for list keys within a gNMI path.
+func (k {{ .KeyStructName }}) String() string {
+	return fmt.Sprintf("
+	{{- range $idx, $key := .Keys -}}
+	[{{ index $.KeyYANGNames $idx }}=%v]
+	{{- end -}}
+	"{{ range $key := .Keys }}, k.{{ $key.Name }}{{ end }})
+}
+
+// Fields returns the values of the fields of the {{ .KeyStructName }} key,
+// keyed by the YANG name of the key leaf that each corresponds to.
+func (k {{ .KeyStructName }}) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		{{- range $idx, $key := .Keys }}
+		"{{ index $.KeyYANGNames $idx }}": k.{{ $key.Name }},
+		{{- end }}
+	}
+}
 `)
 
 	// goEnumDefinitionTemplate takes an input generatedGoEnumeration struct
 	// and outputs the Go code that is associated with the enumerated type to be
 	// generated.
 	goEnumDefinitionTemplate = mustMakeTemplate("enumDefinition", `
+{{- if .DefaultIsZero }}
+// E_{{ .EnumerationPrefix }} is a derived int64 type which is used to represent
+// the enumerated node {{ .EnumerationPrefix }}. The value {{ .EnumerationPrefix }}_{{ index .Values 0 }}
+// is the default value of the enumeration in the YANG schema, and is used as
+// the nil value, such that an unset enumeration is not distinguishable from
+// one that is set to its default value.
+{{- else }}
 // E_{{ .EnumerationPrefix }} is a derived int64 type which is used to represent
 // the enumerated node {{ .EnumerationPrefix }}. An additional value named
 // {{ .EnumerationPrefix }}_UNSET is added to the enumeration which is used as
 // the nil value, indicating that the enumeration was not explicitly set by
 // the program importing the generated structures.
+{{- end }}
 type E_{{ .EnumerationPrefix }} int64
 
 // IsYANGGoEnum ensures that {{ .EnumerationPrefix }} implements the yang.GoEnum
@@ -764,14 +1227,87 @@ func (t *{{ .Receiver }}) GetOrCreate{{ .ListName }}(
 // caller has different actions based on whether the leaf is set or unset, it
 // should use 'if t.{{ .Name }} == nil' before retrieving the leaf's value.
 func (t *{{ .Receiver }}) Get{{ .Name }}() {{ .Type }} {
-	if t == nil || t.{{ .Name }} == {{ if .IsPtr -}} nil {{- else }} {{ .Zero }} {{- end }} {
+	if t == nil || {{ if .LenField -}} t.{{ .LenField }} == 0 {{- else -}} t.{{ .Name }} == {{ if .IsPtr -}} nil {{- else }} {{ .Zero }} {{- end }} {{- end }} {
 		{{- if .Default }}
 		return {{ .Default }}
 		{{- else }}
 		return {{ .Zero }}
 		{{- end }}
 	}
-	return {{ if .IsPtr -}} * {{- end -}} t.{{ .Name }}
+	return {{ if .IsPtr -}} * {{- end -}} t.{{ .Name }} {{- if .LenField }}[:t.{{ .LenField }}] {{- end }}
+}
+`)
+
+	// goLeafSetterTemplate defines a template for a function that, for a
+	// particular leaf, generates a setter method.
+	goLeafSetterTemplate = mustMakeTemplate("setLeaf", `
+// Set{{ .Name }} sets the value of the leaf {{ .Name }} in the {{ .Receiver }}
+// struct.
+{{- if .RangeConditions }} An error is returned if v is not within the range
+// {{ .Range }} specified for the leaf by the YANG schema.
+{{- end }}
+func (t *{{ .Receiver }}) Set{{ .Name }}(v {{ .Type }}) error {
+	{{- if .RangeConditions }}
+	if !({{ range $i, $c := .RangeConditions }}{{ if $i }} || {{ end }}{{ $c }}{{ end }}) {
+		return fmt.Errorf("value %v for leaf {{ .YANGName }} is not within the range {{ .Range }}", v)
+	}
+	{{- end }}
+	t.{{ .Name }} = {{ if .IsPtr }}&{{ end }}v
+	{{- if .PresenceField }}
+	if t.{{ .PresenceField }} == nil {
+		t.{{ .PresenceField }} = map[string]bool{}
+	}
+	t.{{ .PresenceField }}["{{ .Name }}"] = true
+	{{- end }}
+	return nil
+}
+`)
+
+	// goWasSetTemplate defines a template for a method that returns whether
+	// a field of a struct has been set using its setter method.
+	goWasSetTemplate = mustMakeTemplate("wasSet", `
+// WasSet returns true if the field of the {{ .Receiver }} struct with the
+// specified name has been set using its setter method, even if it was set
+// to its default value.
+func (t *{{ .Receiver }}) WasSet(field string) bool {
+	return t != nil && t.{{ .PresenceField }}[field]
+}
+`)
+
+	// goHasMethodTemplate defines a template for a function that, for a
+	// particular field, returns whether the field is populated.
+	goHasMethodTemplate = mustMakeTemplate("hasField", `
+// Has{{ .Name }} returns true if the field {{ .Name }} of the {{ .Receiver }}
+// struct is populated.
+func (t *{{ .Receiver }}) Has{{ .Name }}() bool {
+	{{- if .AlwaysSet }}
+	return t != nil
+	{{- else if .LenField }}
+	return t != nil && t.{{ .LenField }} != 0
+	{{- else if .IsOrderedList }}
+	return t != nil && t.{{ .Name }}.Len() != 0
+	{{- else if .IsCollection }}
+	return t != nil && len(t.{{ .Name }}) != 0
+	{{- else }}
+	return t != nil && t.{{ .Name }} != {{ .Zero }}
+	{{- end }}
+}
+`)
+
+	// goUnionAccessorTemplate defines a template for a method that returns
+	// the value of a union field as one of the types within the union, and
+	// whether the union holds a value of that type.
+	goUnionAccessorTemplate = mustMakeTemplate("unionAccessor", `
+// {{ .Name }} returns the value of the {{ .FieldName }} field of the {{ .Receiver }}
+// struct as a {{ .Type }}, and true if the union holds a value of that type.
+func (t *{{ .Receiver }}) {{ .Name }}() ({{ .Type }}, bool) {
+	if t != nil {
+		if v, ok := t.{{ .FieldName }}.({{ .AssertType }}); ok {
+			return {{ .Value }}, true
+		}
+	}
+	var zero {{ .Type }}
+	return zero, false
 }
 `)
 
@@ -788,7 +1324,11 @@ func (t *{{ .Receiver }}) PopulateDefaults() {
 	ygot.BuildEmptyTree(t)
 
 	{{- range $Leaf := .Leaves }}
-	{{- if $Leaf.Default }}
+	{{- if and $Leaf.Default $Leaf.LenField }}
+	if t.{{ $Leaf.LenField }} == 0 {
+		t.{{ $Leaf.LenField }} = copy(t.{{ $Leaf.Name }}[:], {{ $Leaf.Default }})
+	}
+	{{- else if $Leaf.Default }}
 	if t.{{ $Leaf.Name }} == {{ if $Leaf.IsPtr -}} nil {{- else }} {{ $Leaf.Zero }} {{- end }} {
 		{{- if $Leaf.IsPtr }}
 		var v {{ $Leaf.Type }} = {{ $Leaf.Default }}
@@ -807,6 +1347,187 @@ func (t *{{ .Receiver }}) PopulateDefaults() {
 		e.PopulateDefaults()
 	}
 	{{- end }}
+	{{- range $listName := .ChildOrderedListNames }}
+	for _, e := range t.{{ $listName }}.Values() {
+		e.PopulateDefaults()
+	}
+	{{- end }}
+	{{- range $listName := .ChildValueListNames }}
+	for k, e := range t.{{ $listName }} {
+		e.PopulateDefaults()
+		t.{{ $listName }}[k] = e
+	}
+	{{- end }}
+}
+`)
+
+	// goEqualMethodTemplate is a template for generating an Equal method for a
+	// GoStruct that compares it to another instance of the same struct.
+	goEqualMethodTemplate = mustMakeTemplate("equal", `
+// Equal reports whether the {{ .Receiver }} t and other contain the same
+// values. Child containers and list members are compared using their Equal
+// methods. Nil and empty lists or leaf-lists are considered to be equal.
+// Union fields, which may hold values of different types, are compared using
+// reflect.DeepEqual.
+func (t *{{ .Receiver }}) Equal(other *{{ .Receiver }}) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	{{- range $f := .Fields }}
+	{{- if $f.IsContainer }}
+	if !t.{{ $f.Name }}.Equal(other.{{ $f.Name }}) {
+		return false
+	}
+	{{- else if $f.IsKeyedList }}
+	if len(t.{{ $f.Name }}) != len(other.{{ $f.Name }}) {
+		return false
+	}
+	for k, v := range t.{{ $f.Name }} {
+		ov, ok := other.{{ $f.Name }}[k]
+		if !ok || !v.Equal({{ if $f.IsValueEntryList }}&{{ end }}ov) {
+			return false
+		}
+	}
+	{{- else if $f.IsOrderedList }}
+	if t.{{ $f.Name }}.Len() != other.{{ $f.Name }}.Len() {
+		return false
+	}
+	ov{{ $f.Name }} := other.{{ $f.Name }}.Values()
+	for i, v := range t.{{ $f.Name }}.Values() {
+		if !v.Equal(ov{{ $f.Name }}[i]) {
+			return false
+		}
+	}
+	{{- else if $f.IsUnkeyedList }}
+	if len(t.{{ $f.Name }}) != len(other.{{ $f.Name }}) {
+		return false
+	}
+	for i, v := range t.{{ $f.Name }} {
+		if !v.Equal(other.{{ $f.Name }}[i]) {
+			return false
+		}
+	}
+	{{- else if $f.IsLeafList }}
+	{{- if $f.LenField }}
+	if t.{{ $f.LenField }} != other.{{ $f.LenField }} {
+		return false
+	}
+	for i, v := range t.{{ $f.Name }}[:t.{{ $f.LenField }}] {
+	{{- else }}
+	if len(t.{{ $f.Name }}) != len(other.{{ $f.Name }}) {
+		return false
+	}
+	for i, v := range t.{{ $f.Name }} {
+	{{- end }}
+		{{- if $f.IsBinary }}
+		if string(v) != string(other.{{ $f.Name }}[i]) {
+		{{- else if $f.IsUnion }}
+		if !reflect.DeepEqual(v, other.{{ $f.Name }}[i]) {
+		{{- else }}
+		if v != other.{{ $f.Name }}[i] {
+		{{- end }}
+			return false
+		}
+	}
+	{{- else if $f.IsPtr }}
+	if (t.{{ $f.Name }} == nil) != (other.{{ $f.Name }} == nil) || (t.{{ $f.Name }} != nil && *t.{{ $f.Name }} != *other.{{ $f.Name }}) {
+		return false
+	}
+	{{- else if $f.IsBinary }}
+	if string(t.{{ $f.Name }}) != string(other.{{ $f.Name }}) {
+		return false
+	}
+	{{- else if $f.IsUnion }}
+	if !reflect.DeepEqual(t.{{ $f.Name }}, other.{{ $f.Name }}) {
+		return false
+	}
+	{{- else }}
+	if t.{{ $f.Name }} != other.{{ $f.Name }} {
+		return false
+	}
+	{{- end }}
+	{{- end }}
+	return true
+}
+`)
+
+	// goResetMethodTemplate takes an input generatedEqualMethod, and
+	// generates a ΛReset method for the GoStruct that clears it in place
+	// such that it can be reused, e.g., from a sync.Pool.
+	goResetMethodTemplate = mustMakeTemplate("reset", `
+// ΛReset clears the {{ .Receiver }} t in place, such that it is equal to a
+// newly created {{ .Receiver }} and can be reused. Child containers and list
+// members are recursively cleared using their ΛReset methods before being
+// removed from t.
+func (t *{{ .Receiver }}) ΛReset() {
+	if t == nil {
+		return
+	}
+	{{- range $f := .Fields }}
+	{{- if $f.IsContainer }}
+	t.{{ $f.Name }}.ΛReset()
+	{{- else if and $f.IsKeyedList (not $f.IsValueEntryList) }}
+	for _, v := range t.{{ $f.Name }} {
+		v.ΛReset()
+	}
+	{{- else if $f.IsOrderedList }}
+	for _, v := range t.{{ $f.Name }}.Values() {
+		v.ΛReset()
+	}
+	{{- else if $f.IsUnkeyedList }}
+	for _, v := range t.{{ $f.Name }} {
+		v.ΛReset()
+	}
+	{{- end }}
+	{{- end }}
+	*t = {{ .Receiver }}{}
+}
+`)
+
+	// goPopulatedChildrenTemplate takes an input
+	// generatedPopulatedChildrenMethod, and generates a ΛPopulatedChildren
+	// method for the GoStruct that returns the schema paths of its
+	// populated children without the use of reflection.
+	goPopulatedChildrenTemplate = mustMakeTemplate("populatedChildren", `
+// ΛPopulatedChildren returns the schema paths, relative to the {{ .Receiver }},
+// of its populated children, sorted lexicographically. Each member of a keyed
+// list is returned with its keys, in the form list[key=value]. It implements
+// the ygot.PopulatedChildrenGoStruct interface.
+func (t *{{ .Receiver }}) ΛPopulatedChildren() []string {
+	if t == nil {
+		return nil
+	}
+	var paths []string
+	{{- range $f := .Fields }}
+	{{- if $f.Keys }}
+	{{- if $f.Has.IsOrderedList }}
+	for _, k := range t.{{ $f.Has.Name }}.Keys() {
+	{{- else if $f.ValueEntries }}
+	for k := range t.{{ $f.Has.Name }} {
+	{{- else }}
+	for k, v := range t.{{ $f.Has.Name }} {
+		if v == nil {
+			continue
+		}
+	{{- end }}
+		{{- range $p := $f.Paths }}
+		paths = append(paths, ygot.ListMemberPath("{{ $p }}", map[string]interface{}{
+			{{- range $k := $f.Keys }}
+			"{{ $k.Name }}": {{ $k.Value }},
+			{{- end }}
+		}))
+		{{- end }}
+	}
+	{{- else if $f.Has.AlwaysSet }}
+	paths = append(paths{{ range $p := $f.Paths }}, "{{ $p }}"{{ end }})
+	{{- else }}
+	if {{ if $f.Has.LenField }}t.{{ $f.Has.LenField }} != 0{{ else if $f.Has.IsOrderedList }}t.{{ $f.Has.Name }}.Len() != 0{{ else if $f.Has.IsCollection }}len(t.{{ $f.Has.Name }}) != 0{{ else }}t.{{ $f.Has.Name }} != {{ $f.Has.Zero }}{{ end }} {
+		paths = append(paths{{ range $p := $f.Paths }}, "{{ $p }}"{{ end }})
+	}
+	{{- end }}
+	{{- end }}
+	sort.Strings(paths)
+	return paths
 }
 `)
 
@@ -906,41 +1627,529 @@ func (t *{{ .Receiver }}) Append{{ .ListName }}(v *{{ .ListType }}) error {
 }
 `)
 
-	// goListMemberRenameTemplate provides a template for a function which renames
-	// an entry within a list. It is used to generate functions for each list within
-	// a generated Go struct.
-	goListMemberRenameTemplate = mustMakeTemplate("renameListEntry", `
-// Rename{{ .ListName }} renames an entry in the list {{ .ListName }} within
-// the {{ .Receiver }} struct. The entry with key oldK is renamed to newK updating
-// the key within the value.
-func (t *{{ .Receiver }}) Rename{{ .ListName }}(
-	{{- if ne .KeyStruct "" -}}
-	oldK, newK {{ .KeyStruct -}}
-  {{- else -}}
-	{{- range $key := .Keys -}}
-	oldK, newK {{ $key.Type -}}
-	{{- end -}}
-	{{- end -}}
-) error {
-	if _, ok := t.{{ .ListName }}[newK]; ok {
-		return fmt.Errorf("key %v already exists in {{ .ListName }}", newK)
+	// goOrderedMapTemplate takes an input generatedGoListMethod struct for
+	// an ordered list and outputs the ordered map type that stores the list,
+	// along with its methods.
+	goOrderedMapTemplate = mustMakeTemplate("orderedMap", `
+// {{ .OrderedMap }} is an ordered map that represents the "ordered-by user"
+// list {{ .ListName }} of {{ .Receiver }}, retaining the order in which
+// members are appended to the list.
+type {{ .OrderedMap }} struct {
+	keys     []{{ .KeyType }}
+	valueMap map[{{ .KeyType }}]*{{ .ListType }}
+}
+
+// IsYANGOrderedList ensures that {{ .OrderedMap }} implements the
+// ygot.GoOrderedList interface.
+func (*{{ .OrderedMap }}) IsYANGOrderedList() {}
+
+// Len returns the number of members of the {{ .OrderedMap }}.
+func (o *{{ .OrderedMap }}) Len() int {
+	if o == nil {
+		return 0
 	}
+	return len(o.keys)
+}
 
-	e, ok := t.{{ .ListName }}[oldK]
-	if !ok {
-		return fmt.Errorf("key %v not found in {{ .ListName }}", oldK)
+// Keys returns a copy of the keys of the {{ .OrderedMap }}, in order.
+func (o *{{ .OrderedMap }}) Keys() []{{ .KeyType }} {
+	if o == nil {
+		return nil
 	}
+	return append([]{{ .KeyType }}{}, o.keys...)
+}
 
-	{{- if ne .KeyStruct "" -}}
-	{{- range $key := .Keys -}}
-	{{- if $key.IsScalarField }}
-	e.{{ $key.Name }} = &newK.{{ $key.Name }}
-	{{- else }}
-	e.{{ $key.Name }} = newK.{{ $key.Name }}
-	{{- end -}}
-	{{- end -}}
-	{{ else -}}
-	{{- $key := index .Keys 0 -}}
+// Values returns the members of the {{ .OrderedMap }}, in order.
+func (o *{{ .OrderedMap }}) Values() []*{{ .ListType }} {
+	if o == nil {
+		return nil
+	}
+	var values []*{{ .ListType }}
+	for _, k := range o.keys {
+		values = append(values, o.valueMap[k])
+	}
+	return values
+}
+
+// ΛKeys returns the keys of the {{ .OrderedMap }}, in order, such that it
+// implements the ygot.GoOrderedList interface.
+func (o *{{ .OrderedMap }}) ΛKeys() []interface{} {
+	if o == nil {
+		return nil
+	}
+	var keys []interface{}
+	for _, k := range o.keys {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// ΛValues returns the members of the {{ .OrderedMap }}, in order, such that
+// it implements the ygot.GoOrderedList interface.
+func (o *{{ .OrderedMap }}) ΛValues() []ygot.GoStruct {
+	if o == nil {
+		return nil
+	}
+	var values []ygot.GoStruct
+	for _, k := range o.keys {
+		values = append(values, o.valueMap[k])
+	}
+	return values
+}
+
+// Get returns the member of the {{ .OrderedMap }} with the specified key, or
+// nil if there is no such member.
+func (o *{{ .OrderedMap }}) Get(key {{ .KeyType }}) *{{ .ListType }} {
+	if o == nil {
+		return nil
+	}
+	return o.valueMap[key]
+}
+
+// Delete removes the member with the specified key from the
+// {{ .OrderedMap }}, retaining the order of the remaining members. It
+// returns whether a member was removed.
+func (o *{{ .OrderedMap }}) Delete(key {{ .KeyType }}) bool {
+	if o == nil {
+		return false
+	}
+	if _, ok := o.valueMap[key]; !ok {
+		return false
+	}
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	delete(o.valueMap, key)
+	return true
+}
+
+// Append appends the supplied {{ .ListType }} struct to the end of the
+// {{ .OrderedMap }}. If the key value(s) specified in the supplied
+// {{ .ListType }} already exist in the list, an error is returned.
+func (o *{{ .OrderedMap }}) Append(v *{{ .ListType }}) error {
+	if v == nil {
+		return fmt.Errorf("nil member for list {{ .ListName }}")
+	}
+	{{ if ne .KeyStruct "" -}}
+	{{- range $key := .Keys }}
+	{{- if $key.IsScalarField -}}
+	if v.{{ $key.Name }} == nil {
+		return fmt.Errorf("invalid nil key for {{ $key.Name }}")
+	}
+
+	{{ end -}}
+	{{- end -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{- if $key.IsScalarField }}
+		{{ $key.Name }}: *v.{{ $key.Name }},
+		{{- else }}
+		{{ $key.Name }}: v.{{ $key.Name }},
+		{{- end -}}
+		{{ end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+		{{- if $key.IsScalarField -}}
+	if v.{{ $key.Name }} == nil {
+		return fmt.Errorf("invalid nil key received for {{ $key.Name }}")
+	}
+
+	key := *v.{{ $key.Name }}
+		{{- else -}}
+	key := v.{{ $key.Name }}
+		{{- end -}}
+	{{- end -}}
+	{{- end }}
+
+	if _, ok := o.valueMap[key]; ok {
+		return fmt.Errorf("duplicate key for list {{ .ListName }} %v", key)
+	}
+	if o.valueMap == nil {
+		o.valueMap = map[{{ .KeyType }}]*{{ .ListType }}{}
+	}
+	o.keys = append(o.keys, key)
+	o.valueMap[key] = v
+	return nil
+}
+`)
+
+	// goNewOrderedListMemberTemplate takes an input generatedGoListMethod
+	// struct for an ordered list and outputs a method, using the specified
+	// receiver, that appends a new member with the keys specified by the
+	// input arguments of the function to the list.
+	goNewOrderedListMemberTemplate = mustMakeTemplate("newOrderedListEntry", `
+// New{{ .ListName }} creates a new entry in the {{ .ListName }} list of the
+// {{ .Receiver}} struct, which is appended to the end of the list. The keys
+// of the list are populated from the input arguments.
+func (t *{{ .Receiver }}) New{{ .ListName }}(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) (*{{ .ListType }}, error){
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.{{ .ListName }} == nil {
+		t.{{ .ListName }} = &{{ .OrderedMap }}{}
+	}
+
+	v := &{{ .ListType }}{
+		{{- range $key := .Keys }}
+		{{- if $key.IsScalarField }}
+		{{ $key.Name }}: &{{ $key.Name }},
+		{{- else }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end -}}
+		{{- end }}
+	}
+	if err := t.{{ .ListName }}.Append(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+`)
+
+	// goOrderedListGetterTemplate defines a template for a function that,
+	// for a particular list key, gets an existing member of an ordered list.
+	goOrderedListGetterTemplate = mustMakeTemplate("getOrderedList", `
+// Get{{ .ListName }} retrieves the value with the specified key from
+// the {{ .ListName }} ordered list of {{ .Receiver }}. If the receiver is nil,
+// or the specified key is not present in the list, nil is returned such that
+// Get* methods may be safely chained.
+func (t *{{ .Receiver }}) Get{{ .ListName }}(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) (*{{ .ListType }}){
+
+	if t == nil {
+		return nil
+	}
+
+	{{ if ne .KeyStruct "" -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+	key := {{ $key.Name }}
+	{{- end -}}
+	{{- end }}
+
+	return t.{{ .ListName }}.Get(key)
+}
+`)
+
+	// goGetOrCreateOrderedListTemplate defines a template for a function
+	// that, for a particular list key, gets an existing member of an ordered
+	// list, or appends it to the list if it doesn't exist.
+	goGetOrCreateOrderedListTemplate = mustMakeTemplate("getOrCreateOrderedList", `
+// GetOrCreate{{ .ListName }} retrieves the value with the specified keys from
+// the receiver {{ .Receiver }}. If the entry does not exist, then it is created
+// and appended to the end of the list. It returns the existing or new list
+// member.
+func (t *{{ .Receiver }}) GetOrCreate{{ .ListName }}(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) (*{{ .ListType }}){
+
+	{{ if ne .KeyStruct "" -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+	key := {{ $key.Name }}
+	{{- end -}}
+	{{- end }}
+
+	if v := t.{{ .ListName }}.Get(key); v != nil {
+		return v
+	}
+	// Panic if we receive an error, since we should have retrieved an existing
+	// list member. This allows chaining of GetOrCreate methods.
+	v, err := t.New{{ .ListName }}(
+		{{- range $i, $key := .Keys -}}
+		{{ $key.Name }}
+		{{- if ne (inc $i) $length -}}, {{ end -}}
+		{{- end -}})
+	if err != nil {
+		panic(fmt.Sprintf("GetOrCreate{{ .ListName }} got unexpected error: %v", err))
+	}
+	return v
+}
+`)
+
+	// goDeleteOrderedListTemplate defines a template for a function that,
+	// for a particular list key, deletes an existing member of an ordered
+	// list.
+	goDeleteOrderedListTemplate = mustMakeTemplate("deleteOrderedList", `
+// Delete{{ .ListName }} deletes the value with the specified keys from
+// the receiver {{ .Receiver }}, retaining the order of the remaining members
+// of the list. If there is no such element, the function is a no-op.
+func (t *{{ .Receiver }}) Delete{{ .ListName }}(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) {
+	{{ if ne .KeyStruct "" -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+	key := {{ $key.Name }}
+	{{- end -}}
+	{{- end }}
+
+	t.{{ .ListName }}.Delete(key)
+}
+`)
+
+	// goOrderedListAppendTemplate defines a template for a function that
+	// takes an input list member struct and appends it to the end of an
+	// ordered list.
+	goOrderedListAppendTemplate = mustMakeTemplate("appendOrderedList", `
+// Append{{ .ListName }} appends the supplied {{ .ListType }} struct to the
+// end of the list {{ .ListName }} of {{ .Receiver }}. If the key value(s)
+// specified in the supplied {{ .ListType }} already exist in the list, an
+// error is returned.
+func (t *{{ .Receiver }}) Append{{ .ListName }}(v *{{ .ListType }}) error {
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.{{ .ListName }} == nil {
+		t.{{ .ListName }} = &{{ .OrderedMap }}{}
+	}
+	return t.{{ .ListName }}.Append(v)
+}
+`)
+
+	// goNewValueEntryListMemberTemplate takes an input generatedGoListMethod
+	// struct for a list whose members are stored by value and outputs a
+	// method, using the specified receiver, that creates a new member of the
+	// list with the keys specified by the input arguments of the function.
+	goNewValueEntryListMemberTemplate = mustMakeTemplate("newValueEntryListEntry", `
+// New{{ .ListName }} creates a new entry in the {{ .ListName }} list of the
+// {{ .Receiver}} struct. The keys of the list are populated from the input
+// arguments. Since the members of the list are stored by value, the new
+// member is returned as a copy, which must be stored in the list again once
+// it has been modified. The copy is shallow: its leaves point to the same
+// values as those of the stored member, such that assigning to a leaf
+// through its pointer, rather than replacing the pointer, also modifies the
+// stored member, including its keys.
+func (t *{{ .Receiver }}) New{{ .ListName }}(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) ({{ .ListType }}, error){
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.{{ .ListName }} == nil {
+		{{- if ne .KeyStruct "" }}
+		t.{{ .ListName }} = make(map[{{ .KeyStruct }}]{{ .ListType }})
+		{{- else }}
+			{{- $listName := .ListName -}}
+			{{- $listType := .ListType -}}
+			{{- range $key := .Keys }}
+		t.{{ $listName }} = make(map[{{ $key.Type }}]{{ $listType }})
+			{{- end }} {{- end }}
+	}
+
+	{{ if ne .KeyStruct "" -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+	key := {{ $key.Name }}
+	{{- end -}}
+	{{- end }}
+
+	// Ensure that this key has not already been used in the
+	// list. Keyed YANG lists do not allow duplicate keys to
+	// be created.
+	if _, ok := t.{{ .ListName }}[key]; ok {
+		return {{ .ListType }}{}, fmt.Errorf("duplicate key %v for list {{ .ListName }}", key)
+	}
+
+	t.{{ .ListName }}[key] = {{ .ListType }}{
+		{{- range $key := .Keys }}
+		{{- if $key.IsScalarField }}
+		{{ $key.Name }}: &{{ $key.Name }},
+		{{- else }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end -}}
+		{{- end }}
+	}
+
+	return t.{{ .ListName }}[key], nil
+}
+`)
+
+	// goValueEntryListGetterTemplate defines a template for a function that,
+	// for a particular list key, gets a copy of an existing member of a list
+	// whose members are stored by value.
+	goValueEntryListGetterTemplate = mustMakeTemplate("getValueEntryList", `
+// Get{{ .ListName }} retrieves a copy of the value with the specified key
+// from the {{ .ListName }} map field of {{ .Receiver }}, along with whether
+// the key is present in the list. If the receiver is nil, or the specified
+// key is not present in the list, the zero value of {{ .ListType }} is
+// returned. The copy is shallow: its leaves point to the same values as
+// those of the stored member, such that assigning to a leaf through its
+// pointer, rather than replacing the pointer, also modifies the stored
+// member, including its keys.
+func (t *{{ .Receiver }}) Get{{ .ListName }}(
+  {{- $length := len .Keys -}}
+  {{- range $i, $key := .Keys -}}
+	{{ $key.Name }} {{ $key.Type -}}
+	{{- if ne (inc $i) $length -}}, {{ end -}}
+  {{- end -}}
+  ) ({{ .ListType }}, bool){
+
+	if t == nil {
+		return {{ .ListType }}{}, false
+	}
+
+	{{ if ne .KeyStruct "" -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{ $key.Name }}: {{ $key.Name }},
+		{{- end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+	key := {{ $key.Name }}
+	{{- end -}}
+	{{- end }}
+
+	lm, ok := t.{{ .ListName }}[key]
+	return lm, ok
+}
+`)
+
+	// goValueEntryListAppendTemplate defines a template for a function that
+	// takes an input list member struct, extracts the key value, and stores
+	// a copy of it in a map whose members are stored by value.
+	goValueEntryListAppendTemplate = mustMakeTemplate("appendValueEntryList", `
+// Append{{ .ListName }} appends a copy of the supplied {{ .ListType }} struct
+// to the list {{ .ListName }} of {{ .Receiver }}. If the key value(s)
+// specified in the supplied {{ .ListType }} already exist in the list, an
+// error is returned.
+func (t *{{ .Receiver }}) Append{{ .ListName }}(v {{ .ListType }}) error {
+	{{ if ne .KeyStruct "" -}}
+	{{- range $key := .Keys }}
+	{{- if $key.IsScalarField -}}
+	if v.{{ $key.Name }} == nil {
+		return fmt.Errorf("invalid nil key for {{ $key.Name }}")
+	}
+
+	{{ end -}}
+	{{- end -}}
+	key := {{ .KeyStruct }}{
+		{{- range $key := .Keys }}
+		{{- if $key.IsScalarField }}
+		{{ $key.Name }}: *v.{{ $key.Name }},
+		{{- else }}
+		{{ $key.Name }}: v.{{ $key.Name }},
+		{{- end -}}
+		{{ end }}
+	}
+	{{- else -}}
+	{{- range $key := .Keys -}}
+		{{- if $key.IsScalarField -}}
+	if v.{{ $key.Name }} == nil {
+		return fmt.Errorf("invalid nil key received for {{ $key.Name }}")
+	}
+
+	key := *v.{{ $key.Name }}
+		{{- else -}}
+	key := v.{{ $key.Name }}
+		{{- end -}}
+	{{- end -}}
+	{{- end }}
+
+	// Initialise the list within the receiver struct if it has not already been
+	// created.
+	if t.{{ .ListName }} == nil {
+		{{- if ne .KeyStruct "" }}
+		t.{{ .ListName }} = make(map[{{ .KeyStruct }}]{{ .ListType }})
+		{{- else }}
+			{{- $listName := .ListName -}}
+			{{- $listType := .ListType -}}
+			{{- range $key := .Keys }}
+		t.{{ $listName }} = make(map[{{ $key.Type }}]{{ $listType }})
+			{{- end }}
+		{{- end }}
+	}
+
+	if _, ok := t.{{ .ListName }}[key]; ok {
+		return fmt.Errorf("duplicate key for list {{ .ListName }} %v", key)
+	}
+
+	t.{{ .ListName }}[key] = v
+	return nil
+}
+`)
+
+	// goListMemberRenameTemplate provides a template for a function which renames
+	// an entry within a list. It is used to generate functions for each list within
+	// a generated Go struct.
+	goListMemberRenameTemplate = mustMakeTemplate("renameListEntry", `
+// Rename{{ .ListName }} renames an entry in the list {{ .ListName }} within
+// the {{ .Receiver }} struct. The entry with key oldK is renamed to newK updating
+// the key within the value.
+func (t *{{ .Receiver }}) Rename{{ .ListName }}(
+	{{- if ne .KeyStruct "" -}}
+	oldK, newK {{ .KeyStruct -}}
+  {{- else -}}
+	{{- range $key := .Keys -}}
+	oldK, newK {{ $key.Type -}}
+	{{- end -}}
+	{{- end -}}
+) error {
+	if _, ok := t.{{ .ListName }}[newK]; ok {
+		return fmt.Errorf("key %v already exists in {{ .ListName }}", newK)
+	}
+
+	e, ok := t.{{ .ListName }}[oldK]
+	if !ok {
+		return fmt.Errorf("key %v not found in {{ .ListName }}", oldK)
+	}
+
+	{{- if ne .KeyStruct "" -}}
+	{{- range $key := .Keys -}}
+	{{- if $key.IsScalarField }}
+	e.{{ $key.Name }} = &newK.{{ $key.Name }}
+	{{- else }}
+	e.{{ $key.Name }} = newK.{{ $key.Name }}
+	{{- end -}}
+	{{- end -}}
+	{{ else -}}
+	{{- $key := index .Keys 0 -}}
 	{{- if $key.IsScalarField }}
 	e.{{ $key.Name }} = &newK
 	{{- else }}
@@ -974,6 +2183,31 @@ func (t *{{ .Receiver }}) ΛListKeyMap() (map[string]interface{}, error) {
 		{{- end }}
 	}, nil
 }
+`)
+
+	// goListKeyConstantsTemplate defines the template for a variable that is
+	// generated for a keyed YANG list. It contains the YANG identifier of each
+	// key leaf in the order in which the keys are declared in the schema.
+	goListKeyConstantsTemplate = mustMakeTemplate("listKeyConstants", `
+// {{ .Name }}ListKeys contains the YANG names of the keys of the {{ .Name }}
+// struct, which is a YANG list entry, in the order in which they are declared
+// in the schema.
+var {{ .Name }}ListKeys = []string{
+	{{- range $key := .ListKeyYANGNames }}
+	"{{ $key }}",
+	{{- end }}
+}
+`)
+
+	// goDefaultConstantsTemplate is a template for generating package-level
+	// variables storing the YANG default values of the leaves and leaf-lists
+	// of a GoStruct.
+	goDefaultConstantsTemplate = mustMakeTemplate("defaultConstants", `
+{{- range $c := . }}
+// {{ $c.Name }} is the default value of the {{ $c.FieldName }} field of
+// {{ $c.Receiver }}, as specified in the YANG schema.
+var {{ $c.Name }} {{ if $c.Type }}{{ $c.Type }} {{ end }}= {{ $c.Value }}
+{{ end -}}
 `)
 
 	// goEnumMapTemplate provides a template to output a constant map which
@@ -998,6 +2232,70 @@ var ΛEnum = map[string]map[int64]ygot.EnumDefinition{
 	},
 	{{- end }}
 }
+`)
+
+	// goEnumRegistryTemplate provides a template to output a map which
+	// lists the values of each enumerated type within the schema in the
+	// order of their int64 values.
+	goEnumRegistryTemplate = mustMakeTemplate("enumRegistry", `
+// ΛEnumRegistry is a map, keyed by the name of the type defined for each enum
+// in the generated Go code, of the definitions of the values of the
+// enumeration, ordered by the constant int64 value of each value. The map is
+// named ΛEnumRegistry in order to avoid clash with any valid YANG identifier.
+var ΛEnumRegistry = map[string][]ygot.EnumDefinition{
+	{{- range $enumName, $enumValues := . }}
+	"E_{{ $enumName }}": {
+		{{- range $value, $valDef := $enumValues }}
+		{Name: "{{ $valDef.Name }}"
+			{{- if ne $valDef.DefiningModule "" -}}
+				, DefiningModule: "{{ $valDef.DefiningModule }}"
+			{{- end -}}
+			{{- if ne $valDef.Value 0 -}}
+				, Value: {{ $valDef.Value }}
+			{{- end -}}
+		},
+		{{- end }}
+	},
+	{{- end }}
+}
+`)
+
+	// goEnumFromStringMapTemplate provides a template to output a map which
+	// can be used to resolve the int64 value of any enumeration within the
+	// schema from the string that is used to represent it.
+	goEnumFromStringMapTemplate = mustMakeTemplate("enumFromStringMap", `
+// ΛEnumFromString is a map, keyed by the name of the type defined for each enum
+// in the generated Go code, which provides a mapping between the string that is
+// used to represent each value of the enumeration in the YANG schema, and its
+// constant int64 value. Values that are defined by a module, such as identities,
+// are also keyed by their name prefixed with that of the defining module, as
+// they are represented in RFC7951 JSON; where more than one module defines a
+// value with the same name, only the prefixed names are included. The map is
+// named ΛEnumFromString in order to avoid clash with any valid YANG identifier.
+var ΛEnumFromString = map[string]map[string]int64{
+	{{- range $enumName, $enumValues := . }}
+	"E_{{ $enumName }}": {
+		{{- range $enumValues }}
+		"{{ .Name }}": {{ .Value }},
+		{{- end }}
+	},
+	{{- end }}
+}
+`)
+
+	// goEnumFromStringTemplate takes an input generatedGoEnumeration struct
+	// and outputs a function that returns the value of the enumerated type
+	// that is represented by a string, using the ΛEnumFromString map.
+	goEnumFromStringTemplate = mustMakeTemplate("enumFromString", `
+// E_{{ .EnumerationPrefix }}FromString returns the value of E_{{ .EnumerationPrefix }}
+// that is represented by s in the YANG schema. Values that are defined by a
+// module may be specified with or without the name of the module as a prefix
+// (e.g., module:NAME). It returns false if s does not represent a value of
+// E_{{ .EnumerationPrefix }}.
+func E_{{ .EnumerationPrefix }}FromString(s string) (E_{{ .EnumerationPrefix }}, bool) {
+	v, ok := ΛEnumFromString["E_{{ .EnumerationPrefix }}"][s]
+	return E_{{ .EnumerationPrefix }}(v), ok
+}
 `)
 
 	// goEnumTypeMapTemplate provides a template to output a constant map which
@@ -1018,6 +2316,71 @@ func initΛEnumTypes(){
 	{{- end }}
   }
 }
+`)
+
+	// goPathTypeMapTemplate provides a template to output a map which can be
+	// used to resolve the schema path of a generated struct into its
+	// reflect.Type.
+	goPathTypeMapTemplate = mustMakeTemplate("pathTypeMap", `
+// SchemaPathToType is a map, keyed by the absolute YANG schema path of each
+// generated struct, of the reflect.Type of the struct. The reflect.Type is
+// of the struct itself, rather than a pointer to it, such that reflect.New
+// can be used to create a new instance of the GoStruct.
+var SchemaPathToType = map[string]reflect.Type{
+{{- range $schemapath, $structName := . }}
+	"{{ $schemapath }}": reflect.TypeOf({{ $structName }}{}),
+{{- end }}
+}
+`)
+
+	// goInterfaceChecksTemplate provides a template to output compile-time
+	// assertions that each generated struct implements the ygot.GoStruct
+	// interface, and optionally the ygot.ValidatedGoStruct interface.
+	goInterfaceChecksTemplate = mustMakeTemplate("interfaceChecks", `
+// Ensure that the generated structs implement the interfaces that are
+// expected by the ygot library.
+{{- range $structName := .StructNames }}
+var _ ygot.GoStruct = (*{{ $structName }})(nil)
+{{- if $.Validated }}
+var _ ygot.ValidatedGoStruct = (*{{ $structName }})(nil)
+{{- end }}
+{{- end }}
+`)
+
+	// goRootHelpersTemplate provides a template to output the root-level
+	// helper functions that are generated in place of the fake root. The
+	// helpers operate over a set of instances of the structs generated for
+	// the entities at the root of the schema tree, which are described by
+	// the generated ΛRootEntities map.
+	goRootHelpersTemplate = mustMakeTemplate("rootHelpers", `
+// ΛRootEntities is a map, keyed by the name of the generated struct, of the
+// entities at the root of the YANG schema tree for which structs are
+// generated.
+var ΛRootEntities = map[string]ygot.RootEntity{
+{{- range $e := . }}
+	"{{ $e.StructName }}": {
+		Path:   "{{ $e.Path }}",
+		Module: "{{ $e.Module }}",
+		{{- if $e.Keys }}
+		Keys:   []string{ {{- range $i, $k := $e.Keys }}{{ if $i }}, {{ end }}"{{ $k }}"{{ end -}} },
+		{{- end }}
+	},
+{{- end }}
+}
+
+// EmitAllJSON serialises the supplied structs, each of which must be an
+// instance of an entity at the root of the YANG schema tree, to a single
+// JSON document in the format specified by opts.
+func EmitAllJSON(opts *ygot.EmitJSONConfig, structs ...ygot.GoStruct) (string, error) {
+	return ygot.EmitRootJSON(ΛRootEntities, opts, structs...)
+}
+
+// MergeAll merges the supplied structs, each of which must be an instance
+// of an entity at the root of the YANG schema tree, such that a single
+// instance of each container, and of each list member, is returned.
+func MergeAll(structs ...ygot.GoStruct) ([]ygot.GoStruct, error) {
+	return ygot.MergeRootStructs(ΛRootEntities, structs)
+}
 `)
 
 	// goEnumTypeMapAccessTemplate provides a template to output an accessor
@@ -1057,6 +2420,30 @@ var (
 {{- end }}
 	}
 )
+`)
+
+	// schemaPerModuleVarTemplate provides a template to output a map, keyed
+	// by YANG module name, of byte slices which each contain the serialised
+	// schema of the entries instantiated by that module.
+	schemaPerModuleVarTemplate = mustMakeTemplate("schemaPerModuleVar", `
+var (
+	// {{ .VarName }} is a map, keyed by YANG module name, of byte slices which
+	// each contain a gzip compressed representation of the part of the YANG
+	// schema from which the Go code was generated that is instantiated by the
+	// module. When uncompressed, the contents of each byte slice is a JSON
+	// document containing the JSON marshalled contents of a goyang yang.Entry
+	// struct, corresponding to the schema root. The documents are merged by
+	// ygot.GzipToSchemaModules to form the schema for the generated structs.
+	{{ .VarName }} = map[string][]byte{
+{{- range $mod := .Modules }}
+		"{{ $mod.Name }}": []byte{
+{{- range $i, $line := $mod.Schema }}
+			{{ $line }}
+{{- end }}
+		},
+{{- end }}
+	}
+)
 `)
 
 	// unionTypeTemplate outputs the type that corresponds to a multi-type union
@@ -1126,6 +2513,40 @@ type {{ .Name }} interface {
 // implements the {{ $intfName }} interface.
 func ({{ $typeName }}) Documentation_for_{{ $intfName }}() {}
 {{ end -}}
+`)
+
+	// unionGenericTemplate defines a template that outputs a type constraint
+	// satisfied by the types of a simple union, and a generic function that
+	// constructs a value of the union.
+	unionGenericTemplate = mustMakeTemplate("unionGeneric", `
+// {{ .Name }}_Constraint is a type constraint that is satisfied by the types
+// that can be stored within the {{ .Name }} union.
+type {{ .Name }}_Constraint interface {
+	{{ range $i, $term := .GenericTerms }}{{ if $i }} | {{ end }}{{ $term.Term }}{{ end }}
+}
+
+// New_{{ .Name }} returns the value v as a {{ .Name }}. Values of types that
+// are not within the union are converted to the type within the union that
+// has the same underlying type.
+func New_{{ .Name }}[T {{ .Name }}_Constraint](v T) {{ .Name }} {
+	if u, ok := interface{}(v).({{ .Name }}); ok {
+		return u
+	}
+	{{- $hasKinds := false }}
+	{{- range $term := .GenericTerms }}{{ if $term.Kind }}{{ $hasKinds = true }}{{ end }}{{ end }}
+	{{- if $hasKinds }}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	{{- range $term := .GenericTerms }}
+	{{- if $term.Kind }}
+	case reflect.{{ $term.Kind }}:
+		return rv.Convert(reflect.TypeOf((*{{ $term.Type }})(nil)).Elem()).Interface().({{ $term.Type }})
+	{{- end }}
+	{{- end }}
+	}
+	{{- end }}
+	panic(fmt.Sprintf("cannot convert %v of type %T to {{ .Name }}", v, v))
+}
 `)
 
 	// unionHelperSimpleTemplate defines a template that defines a helper method
@@ -1212,12 +2633,13 @@ func mustMakeTemplate(name, src string) *template.Template {
 // GoDefaultYgotImportPath, and an unset cfg.GoOptions.YtypesImportPath results in the
 // path for ytypes being set to GoDefaultYtypesImportPath. The supplied rootName is the
 // name of the fake root struct, if it was produced - and is used to output a schema
-// definition in the file header.
+// definition in the file header. If instanceIdentifierUnions is set to true,
+// the type used for instance-identifier subtypes of simple unions is output.
 //
 // The header returned is split into two strings, the common header is a header that
 // should be used for all files within the output package. The one off header should
 // be included in only one file of the package.
-func writeGoHeader(yangFiles, includePaths []string, cfg GeneratorConfig, rootName string, modelData []*gpb.ModelData) (string, string, error) {
+func writeGoHeader(yangFiles, includePaths []string, cfg GeneratorConfig, rootName string, modelData []*gpb.ModelData, instanceIdentifierUnions bool, customTypeImports []string) (string, string, error) {
 	// Determine the running binary's name.
 	if cfg.Caller == "" {
 		cfg.Caller = genutil.CallerName()
@@ -1239,6 +2661,9 @@ func writeGoHeader(yangFiles, includePaths []string, cfg GeneratorConfig, rootNa
 	if cfg.GoOptions.GNMIProtoPath == "" {
 		cfg.GoOptions.GNMIProtoPath = genutil.GoDefaultGNMIImportPath
 	}
+	if cfg.GoOptions.ProtomapImportPath == "" {
+		cfg.GoOptions.ProtomapImportPath = genutil.GoDefaultProtomapImportPath
+	}
 
 	// Build input to the header template which stores parameters which are included
 	// in the header of generated code.
@@ -1249,11 +2674,27 @@ func writeGoHeader(yangFiles, includePaths []string, cfg GeneratorConfig, rootNa
 		CompressEnabled  bool             // CompressEnabled indicates whether compression is enabled.
 		GeneratingBinary string           // GeneratingBinary is the name of the binary generating the code.
 		GenerateSchema   bool             // GenerateSchema stores whether the generator requested that the schema was to be stored with the output code.
+		SchemaPerModule  bool             // SchemaPerModule stores whether the stored schema is split into one document per YANG module.
 		GoOptions        GoOpts           // GoOptions stores additional Go-specific options for the output code, including package paths.
 		BinaryTypeName   string           // BinaryTypeName is the name of the type used for YANG binary types.
+		CustomBinaryType string           // CustomBinaryType is the name of the user-specified type that the binary type is an alias of.
 		EmptyTypeName    string           // EmptyTypeName is the name of the type used for YANG empty types.
 		FakeRootName     string           // FakeRootName is the name of the fake root struct in the YANG type
 		ModelData        []*gpb.ModelData // ModelData contains the gNMI ModelData definition for the input types.
+		// InstanceIdentifierUnions indicates whether instance-identifier subtypes of simple unions are used.
+		InstanceIdentifierUnions bool
+		// UnionInstanceIdentifierTypeName is the name of the type used for instance-identifier subtypes of simple unions.
+		UnionInstanceIdentifierTypeName string
+		// CustomTypeImports is the set of import paths of packages defining custom types used within the generated code.
+		CustomTypeImports []string
+		// Preamble is the file header comment and build constraints that are output before the package documentation.
+		Preamble string
+		// RootGetByPath indicates whether a GetByPath method is generated for the fake root.
+		RootGetByPath bool
+		// RootProtoBridge indicates whether ToProto and FromProto methods are generated for the fake root.
+		RootProtoBridge bool
+		// LeafrefValidation indicates whether the leafrefs of the fake root's data tree are validated by generated code.
+		LeafrefValidation bool
 	}{
 		PackageName:      cfg.PackageName,
 		YANGFiles:        yangFiles,
@@ -1261,15 +2702,30 @@ func writeGoHeader(yangFiles, includePaths []string, cfg GeneratorConfig, rootNa
 		CompressEnabled:  cfg.TransformationOptions.CompressBehaviour.CompressEnabled(),
 		GeneratingBinary: cfg.Caller,
 		GenerateSchema:   cfg.GenerateJSONSchema,
+		SchemaPerModule:  cfg.GenerateJSONSchema && cfg.GenerateJSONSchemaPerModule,
 		GoOptions:        cfg.GoOptions,
 		BinaryTypeName:   ygot.BinaryTypeName,
+		CustomBinaryType: cfg.GoOptions.BinaryTypeName,
 		EmptyTypeName:    ygot.EmptyTypeName,
 		ModelData:        modelData,
+
+		InstanceIdentifierUnions:        instanceIdentifierUnions && cfg.GoOptions.GenerateSimpleUnions,
+		UnionInstanceIdentifierTypeName: ygot.UnionInstanceIdentifierTypeName,
+		CustomTypeImports:               customTypeImports,
+	}
+
+	preamble, err := goFilePreamble(cfg.GoOptions.FileHeaderText, cfg.GoOptions.BuildTags)
+	if err != nil {
+		return "", "", err
 	}
+	s.Preamble = preamble
 
 	s.FakeRootName = "nil"
 	if cfg.TransformationOptions.GenerateFakeRoot && rootName != "" {
 		s.FakeRootName = fmt.Sprintf("&%s{}", rootName)
+		s.RootGetByPath = cfg.GenerateJSONSchema && cfg.GoOptions.GenerateRootGetByPath
+		s.RootProtoBridge = cfg.GenerateJSONSchema && cfg.GoOptions.GenerateProtoBridge
+		s.LeafrefValidation = cfg.GenerateJSONSchema && cfg.GoOptions.GenerateLeafrefValidation
 	}
 
 	var common bytes.Buffer
@@ -1285,6 +2741,124 @@ func writeGoHeader(yangFiles, includePaths []string, cfg GeneratorConfig, rootNa
 	return common.String(), oneoff.String(), nil
 }
 
+// writeGoEnumFile returns the contents of a Go source file, within the
+// package described by cfg, that contains the enumerated type definitions and
+// enum map within genum, along with the supplied enumTypeMap code, which may
+// be empty if the schema is not generated.
+func writeGoEnumFile(cfg GeneratorConfig, genum *enumGeneratedCode, enumTypeMap string) (string, error) {
+	preamble, err := goFilePreamble(cfg.GoOptions.FileHeaderText, cfg.GoOptions.BuildTags)
+	if err != nil {
+		return "", err
+	}
+
+	s := struct {
+		Preamble       string // Preamble is the file header comment and build constraints.
+		PackageName    string // PackageName is the name of the package to be generated.
+		YgotImportPath string // YgotImportPath is the import path of the ygot package.
+		UsesReflect    bool   // UsesReflect indicates whether the enum type map, which uses the reflect package, is output.
+	}{
+		Preamble:       preamble,
+		PackageName:    cfg.PackageName,
+		YgotImportPath: cfg.GoOptions.YgotImportPath,
+		UsesReflect:    enumTypeMap != "",
+	}
+	if s.PackageName == "" {
+		s.PackageName = defaultPackageName
+	}
+	if s.YgotImportPath == "" {
+		s.YgotImportPath = genutil.GoDefaultYgotImportPath
+	}
+
+	var b strings.Builder
+	if err := goEnumFileHeaderTemplate.Execute(&b, s); err != nil {
+		return "", err
+	}
+	for _, snippet := range genum.enums {
+		b.WriteString(snippet)
+		b.WriteString("\n")
+	}
+	b.WriteString(genum.valMap)
+	b.WriteString("\n")
+	if enumTypeMap != "" {
+		b.WriteString(enumTypeMap)
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// buildTagRegexp matches a valid build tag, which may be negated.
+var buildTagRegexp = regexp.MustCompile(`^!?[A-Za-z0-9_.]+$`)
+
+// goFilePreamble returns the code that should be output at the top of each
+// generated Go file, before the package documentation. The supplied
+// headerText is output as a comment, followed by a build constraint that
+// requires each of the supplied buildTags to be satisfied. An error is
+// returned if any of the build tags is invalid.
+func goFilePreamble(headerText string, buildTags []string) (string, error) {
+	var b strings.Builder
+	if headerText != "" {
+		for _, line := range strings.Split(strings.TrimRight(headerText, "\n"), "\n") {
+			if line == "" {
+				b.WriteString("//\n")
+				continue
+			}
+			fmt.Fprintf(&b, "// %s\n", line)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(buildTags) != 0 {
+		for _, t := range buildTags {
+			if !buildTagRegexp.MatchString(t) {
+				return "", fmt.Errorf("invalid build tag %q", t)
+			}
+		}
+		fmt.Fprintf(&b, "//go:build %s\n", strings.Join(buildTags, " && "))
+		fmt.Fprintf(&b, "// +build %s\n\n", strings.Join(buildTags, ","))
+	}
+	return b.String(), nil
+}
+
+// usesUnionSubtype returns true if any field within the IR is a union that
+// has a subtype that is mapped to the Go type t.
+func usesUnionSubtype(ir *IR, t string) bool {
+	for _, dir := range ir.Directories {
+		for _, field := range dir.Fields {
+			if field.LangType == nil || len(field.LangType.UnionTypes) < 2 {
+				continue
+			}
+			if _, ok := field.LangType.UnionTypes[t]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// usedCustomTypeImports returns the import paths, in sorted order, of the
+// packages that define the custom Go types that are used by fields within
+// the IR. customTypeImports maps the package-qualified name of each custom
+// type to the import path of the package that defines it.
+func usedCustomTypeImports(ir *IR, customTypeImports map[string]string) []string {
+	imports := map[string]bool{}
+	for _, dir := range ir.Directories {
+		for _, field := range dir.Fields {
+			if field.LangType == nil {
+				continue
+			}
+			if p, ok := customTypeImports[field.LangType.NativeType]; ok {
+				imports[p] = true
+			}
+		}
+	}
+	var paths []string
+	for p := range imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 // IsScalarField determines which fields should be converted to pointers when
 // outputting structs; this is done to allow checks against nil.
 func IsScalarField(field *NodeDetails) bool {
@@ -1305,6 +2879,44 @@ func IsScalarField(field *NodeDetails) bool {
 	return true
 }
 
+// isPtrField determines whether the field of the parent struct is output as a
+// pointer. Scalar fields are output as pointers, unless nonPtrMandatory is set
+// and the field is a mandatory leaf or a key of the parent list, since such
+// leaves must always be present.
+func isPtrField(field *NodeDetails, parent *ParsedDirectory, nonPtrMandatory bool) bool {
+	if !IsScalarField(field) {
+		return false
+	}
+	if !nonPtrMandatory {
+		return true
+	}
+	for kn := range parent.ListKeys {
+		if parent.Fields[kn] == field {
+			return false
+		}
+	}
+	return !field.YANGDetails.Mandatory
+}
+
+// fixedArrayLen returns the number of elements of the fixed-size array that the
+// leaf-list field is output as, or zero if the field is output as a slice. Where
+// the FixedArraysForBoundedLists option is set, leaf-lists that are not of union
+// or binary type are output as arrays if their min-elements and max-elements are
+// equal, or if their max-elements does not exceed FixedArrayMaxElements.
+func fixedArrayLen(field *NodeDetails, goOpts GoOpts) uint64 {
+	if !goOpts.FixedArraysForBoundedLists || field.Type != LeafListNode {
+		return 0
+	}
+	if len(field.LangType.UnionTypes) > 1 || field.LangType.NativeType == ygot.BinaryTypeName {
+		return 0
+	}
+	d := field.YANGDetails
+	if d.MaxElements != 0 && (d.MinElements == d.MaxElements || d.MaxElements <= goOpts.FixedArrayMaxElements) {
+		return d.MaxElements
+	}
+	return 0
+}
+
 // writeGoStruct generates code snippets for targetStruct. The parameter goStructElements
 // contains other Directory structs for which code is being generated, that may be referenced
 // during the generation of the code corresponding to targetStruct (e.g., to determine a
@@ -1316,6 +2928,8 @@ func IsScalarField(field *NodeDetails) bool {
 //    this schema.
 //  - ignoreShadowSchemaPaths - a bool indicating that when OpenConfig path compression is
 //    enabled, the shadowed paths are ignored while unmarshalling.
+//  - listKeyStructSuffix - the suffix used to name the key structs of multi-keyed lists,
+//    DefaultListKeyStructSuffix is used if it is empty.
 //  - generateJSONSchema - a bool indicating whether the generated code should include the
 //    JSON representation of the YANG schema for this element.
 //  - goOpts - Go specific code generation options as a GoOpts struct.
@@ -1328,7 +2942,7 @@ func IsScalarField(field *NodeDetails) bool {
 //	   of targetStruct (listKeys).
 //	3. Methods with the struct corresponding to targetStruct as a receiver, e.g., for each
 //	   list a NewListMember() method is generated.
-func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*ParsedDirectory, generatedUnions map[string]bool, ignoreShadowSchemaPaths bool, goOpts GoOpts, generateJSONSchema bool) (GoStructCodeSnippet, []error) {
+func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*ParsedDirectory, generatedUnions map[string]bool, ignoreShadowSchemaPaths bool, listKeyStructSuffix string, goOpts GoOpts, generateJSONSchema bool) (GoStructCodeSnippet, []error) {
 	if targetStruct == nil {
 		return GoStructCodeSnippet{}, []error{fmt.Errorf("cannot create code for nil targetStruct")}
 	}
@@ -1342,6 +2956,10 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 		YANGPath:        targetStruct.Path,
 		BelongingModule: targetStruct.BelongingModule,
 	}
+	if goOpts.MarkDeprecated {
+		structDef.Deprecated = deprecationNotice(targetStruct.Status)
+	}
+	structDef.ValidateLeafrefs = generateJSONSchema && goOpts.GenerateLeafrefValidation && targetStruct.IsFakeRoot
 
 	// associatedListKeyStructs is a slice containing the key structures for any multi-keyed
 	// lists that are fields of the struct.
@@ -1358,10 +2976,27 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 	// to generated for the struct.
 	var associatedLeafGetters []*generatedLeafGetter
 
+	// associatedLeafSetters is a slice of structs which define the set of leaf
+	// setters to be generated for the struct.
+	var associatedLeafSetters []*generatedLeafSetter
+
+	// associatedHasMethods is a slice of structs which define the set of
+	// Has methods to be generated for the struct.
+	var associatedHasMethods []*generatedHasMethod
+	var associatedUnionAccessors []*generatedUnionAccessor
+
 	associatedDefaultMethod := generatedDefaultMethod{
 		Receiver: targetStruct.Name,
 	}
 
+	associatedEqualMethod := generatedEqualMethod{
+		Receiver: targetStruct.Name,
+	}
+
+	associatedPopulatedChildren := generatedPopulatedChildrenMethod{
+		Receiver: targetStruct.Name,
+	}
+
 	// definedNameMap defines a map, keyed by YANG identifier to the Go struct field name.
 	definedNameMap := map[string]*yangFieldMap{}
 
@@ -1378,15 +3013,52 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 		annotationPrefix = DefaultAnnotationPrefix
 	}
 
+	annotationType := goOpts.AnnotationFieldType
+	// Set the default annotation field type if it is unset.
+	if goOpts.AnnotationFieldType == "" {
+		annotationType = annotationFieldType
+	}
+
 	if goOpts.AddAnnotationFields {
 		// Add the top-level struct metadata field.
 		structDef.Fields = append(structDef.Fields, &goStructField{
 			Name: fmt.Sprintf("%sMetadata", annotationPrefix),
-			Type: annotationFieldType,
+			Type: annotationType,
 			Tags: `path:"@" ygotAnnotation:"true"`,
 		})
 	}
 
+	if goOpts.EmbedMetadataType != "" {
+		// Add the user-provided metadata field, which is not a schema node.
+		structDef.Fields = append(structDef.Fields, &goStructField{
+			Name: fmt.Sprintf("%sMetadata", DefaultAnnotationPrefix),
+			Type: goOpts.EmbedMetadataType,
+			Tags: `ygotMetadata:"true"`,
+		})
+	}
+
+	var presenceField string
+	if goOpts.TrackFieldPresence {
+		// Add the field recording which leaves have been set by their
+		// setters, which is not a schema node.
+		presenceField = fmt.Sprintf("%sPresent", DefaultAnnotationPrefix)
+		structDef.Fields = append(structDef.Fields, &goStructField{
+			Name: presenceField,
+			Type: "map[string]bool",
+			Tags: `ygotMetadata:"true"`,
+		})
+	}
+
+	if goOpts.GenerateValidationHooks {
+		// Add the field storing user-supplied validation hooks, which is
+		// consulted by ytypes when the struct is validated.
+		structDef.Fields = append(structDef.Fields, &goStructField{
+			Name: ytypes.ValidateFieldHooksFieldName,
+			Type: "map[string]func(interface{}) error",
+			Tags: `ygotMetadata:"true"`,
+		})
+	}
+
 	goFieldNameMap := GoFieldNameMap(targetStruct)
 	// Alphabetically order fields to produce deterministic output.
 	for _, fName := range targetStruct.OrderedFieldNames() {
@@ -1395,6 +3067,18 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 		// the corresponding type. fieldDef is used to store the definition of the field (name
 		// and type) that are calculated.
 		var fieldDef *goStructField
+		// valueLeaf indicates that the field is a scalar leaf that is
+		// output as a value type rather than a pointer.
+		var valueLeaf bool
+		// lenField is the name of the field storing the number of populated
+		// elements of a leaf-list that is output as a fixed-size array.
+		var lenField string
+		// hasMethod describes how to determine whether the field is
+		// populated.
+		var hasMethod *generatedHasMethod
+		// childKeys are the keys of the field where it is a keyed list.
+		var childKeys []*populatedChildKey
+		var valueEntries bool
 
 		field := targetStruct.Fields[fName]
 		fieldName := goFieldNameMap[fName]
@@ -1405,20 +3089,67 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 			// If the field within the struct is a list, then generate code for this list. This
 			// includes extracting any new types that are required to represent the key of a
 			// list that has multiple keys.
-			fieldType, multiKeyListKey, listMethods, listErr := yangListFieldToGoType(field, fieldName, targetStruct, goStructElements)
+			// Keyed lists that are ordered-by user are stored in a
+			// generated ordered map type, which retains the order in
+			// which members are appended.
+			orderedByUser := goOpts.GenerateOrderedListSupport && field.YANGDetails.OrderedByUser
+			fieldType, multiKeyListKey, listMethods, listErr := yangListFieldToGoType(field, fieldName, targetStruct, goStructElements, listKeyStructSuffix, goOpts.NonPointerMandatoryLeaves, goOpts.ValueEntryLists && !orderedByUser)
 			if listErr != nil {
 				errs = append(errs, listErr)
 			}
 
+			ordered := orderedByUser && listMethods != nil
+			valueEntries = listMethods != nil && listMethods.ValueEntries
+			if ordered {
+				if err := setOrderedMap(listMethods, goStructElements); err != nil {
+					errs = append(errs, err)
+				}
+				fieldType = fmt.Sprintf("*%s", listMethods.OrderedMap)
+			}
+
 			fieldDef = &goStructField{
 				Name:       fieldName,
 				Type:       fieldType,
 				IsYANGList: true,
 			}
-			associatedDefaultMethod.ChildListNames = append(associatedDefaultMethod.ChildListNames, fieldName)
+			switch {
+			case ordered:
+				associatedDefaultMethod.ChildOrderedListNames = append(associatedDefaultMethod.ChildOrderedListNames, fieldName)
+			case valueEntries:
+				associatedDefaultMethod.ChildValueListNames = append(associatedDefaultMethod.ChildValueListNames, fieldName)
+			default:
+				associatedDefaultMethod.ChildListNames = append(associatedDefaultMethod.ChildListNames, fieldName)
+			}
+			associatedEqualMethod.Fields = append(associatedEqualMethod.Fields, &equalMethodField{
+				Name:             fieldName,
+				IsKeyedList:      strings.HasPrefix(fieldType, "map["),
+				IsUnkeyedList:    strings.HasPrefix(fieldType, "[]"),
+				IsOrderedList:    ordered,
+				IsValueEntryList: valueEntries,
+			})
+
+			hasMethod = &generatedHasMethod{
+				Name:          fieldName,
+				IsCollection:  !ordered,
+				IsOrderedList: ordered,
+				Receiver:      targetStruct.Name,
+			}
+			associatedHasMethods = append(associatedHasMethods, hasMethod)
 
 			if listMethods != nil {
 				associatedListMethods = append(associatedListMethods, listMethods)
+
+				listElem := goStructElements[field.YANGDetails.Path]
+				for i, k := range listMethods.Keys {
+					v := "k"
+					if listMethods.KeyStruct != "" {
+						v = fmt.Sprintf("k.%s", k.Name)
+					}
+					childKeys = append(childKeys, &populatedChildKey{
+						Name:  listElem.ListKeyYANGNames[i],
+						Value: v,
+					})
+				}
 			}
 
 			if multiKeyListKey != nil {
@@ -1443,6 +3174,16 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 				IsYANGContainer: true,
 			}
 			associatedDefaultMethod.ChildContainerNames = append(associatedDefaultMethod.ChildContainerNames, fieldName)
+			associatedEqualMethod.Fields = append(associatedEqualMethod.Fields, &equalMethodField{
+				Name:        fieldName,
+				IsContainer: true,
+			})
+			hasMethod = &generatedHasMethod{
+				Name:     fieldName,
+				Zero:     "nil",
+				Receiver: targetStruct.Name,
+			}
+			associatedHasMethods = append(associatedHasMethods, hasMethod)
 		case LeafNode, LeafListNode:
 			// Only if this union has more than one subtype do we generate the union;
 			// otherwise, we use that subtype directly.
@@ -1468,12 +3209,7 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 
 				var genTypes []string
 				for t := range field.LangType.UnionTypes {
-					tn := yang.CamelCase(t)
-					// Ensure that we sanitise the type name to be used in the
-					// output struct.
-					if t == "interface{}" {
-						tn = "Interface"
-					}
+					tn := unionSubtypeName(t)
 					if goOpts.GenerateSimpleUnions {
 						if simpleName, ok := ygot.SimpleUnionBuiltinGoTypes[t]; ok {
 							tn = simpleName
@@ -1499,11 +3235,18 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 				}
 				// Create the subtype documentation string.
 				intf.SubtypeDocumentation = strings.Join(genTypes, ", ")
+				if goOpts.GenericUnions {
+					intf.GenericTerms = genericUnionTerms(intf.Types)
+				}
 				genUnions = append(genUnions, intf)
 			}
 
 			fType := field.LangType.NativeType
 			zeroValue := field.LangType.ZeroValue
+			// structFieldType is the type of the field within the struct,
+			// which differs from fType only where a leaf-list is output as
+			// a fixed-size array.
+			structFieldType := fType
 
 			if field.Type == LeafListNode {
 				// We represent a leaf-list in the output
@@ -1512,9 +3255,21 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 				// Slices have a nil zero value rather than the value of their
 				// underlying type.
 				zeroValue = "nil"
+				structFieldType = fType
+
+				if n := fixedArrayLen(field, goOpts); n != 0 {
+					lenField = fmt.Sprintf("%sLen", fieldName)
+					for _, fn := range goFieldNameMap {
+						if fn == lenField {
+							errs = append(errs, fmt.Errorf("%s: length field %s of fixed-size array field %s collides with an existing field", field.YANGDetails.Path, lenField, fieldName))
+						}
+					}
+					structFieldType = fmt.Sprintf("[%d]%s", n, field.LangType.NativeType)
+				}
 			}
 
-			scalarField := IsScalarField(field)
+			scalarField := isPtrField(field, targetStruct, goOpts.NonPointerMandatoryLeaves)
+			valueLeaf = IsScalarField(field) && !scalarField
 
 			definedNameMap[fName].IsPtr = scalarField
 
@@ -1526,13 +3281,56 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 				Type:     fType,
 				Zero:     zeroValue,
 				IsPtr:    scalarField,
+				LenField: lenField,
 				Receiver: targetStruct.Name,
 				Default:  field.LangType.DefaultValue,
 			})
 
+			hasMethod = &generatedHasMethod{
+				Name:         fieldName,
+				Zero:         zeroValue,
+				IsCollection: field.Type == LeafListNode,
+				AlwaysSet:    valueLeaf,
+				LenField:     lenField,
+				Receiver:     targetStruct.Name,
+			}
+			if scalarField {
+				hasMethod.Zero = "nil"
+			}
+			associatedHasMethods = append(associatedHasMethods, hasMethod)
+
+			if field.Type == LeafNode && len(field.LangType.UnionTypes) > 1 {
+				associatedUnionAccessors = append(associatedUnionAccessors, unionAccessors(targetStruct.Name, fieldName, field.LangType.NativeType, field.LangType.UnionTypes, goOpts.GenerateSimpleUnions)...)
+			}
+
+			if field.Type == LeafNode {
+				setter := &generatedLeafSetter{
+					Name:          fieldName,
+					YANGName:      field.YANGDetails.Name,
+					Type:          fType,
+					IsPtr:         scalarField,
+					Receiver:      targetStruct.Name,
+					PresenceField: presenceField,
+				}
+				if t := field.YANGDetails.Type; t != nil && len(t.Range) != 0 {
+					setter.Range = t.Range.String()
+					setter.RangeConditions = goRangeConditions(t.Range, "v", strings.HasPrefix(fType, "uint"))
+				}
+				associatedLeafSetters = append(associatedLeafSetters, setter)
+			}
+
+			associatedEqualMethod.Fields = append(associatedEqualMethod.Fields, &equalMethodField{
+				Name:       fieldName,
+				IsLeafList: field.Type == LeafListNode,
+				IsPtr:      scalarField,
+				IsBinary:   field.LangType.NativeType == ygot.BinaryTypeName,
+				IsUnion:    len(field.LangType.UnionTypes) > 1,
+				LenField:   lenField,
+			})
+
 			fieldDef = &goStructField{
 				Name:          fieldName,
-				Type:          fType,
+				Type:          structFieldType,
 				IsScalarField: scalarField,
 			}
 		default:
@@ -1597,17 +3395,68 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 			}
 		}
 
+		if field.YANGDetails.ChoiceMembership != "" {
+			tagBuf.WriteString(fmt.Sprintf(` choice:"%s"`, field.YANGDetails.ChoiceMembership))
+		}
+
+		if valueLeaf {
+			tagBuf.WriteString(` ygotValueLeaf:"true"`)
+		}
+
+		if lenField != "" {
+			tagBuf.WriteString(fmt.Sprintf(` ygotArrayLen:"%s"`, lenField))
+		}
+
+		if goOpts.AddJSONTags {
+			tagBuf.WriteString(fmt.Sprintf(` json:"%s,omitempty"`, rfc7951FieldName(field.MappedPaths, field.MappedPathModules, targetStruct.BelongingModule)))
+		}
+
 		fieldDef.Tags = tagBuf.String()
 
+		if goOpts.IncludeConstraintComments && (field.Type == LeafNode || field.Type == LeafListNode) {
+			fieldDef.Comments = constraintComments(field.YANGDetails)
+		}
+
+		if d := deprecationNotice(field.YANGDetails.Status); goOpts.MarkDeprecated && d != "" {
+			if len(fieldDef.Comments) != 0 {
+				fieldDef.Comments = append(fieldDef.Comments, "")
+			}
+			fieldDef.Comments = append(fieldDef.Comments, fmt.Sprintf("Deprecated: %s", d))
+		}
+
 		// Append the generated field definition to the set of fields of the struct.
 		structDef.Fields = append(structDef.Fields, fieldDef)
 
+		childField := &populatedChildField{
+			Has:          hasMethod,
+			Keys:         childKeys,
+			ValueEntries: valueEntries,
+		}
+		for _, p := range field.MappedPaths {
+			childField.Paths = append(childField.Paths, util.SlicePathToString(p))
+		}
+		associatedPopulatedChildren.Fields = append(associatedPopulatedChildren.Fields, childField)
+
+		if lenField != "" {
+			// Append the field storing the number of populated elements of
+			// the fixed-size array, which does not correspond to a schema node.
+			lenTags := fmt.Sprintf(`ygotArrayLenOf:"%s"`, fieldName)
+			if goOpts.AddJSONTags {
+				lenTags += ` json:"-"`
+			}
+			structDef.Fields = append(structDef.Fields, &goStructField{
+				Name: lenField,
+				Type: "int",
+				Tags: lenTags,
+			})
+		}
+
 		if goOpts.AddAnnotationFields {
 			// Append the definition of the field annotation to the set of fields in the
 			// struct.
 			structDef.Fields = append(structDef.Fields, &goStructField{
 				Name: fmt.Sprintf("%s%s", annotationPrefix, fieldDef.Name),
-				Type: annotationFieldType,
+				Type: annotationType,
 				Tags: metadataTagBuf.String(),
 			})
 		}
@@ -1627,12 +3476,26 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 		if err := goListKeyTemplate.Execute(&listkeyBuf, listKey); err != nil {
 			errs = append(errs, err)
 		}
+		if goOpts.GenerateKeyStructHelpers {
+			if err := goListKeyHelpersTemplate.Execute(&listkeyBuf, listKey); err != nil {
+				errs = append(errs, err)
+			}
+		}
 	}
 
 	// methodBuf is used to store the code generated for methods that have the
 	// target entity's generated struct as a receiver.
 	var methodBuf bytes.Buffer
 	for _, method := range associatedListMethods {
+		if method.OrderedMap != "" {
+			errs = append(errs, generateOrderedListMethods(&listkeyBuf, &methodBuf, method, goOpts)...)
+			continue
+		}
+		if method.ValueEntries {
+			errs = append(errs, generateValueEntryListMethods(&methodBuf, method, goOpts)...)
+			continue
+		}
+
 		if err := goNewListMemberTemplate.Execute(&methodBuf, method); err != nil {
 			errs = append(errs, err)
 		}
@@ -1665,23 +3528,68 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 		}
 	}
 
-	if goOpts.GenerateGetters {
-		if err := generateGetOrCreateStruct(&methodBuf, structDef); err != nil {
-			errs = append(errs, err)
-		}
-		if err := generateContainerGetters(&methodBuf, structDef); err != nil {
+	if goOpts.GenerateGetters {
+		if err := generateGetOrCreateStruct(&methodBuf, structDef); err != nil {
+			errs = append(errs, err)
+		}
+		if err := generateContainerGetters(&methodBuf, structDef); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if goOpts.GenerateLeafGetters {
+		if err := generateLeafGetters(&methodBuf, associatedLeafGetters); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if goOpts.GenerateSetters {
+		if err := generateLeafSetters(&methodBuf, associatedLeafSetters); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if goOpts.TrackFieldPresence {
+		if err := goWasSetTemplate.Execute(&methodBuf, &generatedWasSetMethod{
+			Receiver:      targetStruct.Name,
+			PresenceField: presenceField,
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if goOpts.GenerateHasMethods {
+		for _, m := range associatedHasMethods {
+			if err := goHasMethodTemplate.Execute(&methodBuf, m); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if goOpts.GenerateUnionAccessors {
+		for _, a := range associatedUnionAccessors {
+			if err := goUnionAccessorTemplate.Execute(&methodBuf, a); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if goOpts.GeneratePopulateDefault {
+		associatedDefaultMethod.Leaves = associatedLeafGetters
+		if err := goDefaultMethodTemplate.Execute(&methodBuf, associatedDefaultMethod); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if goOpts.GenerateEqualMethod {
+		if err := goEqualMethodTemplate.Execute(&methodBuf, associatedEqualMethod); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
-	if goOpts.GenerateLeafGetters {
-		if err := generateLeafGetters(&methodBuf, associatedLeafGetters); err != nil {
+	if goOpts.GeneratePoolReset {
+		if err := goResetMethodTemplate.Execute(&methodBuf, associatedEqualMethod); err != nil {
 			errs = append(errs, err)
 		}
 	}
-	if goOpts.GeneratePopulateDefault {
-		associatedDefaultMethod.Leaves = associatedLeafGetters
-		if err := goDefaultMethodTemplate.Execute(&methodBuf, associatedDefaultMethod); err != nil {
+
+	if goOpts.GenerateChildPaths {
+		if err := goPopulatedChildrenTemplate.Execute(&methodBuf, associatedPopulatedChildren); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -1690,15 +3598,53 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 		errs = append(errs, err)
 	}
 
+	if goOpts.GenerateDefaultConstants {
+		var consts []*generatedDefaultConstant
+		for _, l := range associatedLeafGetters {
+			if l.Default == nil {
+				continue
+			}
+			c := &generatedDefaultConstant{
+				Name:      fmt.Sprintf("%s_%sDefault", targetStruct.Name, l.Name),
+				FieldName: l.Name,
+				Receiver:  targetStruct.Name,
+				Type:      l.Type,
+				Value:     *l.Default,
+			}
+			if strings.HasPrefix(l.Type, "[]") {
+				c.Type = ""
+			}
+			consts = append(consts, c)
+		}
+		if err := goDefaultConstantsTemplate.Execute(&methodBuf, consts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if goOpts.GenerateListKeyConstants && len(targetStruct.ListKeyYANGNames) != 0 {
+		if err := goListKeyConstantsTemplate.Execute(&methodBuf, targetStruct); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	// interfaceBuf is used to store the code generated for interfaces that
 	// are used for multi-type unions within the struct.
 	var interfaceBuf bytes.Buffer
 	for _, intf := range genUnions {
+		if _, ok := intf.Types[ygot.BinaryTypeName]; ok && goOpts.GenerateSimpleUnions && goOpts.BinaryTypeName != "" {
+			errs = append(errs, fmt.Errorf("union %s has a binary subtype, which is not supported with the custom binary type %s", intf.Name, goOpts.BinaryTypeName))
+			continue
+		}
 		if goOpts.GenerateSimpleUnions {
 			if _, ok := generatedUnions[intf.Name]; !ok {
 				if err := unionTypeSimpleTemplate.Execute(&interfaceBuf, intf); err != nil {
 					errs = append(errs, err)
 				}
+				if goOpts.GenericUnions {
+					if err := unionGenericTemplate.Execute(&interfaceBuf, intf); err != nil {
+						errs = append(errs, err)
+					}
+				}
 				generatedUnions[intf.Name] = true
 			}
 			if err := unionHelperSimpleTemplate.Execute(&interfaceBuf, intf); err != nil {
@@ -1722,14 +3668,56 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 			errs = append(errs, err)
 		}
 
+		if goOpts.ValidateWithContext {
+			if err := goStructContextValidatorTemplate.Execute(&methodBuf, structDef); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if goOpts.GenerateSubtreeValidate {
+			if err := goStructSubtreeValidatorTemplate.Execute(&methodBuf, structDef); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if goOpts.GenerateCBORMethods && targetStruct.IsFakeRoot {
+			if err := goCBORMethodsTemplate.Execute(&methodBuf, structDef); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if goOpts.GenerateRootGetByPath && targetStruct.IsFakeRoot {
+			if err := goRootGetByPathTemplate.Execute(&methodBuf, structDef); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if goOpts.GenerateProtoBridge && targetStruct.IsFakeRoot {
+			if err := goRootProtoBridgeTemplate.Execute(&methodBuf, structDef); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if structDef.ValidateLeafrefs {
+			if err := generateLeafrefValidation(&methodBuf, targetStruct, goStructElements, goOpts); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
 		if err := generateEnumTypeMapAccessor(&methodBuf, structDef); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
-	if err := generateBelongingModuleFunction(&methodBuf, structDef); err != nil {
+	compat, err := runtimeCompatFor(goOpts.RuntimeCompatVersion)
+	if err != nil {
 		errs = append(errs, err)
 	}
+	if compat.belongingModule {
+		if err := generateBelongingModuleFunction(&methodBuf, structDef); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	return GoStructCodeSnippet{
 		StructName: structDef.StructName,
@@ -1740,6 +3728,134 @@ func writeGoStruct(targetStruct *ParsedDirectory, goStructElements map[string]*P
 	}, errs
 }
 
+// setOrderedMap populates the name of the ordered map type that stores the
+// ordered list described by method, along with the type of the list's key. It
+// returns an error if the name of the ordered map type conflicts with that of
+// a generated struct within goStructElements.
+func setOrderedMap(method *generatedGoListMethod, goStructElements map[string]*ParsedDirectory) error {
+	method.OrderedMap = fmt.Sprintf("%s_OrderedMap", method.ListType)
+	for _, d := range goStructElements {
+		if d.Name == method.OrderedMap {
+			return fmt.Errorf("ordered map name %s for list %s conflicts with a generated struct", method.OrderedMap, method.ListName)
+		}
+	}
+	method.KeyType = method.KeyStruct
+	if method.KeyType == "" {
+		method.KeyType = method.Keys[0].Type
+	}
+	return nil
+}
+
+// generateOrderedListMethods outputs the ordered map type that stores the
+// ordered list described by method to typeBuf, and the methods that have the
+// list's parent struct as a receiver to methodBuf, according to goOpts. Since
+// the order of the list is retained when members are appended, no method to
+// rename a member of the list is generated.
+func generateOrderedListMethods(typeBuf, methodBuf io.Writer, method *generatedGoListMethod, goOpts GoOpts) []error {
+	var errs []error
+	if err := goOrderedMapTemplate.Execute(typeBuf, method); err != nil {
+		errs = append(errs, err)
+	}
+	if err := goNewOrderedListMemberTemplate.Execute(methodBuf, method); err != nil {
+		errs = append(errs, err)
+	}
+	if goOpts.GenerateGetters {
+		if err := goGetOrCreateOrderedListTemplate.Execute(methodBuf, method); err != nil {
+			errs = append(errs, err)
+		}
+		if err := goOrderedListGetterTemplate.Execute(methodBuf, method); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if goOpts.GenerateDeleteMethod {
+		if err := goDeleteOrderedListTemplate.Execute(methodBuf, method); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if goOpts.GenerateAppendMethod {
+		if err := goOrderedListAppendTemplate.Execute(methodBuf, method); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// generateValueEntryListMethods writes the methods that are generated for a
+// list whose members are stored by value to methodBuf. Since pointers to the
+// members of such a list cannot be returned, GetOrCreate and Rename methods
+// are not generated.
+func generateValueEntryListMethods(methodBuf io.Writer, method *generatedGoListMethod, goOpts GoOpts) []error {
+	var errs []error
+	if err := goNewValueEntryListMemberTemplate.Execute(methodBuf, method); err != nil {
+		errs = append(errs, err)
+	}
+	if goOpts.GenerateGetters {
+		if err := goValueEntryListGetterTemplate.Execute(methodBuf, method); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if goOpts.GenerateDeleteMethod {
+		if err := goDeleteListTemplate.Execute(methodBuf, method); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if goOpts.GenerateAppendMethod {
+		if err := goValueEntryListAppendTemplate.Execute(methodBuf, method); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// unionSubtypeName returns the name that is used for the Go type t within
+// a multi-type union in the generated code, sanitised such that it can be
+// used within an identifier.
+func unionSubtypeName(t string) string {
+	switch t {
+	case "interface{}":
+		return "Interface"
+	case ygot.InstanceIdentifierTypeName:
+		return "InstanceIdentifier"
+	}
+	return yang.CamelCase(t)
+}
+
+// unionAccessors returns the accessor methods that should be generated for
+// the union field with the name fieldName, of the union type unionName, within
+// the struct receiver, for each of the Go types in unionTypes. If
+// simpleUnions is set, the union is assumed to have been generated using
+// simple union types, otherwise a wrapper struct is assumed to exist for
+// each type within the union.
+func unionAccessors(receiver, fieldName, unionName string, unionTypes map[string]int, simpleUnions bool) []*generatedUnionAccessor {
+	var accessors []*generatedUnionAccessor
+	for t := range unionTypes {
+		tn := unionSubtypeName(t)
+		a := &generatedUnionAccessor{
+			Name:       fieldName + tn,
+			FieldName:  fieldName,
+			Type:       t,
+			AssertType: fmt.Sprintf("*%s_%s", unionName, tn),
+			Value:      fmt.Sprintf("v.%s", tn),
+			Receiver:   receiver,
+		}
+		if simpleUnions {
+			simpleName, ok := ygot.SimpleUnionBuiltinGoTypes[t]
+			switch {
+			case t == "interface{}":
+				a.AssertType, a.Value = simpleName, "v.Value"
+			case ok:
+				a.AssertType, a.Value = simpleName, fmt.Sprintf("%s(v)", t)
+			default:
+				// Enumerated types are stored directly within the union.
+				a.AssertType, a.Value = t, "v"
+			}
+		}
+		accessors = append(accessors, a)
+	}
+	sort.Slice(accessors, func(i, j int) bool { return accessors[i].Name < accessors[j].Name })
+	return accessors
+}
+
 // mappedPathTag returns a generated Go Struct tag containing the stringified
 // input paths separated by '|'. If prefix is supplied, it is prepended to the
 // last element in each path.
@@ -1760,6 +3876,30 @@ func mappedPathTag(paths [][]string, prefix string) string {
 	return buf.String()
 }
 
+// rfc7951FieldName returns the name of a field of a GoStruct as it is output
+// in RFC7951 JSON, based on the shortest of its mapped paths. The name is
+// qualified with the name of the module that defines the field if it differs
+// from parentModule, the module to which the GoStruct belongs.
+func rfc7951FieldName(paths, modules [][]string, parentModule string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	var idx int
+	for i, p := range paths {
+		if len(p) < len(paths[idx]) {
+			idx = i
+		}
+	}
+	p := paths[idx]
+	name := p[len(p)-1]
+	if idx < len(modules) && len(modules[idx]) > 0 {
+		if mod := modules[idx][len(modules[idx])-1]; mod != parentModule {
+			return fmt.Sprintf("%s:%s", mod, name)
+		}
+	}
+	return name
+}
+
 // generateValidator generates a validation function string for structDef and
 // appends it to the supplied buffer.
 // Assuming structDef represents the following struct:
@@ -1869,6 +4009,137 @@ func generateLeafGetters(buf *bytes.Buffer, leaves []*generatedLeafGetter) error
 	return errs.Err()
 }
 
+// generateLeafSetters generates SetXXX methods for the leaf fields described by
+// the supplied slice of generatedLeafSetter structs.
+func generateLeafSetters(buf *bytes.Buffer, leaves []*generatedLeafSetter) error {
+	var errs errlist.List
+	for _, l := range leaves {
+		if err := goLeafSetterTemplate.Execute(buf, l); err != nil {
+			errs.Add(err)
+		}
+	}
+	return errs.Err()
+}
+
+// genericUnionTerms returns the terms of the type constraint for a union
+// whose subtypes are described by the types map, which is keyed by the name
+// of the type generated for the subtype, with values of the Go type that the
+// subtype was mapped to. An approximation element is used for a subtype
+// where no other subtype has the same underlying type, such that the
+// constraint remains valid - otherwise the name of the generated type is
+// used. The terms are returned in the order of the names of the types.
+func genericUnionTerms(types map[string]string) []*genericUnionTerm {
+	underlying := map[string]string{}
+	count := map[string]int{}
+	for tn, t := range types {
+		var u string
+		switch {
+		case t == ygot.BinaryTypeName:
+			u = "[]byte"
+		case t == ygot.EmptyTypeName:
+			u = "bool"
+		case t == ygot.InstanceIdentifierTypeName:
+			u = "string"
+		case t == "interface{}":
+		case ygot.SimpleUnionBuiltinGoTypes[t] != "":
+			u = t
+		case strings.HasPrefix(tn, goEnumPrefix):
+			u = "int64"
+		}
+		underlying[tn] = u
+		count[u]++
+	}
+
+	var names []string
+	for tn := range types {
+		names = append(names, tn)
+	}
+	sort.Strings(names)
+
+	var terms []*genericUnionTerm
+	for _, tn := range names {
+		u := underlying[tn]
+		term := &genericUnionTerm{Term: tn, Type: tn}
+		// Enumerated types are not approximated, since integer values
+		// cannot be assumed to be values of the enumeration.
+		if u != "" && count[u] == 1 && !strings.HasPrefix(tn, goEnumPrefix) {
+			term.Term = "~" + u
+			term.Kind = strings.ToUpper(u[:1]) + u[1:]
+			if u == "[]byte" {
+				term.Kind = "Slice"
+			}
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// constraintComments returns the lines of a comment describing the must,
+// when, range, length and pattern statements that apply to the node with the
+// supplied details. Whitespace within XPath expressions is collapsed such
+// that each statement is output on a single line. It returns nil if no such
+// statements apply to the node.
+func constraintComments(d YANGNodeDetails) []string {
+	var lines []string
+	for _, m := range d.Must {
+		lines = append(lines, fmt.Sprintf("  must: %s", strings.Join(strings.Fields(m), " ")))
+	}
+	if d.When != "" {
+		lines = append(lines, fmt.Sprintf("  when: %s", strings.Join(strings.Fields(d.When), " ")))
+	}
+	if t := d.Type; t != nil {
+		if len(t.Range) != 0 {
+			lines = append(lines, fmt.Sprintf("  range: %s", t.Range))
+		}
+		if len(t.Length) != 0 {
+			lines = append(lines, fmt.Sprintf("  length: %s", t.Length))
+		}
+		for _, p := range t.Pattern {
+			lines = append(lines, fmt.Sprintf("  pattern: %s", p))
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return append([]string{"YANG constraints:"}, lines...)
+}
+
+// deprecationNotice returns the text of the deprecation notice that is output
+// for a node with the supplied YANG status, or the empty string if the node is
+// not deprecated or obsolete.
+func deprecationNotice(status string) string {
+	switch status {
+	case "deprecated", "obsolete":
+		return fmt.Sprintf("This node is marked as %s in the YANG schema.", status)
+	}
+	return ""
+}
+
+// goRangeConditions returns a Go boolean expression for each of the ranges
+// within r, which is true if the value of the variable named v is within the
+// range. If unsigned is true, the type of v is unsigned, such that a lower
+// bound of zero is not checked.
+func goRangeConditions(r yang.YangRange, v string, unsigned bool) []string {
+	var conds []string
+	for _, yr := range r {
+		if yr.Min.Equal(yr.Max) {
+			conds = append(conds, fmt.Sprintf("%s == %s", v, yr.Min))
+			continue
+		}
+		var bounds []string
+		if !unsigned || yr.Min.Value != 0 {
+			bounds = append(bounds, fmt.Sprintf("%s >= %s", v, yr.Min))
+		}
+		bounds = append(bounds, fmt.Sprintf("%s <= %s", v, yr.Max))
+		c := strings.Join(bounds, " && ")
+		if len(r) > 1 && len(bounds) > 1 {
+			c = fmt.Sprintf("(%s)", c)
+		}
+		conds = append(conds, c)
+	}
+	return conds
+}
+
 // generateGetOrCreateList generates a getter function similar to that created
 // by the generateGetOrCreateStruct function for maps within the generated Go
 // code (which represent YANG lists). It handles both simple and composite key
@@ -1993,8 +4264,13 @@ func generateGetListKey(buf *bytes.Buffer, s *ParsedDirectory, nameMap map[strin
 //	  leaves that make up the key. The type of the list is then a map, keyed by the new struct
 //	  type.
 // In the case that the list has multiple keys, the type generated as the key of the list is returned.
+// Its name is formed by appending keyStructSuffix, or DefaultListKeyStructSuffix if it is empty,
+// to the name of the list's struct.
 // If errors are encountered during the type generation for the list, the error is returned.
-func yangListFieldToGoType(listField *NodeDetails, listFieldName string, parent *ParsedDirectory, goStructElements map[string]*ParsedDirectory) (string, *generatedGoMultiKeyListStruct, *generatedGoListMethod, error) {
+// If nonPtrMandatory is set, the key fields of the list member struct are value types rather than
+// pointers. If valueEntries is set, and the list is keyed and its members contain only leaves, the
+// members of the list are stored by value rather than as pointers within the map.
+func yangListFieldToGoType(listField *NodeDetails, listFieldName string, parent *ParsedDirectory, goStructElements map[string]*ParsedDirectory, keyStructSuffix string, nonPtrMandatory, valueEntries bool) (string, *generatedGoMultiKeyListStruct, *generatedGoListMethod, error) {
 	// The list itself, since it is a container, has a struct associated with it. Retrieve
 	// this from the set of Directory structs for which code (a Go struct) will be
 	//  generated such that additional details can be used in the code generation.
@@ -2014,6 +4290,12 @@ func yangListFieldToGoType(listField *NodeDetails, listFieldName string, parent
 	var listKeys []goStructField
 	var listKeyStructName string
 
+	valueEntries = valueEntries && hasOnlyLeaves(listElem)
+	elemPrefix := "*"
+	if valueEntries {
+		elemPrefix = ""
+	}
+
 	shortestPath := func(ss [][]string) [][]string {
 		var shortest []string
 		for _, s := range ss {
@@ -2041,7 +4323,7 @@ func yangListFieldToGoType(listField *NodeDetails, listFieldName string, parent
 			// The shortest mapped path for a list key must be the path to the key.
 			Tags: mappedPathTag(shortestPath(keyType.MappedPaths), ""),
 		}
-		keyField.IsScalarField = IsScalarField(keyType)
+		keyField.IsScalarField = isPtrField(keyType, listElem, nonPtrMandatory)
 		listKeys = append(listKeys, keyField)
 	}
 
@@ -2051,13 +4333,16 @@ func yangListFieldToGoType(listField *NodeDetails, listFieldName string, parent
 		// a simple Go type as the key. Note that a leaf-list can never be
 		// a key, so we do not need to handle the case whereby we would have to
 		// have a slice which keys the list.
-		listType = fmt.Sprintf("map[%s]*%s", listKeys[0].Type, listElem.Name)
+		listType = fmt.Sprintf("map[%s]%s%s", listKeys[0].Type, elemPrefix, listElem.Name)
 	default:
 		// This is a list with multiple keys, so we need to generate a new structure
 		// that represents the list key itself - this struct is described in a
 		// generatedGoMultiKeyListStruct struct, which is then expanded by a template to the struct
 		// definition.
-		listKeyStructName = fmt.Sprintf("%s_Key", listElem.Name)
+		if keyStructSuffix == "" {
+			keyStructSuffix = DefaultListKeyStructSuffix
+		}
+		listKeyStructName = fmt.Sprintf("%s%s", listElem.Name, keyStructSuffix)
 		names := make(map[string]bool, len(goStructElements))
 		for _, d := range goStructElements {
 			names[d.Name] = true
@@ -2074,23 +4359,36 @@ func yangListFieldToGoType(listField *NodeDetails, listFieldName string, parent
 			ParentPath:    parent.Path,
 			ListName:      listFieldName,
 			Keys:          listKeys,
+			KeyYANGNames:  listElem.ListKeyYANGNames,
 		}
-		listType = fmt.Sprintf("map[%s]*%s", listKeyStructName, listElem.Name)
+		listType = fmt.Sprintf("map[%s]%s%s", listKeyStructName, elemPrefix, listElem.Name)
 	}
 
 	// Generate the specification for the methods that should be generated for this
 	// list, such that this can be handed to the relevant templates to generate code.
 	listMethodSpec := &generatedGoListMethod{
-		ListName:  listFieldName,
-		ListType:  listElem.Name,
-		KeyStruct: listKeyStructName,
-		Keys:      listKeys,
-		Receiver:  parent.Name,
+		ListName:     listFieldName,
+		ListType:     listElem.Name,
+		KeyStruct:    listKeyStructName,
+		Keys:         listKeys,
+		Receiver:     parent.Name,
+		ValueEntries: valueEntries,
 	}
 
 	return listType, multiListKey, listMethodSpec, nil
 }
 
+// hasOnlyLeaves returns true if all of the fields of the directory dir are
+// YANG leaves.
+func hasOnlyLeaves(dir *ParsedDirectory) bool {
+	for _, f := range dir.Fields {
+		if f.Type != LeafNode {
+			return false
+		}
+	}
+	return true
+}
+
 // writeGoEnum takes an input goEnumeratedType, and generates the code corresponding
 // to it. If errors are encountered whilst mapping the enumeration to
 // code, they are returned. The enumDefinition template is used to convert a
@@ -2100,6 +4398,7 @@ func writeGoEnum(inputEnum *goEnumeratedType) (string, error) {
 	if err := goEnumDefinitionTemplate.Execute(&buf, generatedGoEnumeration{
 		EnumerationPrefix: inputEnum.Name,
 		Values:            inputEnum.CodeValues,
+		DefaultIsZero:     inputEnum.DefaultIsZero,
 	}); err != nil {
 		return "", err
 	}
@@ -2122,6 +4421,80 @@ func writeGoEnumMap(enums map[string]map[int64]ygot.EnumDefinition) (string, err
 	return buf.String(), nil
 }
 
+// writeGoEnumRegistry outputs a map, keyed by the name of each enumerated
+// type, of the slice of definitions of its values using the enumRegistry
+// template. Since text/template visits map keys in sorted order, the values
+// of each enumerated type are ordered by their int64 value.
+func writeGoEnumRegistry(enums map[string]map[int64]ygot.EnumDefinition) (string, error) {
+	if len(enums) == 0 {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := goEnumRegistryTemplate.Execute(&buf, enums); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// writeGoEnumFromString outputs the function that returns the value of the
+// input enumerated type that is represented by a string.
+func writeGoEnumFromString(inputEnum *goEnumeratedType) (string, error) {
+	var buf strings.Builder
+	if err := goEnumFromStringTemplate.Execute(&buf, generatedGoEnumeration{
+		EnumerationPrefix: inputEnum.Name,
+	}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// enumFromStringEntry is a string that represents the value of an
+// enumerated type, along with the int64 value that it represents.
+type enumFromStringEntry struct {
+	Name  string
+	Value int64
+}
+
+// writeGoEnumFromStringMap outputs a map, keyed by the name of each
+// enumerated type, of the int64 value of each of its values keyed by the
+// string that represents it, using the enumFromStringMap template. Values
+// that are defined by a module are also keyed by their name prefixed by that
+// of the module; an unprefixed name that is used by more than one value is
+// omitted, since it is ambiguous.
+func writeGoEnumFromStringMap(enums map[string]map[int64]ygot.EnumDefinition) (string, error) {
+	if len(enums) == 0 {
+		return "", nil
+	}
+
+	entries := map[string][]enumFromStringEntry{}
+	for enumName, values := range enums {
+		var ordered []int64
+		nameCount := map[string]int{}
+		for v, def := range values {
+			ordered = append(ordered, v)
+			nameCount[def.Name]++
+		}
+		sort.Slice(ordered, func(i, j int) bool { return ordered[i] < ordered[j] })
+
+		for _, v := range ordered {
+			def := values[v]
+			if nameCount[def.Name] == 1 {
+				entries[enumName] = append(entries[enumName], enumFromStringEntry{Name: def.Name, Value: v})
+			}
+			if def.DefiningModule != "" {
+				entries[enumName] = append(entries[enumName], enumFromStringEntry{Name: fmt.Sprintf("%s:%s", def.DefiningModule, def.Name), Value: v})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := goEnumFromStringMapTemplate.Execute(&buf, entries); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 // generateEnumTypeMap outputs a map using the enumTypeMap template. It takes an
 // input of a map, keyed by schema path, to the string names of the enumerated
 // types that can correspond to the schema path. The map generated allows a
@@ -2134,6 +4507,98 @@ func generateEnumTypeMap(enumTypeMap map[string][]string) (string, error) {
 	return buf.String(), nil
 }
 
+// generatePathTypeMap outputs a map using the pathTypeMap template. It takes
+// an input map, keyed by schema path, of the name of the struct generated for
+// the schema path. The map generated allows a schema path to be mapped to the
+// reflect.Type of the corresponding GoStruct.
+func generatePathTypeMap(pathTypeMap map[string]string) (string, error) {
+	var buf bytes.Buffer
+	if err := goPathTypeMapTemplate.Execute(&buf, pathTypeMap); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// generateInterfaceChecks outputs compile-time interface assertions using the
+// interfaceChecks template for each of the struct names supplied. If validated
+// is true, the structs are also asserted to implement ygot.ValidatedGoStruct,
+// which requires that the validation methods have been generated.
+func generateInterfaceChecks(structNames []string, validated bool) (string, error) {
+	var buf bytes.Buffer
+	if err := goInterfaceChecksTemplate.Execute(&buf, struct {
+		StructNames []string
+		Validated   bool
+	}{
+		StructNames: structNames,
+		Validated:   validated,
+	}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// goRootEntity is the description of an entity at the root of the YANG
+// schema tree that is output within the ΛRootEntities map.
+type goRootEntity struct {
+	// StructName is the name of the struct generated for the entity.
+	StructName string
+	// Path is the path of the entity, from the root, in the form of a
+	// path struct tag.
+	Path string
+	// Module is the module of each element of Path, in the form of a
+	// module struct tag.
+	Module string
+	// Keys is the ordered set of YANG names of the keys of the entity.
+	Keys []string
+}
+
+// generateRootHelpers outputs the root-level helper functions using the
+// rootHelpers template for the directories within dirs that are at the root
+// of the YANG schema tree. These are the containers and lists whose parent
+// is the root, along with lists whose parent container is at the root when
+// compressPaths is true, such that they correspond to the fields that the
+// fake root would have.
+func generateRootHelpers(dirs map[string]*ParsedDirectory, compressPaths bool) (string, error) {
+	var entities []goRootEntity
+	for _, dir := range dirs {
+		if dir.IsFakeRoot {
+			continue
+		}
+		p := strings.Split(dirSchemaPath(dir), "/")[1:]
+		var mods []string
+		switch {
+		case len(p) == 1:
+			mods = []string{dir.BelongingModule}
+		case len(p) == 2 && compressPaths && dir.Type == List:
+			mods = []string{dir.RootElementModule, dir.BelongingModule}
+		default:
+			continue
+		}
+		entities = append(entities, goRootEntity{
+			StructName: dir.Name,
+			Path:       strings.Join(p, "/"),
+			Module:     strings.Join(mods, "/"),
+			Keys:       dir.ListKeyYANGNames,
+		})
+	}
+	sort.Slice(entities, func(i, j int) bool { return entities[i].StructName < entities[j].StructName })
+
+	var buf bytes.Buffer
+	if err := goRootHelpersTemplate.Execute(&buf, entities); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// dirSchemaPath returns the absolute schema path of the supplied directory,
+// with the module name removed. The fake root is mapped to the "/" path.
+func dirSchemaPath(dir *ParsedDirectory) string {
+	if dir.IsFakeRoot {
+		return "/"
+	}
+	return util.SlicePathToString(append([]string{""}, strings.Split(dir.Path, "/")[2:]...))
+}
+
 // generateEnumTypeMapAccessor generates a function which returns the defined
 // enumTypeMap for a struct.
 func generateEnumTypeMapAccessor(b *bytes.Buffer, s generatedGoStruct) error {
@@ -2176,6 +4641,54 @@ func writeGoSchema(js []byte, schemaVarName string) (string, error) {
 	return buf.String(), nil
 }
 
+// writeGoSchemaPerModule takes the JSON-serialised schema documents, keyed
+// by YANG module name, and stores them gzipped in a map variable which can be
+// written out to the generated Go code file.
+func writeGoSchemaPerModule(jss map[string][]byte, schemaVarName string) (string, error) {
+	type moduleSchema struct {
+		Name   string
+		Schema []string
+	}
+
+	var mods []string
+	for m := range jss {
+		mods = append(mods, m)
+	}
+	sort.Strings(mods)
+
+	var schemas []moduleSchema
+	for _, m := range mods {
+		jbyte, err := WriteGzippedByteSlice(jss[m])
+		if err != nil {
+			return "", fmt.Errorf("could not write Byte slice for module %s: %v", m, err)
+		}
+		schemas = append(schemas, moduleSchema{
+			Name:   m,
+			Schema: BytesToGoByteSlice(jbyte),
+		})
+	}
+
+	vn := defaultSchemaVarName
+	if schemaVarName != "" {
+		vn = schemaVarName
+	}
+
+	in := struct {
+		VarName string
+		Modules []moduleSchema
+	}{
+		VarName: vn,
+		Modules: schemas,
+	}
+
+	var buf bytes.Buffer
+	if err := schemaPerModuleVarTemplate.Execute(&buf, in); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
 // goLeafDefaults returns the default value(s) of the leaf e if specified. If it
 // is unspecified, the value specified by the type is returned if it is not nil,
 // otherwise nil is returned to indicate no default was specified.
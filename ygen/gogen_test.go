@@ -16,6 +16,10 @@ package ygen
 
 import (
 	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -139,6 +143,502 @@ func (t *Tstruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes
 func (*Tstruct) ΛBelongingModule() string {
 	return "exmod"
 }
+`,
+		},
+	}, {
+		name: "simple single leaf mapping test with context validation",
+		inStructToMap: &ParsedDirectory{
+			Name: "Tstruct",
+			Fields: map[string]*NodeDetails{
+				"f1": {
+					Name: "F1",
+					YANGDetails: YANGNodeDetails{
+						Name:              "f1",
+						RootElementModule: "exmod",
+						Path:              "/root-module/tstruct/f1",
+					},
+					Type: LeafNode,
+					LangType: &MappedType{
+						NativeType: "int8",
+						ZeroValue:  "0",
+					},
+					MappedPaths:       [][]string{{"f1"}},
+					MappedPathModules: [][]string{{"exmod"}},
+				},
+			},
+			Path:            "/root-module/tstruct",
+			BelongingModule: "exmod",
+		},
+		inGoOpts: GoOpts{
+			ValidateWithContext: true,
+		},
+		want: wantGoStructOut{
+			structs: `
+// Tstruct represents the /root-module/tstruct YANG schema element.
+type Tstruct struct {
+	F1	*int8	` + "`" + `path:"f1" module:"exmod"` + "`" + `
+}
+
+// IsYANGGoStruct ensures that Tstruct implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Tstruct) IsYANGGoStruct() {}
+`,
+			methods: `
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Tstruct) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Tstruct"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛValidateContext validates s against the YANG schema corresponding to its
+// type, returning ctx.Err() if ctx is done before validation completes.
+func (t *Tstruct) ΛValidateContext(ctx context.Context, opts ...ygot.ValidationOption) error {
+	if err := ytypes.ValidateContext(ctx, SchemaTree["Tstruct"], t, opts...); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Tstruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Tstruct.
+func (*Tstruct) ΛBelongingModule() string {
+	return "exmod"
+}
+`,
+		},
+	}, {
+		name: "simple single leaf mapping test with subtree validation",
+		inStructToMap: &ParsedDirectory{
+			Name: "Tstruct",
+			Fields: map[string]*NodeDetails{
+				"f1": {
+					Name: "F1",
+					YANGDetails: YANGNodeDetails{
+						Name:              "f1",
+						RootElementModule: "exmod",
+						Path:              "/root-module/tstruct/f1",
+					},
+					Type: LeafNode,
+					LangType: &MappedType{
+						NativeType: "int8",
+						ZeroValue:  "0",
+					},
+					MappedPaths:       [][]string{{"f1"}},
+					MappedPathModules: [][]string{{"exmod"}},
+				},
+			},
+			Path:            "/root-module/tstruct",
+			BelongingModule: "exmod",
+		},
+		inGoOpts: GoOpts{
+			GenerateSubtreeValidate: true,
+		},
+		want: wantGoStructOut{
+			structs: `
+// Tstruct represents the /root-module/tstruct YANG schema element.
+type Tstruct struct {
+	F1	*int8	` + "`" + `path:"f1" module:"exmod"` + "`" + `
+}
+
+// IsYANGGoStruct ensures that Tstruct implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Tstruct) IsYANGGoStruct() {}
+`,
+			methods: `
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Tstruct) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Tstruct"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛValidateAt validates the subtree of s found at path against the YANG
+// schema corresponding to its type, without validating the rest of s.
+func (t *Tstruct) ΛValidateAt(path *gpb.Path, opts ...ygot.ValidationOption) error {
+	if err := ytypes.ValidateAt(SchemaTree["Tstruct"], t, path, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Tstruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Tstruct.
+func (*Tstruct) ΛBelongingModule() string {
+	return "exmod"
+}
+`,
+		},
+	}, {
+		name: "fake root with CBOR methods",
+		inStructToMap: &ParsedDirectory{
+			Name: "Device",
+			Fields: map[string]*NodeDetails{
+				"f1": {
+					Name: "F1",
+					YANGDetails: YANGNodeDetails{
+						Name:              "f1",
+						RootElementModule: "exmod",
+						Path:              "/f1",
+					},
+					Type: LeafNode,
+					LangType: &MappedType{
+						NativeType: "int8",
+						ZeroValue:  "0",
+					},
+					MappedPaths:       [][]string{{"f1"}},
+					MappedPathModules: [][]string{{"exmod"}},
+				},
+			},
+			Path:       "/device",
+			IsFakeRoot: true,
+		},
+		inGoOpts: GoOpts{
+			GenerateCBORMethods: true,
+		},
+		want: wantGoStructOut{
+			structs: `
+// Device represents the /device YANG schema element.
+type Device struct {
+	F1	*int8	` + "`" + `path:"f1" module:"exmod"` + "`" + `
+}
+
+// IsYANGGoStruct ensures that Device implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Device) IsYANGGoStruct() {}
+`,
+			methods: `
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Device) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Device"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛMarshalCBOR marshals the data tree rooted at t to a CBOR data item, which
+// has the structure of the RFC7951 JSON representation of t, using the
+// supplied codec. The data item is not encoded according to RFC9254
+// YANG-CBOR: values such as 64-bit integers and binary leaves are encoded
+// as text strings, as they are within RFC7951 JSON.
+func (t *Device) ΛMarshalCBOR(codec ygot.CBORCodec) ([]byte, error) {
+	return ygot.MarshalCBOR(t, codec, &ygot.RFC7951JSONConfig{AppendModuleName: true})
+}
+
+// ΛUnmarshalCBOR unmarshals the CBOR data item in data, which has the
+// structure of the RFC7951 JSON representation of the data tree, such as is
+// output by ΛMarshalCBOR, into t using the supplied codec. The supplied
+// options (opts) are used to control the behaviour of the unmarshal function.
+func (t *Device) ΛUnmarshalCBOR(codec ygot.CBORCodec, data []byte, opts ...ytypes.UnmarshalOpt) error {
+	tree, err := ygot.UnmarshalCBORTree(codec, data)
+	if err != nil {
+		return err
+	}
+	return ytypes.Unmarshal(SchemaTree["Device"], t, tree, opts...)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Device) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Device.
+func (*Device) ΛBelongingModule() string {
+	return ""
+}
+`,
+		},
+	}, {
+		name: "fake root with GetByPath method",
+		inStructToMap: &ParsedDirectory{
+			Name: "Device",
+			Fields: map[string]*NodeDetails{
+				"f1": {
+					Name: "F1",
+					YANGDetails: YANGNodeDetails{
+						Name:              "f1",
+						RootElementModule: "exmod",
+						Path:              "/f1",
+					},
+					Type: LeafNode,
+					LangType: &MappedType{
+						NativeType: "int8",
+						ZeroValue:  "0",
+					},
+					MappedPaths:       [][]string{{"f1"}},
+					MappedPathModules: [][]string{{"exmod"}},
+				},
+			},
+			Path:       "/device",
+			IsFakeRoot: true,
+		},
+		inGoOpts: GoOpts{
+			GenerateRootGetByPath: true,
+		},
+		want: wantGoStructOut{
+			structs: `
+// Device represents the /device YANG schema element.
+type Device struct {
+	F1	*int8	` + "`" + `path:"f1" module:"exmod"` + "`" + `
+}
+
+// IsYANGGoStruct ensures that Device implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Device) IsYANGGoStruct() {}
+`,
+			methods: `
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Device) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Device"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetByPath returns the value found at path within the data tree rooted at
+// t. The value returned is either that of a leaf, or a pointer to the struct
+// representing a container or list member. The keys of any lists along the
+// path must be specified. An error is returned if no populated node is found
+// at path.
+func (t *Device) GetByPath(path *gpb.Path) (interface{}, error) {
+	nodes, err := ytypes.GetNode(SchemaTree["Device"], t, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) != 1 {
+		return nil, fmt.Errorf("path %v matched %d nodes, expected 1", path, len(nodes))
+	}
+	switch v := reflect.ValueOf(nodes[0].Data); v.Kind() {
+	case reflect.Invalid:
+		return nil, fmt.Errorf("no value found at path %v", path)
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		if v.IsNil() {
+			return nil, fmt.Errorf("no value found at path %v", path)
+		}
+	}
+	return nodes[0].Data, nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Device) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Device.
+func (*Device) ΛBelongingModule() string {
+	return ""
+}
+`,
+		},
+	}, {
+		name: "fake root with protobuf bridge methods",
+		inStructToMap: &ParsedDirectory{
+			Name: "Device",
+			Fields: map[string]*NodeDetails{
+				"f1": {
+					Name: "F1",
+					YANGDetails: YANGNodeDetails{
+						Name:              "f1",
+						RootElementModule: "exmod",
+						Path:              "/f1",
+					},
+					Type: LeafNode,
+					LangType: &MappedType{
+						NativeType: "int8",
+						ZeroValue:  "0",
+					},
+					MappedPaths:       [][]string{{"f1"}},
+					MappedPathModules: [][]string{{"exmod"}},
+				},
+			},
+			Path:       "/device",
+			IsFakeRoot: true,
+		},
+		inGoOpts: GoOpts{
+			GenerateProtoBridge: true,
+		},
+		want: wantGoStructOut{
+			structs: `
+// Device represents the /device YANG schema element.
+type Device struct {
+	F1	*int8	` + "`" + `path:"f1" module:"exmod"` + "`" + `
+}
+
+// IsYANGGoStruct ensures that Device implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Device) IsYANGGoStruct() {}
+`,
+			methods: `
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Device) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Device"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ToProto populates p, which must be the protobuf message generated for the
+// root of the YANG schema, with the contents of the data tree rooted at t.
+// Leaf-lists, decimal64 leaves, and protobufs generated with proto maps are
+// not supported, and an error is returned if such a field is populated.
+func (t *Device) ToProto(p proto.Message) error {
+	return protomap.GoStructToProto(t, p)
+}
+
+// FromProto populates the data tree rooted at t with the contents of p, which
+// must be the protobuf message generated for the root of the YANG schema.
+// Leaf-lists, decimal64 leaves, and protobufs generated with proto maps are
+// not supported, and an error is returned if such a field is populated.
+func (t *Device) FromProto(p proto.Message) error {
+	return protomap.GoStructFromProto(p, SchemaTree["Device"], t)
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Device) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Device.
+func (*Device) ΛBelongingModule() string {
+	return ""
+}
+`,
+		},
+	}, {
+		name: "fake root with leafref validation",
+		inStructToMap: &ParsedDirectory{
+			Name: "Device",
+			Fields: map[string]*NodeDetails{
+				"f1": {
+					Name: "F1",
+					YANGDetails: YANGNodeDetails{
+						Name:              "f1",
+						RootElementModule: "exmod",
+						Path:              "/exmod/f1",
+						SchemaPath:        "/f1",
+					},
+					Type: LeafNode,
+					LangType: &MappedType{
+						NativeType: "string",
+						ZeroValue:  `""`,
+					},
+					MappedPaths:       [][]string{{"f1"}},
+					MappedPathModules: [][]string{{"exmod"}},
+				},
+				"f2": {
+					Name: "F2",
+					YANGDetails: YANGNodeDetails{
+						Name:              "f2",
+						RootElementModule: "exmod",
+						Path:              "/exmod/f2",
+						SchemaPath:        "/f2",
+						LeafrefTargetPath: "/exmod/f1",
+					},
+					Type: LeafListNode,
+					LangType: &MappedType{
+						NativeType: "string",
+						ZeroValue:  `""`,
+					},
+					MappedPaths:       [][]string{{"f2"}},
+					MappedPathModules: [][]string{{"exmod"}},
+				},
+			},
+			Path:       "/device",
+			IsFakeRoot: true,
+		},
+		inGoOpts: GoOpts{
+			GenerateLeafrefValidation: true,
+		},
+		want: wantGoStructOut{
+			structs: `
+// Device represents the /device YANG schema element.
+type Device struct {
+	F1	*string	` + "`" + `path:"f1" module:"exmod"` + "`" + `
+	F2	[]string	` + "`" + `path:"f2" module:"exmod"` + "`" + `
+}
+
+// IsYANGGoStruct ensures that Device implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Device) IsYANGGoStruct() {}
+`,
+			methods: `
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Device) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Device"], t, append(opts[:len(opts):len(opts)], &ytypes.LeafrefOptions{IgnoreMissingData: true})...); err != nil {
+		return err
+	}
+	return t.validateLeafrefs(opts...)
+}
+
+// leafrefTargets_Device_F1 returns the set of values of the /f1
+// leaf within the data tree rooted at t.
+func (t *Device) leafrefTargets_Device_F1() map[interface{}]bool {
+	values := map[interface{}]bool{}
+	if t.F1 != nil {
+		values[*t.F1] = true
+	}
+	return values
+}
+
+// validateLeafrefs checks that each value of each leafref within the data
+// tree rooted at t exists at the target of the leafref, unless opts contains
+// a ytypes.LeafrefOptions specifying that missing data is to be ignored. The
+// predicates of leafref paths are not evaluated, such that a value exists if
+// any instance of the target leaf has the same value.
+func (t *Device) validateLeafrefs(opts ...ygot.ValidationOption) error {
+	var ignoreMissingData bool
+	for _, o := range opts {
+		if lo, ok := o.(*ytypes.LeafrefOptions); ok {
+			ignoreMissingData = lo.IgnoreMissingData
+		}
+	}
+	if ignoreMissingData {
+		return nil
+	}
+
+	var errs []string
+	{
+		targets := t.leafrefTargets_Device_F1()
+		for _, v1 := range t.F2 {
+			if !targets[v1] {
+				errs = append(errs, fmt.Sprintf("leafref /f2 value %v does not exist at /f1", v1))
+			}
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Device) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Device.
+func (*Device) ΛBelongingModule() string {
+	return ""
+}
 `,
 		},
 	}, {
@@ -1043,11 +1543,128 @@ func (t *Tstruct) ΛValidate(opts ...ygot.ValidationOption) error {
 
 // ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
 // that are included in the generated code.
-func (t *Tstruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+func (t *Tstruct) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
+
+// ΛBelongingModule returns the name of the module that defines the namespace
+// of Tstruct.
+func (*Tstruct) ΛBelongingModule() string {
+	return "exmod"
+}
+`,
+		},
+	}, {
+		name: "multi-key list member with list key constants",
+		inStructToMap: &ParsedDirectory{
+			Name: "Tstruct_ListWithKey",
+			Type: List,
+			Fields: map[string]*NodeDetails{
+				"keyLeafOne": {
+					Name: "KeyLeafOne",
+					YANGDetails: YANGNodeDetails{
+						Name:              "keyLeafOne",
+						RootElementModule: "exmod",
+						Path:              "/root-module/tstruct/listWithKey/keyLeafOne",
+					},
+					Type: LeafNode,
+					LangType: &MappedType{
+						NativeType: "string",
+						ZeroValue:  `""`,
+					},
+					MappedPaths:       [][]string{{"keyLeafOne"}},
+					MappedPathModules: [][]string{{"exmod"}},
+				},
+				"keyLeafTwo": {
+					Name: "KeyLeafTwo",
+					YANGDetails: YANGNodeDetails{
+						Name:              "keyLeafTwo",
+						RootElementModule: "exmod",
+						Path:              "/root-module/tstruct/listWithKey/keyLeafTwo",
+					},
+					Type: LeafNode,
+					LangType: &MappedType{
+						NativeType: "int8",
+						ZeroValue:  "0",
+					},
+					MappedPaths:       [][]string{{"keyLeafTwo"}},
+					MappedPathModules: [][]string{{"exmod"}},
+				},
+			},
+			ListKeys: map[string]*ListKey{
+				"keyLeafOne": {
+					Name: "KeyLeafOne",
+					LangType: &MappedType{
+						NativeType: "string",
+						ZeroValue:  `""`,
+					},
+				},
+				"keyLeafTwo": {
+					Name: "KeyLeafTwo",
+					LangType: &MappedType{
+						NativeType: "int8",
+						ZeroValue:  "0",
+					},
+				},
+			},
+			ListKeyYANGNames: []string{"keyLeafTwo", "keyLeafOne"},
+			Path:             "/root-module/tstruct/listWithKey",
+			BelongingModule:  "exmod",
+		},
+		inGoOpts: GoOpts{
+			GenerateListKeyConstants: true,
+		},
+		want: wantGoStructOut{
+			structs: `
+// Tstruct_ListWithKey represents the /root-module/tstruct/listWithKey YANG schema element.
+type Tstruct_ListWithKey struct {
+	KeyLeafOne	*string	` + "`" + `path:"keyLeafOne" module:"exmod"` + "`" + `
+	KeyLeafTwo	*int8	` + "`" + `path:"keyLeafTwo" module:"exmod"` + "`" + `
+}
+
+// IsYANGGoStruct ensures that Tstruct_ListWithKey implements the yang.GoStruct
+// interface. This allows functions that need to handle this struct to
+// identify it as being generated by ygen.
+func (*Tstruct_ListWithKey) IsYANGGoStruct() {}
+`,
+			methods: `
+// ΛListKeyMap returns the keys of the Tstruct_ListWithKey struct, which is a YANG list entry.
+func (t *Tstruct_ListWithKey) ΛListKeyMap() (map[string]interface{}, error) {
+	if t.KeyLeafOne == nil {
+		return nil, fmt.Errorf("nil value for key KeyLeafOne")
+	}
+
+	if t.KeyLeafTwo == nil {
+		return nil, fmt.Errorf("nil value for key KeyLeafTwo")
+	}
+
+	return map[string]interface{}{
+		"keyLeafOne": *t.KeyLeafOne,
+		"keyLeafTwo": *t.KeyLeafTwo,
+	}, nil
+}
+
+// Tstruct_ListWithKeyListKeys contains the YANG names of the keys of the Tstruct_ListWithKey
+// struct, which is a YANG list entry, in the order in which they are declared
+// in the schema.
+var Tstruct_ListWithKeyListKeys = []string{
+	"keyLeafTwo",
+	"keyLeafOne",
+}
+
+// Validate validates s against the YANG schema corresponding to its type.
+func (t *Tstruct_ListWithKey) ΛValidate(opts ...ygot.ValidationOption) error {
+	if err := ytypes.Validate(SchemaTree["Tstruct_ListWithKey"], t, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ΛEnumTypeMap returns a map, keyed by YANG schema path, of the enumerated types
+// that are included in the generated code.
+func (t *Tstruct_ListWithKey) ΛEnumTypeMap() map[string][]reflect.Type { return ΛEnumTypes }
 
 // ΛBelongingModule returns the name of the module that defines the namespace
-// of Tstruct.
-func (*Tstruct) ΛBelongingModule() string {
+// of Tstruct_ListWithKey.
+func (*Tstruct_ListWithKey) ΛBelongingModule() string {
 	return "exmod"
 }
 `,
@@ -1826,7 +2443,7 @@ func (*Container) ΛBelongingModule() string {
 			tt.inOtherStructMap[tt.inStructToMap.Path] = tt.inStructToMap
 			// Always generate the JSON schema for this test.
 			generatedUnions := map[string]bool{}
-			got, errs := writeGoStruct(tt.inStructToMap, tt.inOtherStructMap, generatedUnions, tt.inIgnoreShadowSchemaPaths, tt.inGoOpts, true)
+			got, errs := writeGoStruct(tt.inStructToMap, tt.inOtherStructMap, generatedUnions, tt.inIgnoreShadowSchemaPaths, "", tt.inGoOpts, true)
 
 			if len(errs) != 0 && !tt.want.wantErr {
 				t.Fatalf("%s writeGoStruct(targetStruct: %v): received unexpected errors: %v",
@@ -1900,9 +2517,10 @@ func TestGenGoEnumeratedTypes(t *testing.T) {
 	}
 
 	tests := []struct {
-		name string
-		in   map[string]*EnumeratedYANGType
-		want map[string]*goEnumeratedType
+		name       string
+		in         map[string]*EnumeratedYANGType
+		inDefaults map[string]string
+		want       map[string]*goEnumeratedType
 	}{{
 		name: "enum",
 		in: map[string]*EnumeratedYANGType{
@@ -1951,10 +2569,43 @@ func TestGenGoEnumeratedTypes(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "enum with default as zero",
+		in: map[string]*EnumeratedYANGType{
+			"foo": {
+				Name:     "EnumeratedValue",
+				Kind:     SimpleEnumerationType,
+				TypeName: "enumerated-value",
+				ValToYANGDetails: []ygot.EnumDefinition{
+					{Name: "VALUE_A"},
+					{Name: "VALUE_B"},
+					{Name: "VALUE_C"},
+				},
+			},
+		},
+		inDefaults: map[string]string{
+			"foo": "VALUE_B",
+		},
+		want: map[string]*goEnumeratedType{
+			"EnumeratedValue": {
+				Name: "EnumeratedValue",
+				CodeValues: map[int64]string{
+					0: "VALUE_B",
+					1: "VALUE_A",
+					3: "VALUE_C",
+				},
+				YANGValues: map[int64]ygot.EnumDefinition{
+					0: {Name: "VALUE_B"},
+					1: {Name: "VALUE_A"},
+					3: {Name: "VALUE_C"},
+				},
+				DefaultIsZero: true,
+			},
+		},
 	}}
 
 	for _, tt := range tests {
-		got, err := genGoEnumeratedTypes(tt.in)
+		got, err := genGoEnumeratedTypes(tt.in, tt.inDefaults)
 		if err != nil {
 			t.Errorf("%s: genGoEnumeratedTypes(%v): got unexpected error: %v",
 				tt.name, tt.in, err)
@@ -2116,6 +2767,384 @@ var ΛEnum = map[string]map[int64]ygot.EnumDefinition{
 	}
 }
 
+func TestWriteGoEnumRegistry(t *testing.T) {
+	tests := []struct {
+		name    string
+		inMap   map[string]map[int64]ygot.EnumDefinition
+		wantMap string
+	}{{
+		name:  "empty input",
+		inMap: map[string]map[int64]ygot.EnumDefinition{},
+	}, {
+		name: "enumeration and identity input",
+		inMap: map[string]map[int64]ygot.EnumDefinition{
+			"EnumOne": {
+				3: {Name: "VAL3", Value: 2},
+				1: {Name: "VAL1"},
+				2: {Name: "VAL2", Value: 1},
+			},
+			"IdentityOne": {
+				2: {Name: "ID_B", DefiningModule: "mod-b"},
+				1: {Name: "ID_A", DefiningModule: "mod-a"},
+			},
+		},
+		wantMap: `
+// ΛEnumRegistry is a map, keyed by the name of the type defined for each enum
+// in the generated Go code, of the definitions of the values of the
+// enumeration, ordered by the constant int64 value of each value. The map is
+// named ΛEnumRegistry in order to avoid clash with any valid YANG identifier.
+var ΛEnumRegistry = map[string][]ygot.EnumDefinition{
+	"E_EnumOne": {
+		{Name: "VAL1"},
+		{Name: "VAL2", Value: 1},
+		{Name: "VAL3", Value: 2},
+	},
+	"E_IdentityOne": {
+		{Name: "ID_A", DefiningModule: "mod-a"},
+		{Name: "ID_B", DefiningModule: "mod-b"},
+	},
+}
+`,
+	}}
+
+	for _, tt := range tests {
+		got, err := writeGoEnumRegistry(tt.inMap)
+		if err != nil {
+			t.Errorf("%s: got unexpected error when generating registry: %v", tt.name, err)
+			continue
+		}
+
+		if tt.wantMap != got {
+			diff := fmt.Sprintf("got: %s, want %s", got, tt.wantMap)
+			if diffl, err := testutil.GenerateUnifiedDiff(tt.wantMap, got); err == nil {
+				diff = "diff (-want, +got):\n" + diffl
+			}
+			t.Errorf("%s: did not get expected generated registry, %s", tt.name, diff)
+		}
+	}
+}
+
+func TestWriteGoEnumFromStringMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		inMap   map[string]map[int64]ygot.EnumDefinition
+		wantMap string
+	}{{
+		name:  "empty input",
+		inMap: map[string]map[int64]ygot.EnumDefinition{},
+	}, {
+		name: "enumeration and identity input",
+		inMap: map[string]map[int64]ygot.EnumDefinition{
+			"EnumOne": {
+				2: {Name: "VAL2"},
+				1: {Name: "VAL1"},
+			},
+			"IdentityOne": {
+				3: {Name: "ID_A", DefiningModule: "mod-b"},
+				2: {Name: "ID_B", DefiningModule: "mod-b"},
+				1: {Name: "ID_A", DefiningModule: "mod-a"},
+			},
+		},
+		wantMap: `
+// ΛEnumFromString is a map, keyed by the name of the type defined for each enum
+// in the generated Go code, which provides a mapping between the string that is
+// used to represent each value of the enumeration in the YANG schema, and its
+// constant int64 value. Values that are defined by a module, such as identities,
+// are also keyed by their name prefixed with that of the defining module, as
+// they are represented in RFC7951 JSON; where more than one module defines a
+// value with the same name, only the prefixed names are included. The map is
+// named ΛEnumFromString in order to avoid clash with any valid YANG identifier.
+var ΛEnumFromString = map[string]map[string]int64{
+	"E_EnumOne": {
+		"VAL1": 1,
+		"VAL2": 2,
+	},
+	"E_IdentityOne": {
+		"mod-a:ID_A": 1,
+		"ID_B": 2,
+		"mod-b:ID_B": 2,
+		"mod-b:ID_A": 3,
+	},
+}
+`,
+	}}
+
+	for _, tt := range tests {
+		got, err := writeGoEnumFromStringMap(tt.inMap)
+		if err != nil {
+			t.Errorf("%s: got unexpected error when generating map: %v", tt.name, err)
+			continue
+		}
+
+		if tt.wantMap != got {
+			diff := fmt.Sprintf("got: %s, want %s", got, tt.wantMap)
+			if diffl, err := testutil.GenerateUnifiedDiff(tt.wantMap, got); err == nil {
+				diff = "diff (-want, +got):\n" + diffl
+			}
+			t.Errorf("%s: did not get expected generated map, %s", tt.name, diff)
+		}
+	}
+}
+
+func TestGenericUnionTerms(t *testing.T) {
+	tests := []struct {
+		name    string
+		inTypes map[string]string
+		want    []*genericUnionTerm
+	}{{
+		name: "builtin and enumerated types",
+		inTypes: map[string]string{
+			"UnionString":  "string",
+			"UnionUint32":  "uint32",
+			"E_Foo_Bar":    "E_Foo_Bar",
+			"Binary":       "Binary",
+			"UnionFloat64": "float64",
+		},
+		want: []*genericUnionTerm{
+			{Term: "~[]byte", Type: "Binary", Kind: "Slice"},
+			{Term: "E_Foo_Bar", Type: "E_Foo_Bar"},
+			{Term: "~float64", Type: "UnionFloat64", Kind: "Float64"},
+			{Term: "~string", Type: "UnionString", Kind: "String"},
+			{Term: "~uint32", Type: "UnionUint32", Kind: "Uint32"},
+		},
+	}, {
+		name: "ambiguous underlying types",
+		inTypes: map[string]string{
+			"UnionBool":         "bool",
+			"YANGEmpty":         "YANGEmpty",
+			"UnionInt64":        "int64",
+			"E_Foo_Bar":         "E_Foo_Bar",
+			"*UnionUnsupported": "interface{}",
+		},
+		want: []*genericUnionTerm{
+			{Term: "*UnionUnsupported", Type: "*UnionUnsupported"},
+			{Term: "E_Foo_Bar", Type: "E_Foo_Bar"},
+			{Term: "UnionBool", Type: "UnionBool"},
+			{Term: "UnionInt64", Type: "UnionInt64"},
+			{Term: "YANGEmpty", Type: "YANGEmpty"},
+		},
+	}, {
+		name: "instance identifier",
+		inTypes: map[string]string{
+			"UnionInstanceIdentifier": "ygot.InstanceIdentifier",
+			"UnionUint32":             "uint32",
+		},
+		want: []*genericUnionTerm{
+			{Term: "~string", Type: "UnionInstanceIdentifier", Kind: "String"},
+			{Term: "~uint32", Type: "UnionUint32", Kind: "Uint32"},
+		},
+	}, {
+		name: "instance identifier and string",
+		inTypes: map[string]string{
+			"UnionInstanceIdentifier": "ygot.InstanceIdentifier",
+			"UnionString":             "string",
+		},
+		want: []*genericUnionTerm{
+			{Term: "UnionInstanceIdentifier", Type: "UnionInstanceIdentifier"},
+			{Term: "UnionString", Type: "UnionString"},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, genericUnionTerms(tt.inTypes)); diff != "" {
+				t.Errorf("genericUnionTerms(%v): did not get expected terms, diff(-want, +got):\n%s", tt.inTypes, diff)
+			}
+		})
+	}
+}
+
+func TestConstraintComments(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   YANGNodeDetails
+		want []string
+	}{{
+		desc: "no constraints",
+		in:   YANGNodeDetails{Type: &YANGType{Name: "string"}},
+	}, {
+		desc: "all constraints",
+		in: YANGNodeDetails{
+			Must: []string{"../a = 'b'", ". > 1 or\n     ../c"},
+			When: "../d != 'e'",
+			Type: &YANGType{
+				Name:    "uint8",
+				Range:   yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(10)}},
+				Length:  yang.YangRange{{Min: yang.FromInt(2), Max: yang.FromInt(2)}},
+				Pattern: []string{"^a$", "^.*$"},
+			},
+		},
+		want: []string{
+			"YANG constraints:",
+			"  must: ../a = 'b'",
+			"  must: . > 1 or ../c",
+			"  when: ../d != 'e'",
+			"  range: 1..10",
+			"  length: 2",
+			"  pattern: ^a$",
+			"  pattern: ^.*$",
+		},
+	}, {
+		desc: "when without type",
+		in:   YANGNodeDetails{When: "../d"},
+		want: []string{"YANG constraints:", "  when: ../d"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, constraintComments(tt.in)); diff != "" {
+				t.Errorf("constraintComments: did not get expected comment, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnionAccessors(t *testing.T) {
+	tests := []struct {
+		name           string
+		inUnionTypes   map[string]int
+		inSimpleUnions bool
+		want           []*generatedUnionAccessor
+	}{{
+		name:           "simple unions",
+		inUnionTypes:   map[string]int{"string": 0, "Binary": 1, "E_Foo_Bar": 2, "interface{}": 3, "ygot.InstanceIdentifier": 4},
+		inSimpleUnions: true,
+		want: []*generatedUnionAccessor{
+			{Name: "LeafBinary", FieldName: "Leaf", Type: "Binary", AssertType: "Binary", Value: "Binary(v)", Receiver: "Parent"},
+			{Name: "LeafE_Foo_Bar", FieldName: "Leaf", Type: "E_Foo_Bar", AssertType: "E_Foo_Bar", Value: "v", Receiver: "Parent"},
+			{Name: "LeafInstanceIdentifier", FieldName: "Leaf", Type: "ygot.InstanceIdentifier", AssertType: "UnionInstanceIdentifier", Value: "ygot.InstanceIdentifier(v)", Receiver: "Parent"},
+			{Name: "LeafInterface", FieldName: "Leaf", Type: "interface{}", AssertType: "*UnionUnsupported", Value: "v.Value", Receiver: "Parent"},
+			{Name: "LeafString", FieldName: "Leaf", Type: "string", AssertType: "UnionString", Value: "string(v)", Receiver: "Parent"},
+		},
+	}, {
+		name:         "wrapper unions",
+		inUnionTypes: map[string]int{"string": 0, "E_Foo_Bar": 1, "interface{}": 2},
+		want: []*generatedUnionAccessor{
+			{Name: "LeafE_Foo_Bar", FieldName: "Leaf", Type: "E_Foo_Bar", AssertType: "*Parent_Leaf_Union_E_Foo_Bar", Value: "v.E_Foo_Bar", Receiver: "Parent"},
+			{Name: "LeafInterface", FieldName: "Leaf", Type: "interface{}", AssertType: "*Parent_Leaf_Union_Interface", Value: "v.Interface", Receiver: "Parent"},
+			{Name: "LeafString", FieldName: "Leaf", Type: "string", AssertType: "*Parent_Leaf_Union_String", Value: "v.String", Receiver: "Parent"},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unionAccessors("Parent", "Leaf", "Parent_Leaf_Union", tt.inUnionTypes, tt.inSimpleUnions)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unionAccessors(%v, %v): did not get expected accessors, diff(-want, +got):\n%s", tt.inUnionTypes, tt.inSimpleUnions, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateInterfaceChecks(t *testing.T) {
+	tests := []struct {
+		name          string
+		inStructNames []string
+		inValidated   bool
+		want          string
+	}{{
+		name:          "structs without validation",
+		inStructNames: []string{"Device", "Interface"},
+		want: `
+// Ensure that the generated structs implement the interfaces that are
+// expected by the ygot library.
+var _ ygot.GoStruct = (*Device)(nil)
+var _ ygot.GoStruct = (*Interface)(nil)
+`,
+	}, {
+		name:          "structs with validation",
+		inStructNames: []string{"Device", "Interface"},
+		inValidated:   true,
+		want: `
+// Ensure that the generated structs implement the interfaces that are
+// expected by the ygot library.
+var _ ygot.GoStruct = (*Device)(nil)
+var _ ygot.ValidatedGoStruct = (*Device)(nil)
+var _ ygot.GoStruct = (*Interface)(nil)
+var _ ygot.ValidatedGoStruct = (*Interface)(nil)
+`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generateInterfaceChecks(tt.inStructNames, tt.inValidated)
+			if err != nil {
+				t.Fatalf("generateInterfaceChecks(%v, %v): got unexpected error: %v", tt.inStructNames, tt.inValidated, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("generateInterfaceChecks(%v, %v): did not get expected code, diff(-want, +got):\n%s", tt.inStructNames, tt.inValidated, diff)
+			}
+		})
+	}
+}
+
+func TestWriteGoHeaderPreamble(t *testing.T) {
+	tests := []struct {
+		name             string
+		inFileHeaderText string
+		inBuildTags      []string
+		wantPrefix       string
+		wantErr          bool
+	}{{
+		name:       "no preamble",
+		wantPrefix: "/*\nPackage ocstructs is a generated package",
+	}, {
+		name:             "file header text",
+		inFileHeaderText: "Copyright 2022 Example Inc.\n\nLicensed under the Apache License, Version 2.0.\n",
+		wantPrefix: `// Copyright 2022 Example Inc.
+//
+// Licensed under the Apache License, Version 2.0.
+
+/*
+Package ocstructs is a generated package`,
+	}, {
+		name:        "build tags",
+		inBuildTags: []string{"linux", "!nogen"},
+		wantPrefix: `//go:build linux && !nogen
+// +build linux,!nogen
+
+/*
+Package ocstructs is a generated package`,
+	}, {
+		name:             "file header text and build tags",
+		inFileHeaderText: "Generated code, do not edit.",
+		inBuildTags:      []string{"integration"},
+		wantPrefix: `// Generated code, do not edit.
+
+//go:build integration
+// +build integration
+
+/*
+Package ocstructs is a generated package`,
+	}, {
+		name:        "invalid build tag",
+		inBuildTags: []string{"linux || darwin"},
+		wantErr:     true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := GeneratorConfig{
+				PackageName: "ocstructs",
+				GoOptions: GoOpts{
+					FileHeaderText: tt.inFileHeaderText,
+					BuildTags:      tt.inBuildTags,
+				},
+			}
+			got, _, err := writeGoHeader([]string{"openconfig-test.yang"}, nil, cfg, "", nil, false, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("writeGoHeader: got error %v, wantErr: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !strings.HasPrefix(got, tt.wantPrefix) {
+				t.Errorf("writeGoHeader: did not get expected header, got:\n%s\nwant prefix:\n%s", got, tt.wantPrefix)
+			}
+		})
+	}
+}
+
 func TestGoLeafDefaults(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -2171,3 +3200,218 @@ func TestGoLeafDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestRFC7951FieldName(t *testing.T) {
+	tests := []struct {
+		desc           string
+		inPaths        [][]string
+		inModules      [][]string
+		inParentModule string
+		want           string
+	}{{
+		desc:           "field in same module as parent",
+		inPaths:        [][]string{{"config", "a"}},
+		inModules:      [][]string{{"mod-a", "mod-a"}},
+		inParentModule: "mod-a",
+		want:           "a",
+	}, {
+		desc:           "field in different module from parent",
+		inPaths:        [][]string{{"state", "b"}},
+		inModules:      [][]string{{"mod-a", "mod-b"}},
+		inParentModule: "mod-a",
+		want:           "mod-b:b",
+	}, {
+		desc:      "field of fake root",
+		inPaths:   [][]string{{"a"}},
+		inModules: [][]string{{"mod-a"}},
+		want:      "mod-a:a",
+	}, {
+		desc:           "shortest path is used",
+		inPaths:        [][]string{{"config", "key"}, {"key"}},
+		inModules:      [][]string{{"mod-a", "mod-a"}, {"mod-b"}},
+		inParentModule: "mod-a",
+		want:           "mod-b:key",
+	}, {
+		desc: "no paths",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := rfc7951FieldName(tt.inPaths, tt.inModules, tt.inParentModule); got != tt.want {
+				t.Errorf("rfc7951FieldName(%v, %v, %q): did not get expected name, got: %q, want: %q", tt.inPaths, tt.inModules, tt.inParentModule, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPtrField(t *testing.T) {
+	leaf := func(mandatory bool) *NodeDetails {
+		return &NodeDetails{
+			Name: "Leaf",
+			Type: LeafNode,
+			LangType: &MappedType{
+				NativeType: "string",
+			},
+			YANGDetails: YANGNodeDetails{
+				Name:      "leaf",
+				Mandatory: mandatory,
+			},
+		}
+	}
+
+	optional, mandatory, key := leaf(false), leaf(true), leaf(false)
+	enum := &NodeDetails{
+		Name:        "Enum",
+		Type:        LeafNode,
+		LangType:    &MappedType{NativeType: "E_Enum", IsEnumeratedValue: true},
+		YANGDetails: YANGNodeDetails{Name: "enum", Mandatory: true},
+	}
+	parent := &ParsedDirectory{
+		Name: "List",
+		Type: List,
+		Fields: map[string]*NodeDetails{
+			"optional":  optional,
+			"mandatory": mandatory,
+			"key":       key,
+			"enum":      enum,
+		},
+		ListKeys: map[string]*ListKey{
+			"key": {Name: "Key", LangType: &MappedType{NativeType: "string"}},
+		},
+	}
+
+	tests := []struct {
+		desc              string
+		inField           *NodeDetails
+		inNonPtrMandatory bool
+		want              bool
+	}{{
+		desc:    "optional leaf",
+		inField: optional,
+		want:    true,
+	}, {
+		desc:    "mandatory leaf without option",
+		inField: mandatory,
+		want:    true,
+	}, {
+		desc:    "list key without option",
+		inField: key,
+		want:    true,
+	}, {
+		desc:              "optional leaf with option",
+		inField:           optional,
+		inNonPtrMandatory: true,
+		want:              true,
+	}, {
+		desc:              "mandatory leaf with option",
+		inField:           mandatory,
+		inNonPtrMandatory: true,
+		want:              false,
+	}, {
+		desc:              "list key with option",
+		inField:           key,
+		inNonPtrMandatory: true,
+		want:              false,
+	}, {
+		desc:              "mandatory enumerated leaf with option",
+		inField:           enum,
+		inNonPtrMandatory: true,
+		want:              false,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := isPtrField(tt.inField, parent, tt.inNonPtrMandatory); got != tt.want {
+				t.Errorf("isPtrField(%s, %v): got: %v, want: %v", tt.inField.Name, tt.inNonPtrMandatory, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoRangeConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		inRange    yang.YangRange
+		inType     string
+		inUnsigned bool
+		want       []string
+		// wantValid and wantInvalid are values of inType that are
+		// within and outside of the range respectively.
+		wantValid   []string
+		wantInvalid []string
+	}{{
+		name:        "single range",
+		inRange:     yang.YangRange{{Min: yang.FromInt(68), Max: yang.FromInt(9216)}},
+		inType:      "uint16",
+		inUnsigned:  true,
+		want:        []string{"v >= 68 && v <= 9216"},
+		wantValid:   []string{"68", "1500", "9216"},
+		wantInvalid: []string{"0", "67", "9217", "65535"},
+	}, {
+		name:        "unsigned range from zero",
+		inRange:     yang.YangRange{{Min: yang.FromInt(0), Max: yang.FromInt(100)}},
+		inType:      "uint8",
+		inUnsigned:  true,
+		want:        []string{"v <= 100"},
+		wantValid:   []string{"0", "100"},
+		wantInvalid: []string{"101", "255"},
+	}, {
+		name:        "signed range from zero",
+		inRange:     yang.YangRange{{Min: yang.FromInt(0), Max: yang.FromInt(100)}},
+		inType:      "int32",
+		want:        []string{"v >= 0 && v <= 100"},
+		wantValid:   []string{"0", "100"},
+		wantInvalid: []string{"-1", "101"},
+	}, {
+		name: "multiple ranges",
+		inRange: yang.YangRange{
+			{Min: yang.FromInt(-10), Max: yang.FromInt(-1)},
+			{Min: yang.FromInt(1), Max: yang.FromInt(10)},
+		},
+		inType:      "int8",
+		want:        []string{"(v >= -10 && v <= -1)", "(v >= 1 && v <= 10)"},
+		wantValid:   []string{"-10", "-1", "1", "10"},
+		wantInvalid: []string{"-128", "-11", "0", "11", "127"},
+	}, {
+		name: "single values",
+		inRange: yang.YangRange{
+			{Min: yang.FromInt(42), Max: yang.FromInt(42)},
+			{Min: yang.FromInt(84), Max: yang.FromInt(128)},
+		},
+		inType:      "uint64",
+		inUnsigned:  true,
+		want:        []string{"v == 42", "(v >= 84 && v <= 128)"},
+		wantValid:   []string{"42", "84", "128"},
+		wantInvalid: []string{"0", "43", "83", "129", "18446744073709551615"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, goRangeConditions(tt.inRange, "v", tt.inUnsigned)); diff != "" {
+				t.Errorf("goRangeConditions(%v, v, %v): did not get expected conditions, diff(-want, +got):\n%s", tt.inRange, tt.inUnsigned, diff)
+			}
+
+			// Evaluate the conditions for each value in the same
+			// form that they are output in generated setters.
+			inRange := func(val string) bool {
+				v := fmt.Sprintf("%s(%s)", tt.inType, val)
+				expr := strings.Join(goRangeConditions(tt.inRange, v, tt.inUnsigned), " || ")
+				got, err := types.Eval(token.NewFileSet(), nil, token.NoPos, expr)
+				if err != nil {
+					t.Fatalf("cannot evaluate expression %s, %v", expr, err)
+				}
+				return constant.BoolVal(got.Value)
+			}
+			for _, val := range tt.wantValid {
+				if !inRange(val) {
+					t.Errorf("value %s: got out of range, want in range", val)
+				}
+			}
+			for _, val := range tt.wantInvalid {
+				if inRange(val) {
+					t.Errorf("value %s: got in range, want out of range", val)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,391 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/ygot/ygot"
+)
+
+// generatedLeafrefNavigation describes the generated code that visits each
+// value of a leaf or leaf-list within the data tree rooted at the fake root,
+// which is the receiver, t, of the generated method.
+type generatedLeafrefNavigation struct {
+	// Open is the set of lines of code, indented relative to the
+	// enclosing code, that surround the code handling each value.
+	Open []string
+	// Close is the set of lines of code that close the blocks opened by
+	// Open.
+	Close []string
+	// Indent is the indentation of the code handling each value, relative
+	// to the enclosing code.
+	Indent string
+	// Value is the Go expression for the value within the code handling
+	// it.
+	Value string
+}
+
+// generatedLeafrefCheck describes a leafref leaf or leaf-list whose values
+// are checked against the values of its target.
+type generatedLeafrefCheck struct {
+	Path       string                      // Path is the schema path of the leafref.
+	TargetPath string                      // TargetPath is the schema path of the leaf storing the values that the leafref references.
+	Nav        *generatedLeafrefNavigation // Nav is the code that visits each value of the leafref.
+}
+
+// generatedLeafrefTarget describes a leaf that is the target of one or more
+// leafrefs, for which a method returning the set of its values within the
+// data tree is generated.
+type generatedLeafrefTarget struct {
+	Receiver string                      // Receiver is the name of the fake root struct.
+	Func     string                      // Func is the name of the generated method.
+	Path     string                      // Path is the schema path of the target leaf.
+	Nav      *generatedLeafrefNavigation // Nav is the code that visits each value of the target leaf.
+	Checks   []*generatedLeafrefCheck    // Checks is the set of leafrefs that reference the target.
+}
+
+var (
+	// goLeafrefTargetTemplate takes an input generatedLeafrefTarget and
+	// generates the method of the fake root that returns the set of values
+	// of the target leaf.
+	goLeafrefTargetTemplate = mustMakeTemplate("leafrefTarget", `
+// {{ .Func }} returns the set of values of the {{ .Path }}
+// leaf within the data tree rooted at t.
+func (t *{{ .Receiver }}) {{ .Func }}() map[interface{}]bool {
+	values := map[interface{}]bool{}
+{{- range .Nav.Open }}
+	{{ . }}
+{{- end }}
+	{{ .Nav.Indent }}values[{{ .Nav.Value }}] = true
+{{- range .Nav.Close }}
+	{{ . }}
+{{- end }}
+	return values
+}
+`)
+
+	// goLeafrefValidatorTemplate takes an input struct containing the name
+	// of the fake root and the generatedLeafrefTargets of its data tree,
+	// and generates the method that is called by the fake root's ΛValidate
+	// method to check the value of each leafref.
+	goLeafrefValidatorTemplate = mustMakeTemplate("leafrefValidator", `
+// validateLeafrefs checks that each value of each leafref within the data
+// tree rooted at t exists at the target of the leafref, unless opts contains
+// a ytypes.LeafrefOptions specifying that missing data is to be ignored. The
+// predicates of leafref paths are not evaluated, such that a value exists if
+// any instance of the target leaf has the same value.
+func (t *{{ .Receiver }}) validateLeafrefs(opts ...ygot.ValidationOption) error {
+	var ignoreMissingData bool
+	for _, o := range opts {
+		if lo, ok := o.(*ytypes.LeafrefOptions); ok {
+			ignoreMissingData = lo.IgnoreMissingData
+		}
+	}
+	if ignoreMissingData {
+		return nil
+	}
+
+	var errs []string
+{{- range $target := .Targets }}
+	{
+		targets := t.{{ $target.Func }}()
+{{- range $check := $target.Checks }}
+{{- range $check.Nav.Open }}
+		{{ . }}
+{{- end }}
+		{{ $check.Nav.Indent }}if !targets[{{ $check.Nav.Value }}] {
+		{{ $check.Nav.Indent }}	errs = append(errs, fmt.Sprintf("leafref {{ $check.Path }} value %v does not exist at {{ $check.TargetPath }}", {{ $check.Nav.Value }}))
+		{{ $check.Nav.Indent }}}
+{{- range $check.Nav.Close }}
+		{{ . }}
+{{- end }}
+{{- end }}
+	}
+{{- end }}
+	if len(errs) != 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+`)
+)
+
+// leafrefDirNavigation describes the generated code that visits each
+// instance of a directory within the data tree rooted at the fake root.
+type leafrefDirNavigation struct {
+	dir   *ParsedDirectory // dir is the directory that is visited.
+	expr  string           // expr is the Go expression for the instance.
+	open  []string         // open is the set of lines of code surrounding the code that handles each instance.
+	depth int              // depth is the number of blocks opened by open.
+}
+
+// leafrefTargetField is a leaf field of a directory that may be the target of
+// a leafref.
+type leafrefTargetField struct {
+	dir  *ParsedDirectory
+	name string // name is the key of the field within the Fields of dir.
+}
+
+// generateLeafrefValidation outputs to buf the validateLeafrefs method of the
+// fake root, root, which checks the values of the leafrefs within the data
+// tree using generated code that navigates the tree, along with the methods
+// that return the values of each leafref target. goStructElements is the set
+// of directories for which code is generated.
+func generateLeafrefValidation(buf io.Writer, root *ParsedDirectory, goStructElements map[string]*ParsedDirectory, goOpts GoOpts) error {
+	targets, err := leafrefTargets(root, goStructElements, goOpts)
+	if err != nil {
+		return err
+	}
+	for _, t := range targets {
+		if err := goLeafrefTargetTemplate.Execute(buf, t); err != nil {
+			return err
+		}
+	}
+	return goLeafrefValidatorTemplate.Execute(buf, struct {
+		Receiver string
+		Targets  []*generatedLeafrefTarget
+	}{
+		Receiver: root.Name,
+		Targets:  targets,
+	})
+}
+
+// leafrefTargets returns the leaves, within the data tree rooted at the fake
+// root, root, that are the targets of leafrefs, along with the leafrefs that
+// reference each of them, sorted by the name of the method generated for the
+// target. Leafrefs whose target is not within the generated code, and those
+// whose values cannot be compared without reflection, are omitted.
+func leafrefTargets(root *ParsedDirectory, goStructElements map[string]*ParsedDirectory, goOpts GoOpts) ([]*generatedLeafrefTarget, error) {
+	navs := map[string]*leafrefDirNavigation{}
+	if err := leafrefDirNavigations(&leafrefDirNavigation{dir: root, expr: "t"}, goStructElements, goOpts, navs); err != nil {
+		return nil, err
+	}
+
+	var dirPaths []string
+	for p := range navs {
+		dirPaths = append(dirPaths, p)
+	}
+	sort.Strings(dirPaths)
+
+	// Index the leaves by the schema paths that they represent. The
+	// paths of the leaves themselves take precedence over those that are
+	// mapped to them by schema compression.
+	index := map[string]*leafrefTargetField{}
+	for _, p := range dirPaths {
+		d := navs[p].dir
+		for _, fn := range d.OrderedFieldNames() {
+			if f := d.Fields[fn]; f.Type == LeafNode {
+				index[f.YANGDetails.Path] = &leafrefTargetField{dir: d, name: fn}
+			}
+		}
+	}
+	for _, p := range dirPaths {
+		d := navs[p].dir
+		for _, fn := range d.OrderedFieldNames() {
+			f := d.Fields[fn]
+			if f.Type != LeafNode {
+				continue
+			}
+			for _, mp := range append(append([][]string{}, f.MappedPaths...), f.ShadowMappedPaths...) {
+				mapped := mappedSchemaPath(d, f, mp)
+				if _, ok := index[mapped]; !ok {
+					index[mapped] = &leafrefTargetField{dir: d, name: fn}
+				}
+			}
+		}
+	}
+
+	targets := map[string]*generatedLeafrefTarget{}
+	for _, p := range dirPaths {
+		d := navs[p].dir
+		for _, fn := range d.OrderedFieldNames() {
+			f := d.Fields[fn]
+			if f.YANGDetails.LeafrefTargetPath == "" || (f.Type != LeafNode && f.Type != LeafListNode) {
+				continue
+			}
+			tf, ok := index[f.YANGDetails.LeafrefTargetPath]
+			if !ok || tf.dir.Fields[tf.name] == f {
+				// The target is either not within the generated code, or
+				// is the same field, as is the case for the keys of
+				// compressed lists.
+				continue
+			}
+			nav, ok := leafrefValueNavigation(navs[p], fn, goOpts)
+			if !ok {
+				continue
+			}
+			tpath := tf.dir.Fields[tf.name].YANGDetails.SchemaPath
+			fnName := fmt.Sprintf("leafrefTargets_%s_%s", tf.dir.Name, GoFieldNameMap(tf.dir)[tf.name])
+			t, ok := targets[fnName]
+			if !ok {
+				tnav, ok := leafrefValueNavigation(navs[tf.dir.Path], tf.name, goOpts)
+				if !ok {
+					continue
+				}
+				t = &generatedLeafrefTarget{
+					Receiver: root.Name,
+					Func:     fnName,
+					Path:     tpath,
+					Nav:      tnav,
+				}
+				targets[fnName] = t
+			}
+			t.Checks = append(t.Checks, &generatedLeafrefCheck{
+				Path:       f.YANGDetails.SchemaPath,
+				TargetPath: tpath,
+				Nav:        nav,
+			})
+		}
+	}
+
+	var names []string
+	for n := range targets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	var ts []*generatedLeafrefTarget
+	for _, n := range names {
+		ts = append(ts, targets[n])
+	}
+	return ts, nil
+}
+
+// mappedSchemaPath returns the absolute schema path, in the form of the Path
+// of the YANGNodeDetails of a field, of the path mp that the field f of the
+// directory d is mapped to.
+func mappedSchemaPath(d *ParsedDirectory, f *NodeDetails, mp []string) string {
+	if len(mp) != 0 && mp[0] == "" {
+		// Absolute mapped paths do not include the module name.
+		mod := strings.SplitN(strings.TrimPrefix(f.YANGDetails.Path, "/"), "/", 2)[0]
+		return fmt.Sprintf("/%s%s", mod, strings.Join(mp, "/"))
+	}
+	return fmt.Sprintf("%s/%s", d.Path, strings.Join(mp, "/"))
+}
+
+// leafrefDirNavigations populates navs, keyed by the path of each directory,
+// with the code that visits each instance of the directory of nav and its
+// descendant directories.
+func leafrefDirNavigations(nav *leafrefDirNavigation, goStructElements map[string]*ParsedDirectory, goOpts GoOpts, navs map[string]*leafrefDirNavigation) error {
+	dir := nav.dir
+	navs[dir.Path] = nav
+	goFieldNames := GoFieldNameMap(dir)
+	for _, fn := range dir.OrderedFieldNames() {
+		f := dir.Fields[fn]
+		if f.Type != ContainerNode && f.Type != ListNode {
+			continue
+		}
+		child, ok := goStructElements[f.YANGDetails.Path]
+		if !ok {
+			return fmt.Errorf("could not resolve %s into a defined struct", f.YANGDetails.Path)
+		}
+
+		v := fmt.Sprintf("v%d", nav.depth+1)
+		indent := strings.Repeat("\t", nav.depth)
+		field := fmt.Sprintf("%s.%s", nav.expr, goFieldNames[fn])
+		open := append([]string{}, nav.open...)
+		switch {
+		case f.Type == ContainerNode:
+			open = append(open, fmt.Sprintf("%sif %s := %s; %s != nil {", indent, v, field, v))
+		case len(child.ListKeys) != 0 && goOpts.GenerateOrderedListSupport && f.YANGDetails.OrderedByUser:
+			open = append(open, fmt.Sprintf("%sfor _, %s := range %s.Values() {", indent, v, field))
+		default:
+			open = append(open, fmt.Sprintf("%sfor _, %s := range %s {", indent, v, field))
+		}
+		valueEntries := f.Type == ListNode && len(child.ListKeys) != 0 && goOpts.ValueEntryLists && !(goOpts.GenerateOrderedListSupport && f.YANGDetails.OrderedByUser) && hasOnlyLeaves(child)
+		if f.Type == ListNode && !valueEntries {
+			open = append(open,
+				fmt.Sprintf("%s\tif %s == nil {", indent, v),
+				fmt.Sprintf("%s\t\tcontinue", indent),
+				fmt.Sprintf("%s\t}", indent))
+		}
+
+		if err := leafrefDirNavigations(&leafrefDirNavigation{dir: child, expr: v, open: open, depth: nav.depth + 1}, goStructElements, goOpts, navs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// leafrefValueNavigation returns the code that visits each value of the leaf
+// or leaf-list field with the key name within the directory of nav. It
+// returns false if the values of the field cannot be compared without
+// reflection.
+func leafrefValueNavigation(nav *leafrefDirNavigation, name string, goOpts GoOpts) (*generatedLeafrefNavigation, bool) {
+	dir := nav.dir
+	f := dir.Fields[name]
+	if f.LangType == nil {
+		return nil, false
+	}
+	switch {
+	case len(f.LangType.UnionTypes) > 1 && !goOpts.GenerateSimpleUnions:
+		// Non-simple union values are pointers to wrapper structs.
+		return nil, false
+	case f.LangType.NativeType == ygot.EmptyTypeName, f.LangType.NativeType == "interface{}":
+		return nil, false
+	}
+
+	field := fmt.Sprintf("%s.%s", nav.expr, GoFieldNameMap(dir)[name])
+	open := append([]string{}, nav.open...)
+	depth := nav.depth
+	var value string
+	switch {
+	case f.Type == LeafListNode:
+		v := fmt.Sprintf("v%d", depth+1)
+		elems := field
+		if fixedArrayLen(f, goOpts) != 0 {
+			elems = fmt.Sprintf("%s[:%sLen]", field, field)
+		}
+		open = append(open, fmt.Sprintf("%sfor _, %s := range %s {", strings.Repeat("\t", depth), v, elems))
+		depth++
+		value = v
+		if f.LangType.NativeType == ygot.BinaryTypeName {
+			value = fmt.Sprintf("string(%s)", v)
+		}
+	case isPtrField(f, dir, goOpts.NonPointerMandatoryLeaves):
+		open = append(open, fmt.Sprintf("%sif %s != nil {", strings.Repeat("\t", depth), field))
+		depth++
+		value = fmt.Sprintf("*%s", field)
+	case f.LangType.IsEnumeratedValue:
+		open = append(open, fmt.Sprintf("%sif %s != 0 {", strings.Repeat("\t", depth), field))
+		depth++
+		value = field
+	case f.LangType.NativeType == ygot.BinaryTypeName:
+		open = append(open, fmt.Sprintf("%sif %s != nil {", strings.Repeat("\t", depth), field))
+		depth++
+		value = fmt.Sprintf("string(%s)", field)
+	case len(f.LangType.UnionTypes) > 1:
+		open = append(open, fmt.Sprintf("%sif %s != nil {", strings.Repeat("\t", depth), field))
+		depth++
+		value = field
+	default:
+		// The field is a mandatory leaf that is output as a value.
+		value = field
+	}
+
+	var closing []string
+	for i := depth - 1; i >= 0; i-- {
+		closing = append(closing, fmt.Sprintf("%s}", strings.Repeat("\t", i)))
+	}
+	return &generatedLeafrefNavigation{
+		Open:   open,
+		Close:  closing,
+		Indent: strings.Repeat("\t", depth),
+		Value:  value,
+	}, true
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/ygot/genutil"
+)
+
+func TestLeafrefValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		inCompress    genutil.CompressBehaviour
+		inNoFakeRoot  bool
+		wantMethods   []string
+		wantNoMethods []string
+	}{{
+		name:       "compressed schema",
+		inCompress: genutil.PreferIntendedConfig,
+		wantMethods: []string{
+			`if err := ytypes.Validate(SchemaTree["Device"], t, append(opts[:len(opts):len(opts)], &ytypes.LeafrefOptions{IgnoreMissingData: true})...); err != nil {
+		return err
+	}
+	return t.validateLeafrefs(opts...)`,
+			`func (t *Device) leafrefTargets_Interface_Name() map[interface{}]bool {
+	values := map[interface{}]bool{}
+	for _, v1 := range t.Interface {
+		if v1 == nil {
+			continue
+		}
+		if v1.Name != nil {
+			values[*v1.Name] = true
+		}
+	}
+	return values
+}`,
+			`		targets := t.leafrefTargets_Interface_Name()
+		if v1 := t.Routing; v1 != nil {
+			if v1.RouterIdInterface != nil {
+				if !targets[*v1.RouterIdInterface] {
+					errs = append(errs, fmt.Sprintf("leafref /routing/config/router-id-interface value %v does not exist at /interfaces/interface/config/name", *v1.RouterIdInterface))
+				}
+			}
+		}
+		for _, v1 := range t.Vlan {
+			if v1 == nil {
+				continue
+			}
+			for _, v2 := range v1.MemberInterface {
+				if !targets[v2] {
+					errs = append(errs, fmt.Sprintf("leafref /vlans/vlan/config/member-interface value %v does not exist at /interfaces/interface/config/name", v2))
+				}
+			}
+		}
+	}`,
+			`leafref /routing/config/default-vlan value %v does not exist at /vlans/vlan/config/id`,
+		},
+		wantNoMethods: []string{
+			// The keys of compressed lists reference the field itself.
+			`leafref /interfaces/interface/name value`,
+		},
+	}, {
+		name: "uncompressed schema",
+		wantMethods: []string{
+			`leafref /interfaces/interface/name value %v does not exist at /interfaces/interface/config/name`,
+			`leafref /vlans/vlan/state/member-interface value %v does not exist at /interfaces/interface/name`,
+			`leafref /routing/state/default-vlan value %v does not exist at /vlans/vlan/config/id`,
+		},
+	}, {
+		name:         "no fake root",
+		inCompress:   genutil.PreferIntendedConfig,
+		inNoFakeRoot: true,
+		wantNoMethods: []string{
+			`validateLeafrefs`,
+			`leafrefTargets_`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inFiles := []string{filepath.Join(datapath, "openconfig-leafref-validation.yang")}
+			cg := NewYANGCodeGenerator(&GeneratorConfig{
+				GenerateJSONSchema: true,
+				GoOptions: GoOpts{
+					GenerateSimpleUnions:      true,
+					GenerateLeafrefValidation: true,
+				},
+				TransformationOptions: TransformationOpts{
+					CompressBehaviour:    tt.inCompress,
+					GenerateFakeRoot:     !tt.inNoFakeRoot,
+					ShortenEnumLeafNames: true,
+				},
+			})
+			gotCode, errs := cg.GenerateGoCode(inFiles, nil)
+			if errs != nil {
+				t.Fatalf("GenerateGoCode(%v): got unexpected errors: %v", inFiles, errs)
+			}
+
+			var methods strings.Builder
+			for _, s := range gotCode.Structs {
+				methods.WriteString(s.Methods)
+			}
+			for _, want := range tt.wantMethods {
+				if !strings.Contains(methods.String(), want) {
+					t.Errorf("did not find expected code in generated methods, want:\n%s\ngot:\n%s", want, methods.String())
+				}
+			}
+			for _, notWant := range tt.wantNoMethods {
+				if strings.Contains(methods.String(), notWant) {
+					t.Errorf("found unexpected code in generated methods: %s", notWant)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// enumMapFn is the filename to be used for the enum map when Go code is output to a directory.
+	enumMapFn = "enum_map.go"
+	// enumFn is the filename to be used for the enum code when Go code is output to a directory.
+	enumFn = "enum.go"
+	// schemaFn is the filename to be used for the schema code when outputting to a directory.
+	schemaFn = "schema.go"
+	// interfaceFn is the filename to be used for interface code when outputting to a directory.
+	interfaceFn = "union.go"
+	// structsFileFmt is the format string filename (missing index) to be
+	// used for files containing structs when outputting to a directory.
+	structsFileFmt = "structs-%d.go"
+)
+
+// String returns the contents of a single Go source file containing all of
+// the generated code within the GeneratedGoCode struct, such that it can be
+// written directly to a file by the caller. If EnumFile is populated, the
+// enumerated type code is omitted, and EnumFile must be written alongside
+// the returned file.
+func (g *GeneratedGoCode) String() string {
+	var b strings.Builder
+	b.WriteString(g.CommonHeader)
+	b.WriteString(g.OneOffHeader)
+
+	// Write the Structs - which are the struct definitions for the generated
+	// YANG entities, followed by the enumerations.
+	for _, snippet := range g.Structs {
+		b.WriteString(snippet.String())
+		b.WriteString("\n")
+	}
+
+	// Where the enumerated types are output to a separate file, they are
+	// omitted from the combined output.
+	enumTypeMap := g.EnumTypeMap
+	if g.EnumFile == "" {
+		for _, snippet := range g.Enums {
+			b.WriteString(snippet)
+			b.WriteString("\n")
+		}
+
+		b.WriteString(g.EnumMap)
+		b.WriteString("\n")
+	} else {
+		enumTypeMap = ""
+	}
+
+	for _, code := range []string{g.JSONSchemaCode, enumTypeMap, g.PathTypeMap, g.RootHelpers, g.InterfaceChecks} {
+		if len(code) > 0 {
+			b.WriteString(code)
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// GoCodeFiles returns a map, keyed by filename, containing the contents of
+// each Go source file that the GeneratedGoCode should be written to. The
+// methods, interfaces, and enumeration code snippets are each output into
+// their own file, and the structs are split evenly among fileN files. Each
+// file is prefixed with the CommonHeader. An error is returned if fileN is
+// not between 1 and the number of generated structs.
+func (g *GeneratedGoCode) GoCodeFiles(fileN int) (map[string][]byte, error) {
+	structN := len(g.Structs)
+	if fileN < 1 || fileN > structN {
+		return nil, fmt.Errorf("requested %d files, but must be between 1 and %d (number of schema structs)", fileN, structN)
+	}
+
+	out := map[string]string{
+		schemaFn: g.JSONSchemaCode,
+		enumFn:   strings.Join(g.Enums, "\n"),
+	}
+
+	var structFiles []string
+	var code, interfaceCode strings.Builder
+	structsPerFile := int(math.Ceil(float64(structN) / float64(fileN)))
+	// Empty files could appear with certain structN/fileN combinations due
+	// to the ceiling numbers being used for structsPerFile.
+	// e.g. 4/3 gives two files of two structs.
+	// This is a little more complex, but spreads out the structs more evenly.
+	// If we instead use the floor number, and put all remainder structs in
+	// the last file, we might double the last file's number of structs if we get unlucky.
+	// e.g. 99/10 assigns 18 structs to the last file.
+	emptyFiles := fileN - int(math.Ceil(float64(structN)/float64(structsPerFile)))
+	code.WriteString(g.OneOffHeader)
+	for i, s := range g.Structs {
+		code.WriteString(s.StructDef)
+		code.WriteString(s.ListKeys)
+		code.WriteString("\n")
+		code.WriteString(s.Methods)
+		if s.Methods != "" {
+			code.WriteString("\n")
+		}
+		interfaceCode.WriteString(s.Interfaces)
+		if s.Interfaces != "" {
+			interfaceCode.WriteString("\n")
+		}
+		// The last file contains the remainder of the structs.
+		if i == structN-1 || (i+1)%structsPerFile == 0 {
+			structFiles = append(structFiles, code.String())
+			code.Reset()
+		}
+	}
+	for i := 0; i != emptyFiles; i++ {
+		structFiles = append(structFiles, "")
+	}
+
+	for i, structFile := range structFiles {
+		out[fmt.Sprintf(structsFileFmt, i)] = structFile
+	}
+
+	code.Reset()
+	code.WriteString(g.EnumMap)
+	if code.Len() != 0 {
+		code.WriteString("\n")
+	}
+	code.WriteString(g.EnumTypeMap)
+	if len(g.PathTypeMap) != 0 {
+		code.WriteString("\n")
+		code.WriteString(g.PathTypeMap)
+	}
+
+	if len(g.RootHelpers) != 0 {
+		code.WriteString("\n")
+		code.WriteString(g.RootHelpers)
+	}
+
+	out[enumMapFn] = code.String()
+	interfaceCode.WriteString(g.InterfaceChecks)
+	out[interfaceFn] = interfaceCode.String()
+
+	files := make(map[string][]byte, len(out))
+	for name, code := range out {
+		files[name] = []byte(g.CommonHeader + code)
+	}
+
+	return files, nil
+}
+
+// WriteGoCodeFiles writes the GeneratedGoCode to the set of files returned by
+// GoCodeFiles within the directory dir, creating or truncating each file. An
+// error is returned if the directory does not exist, or if any file cannot be
+// written, in which case an unspecified subset of the files may have been
+// written.
+func (g *GeneratedGoCode) WriteGoCodeFiles(dir string, fileN int) error {
+	files, err := g.GoCodeFiles(fileN)
+	if err != nil {
+		return err
+	}
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), contents, 0644); err != nil {
+			return fmt.Errorf("could not write file %q: %v", name, err)
+		}
+	}
+	return nil
+}
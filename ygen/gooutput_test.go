@@ -0,0 +1,431 @@
+// Copyright 2021 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/ygot/genutil"
+)
+
+// generateSimpleGoCode generates Go code for the openconfig-simple test
+// module, failing the test if generation is unsuccessful.
+func generateSimpleGoCode(t *testing.T) *GeneratedGoCode {
+	t.Helper()
+	return generateSimpleGoCodeWithOpts(t, GoOpts{})
+}
+
+// generateSimpleGoCodeWithOpts generates Go code for the openconfig-simple
+// test module in the same manner as generateSimpleGoCode, additionally
+// setting the GoOpts fields that are set within opts.
+func generateSimpleGoCodeWithOpts(t *testing.T, opts GoOpts) *GeneratedGoCode {
+	t.Helper()
+	opts.GenerateSimpleUnions = true
+	opts.GenerateLeafGetters = true
+	cg := NewYANGCodeGenerator(&GeneratorConfig{
+		GoOptions: opts,
+		TransformationOptions: TransformationOpts{
+			CompressBehaviour: genutil.PreferIntendedConfig,
+		},
+		GenerateJSONSchema: true,
+	})
+	code, errs := cg.GenerateGoCode([]string{filepath.Join(datapath, "openconfig-simple.yang")}, nil)
+	if errs != nil {
+		t.Fatalf("GenerateGoCode: got unexpected errors: %v", errs)
+	}
+	return code
+}
+
+func TestGeneratedGoCodeString(t *testing.T) {
+	code := generateSimpleGoCode(t)
+
+	var want bytes.Buffer
+	fmt.Fprint(&want, code.CommonHeader)
+	fmt.Fprint(&want, code.OneOffHeader)
+	for _, s := range code.Structs {
+		fmt.Fprintln(&want, s.String())
+	}
+	for _, e := range code.Enums {
+		fmt.Fprintln(&want, e)
+	}
+	fmt.Fprintln(&want, code.EnumMap)
+	fmt.Fprintln(&want, code.JSONSchemaCode)
+	fmt.Fprintln(&want, code.EnumTypeMap)
+
+	if diff := cmp.Diff(want.String(), code.String()); diff != "" {
+		t.Errorf("String(): did not get expected output, diff (-want, +got):\n%s", diff)
+	}
+}
+
+// topLevelDecls parses the Go source file src, and returns the source code of
+// each of its top-level declarations other than imports, sorted.
+func topLevelDecls(t *testing.T, src string) []string {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		t.Fatalf("cannot parse generated code: %v\n%s", err, src)
+	}
+	var decls []string
+	for _, d := range f.Decls {
+		if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			continue
+		}
+		decls = append(decls, src[fset.Position(d.Pos()).Offset:fset.Position(d.End()).Offset])
+	}
+	sort.Strings(decls)
+	return decls
+}
+
+func TestGeneratedGoCodeSeparateEnumFile(t *testing.T) {
+	combined := generateSimpleGoCode(t)
+	split := generateSimpleGoCodeWithOpts(t, GoOpts{SeparateEnumFile: true})
+
+	if combined.EnumFile != "" {
+		t.Errorf("EnumFile: got %q, want empty string when SeparateEnumFile is unset", combined.EnumFile)
+	}
+	if split.EnumFile == "" {
+		t.Fatalf("EnumFile: got empty string, want enum file when SeparateEnumFile is set")
+	}
+
+	for _, e := range combined.Enums {
+		if strings.Contains(split.String(), e) {
+			t.Errorf("String(): got output containing enum %s, want it only in EnumFile", e)
+		}
+	}
+
+	want := topLevelDecls(t, combined.String())
+	got := append(topLevelDecls(t, split.String()), topLevelDecls(t, split.EnumFile)...)
+	sort.Strings(got)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("split files did not contain the same declarations as the combined file, diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestGoCodeFiles(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               *GeneratedGoCode
+		inFileN          int
+		want             map[string]string
+		wantErrSubstring string
+	}{{
+		name: "simple struct with all only structs populated",
+		in: &GeneratedGoCode{
+			CommonHeader: "common_header\n",
+			OneOffHeader: "oneoff_header\n",
+			Structs: []GoStructCodeSnippet{{
+				StructName: "name",
+				StructDef:  "def\n",
+				ListKeys:   "name_key",
+				Methods:    "methods",
+				Interfaces: "interfaces",
+			}},
+		},
+		inFileN: 1,
+		want: map[string]string{
+			enumMapFn:                      "common_header\n",
+			enumFn:                         "common_header\n",
+			schemaFn:                       "common_header\n",
+			interfaceFn:                    "common_header\ninterfaces\n",
+			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ndef\nname_key\nmethods\n",
+		},
+	}, {
+		name: "less than 1 file requested for splitting",
+		in: &GeneratedGoCode{
+			CommonHeader: "common_header\n",
+			OneOffHeader: "oneoff_header\n",
+			Structs: []GoStructCodeSnippet{{
+				StructName: "name",
+				StructDef:  "def\n",
+				ListKeys:   "name_key",
+				Methods:    "methods",
+				Interfaces: "interfaces",
+			}},
+		},
+		inFileN:          0,
+		wantErrSubstring: "requested 0 files",
+	}, {
+		name: "more than # of structs files requested for splitting",
+		in: &GeneratedGoCode{
+			CommonHeader: "common_header\n",
+			OneOffHeader: "oneoff_header\n",
+			Structs: []GoStructCodeSnippet{{
+				StructName: "name",
+				StructDef:  "def\n",
+				ListKeys:   "name_key",
+				Methods:    "methods",
+				Interfaces: "interfaces",
+			}},
+		},
+		inFileN:          2,
+		wantErrSubstring: "requested 2 files",
+	}, {
+		name: "two structs with enums populated",
+		in: &GeneratedGoCode{
+			CommonHeader: "common_header\n",
+			OneOffHeader: "oneoff_header\n",
+			Structs: []GoStructCodeSnippet{{
+				StructName: "s1",
+				StructDef:  "s1def\n",
+				ListKeys:   "s1key",
+				Methods:    "s1methods",
+				Interfaces: "s1interfaces",
+			}, {
+				StructName: "s2",
+				StructDef:  "s2def\n",
+				ListKeys:   "s2key",
+				Methods:    "s2methods",
+				Interfaces: "s2interfaces",
+			}},
+			Enums:   []string{"enum1", "enum2"},
+			EnumMap: "enummap",
+		},
+		inFileN: 1,
+		want: map[string]string{
+			enumMapFn:                      "common_header\nenummap\n",
+			enumFn:                         "common_header\nenum1\nenum2",
+			schemaFn:                       "common_header\n",
+			interfaceFn:                    "common_header\ns1interfaces\ns2interfaces\n",
+			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ns1def\ns1key\ns1methods\ns2def\ns2key\ns2methods\n",
+		},
+	}, {
+		name: "two structs, separated into two files",
+		in: &GeneratedGoCode{
+			CommonHeader: "common_header\n",
+			OneOffHeader: "oneoff_header\n",
+			Structs: []GoStructCodeSnippet{{
+				StructName: "s1",
+				StructDef:  "s1def\n",
+				ListKeys:   "s1key",
+				Methods:    "s1methods",
+				Interfaces: "s1interfaces",
+			}, {
+				StructName: "q2",
+				StructDef:  "q2def\n",
+				ListKeys:   "q2key",
+				Methods:    "q2methods",
+				Interfaces: "q2interfaces",
+			}},
+			JSONSchemaCode: "schema",
+		},
+		inFileN: 2,
+		want: map[string]string{
+			enumMapFn:                      "common_header\n",
+			enumFn:                         "common_header\n",
+			schemaFn:                       "common_header\nschema",
+			interfaceFn:                    "common_header\ns1interfaces\nq2interfaces\n",
+			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ns1def\ns1key\ns1methods\n",
+			fmt.Sprintf(structsFileFmt, 1): "common_header\nq2def\nq2key\nq2methods\n",
+		},
+	}, {
+		name: "five structs, separated into four files",
+		in: &GeneratedGoCode{
+			CommonHeader: "common_header\n",
+			OneOffHeader: "oneoff_header\n",
+			Structs: []GoStructCodeSnippet{{
+				StructName: "s1",
+				StructDef:  "s1def\n",
+				ListKeys:   "s1key",
+				Methods:    "s1methods",
+				Interfaces: "s1interfaces",
+			}, {
+				StructName: "s2",
+				StructDef:  "s2def\n",
+				ListKeys:   "s2key",
+				Methods:    "s2methods",
+				Interfaces: "s2interfaces",
+			}, {
+				StructName: "s3",
+				StructDef:  "s3def\n",
+				ListKeys:   "s3key",
+			}, {
+				StructName: "s4",
+				StructDef:  "s4def\n",
+				ListKeys:   "s4key",
+			}, {
+				StructName: "s5",
+				StructDef:  "s5def\n",
+				ListKeys:   "s5key",
+			}},
+			JSONSchemaCode: "schema",
+		},
+		inFileN: 4,
+		want: map[string]string{
+			enumMapFn:                      "common_header\n",
+			enumFn:                         "common_header\n",
+			schemaFn:                       "common_header\nschema",
+			interfaceFn:                    "common_header\ns1interfaces\ns2interfaces\n",
+			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ns1def\ns1key\ns1methods\ns2def\ns2key\ns2methods\n",
+			fmt.Sprintf(structsFileFmt, 1): "common_header\ns3def\ns3key\ns4def\ns4key\n",
+			fmt.Sprintf(structsFileFmt, 2): "common_header\ns5def\ns5key\n",
+			fmt.Sprintf(structsFileFmt, 3): "common_header\n",
+		},
+	}, {
+		name: "five structs, separated into three files",
+		in: &GeneratedGoCode{
+			CommonHeader: "common_header\n",
+			OneOffHeader: "oneoff_header\n",
+			Structs: []GoStructCodeSnippet{{
+				StructName: "s1",
+				StructDef:  "s1def\n",
+				ListKeys:   "s1key",
+				Methods:    "s1methods",
+				Interfaces: "s1interfaces",
+			}, {
+				StructName: "s2",
+				StructDef:  "s2def\n",
+				ListKeys:   "s2key",
+				Methods:    "s2methods",
+				Interfaces: "s2interfaces",
+			}, {
+				StructName: "s3",
+				StructDef:  "s3def\n",
+				ListKeys:   "s3key",
+			}, {
+				StructName: "s4",
+				StructDef:  "s4def\n",
+				ListKeys:   "s4key",
+			}, {
+				StructName: "s5",
+				StructDef:  "s5def\n",
+				ListKeys:   "s5key",
+			}},
+			JSONSchemaCode: "schema",
+		},
+		inFileN: 3,
+		want: map[string]string{
+			enumMapFn:                      "common_header\n",
+			enumFn:                         "common_header\n",
+			schemaFn:                       "common_header\nschema",
+			interfaceFn:                    "common_header\ns1interfaces\ns2interfaces\n",
+			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ns1def\ns1key\ns1methods\ns2def\ns2key\ns2methods\n",
+			fmt.Sprintf(structsFileFmt, 1): "common_header\ns3def\ns3key\ns4def\ns4key\n",
+			fmt.Sprintf(structsFileFmt, 2): "common_header\ns5def\ns5key\n",
+		},
+	}, {
+		name: "five structs, separated into two files",
+		in: &GeneratedGoCode{
+			CommonHeader: "common_header\n",
+			OneOffHeader: "oneoff_header\n",
+			Structs: []GoStructCodeSnippet{{
+				StructName: "s1",
+				StructDef:  "s1def\n",
+				ListKeys:   "s1key",
+				Methods:    "s1methods",
+				Interfaces: "s1interfaces",
+			}, {
+				StructName: "s2",
+				StructDef:  "s2def\n",
+				ListKeys:   "s2key",
+				Methods:    "s2methods",
+				Interfaces: "s2interfaces",
+			}, {
+				StructName: "s3",
+				StructDef:  "s3def\n",
+				ListKeys:   "s3key",
+			}, {
+				StructName: "s4",
+				StructDef:  "s4def\n",
+				ListKeys:   "s4key",
+			}, {
+				StructName: "s5",
+				StructDef:  "s5def\n",
+				ListKeys:   "s5key",
+			}},
+			JSONSchemaCode: "schema",
+		},
+		inFileN: 2,
+		want: map[string]string{
+			enumMapFn:                      "common_header\n",
+			enumFn:                         "common_header\n",
+			schemaFn:                       "common_header\nschema",
+			interfaceFn:                    "common_header\ns1interfaces\ns2interfaces\n",
+			fmt.Sprintf(structsFileFmt, 0): "common_header\noneoff_header\ns1def\ns1key\ns1methods\ns2def\ns2key\ns2methods\ns3def\ns3key\n",
+			fmt.Sprintf(structsFileFmt, 1): "common_header\ns4def\ns4key\ns5def\ns5key\n",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := tt.in.GoCodeFiles(tt.inFileN)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %v", diff)
+			}
+			var got map[string]string
+			if files != nil {
+				got = map[string]string{}
+				for name, contents := range files {
+					got[name] = string(contents)
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GoCodeFiles(%d): did not get expected output, diff (-want, +got):\n%s", tt.inFileN, diff)
+			}
+		})
+	}
+}
+
+func TestWriteGoCodeFiles(t *testing.T) {
+	code := generateSimpleGoCode(t)
+
+	want, err := code.GoCodeFiles(2)
+	if err != nil {
+		t.Fatalf("GoCodeFiles(2): got unexpected error: %v", err)
+	}
+
+	dir, err := ioutil.TempDir("", "ygot-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := code.WriteGoCodeFiles(dir, 2); err != nil {
+		t.Fatalf("WriteGoCodeFiles(%s, 2): got unexpected error: %v", dir, err)
+	}
+
+	got := map[string][]byte{}
+	fs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range fs {
+		contents, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[f.Name()] = contents
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WriteGoCodeFiles(%s, 2): did not get expected files, diff (-want, +got):\n%s", dir, diff)
+	}
+
+	if err := code.WriteGoCodeFiles(filepath.Join(dir, "does-not-exist"), 1); err == nil {
+		t.Errorf("WriteGoCodeFiles: did not get expected error for non-existent directory")
+	}
+}
@@ -180,6 +180,21 @@ func (ir *IR) SchemaTree(inclDescriptions bool) ([]byte, error) {
 	return rawSchema, nil
 }
 
+// SchemaTreePerModule returns the JSON serialised tree of the schema for the
+// set of modules used to generate the IR, split into one document per YANG
+// module and keyed by the name of the module. Each document is rooted on the
+// same root entry as the document returned by SchemaTree, and contains only
+// the entries that are instantiated by the module, along with their
+// ancestors. Merging the trees of all documents produces the tree returned by
+// SchemaTree.
+func (ir *IR) SchemaTreePerModule(inclDescriptions bool) (map[string][]byte, error) {
+	dirNames := make(map[string]string, len(ir.Directories))
+	for p, d := range ir.Directories {
+		dirNames[p] = d.Name
+	}
+	return buildJSONTreePerModule(ir.parsedModules, dirNames, ir.fakeroot, ir.opts.TransformationOptions.CompressBehaviour.CompressEnabled(), inclDescriptions)
+}
+
 // ParsedDirectory describes an internal node within the generated
 // code. Such a 'directory' may represent a struct, or a message,
 // in the generated code. It represents a YANG 'container' or 'list'.
@@ -245,12 +260,20 @@ type ParsedDirectory struct {
 	// DefiningModule is the module that contains the text definition of
 	// the field.
 	DefiningModule string
+	// DefiningModuleRevision is the most recent revision date specified
+	// by the revision statements of DefiningModule. It is empty if the
+	// module does not contain any revision statements.
+	DefiningModuleRevision string
 	// ConfigFalse represents whether the node is state data as opposed to
 	// configuration data.
 	// The meaning of "config" is exactly the same as the "config"
 	// statement in YANG:
 	// https://datatracker.ietf.org/doc/html/rfc7950#section-7.21.1
 	ConfigFalse bool
+	// Status is the argument of the status statement of the node (i.e.,
+	// current, deprecated or obsolete). It is empty if the node does not
+	// have a status statement.
+	Status string
 }
 
 // OrderedFieldNames returns the YANG name of all fields belonging to the
@@ -480,6 +503,36 @@ type YANGNodeDetails struct {
 	PresenceStatement *string
 	// Description contains the description of the node.
 	Description string
+	// ChoiceMembership contains the names of the choice and case
+	// statements that enclose the node within its parent, from outermost
+	// to innermost, each separated by "/" (e.g., addr-type/ipv4). It is
+	// populated only when the TrackChoiceMembership transformation option
+	// is set, and is empty when the node is not within a choice.
+	ChoiceMembership string
+	// Mandatory indicates whether the node is marked as mandatory within
+	// the YANG schema.
+	Mandatory bool
+	// MinElements is the value of the min-elements statement of a list or
+	// leaf-list node. It is zero where the statement is not specified.
+	MinElements uint64
+	// MaxElements is the value of the max-elements statement of a list or
+	// leaf-list node. It is zero where the number of elements is not
+	// bounded.
+	MaxElements uint64
+	// OrderedByUser indicates that the node is a list or leaf-list whose
+	// entries are ordered by the user, as specified by an "ordered-by
+	// user" statement, rather than by the system.
+	OrderedByUser bool
+	// Must contains the XPath expressions of the must statements of the
+	// node, in the order in which they are specified in the schema.
+	Must []string
+	// When is the XPath expression of the when statement of the node. It
+	// is empty if the node has no when statement.
+	When string
+	// Status is the argument of the status statement of the node (i.e.,
+	// current, deprecated or obsolete). It is empty if the node does not
+	// have a status statement.
+	Status string
 	// Type is the YANG type which represents the node. It is only
 	// applicable for leaf or leaf-list nodes because only these nodes can
 	// have type statements.
@@ -491,6 +544,19 @@ type YANGNodeDetails struct {
 type YANGType struct {
 	// Name is the YANG type name of the type.
 	Name string
+	// Range is the set of ranges to which the values of an integer type
+	// are restricted. It is nil if the type is not an integer type, or if
+	// its values are not restricted beyond those of its built-in type.
+	Range yang.YangRange
+	// Length is the set of ranges to which the length of a string or
+	// binary type is restricted. It is nil if the type is not a string or
+	// binary type, or if its length is not restricted.
+	Length yang.YangRange
+	// Pattern is the set of regular expressions, each of which the values
+	// of a string type must match. The expressions are anchored such that
+	// they match the entire value. It is nil if the type is not a string
+	// type, or if its values are not restricted by a pattern.
+	Pattern []string
 	// TODO(wenbli): Add this.
 	// Module is the name of the module which defined the type. This is
 	// only applicable if the type were a typedef.
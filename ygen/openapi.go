@@ -0,0 +1,267 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/genutil"
+	"github.com/openconfig/ygot/util"
+)
+
+// openAPISchemaDialect is the JSON Schema dialect of the documents that are
+// output by OpenAPISchema.
+const openAPISchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// OpenAPISchema returns a JSON Schema (draft 2020-12) document, suitable for
+// use within an OpenAPI specification, that describes the RFC7951 JSON
+// encoding of the data tree of the set of modules used to generate the IR.
+// Unlike the document returned by SchemaTree, which is used by the ygot
+// libraries at runtime, the document describes the data tree itself, and hence
+// is unaffected by schema compression.
+func (ir *IR) OpenAPISchema() ([]byte, error) {
+	props, required, err := openAPIProperties(ir.parsedModules, "")
+	if err != nil {
+		return nil, err
+	}
+	doc := map[string]interface{}{
+		"$schema":    openAPISchemaDialect,
+		"type":       "object",
+		"properties": props,
+	}
+	if len(required) != 0 {
+		doc["required"] = required
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// openAPIProperties returns the JSON Schema properties describing the data
+// tree children of each of the supplied parent entries, along with the names
+// of those children that are mandatory. The names of the properties are
+// qualified with the name of the module that instantiates them if it differs
+// from the supplied module, as per RFC7951.
+func openAPIProperties(parents []*yang.Entry, module string) (map[string]interface{}, []string, error) {
+	props := map[string]interface{}{}
+	var required []string
+	for _, p := range parents {
+		for _, ch := range util.FindFirstNonChoiceOrCase(p) {
+			if ch.RPC != nil || ch.Kind == yang.NotificationEntry {
+				continue
+			}
+			chMod, err := ch.InstantiatingModule()
+			if err != nil {
+				return nil, nil, fmt.Errorf("cannot find instantiating module for %s: %v", ch.Path(), err)
+			}
+			s, err := openAPIEntrySchema(ch, chMod)
+			if err != nil {
+				return nil, nil, err
+			}
+			name := ch.Name
+			if chMod != module {
+				name = fmt.Sprintf("%s:%s", chMod, ch.Name)
+			}
+			props[name] = s
+			if ch.Mandatory == yang.TSTrue {
+				required = append(required, name)
+			}
+		}
+	}
+	sort.Strings(required)
+	return props, required, nil
+}
+
+// openAPIEntrySchema returns the JSON Schema describing the RFC7951 JSON
+// encoding of the yang.Entry e, which is instantiated by the supplied module.
+func openAPIEntrySchema(e *yang.Entry, module string) (map[string]interface{}, error) {
+	var s map[string]interface{}
+	switch {
+	case e.IsDir():
+		props, required, err := openAPIProperties([]*yang.Entry{e}, module)
+		if err != nil {
+			return nil, err
+		}
+		obj := map[string]interface{}{
+			"type":       "object",
+			"properties": props,
+		}
+		if e.IsList() {
+			// Each key of the list must be present within each list member.
+			required = append(required, strings.Fields(e.Key)...)
+			sort.Strings(required)
+		}
+		if len(required) != 0 {
+			obj["required"] = required
+		}
+		s = obj
+		if e.IsList() {
+			s = map[string]interface{}{
+				"type":  "array",
+				"items": obj,
+			}
+		}
+	case e.IsLeafList():
+		items, err := openAPITypeSchema(e, e.Type)
+		if err != nil {
+			return nil, err
+		}
+		s = map[string]interface{}{
+			"type":  "array",
+			"items": items,
+		}
+	default:
+		var err error
+		if s, err = openAPITypeSchema(e, e.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	if e.Description != "" {
+		s["description"] = e.Description
+	}
+	if e.ReadOnly() {
+		s["readOnly"] = true
+	}
+	return s, nil
+}
+
+// openAPITypeSchema returns the JSON Schema describing the RFC7951 JSON
+// encoding of a value of the YANG type t, which is the type of the leaf or
+// leaf-list e. Length restrictions are output only where the type has a single
+// length range.
+func openAPITypeSchema(e *yang.Entry, t *yang.YangType) (map[string]interface{}, error) {
+	if t == nil {
+		return nil, fmt.Errorf("nil type for %s", e.Path())
+	}
+
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		s := map[string]interface{}{"type": "integer"}
+		r := t.Range
+		if len(r) == 0 {
+			r = builtinIntegerRanges[t.Kind]
+		}
+		switch {
+		case len(r) == 1:
+			s["minimum"] = json.Number(r[0].Min.String())
+			s["maximum"] = json.Number(r[0].Max.String())
+		case len(r) > 1:
+			var ranges []interface{}
+			for _, yr := range r {
+				ranges = append(ranges, map[string]interface{}{
+					"minimum": json.Number(yr.Min.String()),
+					"maximum": json.Number(yr.Max.String()),
+				})
+			}
+			s["anyOf"] = ranges
+		}
+		return s, nil
+	case yang.Yint64, yang.Yuint64, yang.Ydecimal64:
+		// RFC7951 encodes 64-bit numeric values as strings.
+		return map[string]interface{}{
+			"type":   "string",
+			"format": yang.TypeKindToName[t.Kind],
+		}, nil
+	case yang.Ystring:
+		s := map[string]interface{}{"type": "string"}
+		if l := restrictedLength(t); len(l) == 1 {
+			if l[0].Min.Value != 0 {
+				s["minLength"] = json.Number(l[0].Min.String())
+			}
+			if l[0].Max.Value != math.MaxUint64 {
+				s["maxLength"] = json.Number(l[0].Max.String())
+			}
+		}
+		switch pats := restrictedPattern(t); {
+		case len(pats) == 1:
+			s["pattern"] = pats[0]
+		case len(pats) > 1:
+			var all []interface{}
+			for _, p := range pats {
+				all = append(all, map[string]interface{}{"pattern": p})
+			}
+			s["allOf"] = all
+		}
+		return s, nil
+	case yang.Ybinary:
+		return map[string]interface{}{
+			"type":            "string",
+			"contentEncoding": "base64",
+		}, nil
+	case yang.Ybool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case yang.Yempty:
+		// RFC7951 encodes an empty value as [null].
+		return map[string]interface{}{
+			"type":     "array",
+			"items":    map[string]interface{}{"type": "null"},
+			"minItems": 1,
+			"maxItems": 1,
+		}, nil
+	case yang.Yenum:
+		if t.Enum == nil {
+			return nil, fmt.Errorf("enumeration with no values for %s", e.Path())
+		}
+		var values []int64
+		for v := range t.Enum.ValueMap() {
+			values = append(values, v)
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		var names []string
+		for _, v := range values {
+			names = append(names, t.Enum.ValueMap()[v])
+		}
+		return map[string]interface{}{
+			"type": "string",
+			"enum": names,
+		}, nil
+	case yang.Yidentityref:
+		if t.IdentityBase == nil {
+			return nil, fmt.Errorf("identityref with nil base for %s", e.Path())
+		}
+		var names []string
+		for _, v := range t.IdentityBase.Values {
+			names = append(names, fmt.Sprintf("%s:%s", genutil.ParentModuleName(v), v.Name))
+		}
+		sort.Strings(names)
+		return map[string]interface{}{
+			"type": "string",
+			"enum": names,
+		}, nil
+	case yang.Yunion:
+		var types []interface{}
+		for _, st := range t.Type {
+			s, err := openAPITypeSchema(e, st)
+			if err != nil {
+				return nil, err
+			}
+			types = append(types, s)
+		}
+		return map[string]interface{}{"anyOf": types}, nil
+	case yang.Yleafref:
+		target, err := util.FindLeafRefSchema(e, t.Path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve leafref %s for %s: %v", t.Path, e.Path(), err)
+		}
+		return openAPITypeSchema(target, target.Type)
+	case yang.YinstanceIdentifier, yang.Ybits:
+		return map[string]interface{}{"type": "string"}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %v for %s", t.Kind, e.Path())
+}
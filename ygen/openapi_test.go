@@ -0,0 +1,106 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygen
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygot/genutil"
+)
+
+func TestOpenAPISchema(t *testing.T) {
+	tests := []struct {
+		name           string
+		inFiles        []string
+		inCompress     genutil.CompressBehaviour
+		wantSubschemas map[string]string
+	}{{
+		name:    "simple openconfig module",
+		inFiles: []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		wantSubschemas: map[string]string{
+			"$schema": `"https://json-schema.org/draft/2020-12/schema"`,
+			"type":    `"object"`,
+			"properties/openconfig-simple:parent/properties/child/properties/config/properties/one":   `{"type": "string"}`,
+			"properties/openconfig-simple:parent/properties/child/properties/config/properties/three": `{"type": "string", "enum": ["ONE", "TWO"]}`,
+			"properties/openconfig-simple:parent/properties/child/properties/config/properties/four":  `{"type": "string", "contentEncoding": "base64"}`,
+			"properties/openconfig-simple:parent/properties/child/properties/state/properties/two":    `{"type": "string", "readOnly": true}`,
+			"properties/openconfig-simple:remote-container/properties/config/properties/a-leaf":       `{"type": "string"}`,
+		},
+	}, {
+		name:       "simple openconfig module, unaffected by compression",
+		inFiles:    []string{filepath.Join(datapath, "openconfig-simple.yang")},
+		inCompress: genutil.PreferIntendedConfig,
+		wantSubschemas: map[string]string{
+			"properties/openconfig-simple:parent/properties/child/properties/config/properties/three": `{"type": "string", "enum": ["ONE", "TWO"]}`,
+			"properties/openconfig-simple:parent/properties/child/properties/state/properties/three":  `{"type": "string", "enum": ["ONE", "TWO"], "readOnly": true}`,
+		},
+	}, {
+		name:    "integer ranges, 64-bit values, and lists",
+		inFiles: []string{filepath.Join(datapath, "openconfig-ranges.yang")},
+		wantSubschemas: map[string]string{
+			"properties/openconfig-ranges:system/properties/config/properties/mtu":                         `{"type": "integer", "minimum": 68, "maximum": 9216}`,
+			"properties/openconfig-ranges:system/properties/config/properties/level":                       `{"type": "integer", "anyOf": [{"minimum": -10, "maximum": -1}, {"minimum": 1, "maximum": 10}]}`,
+			"properties/openconfig-ranges:system/properties/config/properties/answer":                      `{"type": "integer", "minimum": 42, "maximum": 42}`,
+			"properties/openconfig-ranges:system/properties/config/properties/counter":                     `{"type": "string", "format": "uint64"}`,
+			"properties/openconfig-ranges:system/properties/ports/properties/port/type":                    `"array"`,
+			"properties/openconfig-ranges:system/properties/ports/properties/port/items/required":          `["number"]`,
+			"properties/openconfig-ranges:system/properties/ports/properties/port/items/properties/number": `{"type": "integer", "minimum": 1, "maximum": 65535}`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewYANGCodeGenerator(&GeneratorConfig{
+				GenerateOpenAPISchema: true,
+				TransformationOptions: TransformationOpts{
+					CompressBehaviour: tt.inCompress,
+				},
+			})
+			gotCode, errs := cg.GenerateGoCode(tt.inFiles, nil)
+			if errs != nil {
+				t.Fatalf("GenerateGoCode(%v): got unexpected errors: %v", tt.inFiles, errs)
+			}
+
+			var got interface{}
+			if err := json.Unmarshal(gotCode.OpenAPISchema, &got); err != nil {
+				t.Fatalf("cannot unmarshal OpenAPI schema, %v", err)
+			}
+
+			for path, wantJSON := range tt.wantSubschemas {
+				var want interface{}
+				if err := json.Unmarshal([]byte(wantJSON), &want); err != nil {
+					t.Fatalf("cannot unmarshal wanted subschema for %s, %v", path, err)
+				}
+
+				gotSub := got
+				for _, p := range strings.Split(path, "/") {
+					m, ok := gotSub.(map[string]interface{})
+					if !ok {
+						t.Fatalf("%s: %s is not within an object in the OpenAPI schema, got: %v", path, p, gotSub)
+					}
+					gotSub = m[p]
+				}
+
+				if diff := cmp.Diff(want, gotSub); diff != "" {
+					t.Errorf("%s: did not get expected subschema, (-want +got):\n%s", path, diff)
+				}
+			}
+		})
+	}
+}
@@ -57,6 +57,16 @@ type ProtoLangMapper struct {
 	// enumPackageName is the name of the package within which global enumerated values
 	// are defined (i.e., typedefs that contain enumerations, or YANG identities).
 	enumPackageName string
+	// identifierSanitizer, if non-nil, is used to sanitize the YANG name
+	// of each element prior to it being converted to a protobuf name.
+	identifierSanitizer func(string) string
+	// wellKnownTypes is a map, keyed by the name of a YANG typedef, of the
+	// fully-qualified google.protobuf well-known type that leaves of the
+	// typedef are mapped to.
+	wellKnownTypes map[string]string
+	// narrowIntegers specifies whether YANG integer types of 32 bits or
+	// narrower are mapped to 32-bit protobuf scalar types.
+	narrowIntegers bool
 }
 
 // NewProtoLangMapper creates a new ProtoLangMapper instance, initialised with the
@@ -84,7 +94,7 @@ func (s *ProtoLangMapper) DirectoryName(e *yang.Entry, cb genutil.CompressBehavi
 // Since this conversion is lossy, a later step should resolve any naming
 // conflicts between different fields.
 func (s *ProtoLangMapper) FieldName(e *yang.Entry) (string, error) {
-	return safeProtoIdentifierName(e.Name), nil
+	return safeProtoIdentifierName(s.sanitizedName(e)), nil
 }
 
 // LeafType maps the input leaf entry to a MappedType object containing the
@@ -194,6 +204,49 @@ func (s *ProtoLangMapper) SetSchemaTree(st *schemaTree) {
 	s.schematree = st
 }
 
+// SetIdentifierSanitizer is used to supply a function that is applied to
+// the YANG name of each schema element prior to it being used to generate
+// the name of a protobuf message, field or package.
+func (s *ProtoLangMapper) SetIdentifierSanitizer(sanitize func(string) string) {
+	s.identifierSanitizer = sanitize
+}
+
+// SetWellKnownTypeMap is used to supply a map, keyed by the name of a YANG
+// typedef, of the google.protobuf well-known type (e.g., Timestamp) that leaves
+// of the typedef should be mapped to. An error is returned if any of the
+// well-known types are not supported.
+func (s *ProtoLangMapper) SetWellKnownTypeMap(m map[string]string) error {
+	wkt := map[string]string{}
+	for typedef, t := range m {
+		fqt := t
+		if !strings.HasPrefix(fqt, protoWellKnownTypePrefix) {
+			fqt = protoWellKnownTypePrefix + t
+		}
+		if _, ok := protoWellKnownTypeImports[fqt]; !ok {
+			return fmt.Errorf("unsupported well-known type %s for typedef %s", t, typedef)
+		}
+		wkt[typedef] = fqt
+	}
+	s.wellKnownTypes = wkt
+	return nil
+}
+
+// SetNarrowIntegers is used to specify whether YANG integer types that are
+// 32 bits wide or narrower should be mapped to the sint32 and uint32 protobuf
+// scalar types, rather than to sint64 and uint64.
+func (s *ProtoLangMapper) SetNarrowIntegers(narrow bool) {
+	s.narrowIntegers = narrow
+}
+
+// sanitizedName returns the YANG name of the supplied entry, after the
+// identifier sanitizer has been applied to it, if one is set.
+func (s *ProtoLangMapper) sanitizedName(e *yang.Entry) string {
+	if s.identifierSanitizer == nil {
+		return e.Name
+	}
+	return s.identifierSanitizer(e.Name)
+}
+
 // resolveProtoTypeArgs specifies input parameters required for resolving types
 // from YANG to protobuf.
 // TODO(robjs): Consider embedding resolveProtoTypeArgs in this struct per
@@ -266,6 +319,10 @@ func (s *ProtoLangMapper) yangTypeToProtoType(args resolveTypeArgs, pargs resolv
 		return mtype, nil
 	}
 
+	if wkt, ok := s.wellKnownTypes[args.yangType.Name]; ok {
+		return &MappedType{NativeType: wkt}, nil
+	}
+
 	switch args.yangType.Kind {
 	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
 		return &MappedType{NativeType: ywrapperAccessor + "IntValue"}, nil
@@ -277,6 +334,10 @@ func (s *ProtoLangMapper) yangTypeToProtoType(args resolveTypeArgs, pargs resolv
 		return &MappedType{NativeType: ywrapperAccessor + "BoolValue"}, nil
 	case yang.Ystring:
 		return &MappedType{NativeType: ywrapperAccessor + "StringValue"}, nil
+	case yang.YinstanceIdentifier:
+		// Instance identifiers are represented by the string form of the
+		// path that they identify, as per their RFC7951 JSON encoding.
+		return &MappedType{NativeType: ywrapperAccessor + "StringValue"}, nil
 	case yang.Ydecimal64:
 		return &MappedType{NativeType: ywrapperAccessor + "Decimal64Value"}, nil
 	case yang.Yleafref:
@@ -286,13 +347,18 @@ func (s *ProtoLangMapper) yangTypeToProtoType(args resolveTypeArgs, pargs resolv
 		if err != nil {
 			return nil, err
 		}
-		return s.yangTypeToProtoType(resolveTypeArgs{yangType: target.Type, contextEntry: target}, pargs, opts)
+		mtype, err := s.yangTypeToProtoType(resolveTypeArgs{yangType: target.Type, contextEntry: target}, pargs, opts)
+		if err != nil {
+			return nil, err
+		}
+		setResolvedLeafrefType(mtype)
+		return mtype, nil
 	case yang.Yenum:
 		mtype, err := yangEnumTypeToProtoType(args)
 		if err != nil {
 			return nil, err
 		}
-		_, key, err := s.enumSet.enumName(args.contextEntry, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.ParseOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, false, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+		_, key, err := s.enumSet.enumName(args.contextEntry, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.ParseOptions.skipEnumDedup(), opts.TransformationOptions.ShortenEnumLeafNames, false, opts.TransformationOptions.EnumOrgPrefixesToTrim)
 		if err != nil {
 			return nil, err
 		}
@@ -341,15 +407,25 @@ func (s *ProtoLangMapper) yangTypeToProtoScalarType(args resolveTypeArgs, pargs
 		return mtype, nil
 	}
 	switch args.yangType.Kind {
-	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
+	case yang.Yint8, yang.Yint16, yang.Yint32:
+		if s.narrowIntegers {
+			return &MappedType{NativeType: "sint32"}, nil
+		}
 		return &MappedType{NativeType: "sint64"}, nil
-	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+	case yang.Yint64:
+		return &MappedType{NativeType: "sint64"}, nil
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		if s.narrowIntegers {
+			return &MappedType{NativeType: "uint32"}, nil
+		}
+		return &MappedType{NativeType: "uint64"}, nil
+	case yang.Yuint64:
 		return &MappedType{NativeType: "uint64"}, nil
 	case yang.Ybinary:
 		return &MappedType{NativeType: "bytes"}, nil
 	case yang.Ybool, yang.Yempty:
 		return &MappedType{NativeType: "bool"}, nil
-	case yang.Ystring:
+	case yang.Ystring, yang.YinstanceIdentifier:
 		return &MappedType{NativeType: "string"}, nil
 	case yang.Ydecimal64:
 		// Decimal64 continues to be a message even when we are mapping scalars
@@ -360,13 +436,18 @@ func (s *ProtoLangMapper) yangTypeToProtoScalarType(args resolveTypeArgs, pargs
 		if err != nil {
 			return nil, err
 		}
-		return s.yangTypeToProtoScalarType(resolveTypeArgs{yangType: target.Type, contextEntry: target}, pargs, opts)
+		mtype, err := s.yangTypeToProtoScalarType(resolveTypeArgs{yangType: target.Type, contextEntry: target}, pargs, opts)
+		if err != nil {
+			return nil, err
+		}
+		setResolvedLeafrefType(mtype)
+		return mtype, nil
 	case yang.Yenum:
 		mtype, err := yangEnumTypeToProtoType(args)
 		if err != nil {
 			return nil, err
 		}
-		_, key, err := s.enumSet.enumName(args.contextEntry, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.ParseOptions.SkipEnumDeduplication, opts.TransformationOptions.ShortenEnumLeafNames, false, opts.TransformationOptions.EnumOrgPrefixesToTrim)
+		_, key, err := s.enumSet.enumName(args.contextEntry, opts.TransformationOptions.CompressBehaviour.CompressEnabled(), !opts.TransformationOptions.EnumerationsUseUnderscores, opts.ParseOptions.skipEnumDedup(), opts.TransformationOptions.ShortenEnumLeafNames, false, opts.TransformationOptions.EnumOrgPrefixesToTrim)
 		if err != nil {
 			return nil, err
 		}
@@ -460,6 +541,9 @@ func (s *ProtoLangMapper) protoUnionType(args resolveTypeArgs, pargs resolveProt
 			if err != nil {
 				return nil, fmt.Errorf("error mapping single type within a union: %v", err)
 			}
+			// The union itself is not a leafref, even where its single
+			// subtype is.
+			n.ResolvedLeafrefType = nil
 			return n, nil
 		}
 	}
@@ -546,7 +630,7 @@ func (s *ProtoLangMapper) protoMsgName(e *yang.Entry, compressPaths bool) string
 		s.uniqueProtoMsgNames[pkg] = make(map[string]bool)
 	}
 
-	n := genutil.MakeNameUnique(yang.CamelCase(e.Name), s.uniqueProtoMsgNames[pkg])
+	n := genutil.MakeNameUnique(yang.CamelCase(s.sanitizedName(e)), s.uniqueProtoMsgNames[pkg])
 	s.uniqueProtoMsgNames[pkg][n] = true
 
 	// Record that this was the proto message name that was used.
@@ -589,7 +673,7 @@ func (s *ProtoLangMapper) protobufPackage(e *yang.Entry, compressPaths bool) str
 			// we also exclude it from the package name.
 			continue
 		}
-		parts = append(parts, safeProtoIdentifierName(p.Name))
+		parts = append(parts, safeProtoIdentifierName(s.sanitizedName(p)))
 	}
 
 	// Reverse the slice since we traversed from leaf back to root.
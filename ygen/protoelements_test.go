@@ -53,6 +53,13 @@ func TestYangTypeToProtoType(t *testing.T) {
 		},
 		wantWrapper: &MappedType{NativeType: "ywrapper.UintValue"},
 		wantScalar:  &MappedType{NativeType: "uint64"},
+	}, {
+		name: "instance-identifier type",
+		in: []resolveTypeArgs{
+			{yangType: &yang.YangType{Kind: yang.YinstanceIdentifier}},
+		},
+		wantWrapper: &MappedType{NativeType: "ywrapper.StringValue"},
+		wantScalar:  &MappedType{NativeType: "string"},
 	}, {
 		name: "bool types",
 		in: []resolveTypeArgs{
@@ -135,7 +142,7 @@ func TestYangTypeToProtoType(t *testing.T) {
 		wantWrapper: &MappedType{NativeType: "ywrapper.StringValue"},
 		wantSame:    true,
 	}, {
-		name: "union of string, unsupported instance identifier",
+		name: "union of string, instance identifier",
 		in: []resolveTypeArgs{
 			{
 				yangType: &yang.YangType{
@@ -147,6 +154,21 @@ func TestYangTypeToProtoType(t *testing.T) {
 				},
 			},
 		},
+		wantWrapper: &MappedType{NativeType: "ywrapper.StringValue"},
+		wantSame:    true,
+	}, {
+		name: "union of string, unsupported bits",
+		in: []resolveTypeArgs{
+			{
+				yangType: &yang.YangType{
+					Kind: yang.Yunion,
+					Type: []*yang.YangType{
+						{Kind: yang.Ystring, Name: "string"},
+						{Kind: yang.Ybits, Name: "bits"},
+					},
+				},
+			},
+		},
 		wantErr:  true,
 		wantSame: true,
 	}, {
@@ -440,8 +462,14 @@ func TestYangTypeToProtoType(t *testing.T) {
 				},
 			},
 		},
-		wantWrapper: &MappedType{NativeType: "ywrapper.StringValue"},
-		wantScalar:  &MappedType{NativeType: "string"},
+		wantWrapper: &MappedType{
+			NativeType:          "ywrapper.StringValue",
+			ResolvedLeafrefType: &MappedType{NativeType: "ywrapper.StringValue"},
+		},
+		wantScalar: &MappedType{
+			NativeType:          "string",
+			ResolvedLeafrefType: &MappedType{NativeType: "string"},
+		},
 	}, {
 		name: "leafref to leafref",
 		in: []resolveTypeArgs{{
@@ -499,8 +527,12 @@ func TestYangTypeToProtoType(t *testing.T) {
 				},
 			},
 		},
-		wantWrapper: &MappedType{NativeType: "basePackage.enumPackage.EnumModule", IsEnumeratedValue: true},
-		wantSame:    true,
+		wantWrapper: &MappedType{
+			NativeType:          "basePackage.enumPackage.EnumModule",
+			IsEnumeratedValue:   true,
+			ResolvedLeafrefType: &MappedType{NativeType: "basePackage.enumPackage.EnumModule", IsEnumeratedValue: true},
+		},
+		wantSame: true,
 	}, {
 		name: "leafref to union",
 		in: []resolveTypeArgs{{
@@ -561,6 +593,10 @@ func TestYangTypeToProtoType(t *testing.T) {
 		wantWrapper: &MappedType{
 			UnionTypes:     map[string]int{"bool": 0, "string": 1},
 			UnionTypeInfos: map[string]MappedUnionSubtype{"bool": {}, "string": {}},
+			ResolvedLeafrefType: &MappedType{
+				UnionTypes:     map[string]int{"bool": 0, "string": 1},
+				UnionTypeInfos: map[string]MappedUnionSubtype{"bool": {}, "string": {}},
+			},
 		},
 		wantSame: true,
 	}}
@@ -597,7 +633,7 @@ func TestYangTypeToProtoType(t *testing.T) {
 			for _, e := range enumMapFromEntries(tt.inEntries) {
 				addEnumsToEnumMap(e, enumMap)
 			}
-			enumSet, _, errs := findEnumSet(enumMap, false, true, false, true, true, true, nil)
+			enumSet, _, errs := findEnumSet(enumMap, false, true, false, false, true, true, true, nil)
 			if errs != nil {
 				if !tt.wantErr {
 					t.Errorf("findEnumSet failed: %v", errs)
@@ -18,10 +18,13 @@ import (
 	"bytes"
 	"fmt"
 	"hash/fnv"
+	"math"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/genutil"
@@ -50,6 +53,25 @@ const (
 	ywrapperAccessor = "ywrapper."
 )
 
+// proto3OptionalTypes maps the name of each ywrapper message to the native
+// protobuf scalar type that is used in its place when proto3 optional fields
+// are used to track field presence. Decimal64Value has no native equivalent
+// and is hence not included.
+var proto3OptionalTypes = map[string]string{
+	ywrapperAccessor + "BoolValue":   "bool",
+	ywrapperAccessor + "BytesValue":  "bytes",
+	ywrapperAccessor + "IntValue":    "sint64",
+	ywrapperAccessor + "StringValue": "string",
+	ywrapperAccessor + "UintValue":   "uint64",
+}
+
+// protoWellKnownTypeImports maps the well-known types that YANG typedefs can
+// be mapped to, to the import that is required when they are used.
+var protoWellKnownTypeImports = map[string]string{
+	"google.protobuf.Timestamp": "google/protobuf/timestamp.proto",
+	"google.protobuf.Duration":  "google/protobuf/duration.proto",
+}
+
 const (
 	// protoEnumZeroName is the name given to the value 0 in each generated protobuf enum.
 	protoEnumZeroName string = "UNSET"
@@ -58,6 +80,12 @@ const (
 	// protoAnyPackage is the name of the import to be used when a google.protobuf.Any field
 	// is included in the output data.
 	protoAnyPackage = "google/protobuf/any.proto"
+	// protoWellKnownTypePrefix is the package prefix of the google.protobuf
+	// well-known types.
+	protoWellKnownTypePrefix = "google.protobuf."
+	// protoWellKnownImportPrefix is the prefix of the import paths of the
+	// google.protobuf well-known types.
+	protoWellKnownImportPrefix = "google/protobuf/"
 	// protoListKeyMessageSuffix specifies the suffix that should be added to a list's name
 	// to specify the repeated message that makes up the list's key. The repeated message is
 	// called <ListNameInCamelCase><protoListKeyMessageSuffix>.
@@ -65,6 +93,12 @@ const (
 	// protoSchemaAnnotationOption specifies the name of the FieldOption used to annotate
 	// schemapaths into a protobuf message.
 	protoSchemaAnnotationOption = "(yext.schemapath)"
+	// protoValidateRulesOption specifies the name of the FieldOption used to
+	// specify the protoc-gen-validate constraints for a field.
+	protoValidateRulesOption = "(validate.rules)"
+	// protoValidateImport is the name of the import to be used when
+	// protoc-gen-validate constraints are included in the output protobuf.
+	protoValidateImport = "validate/validate.proto"
 	// protoMatchingListNameKeySuffix defines the suffix that should be added to a list
 	// key's name in the case that it matches the name of the list itself. This is required
 	// since in the case that we have YANG whereby there is a list that has a key
@@ -103,6 +137,7 @@ type protoMsgField struct {
 	Name        string           // Name is the field's name.
 	Type        string           // Type is the protobuf type for the field.
 	IsRepeated  bool             // IsRepeated indicates whether the field is repeated.
+	IsOptional  bool             // IsOptional indicates whether the field is marked with the proto3 optional keyword.
 	Options     []*protoOption   // Extensions is the set of field extensions that should be specified for the field.
 	IsOneOf     bool             // IsOneOf indicates that the field is a oneof and hence consists of multiple subfields.
 	OneOfFields []*protoMsgField // OneOfFields contains the set of fields within the oneof
@@ -125,6 +160,8 @@ type protoMsg struct {
 	Enums       map[string]*protoMsgEnum  // Enums lists the embedded enumerations within the message.
 	ChildMsgs   []*generatedProto3Message // ChildMsgs is the set of messages that should be embedded within the message.
 	PathComment bool                      // PathComment - when set - indicates that comments that specify the path to a message should be included in the output protobuf.
+	Module      string                    // Module - when set - is the name of the YANG module that defines the message's schema element, which is included in a comment in the output protobuf.
+	Revision    string                    // Revision is the most recent revision date of Module.
 }
 
 // protoMsgEnum represents an embedded enumeration within a protobuf message.
@@ -206,6 +243,10 @@ option go_package = "{{ .GoPackageName }}";
 {{ if .PathComment -}}
 // {{ .Name }} represents the {{ .YANGPath }} YANG schema element.
 {{ end -}}
+{{ if .Module -}}
+// {{ .Name }} is defined in the {{ .Module }} YANG module
+{{- if .Revision }} (revision {{ .Revision }}){{ end }}.
+{{ end -}}
 message {{ .Name }} {
 {{- range $idx, $msg := .ChildMsgs -}}
 	{{- indentLines $msg.MessageCode -}}
@@ -228,6 +269,7 @@ message {{ .Name }} {
   }
   {{- else -}}
   {{ if $field.IsRepeated }}repeated {{ end -}}
+  {{ if $field.IsOptional }}optional {{ end -}}
   {{ $field.Type }} {{ $field.Name }} = {{ $field.Tag }}
   {{- $noOptions := len .Options -}}
   {{- if ne $noOptions 0 }} [
@@ -296,6 +338,10 @@ type protoMsgConfig struct {
 	annotateSchemaPaths bool   // annotateSchemaPaths uses the yext protobuf field extensions to annotate the paths from the schema into the output protobuf.
 	annotateEnumNames   bool   // annotateEnumNames uses the yext protobuf enum value extensions to annoate the original YANG name for an enum into the output protobuf.
 	nestedMessages      bool   // nestedMessages indicates whether nested messages should be output for the protobuf schema.
+	useProtoMaps        bool   // useProtoMaps indicates whether lists with a single scalar key should be output as protobuf map fields.
+	annotateModuleInfo  bool   // annotateModuleInfo indicates whether messages should be output with a comment indicating the YANG module, and its revision, that defines them.
+	useProto3Optional   bool   // useProto3Optional indicates whether scalar leaves should be output as proto3 optional native scalar fields rather than ywrapper messages.
+	emitValidateRules   bool   // emitValidateRules indicates whether the YANG restrictions of leaves should be output as protoc-gen-validate constraints.
 }
 
 // writeProto3Message outputs the generated Protobuf3 code for a particular protobuf message. It takes:
@@ -406,7 +452,9 @@ func writeProto3MsgNested(msg *ParsedDirectory, ir *IR, cfg *protoMsgConfig) (*g
 
 		epk := filepath.Join(cfg.baseImportPath, cfg.basePackageName, cfg.enumPackageName, fmt.Sprintf("%s.proto", cfg.enumPackageName))
 		for i := range allImports {
-			if !strings.HasPrefix(i, cfg.baseImportPath) {
+			// Imports of the google.protobuf well-known types are never
+			// relative to the base import path.
+			if !strings.HasPrefix(i, cfg.baseImportPath) || strings.HasPrefix(i, protoWellKnownImportPrefix) || i == protoValidateImport {
 				imports = append(imports, i)
 			}
 			if allImports[epk] {
@@ -528,6 +576,10 @@ func genProto3Msg(msg *ParsedDirectory, ir *IR, cfg *protoMsgConfig, parentPkg s
 		Enums:     map[string]*protoMsgEnum{},
 		ChildMsgs: childMsgs,
 	}
+	if cfg.annotateModuleInfo {
+		msgDef.Module = msg.DefiningModule
+		msgDef.Revision = msg.DefiningModuleRevision
+	}
 
 	definedFieldNames := map[string]bool{}
 	imports := map[string]interface{}{}
@@ -590,6 +642,15 @@ func genProto3Msg(msg *ParsedDirectory, ir *IR, cfg *protoMsgConfig, parentPkg s
 				continue
 			}
 			addNewKeys(imports, lImports)
+			if wktImport, ok := protoWellKnownTypeImports[fieldDef.Type]; ok {
+				imports[wktImport] = true
+			}
+			if cfg.emitValidateRules {
+				if o := protoValidateRules(fieldDef, field.YANGDetails.Type); o != nil {
+					fieldDef.Options = append(fieldDef.Options, o)
+					imports[protoValidateImport] = true
+				}
+			}
 			if repeatedMsg != nil {
 				msgDefs = append(msgDefs, repeatedMsg)
 			}
@@ -668,8 +729,8 @@ func addProtoListField(fieldDef *protoMsgField, msgDef *protoMsg, args *protoDef
 
 	fieldDef.Type = listDef.listType
 
-	// Lists are always repeated fields.
-	fieldDef.IsRepeated = true
+	// Lists are repeated fields, unless they are output as a map.
+	fieldDef.IsRepeated = !listDef.isMap
 	return nKeyMsg, listDef.imports, nil
 }
 
@@ -755,15 +816,25 @@ func addProtoLeafOrLeafListField(fieldDef *protoMsgField, msgDef *protoMsg, args
 	if args.field.Type == LeafListNode {
 		fieldDef.IsRepeated = true
 	}
+
+	// Where proto3 optional fields are used, wrapper messages are replaced by
+	// their native scalar type. Since repeated fields cannot be marked optional,
+	// only leaves are marked as such.
+	if t, ok := proto3OptionalTypes[fieldDef.Type]; ok && args.cfg.useProto3Optional {
+		fieldDef.Type = t
+		fieldDef.IsOptional = !fieldDef.IsRepeated
+	}
 	return repeatedMsg, imports, nil
 }
 
 // writeProtoEnums takes a map of enumerated types within the YANG schema and
 // returns the mapped Protobuf enum definition corresponding to each type. If
 // the annotateEnumNames bool is set, then the original enum value label is
-// stored in the definition. Since leaves that are of type enumeration are
-// output directly within a Protobuf message, these are skipped.
-func writeProtoEnums(enums map[string]*EnumeratedYANGType, annotateEnumNames bool) ([]string, error) {
+// stored in the definition. If the prefixEnumValues bool is set, then each
+// value is prefixed with the upper snake case form of the enumeration's name.
+// Since leaves that are of type enumeration are output directly within a
+// Protobuf message, these are skipped.
+func writeProtoEnums(enums map[string]*EnumeratedYANGType, annotateEnumNames, prefixEnumValues bool) ([]string, error) {
 	var errs util.Errors
 	var genEnums []string
 	for _, enum := range enums {
@@ -794,7 +865,7 @@ func writeProtoEnums(enums map[string]*EnumeratedYANGType, annotateEnumNames boo
 				values[int64(tag)] = toProtoEnumValue(safeProtoIdentifierName(enumDef.Name), enumDef.Name, annotateEnumNames)
 			}
 			p.Values = values
-			p.ValuePrefix = strings.ToUpper(enum.Name)
+			p.ValuePrefix = protoEnumValuePrefix(enum.Name, prefixEnumValues)
 			p.Description = fmt.Sprintf("YANG identity %s", enum.identityBaseName)
 		case DerivedEnumerationType, DerivedUnionEnumerationType:
 			ge, err := genProtoEnum(enum, annotateEnumNames, true)
@@ -805,7 +876,7 @@ func writeProtoEnums(enums map[string]*EnumeratedYANGType, annotateEnumNames boo
 			p.Values = ge.Values
 
 			// Capitalize name per proto style.
-			p.ValuePrefix = strings.ToUpper(enum.Name)
+			p.ValuePrefix = protoEnumValuePrefix(enum.Name, prefixEnumValues)
 			p.Description = fmt.Sprintf("YANG enumerated type %s", enum.TypeName)
 		default:
 			errs = append(errs, fmt.Errorf("unknown type of enumerated value in writeProtoEnums for %s, got: %v, kind: %v", enum.Name, enum, enum.Kind))
@@ -825,6 +896,31 @@ func writeProtoEnums(enums map[string]*EnumeratedYANGType, annotateEnumNames boo
 	return genEnums, nil
 }
 
+// protoEnumValuePrefix returns the prefix that should be used for the values
+// of the enumeration named name. If upperSnake is set, then a separator is
+// inserted at each word boundary within the CamelCase name, such that for
+// example ProtoEnumsBASEIDENTITY is mapped to PROTO_ENUMS_BASEIDENTITY.
+// Otherwise the name is converted to upper case.
+func protoEnumValuePrefix(name string, upperSnake bool) string {
+	if !upperSnake {
+		return strings.ToUpper(name)
+	}
+
+	var b strings.Builder
+	r := []rune(name)
+	for i, c := range r {
+		if i > 0 && unicode.IsUpper(c) && r[i-1] != '_' {
+			prevLower := unicode.IsLower(r[i-1]) || unicode.IsDigit(r[i-1])
+			nextLower := i+1 < len(r) && unicode.IsLower(r[i+1])
+			if prevLower || (unicode.IsUpper(r[i-1]) && nextLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(c))
+	}
+	return b.String()
+}
+
 // genProtoEnum takes an input yang.Entry that contains an enumerated type
 // and returns a protoMsgEnum that contains its definition within the proto
 // schema. If the annotateEnumNames bool is set, then the original YANG name
@@ -852,6 +948,18 @@ func genProtoEnum(enum *EnumeratedYANGType, annotateEnumNames, isLeafOrTypedef b
 type protoMsgListField struct {
 	listType string   // listType is the name of the message that represents a list member.
 	imports  []string // imports is the set of modules that are required by this list message.
+	isMap    bool     // isMap indicates that listType is a map type keyed by the list's key.
+}
+
+// protoMapKeyTypes is the set of protobuf types that list keys can be mapped
+// to which are valid as the key of a protobuf map field.
+var protoMapKeyTypes = map[string]bool{
+	"string": true,
+	"sint32": true,
+	"sint64": true,
+	"uint32": true,
+	"uint64": true,
+	"bool":   true,
 }
 
 // protoListDefinition takes an input field described by a yang.Entry, the generator context (the set of proto messages, and the generator
@@ -866,23 +974,34 @@ func protoListDefinition(args *protoDefinitionArgs) (*protoMsgListField, *protoM
 	listMsgName := listMsg.Name
 	childPkg := listMsg.PackageName
 
+	// listMsgRef returns the definition of a field of the list message
+	// itself, qualified as required to refer to it from the parent.
+	listMsgRef := func() *protoMsgListField {
+		if args.cfg.nestedMessages {
+			return &protoMsgListField{listType: listMsgName}
+		}
+		p := fmt.Sprintf("%s.%s.%s", args.cfg.basePackageName, childPkg, listMsgName)
+		p, _ = stripPackagePrefix(fmt.Sprintf("%s.%s", args.cfg.basePackageName, args.parentPkg), p)
+		return &protoMsgListField{
+			listType: p,
+			imports:  []string{importPath(args.cfg.baseImportPath, args.cfg.basePackageName, childPkg)},
+		}
+	}
+
 	var listKeyMsg *protoMsg
 	var listDef *protoMsgListField
-	if len(listMsg.ListKeys) == 0 {
+	switch keyType, isMapKey := protoMapKeyType(listMsg); {
+	case len(listMsg.ListKeys) == 0:
 		// In proto3 we represent unkeyed lists as a
 		// repeated field of the list message.
-		listDef = &protoMsgListField{
-			listType: listMsgName,
-		}
-		if !args.cfg.nestedMessages {
-			p := fmt.Sprintf("%s.%s.%s", args.cfg.basePackageName, childPkg, listMsgName)
-			p, _ = stripPackagePrefix(fmt.Sprintf("%s.%s", args.cfg.basePackageName, args.parentPkg), p)
-			listDef = &protoMsgListField{
-				listType: p,
-			}
-			listDef.imports = []string{importPath(args.cfg.baseImportPath, args.cfg.basePackageName, childPkg)}
-		}
-	} else {
+		listDef = listMsgRef()
+	case args.cfg.useProtoMaps && isMapKey:
+		// Lists with a single key that can be used as a protobuf map key
+		// are represented as a map of the key to the list message.
+		listDef = listMsgRef()
+		listDef.listType = fmt.Sprintf("map<%s, %s>", keyType, listDef.listType)
+		listDef.isMap = true
+	default:
 		// YANG lists are mapped to a repeated message structure as described
 		// in the YANG to Protobuf transformation specification.
 		var err error
@@ -906,6 +1025,24 @@ func protoListDefinition(args *protoDefinitionArgs) (*protoMsgListField, *protoM
 	return listDef, listKeyMsg, nil
 }
 
+// protoMapKeyType returns the protobuf type of the key of the list described
+// by listMsg, and whether the list can be represented as a protobuf map using
+// it. Only lists with a single key of a non-enumerated, non-union scalar type
+// that protobuf accepts as a map key can be represented as a map.
+func protoMapKeyType(listMsg *ParsedDirectory) (string, bool) {
+	if len(listMsg.ListKeys) != 1 {
+		return "", false
+	}
+	for _, k := range listMsg.ListKeys {
+		lt := k.LangType
+		if lt == nil || lt.IsEnumeratedValue || lt.UnionTypes != nil || !protoMapKeyTypes[lt.NativeType] {
+			return "", false
+		}
+		return lt.NativeType, true
+	}
+	return "", false
+}
+
 // protoDefinedLeaf defines a YANG leaf within a protobuf message.
 type protoDefinedLeaf struct {
 	protoType   string                   // protoType is the protobuf type that the leaf should be mapped to.
@@ -1257,6 +1394,57 @@ func protoPackageToFilePath(pkg string) []string {
 	return append(pp, fmt.Sprintf("%s.proto", pp[len(pp)-1]))
 }
 
+// protoValidateRules returns the protoc-gen-validate field option that
+// constrains the values of the field described by fieldDef according to the
+// range, length and pattern restrictions of the YANG type t. Constraints are
+// only output for fields of native protobuf scalar types, such that leaves
+// that are output as ywrapper messages, enumerations or decimal64 values are
+// not constrained. Since protoc-gen-validate supports a single interval and a
+// single pattern per field, restrictions that consist of more than one range
+// or pattern are not output. nil is returned if there are no constraints.
+func protoValidateRules(fieldDef *protoMsgField, t *YANGType) *protoOption {
+	if t == nil {
+		return nil
+	}
+
+	var rules []string
+	switch fieldDef.Type {
+	case "sint32", "sint64", "uint32", "uint64":
+		if len(t.Range) == 1 {
+			r := t.Range[0]
+			if fieldDef.Type == "sint32" || fieldDef.Type == "sint64" || r.Min.Value != 0 {
+				rules = append(rules, fmt.Sprintf("gte: %s", r.Min))
+			}
+			rules = append(rules, fmt.Sprintf("lte: %s", r.Max))
+		}
+	case "string", "bytes":
+		if len(t.Length) == 1 {
+			r := t.Length[0]
+			if r.Min.Value != 0 {
+				rules = append(rules, fmt.Sprintf("min_len: %s", r.Min))
+			}
+			if r.Max.Value != math.MaxUint64 {
+				rules = append(rules, fmt.Sprintf("max_len: %s", r.Max))
+			}
+		}
+		if fieldDef.Type == "string" && len(t.Pattern) == 1 {
+			rules = append(rules, fmt.Sprintf("pattern: %s", strconv.Quote(t.Pattern[0])))
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	name := fmt.Sprintf("%s.%s", protoValidateRulesOption, fieldDef.Type)
+	if fieldDef.IsRepeated {
+		name = fmt.Sprintf("%s.repeated.items.%s", protoValidateRulesOption, fieldDef.Type)
+	}
+	return &protoOption{
+		Name:  name,
+		Value: fmt.Sprintf("{%s}", strings.Join(rules, ", ")),
+	}
+}
+
 // protoSchemaPathAnnotation takes a protobuf message and field, and returns the protobuf
 // field option definitions required to annotate it with its schema path(s).
 func protoSchemaPathAnnotation(msg *ParsedDirectory, fieldName string, compressPaths bool) (*protoOption, error) {
@@ -1297,3 +1485,76 @@ func stripPackagePrefix(pfx, path string) (string, bool) {
 func importPath(baseImportPath, basePkgName, childPkg string) string {
 	return filepath.Join(append([]string{baseImportPath}, protoPackageToFilePath(fmt.Sprintf("%s.%s", basePkgName, childPkg))...)...)
 }
+
+// combineProto3Packages merges the set of generated packages, pkgs, into a
+// single package named basePkgName. Messages and enums within each child
+// package are wrapped in a message named for the child package such that
+// references to them in the form <child>.<name> or <base>.<child>.<name>
+// continue to resolve within the combined file. The imports required by each
+// package, supplied as a map keyed by package name in pkgImports, are merged
+// with imports of packages that are now included in the combined file being
+// removed. The combined package and its imports are returned.
+func combineProto3Packages(pkgs map[string]Proto3Package, pkgImports map[string]map[string]interface{}, basePkgName, baseImportPath string) (Proto3Package, map[string]interface{}, error) {
+	combined := pkgs[basePkgName]
+	combined.FilePath = protoPackageToFilePath(basePkgName)
+	combined.Messages = append([]string{}, combined.Messages...)
+	combined.Enums = append([]string{}, combined.Enums...)
+
+	imports := map[string]interface{}{}
+	addNewKeys(imports, stringKeys(pkgImports[basePkgName]))
+
+	var childPkgs []string
+	for n := range pkgs {
+		if n != basePkgName {
+			childPkgs = append(childPkgs, n)
+		}
+	}
+	sort.Strings(childPkgs)
+
+	internal := map[string]bool{}
+	for _, n := range childPkgs {
+		child, ok := stripPackagePrefix(basePkgName, n)
+		if !ok || strings.Contains(child, ".") {
+			return Proto3Package{}, nil, fmt.Errorf("package %s cannot be combined into a single file for base package %s", n, basePkgName)
+		}
+		internal[importPath(baseImportPath, basePkgName, child)] = true
+
+		pkg := pkgs[n]
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "message %s {\n", child)
+		for _, c := range append(append([]string{}, pkg.Messages...), pkg.Enums...) {
+			fmt.Fprintf(&b, "%s\n", indentProtoCode(c))
+		}
+		b.WriteString("}\n")
+		combined.Messages = append(combined.Messages, b.String())
+
+		if pkg.UsesYwrapperImport {
+			combined.UsesYwrapperImport = true
+		}
+		if pkg.UsesYextImport {
+			combined.UsesYextImport = true
+		}
+		addNewKeys(imports, stringKeys(pkgImports[n]))
+	}
+
+	for i := range imports {
+		if internal[i] {
+			delete(imports, i)
+		}
+	}
+
+	return combined, imports, nil
+}
+
+// indentProtoCode indents each non-empty line of the supplied protobuf code
+// by a single level, such that it can be nested within another message.
+// Leading and trailing newlines are removed from the returned code.
+func indentProtoCode(code string) string {
+	lines := strings.Split(strings.Trim(code, "\n"), "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = "  " + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
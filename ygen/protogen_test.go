@@ -69,6 +69,7 @@ func TestGenProto3Msg(t *testing.T) {
 		inEnumPackage         string
 		inBaseImportPath      string
 		inAnnotateSchemaPaths bool
+		inUseProto3Optional   bool
 		inParentPackage       string
 		inChildMsgs           []*generatedProto3Message
 		wantMsgs              map[string]*protoMsg
@@ -121,6 +122,88 @@ func TestGenProto3Msg(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "simple message with scalar fields using proto3 optional",
+		inMsg: &ParsedDirectory{
+			Name: "MessageName",
+			Type: Container,
+			Fields: map[string]*NodeDetails{
+				"field-one": {
+					Name: "field_one",
+					Type: LeafNode,
+					LangType: &MappedType{
+						NativeType: "ywrapper.StringValue",
+					},
+					YANGDetails: YANGNodeDetails{
+						Name: "field-one",
+						Path: "/field-one",
+					},
+				},
+				"field-two": {
+					Name: "field_two",
+					Type: LeafNode,
+					LangType: &MappedType{
+						NativeType: "ywrapper.BytesValue",
+					},
+					YANGDetails: YANGNodeDetails{
+						Name: "field-two",
+						Path: "/field-two",
+					},
+				},
+				"field-three": {
+					Name: "field_three",
+					Type: LeafNode,
+					LangType: &MappedType{
+						NativeType: "ywrapper.Decimal64Value",
+					},
+					YANGDetails: YANGNodeDetails{
+						Name: "field-three",
+						Path: "/field-three",
+					},
+				},
+				"field-four": {
+					Name: "field_four",
+					Type: LeafListNode,
+					LangType: &MappedType{
+						NativeType: "ywrapper.UintValue",
+					},
+					YANGDetails: YANGNodeDetails{
+						Name: "field-four",
+						Path: "/field-four",
+					},
+				},
+			},
+			Path: "/root/message-name",
+		},
+		inBasePackage:       "base",
+		inEnumPackage:       "enums",
+		inUseProto3Optional: true,
+		wantMsgs: map[string]*protoMsg{
+			"MessageName": {
+				Name:     "MessageName",
+				YANGPath: "/root/message-name",
+				Fields: []*protoMsgField{{
+					Tag:        410095931,
+					Name:       "field_one",
+					Type:       "string",
+					IsOptional: true,
+				}, {
+					Tag:        25944937,
+					Name:       "field_two",
+					Type:       "bytes",
+					IsOptional: true,
+				}, {
+					Tag:  151168411,
+					Name: "field_three",
+					Type: "ywrapper.Decimal64Value",
+				}, {
+					Tag:        359217133,
+					Name:       "field_four",
+					Type:       "uint64",
+					IsRepeated: true,
+				}},
+			},
+		},
 	}, {
 		name: "simple message with child messages, ensure no difference in logic",
 		inMsg: &ParsedDirectory{
@@ -648,6 +731,7 @@ func TestGenProto3Msg(t *testing.T) {
 				enumPackageName:     tt.inEnumPackage,
 				baseImportPath:      tt.inBaseImportPath,
 				annotateSchemaPaths: tt.inAnnotateSchemaPaths,
+				useProto3Optional:   tt.inUseProto3Optional,
 			}, tt.inParentPackage, tt.inChildMsgs)
 
 			if (errs != nil) != tt.wantErr {
@@ -1734,6 +1818,120 @@ func TestGenListKeyProto(t *testing.T) {
 	}
 }
 
+func TestProtoEnumValuePrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		inName       string
+		inUpperSnake bool
+		want         string
+	}{{
+		name:   "upper case",
+		inName: "ProtoEnumsEnumTypedef",
+		want:   "PROTOENUMSENUMTYPEDEF",
+	}, {
+		name:         "upper snake case",
+		inName:       "ProtoEnumsEnumTypedef",
+		inUpperSnake: true,
+		want:         "PROTO_ENUMS_ENUM_TYPEDEF",
+	}, {
+		name:         "upper snake case with upper case word",
+		inName:       "ProtoEnumsBASEIDENTITY",
+		inUpperSnake: true,
+		want:         "PROTO_ENUMS_BASEIDENTITY",
+	}, {
+		name:         "upper snake case with upper case word followed by word",
+		inName:       "OpenconfigIFTYPEValue",
+		inUpperSnake: true,
+		want:         "OPENCONFIG_IFTYPE_VALUE",
+	}, {
+		name:         "upper snake case with digits",
+		inName:       "Ipv4Address",
+		inUpperSnake: true,
+		want:         "IPV4_ADDRESS",
+	}, {
+		name:         "upper snake case with existing separator",
+		inName:       "Foo_Bar",
+		inUpperSnake: true,
+		want:         "FOO_BAR",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := protoEnumValuePrefix(tt.inName, tt.inUpperSnake); got != tt.want {
+				t.Errorf("protoEnumValuePrefix(%s, %v): did not get expected prefix, got: %s, want: %s", tt.inName, tt.inUpperSnake, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProtoValidateRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		inField    *protoMsgField
+		inType     *YANGType
+		wantOption *protoOption
+	}{{
+		name:    "unsigned range with zero lower bound",
+		inField: &protoMsgField{Type: "uint64"},
+		inType:  &YANGType{Range: yang.YangRange{{Min: yang.FromInt(0), Max: yang.FromInt(100)}}},
+		wantOption: &protoOption{
+			Name:  "(validate.rules).uint64",
+			Value: "{lte: 100}",
+		},
+	}, {
+		name:    "signed range",
+		inField: &protoMsgField{Type: "sint64"},
+		inType:  &YANGType{Range: yang.YangRange{{Min: yang.FromInt(-10), Max: yang.FromInt(10)}}},
+		wantOption: &protoOption{
+			Name:  "(validate.rules).sint64",
+			Value: "{gte: -10, lte: 10}",
+		},
+	}, {
+		name:    "multiple ranges are not supported",
+		inField: &protoMsgField{Type: "sint64"},
+		inType: &YANGType{Range: yang.YangRange{
+			{Min: yang.FromInt(-10), Max: yang.FromInt(-1)},
+			{Min: yang.FromInt(1), Max: yang.FromInt(10)},
+		}},
+	}, {
+		name:    "string length and pattern",
+		inField: &protoMsgField{Type: "string"},
+		inType: &YANGType{
+			Length:  yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(8)}},
+			Pattern: []string{"^(a+)$"},
+		},
+		wantOption: &protoOption{
+			Name:  "(validate.rules).string",
+			Value: `{min_len: 1, max_len: 8, pattern: "^(a+)$"}`,
+		},
+	}, {
+		name:    "multiple patterns are not supported",
+		inField: &protoMsgField{Type: "string"},
+		inType:  &YANGType{Pattern: []string{"^(a+)$", "^(b+)$"}},
+	}, {
+		name:    "repeated field",
+		inField: &protoMsgField{Type: "string", IsRepeated: true},
+		inType:  &YANGType{Pattern: []string{"^(a+)$"}},
+		wantOption: &protoOption{
+			Name:  "(validate.rules).repeated.items.string",
+			Value: `{pattern: "^(a+)$"}`,
+		},
+	}, {
+		name:    "wrapper message field",
+		inField: &protoMsgField{Type: "ywrapper.UintValue"},
+		inType:  &YANGType{Range: yang.YangRange{{Min: yang.FromInt(1), Max: yang.FromInt(10)}}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := protoValidateRules(tt.inField, tt.inType)
+			if diff := cmp.Diff(tt.wantOption, got); diff != "" {
+				t.Errorf("protoValidateRules(%v, %v): did not get expected option, diff(-want, +got):\n%s", tt.inField, tt.inType, diff)
+			}
+		})
+	}
+}
+
 func TestWriteProtoEnums(t *testing.T) {
 	// Create mock enumerations within goyang since we cannot create them in-line.
 	testEnums := map[string][]string{
@@ -1754,6 +1952,7 @@ func TestWriteProtoEnums(t *testing.T) {
 		name                string
 		inEnums             map[string]*EnumeratedYANGType
 		inAnnotateEnumNames bool
+		inPrefixEnumValues  bool
 		wantEnums           []string
 		wantErr             bool
 	}{{
@@ -1843,12 +2042,51 @@ enum SecondEnum {
   SECONDENUM_VALUE_1 = 1 [(yext.yang_name) = "VALUE_1"];
   SECONDENUM_VALUE_2 = 2 [(yext.yang_name) = "VALUE_2"];
 }
+`,
+		},
+	}, {
+		name: "enums with values prefixed in upper snake case",
+		inEnums: map[string]*EnumeratedYANGType{
+			"/field-name|enum": {
+				Name:             "ModIDENTITYValue",
+				Kind:             IdentityType,
+				identityBaseName: "IdentityValue",
+				ValToYANGDetails: []ygot.EnumDefinition{{
+					Name:           "VALUE_A",
+					DefiningModule: "mod",
+				}},
+			},
+			"e": {
+				Name:     "EnumName",
+				Kind:     DerivedEnumerationType,
+				TypeName: "typedef",
+				ValToYANGDetails: []ygot.EnumDefinition{{
+					Name:  "SPEED_2.5G",
+					Value: 0,
+				}},
+			},
+		},
+		inAnnotateEnumNames: true,
+		inPrefixEnumValues:  true,
+		wantEnums: []string{
+			`
+// EnumName represents an enumerated type generated for the YANG enumerated type typedef.
+enum EnumName {
+  ENUM_NAME_UNSET = 0;
+  ENUM_NAME_SPEED_2_5G = 1 [(yext.yang_name) = "SPEED_2.5G"];
+}
+`, `
+// ModIDENTITYValue represents an enumerated type generated for the YANG identity IdentityValue.
+enum ModIDENTITYValue {
+  MOD_IDENTITY_VALUE_UNSET = 0;
+  MOD_IDENTITY_VALUE_VALUE_A = 321526273 [(yext.yang_name) = "VALUE_A"];
+}
 `,
 		},
 	}}
 
 	for _, tt := range tests {
-		got, err := writeProtoEnums(tt.inEnums, tt.inAnnotateEnumNames)
+		got, err := writeProtoEnums(tt.inEnums, tt.inAnnotateEnumNames, tt.inPrefixEnumValues)
 		if (err != nil) != tt.wantErr {
 			t.Errorf("%s: writeProtoEnums(%v): did not get expected error, got: %v", tt.name, tt.inEnums, err)
 		}
@@ -37,6 +37,55 @@ import (
 // the entry corresponds to. In the case that the fake root struct that is provided
 // is nil, a synthetic root entry is used to store the schema tree.
 func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry, compressed bool, inclDescriptions bool) ([]byte, error) {
+	rootEntry, err := buildRootEntry(ms, dn, fakeroot, compressed, inclDescriptions)
+	if err != nil {
+		return nil, err
+	}
+	return marshalJSONTree(rootEntry)
+}
+
+// buildJSONTreePerModule takes the same arguments as buildJSONTree, and
+// returns the JSON serialised schema tree split into one document per YANG
+// module, keyed by module name. Each document is rooted on the same root entry
+// as the document returned by buildJSONTree, and contains the schema nodes
+// that are instantiated by the module, along with the ancestors of those nodes
+// such that each node remains at its original location in the tree. Merging
+// the directories of the trees in each document results in the tree that is
+// returned by buildJSONTree - such that nodes that are augmented into another
+// module's tree are stitched back into place.
+func buildJSONTreePerModule(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry, compressed bool, inclDescriptions bool) (map[string][]byte, error) {
+	rootEntry, err := buildRootEntry(ms, dn, fakeroot, compressed, inclDescriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	entryMods := map[*yang.Entry]string{}
+	for _, ch := range rootEntry.Dir {
+		if err := instantiatingModules(ch, entryMods); err != nil {
+			return nil, err
+		}
+	}
+
+	mods := map[string]bool{}
+	for _, m := range entryMods {
+		mods[m] = true
+	}
+
+	trees := make(map[string][]byte, len(mods))
+	for m := range mods {
+		e, _ := filterEntryByModule(rootEntry, m, entryMods)
+		j, err := marshalJSONTree(e)
+		if err != nil {
+			return nil, err
+		}
+		trees[m] = j
+	}
+	return trees, nil
+}
+
+// buildRootEntry builds the annotated root entry of the schema tree for the
+// set of modules ms, as described in buildJSONTree.
+func buildRootEntry(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry, compressed bool, inclDescriptions bool) (*yang.Entry, error) {
 	rootEntry := &yang.Entry{
 		Dir:        map[string]*yang.Entry{},
 		Annotation: map[string]interface{}{},
@@ -49,6 +98,17 @@ func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry,
 			}
 			rootEntry.Dir[ch.Name] = ch
 		}
+		// Top-level rpcs are only included where their input or output
+		// has been mapped to a struct.
+		for _, ch := range m.Dir {
+			if ch.RPC == nil || !hasMappedOperationPayload(ch, dn) {
+				continue
+			}
+			if _, ex := rootEntry.Dir[ch.Name]; ex {
+				return nil, fmt.Errorf("overlapping root children for key %s", ch.Name)
+			}
+			rootEntry.Dir[ch.Name] = ch
+		}
 	}
 
 	if fakeroot != nil {
@@ -65,7 +125,26 @@ func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry,
 	if compressed {
 		rootEntry.Annotation[util.CompressedSchemaAnnotation] = compressed
 	}
+	return rootEntry, nil
+}
 
+// hasMappedOperationPayload returns true if the input or output of the rpc
+// or action e has been mapped to a struct within the dn map.
+func hasMappedOperationPayload(e *yang.Entry, dn map[string]string) bool {
+	for _, io := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+		if io == nil {
+			continue
+		}
+		if _, ok := dn[io.Path()]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalJSONTree returns the indented JSON serialisation of the schema tree
+// rooted at rootEntry.
+func marshalJSONTree(rootEntry *yang.Entry) ([]byte, error) {
 	j, err := json.MarshalIndent(rootEntry, "", strings.Repeat(" ", 4))
 	if err != nil {
 		return nil, fmt.Errorf("JSON marshalling error: %v", err)
@@ -76,6 +155,67 @@ func buildJSONTree(ms []*yang.Entry, dn map[string]string, fakeroot *yang.Entry,
 	return j, nil
 }
 
+// indentJSONSchema returns the JSON document js re-indented such that each
+// level is indented using indent. If indent is empty, js is returned
+// unmodified.
+func indentJSONSchema(js []byte, indent string) ([]byte, error) {
+	if indent == "" {
+		return js, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, js, "", indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// instantiatingModules populates the supplied mods map with the name of the
+// module that instantiates e, and each of its descendants, keyed by the entry.
+func instantiatingModules(e *yang.Entry, mods map[*yang.Entry]string) error {
+	m, err := e.InstantiatingModule()
+	if err != nil {
+		return fmt.Errorf("cannot determine instantiating module of %s: %v", e.Path(), err)
+	}
+	mods[e] = m
+	for _, ch := range e.Dir {
+		if err := instantiatingModules(ch, mods); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterEntryByModule returns a copy of the schema tree rooted at e which
+// contains only the entries that are instantiated by the module mod, and
+// their ancestors, using the supplied mods map to determine the module of
+// each entry. Entries in the returned tree are shallow copies of those in the
+// input tree, other than their Dir field. The returned bool indicates whether
+// the returned entry is non-empty - that is, whether e, or any of its
+// descendants, is instantiated by mod. The root of the tree, which has no
+// entry within mods, is always returned.
+func filterEntryByModule(e *yang.Entry, mod string, mods map[*yang.Entry]string) (*yang.Entry, bool) {
+	var dir map[string]*yang.Entry
+	for name, ch := range e.Dir {
+		fc, ok := filterEntryByModule(ch, mod, mods)
+		if !ok {
+			continue
+		}
+		if dir == nil {
+			dir = map[string]*yang.Entry{}
+		}
+		dir[name] = fc
+	}
+
+	m, ok := mods[e]
+	if ok && m != mod && dir == nil {
+		return nil, false
+	}
+
+	c := *e
+	c.Dir = dir
+	return &c, true
+}
+
 // annotateChildren annotates the children of e with their schema path, and the value corresponding
 // to its path in the supplied dn map. The dn map is assumed to contain the
 // names of unique directories that are generated within the code to be output.
@@ -90,6 +230,22 @@ func annotateChildren(e *yang.Entry, dn map[string]string, inclDescriptions bool
 			annotateChildren(ch, dn, inclDescriptions)
 		}
 	}
+
+	// The input and output of rpcs and actions are annotated only where
+	// they have been mapped to a struct.
+	for _, ch := range e.Dir {
+		if ch.RPC == nil {
+			continue
+		}
+		for _, io := range []*yang.Entry{ch.RPC.Input, ch.RPC.Output} {
+			if io == nil {
+				continue
+			}
+			if _, ok := dn[io.Path()]; ok {
+				annotateChildren(io, dn, inclDescriptions)
+			}
+		}
+	}
 }
 
 // annotateEntry modifies the yang.Entry e to:
@@ -0,0 +1,139 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+)
+
+// CBORCodec is an interface implemented by a CBOR library, which is used to
+// encode and decode GoStructs to and from CBOR. It allows the caller to select
+// the CBOR implementation, such that ygot does not depend on a particular
+// library.
+type CBORCodec interface {
+	// Marshal encodes v, which is made up of maps keyed by string, slices,
+	// strings, numbers, booleans and nil values, to a CBOR data item.
+	Marshal(v interface{}) ([]byte, error)
+	// Unmarshal decodes the CBOR data item in data, storing the result in
+	// the value pointed to by v, which is a pointer to an empty interface.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// MarshalCBOR marshals the supplied GoStruct to CBOR using the supplied
+// codec. The encoded data item has the same structure as the RFC7951 JSON
+// representation of s, which is generated according to args. It is not
+// encoded according to RFC9254 YANG-CBOR: values are encoded as they are
+// within RFC7951 JSON, such that, for example, 64-bit integers, decimal64
+// and binary values are encoded as text strings rather than as native CBOR
+// integers and byte strings.
+func MarshalCBOR(s GoStruct, codec CBORCodec, args *RFC7951JSONConfig) ([]byte, error) {
+	if codec == nil {
+		return nil, fmt.Errorf("nil CBOR codec supplied")
+	}
+	t, err := ConstructIETFJSON(s, args)
+	if err != nil {
+		return nil, fmt.Errorf("cannot construct RFC7951 tree: %v", err)
+	}
+	return codec.Marshal(t)
+}
+
+// UnmarshalCBORTree decodes the CBOR data item in data using the supplied
+// codec, and returns it as a tree that has the same form as that returned by
+// json.Unmarshal for the corresponding RFC7951 JSON. The tree can hence be
+// unmarshalled into a GoStruct using ytypes.Unmarshal. It returns an error if
+// the data item is not a map, or contains map keys that are not strings.
+func UnmarshalCBORTree(codec CBORCodec, data []byte) (map[string]interface{}, error) {
+	if codec == nil {
+		return nil, fmt.Errorf("nil CBOR codec supplied")
+	}
+	var v interface{}
+	if err := codec.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("cannot decode CBOR: %v", err)
+	}
+	nv, err := cborToJSONValue(v)
+	if err != nil {
+		return nil, err
+	}
+	tree, ok := nv.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("CBOR data item is not a map, got: %T", v)
+	}
+	return tree, nil
+}
+
+// cborToJSONValue converts the value v, as decoded by a CBOR library, to
+// the type that json.Unmarshal would have used for the same value. Maps are
+// converted to map[string]interface{}, and numbers are converted to float64.
+func cborToJSONValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			ne, err := cborToJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = ne
+		}
+		return v, nil
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			ks, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("CBOR map key %v is not a string, got: %T", k, k)
+			}
+			ne, err := cborToJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			m[ks] = ne
+		}
+		return m, nil
+	case []interface{}:
+		for i, e := range v {
+			ne, err := cborToJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = ne
+		}
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint8:
+		return float64(v), nil
+	case uint16:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case nil, bool, string, float64:
+		return v, nil
+	}
+	return nil, fmt.Errorf("unsupported type %T in CBOR data item", v)
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// fakeCBORCodec is a CBORCodec which records the value that it is asked to
+// marshal, and returns a fixed value when unmarshalling.
+type fakeCBORCodec struct {
+	marshalled interface{}
+	decoded    interface{}
+	err        error
+}
+
+func (f *fakeCBORCodec) Marshal(v interface{}) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.marshalled = v
+	return []byte("cbor"), nil
+}
+
+func (f *fakeCBORCodec) Unmarshal(_ []byte, v interface{}) error {
+	if f.err != nil {
+		return f.err
+	}
+	*v.(*interface{}) = f.decoded
+	return nil
+}
+
+func TestMarshalCBOR(t *testing.T) {
+	tests := []struct {
+		desc             string
+		in               GoStruct
+		inCodec          *fakeCBORCodec
+		want             interface{}
+		wantErrSubstring string
+	}{{
+		desc: "simple struct",
+		in: &renderExample{
+			Str:    String("hello"),
+			IntVal: Int32(42),
+		},
+		inCodec: &fakeCBORCodec{},
+		want: map[string]interface{}{
+			"str":     "hello",
+			"int-val": int32(42),
+		},
+	}, {
+		desc:             "codec error",
+		in:               &renderExample{Str: String("hello")},
+		inCodec:          &fakeCBORCodec{err: fmt.Errorf("injected error")},
+		wantErrSubstring: "injected error",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := MarshalCBOR(tt.in, tt.inCodec, nil)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MarshalCBOR: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if string(got) != "cbor" {
+				t.Errorf("MarshalCBOR: did not get output of codec, got: %q", got)
+			}
+			if diff := cmp.Diff(tt.want, tt.inCodec.marshalled); diff != "" {
+				t.Errorf("MarshalCBOR: did not pass expected tree to codec, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalCBORTree(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inDecoded        interface{}
+		want             map[string]interface{}
+		wantErrSubstring string
+	}{{
+		desc: "generic map keys and integer values",
+		inDecoded: map[interface{}]interface{}{
+			"str":     "hello",
+			"int-val": uint64(42),
+			"neg-val": int64(-42),
+			"float":   float32(1.5),
+			"bool":    true,
+			"list": []interface{}{
+				map[interface{}]interface{}{"key": uint8(1)},
+			},
+			"container": map[string]interface{}{
+				"leaf-list": []interface{}{int16(1), "two"},
+			},
+		},
+		want: map[string]interface{}{
+			"str":     "hello",
+			"int-val": float64(42),
+			"neg-val": float64(-42),
+			"float":   float64(1.5),
+			"bool":    true,
+			"list": []interface{}{
+				map[string]interface{}{"key": float64(1)},
+			},
+			"container": map[string]interface{}{
+				"leaf-list": []interface{}{float64(1), "two"},
+			},
+		},
+	}, {
+		desc:             "non-string map key",
+		inDecoded:        map[interface{}]interface{}{uint64(1): "one"},
+		wantErrSubstring: "is not a string",
+	}, {
+		desc:             "unsupported type",
+		inDecoded:        map[interface{}]interface{}{"bytes": []byte("one")},
+		wantErrSubstring: "unsupported type []uint8",
+	}, {
+		desc:             "not a map",
+		inDecoded:        []interface{}{"one"},
+		wantErrSubstring: "is not a map",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := UnmarshalCBORTree(&fakeCBORCodec{decoded: tt.inDecoded}, []byte("cbor"))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalCBORTree: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UnmarshalCBORTree: did not get expected tree, (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
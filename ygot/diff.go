@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
 	"google.golang.org/protobuf/proto"
 
@@ -218,8 +219,8 @@ func findSetLeaves(s GoStruct, opts ...DiffOpt) (map[*pathSpec]interface{}, erro
 		}
 
 		// Handle the case of having an annotated struct - in the diff case we
-		// do not process schema annotations.
-		if util.IsYgotAnnotation(ni.StructField) {
+		// do not process schema annotations, or embedded metadata.
+		if util.IsYgotAnnotation(ni.StructField) || util.IsYgotMetadata(ni.StructField) || util.IsYgotArrayLen(ni.StructField) {
 			return
 		}
 
@@ -262,6 +263,22 @@ func findSetLeaves(s GoStruct, opts ...DiffOpt) (map[*pathSpec]interface{}, erro
 
 		ni.Annotation = []interface{}{vp}
 
+		// Leaves that are stored as values are always set, and are hence
+		// handled in the same manner as a populated pointer.
+		if util.IsYgotValueLeaf(ni.StructField) && ni.FieldValue.CanAddr() {
+			ni.FieldValue = ni.FieldValue.Addr()
+		}
+
+		// Leaf-lists that are stored as fixed-size arrays are handled as a
+		// slice of their populated elements.
+		if _, ok := util.YgotArrayLenField(ni.StructField); ok && ni.Parent != nil {
+			fv, err := util.FixedArrayElems(reflect.Indirect(ni.Parent.FieldValue), ni.StructField)
+			if err != nil {
+				return util.NewErrs(err)
+			}
+			ni.FieldValue = fv
+		}
+
 		// Ignore non-data, or default data values.
 		if util.IsNilOrInvalidValue(ni.FieldValue) || util.IsValueNilOrDefault(ni.FieldValue.Interface()) || util.IsValueStructPtr(ni.FieldValue) || util.IsValueMap(ni.FieldValue) {
 			return
@@ -465,3 +482,188 @@ func Diff(original, modified GoStruct, opts ...DiffOpt) (*gnmipb.Notification, e
 
 	return n, nil
 }
+
+// DeletedPaths takes an original and modified GoStruct, which must be of the
+// same type, and returns the set of gNMI paths that are populated in original
+// but are not populated in modified. It is the deletion half of a replace of
+// original with modified, and complements the Diff function.
+//
+// A container or list entry is considered to be populated if any leaf within
+// it is set. Where all of the contents of a container or list entry in
+// original are absent from modified, only the path of the container or list
+// entry (including its keys) is returned rather than the path of each of its
+// leaves. The returned paths are sorted such that the output is deterministic,
+// and are relative to the supplied GoStructs where they do not represent the
+// root of a YANG schema tree.
+func DeletedPaths(original, modified GoStruct) ([]*gnmipb.Path, error) {
+	if reflect.TypeOf(original) != reflect.TypeOf(modified) {
+		return nil, fmt.Errorf("cannot diff structs of different types, original: %T, modified: %T", original, modified)
+	}
+
+	origPaths, err := populatedPaths(original)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract populated paths from original struct: %v", err)
+	}
+
+	modPaths, err := populatedPaths(modified)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract populated paths from modified struct: %v", err)
+	}
+
+	deleted := map[string]*gnmipb.Path{}
+	for ps, p := range origPaths {
+		if _, ok := modPaths[ps]; !ok {
+			deleted[ps] = p
+		}
+	}
+
+	var keys []string
+	for ps, p := range deleted {
+		// Only the least specific path that has been deleted is returned,
+		// such that paths whose parent is also deleted are skipped.
+		if len(p.Elem) > 1 {
+			parent, err := PathToString(&gnmipb.Path{Elem: p.Elem[:len(p.Elem)-1]})
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := deleted[parent]; ok {
+				continue
+			}
+		}
+		keys = append(keys, ps)
+	}
+	sort.Strings(keys)
+
+	paths := []*gnmipb.Path{}
+	for _, k := range keys {
+		paths = append(paths, deleted[k])
+	}
+	return paths, nil
+}
+
+// ValidatePathsPresent returns the subset of the paths in required that are
+// not populated within the GoStruct s, which is described by schema, in the
+// order in which they are specified. It can be used to check that the fields
+// that must be specified within a configuration are populated before it is
+// sent to a device.
+//
+// Each required path is relative to s, and may address a leaf, leaf-list,
+// container, list or list member. The keys of each list member along the
+// path must be specified, other than for the last element of the path,
+// where a list without keys is considered to be populated if any member
+// is populated. Containers that are not represented within s, such as the
+// config and state containers of a compressed schema, may be included in the
+// paths. As in DeletedPaths, a container or list member is populated if any
+// leaf within it is set. An error is returned if a required path is not
+// valid within schema.
+func ValidatePathsPresent(schema *yang.Entry, s GoStruct, required []*gnmipb.Path) ([]*gnmipb.Path, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("nil schema for %T", s)
+	}
+
+	populated, err := populatedPaths(s)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract populated paths from struct: %v", err)
+	}
+	// Lists that are addressed without keys are populated if any of their
+	// members is populated, hence store the paths of each populated member
+	// with the keys of the list removed.
+	populatedLists := map[string]bool{}
+	for _, p := range populated {
+		if last := p.Elem[len(p.Elem)-1]; len(last.GetKey()) != 0 {
+			elems := append(append([]*gnmipb.PathElem{}, p.Elem[:len(p.Elem)-1]...), &gnmipb.PathElem{Name: last.GetName()})
+			ps, err := PathToString(&gnmipb.Path{Elem: elems})
+			if err != nil {
+				return nil, err
+			}
+			populatedLists[ps] = true
+		}
+	}
+
+	missing := []*gnmipb.Path{}
+	for _, p := range required {
+		elems, err := requiredPathElems(schema, p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid required path %s: %v", pathElemString(p.GetElem()), err)
+		}
+		if len(elems) == 0 {
+			// The root is populated if anything within it is populated.
+			if len(populated) == 0 {
+				missing = append(missing, p)
+			}
+			continue
+		}
+		ps, err := PathToString(&gnmipb.Path{Elem: elems})
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := populated[ps]; !ok && !populatedLists[ps] {
+			missing = append(missing, p)
+		}
+	}
+	return missing, nil
+}
+
+// requiredPathElems returns the elements of the path p, with any module
+// prefixes removed from their names, after checking that p addresses a node
+// within schema, and that the keys specified for each list within the path are
+// those of the list.
+func requiredPathElems(schema *yang.Entry, p *gnmipb.Path) ([]*gnmipb.PathElem, error) {
+	var elems []*gnmipb.PathElem
+	s := schema
+	for i, e := range p.GetElem() {
+		name := util.StripModulePrefix(e.GetName())
+		cs := util.FirstChild(s, []string{name})
+		if cs == nil {
+			return nil, fmt.Errorf("cannot find schema for %s in %s", name, s.Name)
+		}
+		switch {
+		case len(e.GetKey()) != 0:
+			if !cs.IsList() {
+				return nil, fmt.Errorf("keys specified for non-list %s", name)
+			}
+			keyNames := strings.Fields(cs.Key)
+			if len(keyNames) != len(e.GetKey()) {
+				return nil, fmt.Errorf("list %s has keys %v, got %v", name, keyNames, e.GetKey())
+			}
+			for _, kn := range keyNames {
+				if _, ok := e.GetKey()[kn]; !ok {
+					return nil, fmt.Errorf("missing key %s for list %s", kn, name)
+				}
+			}
+		case cs.IsList() && i != len(p.GetElem())-1:
+			return nil, fmt.Errorf("keys must be specified for list %s", name)
+		}
+		elems = append(elems, &gnmipb.PathElem{Name: name, Key: e.GetKey()})
+		s = cs
+	}
+	return elems, nil
+}
+
+// populatedPaths returns the set of data tree paths, keyed by their string
+// representation, that are populated within the GoStruct s. The set includes
+// the path of each leaf that is set, along with the path of each container or
+// list entry that is an ancestor of such a leaf.
+func populatedPaths(s GoStruct) (map[string]*gnmipb.Path, error) {
+	leaves, err := findSetLeaves(s)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := map[string]*gnmipb.Path{}
+	for ps := range leaves {
+		for _, p := range ps.gNMIPaths {
+			for i := 1; i <= len(p.Elem); i++ {
+				np := &gnmipb.Path{Elem: p.Elem[:i]}
+				k, err := PathToString(np)
+				if err != nil {
+					return nil, err
+				}
+				if _, ok := paths[k]; !ok {
+					paths[k] = proto.Clone(np).(*gnmipb.Path)
+				}
+			}
+		}
+	}
+	return paths, nil
+}
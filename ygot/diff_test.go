@@ -23,6 +23,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/testutil"
 	"github.com/openconfig/ygot/util"
 	"google.golang.org/protobuf/proto"
@@ -1547,6 +1548,284 @@ func TestDiff(t *testing.T) {
 	}
 }
 
+func TestDiffOrderedList(t *testing.T) {
+	orig := &orderedListStruct{List: &orderedListMap{}}
+	mod := &orderedListStruct{List: &orderedListMap{}}
+	for _, k := range []string{"b", "a"} {
+		orig.List.append(k, "val-"+k)
+		mod.List.append(k, "val-"+k)
+	}
+	mod.List.valueMap["a"].Value = String("new")
+
+	got, err := Diff(orig, mod)
+	if err != nil {
+		t.Fatalf("Diff: got unexpected error: %v", err)
+	}
+	want := &gnmipb.Notification{
+		Update: []*gnmipb.Update{{
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+				{Name: "list", Key: map[string]string{"key": "a"}},
+				{Name: "value"},
+			}},
+			Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"new"}},
+		}},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Diff: did not get expected notification, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestDeletedPaths(t *testing.T) {
+	tests := []struct {
+		desc          string
+		inOrig, inMod GoStruct
+		want          []*gnmipb.Path
+		wantErrSubStr string
+	}{{
+		desc:   "no deletions",
+		inOrig: &renderExample{Str: String("merlot")},
+		inMod:  &renderExample{Str: String("pinot-noir"), IntVal: Int32(42)},
+		want:   []*gnmipb.Path{},
+	}, {
+		desc: "leaf removed",
+		inOrig: &renderExample{
+			Str:    String("merlot"),
+			IntVal: Int32(42),
+		},
+		inMod: &renderExample{
+			IntVal: Int32(42),
+		},
+		want: []*gnmipb.Path{
+			mustPath(t, "/str"),
+		},
+	}, {
+		desc: "list entry removed",
+		inOrig: &renderExample{
+			List: map[uint32]*renderExampleList{
+				1: {Val: String("syrah")},
+				2: {Val: String("shiraz")},
+			},
+		},
+		inMod: &renderExample{
+			List: map[uint32]*renderExampleList{
+				1: {Val: String("syrah")},
+			},
+		},
+		want: []*gnmipb.Path{
+			mustPath(t, "/list[val=shiraz]"),
+		},
+	}, {
+		desc: "container cleared",
+		inOrig: &renderExample{
+			Str: String("merlot"),
+			Ch: &renderExampleChild{
+				Val:  Uint64(42),
+				Enum: EnumTestVALONE,
+			},
+		},
+		inMod: &renderExample{
+			Str: String("merlot"),
+			Ch:  &renderExampleChild{},
+		},
+		want: []*gnmipb.Path{
+			mustPath(t, "/ch"),
+		},
+	}, {
+		desc: "leaf within container removed",
+		inOrig: &renderExample{
+			Ch: &renderExampleChild{
+				Val:  Uint64(42),
+				Enum: EnumTestVALONE,
+			},
+		},
+		inMod: &renderExample{
+			Ch: &renderExampleChild{
+				Val: Uint64(42),
+			},
+		},
+		want: []*gnmipb.Path{
+			mustPath(t, "/ch/enum"),
+		},
+	}, {
+		desc:          "different types",
+		inOrig:        &renderExample{},
+		inMod:         &renderExampleChild{},
+		wantErrSubStr: "cannot diff structs of different types",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := DeletedPaths(tt.inOrig, tt.inMod)
+			if diff := errdiff.Substring(err, tt.wantErrSubStr); diff != "" {
+				t.Fatalf("DeletedPaths(%s, %s): did not get expected error status, %s", pretty.Sprint(tt.inOrig), pretty.Sprint(tt.inMod), diff)
+			}
+			if tt.wantErrSubStr != "" {
+				return
+			}
+			if diff := cmp.Diff(got, tt.want, protocmp.Transform()); diff != "" {
+				t.Errorf("DeletedPaths(%s, %s): did not get expected paths, diff(-got,+want):\n%s", pretty.Sprint(tt.inOrig), pretty.Sprint(tt.inMod), diff)
+			}
+		})
+	}
+}
+
+func TestValidatePathsPresent(t *testing.T) {
+	simpleSchema := mustModuleSchema(t, "openconfig-simple")
+	listSchema := mustModuleSchema(t, "openconfig-withlist")
+
+	partialSimple := &mergeNotifDevice{
+		Parent: &mergeNotifParent{
+			Child: &mergeNotifChild{
+				One:   String("foo"),
+				Three: 1,
+			},
+		},
+		RemoteContainer: &mergeNotifRemote{},
+	}
+
+	partialList := &mergeNotifListDevice{
+		SingleKey: map[string]*mergeNotifSingleKey{
+			"foo": {Key: String("foo")},
+		},
+	}
+
+	tests := []struct {
+		desc          string
+		inSchema      *yang.Entry
+		inStruct      GoStruct
+		inRequired    []string
+		want          []string
+		wantErrSubStr string
+	}{{
+		desc:     "all required paths populated",
+		inSchema: simpleSchema,
+		inStruct: partialSimple,
+		inRequired: []string{
+			"/parent/child/config/one",
+			"/parent/child/config/three",
+			"/parent/child/config",
+			"/parent/child",
+			"/parent",
+		},
+		want: []string{},
+	}, {
+		desc:     "partially populated struct",
+		inSchema: simpleSchema,
+		inStruct: partialSimple,
+		inRequired: []string{
+			"/parent/child/config/one",
+			"/parent/child/config/four",
+			"/parent/child/state/two",
+			"/parent/child/state",
+			"/remote-container/config/a-leaf",
+			"/remote-container",
+		},
+		want: []string{
+			"/parent/child/config/four",
+			"/parent/child/state/two",
+			"/parent/child/state",
+			"/remote-container/config/a-leaf",
+			"/remote-container",
+		},
+	}, {
+		desc:       "module prefixed path",
+		inSchema:   simpleSchema,
+		inStruct:   partialSimple,
+		inRequired: []string{"/openconfig-simple:parent/child/config/one"},
+		want:       []string{},
+	}, {
+		desc:       "empty root",
+		inSchema:   simpleSchema,
+		inStruct:   &mergeNotifDevice{},
+		inRequired: []string{"/", "/parent/child/config/one"},
+		want:       []string{"/", "/parent/child/config/one"},
+	}, {
+		desc:     "list members",
+		inSchema: listSchema,
+		inStruct: partialList,
+		inRequired: []string{
+			"/model/a/single-key[key=foo]/config/key",
+			"/model/a/single-key[key=foo]/state/key",
+			"/model/a/single-key[key=foo]",
+			"/model/a/single-key[key=bar]",
+			"/model/a/single-key",
+			"/model/b/multi-key",
+			"/model/b/multi-key[key1=1][key2=2]",
+		},
+		want: []string{
+			"/model/a/single-key[key=foo]/state/key",
+			"/model/a/single-key[key=bar]",
+			"/model/b/multi-key",
+			"/model/b/multi-key[key1=1][key2=2]",
+		},
+	}, {
+		desc:     "multi-keyed list member",
+		inSchema: listSchema,
+		inStruct: &mergeNotifListDevice{
+			MultiKey: map[mergeNotifMultiKeyKey]*mergeNotifMultiKey{
+				{Key1: 1, Key2: 2}: {Key1: Uint32(1), Key2: Uint64(2)},
+			},
+		},
+		inRequired: []string{
+			"/model/b/multi-key[key2=2][key1=1]/config/key2",
+			"/model/b/multi-key[key1=1][key2=3]",
+		},
+		want: []string{"/model/b/multi-key[key1=1][key2=3]"},
+	}, {
+		desc:          "path not in schema",
+		inSchema:      simpleSchema,
+		inStruct:      partialSimple,
+		inRequired:    []string{"/parent/child/config/five"},
+		wantErrSubStr: "cannot find schema for five in config",
+	}, {
+		desc:          "keys for non-list",
+		inSchema:      simpleSchema,
+		inStruct:      partialSimple,
+		inRequired:    []string{"/parent[name=foo]/child"},
+		wantErrSubStr: "keys specified for non-list parent",
+	}, {
+		desc:          "missing keys within path",
+		inSchema:      listSchema,
+		inStruct:      partialList,
+		inRequired:    []string{"/model/a/single-key/config/key"},
+		wantErrSubStr: "keys must be specified for list single-key",
+	}, {
+		desc:          "wrong key name",
+		inSchema:      listSchema,
+		inStruct:      partialList,
+		inRequired:    []string{"/model/a/single-key[name=foo]"},
+		wantErrSubStr: "missing key key for list single-key",
+	}, {
+		desc:          "nil schema",
+		inStruct:      partialSimple,
+		inRequired:    []string{"/parent"},
+		wantErrSubStr: "nil schema",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var required []*gnmipb.Path
+			for _, p := range tt.inRequired {
+				required = append(required, mustPath(t, p))
+			}
+			got, err := ValidatePathsPresent(tt.inSchema, tt.inStruct, required)
+			if diff := errdiff.Substring(err, tt.wantErrSubStr); diff != "" {
+				t.Fatalf("ValidatePathsPresent(%s, %v): did not get expected error status, %s", pretty.Sprint(tt.inStruct), tt.inRequired, diff)
+			}
+			if tt.wantErrSubStr != "" {
+				return
+			}
+			want := []*gnmipb.Path{}
+			for _, p := range tt.want {
+				want = append(want, mustPath(t, p))
+			}
+			if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+				t.Errorf("ValidatePathsPresent(%s, %v): did not get expected paths, diff(-got,+want):\n%s", pretty.Sprint(tt.inStruct), tt.inRequired, diff)
+			}
+		})
+	}
+}
+
 func TestLeastSpecificPath(t *testing.T) {
 	tests := []struct {
 		name string
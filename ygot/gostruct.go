@@ -1,10 +1,16 @@
 package ygot
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 const (
@@ -66,3 +72,826 @@ func PruneConfigFalse(schema *yang.Entry, s GoStruct) error {
 	}
 	return nil
 }
+
+// ConfigOnly returns a copy of the GoStruct s, described by the schema
+// supplied, from which the branches and leaf nodes that are removed by
+// PruneConfigFalse have been removed. That is, derived state is removed from
+// compressed GoStructs, and "config false" data is removed from uncompressed
+// GoStructs, such that the copy contains only the data that would be present
+// in a GoStruct generated with state excluded. The input GoStruct is not
+// modified.
+func ConfigOnly(schema *yang.Entry, s GoStruct) (GoStruct, error) {
+	c, err := DeepCopy(s)
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy GoStruct: %v", err)
+	}
+	if err := PruneConfigFalse(schema, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ForEachLeaf traverses the populated GoStruct s, described by the schema
+// supplied, and calls fn for each leaf or leaf-list that is set. The path
+// handed to fn is the data tree path of the leaf, with the keys of any list
+// entries appended to the list's path element in the form name[key=value].
+// The value handed to fn is the Go value of the leaf, with pointers to scalar
+// values dereferenced. Where a struct field maps to more than one schema path
+// (e.g., when the schema is compressed), fn is called for each path.
+//
+// Iteration stops at the first error returned by fn, and the error is
+// returned.
+func ForEachLeaf(schema *yang.Entry, s GoStruct, fn func(path []string, value interface{}) error) error {
+	var fnErr error
+	leafIterFunc := func(ni *util.NodeInfo, in, out interface{}) util.Errors {
+		if fnErr != nil || ni == nil || ni.Schema == nil || util.IsNilOrInvalidValue(ni.FieldValue) {
+			return nil
+		}
+
+		switch {
+		case ni.Schema.IsLeafList():
+		case ni.Schema.IsLeaf():
+			// Elements of a leaf-list are traversed with a copy of the
+			// leaf-list's schema, but are reported with the leaf-list.
+			if ni.Parent != nil && ni.Parent.Schema != nil && ni.Parent.Schema.IsLeafList() {
+				return nil
+			}
+		default:
+			return nil
+		}
+
+		v := ni.FieldValue
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		value := v.Interface()
+		if _, isEnum := value.(GoEnum); isEnum && v.Kind() == reflect.Int64 && v.Int() == 0 {
+			// Skip enumerated values that have not been set.
+			return nil
+		}
+
+		p, err := dataTreePath(ni)
+		if err != nil {
+			return util.NewErrs(err)
+		}
+		fnErr = fn(p, value)
+		return nil
+	}
+
+	if errs := util.ForEachField(schema, s, nil, nil, leafIterFunc); errs != nil {
+		return errs
+	}
+	return fnErr
+}
+
+// dataTreePath returns the data tree path of the node described by ni, built
+// from the paths of each of its ancestors. The keys of list entries are
+// appended to the list's path element in the form name[key=value].
+func dataTreePath(ni *util.NodeInfo) ([]string, error) {
+	var p []string
+	var keys string
+	for n := ni; n != nil; n = n.Parent {
+		if n.FieldKey.IsValid() {
+			// List entries have the same PathFromParent as their parent map,
+			// such that only the keys are added at this level.
+			k, err := listEntryKeys(n)
+			if err != nil {
+				return nil, err
+			}
+			keys = k
+			continue
+		}
+		elems := append([]string{}, n.PathFromParent...)
+		if keys != "" && len(elems) != 0 {
+			elems[len(elems)-1] += keys
+			keys = ""
+		}
+		p = append(elems, p...)
+	}
+	return p, nil
+}
+
+// listEntryKeys returns the keys of the list entry described by ni in the
+// form [key1=value1][key2=value2], sorted by key name.
+func listEntryKeys(ni *util.NodeInfo) (string, error) {
+	// The ΛListKeyMap method has a pointer receiver, such that the address
+	// of members that are stored by value within the list is taken.
+	mv := util.ListMemberPtr(ni.FieldValue)
+	var keys map[string]string
+	switch {
+	case mv.Type().Implements(reflect.TypeOf((*KeyHelperGoStruct)(nil)).Elem()):
+		km, err := mv.Interface().(KeyHelperGoStruct).ΛListKeyMap()
+		if err != nil {
+			return "", err
+		}
+		if keys, err = keyMapAsStrings(km); err != nil {
+			return "", err
+		}
+	case ni.Schema != nil && ni.Schema.Key != "" && !strings.Contains(ni.Schema.Key, " "):
+		kv, err := KeyValueAsString(ni.FieldKey.Interface())
+		if err != nil {
+			return "", err
+		}
+		keys = map[string]string{ni.Schema.Key: kv}
+	default:
+		return "", fmt.Errorf("cannot determine keys for list entry %v", ni.FieldKey.Interface())
+	}
+
+	return formatListKeys(keys), nil
+}
+
+// formatListKeys returns keys, which maps the name of each key of a list
+// entry to its value, in the form [key1=value1][key2=value2], sorted by key
+// name.
+func formatListKeys(keys map[string]string) string {
+	var names []string
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, k := range names {
+		fmt.Fprintf(&b, "[%s=%s]", k, keys[k])
+	}
+	return b.String()
+}
+
+// ListMemberPath returns the path of the member of the list at the path p
+// whose keys are keys, which maps the name of each key leaf to its value, in
+// the form p[key1=value1][key2=value2], with the keys sorted by name. It is
+// used by the ΛPopulatedChildren methods of generated GoStructs, which cannot
+// return an error. Key values that KeyValueAsString cannot convert - such as
+// int64 values, or an enumerated value that is not defined by its
+// enumeration - are output in their default Go format, as produced by
+// fmt.Sprint, rather than causing the member to be omitted.
+func ListMemberPath(p string, keys map[string]interface{}) string {
+	ks := make(map[string]string, len(keys))
+	for k, v := range keys {
+		s, err := KeyValueAsString(v)
+		if err != nil {
+			s = fmt.Sprint(v)
+		}
+		ks[k] = s
+	}
+	return p + formatListKeys(ks)
+}
+
+// PopulatedChildren returns the schema paths, relative to the GoStruct s,
+// which is described by schema, of the children of s that are populated,
+// sorted lexicographically. Each non-nil member of a keyed list is returned
+// individually, with its keys appended to the path of the list in the form
+// list[key=value]. Containers, leaves and leaf-lists are returned when they
+// are set, and keyless lists when they have members. Leaves that are output
+// as value types are always considered to be populated. Where a field maps to
+// more than one schema path (e.g., when the schema is compressed), each path
+// is returned.
+//
+// If s implements PopulatedChildrenGoStruct, the result of its generated
+// ΛPopulatedChildren method is returned without the use of reflection.
+func PopulatedChildren(schema *yang.Entry, s GoStruct) ([]string, error) {
+	if ps, ok := s.(PopulatedChildrenGoStruct); ok {
+		return ps.ΛPopulatedChildren(), nil
+	}
+
+	v := reflect.ValueOf(s)
+	if util.IsNilOrInvalidValue(v) || !util.IsValueStructPtr(v) {
+		return nil, fmt.Errorf("invalid GoStruct supplied to PopulatedChildren, got: %T", s)
+	}
+	sv := v.Elem()
+	st := sv.Type()
+
+	var paths []string
+	for i := 0; i < sv.NumField(); i++ {
+		ft, fv := st.Field(i), sv.Field(i)
+		if _, ok := ft.Tag.Lookup("path"); !ok || util.IsYgotAnnotation(ft) {
+			// Fields that are not schema nodes are skipped.
+			continue
+		}
+		sps, err := util.SchemaPaths(ft)
+		if err != nil {
+			return nil, err
+		}
+
+		// members stores the members of the field where it is a keyed
+		// list.
+		var members []*util.NodeInfo
+		var isList bool
+		switch {
+		case fv.Type().Implements(goOrderedListType):
+			if fv.IsNil() {
+				continue
+			}
+			isList = true
+			ol := fv.Interface().(GoOrderedList)
+			vals := ol.ΛValues()
+			for j, k := range ol.ΛKeys() {
+				if m := reflect.ValueOf(vals[j]); !util.IsNilOrInvalidValue(m) {
+					members = append(members, &util.NodeInfo{FieldValue: m, FieldKey: reflect.ValueOf(k)})
+				}
+			}
+		case fv.Kind() == reflect.Map:
+			isList = true
+			for _, k := range fv.MapKeys() {
+				if m := fv.MapIndex(k); !util.IsNilOrInvalidValue(m) {
+					members = append(members, &util.NodeInfo{FieldValue: m, FieldKey: k})
+				}
+			}
+		case fv.Kind() == reflect.Ptr, fv.Kind() == reflect.Interface:
+			if fv.IsNil() {
+				continue
+			}
+		case fv.Kind() == reflect.Slice:
+			if fv.Len() == 0 {
+				continue
+			}
+		case fv.Kind() == reflect.Array:
+			if fv.IsZero() {
+				continue
+			}
+		default:
+			if _, isEnum := fv.Interface().(GoEnum); isEnum && fv.Int() == 0 {
+				continue
+			}
+		}
+
+		if !isList {
+			for _, p := range sps {
+				paths = append(paths, util.SlicePathToString(p))
+			}
+			continue
+		}
+
+		if len(members) == 0 {
+			continue
+		}
+		cs, err := util.ChildSchema(schema, ft)
+		if err != nil {
+			return nil, fmt.Errorf("cannot find schema for field %s: %v", ft.Name, err)
+		}
+		if cs == nil {
+			return nil, fmt.Errorf("cannot find schema for field %s", ft.Name)
+		}
+		for _, m := range members {
+			m.Schema = cs
+			keys, err := listEntryKeys(m)
+			if err != nil {
+				return nil, fmt.Errorf("cannot determine keys of member of %s: %v", ft.Name, err)
+			}
+			for _, p := range sps {
+				paths = append(paths, util.SlicePathToString(p)+keys)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// FlattenOpt is an interface that is implemented by the options to the
+// Flatten function.
+type FlattenOpt interface {
+	// IsFlattenOpt is a marker method for each FlattenOpt.
+	IsFlattenOpt()
+}
+
+// FlattenRFC7951Strings is a FlattenOpt that specifies that each value in
+// the map returned by Flatten is formatted as a string according to its
+// representation in RFC7951 JSON, rather than being the Go value of the
+// leaf. Enumerated values are represented by their names, and binary
+// values are base64 encoded. The values of leaf-lists are represented as a
+// []string.
+type FlattenRFC7951Strings struct{}
+
+// IsFlattenOpt marks FlattenRFC7951Strings as a FlattenOpt.
+func (*FlattenRFC7951Strings) IsFlattenOpt() {}
+
+// Flatten returns a map of the leaves and leaf-lists that are set within the
+// populated GoStruct s, described by the schema supplied. The map is keyed by
+// the absolute data tree path of each leaf in the form
+// /container/list[key=value]/leaf, and its values are the Go values of each
+// leaf as passed to the function handed to ForEachLeaf. The values can be
+// formatted as RFC7951 strings using the FlattenRFC7951Strings option.
+func Flatten(schema *yang.Entry, s GoStruct, opts ...FlattenOpt) (map[string]interface{}, error) {
+	var asStrings bool
+	for _, o := range opts {
+		if _, ok := o.(*FlattenRFC7951Strings); ok {
+			asStrings = true
+		}
+	}
+
+	flat := map[string]interface{}{}
+	err := ForEachLeaf(schema, s, func(path []string, value interface{}) error {
+		p := "/" + strings.Join(path, "/")
+		if !asStrings {
+			flat[p] = value
+			return nil
+		}
+		v, err := rfc7951LeafStrings(value)
+		if err != nil {
+			return fmt.Errorf("cannot format value of %s: %v", p, err)
+		}
+		flat[p] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return flat, nil
+}
+
+// rfc7951LeafStrings returns the value of a leaf or leaf-list, as handed to
+// the function supplied to ForEachLeaf, formatted as its string
+// representation in RFC7951 JSON. Leaf-list values are returned as a
+// []string.
+func rfc7951LeafStrings(value interface{}) (interface{}, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice || IsBinaryType(v.Type()) {
+		return rfc7951String(value)
+	}
+
+	elems, err := leaflistToSlice(v, false)
+	if err != nil {
+		return nil, err
+	}
+	strs := make([]string, 0, len(elems))
+	for _, e := range elems {
+		s, err := rfc7951String(e)
+		if err != nil {
+			return nil, err
+		}
+		strs = append(strs, s)
+	}
+	return strs, nil
+}
+
+// rfc7951String returns the string representation of the scalar value
+// supplied in RFC7951 JSON, unwrapping it if it is a union.
+func rfc7951String(value interface{}) (string, error) {
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Kind() == reflect.Slice:
+		// The only slice that is a scalar value is a binary.
+		return binaryJSON(v)
+	case util.IsValueStructPtr(v):
+		u, err := unwrapUnionInterfaceValue(v, false)
+		if err != nil {
+			return "", err
+		}
+		return rfc7951String(u)
+	}
+	u, err := resolveUnionVal(value, false)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v", u), nil
+}
+
+// PopulateDefaults traverses the GoStruct s, described by the schema supplied,
+// and sets each unset leaf or leaf-list that has a default value in the YANG
+// schema to that default. A leaf is considered unset when its field holds the
+// Go zero value, i.e., a nil pointer, slice or interface, or the UNSET value of
+// an enumerated type. Only containers and list entries that are present in s
+// are traversed; no new containers are created.
+func PopulateDefaults(schema *yang.Entry, s GoStruct) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema supplied for struct %T", s)
+	}
+	if util.IsValueNil(s) {
+		return nil
+	}
+	if errs := populateDefaultsStruct(schema, reflect.ValueOf(s)); errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// populateDefaultsStruct populates the default values of the fields of the
+// struct pointer sv, described by schema, recursing into any populated
+// containers and lists.
+func populateDefaultsStruct(schema *yang.Entry, sv reflect.Value) util.Errors {
+	v := sv.Elem()
+	t := v.Type()
+
+	var errs util.Errors
+	for i := 0; i < t.NumField(); i++ {
+		sf, fv := t.Field(i), v.Field(i)
+		if util.IsYgotAnnotation(sf) || util.IsYgotMetadata(sf) || util.IsYgotArrayLen(sf) {
+			continue
+		}
+
+		cs, err := util.ChildSchema(schema, sf)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if cs == nil {
+			errs = util.AppendErr(errs, fmt.Errorf("could not find schema for field %s of %T", sf.Name, sv.Interface()))
+			continue
+		}
+
+		switch {
+		case util.IsTypeStructPtr(sf.Type):
+			if !fv.IsNil() {
+				errs = util.AppendErrs(errs, populateDefaultsStruct(cs, fv))
+			}
+		case util.IsTypeMap(sf.Type):
+			for _, k := range fv.MapKeys() {
+				mv := util.ListMemberPtr(fv.MapIndex(k))
+				errs = util.AppendErrs(errs, populateDefaultsStruct(cs, mv))
+				if util.IsTypeStruct(sf.Type.Elem()) {
+					// Members of lists that are stored by value must be
+					// stored again once their defaults are populated.
+					fv.SetMapIndex(k, mv.Elem())
+				}
+			}
+		default:
+			if err := populateLeafDefault(cs, sv.Type(), fv); err != nil {
+				errs = util.AppendErr(errs, fmt.Errorf("cannot populate default for field %s of %T: %v", sf.Name, sv.Interface(), err))
+			}
+		}
+	}
+	return errs
+}
+
+// populateLeafDefault sets the leaf or leaf-list field fv, described by schema,
+// to the default value specified in the schema if fv is unset. parentT is the
+// type of the struct pointer that contains fv, and is used to resolve the
+// types of union values.
+func populateLeafDefault(schema *yang.Entry, parentT reflect.Type, fv reflect.Value) error {
+	if len(schema.Default) == 0 || !fv.IsZero() {
+		return nil
+	}
+
+	ts, err := util.ResolveIfLeafRef(schema)
+	if err != nil {
+		return err
+	}
+
+	ft := fv.Type()
+	switch {
+	case schema.IsLeafList():
+		sl := reflect.MakeSlice(ft, 0, len(schema.Default))
+		for _, d := range schema.Default {
+			dv, err := defaultValue(ts, parentT, ft.Elem(), d)
+			if err != nil {
+				return err
+			}
+			sl = reflect.Append(sl, dv)
+		}
+		fv.Set(sl)
+	case ft.Kind() == reflect.Ptr:
+		dv, err := defaultValue(ts, parentT, ft.Elem(), schema.Default[0])
+		if err != nil {
+			return err
+		}
+		p := reflect.New(ft.Elem())
+		p.Elem().Set(dv)
+		fv.Set(p)
+	default:
+		// Enumerated types, unions, and binary values are not stored as
+		// pointers.
+		dv, err := defaultValue(ts, parentT, ft, schema.Default[0])
+		if err != nil {
+			return err
+		}
+		fv.Set(dv)
+	}
+	return nil
+}
+
+// defaultValue returns the default value d, specified in the YANG schema, as a
+// value of the Go type t. schema is the schema of the leaf, with any leafref
+// resolved, and parentT is the type of the struct pointer containing the leaf.
+func defaultValue(schema *yang.Entry, parentT, t reflect.Type, d string) (reflect.Value, error) {
+	switch {
+	case t.Implements(reflect.TypeOf((*GoEnum)(nil)).Elem()):
+		ev, ok, err := enumValueFromName(t, d)
+		switch {
+		case err != nil:
+			return reflect.Value{}, err
+		case !ok:
+			return reflect.Value{}, fmt.Errorf("%q is not a valid value for enumerated type %v", d, t)
+		}
+		return ev, nil
+	case t.Kind() == reflect.Interface:
+		return unionDefaultValue(schema, parentT, t, d)
+	}
+	return scalarDefaultValue(t, d)
+}
+
+// enumValueFromName returns the value of the enumerated type t whose name is
+// name, ignoring any module prefixes. The bool return value indicates whether
+// a matching value was found.
+func enumValueFromName(t reflect.Type, name string) (reflect.Value, bool, error) {
+	em, ok := reflect.Zero(t).Interface().(GoEnum).ΛMap()[t.Name()]
+	if !ok {
+		return reflect.Value{}, false, fmt.Errorf("cannot find enumerated values for type %v", t)
+	}
+	for k, v := range em {
+		if util.StripModulePrefix(v.Name) == util.StripModulePrefix(name) {
+			return reflect.ValueOf(k).Convert(t), true, nil
+		}
+	}
+	return reflect.Value{}, false, nil
+}
+
+// scalarDefaultValue parses the default value d into the non-enumerated,
+// non-union Go type t.
+func scalarDefaultValue(t reflect.Type, d string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(d)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(d)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetBool(b)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(d, 0, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetInt(i)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(d, 0, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetUint(u)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(d, 64)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		// Binary values are represented as a named []byte type, and are
+		// populated with the default as it is written in the schema, as per
+		// the defaults used by generated getters.
+		if t.Elem().Kind() != reflect.Uint8 {
+			return reflect.Value{}, fmt.Errorf("unsupported slice type %v for default value", t)
+		}
+		v.SetBytes([]byte(d))
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %v for default value", t)
+	}
+	return v, nil
+}
+
+// unionDefaultValue returns the default value d as a value of the union
+// interface type t. The enumerated types within the union are tried first,
+// followed by each non-enumerated YANG type of the union in the order they
+// are specified in the schema, which are converted to the union using the
+// generated To_ method of the parent struct type parentT.
+func unionDefaultValue(schema *yang.Entry, parentT, t reflect.Type, d string) (reflect.Value, error) {
+	if em, ok := reflect.Zero(parentT).Interface().(ValidatedGoStruct); ok {
+		for _, et := range em.ΛEnumTypeMap()[absoluteSchemaDataPath(schema)] {
+			if !et.Implements(t) {
+				continue
+			}
+			ev, ok, err := enumValueFromName(et, d)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if ok {
+				v := reflect.New(t).Elem()
+				v.Set(ev)
+				return v, nil
+			}
+		}
+	}
+
+	mn := "To_" + t.Name()
+	toMethod := reflect.Zero(parentT).MethodByName(mn)
+	if !toMethod.IsValid() {
+		return reflect.Value{}, fmt.Errorf("%v does not have a %s method", parentT, mn)
+	}
+	for _, k := range unionKindsNotEnums(schema.Type) {
+		gt, ok := yangKindToGoType[k]
+		if !ok {
+			continue
+		}
+		sv, err := scalarDefaultValue(gt, d)
+		if err != nil {
+			continue
+		}
+		ret := toMethod.Call([]reflect.Value{sv})
+		if ret[1].IsNil() {
+			v := reflect.New(t).Elem()
+			v.Set(ret[0].Elem())
+			return v, nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("cannot find a union type of %v matching %q", t, d)
+}
+
+// yangKindToGoType maps the non-enumerated YANG types that can be members of
+// a union to the Go type accepted by the generated To_ union methods.
+var yangKindToGoType = map[yang.TypeKind]reflect.Type{
+	yang.Ystring:    reflect.TypeOf(""),
+	yang.Ybool:      reflect.TypeOf(false),
+	yang.Yint8:      reflect.TypeOf(int8(0)),
+	yang.Yint16:     reflect.TypeOf(int16(0)),
+	yang.Yint32:     reflect.TypeOf(int32(0)),
+	yang.Yint64:     reflect.TypeOf(int64(0)),
+	yang.Yuint8:     reflect.TypeOf(uint8(0)),
+	yang.Yuint16:    reflect.TypeOf(uint16(0)),
+	yang.Yuint32:    reflect.TypeOf(uint32(0)),
+	yang.Yuint64:    reflect.TypeOf(uint64(0)),
+	yang.Ydecimal64: reflect.TypeOf(float64(0)),
+	yang.Ybinary:    reflect.TypeOf([]byte(nil)),
+
+	yang.YinstanceIdentifier: reflect.TypeOf(InstanceIdentifier("")),
+}
+
+// unionKindsNotEnums returns the YANG kinds of the non-enumerated member
+// types of the union yt, flattening any nested unions, in the order they are
+// specified in the schema.
+func unionKindsNotEnums(yt *yang.YangType) []yang.TypeKind {
+	var ks []yang.TypeKind
+	for _, st := range yt.Type {
+		switch st.Kind {
+		case yang.Yenum, yang.Yidentityref:
+		case yang.Yunion:
+			ks = append(ks, unionKindsNotEnums(st)...)
+		default:
+			ks = append(ks, st.Kind)
+		}
+	}
+	return ks
+}
+
+// absoluteSchemaDataPath returns the absolute data tree path of the schema
+// entry, omitting any choice, case or fake root nodes, as used as the key of
+// the map returned by a GoStruct's ΛEnumTypeMap method.
+func absoluteSchemaDataPath(schema *yang.Entry) string {
+	out := []string{schema.Name}
+	for s := schema.Parent; s != nil; s = s.Parent {
+		if !util.IsChoiceOrCase(s) && !util.IsFakeRoot(s) {
+			out = append([]string{s.Name}, out...)
+		}
+	}
+	return "/" + strings.Join(out, "/")
+}
+
+// StructForPath returns a new GoStruct of the same type as root, described by
+// the schema supplied, that is populated only along the gNMI path. The
+// containers and list members that are ancestors of the node addressed by
+// path are initialised, with the keys of list members taken from the path,
+// and the addressed node is copied from root. This allows a minimal payload
+// to be constructed for a gNMI SetRequest that targets a single path.
+//
+// The path must be expressed using PathElem messages, and must exist within
+// root. Paths that address containers that are removed from compressed
+// schemas address all fields within them.
+func StructForPath(schema *yang.Entry, root GoStruct, path *gnmipb.Path) (GoStruct, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("nil schema supplied for struct %T", root)
+	}
+	if util.IsValueNil(root) {
+		return nil, fmt.Errorf("nil root struct supplied")
+	}
+	rv := reflect.ValueOf(root)
+	if !util.IsValueStructPtr(rv) {
+		return nil, fmt.Errorf("root %T is not a struct pointer", root)
+	}
+	if len(path.GetElement()) != 0 {
+		return nil, fmt.Errorf("path %v must be expressed using PathElem messages", path)
+	}
+
+	elems := path.GetElem()
+	if len(elems) == 0 {
+		return DeepCopy(root)
+	}
+
+	srcs, err := resolveNotificationPath(schema, rv, elems, false)
+	if err != nil {
+		return nil, err
+	}
+	if srcs == nil {
+		return nil, fmt.Errorf("path %s does not exist within %T", pathElemString(elems), root)
+	}
+
+	nv := reflect.New(rv.Type().Elem())
+	dsts, err := resolveNotificationPath(schema, nv, elems, true)
+	if err != nil {
+		return nil, err
+	}
+	// The targets are resolved from structs of the same type, and hence
+	// are returned in the same order.
+	if len(dsts) != len(srcs) {
+		return nil, fmt.Errorf("cannot resolve path %s within new %T", pathElemString(elems), root)
+	}
+	for i, src := range srcs {
+		if err := copyNotificationTarget(dsts[i], src); err != nil {
+			return nil, fmt.Errorf("cannot copy path %s: %v", pathElemString(elems), err)
+		}
+	}
+	return nv.Interface().(GoStruct), nil
+}
+
+// copyNotificationTarget deep copies the field, or list member, addressed by
+// src to the corresponding field of dst.
+func copyNotificationTarget(dst, src *notificationTarget) error {
+	if src.key.IsValid() {
+		sm, dm := src.field.MapIndex(src.key), dst.field.MapIndex(dst.key)
+		if !sm.IsValid() || !dm.IsValid() {
+			return fmt.Errorf("list member %v does not exist", src.key.Interface())
+		}
+		// Members that are stored by value are copied into a copy of the
+		// destination member, which is then stored in the list again.
+		valueMembers := util.IsValueStruct(dm)
+		sm, dm = util.ListMemberPtr(sm), util.ListMemberPtr(dm)
+		if err := copyStruct(dm.Elem(), sm.Elem(), 0, &MergeOverwriteExistingFields{}); err != nil {
+			return err
+		}
+		dst.field.SetMapIndex(dst.key, listMemberValue(dm, valueMembers))
+		dst.store()
+		return nil
+	}
+
+	var err error
+	switch {
+	case src.fixedArray():
+		// The number of populated elements of the array is copied along
+		// with the array.
+		err = copyFixedArrayField(dst.parent.Elem(), src.parent.Elem(), src.sf, 0)
+	case src.field.Kind() == reflect.Ptr:
+		err = copyPtrField(dst.field, src.field, 0)
+	case src.field.Kind() == reflect.Interface:
+		err = copyInterfaceField(dst.field, src.field, 0)
+	case src.field.Kind() == reflect.Map:
+		err = copyMapField(dst.field, src.field, 0)
+	case src.field.Kind() == reflect.Slice:
+		err = copySliceField(dst.field, src.field, 0)
+	default:
+		dst.field.Set(src.field)
+	}
+	if err != nil {
+		return err
+	}
+	dst.store()
+	return nil
+}
+
+// AttachMetadata attaches the RFC7952 metadata annotation name, with the
+// value value, to the field named fieldName of the GoStruct s. The name must
+// be qualified by the name of the module that defines the annotation, e.g.,
+// "ietf-origin:origin". If fieldName is the empty string, the metadata is
+// attached to the data node represented by s itself.
+//
+// The metadata is stored within a MetadataAnnotation in the annotation field
+// of s whose path is that of the named field, prefixed with "@", such as
+// those generated by ygen when the AddAnnotationFields option is set. An
+// error is returned if s has no such field.
+func AttachMetadata(s GoStruct, fieldName, name string, value interface{}) error {
+	sv := reflect.ValueOf(s)
+	if !util.IsValueStructPtr(sv) {
+		return fmt.Errorf("cannot attach metadata to %T, must be a struct pointer", s)
+	}
+	sv = sv.Elem()
+
+	wantPath := "@"
+	if fieldName != "" {
+		ft, ok := sv.Type().FieldByName(fieldName)
+		if !ok {
+			return fmt.Errorf("%T has no field named %s", s, fieldName)
+		}
+		p, ok := ft.Tag.Lookup("path")
+		if !ok {
+			return fmt.Errorf("field %s of %T has no path tag", fieldName, s)
+		}
+		var aps []string
+		for _, fp := range strings.Split(p, "|") {
+			elems := strings.Split(fp, "/")
+			elems[len(elems)-1] = "@" + elems[len(elems)-1]
+			aps = append(aps, strings.Join(elems, "/"))
+		}
+		wantPath = strings.Join(aps, "|")
+	}
+
+	annoT := reflect.TypeOf([]Annotation{})
+	for i := 0; i < sv.NumField(); i++ {
+		ft := sv.Type().Field(i)
+		if !util.IsYgotAnnotation(ft) || ft.Type != annoT || ft.Tag.Get("path") != wantPath {
+			continue
+		}
+		annos := sv.Field(i).Interface().([]Annotation)
+		for _, a := range annos {
+			if ma, ok := a.(*MetadataAnnotation); ok {
+				if ma.Values == nil {
+					ma.Values = map[string]interface{}{}
+				}
+				ma.Values[name] = value
+				return nil
+			}
+		}
+		sv.Field(i).Set(reflect.ValueOf(append(annos, &MetadataAnnotation{
+			Values: map[string]interface{}{name: value},
+		})))
+		return nil
+	}
+	return fmt.Errorf("%T has no annotation field with path %s", s, wantPath)
+}
@@ -1,9 +1,14 @@
 package ygot
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
 )
 
@@ -726,3 +731,985 @@ func TestPruneConfigFalse(t *testing.T) {
 		})
 	}
 }
+
+// configOnlyTestStruct is a GoStruct that represents a compressed container
+// with both configuration and state leaves.
+type configOnlyTestStruct struct {
+	Description  *string                                `path:"description"`
+	Mtu          *uint16                                `path:"mtu"`
+	OperStatus   *string                                `path:"oper-status"`
+	Subinterface map[uint32]*configOnlyTestSubinterface `path:"subinterfaces/subinterface"`
+}
+
+func (*configOnlyTestStruct) IsYANGGoStruct() {}
+
+type configOnlyTestSubinterface struct {
+	Index   *uint32 `path:"index"`
+	Enabled *bool   `path:"enabled"`
+	InPkts  *uint64 `path:"in-pkts"`
+}
+
+func (*configOnlyTestSubinterface) IsYANGGoStruct() {}
+
+// configOnlyExcludeStateStruct is a GoStruct that represents the same
+// container as configOnlyTestStruct, as generated with state excluded.
+type configOnlyExcludeStateStruct struct {
+	Description  *string                                        `path:"description"`
+	Mtu          *uint16                                        `path:"mtu"`
+	Subinterface map[uint32]*configOnlyExcludeStateSubinterface `path:"subinterfaces/subinterface"`
+}
+
+func (*configOnlyExcludeStateStruct) IsYANGGoStruct() {}
+
+type configOnlyExcludeStateSubinterface struct {
+	Index   *uint32 `path:"index"`
+	Enabled *bool   `path:"enabled"`
+}
+
+func (*configOnlyExcludeStateSubinterface) IsYANGGoStruct() {}
+
+func TestConfigOnly(t *testing.T) {
+	schema := &yang.Entry{
+		Name:   "interface",
+		Kind:   yang.DirectoryEntry,
+		Config: yang.TSTrue,
+		Dir: map[string]*yang.Entry{
+			"description": {
+				Name: "description",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			// mtu is a compressed applied configuration leaf, whose
+			// intended configuration sibling has been compressed out.
+			"mtu": {
+				Name:       "mtu",
+				Kind:       yang.LeafEntry,
+				Config:     yang.TSFalse,
+				Type:       &yang.YangType{Kind: yang.Yuint16},
+				Annotation: map[string]interface{}{GoCompressedLeafAnnotation: struct{}{}},
+			},
+			"oper-status": {
+				Name:   "oper-status",
+				Kind:   yang.LeafEntry,
+				Config: yang.TSFalse,
+				Type:   &yang.YangType{Kind: yang.Ystring},
+			},
+			"subinterfaces": {
+				Name: "subinterfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"subinterface": {
+						Name:     "subinterface",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "index",
+						Dir: map[string]*yang.Entry{
+							"index": {
+								Name: "index",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yuint32},
+							},
+							"enabled": {
+								Name: "enabled",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ybool},
+							},
+							"in-pkts": {
+								Name:   "in-pkts",
+								Kind:   yang.LeafEntry,
+								Config: yang.TSFalse,
+								Type:   &yang.YangType{Kind: yang.Yuint64},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(schema)
+
+	tests := []struct {
+		desc     string
+		inStruct func() *configOnlyTestStruct
+		want     *configOnlyExcludeStateStruct
+	}{{
+		desc:     "empty struct",
+		inStruct: func() *configOnlyTestStruct { return &configOnlyTestStruct{} },
+		want:     &configOnlyExcludeStateStruct{},
+	}, {
+		desc: "config and state populated",
+		inStruct: func() *configOnlyTestStruct {
+			return &configOnlyTestStruct{
+				Description: String("eth0"),
+				Mtu:         Uint16(1500),
+				OperStatus:  String("UP"),
+				Subinterface: map[uint32]*configOnlyTestSubinterface{
+					0: {Index: Uint32(0), Enabled: Bool(true), InPkts: Uint64(42)},
+					1: {Index: Uint32(1), InPkts: Uint64(84)},
+				},
+			}
+		},
+		want: &configOnlyExcludeStateStruct{
+			Description: String("eth0"),
+			Mtu:         Uint16(1500),
+			Subinterface: map[uint32]*configOnlyExcludeStateSubinterface{
+				0: {Index: Uint32(0), Enabled: Bool(true)},
+				1: {Index: Uint32(1)},
+			},
+		},
+	}, {
+		desc: "only state populated",
+		inStruct: func() *configOnlyTestStruct {
+			return &configOnlyTestStruct{OperStatus: String("UP")}
+		},
+		want: &configOnlyExcludeStateStruct{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in := tt.inStruct()
+			got, err := ConfigOnly(schema, in)
+			if err != nil {
+				t.Fatalf("ConfigOnly: got unexpected error: %v", err)
+			}
+
+			// The copy should contain the same data as a GoStruct generated
+			// with state excluded.
+			gotJSON, err := ConstructIETFJSON(got, nil)
+			if err != nil {
+				t.Fatalf("ConstructIETFJSON(got): got unexpected error: %v", err)
+			}
+			wantJSON, err := ConstructIETFJSON(tt.want, nil)
+			if err != nil {
+				t.Fatalf("ConstructIETFJSON(want): got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(wantJSON, gotJSON); diff != "" {
+				t.Errorf("ConfigOnly: did not get expected config, diff(-want, +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.inStruct(), in); diff != "" {
+				t.Errorf("ConfigOnly: input GoStruct was modified, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// mapStructTestFourSchema returns the schema that describes the
+// mapStructTestFour struct.
+func mapStructTestFourSchema() *yang.Entry {
+	schema := &yang.Entry{
+		Name: "map-struct-test-four",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"c": {
+				Name: "c",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"acl-set": {
+						Name:     "acl-set",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "name",
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yleafref},
+							},
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name": {
+										Name: "name",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+									"second-value": {
+										Name: "second-value",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+								},
+							},
+						},
+					},
+					"other-set": {
+						Name:     "other-set",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "name",
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yleafref},
+							},
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name": {
+										Name: "name",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Yenum},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(schema)
+	return schema
+}
+
+func TestForEachLeaf(t *testing.T) {
+	schema := mapStructTestFourSchema()
+
+	populated := &mapStructTestFour{
+		C: &mapStructTestFourC{
+			ACLSet: map[string]*mapStructTestFourCACLSet{
+				"n42": {Name: String("n42"), SecondValue: String("foo")},
+				"n84": {Name: String("n84")},
+			},
+			OtherSet: map[ECTest]*mapStructTestFourCOtherSet{
+				ECTestVALONE: {Name: ECTestVALONE},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc         string
+		inStruct     GoStruct
+		inErrAfter   int
+		wantLeaves   []string
+		wantErrCalls int
+		wantErr      bool
+	}{{
+		desc:     "nested lists",
+		inStruct: populated,
+		wantLeaves: []string{
+			"/c/acl-set[name=n42]/config/name: n42",
+			"/c/acl-set[name=n42]/config/second-value: foo",
+			"/c/acl-set[name=n42]/name: n42",
+			"/c/acl-set[name=n84]/config/name: n84",
+			"/c/acl-set[name=n84]/name: n84",
+			"/c/other-set[name=VAL_ONE]/config/name: VAL_ONE",
+			"/c/other-set[name=VAL_ONE]/name: VAL_ONE",
+		},
+	}, {
+		desc:     "unset enumerated leaves are skipped",
+		inStruct: &mapStructTestFour{C: &mapStructTestFourC{OtherSet: map[ECTest]*mapStructTestFourCOtherSet{ECTestVALTWO: {}}}},
+	}, {
+		desc:     "empty struct",
+		inStruct: &mapStructTestFour{},
+	}, {
+		desc:         "callback error stops iteration",
+		inStruct:     populated,
+		inErrAfter:   1,
+		wantErrCalls: 1,
+		wantErr:      true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []string
+			var calls int
+			err := ForEachLeaf(schema, tt.inStruct, func(path []string, value interface{}) error {
+				calls++
+				if tt.inErrAfter != 0 && calls >= tt.inErrAfter {
+					return fmt.Errorf("callback error")
+				}
+				got = append(got, fmt.Sprintf("/%s: %v", strings.Join(path, "/"), value))
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ForEachLeaf: got error %v, wantErr: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if calls != tt.wantErrCalls {
+					t.Errorf("ForEachLeaf: got %d calls to callback after error, want: %d", calls, tt.wantErrCalls)
+				}
+				return
+			}
+			sort.Strings(got)
+			if diff := cmp.Diff(tt.wantLeaves, got); diff != "" {
+				t.Errorf("ForEachLeaf: did not get expected leaves, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	populated := &mapStructTestFour{
+		C: &mapStructTestFourC{
+			ACLSet: map[string]*mapStructTestFourCACLSet{
+				"n42": {Name: String("n42"), SecondValue: String("foo")},
+			},
+			OtherSet: map[ECTest]*mapStructTestFourCOtherSet{
+				ECTestVALONE: {Name: ECTestVALONE},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		inStruct GoStruct
+		inOpts   []FlattenOpt
+		want     map[string]interface{}
+	}{{
+		desc:     "nested lists with enumerated key",
+		inStruct: populated,
+		want: map[string]interface{}{
+			"/c/acl-set[name=n42]/config/name":         "n42",
+			"/c/acl-set[name=n42]/config/second-value": "foo",
+			"/c/acl-set[name=n42]/name":                "n42",
+			"/c/other-set[name=VAL_ONE]/config/name":   ECTest(ECTestVALONE),
+			"/c/other-set[name=VAL_ONE]/name":          ECTest(ECTestVALONE),
+		},
+	}, {
+		desc:     "nested lists with enumerated key, formatted as RFC7951 strings",
+		inStruct: populated,
+		inOpts:   []FlattenOpt{&FlattenRFC7951Strings{}},
+		want: map[string]interface{}{
+			"/c/acl-set[name=n42]/config/name":         "n42",
+			"/c/acl-set[name=n42]/config/second-value": "foo",
+			"/c/acl-set[name=n42]/name":                "n42",
+			"/c/other-set[name=VAL_ONE]/config/name":   "VAL_ONE",
+			"/c/other-set[name=VAL_ONE]/name":          "VAL_ONE",
+		},
+	}, {
+		desc:     "empty struct",
+		inStruct: &mapStructTestFour{},
+		want:     map[string]interface{}{},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Flatten(mapStructTestFourSchema(), tt.inStruct, tt.inOpts...)
+			if err != nil {
+				t.Fatalf("Flatten: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Flatten: did not get expected map, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPopulatedChildren(t *testing.T) {
+	schema := mapStructTestFourSchema()
+
+	tests := []struct {
+		desc             string
+		inSchema         *yang.Entry
+		inStruct         GoStruct
+		want             []string
+		wantErrSubstring string
+	}{{
+		desc:     "present sub-container",
+		inSchema: schema,
+		inStruct: &mapStructTestFour{C: &mapStructTestFourC{}},
+		want:     []string{"c"},
+	}, {
+		desc:     "list entries with keys",
+		inSchema: schema.Dir["c"],
+		inStruct: &mapStructTestFourC{
+			ACLSet: map[string]*mapStructTestFourCACLSet{
+				"n84": {Name: String("n84")},
+				"n42": {Name: String("n42"), SecondValue: String("foo")},
+				"nil": nil,
+			},
+			OtherSet: map[ECTest]*mapStructTestFourCOtherSet{
+				ECTestVALONE: {Name: ECTestVALONE},
+			},
+		},
+		want: []string{
+			"acl-set[name=n42]",
+			"acl-set[name=n84]",
+			"other-set[name=VAL_ONE]",
+		},
+	}, {
+		desc:     "partially populated list entry with compressed leaves",
+		inSchema: schema.Dir["c"].Dir["acl-set"],
+		inStruct: &mapStructTestFourCACLSet{Name: String("n42")},
+		want:     []string{"config/name", "name"},
+	}, {
+		desc:     "list entry with unset enumerated leaf",
+		inSchema: schema.Dir["c"].Dir["other-set"],
+		inStruct: &mapStructTestFourCOtherSet{},
+	}, {
+		desc:     "empty struct",
+		inSchema: schema,
+		inStruct: &mapStructTestFour{},
+	}, {
+		desc:             "nil struct",
+		inSchema:         schema,
+		inStruct:         (*mapStructTestFour)(nil),
+		wantErrSubstring: "invalid GoStruct supplied to PopulatedChildren",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := PopulatedChildren(tt.inSchema, tt.inStruct)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("PopulatedChildren: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("PopulatedChildren: did not get expected paths, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListMemberPath(t *testing.T) {
+	tests := []struct {
+		desc   string
+		inPath string
+		inKeys map[string]interface{}
+		want   string
+	}{{
+		desc:   "string key",
+		inPath: "acl-set",
+		inKeys: map[string]interface{}{"name": "n42"},
+		want:   "acl-set[name=n42]",
+	}, {
+		desc:   "enumerated key",
+		inPath: "c/other-set",
+		inKeys: map[string]interface{}{"name": ECTest(ECTestVALONE)},
+		want:   "c/other-set[name=VAL_ONE]",
+	}, {
+		desc:   "int64 key output in default Go format",
+		inPath: "c/other-set",
+		inKeys: map[string]interface{}{"id": int64(-42)},
+		want:   "c/other-set[id=-42]",
+	}, {
+		desc:   "unsupported key output in default Go format",
+		inPath: "c/other-set",
+		inKeys: map[string]interface{}{"id": []string{"a", "b"}},
+		want:   "c/other-set[id=[a b]]",
+	}, {
+		desc:   "multiple keys sorted by name",
+		inPath: "multi-key",
+		inKeys: map[string]interface{}{"key2": uint64(2), "key1": uint32(1)},
+		want:   "multi-key[key1=1][key2=2]",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := ListMemberPath(tt.inPath, tt.inKeys); got != tt.want {
+				t.Errorf("ListMemberPath(%s, %v): got %s, want: %s", tt.inPath, tt.inKeys, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRFC7951LeafStrings(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   interface{}
+		want interface{}
+	}{{
+		desc: "int64",
+		in:   int64(42),
+		want: "42",
+	}, {
+		desc: "bool",
+		in:   true,
+		want: "true",
+	}, {
+		desc: "enumeration",
+		in:   ECTest(ECTestVALTWO),
+		want: "VAL_TWO",
+	}, {
+		desc: "binary",
+		in:   Binary("abc"),
+		want: "YWJj",
+	}, {
+		desc: "leaf-list of enumerations",
+		in:   []ECTest{ECTestVALONE, ECTestVALTWO},
+		want: []string{"VAL_ONE", "VAL_TWO"},
+	}, {
+		desc: "leaf-list of uint32",
+		in:   []uint32{1, 2},
+		want: []string{"1", "2"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := rfc7951LeafStrings(tt.in)
+			if err != nil {
+				t.Fatalf("rfc7951LeafStrings(%v): got unexpected error: %v", tt.in, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("rfc7951LeafStrings(%v): did not get expected value, diff(-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+// leaflistDefaultThree is a test enumerated type representing the
+// enumeration of the three leaf-list in openconfig-leaflist-default.yang.
+type leaflistDefaultThree int64
+
+func (leaflistDefaultThree) IsYANGGoEnum() {}
+
+func (leaflistDefaultThree) ΛMap() map[string]map[int64]EnumDefinition {
+	return map[string]map[int64]EnumDefinition{
+		"leaflistDefaultThree": {
+			1: {Name: "ONE"},
+			2: {Name: "TWO"},
+		},
+	}
+}
+
+func (e leaflistDefaultThree) String() string {
+	return EnumLogString(e, int64(e), "leaflistDefaultThree")
+}
+
+// leaflistDefaultBinary is a test type representing a YANG binary value.
+type leaflistDefaultBinary []byte
+
+type leaflistDefaultParent struct {
+	Child *leaflistDefaultChild `path:"child"`
+}
+
+func (*leaflistDefaultParent) IsYANGGoStruct() {}
+
+type leaflistDefaultChild struct {
+	Four  []leaflistDefaultBinary `path:"config/four"`
+	One   []string                `path:"config/one"`
+	Three []leaflistDefaultThree  `path:"config/three"`
+	Two   []string                `path:"state/two"`
+}
+
+func (*leaflistDefaultChild) IsYANGGoStruct() {}
+
+func TestPopulateDefaultsLeafListSchema(t *testing.T) {
+	ms := yang.NewModules()
+	if err := ms.Read("../testdata/modules/openconfig-leaflist-default.yang"); err != nil {
+		t.Fatalf("cannot read module: %v", err)
+	}
+	if errs := ms.Process(); errs != nil {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	mod, errs := ms.GetModule("openconfig-leaflist-default")
+	if errs != nil {
+		t.Fatalf("cannot get module: %v", errs)
+	}
+	schema := mod.Dir["parent"]
+
+	tests := []struct {
+		name     string
+		inStruct *leaflistDefaultParent
+		want     *leaflistDefaultParent
+	}{{
+		name:     "absent container is not created",
+		inStruct: &leaflistDefaultParent{},
+		want:     &leaflistDefaultParent{},
+	}, {
+		name:     "defaults populated in present container",
+		inStruct: &leaflistDefaultParent{Child: &leaflistDefaultChild{}},
+		want: &leaflistDefaultParent{Child: &leaflistDefaultChild{
+			Four:  []leaflistDefaultBinary{leaflistDefaultBinary("abc0")},
+			Three: []leaflistDefaultThree{1, 2},
+			Two:   []string{"foo", "foo", "bar", "bar", "baz", "baz"},
+		}},
+	}, {
+		name: "set leaf-lists are not overwritten",
+		inStruct: &leaflistDefaultParent{Child: &leaflistDefaultChild{
+			Three: []leaflistDefaultThree{2},
+			Two:   []string{},
+		}},
+		want: &leaflistDefaultParent{Child: &leaflistDefaultChild{
+			Four:  []leaflistDefaultBinary{leaflistDefaultBinary("abc0")},
+			Three: []leaflistDefaultThree{2},
+			Two:   []string{},
+		}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := PopulateDefaults(schema, tt.inStruct); err != nil {
+				t.Fatalf("PopulateDefaults: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, tt.inStruct); diff != "" {
+				t.Errorf("PopulateDefaults: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// defaultsUnion is a test union interface type.
+type defaultsUnion interface {
+	IsDefaultsUnion()
+}
+
+func (leaflistDefaultThree) IsDefaultsUnion() {}
+
+// defaultsUnionUint32 is a test uint32 type assignable to defaultsUnion.
+type defaultsUnionUint32 uint32
+
+func (defaultsUnionUint32) IsDefaultsUnion() {}
+
+type defaultsRoot struct {
+	Leaf     *uint32                           `path:"leaf"`
+	Enum     leaflistDefaultThree              `path:"enum"`
+	Union    defaultsUnion                     `path:"union"`
+	UnionInt defaultsUnion                     `path:"union-int"`
+	List     map[string]*defaultsRootListEntry `path:"list"`
+}
+
+func (*defaultsRoot) IsYANGGoStruct()                    {}
+func (*defaultsRoot) Validate(...ValidationOption) error { return nil }
+func (*defaultsRoot) ΛBelongingModule() string           { return "" }
+func (*defaultsRoot) ΛEnumTypeMap() map[string][]reflect.Type {
+	return map[string][]reflect.Type{
+		"/root/enum":      {reflect.TypeOf(leaflistDefaultThree(0))},
+		"/root/union":     {reflect.TypeOf(leaflistDefaultThree(0))},
+		"/root/union-int": {reflect.TypeOf(leaflistDefaultThree(0))},
+	}
+}
+
+func (*defaultsRoot) To_defaultsUnion(i interface{}) (defaultsUnion, error) {
+	if v, ok := i.(defaultsUnion); ok {
+		return v, nil
+	}
+	switch v := i.(type) {
+	case uint32:
+		return defaultsUnionUint32(v), nil
+	}
+	return nil, fmt.Errorf("cannot convert %v to defaultsUnion, unknown union type, got: %T", i, i)
+}
+
+type defaultsRootListEntry struct {
+	Name  *string `path:"name"`
+	Value *string `path:"value"`
+}
+
+func (*defaultsRootListEntry) IsYANGGoStruct() {}
+
+func TestPopulateDefaults(t *testing.T) {
+	unionType := &yang.YangType{
+		Kind: yang.Yunion,
+		Type: []*yang.YangType{
+			{Kind: yang.Yenum},
+			{Kind: yang.Yuint32},
+		},
+	}
+	schema := &yang.Entry{
+		Name: "root",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"leaf": {
+				Name:    "leaf",
+				Kind:    yang.LeafEntry,
+				Type:    &yang.YangType{Kind: yang.Yuint32},
+				Default: []string{"42"},
+			},
+			"enum": {
+				Name:    "enum",
+				Kind:    yang.LeafEntry,
+				Type:    &yang.YangType{Kind: yang.Yenum},
+				Default: []string{"TWO"},
+			},
+			"union": {
+				Name:    "union",
+				Kind:    yang.LeafEntry,
+				Type:    unionType,
+				Default: []string{"ONE"},
+			},
+			"union-int": {
+				Name:    "union-int",
+				Kind:    yang.LeafEntry,
+				Type:    unionType,
+				Default: []string{"128"},
+			},
+			"list": {
+				Name:     "list",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "name",
+				Dir: map[string]*yang.Entry{
+					"name": {
+						Name: "name",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"value": {
+						Name:    "value",
+						Kind:    yang.LeafEntry,
+						Type:    &yang.YangType{Kind: yang.Ystring},
+						Default: []string{"dflt"},
+					},
+				},
+			},
+		},
+	}
+	addParents(schema)
+
+	tests := []struct {
+		name             string
+		inSchema         *yang.Entry
+		inStruct         *defaultsRoot
+		want             *defaultsRoot
+		wantErrSubstring string
+	}{{
+		name:     "unset leaves populated",
+		inSchema: schema,
+		inStruct: &defaultsRoot{
+			List: map[string]*defaultsRootListEntry{
+				"one": {Name: String("one")},
+				"two": {Name: String("two"), Value: String("set")},
+			},
+		},
+		want: &defaultsRoot{
+			Leaf:     Uint32(42),
+			Enum:     2,
+			Union:    leaflistDefaultThree(1),
+			UnionInt: defaultsUnionUint32(128),
+			List: map[string]*defaultsRootListEntry{
+				"one": {Name: String("one"), Value: String("dflt")},
+				"two": {Name: String("two"), Value: String("set")},
+			},
+		},
+	}, {
+		name:     "set leaves retained",
+		inSchema: schema,
+		inStruct: &defaultsRoot{
+			Leaf:     Uint32(1),
+			Enum:     1,
+			Union:    defaultsUnionUint32(2),
+			UnionInt: leaflistDefaultThree(2),
+		},
+		want: &defaultsRoot{
+			Leaf:     Uint32(1),
+			Enum:     1,
+			Union:    defaultsUnionUint32(2),
+			UnionInt: leaflistDefaultThree(2),
+		},
+	}, {
+		name:             "nil schema",
+		inStruct:         &defaultsRoot{},
+		wantErrSubstring: "nil schema",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := PopulateDefaults(tt.inSchema, tt.inStruct)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("PopulateDefaults: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.inStruct); diff != "" {
+				t.Errorf("PopulateDefaults: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStructForPath(t *testing.T) {
+	listSchema := mustModuleSchema(t, "openconfig-withlist")
+	simpleSchema := mustModuleSchema(t, "openconfig-simple")
+
+	listRoot := &mergeNotifListDevice{
+		SingleKey: map[string]*mergeNotifSingleKey{
+			"foo": {Key: String("foo")},
+			"bar": {Key: String("bar")},
+		},
+		MultiKey: map[mergeNotifMultiKeyKey]*mergeNotifMultiKey{
+			{Key1: 1, Key2: 2}: {Key1: Uint32(1), Key2: Uint64(2)},
+			{Key1: 3, Key2: 4}: {Key1: Uint32(3), Key2: Uint64(4)},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		inSchema         *yang.Entry
+		inRoot           GoStruct
+		inPath           string
+		want             GoStruct
+		wantErrSubstring string
+	}{{
+		name:     "leaf within single-keyed list member",
+		inSchema: listSchema,
+		inRoot:   listRoot,
+		inPath:   "/model/a/single-key[key=foo]/config/key",
+		want: &mergeNotifListDevice{
+			SingleKey: map[string]*mergeNotifSingleKey{
+				"foo": {Key: String("foo")},
+			},
+		},
+	}, {
+		name:     "multi-keyed list member",
+		inSchema: listSchema,
+		inRoot:   listRoot,
+		inPath:   "/model/b/multi-key[key1=3][key2=4]",
+		want: &mergeNotifListDevice{
+			MultiKey: map[mergeNotifMultiKeyKey]*mergeNotifMultiKey{
+				{Key1: 3, Key2: 4}: {Key1: Uint32(3), Key2: Uint64(4)},
+			},
+		},
+	}, {
+		name:     "whole list",
+		inSchema: listSchema,
+		inRoot:   listRoot,
+		inPath:   "/model/a/single-key",
+		want: &mergeNotifListDevice{
+			SingleKey: map[string]*mergeNotifSingleKey{
+				"foo": {Key: String("foo")},
+				"bar": {Key: String("bar")},
+			},
+		},
+	}, {
+		name:     "empty path",
+		inSchema: listSchema,
+		inRoot:   listRoot,
+		inPath:   "/",
+		want:     listRoot,
+	}, {
+		name:     "container removed by compression",
+		inSchema: simpleSchema,
+		inRoot: &mergeNotifDevice{
+			Parent: &mergeNotifParent{Child: &mergeNotifChild{
+				One:   String("foo"),
+				Three: 1,
+				Two:   String("bar"),
+			}},
+			RemoteContainer: &mergeNotifRemote{ALeaf: String("baz")},
+		},
+		inPath: "/parent/child/config",
+		want: &mergeNotifDevice{
+			Parent: &mergeNotifParent{Child: &mergeNotifChild{
+				One:   String("foo"),
+				Three: 1,
+			}},
+		},
+	}, {
+		name:             "list member that does not exist",
+		inSchema:         listSchema,
+		inRoot:           listRoot,
+		inPath:           "/model/a/single-key[key=baz]/config/key",
+		wantErrSubstring: "does not exist",
+	}, {
+		name:             "missing list keys",
+		inSchema:         listSchema,
+		inRoot:           listRoot,
+		inPath:           "/model/a/single-key/config/key",
+		wantErrSubstring: "keys must be specified",
+	}, {
+		name:             "unknown path",
+		inSchema:         listSchema,
+		inRoot:           listRoot,
+		inPath:           "/model/c",
+		wantErrSubstring: "does not contain a field matching",
+	}, {
+		name:             "nil schema",
+		inRoot:           listRoot,
+		inPath:           "/model",
+		wantErrSubstring: "nil schema",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StructForPath(tt.inSchema, tt.inRoot, mustPath(t, tt.inPath))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("StructForPath: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("StructForPath (-want, +got):\n%s", diff)
+			}
+			if got == tt.inRoot {
+				t.Errorf("StructForPath returned the input struct, want a new struct")
+			}
+		})
+	}
+}
+
+type metadataTestStruct struct {
+	ΛMetadata []Annotation `path:"@" ygotAnnotation:"true"`
+	Name      *string      `path:"config/name|name"`
+	ΛName     []Annotation `path:"config/@name|@name" ygotAnnotation:"true"`
+	Mtu       *uint16      `path:"mtu"`
+}
+
+func (*metadataTestStruct) IsYANGGoStruct() {}
+
+func TestAttachMetadata(t *testing.T) {
+	type attachment struct {
+		fieldName string
+		name      string
+		value     interface{}
+	}
+	tests := []struct {
+		desc             string
+		in               *metadataTestStruct
+		inAttach         []attachment
+		want             *metadataTestStruct
+		wantErrSubstring string
+	}{{
+		desc:     "metadata attached to field",
+		in:       &metadataTestStruct{},
+		inAttach: []attachment{{"Name", "ietf-origin:origin", "ietf-origin:intended"}},
+		want: &metadataTestStruct{
+			ΛName: []Annotation{&MetadataAnnotation{Values: map[string]interface{}{"ietf-origin:origin": "ietf-origin:intended"}}},
+		},
+	}, {
+		desc: "metadata merged into existing metadata annotation",
+		in: &metadataTestStruct{
+			ΛName: []Annotation{&MetadataAnnotation{Values: map[string]interface{}{"ietf-origin:origin": "ietf-origin:intended"}}},
+		},
+		inAttach: []attachment{{"Name", "ietf-netconf-with-defaults:default", true}},
+		want: &metadataTestStruct{
+			ΛName: []Annotation{&MetadataAnnotation{Values: map[string]interface{}{
+				"ietf-origin:origin":                 "ietf-origin:intended",
+				"ietf-netconf-with-defaults:default": true,
+			}}},
+		},
+	}, {
+		desc:     "metadata attached to struct",
+		in:       &metadataTestStruct{},
+		inAttach: []attachment{{"", "ietf-origin:origin", "ietf-origin:system"}},
+		want: &metadataTestStruct{
+			ΛMetadata: []Annotation{&MetadataAnnotation{Values: map[string]interface{}{"ietf-origin:origin": "ietf-origin:system"}}},
+		},
+	}, {
+		desc:             "field without annotation field",
+		in:               &metadataTestStruct{},
+		inAttach:         []attachment{{"Mtu", "ietf-origin:origin", "ietf-origin:system"}},
+		wantErrSubstring: "no annotation field with path @mtu",
+	}, {
+		desc:             "unknown field",
+		in:               &metadataTestStruct{},
+		inAttach:         []attachment{{"Description", "ietf-origin:origin", "ietf-origin:system"}},
+		wantErrSubstring: "has no field named Description",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var err error
+			for _, a := range tt.inAttach {
+				if err = AttachMetadata(tt.in, a.fieldName, a.name, a.value); err != nil {
+					break
+				}
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("AttachMetadata: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.in); diff != "" {
+				t.Errorf("AttachMetadata: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// MergeIETFJSON merges the RFC7951 JSON documents a and b, using schema, which
+// describes the root of both documents, to determine how each member is
+// merged. Unlike MergeJSON, which concatenates the arrays found in both
+// inputs, the members of keyed lists are merged with the member of the other
+// document that has the same keys, and leaf-lists contain the values found in
+// either document once. Containers are merged recursively, and an error is
+// returned if a leaf, or an RFC7951 metadata member, has different values in
+// a and b. The members of keyless lists, which cannot be identified, are
+// concatenated.
+//
+// Member names may be qualified with the name of their module, and members
+// of a and b that correspond to the same schema node are merged regardless of
+// whether their names are qualified. The name used in a is retained in the
+// output. The documents are not otherwise validated against schema, for which
+// ValidateJSON can be used. The merged document is returned indented in the
+// same manner as EmitJSON.
+func MergeIETFJSON(schema *yang.Entry, a, b []byte) ([]byte, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("nil schema supplied")
+	}
+
+	ja, err := decodeJSONDocument(a)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode a: %v", err)
+	}
+	jb, err := decodeJSONDocument(b)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode b: %v", err)
+	}
+
+	o, err := mergeIETFJSONObject(schema, ja, jb, "")
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(o, "", "  ")
+}
+
+// mergeIETFJSONObject merges the JSON values a and b, at the JSON pointer
+// ptr, which must be objects representing the container or list member
+// described by schema.
+func mergeIETFJSONObject(schema *yang.Entry, a, b interface{}, ptr string) (map[string]interface{}, error) {
+	ao, ok := a.(map[string]interface{})
+	if !ok {
+		return nil, jsonPointerErrorf(ptr, "got %s in a, want object for %s", jsonTypeName(a), schema.Name)
+	}
+	bo, ok := b.(map[string]interface{})
+	if !ok {
+		return nil, jsonPointerErrorf(ptr, "got %s in b, want object for %s", jsonTypeName(b), schema.Name)
+	}
+
+	// Index the members of a by the schema node, or annotated member, that
+	// they correspond to, such that the members of b can be matched to
+	// them regardless of module qualification.
+	o := map[string]interface{}{}
+	nodes := map[*yang.Entry]string{}
+	metadata := map[string]string{}
+	for k, v := range ao {
+		o[k] = v
+		if strings.HasPrefix(k, "@") {
+			metadata[util.StripModulePrefix(strings.TrimPrefix(k, "@"))] = k
+			continue
+		}
+		cs := util.FirstChild(schema, []string{util.StripModulePrefix(k)})
+		if cs == nil {
+			return nil, jsonPointerErrorf(jsonPointerAppend(ptr, k), "unknown member %s of %s", k, schema.Name)
+		}
+		nodes[cs] = k
+	}
+
+	names := make([]string, 0, len(bo))
+	for k := range bo {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		v := bo[k]
+		if strings.HasPrefix(k, "@") {
+			ak, ok := metadata[util.StripModulePrefix(strings.TrimPrefix(k, "@"))]
+			switch {
+			case !ok:
+				o[k] = v
+			case !reflect.DeepEqual(o[ak], v):
+				return nil, jsonPointerErrorf(jsonPointerAppend(ptr, ak), "conflicting metadata values %v and %v", o[ak], v)
+			}
+			continue
+		}
+
+		cs := util.FirstChild(schema, []string{util.StripModulePrefix(k)})
+		if cs == nil {
+			return nil, jsonPointerErrorf(jsonPointerAppend(ptr, k), "unknown member %s of %s", k, schema.Name)
+		}
+		ak, ok := nodes[cs]
+		if !ok {
+			o[k] = v
+			continue
+		}
+		var err error
+		if o[ak], err = mergeIETFJSONNode(cs, o[ak], v, jsonPointerAppend(ptr, ak)); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// mergeIETFJSONNode merges the JSON values a and b, at the JSON pointer ptr,
+// which represent the data tree node described by schema.
+func mergeIETFJSONNode(schema *yang.Entry, a, b interface{}, ptr string) (interface{}, error) {
+	switch {
+	case schema.Kind == yang.AnyDataEntry, schema.IsLeaf():
+		if !reflect.DeepEqual(a, b) {
+			return nil, jsonPointerErrorf(ptr, "conflicting values %v and %v for %s", a, b, schema.Name)
+		}
+		return a, nil
+	case schema.IsLeafList():
+		av, ok := a.([]interface{})
+		if !ok {
+			return nil, jsonPointerErrorf(ptr, "got %s in a, want array for leaf-list %s", jsonTypeName(a), schema.Name)
+		}
+		bv, ok := b.([]interface{})
+		if !ok {
+			return nil, jsonPointerErrorf(ptr, "got %s in b, want array for leaf-list %s", jsonTypeName(b), schema.Name)
+		}
+		o := append([]interface{}{}, av...)
+		for _, v := range bv {
+			if !containsJSONValue(o, v) {
+				o = append(o, v)
+			}
+		}
+		return o, nil
+	case schema.IsList():
+		return mergeIETFJSONList(schema, a, b, ptr)
+	default:
+		return mergeIETFJSONObject(schema, a, b, ptr)
+	}
+}
+
+// mergeIETFJSONList merges the JSON values a and b, at the JSON pointer ptr,
+// which must be arrays of the members of the list described by schema. The
+// members of a are output in their original order, followed by the members
+// of b that do not have the same keys as a member of a.
+func mergeIETFJSONList(schema *yang.Entry, a, b interface{}, ptr string) ([]interface{}, error) {
+	av, ok := a.([]interface{})
+	if !ok {
+		return nil, jsonPointerErrorf(ptr, "got %s in a, want array for list %s", jsonTypeName(a), schema.Name)
+	}
+	bv, ok := b.([]interface{})
+	if !ok {
+		return nil, jsonPointerErrorf(ptr, "got %s in b, want array for list %s", jsonTypeName(b), schema.Name)
+	}
+
+	o := append([]interface{}{}, av...)
+	keys := strings.Fields(schema.Key)
+	if len(keys) == 0 {
+		return append(o, bv...), nil
+	}
+
+	index := map[string]int{}
+	for i, m := range av {
+		k, err := jsonListMemberKey(schema, keys, m)
+		if err != nil {
+			return nil, jsonPointerErrorf(jsonPointerAppend(ptr, strconv.Itoa(i)), "in a, %v", err)
+		}
+		if _, ok := index[k]; !ok {
+			index[k] = i
+		}
+	}
+
+	for i, m := range bv {
+		k, err := jsonListMemberKey(schema, keys, m)
+		if err != nil {
+			return nil, jsonPointerErrorf(jsonPointerAppend(ptr, strconv.Itoa(i)), "in b, %v", err)
+		}
+		j, ok := index[k]
+		if !ok {
+			index[k] = len(o)
+			o = append(o, m)
+			continue
+		}
+		if o[j], err = mergeIETFJSONObject(schema, o[j], m, jsonPointerAppend(ptr, strconv.Itoa(j))); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// jsonListMemberKey returns a string that uniquely identifies the values of
+// the keys of the member m of the list described by schema, whose keys are
+// named keys.
+func jsonListMemberKey(schema *yang.Entry, keys []string, m interface{}) (string, error) {
+	obj, ok := m.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("got %s, want object for member of list %s", jsonTypeName(m), schema.Name)
+	}
+
+	vals := make([]interface{}, len(keys))
+	for i, key := range keys {
+		var found bool
+		for k, v := range obj {
+			if util.StripModulePrefix(k) == key {
+				vals[i], found = v, true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("member of list %s is missing key %s", schema.Name, key)
+		}
+	}
+	k, err := json.Marshal(vals)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal keys of member of list %s: %v", schema.Name, err)
+	}
+	return string(k), nil
+}
+
+// containsJSONValue returns true if the decoded JSON value v is within vals.
+func containsJSONValue(vals []interface{}, v interface{}) bool {
+	for _, e := range vals {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
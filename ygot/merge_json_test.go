@@ -0,0 +1,223 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func TestMergeIETFJSON(t *testing.T) {
+	schema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"interfaces": {
+				Name: "interfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"interface": {
+						Name:     "interface",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "name",
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yleafref, Path: "../config/name"},
+							},
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name": {
+										Name: "name",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+									"mtu": {
+										Name: "mtu",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Yuint16},
+									},
+									"description": {
+										Name: "description",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"tags": {
+				Name:     "tags",
+				Kind:     yang.LeafEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Type:     &yang.YangType{Kind: yang.Ystring},
+			},
+			"log": {
+				Name:     "log",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Dir: map[string]*yang.Entry{
+					"msg": {
+						Name: "msg",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+				},
+			},
+		},
+	}
+	addParents(schema)
+
+	tests := []struct {
+		desc             string
+		inSchema         *yang.Entry
+		inA              string
+		inB              string
+		want             string
+		wantErrSubstring string
+	}{{
+		desc:     "configs sharing a keyed list entry",
+		inSchema: schema,
+		inA: `{
+  "m:interfaces": {
+    "interface": [
+      {"name": "eth0", "config": {"name": "eth0", "mtu": 1500}},
+      {"name": "eth1", "config": {"name": "eth1"}}
+    ]
+  }
+}`,
+		inB: `{
+  "m:interfaces": {
+    "interface": [
+      {"name": "eth0", "config": {"name": "eth0", "description": "uplink"}},
+      {"name": "eth2", "config": {"name": "eth2"}}
+    ]
+  }
+}`,
+		want: `{
+  "m:interfaces": {
+    "interface": [
+      {"name": "eth0", "config": {"name": "eth0", "mtu": 1500, "description": "uplink"}},
+      {"name": "eth1", "config": {"name": "eth1"}},
+      {"name": "eth2", "config": {"name": "eth2"}}
+    ]
+  }
+}`,
+	}, {
+		desc:     "disjoint members",
+		inSchema: schema,
+		inA:      `{"m:interfaces": {"interface": [{"name": "eth0"}]}}`,
+		inB:      `{"m:tags": ["one"]}`,
+		want:     `{"m:interfaces": {"interface": [{"name": "eth0"}]}, "m:tags": ["one"]}`,
+	}, {
+		desc:     "module qualified and unqualified names",
+		inSchema: schema,
+		inA:      `{"m:interfaces": {"interface": [{"name": "eth0", "config": {"mtu": 1500}}]}}`,
+		inB:      `{"interfaces": {"m:interface": [{"m:name": "eth0", "config": {"m:mtu": 1500}}]}}`,
+		want:     `{"m:interfaces": {"interface": [{"name": "eth0", "config": {"mtu": 1500}}]}}`,
+	}, {
+		desc:     "leaf-list values are not duplicated",
+		inSchema: schema,
+		inA:      `{"tags": ["one", "two"]}`,
+		inB:      `{"tags": ["two", "three"]}`,
+		want:     `{"tags": ["one", "two", "three"]}`,
+	}, {
+		desc:     "keyless list members are concatenated",
+		inSchema: schema,
+		inA:      `{"log": [{"msg": "hello"}]}`,
+		inB:      `{"log": [{"msg": "hello"}]}`,
+		want:     `{"log": [{"msg": "hello"}, {"msg": "hello"}]}`,
+	}, {
+		desc:     "metadata",
+		inSchema: schema,
+		inA:      `{"tags": ["one"], "@tags": [{"m:origin": "a"}]}`,
+		inB:      `{"@m:tags": [{"m:origin": "a"}], "@log": [{"m:origin": "b"}]}`,
+		want:     `{"tags": ["one"], "@tags": [{"m:origin": "a"}], "@log": [{"m:origin": "b"}]}`,
+	}, {
+		desc:             "conflicting leaf values",
+		inSchema:         schema,
+		inA:              `{"interfaces": {"interface": [{"name": "eth0", "config": {"mtu": 1500}}]}}`,
+		inB:              `{"interfaces": {"interface": [{"name": "eth0", "config": {"mtu": 9000}}]}}`,
+		wantErrSubstring: `invalid JSON at "/interfaces/interface/0/config/mtu": conflicting values 1500 and 9000 for mtu`,
+	}, {
+		desc:             "conflicting metadata values",
+		inSchema:         schema,
+		inA:              `{"@tags": [{"m:origin": "a"}]}`,
+		inB:              `{"@tags": [{"m:origin": "b"}]}`,
+		wantErrSubstring: "conflicting metadata values",
+	}, {
+		desc:             "list member missing key",
+		inSchema:         schema,
+		inA:              `{"interfaces": {"interface": [{"name": "eth0"}]}}`,
+		inB:              `{"interfaces": {"interface": [{"config": {"mtu": 1500}}]}}`,
+		wantErrSubstring: `invalid JSON at "/interfaces/interface/0": in b, member of list interface is missing key name`,
+	}, {
+		desc:             "unknown member",
+		inSchema:         schema,
+		inA:              `{"interfaces": {}}`,
+		inB:              `{"routing": {}}`,
+		wantErrSubstring: "unknown member routing of device",
+	}, {
+		desc:             "mismatched JSON types",
+		inSchema:         schema,
+		inA:              `{"interfaces": {"interface": [{"name": "eth0"}]}}`,
+		inB:              `{"interfaces": {"interface": {"name": "eth0"}}}`,
+		wantErrSubstring: "got object in b, want array for list interface",
+	}, {
+		desc:             "invalid JSON",
+		inSchema:         schema,
+		inA:              `{}`,
+		inB:              `{`,
+		wantErrSubstring: "cannot decode b: invalid JSON document",
+	}, {
+		desc:             "nil schema",
+		inA:              `{}`,
+		inB:              `{}`,
+		wantErrSubstring: "nil schema supplied",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := MergeIETFJSON(tt.inSchema, []byte(tt.inA), []byte(tt.inB))
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal(got, &gotJSON); err != nil {
+				t.Fatalf("cannot unmarshal merged JSON, %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Fatalf("cannot unmarshal wanted JSON, %v", err)
+			}
+			if diff := cmp.Diff(wantJSON, gotJSON); diff != "" {
+				t.Errorf("did not get expected merged JSON, (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,726 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// MergeNotification applies the deletes and updates within the gNMI
+// Notification n to the GoStruct dst, which is described by the schema
+// supplied. As per the gNMI specification, the deletes within the
+// Notification are processed prior to the updates. The paths of the deletes
+// and updates are joined with the prefix of the Notification, and must be
+// expressed using PathElem messages.
+//
+// Updates to leaves and leaf-lists may be specified using scalar TypedValue
+// messages, which are parsed according to the type of the field, such that
+// string values can be used for enumerated and union fields. Updates to any
+// node may be specified using JSON or RFC7951 JSON TypedValue messages. List
+// members that do not exist within dst are created.
+//
+// If a field within dst is already populated with a value that differs from
+// that within an update, an error is returned, unless the
+// MergeOverwriteExistingFields option is specified. Deleting a path that does
+// not exist within dst is not considered an error.
+func MergeNotification(schema *yang.Entry, dst GoStruct, n *gnmipb.Notification, opts ...MergeOpt) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema supplied for struct %T", dst)
+	}
+	if util.IsValueNil(dst) {
+		return fmt.Errorf("nil destination struct supplied")
+	}
+	dv := reflect.ValueOf(dst)
+	if !util.IsValueStructPtr(dv) {
+		return fmt.Errorf("destination %T is not a struct pointer", dst)
+	}
+
+	var errs util.Errors
+	for _, d := range n.GetDelete() {
+		p, err := util.JoinPaths(n.GetPrefix(), d)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if err := deleteNotificationPath(schema, dv, p.GetElem()); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("cannot delete path %s: %v", pathElemString(p.GetElem()), err))
+		}
+	}
+
+	overwrite := fieldOverwriteEnabled(opts)
+	for _, u := range n.GetUpdate() {
+		p, err := util.JoinPaths(n.GetPrefix(), u.GetPath())
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if err := mergeNotificationUpdate(schema, dv, p.GetElem(), u.GetVal(), overwrite); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("cannot apply update to path %s: %v", pathElemString(p.GetElem()), err))
+		}
+	}
+
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// notificationTarget describes a field of a GoStruct that is addressed by a
+// gNMI path.
+type notificationTarget struct {
+	// schema is the schema of the field.
+	schema *yang.Entry
+	// parent is the struct pointer that contains the field.
+	parent reflect.Value
+	// sf is the struct field of parent that is the field.
+	sf reflect.StructField
+	// field is the value of the field.
+	field reflect.Value
+	// key is the key of the member of the map field that is addressed by
+	// the path. It is invalid if the path addresses the field itself.
+	key reflect.Value
+	// stores is the set of functions that store the copies of the list
+	// members containing the field back into their lists, innermost first,
+	// where the members of the lists are stored by value.
+	stores []func()
+}
+
+// fixedArray reports whether the field of t is a leaf-list that is stored as
+// a fixed-size array, whose number of populated elements is stored in
+// another field of the parent struct.
+func (t *notificationTarget) fixedArray() bool {
+	_, ok := util.YgotArrayLenField(t.sf)
+	return ok
+}
+
+// store stores the copies of the list members that contain the field of t
+// back into their lists, where the members of the lists are stored by value.
+// It must be called once the field has been modified.
+func (t *notificationTarget) store() {
+	for _, f := range t.stores {
+		f()
+	}
+}
+
+// notificationLeafUpdate is an update to a single leaf or leaf-list, whose
+// value is expressed in its YANG lexical form.
+type notificationLeafUpdate struct {
+	// path is the path of the leaf.
+	path []*gnmipb.PathElem
+	// values is the value of the leaf, or the values of the leaf-list.
+	values []string
+}
+
+// resolveNotificationPath returns the fields of the struct pointer sv,
+// described by schema, that are addressed by the path elems. The path must
+// address a leaf, leaf-list, container, list or list member. If create is
+// set to true, any containers and list members along the path that do not
+// exist are created, otherwise nil is returned if the path does not exist.
+// If the path addresses a prefix of the paths of fields, as is the case for
+// containers that are removed from compressed schemas, all such fields are
+// returned.
+func resolveNotificationPath(schema *yang.Entry, sv reflect.Value, elems []*gnmipb.PathElem, create bool) ([]*notificationTarget, error) {
+	v := sv.Elem()
+	t := v.Type()
+
+	var partial []*notificationTarget
+	for i := 0; i < t.NumField(); i++ {
+		sf, fv := t.Field(i), v.Field(i)
+		if util.IsYgotAnnotation(sf) || util.IsYgotMetadata(sf) || util.IsYgotArrayLen(sf) {
+			continue
+		}
+
+		paths, err := util.SchemaPaths(sf)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range paths {
+			n, ok := matchNotificationPath(p, elems)
+			if !ok {
+				continue
+			}
+
+			cs, err := util.ChildSchema(schema, sf)
+			if err != nil {
+				return nil, err
+			}
+			if cs == nil {
+				return nil, fmt.Errorf("could not find schema for field %s of %T", sf.Name, sv.Interface())
+			}
+
+			if n < len(p) {
+				// The path addresses an element that is not represented
+				// in the struct, and hence the field is within it.
+				partial = append(partial, &notificationTarget{schema: cs, parent: sv, sf: sf, field: fv})
+				break
+			}
+			return resolveNotificationField(cs, sv, sf, elems[n-1], elems[n:], create)
+		}
+	}
+
+	if partial != nil {
+		return partial, nil
+	}
+	return nil, fmt.Errorf("%T does not contain a field matching %s", sv.Interface(), pathElemString(elems))
+}
+
+// matchNotificationPath determines whether the schema path p of a field
+// matches the path elems. It returns the number of elements of elems that
+// are consumed by p, which is less than the length of p if elems is a prefix
+// of p. Only the last element of p may have keys specified.
+func matchNotificationPath(p []string, elems []*gnmipb.PathElem) (int, bool) {
+	if len(elems) == 0 {
+		return 0, false
+	}
+	n := len(p)
+	if len(elems) < n {
+		n = len(elems)
+	}
+	for i := 0; i < n; i++ {
+		if util.StripModulePrefix(elems[i].GetName()) != p[i] {
+			return 0, false
+		}
+		if i != len(p)-1 && len(elems[i].GetKey()) != 0 {
+			return 0, false
+		}
+	}
+	return n, true
+}
+
+// resolveNotificationField returns the fields addressed by the remaining
+// path elements rest, where sf is the field of the struct pointer sv that is
+// described by schema, and last is the path element that addressed the
+// field.
+func resolveNotificationField(schema *yang.Entry, sv reflect.Value, sf reflect.StructField, last *gnmipb.PathElem, rest []*gnmipb.PathElem, create bool) ([]*notificationTarget, error) {
+	fv := sv.Elem().FieldByIndex(sf.Index)
+	switch {
+	case util.IsTypeStructPtr(fv.Type()):
+		if len(rest) == 0 {
+			return []*notificationTarget{{schema: schema, parent: sv, sf: sf, field: fv}}, nil
+		}
+		if fv.IsNil() {
+			if !create {
+				return nil, nil
+			}
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return resolveNotificationPath(schema, fv, rest, create)
+	case util.IsTypeMap(fv.Type()):
+		if len(last.GetKey()) == 0 {
+			if len(rest) != 0 {
+				return nil, fmt.Errorf("keys must be specified for list %s", last.GetName())
+			}
+			return []*notificationTarget{{schema: schema, parent: sv, sf: sf, field: fv}}, nil
+		}
+		k, nv, err := notificationListMember(schema, fv.Type(), last.GetKey())
+		if err != nil {
+			return nil, err
+		}
+		// Members that are stored by value are resolved within a copy,
+		// which is stored in the list again once it has been modified.
+		valueMembers := util.IsTypeStruct(fv.Type().Elem())
+		mv := fv.MapIndex(k)
+		switch {
+		case mv.IsValid():
+			mv = util.ListMemberPtr(mv)
+		case !create:
+			return nil, nil
+		default:
+			if fv.IsNil() {
+				fv.Set(reflect.MakeMap(fv.Type()))
+			}
+			fv.SetMapIndex(k, listMemberValue(nv, valueMembers))
+			mv = nv
+		}
+		if len(rest) == 0 {
+			return []*notificationTarget{{schema: schema, parent: sv, sf: sf, field: fv, key: k}}, nil
+		}
+		ts, err := resolveNotificationPath(schema, mv, rest, create)
+		if err != nil || !valueMembers {
+			return ts, err
+		}
+		for _, t := range ts {
+			t.stores = append(t.stores, func() { fv.SetMapIndex(k, mv.Elem()) })
+		}
+		return ts, nil
+	case util.IsTypeSlicePtr(fv.Type()):
+		if len(rest) == 0 && len(last.GetKey()) == 0 {
+			return []*notificationTarget{{schema: schema, parent: sv, sf: sf, field: fv}}, nil
+		}
+		return nil, fmt.Errorf("members of unkeyed list %s cannot be addressed", last.GetName())
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("path continues beyond leaf %s", last.GetName())
+	}
+	if len(last.GetKey()) != 0 {
+		return nil, fmt.Errorf("keys specified for non-list %s", last.GetName())
+	}
+	return []*notificationTarget{{schema: schema, parent: sv, sf: sf, field: fv}}, nil
+}
+
+// notificationListMember creates a new member of the list described by
+// schema, which is represented by the map type mt, with its key fields
+// populated with the values in keys. It returns the key of the member within
+// the map, along with a pointer to the new member, which is a struct
+// pointer even where the members of the list are stored by value.
+func notificationListMember(schema *yang.Entry, mt reflect.Type, keys map[string]string) (reflect.Value, reflect.Value, error) {
+	keyNames := strings.Fields(schema.Key)
+	if len(keyNames) != len(keys) {
+		return reflect.Value{}, reflect.Value{}, fmt.Errorf("list %s has keys %v, got %v", schema.Name, keyNames, keys)
+	}
+
+	nv := reflect.New(listMemberType(mt))
+	for _, kn := range keyNames {
+		kv, ok := keys[kn]
+		if !ok {
+			return reflect.Value{}, reflect.Value{}, fmt.Errorf("missing key %s for list %s", kn, schema.Name)
+		}
+		ts, err := resolveNotificationPath(schema, nv, []*gnmipb.PathElem{{Name: kn}}, true)
+		if err != nil {
+			return reflect.Value{}, reflect.Value{}, err
+		}
+		if len(ts) != 1 {
+			return reflect.Value{}, reflect.Value{}, fmt.Errorf("cannot find key field %s for list %s", kn, schema.Name)
+		}
+		if err := setNotificationLeaf(ts[0], []string{kv}, false); err != nil {
+			return reflect.Value{}, reflect.Value{}, fmt.Errorf("invalid key %s for list %s: %v", kn, schema.Name, err)
+		}
+	}
+
+	k, err := notificationListKey(schema, mt.Key(), nv)
+	if err != nil {
+		return reflect.Value{}, reflect.Value{}, err
+	}
+	return k, nv, nil
+}
+
+// listMemberType returns the struct type of the members of the list that is
+// represented by the map type mt, whose values are either struct pointers, or
+// structs where the members of the list are stored by value.
+func listMemberType(mt reflect.Type) reflect.Type {
+	if util.IsTypeStruct(mt.Elem()) {
+		return mt.Elem()
+	}
+	return mt.Elem().Elem()
+}
+
+// listMemberValue returns the value that is stored within the map
+// representing a list for the member that nv, a struct pointer, points to.
+// If valueMembers is true, the members of the list are stored by value.
+func listMemberValue(nv reflect.Value, valueMembers bool) reflect.Value {
+	if valueMembers {
+		return nv.Elem()
+	}
+	return nv
+}
+
+// notificationListKey returns the key of type kt of the member nv of the list
+// described by schema, which is derived from the key fields of the member.
+func notificationListKey(schema *yang.Entry, kt reflect.Type, nv reflect.Value) (reflect.Value, error) {
+	keyNames := strings.Fields(schema.Key)
+	if len(keyNames) == 1 {
+		ts, err := resolveNotificationPath(schema, nv, []*gnmipb.PathElem{{Name: keyNames[0]}}, false)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if len(ts) != 1 {
+			return reflect.Value{}, fmt.Errorf("cannot find key field %s for list %s", keyNames[0], schema.Name)
+		}
+		kf := ts[0].field
+		if util.IsNilOrInvalidValue(kf) {
+			return reflect.Value{}, fmt.Errorf("missing key %s for list %s", keyNames[0], schema.Name)
+		}
+		return reflect.Indirect(kf).Convert(kt), nil
+	}
+
+	// Multi-keyed lists are keyed by a struct whose fields have the same
+	// names as the key fields of the list member.
+	k := reflect.New(kt).Elem()
+	for i := 0; i < kt.NumField(); i++ {
+		kf := kt.Field(i)
+		mf := nv.Elem().FieldByName(kf.Name)
+		if !mf.IsValid() {
+			return reflect.Value{}, fmt.Errorf("cannot find field %s of key %v in %v", kf.Name, kt, nv.Type())
+		}
+		if util.IsNilOrInvalidValue(mf) {
+			return reflect.Value{}, fmt.Errorf("missing key field %s for list %s", kf.Name, schema.Name)
+		}
+		k.Field(i).Set(reflect.Indirect(mf))
+	}
+	return k, nil
+}
+
+// deleteNotificationPath deletes the nodes addressed by the path elems from
+// the struct pointer sv, described by schema. If elems is empty, all fields
+// of sv are reset.
+func deleteNotificationPath(schema *yang.Entry, sv reflect.Value, elems []*gnmipb.PathElem) error {
+	if len(elems) == 0 {
+		sv.Elem().Set(reflect.Zero(sv.Elem().Type()))
+		return nil
+	}
+
+	ts, err := resolveNotificationPath(schema, sv, elems, false)
+	if err != nil {
+		return err
+	}
+	for _, t := range ts {
+		switch {
+		case t.key.IsValid():
+			t.field.SetMapIndex(t.key, reflect.Value{})
+		case t.fixedArray():
+			if err := util.SetFixedArrayElems(t.parent.Elem(), t.sf, reflect.Zero(reflect.SliceOf(t.sf.Type.Elem()))); err != nil {
+				return err
+			}
+		default:
+			t.field.Set(reflect.Zero(t.field.Type()))
+		}
+		t.store()
+	}
+	return nil
+}
+
+// mergeNotificationUpdate applies the update of the path elems to the value
+// tv to the struct pointer sv, described by schema. If overwrite is false, an
+// error is returned if the update would overwrite a field that is set to a
+// different value.
+func mergeNotificationUpdate(schema *yang.Entry, sv reflect.Value, elems []*gnmipb.PathElem, tv *gnmipb.TypedValue, overwrite bool) error {
+	var jv []byte
+	switch v := tv.GetValue().(type) {
+	case *gnmipb.TypedValue_JsonIetfVal:
+		jv = v.JsonIetfVal
+	case *gnmipb.TypedValue_JsonVal:
+		jv = v.JsonVal
+	default:
+		ts, err := resolveNotificationPath(schema, sv, elems, true)
+		if err != nil {
+			return err
+		}
+		if len(ts) != 1 || !isLeafSchema(ts[0].schema) {
+			return fmt.Errorf("scalar value %v supplied for non-leaf path", tv)
+		}
+		vals, err := typedValueLexical(tv, isBinarySchema(ts[0].schema))
+		if err != nil {
+			return err
+		}
+		return setNotificationLeaf(ts[0], vals, overwrite)
+	}
+
+	d := json.NewDecoder(bytes.NewReader(jv))
+	d.UseNumber()
+	var j interface{}
+	if err := d.Decode(&j); err != nil {
+		return fmt.Errorf("cannot unmarshal JSON value: %v", err)
+	}
+
+	s, err := notificationPathSchema(schema, elems)
+	if err != nil {
+		return err
+	}
+	var us []*notificationLeafUpdate
+	if err := flattenJSONUpdate(s, elems, j, &us); err != nil {
+		return err
+	}
+
+	var errs util.Errors
+	for _, u := range us {
+		ts, err := resolveNotificationPath(schema, sv, u.path, true)
+		if err != nil {
+			errs = util.AppendErr(errs, err)
+			continue
+		}
+		if len(ts) != 1 {
+			errs = util.AppendErr(errs, fmt.Errorf("path %s does not address a leaf", pathElemString(u.path)))
+			continue
+		}
+		if err := setNotificationLeaf(ts[0], u.values, overwrite); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("%s: %v", pathElemString(u.path), err))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// notificationPathSchema returns the schema of the data tree node addressed by
+// the path elems relative to schema.
+func notificationPathSchema(schema *yang.Entry, elems []*gnmipb.PathElem) (*yang.Entry, error) {
+	s := schema
+	for _, e := range elems {
+		cs := util.FirstChild(s, []string{e.GetName()})
+		if cs == nil {
+			return nil, fmt.Errorf("cannot find schema for %s in %s", e.GetName(), s.Name)
+		}
+		s = cs
+	}
+	return s, nil
+}
+
+// flattenJSONUpdate appends the leaf updates within the JSON value j, which is
+// the value of the node at path described by schema, to us.
+func flattenJSONUpdate(schema *yang.Entry, path []*gnmipb.PathElem, j interface{}, us *[]*notificationLeafUpdate) error {
+	if isLeafSchema(schema) {
+		vals, err := jsonLexical(j, isBinarySchema(schema), schema.IsLeafList())
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %v", schema.Name, err)
+		}
+		*us = append(*us, &notificationLeafUpdate{path: path, values: vals})
+		return nil
+	}
+
+	// A list is addressed without its keys when its value is an array of
+	// list members.
+	if schema.IsList() && (len(path) == 0 || len(path[len(path)-1].GetKey()) == 0) {
+		jl, ok := j.([]interface{})
+		if !ok {
+			return fmt.Errorf("invalid value for list %s, got: %T, want: array", schema.Name, j)
+		}
+		parent := path
+		if len(path) != 0 {
+			parent = path[:len(path)-1]
+		}
+		for _, m := range jl {
+			jm, ok := m.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("invalid member of list %s, got: %T, want: object", schema.Name, m)
+			}
+			keys := map[string]string{}
+			for _, kn := range strings.Fields(schema.Key) {
+				kv, ok := jm[kn]
+				if !ok {
+					return fmt.Errorf("member of list %s is missing key %s", schema.Name, kn)
+				}
+				ks, err := jsonLexical(kv, false, false)
+				if err != nil {
+					return fmt.Errorf("invalid key %s of list %s: %v", kn, schema.Name, err)
+				}
+				keys[kn] = ks[0]
+			}
+			mp := appendPathElem(parent, &gnmipb.PathElem{Name: schema.Name, Key: keys})
+			if err := flattenJSONUpdate(schema, mp, jm, us); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	jm, ok := j.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid value for %s, got: %T, want: object", schema.Name, j)
+	}
+	for k, v := range jm {
+		name := util.StripModulePrefix(k)
+		cs := util.FirstChild(schema, []string{name})
+		if cs == nil {
+			return fmt.Errorf("cannot find schema for %s in %s", name, schema.Name)
+		}
+		cp := appendPathElem(path, &gnmipb.PathElem{Name: name})
+		if cs.IsList() {
+			// The list element is added with its keys when each member is
+			// flattened.
+			cp = path
+		}
+		if err := flattenJSONUpdate(cs, cp, v, us); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendPathElem returns a copy of path with e appended.
+func appendPathElem(path []*gnmipb.PathElem, e *gnmipb.PathElem) []*gnmipb.PathElem {
+	return append(append([]*gnmipb.PathElem{}, path...), e)
+}
+
+// setNotificationLeaf sets the leaf or leaf-list field of t to vals, which
+// are expressed in their YANG lexical form. If overwrite is false, an error
+// is returned if the field is set to a different value.
+func setNotificationLeaf(t *notificationTarget, vals []string, overwrite bool) error {
+	ts, err := util.ResolveIfLeafRef(t.schema)
+	if err != nil {
+		return err
+	}
+
+	ft := t.field.Type()
+	// The current value of the field, which is the slice of the populated
+	// elements of leaf-lists that are stored as fixed-size arrays.
+	cur := t.field
+	if t.fixedArray() {
+		if cur, err = util.FixedArrayElems(t.parent.Elem(), t.sf); err != nil {
+			return err
+		}
+	}
+
+	var nv reflect.Value
+	switch {
+	case t.schema.IsLeafList():
+		if cur.Kind() != reflect.Slice {
+			return fmt.Errorf("field %s for leaf-list %s has unsupported type %v", t.sf.Name, t.schema.Name, ft)
+		}
+		nv = reflect.MakeSlice(cur.Type(), 0, len(vals))
+		for _, s := range vals {
+			ev, err := defaultValue(ts, t.parent.Type(), ft.Elem(), s)
+			if err != nil {
+				return err
+			}
+			nv = reflect.Append(nv, ev)
+		}
+	case len(vals) != 1:
+		return fmt.Errorf("%d values supplied for leaf %s", len(vals), t.schema.Name)
+	case ft.Kind() == reflect.Ptr:
+		ev, err := defaultValue(ts, t.parent.Type(), ft.Elem(), vals[0])
+		if err != nil {
+			return err
+		}
+		nv = reflect.New(ft.Elem())
+		nv.Elem().Set(ev)
+	default:
+		// Enumerated types, unions, and binary values are not stored as
+		// pointers.
+		if nv, err = defaultValue(ts, t.parent.Type(), ft, vals[0]); err != nil {
+			return err
+		}
+	}
+
+	if !overwrite && !cur.IsZero() && !util.DeepEqualDerefPtrs(cur.Interface(), nv.Interface()) {
+		return fmt.Errorf("field %s is already set to a different value, %v", t.schema.Name, util.ValueStr(cur.Interface()))
+	}
+	if t.fixedArray() {
+		if err := util.SetFixedArrayElems(t.parent.Elem(), t.sf, nv); err != nil {
+			return err
+		}
+	} else {
+		t.field.Set(nv)
+	}
+	t.store()
+	return nil
+}
+
+// isLeafSchema returns true if the schema describes a leaf or leaf-list.
+func isLeafSchema(schema *yang.Entry) bool {
+	return schema.IsLeaf() || schema.IsLeafList()
+}
+
+// isBinarySchema returns true if the schema describes a leaf or leaf-list of
+// type binary.
+func isBinarySchema(schema *yang.Entry) bool {
+	return schema.Type != nil && schema.Type.Kind == yang.Ybinary
+}
+
+// typedValueLexical returns the values within the scalar or leaf-list
+// TypedValue tv in their YANG lexical form. String values of binary leaves
+// are base64 decoded, whereas bytes values are used as is.
+func typedValueLexical(tv *gnmipb.TypedValue, binary bool) ([]string, error) {
+	switch v := tv.GetValue().(type) {
+	case *gnmipb.TypedValue_StringVal:
+		if binary {
+			b, err := base64.StdEncoding.DecodeString(v.StringVal)
+			if err != nil {
+				return nil, err
+			}
+			return []string{string(b)}, nil
+		}
+		return []string{v.StringVal}, nil
+	case *gnmipb.TypedValue_IntVal:
+		return []string{strconv.FormatInt(v.IntVal, 10)}, nil
+	case *gnmipb.TypedValue_UintVal:
+		return []string{strconv.FormatUint(v.UintVal, 10)}, nil
+	case *gnmipb.TypedValue_BoolVal:
+		return []string{strconv.FormatBool(v.BoolVal)}, nil
+	case *gnmipb.TypedValue_BytesVal:
+		return []string{string(v.BytesVal)}, nil
+	case *gnmipb.TypedValue_FloatVal:
+		return []string{strconv.FormatFloat(float64(v.FloatVal), 'f', -1, 32)}, nil
+	case *gnmipb.TypedValue_DecimalVal:
+		r := new(big.Rat).SetFrac(big.NewInt(v.DecimalVal.GetDigits()), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(v.DecimalVal.GetPrecision())), nil))
+		return []string{r.FloatString(int(v.DecimalVal.GetPrecision()))}, nil
+	case *gnmipb.TypedValue_LeaflistVal:
+		var out []string
+		for _, e := range v.LeaflistVal.GetElement() {
+			s, err := typedValueLexical(e, binary)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, s...)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("unsupported TypedValue type %T", tv.GetValue())
+}
+
+// jsonLexical returns the JSON value j, decoded with numbers represented as
+// json.Number, in its YANG lexical form. If leafList is true, j must be an
+// array of values. String values of binary leaves are base64 decoded.
+func jsonLexical(j interface{}, binary, leafList bool) ([]string, error) {
+	if leafList {
+		jl, ok := j.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("got %T, want array", j)
+		}
+		var out []string
+		for _, e := range jl {
+			s, err := jsonLexical(e, binary, false)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, s...)
+		}
+		return out, nil
+	}
+
+	switch v := j.(type) {
+	case string:
+		if binary {
+			b, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return nil, err
+			}
+			return []string{string(b)}, nil
+		}
+		return []string{v}, nil
+	case json.Number:
+		return []string{v.String()}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case []interface{}:
+		// The empty type is represented as [null] in RFC7951 JSON.
+		if len(v) == 1 && v[0] == nil {
+			return []string{"true"}, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported JSON value %v (%T)", j, j)
+}
+
+// pathElemString returns a human-readable representation of the path elems.
+func pathElemString(elems []*gnmipb.PathElem) string {
+	p, err := PathToString(&gnmipb.Path{Elem: elems})
+	if err != nil {
+		return fmt.Sprintf("%v", elems)
+	}
+	return p
+}
@@ -0,0 +1,584 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// mergeNotifThree is a test enumerated type representing the three leaf of
+// openconfig-simple.
+type mergeNotifThree int64
+
+func (mergeNotifThree) IsYANGGoEnum() {}
+
+func (mergeNotifThree) ΛMap() map[string]map[int64]EnumDefinition {
+	return map[string]map[int64]EnumDefinition{
+		"mergeNotifThree": {
+			1: {Name: "ONE"},
+			2: {Name: "TWO"},
+		},
+	}
+}
+
+func (e mergeNotifThree) String() string {
+	return EnumLogString(e, int64(e), "mergeNotifThree")
+}
+
+// mergeNotifBinary is a test type representing a YANG binary value.
+type mergeNotifBinary []byte
+
+// mergeNotifDevice and its children are test structs representing the
+// compressed openconfig-simple schema.
+type mergeNotifDevice struct {
+	Parent          *mergeNotifParent `path:"parent"`
+	RemoteContainer *mergeNotifRemote `path:"remote-container"`
+}
+
+func (*mergeNotifDevice) IsYANGGoStruct() {}
+
+type mergeNotifParent struct {
+	Child *mergeNotifChild `path:"child"`
+}
+
+func (*mergeNotifParent) IsYANGGoStruct() {}
+
+type mergeNotifChild struct {
+	Four  mergeNotifBinary `path:"config/four"`
+	One   *string          `path:"config/one"`
+	Three mergeNotifThree  `path:"config/three"`
+	Two   *string          `path:"state/two"`
+}
+
+func (*mergeNotifChild) IsYANGGoStruct() {}
+
+type mergeNotifRemote struct {
+	ALeaf *string `path:"config/a-leaf"`
+}
+
+func (*mergeNotifRemote) IsYANGGoStruct() {}
+
+// mergeNotifListDevice and its children are test structs representing the
+// compressed openconfig-withlist schema.
+type mergeNotifListDevice struct {
+	MultiKey  map[mergeNotifMultiKeyKey]*mergeNotifMultiKey `path:"model/b/multi-key"`
+	SingleKey map[string]*mergeNotifSingleKey               `path:"model/a/single-key"`
+}
+
+func (*mergeNotifListDevice) IsYANGGoStruct() {}
+
+type mergeNotifSingleKey struct {
+	Key *string `path:"config/key|key"`
+}
+
+func (*mergeNotifSingleKey) IsYANGGoStruct() {}
+func (m *mergeNotifSingleKey) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"key": *m.Key,
+	}, nil
+}
+
+type mergeNotifMultiKeyKey struct {
+	Key1 uint32 `path:"key1"`
+	Key2 uint64 `path:"key2"`
+}
+
+type mergeNotifMultiKey struct {
+	Key1 *uint32 `path:"config/key1|key1"`
+	Key2 *uint64 `path:"config/key2|key2"`
+}
+
+func (*mergeNotifMultiKey) IsYANGGoStruct() {}
+func (m *mergeNotifMultiKey) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"key1": *m.Key1,
+		"key2": *m.Key2,
+	}, nil
+}
+
+// mergeNotifBoundedDevice and its children are test structs representing the
+// uncompressed openconfig-bounded-leaflist schema, generated such that bounded
+// leaf-lists are stored as fixed-size arrays.
+type mergeNotifBoundedDevice struct {
+	Route map[string]*mergeNotifBoundedRoute `path:"routes/route"`
+}
+
+func (*mergeNotifBoundedDevice) IsYANGGoStruct() {}
+
+type mergeNotifBoundedRoute struct {
+	Prefix      *string   `path:"config/prefix|prefix"`
+	NextHops    [2]string `path:"config/next-hops" ygotArrayLen:"NextHopsLen"`
+	NextHopsLen int       `ygotArrayLenOf:"NextHops"`
+	Weights     [4]uint32 `path:"config/weights" ygotArrayLen:"WeightsLen"`
+	WeightsLen  int       `ygotArrayLenOf:"Weights"`
+}
+
+func (*mergeNotifBoundedRoute) IsYANGGoStruct() {}
+func (r *mergeNotifBoundedRoute) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"prefix": *r.Prefix,
+	}, nil
+}
+
+// mustModuleSchema returns the schema of the module modName, which is read
+// from the testdata directory.
+func mustModuleSchema(t *testing.T, modName string) *yang.Entry {
+	t.Helper()
+	ms := yang.NewModules()
+	ms.AddPath("../testdata/modules")
+	if err := ms.Read(modName); err != nil {
+		t.Fatalf("cannot read module: %v", err)
+	}
+	if errs := ms.Process(); errs != nil {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	mod, errs := ms.GetModule(modName)
+	if errs != nil {
+		t.Fatalf("cannot get module: %v", errs)
+	}
+	return mod
+}
+
+// mustPath returns the gNMI path for the string path p.
+func mustPath(t *testing.T, p string) *gnmipb.Path {
+	t.Helper()
+	path, err := StringToStructuredPath(p)
+	if err != nil {
+		t.Fatalf("cannot convert %s to path: %v", p, err)
+	}
+	return path
+}
+
+func TestMergeNotification(t *testing.T) {
+	schema := mustModuleSchema(t, "openconfig-simple")
+
+	tests := []struct {
+		name             string
+		inStruct         *mergeNotifDevice
+		inNotification   *gnmipb.Notification
+		inOpts           []MergeOpt
+		want             *mergeNotifDevice
+		wantErrSubstring string
+	}{{
+		name:     "string leaf update",
+		inStruct: &mergeNotifDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/parent/child/config/one"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+			}},
+		},
+		want: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{One: String("foo")}}},
+	}, {
+		name:     "enum and binary leaf updates with prefix",
+		inStruct: &mergeNotifDevice{},
+		inNotification: &gnmipb.Notification{
+			Prefix: mustPath(t, "/parent/child"),
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "config/three"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "openconfig-simple:TWO"}},
+			}, {
+				Path: mustPath(t, "config/four"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{BytesVal: []byte{0x01, 0x02}}},
+			}, {
+				Path: mustPath(t, "state/two"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar"}},
+			}},
+		},
+		want: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{
+			Four:  mergeNotifBinary{0x01, 0x02},
+			Three: 2,
+			Two:   String("bar"),
+		}}},
+	}, {
+		name:     "RFC7951 JSON update of container",
+		inStruct: &mergeNotifDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/parent"),
+				Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{
+					"child": {
+						"config": {"one": "foo", "three": "ONE", "four": "AQI="},
+						"state": {"two": "bar"}
+					}
+				}`)}},
+			}},
+		},
+		want: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{
+			Four:  mergeNotifBinary{0x01, 0x02},
+			One:   String("foo"),
+			Three: 1,
+			Two:   String("bar"),
+		}}},
+	}, {
+		name:     "JSON update of root",
+		inStruct: &mergeNotifDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: &gnmipb.Path{},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonVal{JsonVal: []byte(`{"openconfig-simple:remote-container": {"config": {"a-leaf": "baz"}}}`)}},
+			}},
+		},
+		want: &mergeNotifDevice{RemoteContainer: &mergeNotifRemote{ALeaf: String("baz")}},
+	}, {
+		name: "delete leaf",
+		inStruct: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{
+			One: String("foo"),
+			Two: String("bar"),
+		}}},
+		inNotification: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(t, "/parent/child/config/one")},
+		},
+		want: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{
+			Two: String("bar"),
+		}}},
+	}, {
+		name: "delete compressed out container",
+		inStruct: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{
+			Four:  mergeNotifBinary{0x01},
+			One:   String("foo"),
+			Three: 1,
+			Two:   String("bar"),
+		}}},
+		inNotification: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(t, "/parent/child/config")},
+		},
+		want: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{
+			Two: String("bar"),
+		}}},
+	}, {
+		name: "delete container",
+		inStruct: &mergeNotifDevice{
+			Parent:          &mergeNotifParent{Child: &mergeNotifChild{One: String("foo")}},
+			RemoteContainer: &mergeNotifRemote{ALeaf: String("baz")},
+		},
+		inNotification: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(t, "/parent")},
+		},
+		want: &mergeNotifDevice{RemoteContainer: &mergeNotifRemote{ALeaf: String("baz")}},
+	}, {
+		name:     "delete non-existent path",
+		inStruct: &mergeNotifDevice{},
+		inNotification: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(t, "/parent/child/config/one")},
+		},
+		want: &mergeNotifDevice{},
+	}, {
+		name:     "deletes are applied before updates",
+		inStruct: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{One: String("foo"), Two: String("bar")}}},
+		inNotification: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(t, "/parent/child")},
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/parent/child/config/one"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "baz"}},
+			}},
+		},
+		want: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{One: String("baz")}}},
+	}, {
+		name:     "update to same value",
+		inStruct: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{One: String("foo")}}},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/parent/child/config/one"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+			}},
+		},
+		want: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{One: String("foo")}}},
+	}, {
+		name:     "update to different value without overwrite",
+		inStruct: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{One: String("foo")}}},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/parent/child/config/one"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar"}},
+			}},
+		},
+		wantErrSubstring: "already set to a different value",
+	}, {
+		name:     "update to different value with overwrite",
+		inStruct: &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{One: String("foo")}}},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/parent/child/config/one"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "bar"}},
+			}},
+		},
+		inOpts: []MergeOpt{&MergeOverwriteExistingFields{}},
+		want:   &mergeNotifDevice{Parent: &mergeNotifParent{Child: &mergeNotifChild{One: String("bar")}}},
+	}, {
+		name:     "invalid enum value",
+		inStruct: &mergeNotifDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/parent/child/config/three"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "THREE"}},
+			}},
+		},
+		wantErrSubstring: `"THREE" is not a valid value`,
+	}, {
+		name:     "scalar value for container",
+		inStruct: &mergeNotifDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/parent/child"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+			}},
+		},
+		wantErrSubstring: "non-leaf path",
+	}, {
+		name:     "unknown path",
+		inStruct: &mergeNotifDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/parent/sibling"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+			}},
+		},
+		wantErrSubstring: "does not contain a field matching",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MergeNotification(schema, tt.inStruct, tt.inNotification, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MergeNotification: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.inStruct); diff != "" {
+				t.Errorf("MergeNotification: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeNotificationLists(t *testing.T) {
+	schema := mustModuleSchema(t, "openconfig-withlist")
+
+	tests := []struct {
+		name             string
+		inStruct         *mergeNotifListDevice
+		inNotification   *gnmipb.Notification
+		want             *mergeNotifListDevice
+		wantErrSubstring string
+	}{{
+		name:     "update creates single-keyed list member",
+		inStruct: &mergeNotifListDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/model/a/single-key[key=foo]/config/key"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+			}},
+		},
+		want: &mergeNotifListDevice{SingleKey: map[string]*mergeNotifSingleKey{
+			"foo": {Key: String("foo")},
+		}},
+	}, {
+		name:     "update creates multi-keyed list member",
+		inStruct: &mergeNotifListDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/model/b/multi-key[key1=1][key2=2]/config/key2"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 2}},
+			}},
+		},
+		want: &mergeNotifListDevice{MultiKey: map[mergeNotifMultiKeyKey]*mergeNotifMultiKey{
+			{Key1: 1, Key2: 2}: {Key1: Uint32(1), Key2: Uint64(2)},
+		}},
+	}, {
+		name:     "JSON update of list",
+		inStruct: &mergeNotifListDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/model/a/single-key"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`[{"key": "foo", "config": {"key": "foo"}}, {"key": "bar"}]`)}},
+			}},
+		},
+		want: &mergeNotifListDevice{SingleKey: map[string]*mergeNotifSingleKey{
+			"foo": {Key: String("foo")},
+			"bar": {Key: String("bar")},
+		}},
+	}, {
+		name: "delete list member",
+		inStruct: &mergeNotifListDevice{SingleKey: map[string]*mergeNotifSingleKey{
+			"foo": {Key: String("foo")},
+			"bar": {Key: String("bar")},
+		}},
+		inNotification: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(t, "/model/a/single-key[key=foo]")},
+		},
+		want: &mergeNotifListDevice{SingleKey: map[string]*mergeNotifSingleKey{
+			"bar": {Key: String("bar")},
+		}},
+	}, {
+		name: "delete list",
+		inStruct: &mergeNotifListDevice{SingleKey: map[string]*mergeNotifSingleKey{
+			"foo": {Key: String("foo")},
+		}},
+		inNotification: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(t, "/model/a/single-key")},
+		},
+		want: &mergeNotifListDevice{},
+	}, {
+		name:     "invalid key value",
+		inStruct: &mergeNotifListDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/model/b/multi-key[key1=foo][key2=2]/config/key2"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 2}},
+			}},
+		},
+		wantErrSubstring: "invalid key key1",
+	}, {
+		name:     "missing keys",
+		inStruct: &mergeNotifListDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/model/a/single-key/config/key"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "foo"}},
+			}},
+		},
+		wantErrSubstring: "keys must be specified",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MergeNotification(schema, tt.inStruct, tt.inNotification)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MergeNotification: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.inStruct); diff != "" {
+				t.Errorf("MergeNotification: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeNotificationFixedArrays(t *testing.T) {
+	schema := mustModuleSchema(t, "openconfig-bounded-leaflist")
+
+	leaflistVal := func(vals ...*gnmipb.TypedValue) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{LeaflistVal: &gnmipb.ScalarArray{Element: vals}}}
+	}
+	uintVal := func(v uint64) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: v}}
+	}
+
+	tests := []struct {
+		name             string
+		inStruct         *mergeNotifBoundedDevice
+		inNotification   *gnmipb.Notification
+		inOpts           []MergeOpt
+		want             *mergeNotifBoundedDevice
+		wantErrSubstring string
+	}{{
+		name:     "update sets elements and length",
+		inStruct: &mergeNotifBoundedDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/routes/route[prefix=p]/config/weights"),
+				Val:  leaflistVal(uintVal(10), uintVal(20)),
+			}},
+		},
+		want: &mergeNotifBoundedDevice{Route: map[string]*mergeNotifBoundedRoute{
+			"p": {Prefix: String("p"), Weights: [4]uint32{10, 20}, WeightsLen: 2},
+		}},
+	}, {
+		name: "update replaces elements and clears unpopulated ones",
+		inStruct: &mergeNotifBoundedDevice{Route: map[string]*mergeNotifBoundedRoute{
+			"p": {Prefix: String("p"), Weights: [4]uint32{1, 2, 3}, WeightsLen: 3},
+		}},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/routes/route[prefix=p]/config/weights"),
+				Val:  leaflistVal(uintVal(42)),
+			}},
+		},
+		inOpts: []MergeOpt{&MergeOverwriteExistingFields{}},
+		want: &mergeNotifBoundedDevice{Route: map[string]*mergeNotifBoundedRoute{
+			"p": {Prefix: String("p"), Weights: [4]uint32{42}, WeightsLen: 1},
+		}},
+	}, {
+		name: "update of populated elements without overwrite",
+		inStruct: &mergeNotifBoundedDevice{Route: map[string]*mergeNotifBoundedRoute{
+			"p": {Prefix: String("p"), Weights: [4]uint32{1, 2, 3}, WeightsLen: 3},
+		}},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/routes/route[prefix=p]/config/weights"),
+				Val:  leaflistVal(uintVal(42)),
+			}},
+		},
+		wantErrSubstring: "already set to a different value",
+	}, {
+		name:     "JSON update of leaf-list",
+		inStruct: &mergeNotifBoundedDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/routes/route[prefix=p]/config/next-hops"),
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`["a", "b"]`)}},
+			}},
+		},
+		want: &mergeNotifBoundedDevice{Route: map[string]*mergeNotifBoundedRoute{
+			"p": {Prefix: String("p"), NextHops: [2]string{"a", "b"}, NextHopsLen: 2},
+		}},
+	}, {
+		name: "delete clears elements and length",
+		inStruct: &mergeNotifBoundedDevice{Route: map[string]*mergeNotifBoundedRoute{
+			"p": {Prefix: String("p"), NextHops: [2]string{"a", "b"}, NextHopsLen: 2},
+		}},
+		inNotification: &gnmipb.Notification{
+			Delete: []*gnmipb.Path{mustPath(t, "/routes/route[prefix=p]/config/next-hops")},
+		},
+		want: &mergeNotifBoundedDevice{Route: map[string]*mergeNotifBoundedRoute{
+			"p": {Prefix: String("p")},
+		}},
+	}, {
+		name:     "too many elements",
+		inStruct: &mergeNotifBoundedDevice{},
+		inNotification: &gnmipb.Notification{
+			Update: []*gnmipb.Update{{
+				Path: mustPath(t, "/routes/route[prefix=p]/config/weights"),
+				Val:  leaflistVal(uintVal(1), uintVal(2), uintVal(3), uintVal(4), uintVal(5)),
+			}},
+		},
+		wantErrSubstring: "cannot set 5 elements",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MergeNotification(schema, tt.inStruct, tt.inNotification, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MergeNotification: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.inStruct); diff != "" {
+				t.Errorf("MergeNotification: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
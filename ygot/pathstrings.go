@@ -315,3 +315,49 @@ func addKey(keys map[string]string, e, k, v string) error {
 	keys[k] = v
 	return nil
 }
+
+// InstanceIdentifier is the type that is used for fields that have a YANG
+// type of instance-identifier. Its value is the string representation of the
+// data tree path that it identifies, as defined in Section 9.13 of RFC7950,
+// such that it is rendered to, and parsed from, RFC7951 JSON as a string (see
+// Section 6.11 of RFC7951).
+type InstanceIdentifier string
+
+// Path returns the instance identifier as a gNMI path. The module prefixes of
+// node and key names are removed, and the quotes surrounding the values of
+// predicates are stripped. An error is returned if the instance identifier
+// is not an absolute path, or cannot be parsed.
+func (i InstanceIdentifier) Path() (*gnmipb.Path, error) {
+	s := string(i)
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("instance identifier %q is not an absolute path", s)
+	}
+	p, err := StringToStructuredPath(s)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range p.Elem {
+		if e.Name == "" {
+			return nil, fmt.Errorf("instance identifier %q contains an empty node name", s)
+		}
+		e.Name = util.StripModulePrefix(e.Name)
+		if len(e.Key) == 0 {
+			continue
+		}
+		keys := make(map[string]string, len(e.Key))
+		for k, v := range e.Key {
+			keys[util.StripModulePrefix(k)] = unquotePredicateValue(v)
+		}
+		e.Key = keys
+	}
+	return p, nil
+}
+
+// unquotePredicateValue removes the single or double quotes that surround the
+// value v of an instance identifier predicate.
+func unquotePredicateValue(v string) string {
+	if len(v) >= 2 && (v[0] == '\'' || v[0] == '"') && v[len(v)-1] == v[0] {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
@@ -545,3 +545,51 @@ func TestPathToSchemaPath(t *testing.T) {
 		}
 	}
 }
+
+func TestInstanceIdentifierPath(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               InstanceIdentifier
+		want             *gnmipb.Path
+		wantErrSubstring string
+	}{{
+		name: "path without predicates",
+		in:   "/a/b/c",
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "a"}, {Name: "b"}, {Name: "c"}}},
+	}, {
+		name: "prefixed path with quoted predicates",
+		in:   "/ex:system/ex:server[ex:ip='192.0.2.1'][ex:port=\"80\"]/ex:name",
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "system"},
+			{Name: "server", Key: map[string]string{"ip": "192.0.2.1", "port": "80"}},
+			{Name: "name"},
+		}},
+	}, {
+		name: "leaf-list predicate",
+		in:   "/a/b[.='foo']",
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "a"},
+			{Name: "b", Key: map[string]string{".": "foo"}},
+		}},
+	}, {
+		name:             "relative path",
+		in:               "a/b",
+		wantErrSubstring: "not an absolute path",
+	}, {
+		name:             "invalid predicate",
+		in:               "/a/b[c]",
+		wantErrSubstring: "error parsing path",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.in.Path()
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Path(): did not get expected error, %s", diff)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Path(): did not get expected path, got: %s, want: %s", prototext.Format(got), prototext.Format(tt.want))
+			}
+		})
+	}
+}
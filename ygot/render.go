@@ -24,6 +24,7 @@ import (
 
 	"github.com/openconfig/gnmi/errlist"
 	"github.com/openconfig/gnmi/value"
+	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
@@ -38,6 +39,13 @@ const (
 	// EmptyTypeName is the name of the type that is used for YANG
 	// empty fields in the output structs.
 	EmptyTypeName string = "YANGEmpty"
+	// InstanceIdentifierTypeName is the name of the type that is used for
+	// YANG instance-identifier fields in the output structs.
+	InstanceIdentifierTypeName string = "ygot.InstanceIdentifier"
+	// UnionInstanceIdentifierTypeName is the name of the type that is used
+	// for instance-identifier subtypes of simple unions in the output
+	// structs.
+	UnionInstanceIdentifierTypeName string = "UnionInstanceIdentifier"
 )
 
 var (
@@ -59,6 +67,8 @@ var (
 		"interface{}":  "*UnionUnsupported",
 		BinaryTypeName: BinaryTypeName,
 		EmptyTypeName:  EmptyTypeName,
+
+		InstanceIdentifierTypeName: UnionInstanceIdentifierTypeName,
 	}
 
 	// unionSingletonUnderlyingTypes stores the underlying types of the
@@ -78,6 +88,8 @@ var (
 		"UnionString":  reflect.TypeOf(string("")),
 		"UnionBool":    reflect.TypeOf(bool(true)),
 		EmptyTypeName:  reflect.TypeOf(bool(true)),
+
+		UnionInstanceIdentifierTypeName: reflect.TypeOf(string("")),
 		// Note: BinaryTypeName is missing here since it's a slice.
 	}
 )
@@ -374,6 +386,27 @@ func findUpdatedLeaves(leaves map[*path]interface{}, s GoStruct, parent *gnmiPat
 		fval := sval.Field(i)
 		ftype := stype.Field(i)
 
+		// Skip embedded metadata fields, since they are not schema nodes.
+		if util.IsYgotMetadata(ftype) || util.IsYgotArrayLen(ftype) {
+			continue
+		}
+
+		// Leaves that are stored as values are always set, and are hence
+		// handled in the same manner as a populated pointer.
+		if util.IsYgotValueLeaf(ftype) {
+			fval = fval.Addr()
+		}
+
+		// Leaf-lists that are stored as fixed-size arrays are handled as a
+		// slice of their populated elements.
+		if _, ok := util.YgotArrayLenField(ftype); ok {
+			var err error
+			if fval, err = util.FixedArrayElems(sval, ftype); err != nil {
+				errs.Add(fmt.Errorf("%v->%s: %v", parent, ftype.Name, err))
+				continue
+			}
+		}
+
 		// Handle nil values, and enumerations specifically.
 		switch fval.Kind() {
 		case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
@@ -392,13 +425,14 @@ func findUpdatedLeaves(leaves map[*path]interface{}, s GoStruct, parent *gnmiPat
 		case reflect.Map:
 			// We need to map each child along with its key value.
 			for _, k := range fval.MapKeys() {
-				childPath, err := mapValuePath(k, fval.MapIndex(k), mapPaths[0])
+				mv := util.ListMemberPtr(fval.MapIndex(k))
+				childPath, err := mapValuePath(k, mv, mapPaths[0])
 				if err != nil {
 					errs.Add(err)
 					continue
 				}
 
-				goStruct, ok := fval.MapIndex(k).Interface().(GoStruct)
+				goStruct, ok := mv.Interface().(GoStruct)
 				if !ok {
 					errs.Add(fmt.Errorf("%v: was not a valid GoStruct", mapPaths[0]))
 					continue
@@ -406,6 +440,20 @@ func findUpdatedLeaves(leaves map[*path]interface{}, s GoStruct, parent *gnmiPat
 				errs.Add(findUpdatedLeaves(leaves, goStruct, childPath))
 			}
 		case reflect.Ptr:
+			// Ordered lists are handled in the same manner as lists that are
+			// stored as maps, with each child mapped along with its key value.
+			if ol, ok := fval.Interface().(GoOrderedList); ok {
+				values := ol.ΛValues()
+				for i, k := range ol.ΛKeys() {
+					childPath, err := mapValuePath(reflect.ValueOf(k), reflect.ValueOf(values[i]), mapPaths[0])
+					if err != nil {
+						errs.Add(err)
+						continue
+					}
+					errs.Add(findUpdatedLeaves(leaves, values[i], childPath))
+				}
+				continue
+			}
 			// Determine whether this is a pointer to a struct (another YANG container), or a leaf.
 			switch fval.Elem().Kind() {
 			case reflect.Struct:
@@ -458,6 +506,109 @@ func findUpdatedLeaves(leaves map[*path]interface{}, s GoStruct, parent *gnmiPat
 	return errs.Err()
 }
 
+// PathFromField returns the absolute gNMI path of the field referenced by
+// fieldPtr within the data tree rooted at root. fieldPtr must be a pointer to
+// a field of a struct within the tree (e.g., &s.Config.Description), or the
+// value of a pointer field (e.g., s.Config.Description). The keys of any lists
+// that are ancestors of the field are included in the returned path. An error
+// is returned if the field cannot be found within root.
+func PathFromField(root GoStruct, fieldPtr interface{}) (*gnmipb.Path, error) {
+	tv := reflect.ValueOf(fieldPtr)
+	if fieldPtr == nil || tv.Kind() != reflect.Ptr || tv.IsNil() {
+		return nil, fmt.Errorf("field reference must be a non-nil pointer, got: %T", fieldPtr)
+	}
+
+	p, err := findFieldPath(tv, root, newPathElemGNMIPath(nil))
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, fmt.Errorf("field reference %T was not found within the supplied struct", fieldPtr)
+	}
+	return p.ToProto()
+}
+
+// findFieldPath walks the GoStruct s, assumed to be rooted at parent, and
+// returns the path of the field that is referenced by the pointer target. It
+// returns a nil path if the field is not found within s. Lists and containers
+// are walked recursively.
+func findFieldPath(target reflect.Value, s GoStruct, parent *gnmiPath) (*gnmiPath, error) {
+	sval := reflect.ValueOf(s)
+	if s == nil || util.IsValueNil(sval) || !sval.IsValid() || !util.IsValueStructPtr(sval) {
+		return nil, fmt.Errorf("input struct for %v was not valid", parent)
+	}
+	sval = sval.Elem()
+	stype := sval.Type()
+
+	for i := 0; i < sval.NumField(); i++ {
+		fval := sval.Field(i)
+		ftype := stype.Field(i)
+
+		if util.IsYgotMetadata(ftype) || util.IsYgotArrayLen(ftype) {
+			continue
+		}
+
+		// The address of the field is compared along with its type, since
+		// the address of a struct is the same as that of its first field.
+		addrMatch := fval.CanAddr() && fval.Addr().Pointer() == target.Pointer() && fval.Addr().Type() == target.Type()
+		valueMatch := fval.Kind() == reflect.Ptr && !fval.IsNil() && fval.Pointer() == target.Pointer() && fval.Type() == target.Type()
+
+		switch fval.Kind() {
+		case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
+			if fval.IsNil() && !addrMatch {
+				continue
+			}
+		}
+
+		mapPaths, err := structTagToLibPaths(ftype, parent, false)
+		if err != nil {
+			return nil, fmt.Errorf("%v->%s: %v", parent, ftype.Name, err)
+		}
+
+		if addrMatch || valueMatch {
+			return mapPaths[0], nil
+		}
+
+		switch fval.Kind() {
+		case reflect.Map:
+			for _, k := range fval.MapKeys() {
+				mv := util.ListMemberPtr(fval.MapIndex(k))
+				goStruct, ok := mv.Interface().(GoStruct)
+				if !ok {
+					return nil, fmt.Errorf("%v: was not a valid GoStruct", mapPaths[0])
+				}
+				childPath, err := mapValuePath(k, mv, mapPaths[0])
+				if err != nil {
+					return nil, err
+				}
+				if p, err := findFieldPath(target, goStruct, childPath); p != nil || err != nil {
+					return p, err
+				}
+			}
+		case reflect.Ptr:
+			if ol, ok := fval.Interface().(GoOrderedList); ok {
+				values := ol.ΛValues()
+				for i, k := range ol.ΛKeys() {
+					childPath, err := mapValuePath(reflect.ValueOf(k), reflect.ValueOf(values[i]), mapPaths[0])
+					if err != nil {
+						return nil, err
+					}
+					if p, err := findFieldPath(target, values[i], childPath); p != nil || err != nil {
+						return p, err
+					}
+				}
+				continue
+			}
+			if goStruct, ok := fval.Interface().(GoStruct); ok {
+				if p, err := findFieldPath(target, goStruct, mapPaths[0]); p != nil || err != nil {
+					return p, err
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
 // mapValuePath calculates the gNMI Path of a map element with the specified
 // key and value. The format of the path returned depends on the input format
 // of the parentPath.
@@ -686,7 +837,7 @@ func EncodeTypedValue(val interface{}, enc gnmipb.Encoding) (*gnmipb.TypedValue,
 	case vv.Type().Kind() == reflect.Int64 && unionSingletonUnderlyingTypes[vv.Type().Name()] == nil:
 		// Invalid int64 that is not an enum or a simple union Int64 type.
 		return nil, fmt.Errorf("cannot represent field value %v as TypedValue", val)
-	case vv.Type().Name() == BinaryTypeName:
+	case IsBinaryType(vv.Type()):
 		// This is a binary type which is defined as a []byte, so we encode it as the bytes.
 		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{vv.Bytes()}}, nil
 	case vv.Type().Name() == EmptyTypeName:
@@ -709,7 +860,7 @@ func EncodeTypedValue(val interface{}, enc gnmipb.Encoding) (*gnmipb.TypedValue,
 		}
 		vv = reflect.ValueOf(nv)
 		// Apart from binary, all other possible union subtypes are scalars or typedefs of scalars.
-		if vv.Type().Name() == BinaryTypeName {
+		if IsBinaryType(vv.Type()) {
 			return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{vv.Bytes()}}, nil
 		}
 	case util.IsValuePtr(vv):
@@ -726,9 +877,146 @@ func EncodeTypedValue(val interface{}, enc gnmipb.Encoding) (*gnmipb.TypedValue,
 		}
 	}
 
+	if vv.Type() == reflect.TypeOf(InstanceIdentifier("")) {
+		// Instance identifiers are encoded as their string representation.
+		vv = vv.Convert(reflect.TypeOf(string("")))
+	}
 	return value.FromScalar(vv.Interface())
 }
 
+// LeafToTypedValue encodes the Go value of the leaf or leaf-list described by
+// schema into a gNMI TypedValue message, choosing the variant of TypedValue
+// based on the YANG type of the node rather than the Go type of the value.
+// Integer, boolean, string and binary values are encoded as the corresponding
+// scalar TypedValue, enumerated values are encoded as a StringVal containing
+// their name, and values of union or identityref type are encoded as a
+// JsonIetfVal containing their RFC7951 JSON representation, such that
+// identities are qualified by the module that defines them. Leafrefs are
+// encoded according to the type of the leaf that they reference, and the
+// values of leaf-lists are encoded as a LeaflistVal. A nil TypedValue is
+// returned if value is nil or an unset enumerated value.
+func LeafToTypedValue(schema *yang.Entry, value interface{}) (*gnmipb.TypedValue, error) {
+	if schema == nil || !(schema.IsLeaf() || schema.IsLeafList()) {
+		return nil, fmt.Errorf("cannot encode value %v, schema is not a leaf or leaf-list: %v", value, schema)
+	}
+	schema, err := util.ResolveIfLeafRef(schema)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve leafref for %s: %v", schema.Name, err)
+	}
+	if schema.Type == nil {
+		return nil, fmt.Errorf("cannot encode value %v, schema %s has no type", value, schema.Name)
+	}
+
+	vv := reflect.ValueOf(value)
+	if !vv.IsValid() || util.IsValueNil(vv) {
+		return nil, nil
+	}
+
+	if !schema.IsLeafList() {
+		return leafTypedValue(schema.Type, vv)
+	}
+
+	if vv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("cannot encode value of type %T for leaf-list %s, must be a slice", value, schema.Name)
+	}
+	arr := &gnmipb.ScalarArray{}
+	for i := 0; i < vv.Len(); i++ {
+		tv, err := leafTypedValue(schema.Type, vv.Index(i))
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode element %d of leaf-list %s: %v", i, schema.Name, err)
+		}
+		if tv != nil {
+			arr.Element = append(arr.Element, tv)
+		}
+	}
+	return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{arr}}, nil
+}
+
+// leafTypedValue encodes the value v, of the YANG type t, into a gNMI
+// TypedValue message as described by LeafToTypedValue.
+func leafTypedValue(t *yang.YangType, v reflect.Value) (*gnmipb.TypedValue, error) {
+	if util.IsValuePtr(v) && !util.IsValueStructPtr(v) {
+		v = v.Elem()
+	}
+	if util.IsNilOrInvalidValue(v) {
+		return nil, nil
+	}
+
+	invalidErr := fmt.Errorf("cannot represent value of type %v as YANG type %s", v.Type(), t.Kind)
+	switch t.Kind {
+	case yang.Yunion, yang.Yidentityref:
+		var val interface{}
+		var err error
+		switch {
+		case util.IsValueStructPtr(v) || util.IsValueInterfaceToStructPtr(v):
+			val, err = unwrapUnionInterfaceValue(v, true)
+		case v.Kind() == reflect.Interface:
+			val, err = resolveUnionVal(v.Elem().Interface(), true)
+		default:
+			val, err = resolveUnionVal(v.Interface(), true)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return nil, nil
+		}
+		if bv := reflect.ValueOf(val); IsBinaryType(bv.Type()) {
+			if val, err = binaryJSON(bv); err != nil {
+				return nil, err
+			}
+		}
+		j, err := json.Marshal(writeIETFScalarJSON(val))
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal value %v to JSON: %v", val, err)
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{j}}, nil
+	case yang.Yenum:
+		name, set, err := enumFieldToString(v, false)
+		if err != nil {
+			return nil, err
+		}
+		if !set {
+			return nil, nil
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{name}}, nil
+	case yang.Ybinary:
+		if v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.Uint8 {
+			return nil, invalidErr
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{v.Bytes()}}, nil
+	case yang.Ybool, yang.Yempty:
+		if v.Kind() != reflect.Bool {
+			return nil, invalidErr
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{v.Bool()}}, nil
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{v.Int()}}, nil
+		}
+		return nil, invalidErr
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		switch v.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{v.Uint()}}, nil
+		}
+		return nil, invalidErr
+	case yang.Ydecimal64:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return value.FromScalar(v.Float())
+		}
+		return nil, invalidErr
+	case yang.Ystring, yang.Ybits, yang.YinstanceIdentifier:
+		if v.Kind() != reflect.String {
+			return nil, invalidErr
+		}
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{v.String()}}, nil
+	}
+	return nil, fmt.Errorf("cannot encode value of unsupported YANG type %s", t.Kind)
+}
+
 // marshalStruct encodes the struct s according to the encoding specified by enc. It
 // is returned as a TypedValue gNMI message.
 func marshalStruct(s GoStruct, enc gnmipb.Encoding) (*gnmipb.TypedValue, error) {
@@ -834,7 +1122,7 @@ func leaflistToSlice(val reflect.Value, prependModuleNameIref bool) ([]interface
 					return nil, err
 				}
 			case ev.Kind() == reflect.Slice:
-				if ev.Type().Name() != BinaryTypeName {
+				if !IsBinaryType(ev.Type()) {
 					return nil, fmt.Errorf("unknown union type within a slice: %v", e.Type().Name())
 				}
 				sval = append(sval, ev.Bytes())
@@ -849,7 +1137,7 @@ func leaflistToSlice(val reflect.Value, prependModuleNameIref bool) ([]interface
 		case reflect.Slice:
 			// The only time we can have a slice within a leaf-list is when
 			// the type of the field is a binary - such that we have a [][]byte field.
-			if e.Type().Name() != BinaryTypeName {
+			if !IsBinaryType(e.Type()) {
 				return nil, fmt.Errorf("unknown type within a slice: %v", e.Type().Name())
 			}
 			sval = append(sval, e.Bytes())
@@ -868,7 +1156,9 @@ func appendTypedValue(l []interface{}, v reflect.Value, prependModuleNameIref bo
 	ival := v.Interface()
 	switch reflect.TypeOf(ival).Kind() {
 	case reflect.String:
-		return append(l, ival.(string)), nil
+		// Derived string types, such as InstanceIdentifier, are
+		// appended as their underlying string value.
+		return append(l, v.String()), nil
 	case reflect.Int8:
 		return append(l, ival.(int8)), nil
 	case reflect.Int16:
@@ -901,7 +1191,7 @@ func appendTypedValue(l []interface{}, v reflect.Value, prependModuleNameIref bo
 	case reflect.Bool:
 		return append(l, ival.(bool)), nil
 	case reflect.Slice:
-		if v.Type().Name() != BinaryTypeName {
+		if !IsBinaryType(v.Type()) {
 			return nil, fmt.Errorf("unknown type within a slice: %v", v.Type().Name())
 		}
 		return append(l, v.Bytes()), nil
@@ -940,6 +1230,15 @@ type RFC7951JSONConfig struct {
 	// is to be rewritten FROM, and the value of the map is the name of the module
 	// it is to be rewritten TO.
 	RewriteModuleNames map[string]string
+	// UseBelongingModuleAsParent specifies that the top-level fields of
+	// a GoStruct that is marshalled are considered to be children of the
+	// module returned by its ΛBelongingModule method, such that their
+	// module names are only prepended when they differ from it. By
+	// default, the module names of all top-level fields are prepended, as
+	// RFC7951 requires for the top-level members of a document. It allows
+	// a non-root GoStruct to be marshalled as if it were a child of its
+	// parent.
+	UseBelongingModuleAsParent bool
 }
 
 // IsMarshal7951Arg marks the RFC7951JSONConfig struct as a valid argument to
@@ -951,12 +1250,35 @@ func (*RFC7951JSONConfig) IsMarshal7951Arg() {}
 // to JSON described by RFC7951. The supplied args control options corresponding
 // to the method by which JSON is marshalled.
 func ConstructIETFJSON(s GoStruct, args *RFC7951JSONConfig) (map[string]interface{}, error) {
-	return structJSON(s, "", jsonOutputConfig{
+	return structJSON(s, rfc7951ParentModule(s, "", args), jsonOutputConfig{
 		jType:         RFC7951,
 		rfc7951Config: args,
 	})
 }
 
+// rfc7951ParentModule returns the name of the module that the top-level
+// fields of the GoStruct s are considered to be children of when it is
+// marshalled to RFC7951 JSON, such that their module names are only prepended
+// when they differ from it. override takes precedence where it is non-empty.
+// Otherwise, the module returned by the GoStruct's ΛBelongingModule method,
+// subject to the RewriteModuleNames rules, is used where
+// UseBelongingModuleAsParent is set within args. The empty string, which
+// causes the module names of all top-level fields to be prepended, is
+// returned by default.
+func rfc7951ParentModule(s GoStruct, override string, args *RFC7951JSONConfig) string {
+	if override != "" {
+		return override
+	}
+	if args == nil || !args.UseBelongingModuleAsParent {
+		return ""
+	}
+	bs, ok := s.(interface{ ΛBelongingModule() string })
+	if !ok {
+		return ""
+	}
+	return rewriteModName(bs.ΛBelongingModule(), args.RewriteModuleNames)
+}
+
 // ConstructInternalJSON marshals a supplied GoStruct to a map, suitable for handing
 // to json.Marshal. It uses the loosely specified JSON format document in
 // go/yang-internal-json.
@@ -1000,7 +1322,11 @@ func Marshal7951(d interface{}, args ...Marshal7951Arg) ([]byte, error) {
 			indent = string(v)
 		}
 	}
-	j, err := jsonValue(reflect.ValueOf(d), "", jsonOutputConfig{
+	var parentMod string
+	if s, ok := d.(GoStruct); ok {
+		parentMod = rfc7951ParentModule(s, "", rfcCfg)
+	}
+	j, err := jsonValue(reflect.ValueOf(d), parentMod, jsonOutputConfig{
 		jType:         RFC7951,
 		rfc7951Config: rfcCfg,
 	})
@@ -1033,6 +1359,20 @@ type jsonOutputConfig struct {
 	// rfc7951Config stores the configuration to be used when outputting RFC7951
 	// JSON.
 	rfc7951Config *RFC7951JSONConfig
+	// sortListsByKey specifies whether the entries of lists output as JSON
+	// arrays should be ordered by comparing their typed key values, rather
+	// than the string representation of the key.
+	sortListsByKey bool
+	// emitMetadata specifies whether annotation fields should be output as
+	// RFC7952 metadata objects, rather than arrays of annotations.
+	emitMetadata bool
+	// alwaysEmitListKeyLeaves specifies whether the key leaves of each
+	// list member should be output as members of its JSON object, using the
+	// value of the list key, where they are not populated within the member.
+	alwaysEmitListKeyLeaves bool
+	// depth is the depth within the GoStruct tree of the GoStruct that is
+	// being marshalled.
+	depth int
 }
 
 // rewriteModName rewrites the module mod according to the specified rewrite rules.
@@ -1103,8 +1443,15 @@ func prependmodsJSON(fType reflect.StructField, parentMod string, args jsonOutpu
 // whether to prepend the name of the module to an element. The format of JSON to
 // be produced and whether such module names are prepended is controlled through the
 // supplied jsonOutputConfig. Returns an error if the GoStruct cannot be rendered
-// to JSON.
+// to JSON, or if it is nested more deeply than MaxTraversalDepth.
 func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string]interface{}, error) {
+	if err := checkTraversalDepth(args.depth); err != nil {
+		return nil, err
+	}
+	// args is a copy, such that the children of s are marshalled at the
+	// next depth.
+	args.depth++
+
 	var errs errlist.List
 
 	sval := reflect.ValueOf(s).Elem()
@@ -1118,6 +1465,11 @@ func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string
 		field := sval.Field(i)
 		fType := stype.Field(i)
 
+		// Skip embedded metadata fields, since they are not schema nodes.
+		if util.IsYgotMetadata(fType) || util.IsYgotArrayLen(fType) {
+			continue
+		}
+
 		// Module names to prepend to the path in RFC7951 output mode.
 		var prependmods [][]string
 		var chMod string
@@ -1142,6 +1494,30 @@ func structJSON(s GoStruct, parentMod string, args jsonOutputConfig) (map[string
 			chMod = parentMod
 		}
 
+		// Annotation fields of a user-defined AnnotationContainer type
+		// are rendered from the annotations that they store.
+		if isAnnotationContainerField(fType) {
+			if util.IsNilOrInvalidValue(field) {
+				continue
+			}
+			field = reflect.ValueOf(field.Interface().(AnnotationContainer).Annotations())
+		}
+
+		// Leaves that are stored as values are always set, and are hence
+		// rendered in the same manner as a populated pointer.
+		if util.IsYgotValueLeaf(fType) {
+			field = field.Addr()
+		}
+
+		// Leaf-lists that are stored as fixed-size arrays are rendered as
+		// a slice of their populated elements.
+		if _, ok := util.YgotArrayLenField(fType); ok {
+			if field, err = util.FixedArrayElems(sval, fType); err != nil {
+				errs.Add(fmt.Errorf("%s: %v", fType.Name, err))
+				continue
+			}
+		}
+
 		value, err := jsonValue(field, chMod, args)
 		if err != nil {
 			errs.Add(err)
@@ -1247,6 +1623,70 @@ func keyValue(v reflect.Value, prependModuleNameIref bool) (interface{}, error)
 	return name, nil
 }
 
+// compareListKeys compares the two list keys a and b, returning a negative
+// value if a is ordered before b, a positive value if a is ordered after b, and
+// zero if they are equal. Where the keys are multi-key structs, each field is
+// compared in turn, such that the keys are ordered by the order in which the
+// key leaves are declared.
+func compareListKeys(a, b reflect.Value) int {
+	if a.Kind() == reflect.Struct && b.Kind() == reflect.Struct && a.Type() == b.Type() {
+		for i := 0; i < a.NumField(); i++ {
+			if c := compareListKeys(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+
+	if a.Kind() == reflect.Interface && !a.IsNil() {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface && !b.IsNil() {
+		b = b.Elem()
+	}
+
+	if a.Type() == b.Type() {
+		if _, isEnum := a.Interface().(GoEnum); isEnum {
+			an, aok, aerr := enumFieldToString(a, false)
+			bn, bok, berr := enumFieldToString(b, false)
+			if aok && bok && aerr == nil && berr == nil {
+				return strings.Compare(an, bn)
+			}
+		}
+
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			switch {
+			case a.Int() < b.Int():
+				return -1
+			case a.Int() > b.Int():
+				return 1
+			}
+			return 0
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			switch {
+			case a.Uint() < b.Uint():
+				return -1
+			case a.Uint() > b.Uint():
+				return 1
+			}
+			return 0
+		case reflect.Float32, reflect.Float64:
+			switch {
+			case a.Float() < b.Float():
+				return -1
+			case a.Float() > b.Float():
+				return 1
+			}
+			return 0
+		case reflect.String:
+			return strings.Compare(a.String(), b.String())
+		}
+	}
+
+	return strings.Compare(fmt.Sprintf("%v", a.Interface()), fmt.Sprintf("%v", b.Interface()))
+}
+
 // mapJSON takes an input reflect.Value containing a map, and
 // constructs the representation for JSON marshalling that corresponds to it.
 // The module within which the map is defined is specified by the parentMod
@@ -1306,6 +1746,11 @@ func mapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (inte
 		return nil, fmt.Errorf("unknown JSON type: %v", args.jType)
 	}
 	sort.Strings(mapKeys)
+	if args.jType == RFC7951 && args.sortListsByKey {
+		sort.SliceStable(mapKeys, func(i, j int) bool {
+			return compareListKeys(mapKeyMap[mapKeys[i]], mapKeyMap[mapKeys[j]]) < 0
+		})
+	}
 
 	if len(mapKeys) == 0 {
 		// empty list should be encoded as empty list
@@ -1331,7 +1776,7 @@ func mapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (inte
 	}
 	for _, kn := range mapKeys {
 		k := mapKeyMap[kn]
-		goStruct, ok := field.MapIndex(k).Interface().(GoStruct)
+		goStruct, ok := util.ListMemberPtr(field.MapIndex(k)).Interface().(GoStruct)
 		if !ok {
 			errs.Add(fmt.Errorf("cannot map struct %v, invalid GoStruct", field))
 			continue
@@ -1343,6 +1788,13 @@ func mapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (inte
 			continue
 		}
 
+		if args.alwaysEmitListKeyLeaves {
+			if err := addListKeyLeaves(val, k, field.Type().Elem(), parentMod, args); err != nil {
+				errs.Add(err)
+				continue
+			}
+		}
+
 		switch args.jType {
 		case RFC7951:
 			vals = append(vals.([]interface{}), val)
@@ -1360,6 +1812,132 @@ func mapJSON(field reflect.Value, parentMod string, args jsonOutputConfig) (inte
 	return vals, nil
 }
 
+// addListKeyLeaves adds the key leaves of a list member, which is a struct of
+// type elemT (or a pointer to it) stored at the key k of the list, to the JSON
+// object val that represents the member, where they are not already present.
+// Where k is a struct, the names of the key leaves are those within the path
+// tags of its fields. Otherwise, the key leaf is the field of elemT that has
+// the same type as k, and which is mapped to a direct child of the list member
+// by one of the paths within its path tag, such as the "name" path of a field
+// tagged `path:"config/name|name"`.
+func addListKeyLeaves(val map[string]interface{}, k reflect.Value, elemT reflect.Type, parentMod string, args jsonOutputConfig) error {
+	if elemT.Kind() == reflect.Ptr {
+		elemT = elemT.Elem()
+	}
+
+	var names []string
+	var keyVals []reflect.Value
+	switch k.Kind() {
+	case reflect.Struct:
+		for i := 0; i < k.NumField(); i++ {
+			p, ok := k.Type().Field(i).Tag.Lookup("path")
+			if !ok {
+				return fmt.Errorf("key field %s of list %v does not have a path tag", k.Type().Field(i).Name, elemT)
+			}
+			names = append(names, p)
+			keyVals = append(keyVals, k.Field(i))
+		}
+	default:
+		for i := 0; i < elemT.NumField(); i++ {
+			fType := elemT.Field(i)
+			ft := fType.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft != k.Type() || util.IsYgotMetadata(fType) {
+				continue
+			}
+			paths, err := structTagToLibPaths(fType, newStringSliceGNMIPath([]string{}), false)
+			if err != nil {
+				return fmt.Errorf("%s: %v", fType.Name, err)
+			}
+			for _, p := range paths {
+				if p.Len() != 1 {
+					continue
+				}
+				n, err := p.LastStringElem()
+				if err != nil {
+					return err
+				}
+				names = append(names, n)
+			}
+		}
+		if len(names) != 1 {
+			return fmt.Errorf("cannot determine the key leaf of list %v, found candidates: %v", elemT, names)
+		}
+		keyVals = append(keyVals, k)
+	}
+
+	for i, n := range names {
+		if _, ok := val[n]; ok {
+			continue
+		}
+		kv := keyVals[i]
+		// Scalar values are rendered from a pointer, in the same manner as
+		// a leaf within a GoStruct.
+		switch kv.Kind() {
+		case reflect.Int64, reflect.Interface:
+		default:
+			pv := reflect.New(kv.Type())
+			pv.Elem().Set(kv)
+			kv = pv
+		}
+		v, err := jsonValue(kv, parentMod, args)
+		if err != nil {
+			return fmt.Errorf("cannot render key %s of list %v: %v", n, elemT, err)
+		}
+		if v != nil {
+			val[n] = v
+		}
+	}
+	return nil
+}
+
+// orderedListJSON constructs the representation of the ordered list l for
+// JSON marshalling. In RFC7951 JSON, the members of the list are output in
+// the order in which they are stored within l. Since lists are output as
+// JSON objects in other formats, their representation is that of a map
+// containing the members of the list. The module within which the list is
+// defined is specified by the parentMod argument.
+func orderedListJSON(l GoOrderedList, parentMod string, args jsonOutputConfig) (interface{}, error) {
+	keys, values := l.ΛKeys(), l.ΛValues()
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("ordered list %T has %d keys and %d values", l, len(keys), len(values))
+	}
+
+	if args.jType != RFC7951 {
+		if len(keys) == 0 {
+			return nil, nil
+		}
+		m := reflect.MakeMap(reflect.MapOf(reflect.TypeOf(keys[0]), reflect.TypeOf(values[0])))
+		for i, k := range keys {
+			m.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(values[i]))
+		}
+		return mapJSON(m, parentMod, args)
+	}
+
+	var errs errlist.List
+	vals := []interface{}{}
+	for i, v := range values {
+		val, err := structJSON(v, parentMod, args)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		if args.alwaysEmitListKeyLeaves {
+			if err := addListKeyLeaves(val, reflect.ValueOf(keys[i]), reflect.TypeOf(v), parentMod, args); err != nil {
+				errs.Add(err)
+				continue
+			}
+		}
+		vals = append(vals, val)
+	}
+	if errs.Err() != nil {
+		return nil, errs.Err()
+	}
+	return vals, nil
+}
+
 // jsonValue takes a reflect.Value which represents a struct field and
 // constructs the representation that can be used to marshal the field to JSON.
 // The module within which the value is defined is specified by the parentMod string,
@@ -1388,6 +1966,14 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (in
 	case reflect.Ptr:
 		switch field.Elem().Kind() {
 		case reflect.Struct:
+			if ol, ok := field.Interface().(GoOrderedList); ok {
+				var err error
+				value, err = orderedListJSON(ol, parentMod, args)
+				if err != nil {
+					errs.Add(err)
+				}
+				break
+			}
 			goStruct, ok := field.Interface().(GoStruct)
 			if !ok {
 				return nil, fmt.Errorf("cannot map struct %v, invalid GoStruct", field)
@@ -1413,6 +1999,8 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (in
 
 		var err error
 		switch {
+		case isAnnotationSlice(field) && args.emitMetadata:
+			value, err = jsonMetadataAnnotationSlice(field)
 		case isAnnotationSlice(field):
 			value, err = jsonAnnotationSlice(field)
 		default:
@@ -1444,13 +2032,13 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (in
 			if value, err = unwrapUnionInterfaceValue(field, prependModuleNameIref); err != nil {
 				return nil, err
 			}
-			if value != nil && reflect.TypeOf(value).Name() == BinaryTypeName {
+			if value != nil && IsBinaryType(reflect.TypeOf(value)) {
 				if value, err = jsonSlice(reflect.ValueOf(value), parentMod, args); err != nil {
 					return nil, err
 				}
 				return value, nil
 			}
-		case field.Elem().Kind() == reflect.Slice && field.Elem().Type().Name() == BinaryTypeName:
+		case field.Elem().Kind() == reflect.Slice && IsBinaryType(field.Elem().Type()):
 			if value, err = jsonSlice(field.Elem(), parentMod, args); err != nil {
 				return nil, err
 			}
@@ -1489,10 +2077,24 @@ func jsonValue(field reflect.Value, parentMod string, args jsonOutputConfig) (in
 // parentMod is used to track the name of the parent module in the case that
 // module names should be prepended.
 func jsonSlice(field reflect.Value, parentMod string, args jsonOutputConfig) (interface{}, error) {
-	if field.Type().Name() == BinaryTypeName {
+	if IsBinaryType(field.Type()) {
 		// Handle the case that that we have a Binary ([]byte) value,
 		// which must be returned as a JSON string.
-		return binaryBase64(field.Bytes()), nil
+		return binaryJSON(field)
+	}
+
+	// A leaf-list of binary values is output as a list of JSON strings,
+	// such that the marshalling of custom binary types is used.
+	if IsBinaryType(field.Type().Elem()) {
+		vals := make([]interface{}, 0, field.Len())
+		for i := 0; i < field.Len(); i++ {
+			s, err := binaryJSON(field.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, s)
+		}
+		return vals, nil
 	}
 
 	// In the case that the field is a slice of struct pointers then this
@@ -1560,6 +2162,42 @@ func jsonAnnotationSlice(v reflect.Value) (interface{}, error) {
 	return vals, nil
 }
 
+// jsonMetadataAnnotationSlice takes a reflect.Value which must represent a
+// ygot Annotation field ([]ygot.Annotation), and marshals it to a single
+// RFC7952 metadata JSON object to be included in the output JSON. Each
+// annotation must marshal to a JSON object, the members of which are merged.
+// An error is returned if the same metadata annotation is specified by more
+// than one annotation.
+func jsonMetadataAnnotationSlice(v reflect.Value) (interface{}, error) {
+	if v.Len() == 0 {
+		return nil, nil
+	}
+
+	vals := map[string]interface{}{}
+	for i := 0; i < v.Len(); i++ {
+		fv := v.Index(i).Interface().(Annotation)
+		jv, err := fv.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal annotation %v type %T to JSON: %v", fv, fv, err)
+		}
+
+		var nv map[string]interface{}
+		if err := json.Unmarshal(jv, &nv); err != nil {
+			return nil, fmt.Errorf("annotation %v, type %T could not be unmarshalled as RFC7952 metadata: %v", fv, fv, err)
+		}
+		for k, mv := range nv {
+			if _, ok := vals[k]; ok {
+				return nil, fmt.Errorf("duplicate metadata annotation %s", k)
+			}
+			vals[k] = mv
+		}
+	}
+	if len(vals) == 0 {
+		return nil, nil
+	}
+	return vals, nil
+}
+
 // unwrapUnionInterfaceValue takes an input reflect.Value which must contain
 // an interface Value, and resolves it from the generated wrapper union struct
 // to the value which should be used for the YANG leaf.
@@ -25,6 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/testutil"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
@@ -1552,6 +1553,90 @@ func TestTogNMINotifications(t *testing.T) {
 	}
 }
 
+func TestPathFromField(t *testing.T) {
+	child := &pathElemExampleChild{Val: String("one"), OtherField: Uint8(42)}
+	mkChild := &pathElemExampleMultiKeyChild{Foo: String("foo"), Bar: Uint16(16), Baz: Uint8(8)}
+	root := &pathElemExample{
+		StringField: String("hello"),
+		List:        map[string]*pathElemExampleChild{"one": child},
+		MKey: map[pathElemExampleMultiKeyChildKey]*pathElemExampleMultiKeyChild{
+			{Foo: "foo", Bar: 16}: mkChild,
+		},
+	}
+
+	tests := []struct {
+		name             string
+		inRoot           GoStruct
+		inField          interface{}
+		want             *gnmipb.Path
+		wantErrSubstring string
+	}{{
+		name:    "leaf at root",
+		inRoot:  root,
+		inField: &root.StringField,
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "string-field"},
+		}},
+	}, {
+		name:    "leaf referenced by pointer value",
+		inRoot:  root,
+		inField: root.StringField,
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "string-field"},
+		}},
+	}, {
+		name:    "leaf within single keyed list",
+		inRoot:  root,
+		inField: &child.OtherField,
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "list", Key: map[string]string{"val": "one"}},
+			{Name: "other-field"},
+		}},
+	}, {
+		name:    "leaf with multiple paths within list",
+		inRoot:  root,
+		inField: child.Val,
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "list", Key: map[string]string{"val": "one"}},
+			{Name: "val"},
+		}},
+	}, {
+		name:    "leaf within multi-keyed list",
+		inRoot:  root,
+		inField: &mkChild.Baz,
+		want: &gnmipb.Path{Elem: []*gnmipb.PathElem{
+			{Name: "m-key", Key: map[string]string{"foo": "foo", "bar": "16"}},
+			{Name: "baz"},
+		}},
+	}, {
+		name:             "field not within root",
+		inRoot:           root,
+		inField:          String("hello"),
+		wantErrSubstring: "was not found",
+	}, {
+		name:             "non-pointer field reference",
+		inRoot:           root,
+		inField:          "hello",
+		wantErrSubstring: "must be a non-nil pointer",
+	}, {
+		name:             "nil root",
+		inField:          &root.StringField,
+		wantErrSubstring: "was not valid",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PathFromField(tt.inRoot, tt.inField)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("PathFromField(%v, %v): did not get expected error, %s", tt.inRoot, tt.inField, diff)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("PathFromField(%v, %v): did not get expected path, diff(-want, +got):\n%s", tt.inRoot, tt.inField, diff)
+			}
+		})
+	}
+}
+
 // exampleDevice and the following structs are a set of structs used for more
 // complex testing in TestConstructIETFJSON
 type exampleDevice struct {
@@ -2026,6 +2111,25 @@ func (t *testAnnotation) UnmarshalJSON(d []byte) error {
 	return json.Unmarshal(d, t)
 }
 
+// testAnnotationContainer is a user-defined annotation container, which
+// stores its annotations in an unexported field.
+type testAnnotationContainer struct {
+	annotations []Annotation
+}
+
+func (t *testAnnotationContainer) Annotations() []Annotation { return t.annotations }
+
+type annotationContainerJSONTestStruct struct {
+	Field     *string                  `path:"field" module:"bar"`
+	ΛMetadata *testAnnotationContainer `path:"@" ygotAnnotation:"true"`
+	ΛField    *testAnnotationContainer `path:"@field" ygotAnnotation:"true"`
+}
+
+func (*annotationContainerJSONTestStruct) IsYANGGoStruct()                         {}
+func (*annotationContainerJSONTestStruct) ΛValidate(...ValidationOption) error     { return nil }
+func (*annotationContainerJSONTestStruct) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*annotationContainerJSONTestStruct) ΛBelongingModule() string                { return "" }
+
 type errorAnnotation struct {
 	AnnotationField string `json:"field"`
 }
@@ -2058,6 +2162,7 @@ func TestConstructJSON(t *testing.T) {
 		inPrependModIref         bool
 		inRewriteModuleNameRules map[string]string
 		inPreferShadowPath       bool
+		inUseBelongingModule     bool
 		wantIETF                 map[string]interface{}
 		wantInternal             map[string]interface{}
 		wantSame                 bool
@@ -3017,6 +3122,30 @@ func TestConstructJSON(t *testing.T) {
 			},
 		},
 		wantSame: true,
+	}, {
+		name: "annotation with user-defined container type",
+		in: &annotationContainerJSONTestStruct{
+			Field: String("russian-river"),
+			ΛField: &testAnnotationContainer{
+				annotations: []Annotation{&testAnnotation{AnnotationFieldOne: "alexander-valley"}},
+			},
+		},
+		wantIETF: map[string]interface{}{
+			"field": "russian-river",
+			"@field": []interface{}{
+				map[string]interface{}{"field": "alexander-valley"},
+			},
+		},
+		wantSame: true,
+	}, {
+		name: "annotation with unset user-defined container type",
+		in: &annotationContainerJSONTestStruct{
+			Field: String("russian-river"),
+		},
+		wantIETF: map[string]interface{}{
+			"field": "russian-river",
+		},
+		wantSame: true,
 	}, {
 		name: "error in annotation - cannot marshal",
 		in: &annotatedJSONTestStruct{
@@ -3305,6 +3434,23 @@ func TestConstructJSON(t *testing.T) {
 		in:       &renderExample{UnionVal: &renderExampleUnionEnum{EnumTestUNSET}},
 		wantIETF: map[string]interface{}{},
 		wantSame: true,
+	}, {
+		name:        "non-root struct with top-level module names",
+		in:          &mapStructTestOneChild{FieldOne: String("bar"), FieldFive: Uint64(42)},
+		inAppendMod: true,
+		wantIETF: map[string]interface{}{
+			"test-one:config":  map[string]interface{}{"field-one": "bar"},
+			"test-five:config": map[string]interface{}{"field-five": "42"},
+		},
+	}, {
+		name:                 "non-root struct using belonging module as parent",
+		in:                   &mapStructTestOneChild{FieldOne: String("bar"), FieldFive: Uint64(42)},
+		inAppendMod:          true,
+		inUseBelongingModule: true,
+		wantIETF: map[string]interface{}{
+			"config":           map[string]interface{}{"field-one": "bar"},
+			"test-five:config": map[string]interface{}{"field-five": "42"},
+		},
 	}}
 
 	for _, tt := range tests {
@@ -3314,6 +3460,7 @@ func TestConstructJSON(t *testing.T) {
 				PrependModuleNameIdentityref: tt.inPrependModIref,
 				RewriteModuleNames:           tt.inRewriteModuleNameRules,
 				PreferShadowPath:             tt.inPreferShadowPath,
+				UseBelongingModuleAsParent:   tt.inUseBelongingModule,
 			})
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("ConstructIETFJSON(%v): got unexpected error: %v, want error %v", tt.in, err, tt.wantErr)
@@ -3953,6 +4100,211 @@ func TestEncodeTypedValue(t *testing.T) {
 	}
 }
 
+func TestLeafToTypedValue(t *testing.T) {
+	leafSchema := func(k yang.TypeKind) *yang.Entry {
+		return &yang.Entry{
+			Name: "leaf",
+			Kind: yang.LeafEntry,
+			Type: &yang.YangType{Kind: k},
+		}
+	}
+
+	leafListSchema := func(k yang.TypeKind) *yang.Entry {
+		e := leafSchema(k)
+		e.ListAttr = yang.NewDefaultListAttr()
+		return e
+	}
+
+	leafrefContainer := &yang.Entry{
+		Name: "container",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"target": {
+				Name: "target",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Yuint32},
+			},
+			"ref": {
+				Name: "ref",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Yleafref, Path: "../target"},
+			},
+		},
+	}
+	for _, e := range leafrefContainer.Dir {
+		e.Parent = leafrefContainer
+	}
+
+	tests := []struct {
+		name             string
+		inSchema         *yang.Entry
+		inVal            interface{}
+		want             *gnmipb.TypedValue
+		wantErrSubstring string
+	}{{
+		name:     "int8",
+		inSchema: leafSchema(yang.Yint8),
+		inVal:    Int8(-42),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{-42}},
+	}, {
+		name:     "int16",
+		inSchema: leafSchema(yang.Yint16),
+		inVal:    Int16(-42),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{-42}},
+	}, {
+		name:     "int32",
+		inSchema: leafSchema(yang.Yint32),
+		inVal:    Int32(-42),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{-42}},
+	}, {
+		name:     "int64",
+		inSchema: leafSchema(yang.Yint64),
+		inVal:    Int64(-42),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{-42}},
+	}, {
+		name:     "uint8",
+		inSchema: leafSchema(yang.Yuint8),
+		inVal:    Uint8(42),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{42}},
+	}, {
+		name:     "uint16",
+		inSchema: leafSchema(yang.Yuint16),
+		inVal:    Uint16(42),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{42}},
+	}, {
+		name:     "uint32",
+		inSchema: leafSchema(yang.Yuint32),
+		inVal:    Uint32(42),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{42}},
+	}, {
+		name:     "uint64",
+		inSchema: leafSchema(yang.Yuint64),
+		inVal:    Uint64(42),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{42}},
+	}, {
+		name:     "decimal64",
+		inSchema: leafSchema(yang.Ydecimal64),
+		inVal:    Float64(4.2),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_FloatVal{4.2}},
+	}, {
+		name:     "string",
+		inSchema: leafSchema(yang.Ystring),
+		inVal:    String("forty-two"),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"forty-two"}},
+	}, {
+		name:     "bool",
+		inSchema: leafSchema(yang.Ybool),
+		inVal:    Bool(true),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{true}},
+	}, {
+		name:     "empty",
+		inSchema: leafSchema(yang.Yempty),
+		inVal:    YANGEmpty(true),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{true}},
+	}, {
+		name:     "binary",
+		inSchema: leafSchema(yang.Ybinary),
+		inVal:    Binary{42},
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BytesVal{[]byte{42}}},
+	}, {
+		name:     "instance-identifier",
+		inSchema: leafSchema(yang.YinstanceIdentifier),
+		inVal:    String("/interfaces/interface[name=eth0]"),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"/interfaces/interface[name=eth0]"}},
+	}, {
+		name:     "enumeration",
+		inSchema: leafSchema(yang.Yenum),
+		inVal:    EnumTestVALONE,
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"VAL_ONE"}},
+	}, {
+		name:     "unset enumeration",
+		inSchema: leafSchema(yang.Yenum),
+		inVal:    EnumTestUNSET,
+		want:     nil,
+	}, {
+		name:     "identityref",
+		inSchema: leafSchema(yang.Yidentityref),
+		inVal:    EnumTestVALTWO,
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{[]byte(`"bar:VAL_TWO"`)}},
+	}, {
+		name:     "simple union with string",
+		inSchema: leafSchema(yang.Yunion),
+		inVal:    testutil.UnionString("forty-two"),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{[]byte(`"forty-two"`)}},
+	}, {
+		name:     "simple union with int64",
+		inSchema: leafSchema(yang.Yunion),
+		inVal:    testutil.UnionInt64(42),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{[]byte(`"42"`)}},
+	}, {
+		name:     "simple union with identityref",
+		inSchema: leafSchema(yang.Yunion),
+		inVal:    EnumTestVALONE,
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{[]byte(`"foo:VAL_ONE"`)}},
+	}, {
+		name:     "simple union with binary",
+		inSchema: leafSchema(yang.Yunion),
+		inVal:    Binary("abc"),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{[]byte(`"YWJj"`)}},
+	}, {
+		name:     "wrapper union",
+		inSchema: leafSchema(yang.Yunion),
+		inVal:    &renderExampleUnionInt64{Int64: 42},
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{[]byte(`"42"`)}},
+	}, {
+		name:     "leafref",
+		inSchema: leafrefContainer.Dir["ref"],
+		inVal:    Uint32(42),
+		want:     &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{42}},
+	}, {
+		name:     "leaf-list",
+		inSchema: leafListSchema(yang.Yuint8),
+		inVal:    []uint8{1, 2},
+		want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{&gnmipb.ScalarArray{
+			Element: []*gnmipb.TypedValue{
+				{Value: &gnmipb.TypedValue_UintVal{1}},
+				{Value: &gnmipb.TypedValue_UintVal{2}},
+			},
+		}}},
+	}, {
+		name:     "nil value",
+		inSchema: leafSchema(yang.Ystring),
+		inVal:    (*string)(nil),
+		want:     nil,
+	}, {
+		name:             "mismatched type",
+		inSchema:         leafSchema(yang.Yuint32),
+		inVal:            String("forty-two"),
+		wantErrSubstring: "cannot represent value of type string as YANG type uint32",
+	}, {
+		name:             "non-slice for leaf-list",
+		inSchema:         leafListSchema(yang.Ystring),
+		inVal:            String("forty-two"),
+		wantErrSubstring: "must be a slice",
+	}, {
+		name: "non-leaf schema",
+		inSchema: &yang.Entry{
+			Name: "container",
+			Kind: yang.DirectoryEntry,
+		},
+		inVal:            String("forty-two"),
+		wantErrSubstring: "schema is not a leaf or leaf-list",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LeafToTypedValue(tt.inSchema, tt.inVal)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("did not get expected error, %s", diff)
+			}
+
+			if !proto.Equal(got, tt.want) {
+				t.Fatalf("did not get expected value, got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func mustPathElem(s string) []*gnmipb.PathElem {
 	p, err := StringToStructuredPath(s)
 	if err != nil {
@@ -4313,3 +4665,505 @@ func TestMarshal7951(t *testing.T) {
 		})
 	}
 }
+
+// valueLeafStruct is a test struct containing leaves that are stored as
+// values rather than pointers.
+type valueLeafStruct struct {
+	Str  string  `path:"str" module:"mod" ygotValueLeaf:"true"`
+	Int  int64   `path:"int" module:"mod" ygotValueLeaf:"true"`
+	Bool bool    `path:"bool" module:"mod" ygotValueLeaf:"true"`
+	Ptr  *uint32 `path:"ptr" module:"mod"`
+}
+
+func (*valueLeafStruct) IsYANGGoStruct() {}
+
+func TestRenderValueLeaves(t *testing.T) {
+	tests := []struct {
+		name             string
+		inStruct         *valueLeafStruct
+		wantInternalJSON map[string]interface{}
+		wantIETFJSON     map[string]interface{}
+		wantUpdates      []*gnmipb.Update
+	}{{
+		name:     "zero values are rendered",
+		inStruct: &valueLeafStruct{},
+		wantInternalJSON: map[string]interface{}{
+			"str":  "",
+			"int":  int64(0),
+			"bool": false,
+		},
+		wantIETFJSON: map[string]interface{}{
+			"str":  "",
+			"int":  "0",
+			"bool": false,
+		},
+		wantUpdates: []*gnmipb.Update{{
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "str"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{""}},
+		}, {
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "int"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{0}},
+		}, {
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "bool"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{false}},
+		}},
+	}, {
+		name:     "populated values",
+		inStruct: &valueLeafStruct{Str: "forty-two", Int: -42, Bool: true, Ptr: Uint32(42)},
+		wantInternalJSON: map[string]interface{}{
+			"str":  "forty-two",
+			"int":  int64(-42),
+			"bool": true,
+			"ptr":  uint32(42),
+		},
+		wantIETFJSON: map[string]interface{}{
+			"str":  "forty-two",
+			"int":  "-42",
+			"bool": true,
+			"ptr":  uint32(42),
+		},
+		wantUpdates: []*gnmipb.Update{{
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "str"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{"forty-two"}},
+		}, {
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "int"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{-42}},
+		}, {
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "bool"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_BoolVal{true}},
+		}, {
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "ptr"}}},
+			Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{42}},
+		}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotInternal, err := ConstructInternalJSON(tt.inStruct)
+			if err != nil {
+				t.Fatalf("ConstructInternalJSON: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantInternalJSON, gotInternal); diff != "" {
+				t.Errorf("ConstructInternalJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+			}
+
+			gotIETF, err := ConstructIETFJSON(tt.inStruct, nil)
+			if err != nil {
+				t.Fatalf("ConstructIETFJSON: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantIETFJSON, gotIETF); diff != "" {
+				t.Errorf("ConstructIETFJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+			}
+
+			gotNotifs, err := TogNMINotifications(tt.inStruct, 42, GNMINotificationsConfig{UsePathElem: true})
+			if err != nil {
+				t.Fatalf("TogNMINotifications: got unexpected error: %v", err)
+			}
+			want := []*gnmipb.Notification{{
+				Timestamp: 42,
+				Update:    tt.wantUpdates,
+			}}
+			if !testutil.NotificationSetEqual(gotNotifs, want) {
+				t.Errorf("TogNMINotifications: did not get expected notifications, got: %v, want: %v", gotNotifs, want)
+			}
+		})
+	}
+}
+
+// fixedArrayStruct is a test struct containing a leaf-list that is stored as a
+// fixed-size array.
+type fixedArrayStruct struct {
+	Hops    [3]string `path:"hops" module:"mod" ygotArrayLen:"HopsLen"`
+	HopsLen int       `ygotArrayLenOf:"Hops"`
+}
+
+func (*fixedArrayStruct) IsYANGGoStruct()                         {}
+func (*fixedArrayStruct) ΛValidate(...ValidationOption) error     { return nil }
+func (*fixedArrayStruct) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*fixedArrayStruct) ΛBelongingModule() string                { return "mod" }
+
+func TestRenderFixedArrays(t *testing.T) {
+	tests := []struct {
+		name             string
+		inStruct         *fixedArrayStruct
+		wantInternalJSON map[string]interface{}
+		wantIETFJSON     map[string]interface{}
+		wantUpdates      []*gnmipb.Update
+		wantErr          string
+	}{{
+		name:             "no populated elements",
+		inStruct:         &fixedArrayStruct{Hops: [3]string{"unused"}},
+		wantInternalJSON: map[string]interface{}{},
+		wantIETFJSON:     map[string]interface{}{},
+	}, {
+		name:     "populated elements only are rendered",
+		inStruct: &fixedArrayStruct{Hops: [3]string{"a", "b", "unused"}, HopsLen: 2},
+		wantInternalJSON: map[string]interface{}{
+			"hops": []interface{}{"a", "b"},
+		},
+		wantIETFJSON: map[string]interface{}{
+			"hops": []interface{}{"a", "b"},
+		},
+		wantUpdates: []*gnmipb.Update{{
+			Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "hops"}}},
+			Val: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{&gnmipb.ScalarArray{
+				Element: []*gnmipb.TypedValue{
+					{Value: &gnmipb.TypedValue_StringVal{"a"}},
+					{Value: &gnmipb.TypedValue_StringVal{"b"}},
+				},
+			}}},
+		}},
+	}, {
+		name:     "length outside of array bounds",
+		inStruct: &fixedArrayStruct{HopsLen: 4},
+		wantErr:  "length 4 of field Hops is not within the bounds of its array of 3 elements",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotInternal, err := ConstructInternalJSON(tt.inStruct)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("ConstructInternalJSON: %s", diff)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if diff := cmp.Diff(tt.wantInternalJSON, gotInternal); diff != "" {
+				t.Errorf("ConstructInternalJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+			}
+
+			gotIETF, err := ConstructIETFJSON(tt.inStruct, nil)
+			if err != nil {
+				t.Fatalf("ConstructIETFJSON: got unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantIETFJSON, gotIETF); diff != "" {
+				t.Errorf("ConstructIETFJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+			}
+
+			gotNotifs, err := TogNMINotifications(tt.inStruct, 42, GNMINotificationsConfig{UsePathElem: true})
+			if err != nil {
+				t.Fatalf("TogNMINotifications: got unexpected error: %v", err)
+			}
+			want := []*gnmipb.Notification{{
+				Timestamp: 42,
+				Update:    tt.wantUpdates,
+			}}
+			if !testutil.NotificationSetEqual(gotNotifs, want) {
+				t.Errorf("TogNMINotifications: did not get expected notifications, got: %v, want: %v", gotNotifs, want)
+			}
+		})
+	}
+}
+
+// orderedListMember is a member of the ordered list within orderedListStruct.
+type orderedListMember struct {
+	Key   *string `path:"key"`
+	Value *string `path:"value"`
+}
+
+func (*orderedListMember) IsYANGGoStruct() {}
+
+func (t *orderedListMember) ΛListKeyMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"key": *t.Key}, nil
+}
+
+// orderedListMap is an ordered list, which retains the order in which its
+// members are appended.
+type orderedListMap struct {
+	keys     []string
+	valueMap map[string]*orderedListMember
+}
+
+func (*orderedListMap) IsYANGOrderedList() {}
+
+func (o *orderedListMap) Len() int { return len(o.keys) }
+
+func (o *orderedListMap) ΛKeys() []interface{} {
+	var keys []interface{}
+	for _, k := range o.keys {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (o *orderedListMap) ΛValues() []GoStruct {
+	var values []GoStruct
+	for _, k := range o.keys {
+		values = append(values, o.valueMap[k])
+	}
+	return values
+}
+
+func (o *orderedListMap) append(key, value string) {
+	if o.valueMap == nil {
+		o.valueMap = map[string]*orderedListMember{}
+	}
+	o.keys = append(o.keys, key)
+	o.valueMap[key] = &orderedListMember{Key: String(key), Value: String(value)}
+}
+
+func (o *orderedListMap) Append(v *orderedListMember) error {
+	if _, ok := o.valueMap[*v.Key]; ok {
+		return fmt.Errorf("duplicate key for list %s", *v.Key)
+	}
+	if o.valueMap == nil {
+		o.valueMap = map[string]*orderedListMember{}
+	}
+	o.keys = append(o.keys, *v.Key)
+	o.valueMap[*v.Key] = v
+	return nil
+}
+
+// orderedListStruct is a GoStruct containing an ordered list.
+type orderedListStruct struct {
+	List *orderedListMap `path:"list"`
+}
+
+func (*orderedListStruct) IsYANGGoStruct() {}
+
+func (*orderedListStruct) ΛValidate(...ValidationOption) error { return nil }
+
+func TestRenderOrderedList(t *testing.T) {
+	inStruct := &orderedListStruct{List: &orderedListMap{}}
+	for _, k := range []string{"b", "a", "c"} {
+		inStruct.List.append(k, "val-"+k)
+	}
+
+	gotIETF, err := EmitJSON(inStruct, &EmitJSONConfig{
+		Format:         RFC7951,
+		SkipValidation: true,
+		Indent:         "  ",
+	})
+	if err != nil {
+		t.Fatalf("EmitJSON: got unexpected error: %v", err)
+	}
+	wantIETF := `{
+  "list": [
+    {
+      "key": "b",
+      "value": "val-b"
+    },
+    {
+      "key": "a",
+      "value": "val-a"
+    },
+    {
+      "key": "c",
+      "value": "val-c"
+    }
+  ]
+}`
+	if diff := cmp.Diff(wantIETF, gotIETF); diff != "" {
+		t.Errorf("EmitJSON: did not get expected RFC7951 JSON, diff(-want, +got):\n%s", diff)
+	}
+
+	gotInternal, err := ConstructInternalJSON(inStruct)
+	if err != nil {
+		t.Fatalf("ConstructInternalJSON: got unexpected error: %v", err)
+	}
+	wantInternal := map[string]interface{}{
+		"list": map[string]interface{}{
+			"a": map[string]interface{}{"key": "a", "value": "val-a"},
+			"b": map[string]interface{}{"key": "b", "value": "val-b"},
+			"c": map[string]interface{}{"key": "c", "value": "val-c"},
+		},
+	}
+	if diff := cmp.Diff(wantInternal, gotInternal); diff != "" {
+		t.Errorf("ConstructInternalJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+	}
+
+	gotNotifs, err := TogNMINotifications(inStruct, 42, GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		t.Fatalf("TogNMINotifications: got unexpected error: %v", err)
+	}
+	var wantUpdates []*gnmipb.Update
+	for _, k := range []string{"a", "b", "c"} {
+		for _, leaf := range []string{"key", "value"} {
+			val := k
+			if leaf == "value" {
+				val = "val-" + k
+			}
+			wantUpdates = append(wantUpdates, &gnmipb.Update{
+				Path: &gnmipb.Path{Elem: []*gnmipb.PathElem{{Name: "list", Key: map[string]string{"key": k}}, {Name: leaf}}},
+				Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{val}},
+			})
+		}
+	}
+	want := []*gnmipb.Notification{{
+		Timestamp: 42,
+		Update:    wantUpdates,
+	}}
+	if !testutil.NotificationSetEqual(gotNotifs, want) {
+		t.Errorf("TogNMINotifications: did not get expected notifications, got: %v, want: %v", gotNotifs, want)
+	}
+}
+
+// emptyLeafStruct is a GoStruct with a leaf of type empty, represented using
+// the YANGEmpty type.
+type emptyLeafStruct struct {
+	E YANGEmpty `path:"e"`
+}
+
+func (*emptyLeafStruct) IsYANGGoStruct()                     {}
+func (*emptyLeafStruct) ΛValidate(...ValidationOption) error { return nil }
+
+// emptyLeafBoolStruct is a GoStruct with a leaf of type empty, represented
+// as a bool.
+type emptyLeafBoolStruct struct {
+	E *bool `path:"e"`
+}
+
+func (*emptyLeafBoolStruct) IsYANGGoStruct()                     {}
+func (*emptyLeafBoolStruct) ΛValidate(...ValidationOption) error { return nil }
+
+func TestEmitJSONEmptyLeaf(t *testing.T) {
+	tests := []struct {
+		name         string
+		inStruct     validatedGoStruct
+		wantRFC7951  string
+		wantInternal string
+	}{{
+		name:     "YANGEmpty set",
+		inStruct: &emptyLeafStruct{E: true},
+		wantRFC7951: `{
+  "e": [
+    null
+  ]
+}`,
+		wantInternal: `{
+  "e": true
+}`,
+	}, {
+		name:         "YANGEmpty unset",
+		inStruct:     &emptyLeafStruct{},
+		wantRFC7951:  `{}`,
+		wantInternal: `{}`,
+	}, {
+		name:     "bool set",
+		inStruct: &emptyLeafBoolStruct{E: Bool(true)},
+		wantRFC7951: `{
+  "e": true
+}`,
+		wantInternal: `{
+  "e": true
+}`,
+	}, {
+		name:         "bool unset",
+		inStruct:     &emptyLeafBoolStruct{},
+		wantRFC7951:  `{}`,
+		wantInternal: `{}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, f := range []struct {
+				format JSONFormat
+				want   string
+			}{{RFC7951, tt.wantRFC7951}, {Internal, tt.wantInternal}} {
+				got, err := EmitJSON(tt.inStruct, &EmitJSONConfig{
+					Format: f.format,
+					Indent: "  ",
+				})
+				if err != nil {
+					t.Fatalf("EmitJSON(%v): got unexpected error: %v", f.format, err)
+				}
+				if diff := cmp.Diff(f.want, got); diff != "" {
+					t.Errorf("EmitJSON(%v): did not get expected JSON, diff(-want, +got):\n%s", f.format, diff)
+				}
+			}
+		})
+	}
+}
+
+// customBinary is a binary type, defined outside of the generated code, which
+// uses URL-safe base64 encoding for its JSON representation.
+type customBinary []byte
+
+func (c customBinary) MarshalBase64() (string, error) {
+	return base64.URLEncoding.EncodeToString(c), nil
+}
+
+// customBinaryStruct is a GoStruct with leaves of a custom binary type.
+type customBinaryStruct struct {
+	B  customBinary   `path:"b"`
+	LL []customBinary `path:"ll"`
+}
+
+func (*customBinaryStruct) IsYANGGoStruct()                     {}
+func (*customBinaryStruct) ΛValidate(...ValidationOption) error { return nil }
+
+func TestEmitJSONCustomBinary(t *testing.T) {
+	tests := []struct {
+		name     string
+		inStruct validatedGoStruct
+		want     string
+	}{{
+		name:     "binary leaf",
+		inStruct: &customBinaryStruct{B: customBinary{0xfb, 0xff}},
+		want: `{
+  "b": "-_8="
+}`,
+	}, {
+		name:     "binary leaf-list",
+		inStruct: &customBinaryStruct{LL: []customBinary{{0xfb, 0xff}, {0x01}}},
+		want: `{
+  "ll": [
+    "-_8=",
+    "AQ=="
+  ]
+}`,
+	}, {
+		name:     "unset",
+		inStruct: &customBinaryStruct{},
+		want:     `{}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, format := range []JSONFormat{RFC7951, Internal} {
+				got, err := EmitJSON(tt.inStruct, &EmitJSONConfig{
+					Format: format,
+					Indent: "  ",
+				})
+				if err != nil {
+					t.Fatalf("EmitJSON(%v): got unexpected error: %v", format, err)
+				}
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("EmitJSON(%v): did not get expected JSON, diff(-want, +got):\n%s", format, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestIsBinaryType(t *testing.T) {
+	tests := []struct {
+		name string
+		in   reflect.Type
+		want bool
+	}{{
+		name: "generated binary type",
+		in:   reflect.TypeOf(Binary{}),
+		want: true,
+	}, {
+		name: "custom binary type",
+		in:   reflect.TypeOf(customBinary{}),
+		want: true,
+	}, {
+		name: "byte slice",
+		in:   reflect.TypeOf([]byte{}),
+	}, {
+		name: "leaf-list of binary",
+		in:   reflect.TypeOf([]Binary{}),
+	}, {
+		name: "nil",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBinaryType(tt.in); got != tt.want {
+				t.Errorf("IsBinaryType(%v): got %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/gnmi/errlist"
+	"github.com/openconfig/ygot/util"
+)
+
+// RootEntity describes a container or list at the root of the YANG schema
+// tree for which a GoStruct is generated. Where code is generated without a
+// fake root, a map of the root entities, keyed by the name of the generated
+// GoStruct, is used by the generated root-level helpers to determine where
+// each GoStruct supplied to them resides within the schema tree.
+type RootEntity struct {
+	// Path is the path of the entity from the root of the schema tree, in
+	// the form of a path struct tag (e.g., "interfaces/interface").
+	Path string
+	// Module is the module of each element of Path, in the form of a module
+	// struct tag (e.g., "openconfig-interfaces/openconfig-interfaces").
+	Module string
+	// Keys is the set of YANG names of the keys of the entity, in the order
+	// in which they are declared in the schema. It is empty for containers.
+	Keys []string
+}
+
+// rootMember is an instance of a root entity, along with the string
+// representation of its key. The key is empty for containers, and the
+// values of multiple keys are separated by a space, as in Internal JSON.
+type rootMember struct {
+	key string
+	s   GoStruct
+}
+
+// rootGroup is the set of instances of a root entity.
+type rootGroup struct {
+	// name is the name of the GoStruct generated for the entity.
+	name string
+	// entity is the description of the root entity.
+	entity RootEntity
+	// members is the set of instances of the entity, in the order in
+	// which they were supplied.
+	members []rootMember
+}
+
+// groupRootStructs groups the supplied structs according to the root entity,
+// as described by entities, of which they are an instance. The groups are
+// returned in the order in which an instance of each was first supplied. An
+// error is returned if a struct is nil, is not an instance of a root entity,
+// or if its key cannot be determined.
+func groupRootStructs(entities map[string]RootEntity, structs []GoStruct) ([]*rootGroup, error) {
+	var groups []*rootGroup
+	byName := map[string]*rootGroup{}
+	for _, s := range structs {
+		if util.IsValueNil(s) {
+			return nil, fmt.Errorf("nil GoStruct supplied")
+		}
+		name := reflect.TypeOf(s).Elem().Name()
+		e, ok := entities[name]
+		if !ok {
+			return nil, fmt.Errorf("%T is not an entity at the root of the schema", s)
+		}
+		key, err := rootStructKey(e, s)
+		if err != nil {
+			return nil, err
+		}
+		g, ok := byName[name]
+		if !ok {
+			g = &rootGroup{name: name, entity: e}
+			byName[name] = g
+			groups = append(groups, g)
+		}
+		g.members = append(g.members, rootMember{key: key, s: s})
+	}
+	return groups, nil
+}
+
+// rootStructKey returns the string representation of the key of s, which is
+// an instance of the root entity e. The empty string is returned if e is a
+// container.
+func rootStructKey(e RootEntity, s GoStruct) (string, error) {
+	if len(e.Keys) == 0 {
+		return "", nil
+	}
+	ks, ok := s.(KeyHelperGoStruct)
+	if !ok {
+		return "", fmt.Errorf("%T is a list member but does not implement KeyHelperGoStruct", s)
+	}
+	km, err := ks.ΛListKeyMap()
+	if err != nil {
+		return "", fmt.Errorf("%T: cannot retrieve keys: %v", s, err)
+	}
+	kp := make([]string, 0, len(e.Keys))
+	for _, k := range e.Keys {
+		v, ok := km[k]
+		if !ok {
+			return "", fmt.Errorf("%T: key %s is not populated", s, k)
+		}
+		kv, err := KeyValueAsString(v)
+		if err != nil {
+			return "", fmt.Errorf("%T: invalid value for key %s: %v", s, k, err)
+		}
+		kp = append(kp, kv)
+	}
+	return strings.Join(kp, " "), nil
+}
+
+// EmitRootJSON serialises the supplied GoStructs, each of which is an
+// instance of one of the root entities described by entities, to a single
+// JSON document as though they were the children of the fake root. It
+// allows code that is generated without a fake root to output the contents
+// of multiple root entities together, and is called by the EmitAllJSON
+// function of such generated code.
+//
+// The members of each list are ordered as they would be were they output
+// from the fake root, other than that they are ordered by the string
+// representation of their keys regardless of SortListsByKey. An error is
+// returned if more than one instance of a container, or more than one list
+// member with the same key, is supplied. Only the Internal, RFC7951 and
+// OpenConfigCompact formats are supported.
+func EmitRootJSON(entities map[string]RootEntity, opts *EmitJSONConfig, structs ...GoStruct) (string, error) {
+	var (
+		f              = Internal
+		vopts          []ValidationOption
+		skipValidation bool
+	)
+	if opts != nil {
+		f = opts.Format
+		vopts = opts.ValidationOpts
+		skipValidation = opts.SkipValidation
+	}
+
+	var args jsonOutputConfig
+	switch f {
+	case Internal:
+		args = jsonOutputConfig{jType: Internal}
+	case RFC7951, OpenConfigCompact:
+		args = rfc7951OutputConfig(opts)
+	default:
+		return "", fmt.Errorf("JSON format %v cannot be rendered for root entities", f)
+	}
+
+	groups, err := groupRootStructs(entities, structs)
+	if err != nil {
+		return "", err
+	}
+
+	var errs errlist.List
+	jsonout := map[string]interface{}{}
+	for _, g := range groups {
+		if len(g.entity.Keys) == 0 && len(g.members) > 1 {
+			errs.Add(fmt.Errorf("%s: %d instances of container supplied", g.name, len(g.members)))
+			continue
+		}
+		sort.SliceStable(g.members, func(i, j int) bool { return g.members[i].key < g.members[j].key })
+
+		// The synthesised field has the struct tags that the field of
+		// the fake root for the entity would have.
+		field := reflect.StructField{
+			Name: g.name,
+			Tag:  reflect.StructTag(fmt.Sprintf(`path:"%s" module:"%s"`, g.entity.Path, g.entity.Module)),
+		}
+		var prependmods [][]string
+		var chMod string
+		if args.jType == RFC7951 && args.rfc7951Config != nil && args.rfc7951Config.AppendModuleName {
+			if prependmods, chMod, err = prependmodsJSON(field, "", args); err != nil {
+				errs.Add(err)
+				continue
+			}
+		}
+
+		var values []interface{}
+		members := map[string]interface{}{}
+		for i, m := range g.members {
+			if i > 0 && m.key == g.members[i-1].key {
+				errs.Add(fmt.Errorf("%s: duplicate list member with key %q supplied", g.name, m.key))
+				continue
+			}
+			if !skipValidation {
+				vs, ok := m.s.(validatedGoStruct)
+				if !ok {
+					errs.Add(fmt.Errorf("%s: GoStruct does not have ΛValidate() method", g.name))
+					continue
+				}
+				if err := vs.ΛValidate(vopts...); err != nil {
+					errs.Add(fmt.Errorf("%s: validation err: %v", g.name, err))
+					continue
+				}
+			}
+			v, err := structJSON(m.s, chMod, args)
+			if err != nil {
+				errs.Add(fmt.Errorf("%s: %v", g.name, err))
+				continue
+			}
+			values = append(values, v)
+			members[m.key] = v
+		}
+
+		var value interface{}
+		switch {
+		case len(g.entity.Keys) == 0:
+			if len(values) == 0 || len(values[0].(map[string]interface{})) == 0 {
+				continue
+			}
+			value = values[0]
+		case args.jType == RFC7951:
+			value = values
+		default:
+			value = members
+		}
+
+		if err := setRootJSON(jsonout, g.entity.Path, prependmods, value); err != nil {
+			errs.Add(fmt.Errorf("%s: %v", g.name, err))
+		}
+	}
+
+	if errs.Err() != nil {
+		return "", errs.Err()
+	}
+	return encodeJSON(jsonout, opts)
+}
+
+// setRootJSON stores value within the JSON object jsonout at the path p,
+// which is in the form of a path struct tag, creating the intermediate
+// objects along the path where they do not exist. The module names within
+// prependmods, as returned by prependmodsJSON, are prepended to the
+// corresponding elements of the path where they are non-empty.
+func setRootJSON(jsonout map[string]interface{}, p string, prependmods [][]string, value interface{}) error {
+	elems := strings.Split(p, "/")
+	if prependmods != nil && (len(prependmods) != 1 || len(prependmods[0]) != len(elems)) {
+		return fmt.Errorf("number of paths and modules elements not the same: (path: %s, modules: %v)", p, prependmods)
+	}
+	parent := jsonout
+	for i, k := range elems {
+		if prependmods != nil && prependmods[0][i] != "" {
+			k = fmt.Sprintf("%s:%s", prependmods[0][i], k)
+		}
+		if i == len(elems)-1 {
+			if _, ok := parent[k]; ok {
+				return fmt.Errorf("duplicate entity at path %s", p)
+			}
+			parent[k] = value
+			break
+		}
+		if _, ok := parent[k]; !ok {
+			parent[k] = map[string]interface{}{}
+		}
+		c, ok := parent[k].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("element %s of path %s is not a container", k, p)
+		}
+		parent = c
+	}
+	return nil
+}
+
+// MergeRootStructs merges the supplied GoStructs, each of which is an
+// instance of one of the root entities described by entities, such that the
+// returned slice contains a single instance of each container, and a single
+// member for each key of each list. Instances are merged using MergeStructs
+// with the supplied options, and an error is returned if they cannot be
+// merged. The returned GoStructs are ordered according to the order in which
+// the first instance of each was supplied, grouped by entity. Where only a
+// single instance is supplied, the supplied GoStruct is returned rather
+// than a copy of it. It is called by the MergeAll function of code that is
+// generated without a fake root.
+func MergeRootStructs(entities map[string]RootEntity, structs []GoStruct, opts ...MergeOpt) ([]GoStruct, error) {
+	groups, err := groupRootStructs(entities, structs)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []GoStruct
+	for _, g := range groups {
+		var keys []string
+		byKey := map[string]GoStruct{}
+		for _, m := range g.members {
+			s, ok := byKey[m.key]
+			if !ok {
+				keys = append(keys, m.key)
+				byKey[m.key] = m.s
+				continue
+			}
+			ns, err := MergeStructs(s, m.s, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("%s: cannot merge instances with key %q: %v", g.name, m.key, err)
+			}
+			byKey[m.key] = ns
+		}
+		for _, k := range keys {
+			merged = append(merged, byKey[k])
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+// testRootEntities describes the test structs that are used as root entities.
+var testRootEntities = map[string]RootEntity{
+	"pathElemExampleMultiKeyChild": {
+		Path:   "lists/list",
+		Module: "mod-a/mod-b",
+		Keys:   []string{"foo", "bar"},
+	},
+	"renderExampleChild": {
+		Path:   "child",
+		Module: "mod-a",
+	},
+}
+
+func TestEmitRootJSON(t *testing.T) {
+	tests := []struct {
+		name             string
+		inStructs        []GoStruct
+		inOpts           *EmitJSONConfig
+		want             string
+		wantErrSubstring string
+	}{{
+		name: "internal format",
+		inStructs: []GoStruct{
+			&pathElemExampleMultiKeyChild{Foo: String("x"), Bar: Uint16(2)},
+			&renderExampleChild{Val: Uint64(42)},
+			&pathElemExampleMultiKeyChild{Foo: String("x"), Bar: Uint16(1)},
+		},
+		want: `{
+			"child": {"val": 42},
+			"lists": {
+				"list": {
+					"x 1": {"foo": "x", "bar": 1},
+					"x 2": {"foo": "x", "bar": 2}
+				}
+			}
+		}`,
+	}, {
+		name: "RFC7951 format with module names",
+		inStructs: []GoStruct{
+			&pathElemExampleMultiKeyChild{Foo: String("y"), Bar: Uint16(1)},
+			&pathElemExampleMultiKeyChild{Foo: String("x"), Bar: Uint16(1)},
+		},
+		inOpts: &EmitJSONConfig{
+			Format:        RFC7951,
+			RFC7951Config: &RFC7951JSONConfig{AppendModuleName: true},
+		},
+		want: `{
+			"mod-a:lists": {
+				"mod-b:list": [
+					{"foo": "x", "bar": 1},
+					{"foo": "y", "bar": 1}
+				]
+			}
+		}`,
+	}, {
+		name: "OpenConfigCompact format",
+		inStructs: []GoStruct{
+			&renderExampleChild{Val: Uint64(42)},
+		},
+		inOpts: &EmitJSONConfig{Format: OpenConfigCompact},
+		want:   `{"child": {"val": "42"}}`,
+	}, {
+		name: "empty container is omitted",
+		inStructs: []GoStruct{
+			&renderExampleChild{},
+		},
+		want: `{}`,
+	}, {
+		name: "unsupported format",
+		inStructs: []GoStruct{
+			&renderExampleChild{Val: Uint64(42)},
+		},
+		inOpts:           &EmitJSONConfig{Format: PathValueList},
+		wantErrSubstring: "cannot be rendered for root entities",
+	}, {
+		name:             "nil struct",
+		inStructs:        []GoStruct{(*renderExampleChild)(nil)},
+		wantErrSubstring: "nil GoStruct supplied",
+	}, {
+		name: "struct that is not a root entity",
+		inStructs: []GoStruct{
+			&renderExample{},
+		},
+		wantErrSubstring: "is not an entity at the root of the schema",
+	}, {
+		name: "duplicate list member",
+		inStructs: []GoStruct{
+			&pathElemExampleMultiKeyChild{Foo: String("x"), Bar: Uint16(1)},
+			&pathElemExampleMultiKeyChild{Foo: String("x"), Bar: Uint16(1)},
+		},
+		wantErrSubstring: `duplicate list member with key "x 1"`,
+	}, {
+		name: "duplicate container",
+		inStructs: []GoStruct{
+			&renderExampleChild{Val: Uint64(1)},
+			&renderExampleChild{Val: Uint64(2)},
+		},
+		wantErrSubstring: "2 instances of container supplied",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EmitRootJSON(testRootEntities, tt.inOpts, tt.inStructs...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("EmitRootJSON: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal([]byte(got), &gotJSON); err != nil {
+				t.Fatalf("EmitRootJSON: cannot unmarshal output JSON: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Fatalf("cannot unmarshal expected JSON: %v", err)
+			}
+			if diff := cmp.Diff(wantJSON, gotJSON); diff != "" {
+				t.Errorf("EmitRootJSON: did not get expected JSON, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeRootStructs(t *testing.T) {
+	tests := []struct {
+		name             string
+		inStructs        []GoStruct
+		want             []GoStruct
+		wantErrSubstring string
+	}{{
+		name: "merge containers and list members",
+		inStructs: []GoStruct{
+			&pathElemExampleMultiKeyChild{Foo: String("x"), Bar: Uint16(1)},
+			&renderExampleChild{Val: Uint64(42)},
+			&pathElemExampleMultiKeyChild{Foo: String("y"), Bar: Uint16(1)},
+			&renderExampleChild{Enum: EnumTestVALONE},
+			&pathElemExampleMultiKeyChild{Foo: String("x"), Bar: Uint16(1), Baz: Uint8(3)},
+		},
+		want: []GoStruct{
+			&pathElemExampleMultiKeyChild{Foo: String("x"), Bar: Uint16(1), Baz: Uint8(3)},
+			&pathElemExampleMultiKeyChild{Foo: String("y"), Bar: Uint16(1)},
+			&renderExampleChild{Val: Uint64(42), Enum: EnumTestVALONE},
+		},
+	}, {
+		name: "conflicting values",
+		inStructs: []GoStruct{
+			&renderExampleChild{Val: Uint64(1)},
+			&renderExampleChild{Val: Uint64(2)},
+		},
+		wantErrSubstring: "cannot merge instances",
+	}, {
+		name: "struct that is not a root entity",
+		inStructs: []GoStruct{
+			&renderExample{},
+		},
+		wantErrSubstring: "is not an entity at the root of the schema",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergeRootStructs(testRootEntities, tt.inStructs)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("MergeRootStructs: did not get expected error, %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MergeRootStructs: did not get expected structs, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
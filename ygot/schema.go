@@ -18,7 +18,9 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"sort"
 
 	"github.com/openconfig/goyang/pkg/yang"
 )
@@ -27,6 +29,57 @@ import (
 // a map of yang.Entry nodes, keyed by the name of the struct that
 // the yang.Entry describes the schema for.
 func GzipToSchema(gzj []byte) (map[string]*yang.Entry, error) {
+	root, err := gzipToEntry(gzj)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := map[string]*yang.Entry{}
+	rebuildSchemaMap(root, nil, schema)
+	return schema, nil
+}
+
+// GzipToSchemaModules takes an input map, keyed by YANG module name, of
+// gzipped JSON schema documents, each of which is rooted at the same root
+// entry and contains only the schema nodes instantiated by that module. The
+// per-module trees are merged into a single tree - such that nodes that are
+// augmented into another module's tree are stitched back into place - and
+// returned as a map of yang.Entry nodes, keyed by the name of the struct that
+// the yang.Entry describes the schema for.
+func GzipToSchemaModules(gzjs map[string][]byte) (map[string]*yang.Entry, error) {
+	if len(gzjs) == 0 {
+		return nil, fmt.Errorf("no schema modules supplied")
+	}
+
+	// Merge in a deterministic order such that the non-Dir fields of the
+	// returned root are stable.
+	mods := make([]string, 0, len(gzjs))
+	for m := range gzjs {
+		mods = append(mods, m)
+	}
+	sort.Strings(mods)
+
+	var root *yang.Entry
+	for _, m := range mods {
+		e, err := gzipToEntry(gzjs[m])
+		if err != nil {
+			return nil, fmt.Errorf("cannot unzip schema for module %s: %v", m, err)
+		}
+		if root == nil {
+			root = e
+			continue
+		}
+		mergeSchemaEntry(root, e)
+	}
+
+	schema := map[string]*yang.Entry{}
+	rebuildSchemaMap(root, nil, schema)
+	return schema, nil
+}
+
+// gzipToEntry unzips the gzipped JSON document gzj and unmarshals it into
+// a yang.Entry.
+func gzipToEntry(gzj []byte) (*yang.Entry, error) {
 	gzr, err := gzip.NewReader(bytes.NewReader(gzj))
 	if err != nil {
 		return nil, err
@@ -42,10 +95,23 @@ func GzipToSchema(gzj []byte) (map[string]*yang.Entry, error) {
 	if err := json.Unmarshal(s, &root); err != nil {
 		return nil, err
 	}
+	return root, nil
+}
 
-	schema := map[string]*yang.Entry{}
-	rebuildSchemaMap(root, nil, schema)
-	return schema, nil
+// mergeSchemaEntry merges the children of src into dst. Where a child exists
+// in both entries, their children are recursively merged; the remaining
+// fields of the child in dst are retained.
+func mergeSchemaEntry(dst, src *yang.Entry) {
+	for name, ch := range src.Dir {
+		if ex, ok := dst.Dir[name]; ok {
+			mergeSchemaEntry(ex, ch)
+			continue
+		}
+		if dst.Dir == nil {
+			dst.Dir = map[string]*yang.Entry{}
+		}
+		dst.Dir[name] = ch
+	}
 }
 
 // rebuildSchemaMap takes an input yang.Entry and appends it to the
@@ -63,4 +129,12 @@ func rebuildSchemaMap(e, parent *yang.Entry, schema map[string]*yang.Entry) {
 	for _, ch := range e.Dir {
 		rebuildSchemaMap(ch, e, schema)
 	}
+
+	if e.RPC != nil {
+		for _, io := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+			if io != nil {
+				rebuildSchemaMap(io, e, schema)
+			}
+		}
+	}
 }
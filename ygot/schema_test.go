@@ -15,11 +15,15 @@
 package ygot
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
+	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/testutil"
 )
@@ -104,3 +108,132 @@ func TestGzipToSchema(t *testing.T) {
 		}
 	}
 }
+
+// mustGzipJSON marshals in to JSON and returns the gzipped result, failing
+// the test if an error is encountered.
+func mustGzipJSON(t *testing.T, in interface{}) []byte {
+	t.Helper()
+	j, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("cannot marshal JSON: %v", err)
+	}
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	if _, err := gzw.Write(j); err != nil {
+		t.Fatalf("cannot write gzip: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("cannot close gzip writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestGzipToSchemaModules(t *testing.T) {
+	rootAnnotation := map[string]interface{}{
+		"isFakeRoot": true,
+		"structname": "Device",
+	}
+	base := &yang.Entry{
+		Name:       "device",
+		Annotation: rootAnnotation,
+		Dir: map[string]*yang.Entry{
+			"container": {
+				Name:       "container",
+				Annotation: map[string]interface{}{"schemapath": "/container", "structname": "Container"},
+				Dir: map[string]*yang.Entry{
+					"leaf": {Name: "leaf"},
+				},
+			},
+		},
+	}
+	augment := &yang.Entry{
+		Name:       "device",
+		Annotation: rootAnnotation,
+		Dir: map[string]*yang.Entry{
+			"container": {
+				Name:       "container",
+				Annotation: map[string]interface{}{"schemapath": "/container", "structname": "Container"},
+				Dir: map[string]*yang.Entry{
+					"augmented": {
+						Name:       "augmented",
+						Annotation: map[string]interface{}{"schemapath": "/container/augmented", "structname": "Container_Augmented"},
+						Dir: map[string]*yang.Entry{
+							"aug-leaf": {Name: "aug-leaf"},
+						},
+					},
+				},
+			},
+			"other": {
+				Name:       "other",
+				Annotation: map[string]interface{}{"schemapath": "/other", "structname": "Other"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		in                map[string][]byte
+		wantStructs       []string
+		wantContainerDirs []string
+		wantErrSubstring  string
+	}{{
+		name: "single module",
+		in: map[string][]byte{
+			"base": mustGzipJSON(t, base),
+		},
+		wantStructs:       []string{"Container", "Device"},
+		wantContainerDirs: []string{"leaf"},
+	}, {
+		name: "augmenting module stitched into base",
+		in: map[string][]byte{
+			"base":    mustGzipJSON(t, base),
+			"augment": mustGzipJSON(t, augment),
+		},
+		wantStructs:       []string{"Container", "Container_Augmented", "Device", "Other"},
+		wantContainerDirs: []string{"augmented", "leaf"},
+	}, {
+		name:             "no modules",
+		in:               map[string][]byte{},
+		wantErrSubstring: "no schema modules supplied",
+	}, {
+		name: "bad gzip data",
+		in: map[string][]byte{
+			"base": []byte("I am not a valid gzip!"),
+		},
+		wantErrSubstring: "cannot unzip schema for module base",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GzipToSchemaModules(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("GzipToSchemaModules: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			var gotStructs []string
+			for n := range got {
+				gotStructs = append(gotStructs, n)
+			}
+			sort.Strings(gotStructs)
+			if !reflect.DeepEqual(gotStructs, tt.wantStructs) {
+				t.Errorf("GzipToSchemaModules: did not get expected structs, got: %v, want: %v", gotStructs, tt.wantStructs)
+			}
+
+			c := got["Container"]
+			var gotDirs []string
+			for n, ch := range c.Dir {
+				gotDirs = append(gotDirs, n)
+				if ch.Parent != c {
+					t.Errorf("GzipToSchemaModules: child %s of Container did not have its parent populated", n)
+				}
+			}
+			sort.Strings(gotDirs)
+			if !reflect.DeepEqual(gotDirs, tt.wantContainerDirs) {
+				t.Errorf("GzipToSchemaModules: did not get expected Container children, got: %v, want: %v", gotDirs, tt.wantContainerDirs)
+			}
+		})
+	}
+}
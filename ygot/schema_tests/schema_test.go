@@ -270,6 +270,87 @@ func TestPruneUncompressed(t *testing.T) {
 	}
 }
 
+func TestConfigOnly(t *testing.T) {
+	configAndState := func() *exampleoc.Device {
+		d := &exampleoc.Device{}
+		b := d.GetOrCreateNetworkInstance("DEFAULT").GetOrCreateProtocol(exampleoc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, "15169").GetOrCreateBgp()
+		n := b.GetOrCreateNeighbor("192.0.2.1")
+		n.PeerAs = ygot.Uint32(29636)
+		n.PeerType = exampleoc.BgpTypes_PeerType_EXTERNAL
+		n.SessionState = exampleoc.Bgp_Neighbor_SessionState_ESTABLISHED
+
+		i := d.GetOrCreateInterface("eth0")
+		i.Description = ygot.String("foo")
+		i.Mtu = ygot.Uint16(1500)
+		i.OperStatus = exampleoc.Interface_OperStatus_UP
+		i.Logical = ygot.Bool(false)
+		return d
+	}
+
+	configOnly := func() *exampleoc.Device {
+		d := &exampleoc.Device{}
+		b := d.GetOrCreateNetworkInstance("DEFAULT").GetOrCreateProtocol(exampleoc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, "15169").GetOrCreateBgp()
+		n := b.GetOrCreateNeighbor("192.0.2.1")
+		n.PeerAs = ygot.Uint32(29636)
+		n.PeerType = exampleoc.BgpTypes_PeerType_EXTERNAL
+
+		i := d.GetOrCreateInterface("eth0")
+		i.Description = ygot.String("foo")
+		i.Mtu = ygot.Uint16(1500)
+		return d
+	}
+
+	in := configAndState()
+	got, err := ygot.ConfigOnly(exampleoc.SchemaTree["Device"], in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, configOnly()); diff != "" {
+		t.Errorf("(-got, +want):\n%s", diff)
+	}
+	if diff := cmp.Diff(in, configAndState()); diff != "" {
+		t.Errorf("input was modified (-got, +want):\n%s", diff)
+	}
+}
+
+func TestConfigOnlyUncompressed(t *testing.T) {
+	configAndState := func() *uexampleoc.Device {
+		d := &uexampleoc.Device{}
+
+		i := d.GetOrCreateInterfaces().GetOrCreateInterface("eth0")
+		c := i.GetOrCreateConfig()
+		c.Description = ygot.String("foo")
+		c.Mtu = ygot.Uint16(1500)
+		s := i.GetOrCreateState()
+		s.Mtu = ygot.Uint16(1500)
+		s.OperStatus = uexampleoc.OpenconfigInterfaces_Interfaces_Interface_State_OperStatus_UP
+		s.Logical = ygot.Bool(false)
+		return d
+	}
+
+	configOnly := func() *uexampleoc.Device {
+		d := &uexampleoc.Device{}
+
+		i := d.GetOrCreateInterfaces().GetOrCreateInterface("eth0")
+		c := i.GetOrCreateConfig()
+		c.Description = ygot.String("foo")
+		c.Mtu = ygot.Uint16(1500)
+		return d
+	}
+
+	in := configAndState()
+	got, err := ygot.ConfigOnly(uexampleoc.SchemaTree["Device"], in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, configOnly()); diff != "" {
+		t.Errorf("(-got, +want):\n%s", diff)
+	}
+	if diff := cmp.Diff(in, configAndState()); diff != "" {
+		t.Errorf("input was modified (-got, +want):\n%s", diff)
+	}
+}
+
 // mustPath returns a string as a gNMI path, causing a panic if the string
 // is invalid.
 func mustPath(s string) *gnmipb.Path {
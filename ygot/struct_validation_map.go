@@ -27,10 +27,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/value"
 	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 const (
@@ -133,6 +140,18 @@ func EnumName(e GoEnum) (string, error) {
 	return name, err
 }
 
+// EnumNameQualified returns the string name of an input GoEnum e, qualified
+// with the name of the YANG module that defines it in the form "module:NAME",
+// as per the encoding of identityref values in RFC7951. The defining module is
+// determined from the ΛMap of the enumeration. If the enumeration is unset,
+// the name returned is an empty string, and if the enumeration has no defining
+// module, the unqualified name is returned. Non-zero out-of-range values and
+// unrecognized enums will produce an error.
+func EnumNameQualified(e GoEnum) (string, error) {
+	name, _, err := enumFieldToString(reflect.ValueOf(e), true)
+	return name, err
+}
+
 // enumFieldToString takes an input reflect.Value, which is type asserted to
 // be a GoEnum, and resolves the string name corresponding to the value within
 // the YANG schema. Returns the string name of the enum, a bool indicating
@@ -180,36 +199,96 @@ func enumFieldToString(field reflect.Value, prependModuleNameIref bool) (string,
 	return n, true, nil
 }
 
+var (
+	// enumStringFormatterMu protects enumStringFormatter.
+	enumStringFormatterMu sync.RWMutex
+	// enumStringFormatter is used by EnumLogString to output the string
+	// for values that are not valid for an enumerated type.
+	enumStringFormatter = defaultEnumStringFormatter
+)
+
+// defaultEnumStringFormatter returns the out-of-range error string output by
+// EnumLogString for the value val of the enumerated type typeName.
+func defaultEnumStringFormatter(typeName string, val int64) string {
+	return fmt.Sprintf("out-of-range %s enum value: %v", typeName, val)
+}
+
+// SetEnumStringFormatter sets the function used by EnumLogString, and hence the
+// String methods of generated enumerated types, to output the string for a
+// value that is not valid for its enumerated type. The function is called with
+// the name of the enumerated type and the invalid value. If f is nil, the
+// default out-of-range error string is restored.
+func SetEnumStringFormatter(f func(typeName string, val int64) string) {
+	if f == nil {
+		f = defaultEnumStringFormatter
+	}
+	enumStringFormatterMu.Lock()
+	defer enumStringFormatterMu.Unlock()
+	enumStringFormatter = f
+}
+
 // EnumLogString uses the EnumDefinition map of the given enum, an input
 // int64 val, and the input type name of the enum to output a log-friendly string.
 // If val is a valid enum value, then the defined YANG string corresponding to
-// the enum value is returned; otherwise, an out-of-range error string is returned.
+// the enum value is returned; otherwise, the string returned by the formatter
+// set by SetEnumStringFormatter is returned, which defaults to an out-of-range
+// error string.
 func EnumLogString(e GoEnum, val int64, enumTypeName string) string {
 	enumDef, ok := e.ΛMap()[enumTypeName][val]
 	if !ok {
-		return fmt.Sprintf("out-of-range %s enum value: %v", enumTypeName, val)
+		// The formatter is called without holding the lock, such that
+		// it may itself call SetEnumStringFormatter or EnumLogString.
+		enumStringFormatterMu.RLock()
+		f := enumStringFormatter
+		enumStringFormatterMu.RUnlock()
+		return f(enumTypeName, val)
 	}
 	return enumDef.Name
 }
 
+// MaxTraversalDepth is the maximum depth of nested GoStructs that is traversed
+// by the functions that walk a GoStruct tree, such as MergeStructs, DeepCopy,
+// PruneEmptyBranches and ConstructIETFJSON. These functions return an error
+// when the depth is exceeded, rather than recursing until the stack overflows,
+// as may occur where a GoStruct contains a cycle. BuildEmptyTree does not
+// initialise structs beyond this depth. The default is sufficiently large that
+// it is not reached by the GoStructs generated for real schemas. It should not
+// be modified concurrently with calls to such functions.
+var MaxTraversalDepth = 1000
+
+// checkTraversalDepth returns an error if depth exceeds MaxTraversalDepth.
+func checkTraversalDepth(depth int) error {
+	if depth > MaxTraversalDepth {
+		return fmt.Errorf("maximum traversal depth of %d exceeded, the GoStruct may contain a cycle", MaxTraversalDepth)
+	}
+	return nil
+}
+
 // BuildEmptyTree initialises the YANG tree starting at the root GoStruct
 // provided. This allows the YANG container hierarchy (i.e., any structs within
 // the tree) to be pre-initialised rather than requiring the user to initialise
 // each as it is required. Given that some trees may be large, then some
 // caution should be exercised in initialising an entire tree. If struct pointer
-// fields are non-nil, they are considered initialised, and are skipped.
+// fields are non-nil, they are considered initialised, and are skipped. Structs
+// that are nested more deeply than MaxTraversalDepth are not initialised.
 func BuildEmptyTree(s GoStruct) {
-	initialiseTree(reflect.ValueOf(s).Elem().Type(), reflect.ValueOf(s).Elem())
+	initialiseTree(reflect.ValueOf(s).Elem().Type(), reflect.ValueOf(s).Elem(), 0)
 }
 
 // initialiseTree takes an input data item's reflect.Value and reflect.Type for
 // a particular GoStruct, and initialises the nested structs that are within it.
-func initialiseTree(t reflect.Type, v reflect.Value) {
+// The depth of the GoStruct within the tree is specified by depth.
+func initialiseTree(t reflect.Type, v reflect.Value, depth int) {
+	if depth >= MaxTraversalDepth {
+		return
+	}
 	for i := 0; i < v.NumField(); i++ {
 		fVal := v.Field(i)
 		fType := t.Field(i)
 
-		if util.IsTypeStructPtr(fType.Type) {
+		// Ordered lists are not initialised, in the same manner as lists
+		// that are represented by maps.
+		if util.IsTypeStructPtr(fType.Type) && !fType.Type.Implements(goOrderedListType) {
 			// Only initialise nested struct pointers, since all struct fields within
 			// a GoStruct are expected to be pointers, and we do not want to initialise
 			// non-struct values. If the struct pointer is not nil, it is skipped.
@@ -218,7 +297,7 @@ func initialiseTree(t reflect.Type, v reflect.Value) {
 			}
 
 			pVal := reflect.New(fType.Type.Elem())
-			initialiseTree(pVal.Elem().Type(), pVal.Elem())
+			initialiseTree(pVal.Elem().Type(), pVal.Elem(), depth+1)
 			fVal.Set(pVal)
 		}
 	}
@@ -229,19 +308,25 @@ func initialiseTree(t reflect.Type, v reflect.Value) {
 // initialised with BuildEmptyTree to have those branches that were not populated
 // removed from the tree. All subtrees rooted at the supplied GoStruct are traversed
 // and any encountered GoStruct pointer fields are removed if they equate to
-// the zero value (i.e. are unpopulated).
-func PruneEmptyBranches(s GoStruct) {
+// the zero value (i.e. are unpopulated). An error is returned if the GoStruct
+// is nested more deeply than MaxTraversalDepth.
+func PruneEmptyBranches(s GoStruct) error {
 	v := reflect.ValueOf(s).Elem()
-	pruneBranchesInternal(v.Type(), v)
+	_, err := pruneBranchesInternal(v.Type(), v, 0)
+	return err
 }
 
 // pruneBranchesInternal implements the logic to remove empty branches from the
 // supplied reflect.Type, reflect.Value which must represent a GoStruct. An empty
 // tree is defined to be a struct that is equal to its zero value. Only struct
 // pointer fields are examined, since these are subtrees within the generated GoStruct
-// types. It returns a bool which indicates whether all fields of the struct were
-// removed.
-func pruneBranchesInternal(t reflect.Type, v reflect.Value) bool {
+// types. The depth of the GoStruct within the tree is specified by depth. It
+// returns a bool which indicates whether all fields of the struct were removed.
+func pruneBranchesInternal(t reflect.Type, v reflect.Value, depth int) (bool, error) {
+	if err := checkTraversalDepth(depth); err != nil {
+		return false, err
+	}
+
 	// Track whether all fields of the GoStruct are nil, such that it can
 	// be returned to the caller. This allows parents that have all empty
 	// children to be removed. This is required because BuildEmptyTree will
@@ -272,7 +357,10 @@ func pruneBranchesInternal(t reflect.Type, v reflect.Value) bool {
 				// If this wasn't an empty struct then we need to recurse to remove
 				// any nil children of this struct.
 				sv := fVal.Elem()
-				childPruned := pruneBranchesInternal(sv.Type(), sv)
+				childPruned, err := pruneBranchesInternal(sv.Type(), sv, depth+1)
+				if err != nil {
+					return false, err
+				}
 				if childPruned {
 					// If all fields of the downstream branches are nil, then
 					// also prune this field.
@@ -303,10 +391,12 @@ func pruneBranchesInternal(t reflect.Type, v reflect.Value) bool {
 					continue
 				}
 				sv := mi.Elem()
-				// We can discard the pruneBranchesInternal return value, since we
-				// know that this map field has len > 0, and therefore cannot be
-				// pruned.
-				_ = pruneBranchesInternal(sv.Type(), sv)
+				// We can discard the pruneBranchesInternal bool return value,
+				// since we know that this map field has len > 0, and therefore
+				// cannot be pruned.
+				if _, err := pruneBranchesInternal(sv.Type(), sv, depth+1); err != nil {
+					return false, err
+				}
 			}
 		default:
 			// Handle the case of a non-map/slice/struct pointer field.
@@ -327,7 +417,101 @@ func pruneBranchesInternal(t reflect.Type, v reflect.Value) bool {
 		}
 
 	}
-	return allChildrenPruned
+	return allChildrenPruned, nil
+}
+
+// ResetOpt is an interface that is implemented by the options to the Reset
+// function.
+type ResetOpt interface {
+	// IsResetOpt is a marker method for each ResetOpt.
+	IsResetOpt()
+}
+
+// ResetRecursive is a ResetOpt that allows control of the behaviour of the
+// Reset function.
+//
+// When used, rather than only setting each field of the supplied GoStruct to
+// its zero value, populated children of the GoStruct - struct pointers, and
+// the members of maps and slices - are recursively reset in place before they
+// are released. Any other references that are held to these children hence
+// observe the reset. As with the default behaviour, the supplied GoStruct is
+// zero-valued once Reset returns, such that no field is considered populated.
+type ResetRecursive struct{}
+
+// IsResetOpt marks ResetRecursive as a ResetOpt.
+func (*ResetRecursive) IsResetOpt() {}
+
+// Reset sets each field of the supplied GoStruct s to its zero value in
+// place, allowing an allocated GoStruct to be reused. By default, only the
+// top-level fields of s are reset, such that any children of s are released
+// unmodified. When the ResetRecursive option is supplied, each child of s is
+// also reset in place before it is released.
+func Reset(s GoStruct, opts ...ResetOpt) error {
+	if util.IsNilOrInvalidValue(reflect.ValueOf(s)) {
+		return fmt.Errorf("invalid input to Reset, got nil value: %v", s)
+	}
+	if !util.IsValueStructPtr(reflect.ValueOf(s)) {
+		return fmt.Errorf("cannot Reset non-struct pointer type %T", s)
+	}
+	v := reflect.ValueOf(s).Elem()
+
+	if !resetRecursiveEnabled(opts) {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	resetStructRecursive(v)
+	return nil
+}
+
+// resetRecursiveEnabled returns true if ResetRecursive is present in the
+// slice of ResetOpt.
+func resetRecursiveEnabled(opts []ResetOpt) bool {
+	for _, o := range opts {
+		switch o.(type) {
+		case *ResetRecursive:
+			return true
+		}
+	}
+	return false
+}
+
+// resetStructRecursive resets the fields of the struct v in place. Non-nil
+// struct pointer fields, and the struct pointer members of maps and slices,
+// are recursively reset before the field is set to its zero value. Since
+// ygot considers non-nil empty maps and slices to be populated, all fields -
+// including binary leaves and leaf-lists - are left as their zero value.
+func resetStructRecursive(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		fVal := v.Field(i)
+		switch {
+		case isAnnotationContainerField(v.Type().Field(i)):
+			// Annotations are copied by reference, and hence are
+			// released without being reset in place.
+		case util.IsValueStructPtr(fVal):
+			if !fVal.IsNil() {
+				resetStructRecursive(fVal.Elem())
+			}
+		case util.IsValueMap(fVal):
+			for _, k := range fVal.MapKeys() {
+				resetMember(fVal.MapIndex(k))
+				fVal.SetMapIndex(k, reflect.Value{})
+			}
+		case util.IsValueSlice(fVal):
+			for j := 0; j < fVal.Len(); j++ {
+				resetMember(fVal.Index(j))
+				fVal.Index(j).Set(reflect.Zero(fVal.Type().Elem()))
+			}
+		}
+		fVal.Set(reflect.Zero(fVal.Type()))
+	}
+}
+
+// resetMember recursively resets the member v of a map or slice if it is a
+// non-nil struct pointer.
+func resetMember(v reflect.Value) {
+	if util.IsValueStructPtr(v) && !v.IsNil() {
+		resetStructRecursive(v.Elem())
+	}
 }
 
 // InitContainer initialises the container cname of the GoStruct s, it can be
@@ -350,6 +534,20 @@ func InitContainer(s GoStruct, cname string) error {
 	return fmt.Errorf("field %s was not a struct to initialise", cname)
 }
 
+// binaryJSON returns the base64 encoded string that represents the binary
+// value v in JSON. The MarshalBase64 method is used when v's type implements
+// Base64Marshaler.
+func binaryJSON(v reflect.Value) (string, error) {
+	if m, ok := v.Interface().(Base64Marshaler); ok {
+		s, err := m.MarshalBase64()
+		if err != nil {
+			return "", fmt.Errorf("cannot marshal binary value of type %T, %v", m, err)
+		}
+		return s, nil
+	}
+	return binaryBase64(v.Bytes()), nil
+}
+
 // binaryBase64 takes an input byte slice and returns it as a base64
 // encoded string.
 func binaryBase64(i []byte) string {
@@ -369,8 +567,29 @@ const (
 	Internal JSONFormat = iota
 	// RFC7951 is JSON that conforms to RFC7951.
 	RFC7951
+	// PathValueList is a JSON array of objects, each of which contains the
+	// absolute path of a populated leaf or leaf-list, and its value, in the
+	// form {"path": <path>, "value": <value>}. It is intended for debugging,
+	// such as comparing a struct's contents against the updates received
+	// in a gNMI subscription, rather than for serialising data.
+	PathValueList
+	// OpenConfigCompact is JSON that is rendered as per RFC7951, but without
+	// the names of modules being prepended to the names of fields, and
+	// without indentation, as expected by some OpenConfig tooling when
+	// importing data in bulk.
+	OpenConfigCompact
 )
 
+// PathValueListConfig specifies the configuration options for JSON output
+// in the PathValueList format.
+type PathValueListConfig struct {
+	// UsePathElem specifies whether paths are rendered from gNMI PathElem
+	// paths, such that the keys of lists are rendered as
+	// name[key=value] elements. If unset, paths are rendered from string
+	// slice paths, in which the keys of lists are path elements.
+	UsePathElem bool
+}
+
 // EmitJSONConfig specifies the how JSON should be created by the EmitJSON function.
 type EmitJSONConfig struct {
 	// Format specifies the JSON format that should be output by the EmitJSON
@@ -378,15 +597,23 @@ type EmitJSONConfig struct {
 	// format JSON will be produced.
 	Format JSONFormat
 	// RFC7951Config specifies the configuration options for RFC7951 JSON. Only
-	// valid if Format is RFC7951.
+	// valid if Format is RFC7951 or OpenConfigCompact.
 	RFC7951Config *RFC7951JSONConfig
+	// PathValueListConfig specifies the configuration options for
+	// PathValueList JSON. Only valid if Format is PathValueList.
+	PathValueListConfig *PathValueListConfig
 	// Indent is the string used for indentation within the JSON output. The
-	// default value is three spaces.
+	// default value is three spaces. Ignored if Format is OpenConfigCompact.
 	Indent string
 	// EscapeHTML determines whether certain characters will be escaped
 	// in the marshalled JSON for safety in HTML embedding. See
 	// https://pkg.go.dev/encoding/json#Encoder.SetEscapeHTML.
 	EscapeHTML bool
+	// EscapeUnicode specifies that each non-ASCII character within the
+	// marshalled JSON, such as within a string leaf value, is output as a
+	// \uXXXX escape sequence, such that the output consists only of ASCII
+	// characters. By default, non-ASCII characters are output as UTF-8.
+	EscapeUnicode bool
 	// SkipValidation specifies whether the GoStruct supplied to EmitJSON should
 	// be validated before emitting its content. Validation is skipped when it
 	// is set to true.
@@ -396,6 +623,40 @@ type EmitJSONConfig struct {
 	// validation rules in the case that a partially populated data instance is
 	// to be emitted.
 	ValidationOpts []ValidationOption
+	// SortListsByKey specifies that the entries of keyed lists should be
+	// emitted in order of their key values, rather than of the string
+	// representation of the key. Key values are compared according to their
+	// type, such that numeric keys are ordered numerically. Lists with
+	// multiple keys are ordered by each key in turn, in the order in which
+	// the keys are declared in the YANG schema. Only used when Format is
+	// RFC7951, since lists are otherwise output as JSON objects.
+	SortListsByKey bool
+	// RootModuleOverride specifies the name of the YANG module within which
+	// the GoStruct supplied to EmitJSON is considered to reside when
+	// determining whether its top-level fields should be prefixed with
+	// their module name in RFC7951 output. By default, all top-level
+	// fields are prefixed, as required by RFC7951, unless
+	// UseBelongingModuleAsParent is set within the RFC7951Config.
+	RootModuleOverride string
+	// EmitMetadata specifies that annotation fields, tagged with
+	// ygotAnnotation, are emitted as RFC7952 metadata. Rather than a JSON
+	// array containing each of the field's annotations, the annotations
+	// must each marshal to a JSON object, the members of which are merged
+	// into a single "@"-prefixed JSON object that is emitted as a sibling
+	// of the annotated node. Annotations can be attached in this form
+	// using AttachMetadata. Only used when Format is RFC7951 or
+	// OpenConfigCompact.
+	EmitMetadata bool
+	// AlwaysEmitListKeyLeaves specifies that the key leaves of each keyed
+	// list member are emitted as members of the JSON object representing it,
+	// even where they are not populated within the GoStruct of the member,
+	// such that they are present for consumers that require them. The value
+	// of each such leaf is taken from the key at which the member is stored
+	// within its list. A key leaf that is populated within the member is
+	// emitted unchanged - for example, a field tagged
+	// `path:"config/name|name"` is emitted at both of its paths. Only used
+	// when Format is RFC7951 or OpenConfigCompact.
+	AlwaysEmitListKeyLeaves bool
 }
 
 // EmitJSON takes an input GoStruct (produced by ygen with validation enabled)
@@ -422,11 +683,24 @@ func EmitJSON(gs GoStruct, opts *EmitJSONConfig) (string, error) {
 		}
 	}
 
-	v, err := makeJSON(s, opts)
+	var v interface{}
+	var err error
+	if opts != nil && opts.Format == PathValueList {
+		v, err = pathValueListJSON(s, opts.PathValueListConfig)
+	} else {
+		v, err = makeJSON(s, opts)
+	}
 	if err != nil {
 		return "", err
 	}
 
+	return encodeJSON(v, opts)
+}
+
+// encodeJSON marshals v, which is the JSON representation of one or more
+// GoStructs, to a string according to the indentation and escaping options
+// within opts.
+func encodeJSON(v interface{}, opts *EmitJSONConfig) (string, error) {
 	sb := &strings.Builder{}
 	enc := json.NewEncoder(sb)
 	indent := indentString
@@ -438,6 +712,9 @@ func EmitJSON(gs GoStruct, opts *EmitJSONConfig) (string, error) {
 			indent = opts.Indent
 		}
 	}
+	if opts != nil && opts.Format == OpenConfigCompact {
+		indent = ""
+	}
 	enc.SetIndent("", indent)
 
 	if err := enc.Encode(v); err != nil {
@@ -446,7 +723,32 @@ func EmitJSON(gs GoStruct, opts *EmitJSONConfig) (string, error) {
 
 	// Exclude the last newline character:
 	// https://pkg.go.dev/encoding/json#Encoder.Encode
-	return sb.String()[:sb.Len()-1], nil
+	js := sb.String()[:sb.Len()-1]
+	if opts != nil && opts.EscapeUnicode {
+		js = escapeNonASCII(js)
+	}
+	return js, nil
+}
+
+// escapeNonASCII returns the JSON document js with each non-ASCII character
+// replaced by its \uXXXX escape sequence. Characters outside of the Basic
+// Multilingual Plane are escaped as a UTF-16 surrogate pair. Since non-ASCII
+// characters can only appear within strings in a JSON document, the result is
+// equivalent to js.
+func escapeNonASCII(js string) string {
+	var b strings.Builder
+	for _, r := range js {
+		switch {
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+		case r > 0xffff:
+			r1, r2 := utf16.EncodeRune(r)
+			fmt.Fprintf(&b, `\u%04x\u%04x`, r1, r2)
+		default:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		}
+	}
+	return b.String()
 }
 
 // makeJSON renders the GoStruct s to map[string]interface{} according to the
@@ -465,17 +767,102 @@ func makeJSON(s GoStruct, opts *EmitJSONConfig) (map[string]interface{}, error)
 			return nil, fmt.Errorf("ConstructInternalJSON error: %v", err)
 		}
 	case RFC7951:
-		var c *RFC7951JSONConfig
-		if opts != nil {
-			c = opts.RFC7951Config
-		}
-		if v, err = ConstructIETFJSON(s, c); err != nil {
+		if v, err = structJSON(s, rootModule(s, opts), rfc7951OutputConfig(opts)); err != nil {
 			return nil, fmt.Errorf("ConstructIETFJSON error: %v", err)
 		}
+	case OpenConfigCompact:
+		if v, err = structJSON(s, rootModule(s, opts), rfc7951OutputConfig(opts)); err != nil {
+			return nil, fmt.Errorf("OpenConfigCompact error: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("JSON format %v cannot be rendered as a JSON object", f)
 	}
 	return v, nil
 }
 
+// rfc7951OutputConfig returns the configuration used to render a GoStruct
+// to RFC7951 JSON according to opts, which specifies either the RFC7951 or
+// OpenConfigCompact format. For the latter, the RFC7951 options are copied
+// such that the module names can be omitted without modifying the caller's
+// configuration.
+func rfc7951OutputConfig(opts *EmitJSONConfig) jsonOutputConfig {
+	args := jsonOutputConfig{jType: RFC7951}
+	if opts == nil {
+		return args
+	}
+	args.rfc7951Config = opts.RFC7951Config
+	args.sortListsByKey = opts.SortListsByKey
+	args.emitMetadata = opts.EmitMetadata
+	args.alwaysEmitListKeyLeaves = opts.AlwaysEmitListKeyLeaves
+	if opts.Format == OpenConfigCompact {
+		cfg := &RFC7951JSONConfig{}
+		if opts.RFC7951Config != nil {
+			*cfg = *opts.RFC7951Config
+		}
+		cfg.AppendModuleName = false
+		args.rfc7951Config = cfg
+	}
+	return args
+}
+
+// rootModule returns the name of the module within which the GoStruct s
+// supplied to EmitJSON is considered to reside, according to the
+// RootModuleOverride and RFC7951 options within opts.
+func rootModule(s GoStruct, opts *EmitJSONConfig) string {
+	if opts == nil {
+		return ""
+	}
+	return rfc7951ParentModule(s, opts.RootModuleOverride, opts.RFC7951Config)
+}
+
+// pathValueListJSON renders the populated leaves of the GoStruct s to a slice
+// of objects, each containing the absolute path of the leaf and its value,
+// sorted by path. The format of the paths is determined by the cfg supplied.
+func pathValueListJSON(s GoStruct, cfg *PathValueListConfig) ([]interface{}, error) {
+	pfx := newStringSliceGNMIPath(nil)
+	if cfg != nil && cfg.UsePathElem {
+		pfx = newPathElemGNMIPath(nil)
+	}
+
+	leaves := map[*path]interface{}{}
+	if err := findUpdatedLeaves(leaves, s, pfx); err != nil {
+		return nil, fmt.Errorf("PathValueList error: %v", err)
+	}
+
+	pathVals := map[string]interface{}{}
+	for p, v := range leaves {
+		pp, err := p.p.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert path %v to gNMI path: %v", p, err)
+		}
+		ps, err := PathToString(pp)
+		if err != nil {
+			return nil, fmt.Errorf("cannot render path %v: %v", p, err)
+		}
+		tv, err := EncodeTypedValue(v, gnmipb.Encoding_JSON)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode value of %s: %v", ps, err)
+		}
+		sv, err := value.ToScalar(tv)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert value of %s: %v", ps, err)
+		}
+		pathVals[ps] = sv
+	}
+
+	var paths []string
+	for p := range pathVals {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	out := []interface{}{}
+	for _, p := range paths {
+		out = append(out, map[string]interface{}{"path": p, "value": pathVals[p]})
+	}
+	return out, nil
+}
+
 // MergeStructJSON marshals the GoStruct ns to JSON according to the configuration, and
 // merges it with the existing JSON provided as a map[string]interface{}. The merged
 // JSON output is returned.
@@ -608,7 +995,7 @@ func MergeStructInto(dst, src GoStruct, opts ...MergeOpt) error {
 		return fmt.Errorf("cannot merge structs that are not of matching types, %T != %T", dst, src)
 	}
 
-	return copyStruct(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem(), opts...)
+	return copyStruct(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem(), 0, opts...)
 }
 
 // DeepCopy returns a deep copy of the supplied GoStruct. A new copy
@@ -618,12 +1005,284 @@ func DeepCopy(s GoStruct) (GoStruct, error) {
 		return nil, fmt.Errorf("invalid input to DeepCopy, got nil value: %v", s)
 	}
 	n := reflect.New(reflect.TypeOf(s).Elem())
-	if err := copyStruct(n.Elem(), reflect.ValueOf(s).Elem()); err != nil {
+	if err := copyStruct(n.Elem(), reflect.ValueOf(s).Elem(), 0); err != nil {
 		return nil, fmt.Errorf("cannot DeepCopy struct: %v", err)
 	}
 	return n.Interface().(GoStruct), nil
 }
 
+// DeepCopyInto clears the supplied dst GoStruct and populates it with a deep
+// copy of src, such that dst can be recycled rather than a new GoStruct being
+// allocated for each copy. Children of dst that are populated and of the
+// type required for the copy, such as struct pointers, map entries and the
+// backing arrays of slices, are reused, and hence should not be referenced
+// elsewhere by the caller. After the call, dst shares no memory with src. An
+// error is returned if dst and src are not of the same type.
+func DeepCopyInto(dst, src GoStruct) error {
+	if util.IsNilOrInvalidValue(reflect.ValueOf(dst)) || util.IsNilOrInvalidValue(reflect.ValueOf(src)) {
+		return fmt.Errorf("invalid input to DeepCopyInto, got nil value, dst: %v, src: %v", dst, src)
+	}
+	if reflect.TypeOf(dst) != reflect.TypeOf(src) {
+		return fmt.Errorf("cannot DeepCopyInto structs that are not of matching types, %T != %T", dst, src)
+	}
+	if !util.IsValueStructPtr(reflect.ValueOf(src)) {
+		return fmt.Errorf("cannot DeepCopyInto non-struct pointer type %T", src)
+	}
+	dstVal, srcVal := reflect.ValueOf(dst), reflect.ValueOf(src)
+	if dstVal.Pointer() == srcVal.Pointer() {
+		return fmt.Errorf("cannot DeepCopyInto a struct from itself, got: %p", dst)
+	}
+	if err := copyStructInto(dstVal.Elem(), srcVal.Elem(), 0); err != nil {
+		return fmt.Errorf("cannot DeepCopyInto struct: %v", err)
+	}
+	return nil
+}
+
+// copyStructInto overwrites each field of the dstVal struct with a deep copy
+// of the corresponding field of the srcVal struct, reusing the memory that is
+// already allocated within dstVal where possible. The depth of the struct
+// within the GoStruct tree that is being copied is specified by depth.
+func copyStructInto(dstVal, srcVal reflect.Value, depth int) error {
+	if err := checkTraversalDepth(depth); err != nil {
+		return err
+	}
+
+	if srcVal.Type() != dstVal.Type() {
+		return fmt.Errorf("cannot copy %s to %s", srcVal.Type().Name(), dstVal.Type().Name())
+	}
+
+	for i := 0; i < srcVal.NumField(); i++ {
+		if isAnnotationContainerField(srcVal.Type().Field(i)) {
+			dstVal.Field(i).Set(srcVal.Field(i))
+			continue
+		}
+		if err := copyValueInto(dstVal.Field(i), srcVal.Field(i), depth); err != nil {
+			return fmt.Errorf("cannot copy field %s: %v", srcVal.Type().Field(i).Name, err)
+		}
+	}
+	return nil
+}
+
+// copyValueInto overwrites the value dst with a deep copy of src, reusing
+// the pointers, maps and slices that are held in dst where they are of the
+// type required, and are not shared with src. The depth of the struct
+// containing the values is specified by depth.
+func copyValueInto(dst, src reflect.Value, depth int) error {
+	if src.Type().Implements(goOrderedListType) {
+		return copyOrderedListInto(dst, src, depth)
+	}
+
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		if dst.IsNil() || dst.Pointer() == src.Pointer() {
+			dst.Set(reflect.New(src.Type().Elem()))
+		}
+		if util.IsValueStructPtr(src) {
+			return copyStructInto(dst.Elem(), src.Elem(), depth+1)
+		}
+		dst.Elem().Set(src.Elem())
+	case reflect.Interface:
+		return copyInterfaceInto(dst, src, depth)
+	case reflect.Map:
+		return copyMapInto(dst, src, depth)
+	case reflect.Slice:
+		return copySliceInto(dst, src, depth)
+	default:
+		// Scalar values, including enumerated values and the fixed-size
+		// arrays used to store leaf-lists, are copied by assignment.
+		dst.Set(src)
+	}
+	return nil
+}
+
+// copyInterfaceInto overwrites the interface value dst with a deep copy of
+// the interface value src, which is expected to hold a union value. A struct
+// pointer or binary value that is held in dst is reused if it is of the same
+// type as that held in src.
+func copyInterfaceInto(dst, src reflect.Value, depth int) error {
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	s := src.Elem()
+	var d reflect.Value
+	if !dst.IsNil() && dst.Elem().Type() == s.Type() {
+		d = dst.Elem()
+	}
+
+	switch {
+	case util.IsValueStructPtr(s):
+		if !d.IsValid() || d.IsNil() || d.Pointer() == s.Pointer() {
+			d = reflect.New(s.Type().Elem())
+		}
+		if err := copyStructInto(d.Elem(), s.Elem(), depth+1); err != nil {
+			return err
+		}
+		dst.Set(d)
+	case IsBinaryType(s.Type()):
+		if !d.IsValid() || d.Pointer() == s.Pointer() {
+			d = reflect.Zero(s.Type())
+		}
+		dst.Set(reflect.AppendSlice(d.Slice(0, 0), s))
+	default:
+		dst.Set(src)
+	}
+	return nil
+}
+
+// copyMapInto overwrites the map dst with a deep copy of the map src. If dst
+// is populated, entries whose keys are not present in src are removed, and
+// the struct pointers stored under the keys that are present are reused.
+// Struct values, which store the members of lists whose members are stored by
+// value, are deep copied. Other values, such as those of metadata maps, are
+// copied by assignment.
+func copyMapInto(dst, src reflect.Value, depth int) error {
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	if dst.IsNil() || dst.Pointer() == src.Pointer() {
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+	}
+
+	for _, k := range dst.MapKeys() {
+		if !src.MapIndex(k).IsValid() {
+			dst.SetMapIndex(k, reflect.Value{})
+		}
+	}
+
+	if util.IsTypeStruct(src.Type().Elem()) {
+		for _, k := range src.MapKeys() {
+			d := reflect.New(src.Type().Elem())
+			if err := copyStructInto(d.Elem(), src.MapIndex(k), depth+1); err != nil {
+				return err
+			}
+			dst.SetMapIndex(k, d.Elem())
+		}
+		return nil
+	}
+
+	structValues := util.IsTypeStructPtr(src.Type().Elem())
+	for _, k := range src.MapKeys() {
+		v := src.MapIndex(k)
+		if !structValues || v.IsNil() {
+			dst.SetMapIndex(k, v)
+			continue
+		}
+		d := dst.MapIndex(k)
+		if !d.IsValid() || d.IsNil() || d.Pointer() == v.Pointer() {
+			d = reflect.New(v.Type().Elem())
+		}
+		if err := copyStructInto(d.Elem(), v.Elem(), depth+1); err != nil {
+			return err
+		}
+		dst.SetMapIndex(k, d)
+	}
+	return nil
+}
+
+// copyOrderedListInto overwrites the ordered list dst with a deep copy of the
+// ordered list src. Since the fields of ordered lists are not exported, the
+// copy is a new ordered list to which copies of the members of src are
+// appended in order. The struct pointers of the members of dst whose keys are
+// present in src are reused.
+func copyOrderedListInto(dst, src reflect.Value, depth int) error {
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	reuse := map[interface{}]GoStruct{}
+	if !dst.IsNil() && dst.Pointer() != src.Pointer() {
+		d := dst.Interface().(GoOrderedList)
+		dstVals := d.ΛValues()
+		for i, k := range d.ΛKeys() {
+			reuse[k] = dstVals[i]
+		}
+	}
+
+	s := src.Interface().(GoOrderedList)
+	l := reflect.New(src.Type().Elem())
+	srcVals := s.ΛValues()
+	for i, k := range s.ΛKeys() {
+		v := reflect.ValueOf(srcVals[i])
+		d := reflect.ValueOf(reuse[k])
+		if util.IsNilOrInvalidValue(d) || d.Pointer() == v.Pointer() {
+			d = reflect.New(v.Type().Elem())
+		}
+		if err := copyStructInto(d.Elem(), v.Elem(), depth+1); err != nil {
+			return err
+		}
+		if err := util.AppendToOrderedList(l, d); err != nil {
+			return err
+		}
+	}
+	dst.Set(l)
+	return nil
+}
+
+// copySliceInto overwrites the slice dst with a deep copy of the slice src.
+// The backing array of dst is reused if it has sufficient capacity, along
+// with the struct pointers held in its populated elements. Annotations are
+// copied by assignment, since their contents are opaque to ygot.
+func copySliceInto(dst, src reflect.Value, depth int) error {
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	n, reusable := src.Len(), dst.Len()
+	aliased := dst.Cap() > 0 && src.Cap() > 0 && dst.Pointer() == src.Pointer()
+	if aliased {
+		reusable = 0
+	}
+
+	var s reflect.Value
+	switch {
+	case !aliased && !dst.IsNil() && dst.Cap() >= n:
+		s = dst.Slice(0, n)
+	default:
+		s = reflect.MakeSlice(dst.Type(), n, n)
+		reflect.Copy(s, dst.Slice(0, reusable))
+	}
+
+	// Clear the elements that are not reused such that the values they
+	// reference can be garbage collected, and are not shared with any
+	// previous contents of dst.
+	zero := reflect.Zero(dst.Type().Elem())
+	for i := reusable; i < n; i++ {
+		s.Index(i).Set(zero)
+	}
+	for i := n; i < reusable; i++ {
+		dst.Index(i).Set(zero)
+	}
+
+	_, isAnnotation := src.Interface().([]Annotation)
+	for i := 0; i < n; i++ {
+		if isAnnotation {
+			s.Index(i).Set(src.Index(i))
+			continue
+		}
+		if err := copyValueInto(s.Index(i), src.Index(i), depth); err != nil {
+			return err
+		}
+	}
+	dst.Set(s)
+	return nil
+}
+
+// isAnnotationContainerField reports whether the struct field sf is an
+// annotation field of a user-defined type implementing AnnotationContainer.
+func isAnnotationContainerField(sf reflect.StructField) bool {
+	return util.IsYgotAnnotation(sf) && sf.Type.Implements(annotationContainerType)
+}
+
 // fieldOverwriteEnabled returns true if MergeOverwriteExistingFields
 // is present in the slice of MergeOpt.
 func fieldOverwriteEnabled(opts []MergeOpt) bool {
@@ -648,8 +1307,14 @@ func mergeEmptyMapsEnabled(opts []MergeOpt) bool {
 	return false
 }
 
-// copyStruct copies the fields of srcVal into the dstVal struct in-place.
-func copyStruct(dstVal, srcVal reflect.Value, opts ...MergeOpt) error {
+// copyStruct copies the fields of srcVal into the dstVal struct in-place. The
+// depth of the struct within the GoStruct tree that is being copied is
+// specified by depth.
+func copyStruct(dstVal, srcVal reflect.Value, depth int, opts ...MergeOpt) error {
+	if err := checkTraversalDepth(depth); err != nil {
+		return err
+	}
+
 	if srcVal.Type() != dstVal.Type() {
 		return fmt.Errorf("cannot copy %s to %s", srcVal.Type().Name(), dstVal.Type().Name())
 	}
@@ -662,21 +1327,86 @@ func copyStruct(dstVal, srcVal reflect.Value, opts ...MergeOpt) error {
 		srcField := srcVal.Field(i)
 		dstField := dstVal.Field(i)
 
+		// Maps stored as metadata, such as validation hooks or the record
+		// of which fields have been set, do not hold GoStructs and hence
+		// their entries are merged into a new map, such that modifying the
+		// copy does not modify the source. Entries of the destination are
+		// retained, with those of the source taking precedence where both
+		// hold the same key.
+		if util.IsYgotMetadata(srcVal.Type().Field(i)) && srcField.Kind() == reflect.Map {
+			if !srcField.IsNil() {
+				m := reflect.MakeMapWithSize(srcField.Type(), srcField.Len()+dstField.Len())
+				for _, f := range []reflect.Value{dstField, srcField} {
+					for _, k := range f.MapKeys() {
+						m.SetMapIndex(k, f.MapIndex(k))
+					}
+				}
+				dstField.Set(m)
+			}
+			continue
+		}
+
+		// Annotation fields of a user-defined AnnotationContainer type are
+		// opaque, and are hence copied by reference where populated.
+		if isAnnotationContainerField(srcVal.Type().Field(i)) {
+			if !srcField.IsZero() {
+				dstField.Set(srcField)
+			}
+			continue
+		}
+
+		// The lengths of fixed-size arrays are copied along with the
+		// array that they describe.
+		if util.IsYgotArrayLen(srcVal.Type().Field(i)) {
+			continue
+		}
+		if _, ok := util.YgotArrayLenField(srcVal.Type().Field(i)); ok {
+			if err := copyFixedArrayField(dstVal, srcVal, srcVal.Type().Field(i), depth, opts...); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if util.IsYgotValueLeaf(srcVal.Type().Field(i)) {
+			// A leaf that is stored as a value and holds the zero value
+			// cannot be distinguished from one that has not been
+			// populated, and hence is treated as unset in both the
+			// source and destination.
+			if srcField.IsZero() {
+				continue
+			}
+			vSrc, vDst := srcField.Interface(), dstField.Interface()
+			if !dstField.IsZero() && vSrc != vDst && !fieldOverwriteEnabled(opts) {
+				return fmt.Errorf("destination value was set, but was not equal to source value when merging value field, src: %v, dst: %v", vSrc, vDst)
+			}
+			dstField.Set(srcField)
+			continue
+		}
+
+		// Ordered lists are merged through their methods, since their
+		// fields are not exported.
+		if srcField.Type().Implements(goOrderedListType) {
+			if err := copyOrderedListField(dstField, srcField, depth, opts...); err != nil {
+				return err
+			}
+			continue
+		}
+
 		switch srcField.Kind() {
 		case reflect.Ptr:
-			if err := copyPtrField(dstField, srcField, opts...); err != nil {
+			if err := copyPtrField(dstField, srcField, depth, opts...); err != nil {
 				return err
 			}
 		case reflect.Interface:
-			if err := copyInterfaceField(dstField, srcField, opts...); err != nil {
+			if err := copyInterfaceField(dstField, srcField, depth, opts...); err != nil {
 				return err
 			}
 		case reflect.Map:
-			if err := copyMapField(dstField, srcField, opts...); err != nil {
+			if err := copyMapField(dstField, srcField, depth, opts...); err != nil {
 				return err
 			}
 		case reflect.Slice:
-			if err := copySliceField(dstField, srcField, opts...); err != nil {
+			if err := copySliceField(dstField, srcField, depth, opts...); err != nil {
 				return err
 			}
 		case reflect.Int64:
@@ -707,7 +1437,8 @@ func copyStruct(dstVal, srcVal reflect.Value, opts ...MergeOpt) error {
 // is returned. If the source and destination both have a pointer field, which is
 // populated then an error is returned unless the value of the field is
 // equal in both structs.
-func copyPtrField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
+// The depth of the struct containing the fields is specified by depth.
+func copyPtrField(dstField, srcField reflect.Value, depth int, opts ...MergeOpt) error {
 
 	if util.IsNilOrInvalidValue(srcField) {
 		return nil
@@ -730,7 +1461,7 @@ func copyPtrField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
 			d = dstField
 		}
 
-		if err := copyStruct(d.Elem(), srcField.Elem(), opts...); err != nil {
+		if err := copyStruct(d.Elem(), srcField.Elem(), depth+1, opts...); err != nil {
 			return err
 		}
 		dstField.Set(d)
@@ -752,7 +1483,8 @@ func copyPtrField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
 
 // copyInterfaceField copies srcField into dstField. Both srcField and dstField
 // are reflect.Value structs which contain an interface value.
-func copyInterfaceField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
+// The depth of the struct containing the fields is specified by depth.
+func copyInterfaceField(dstField, srcField reflect.Value, depth int, opts ...MergeOpt) error {
 	if util.IsNilOrInvalidValue(srcField) {
 		return nil
 	}
@@ -773,12 +1505,12 @@ func copyInterfaceField(dstField, srcField reflect.Value, opts ...MergeOpt) erro
 		}
 
 		d := reflect.New(s.Type())
-		if err := copyStruct(d.Elem(), s, opts...); err != nil {
+		if err := copyStruct(d.Elem(), s, depth+1, opts...); err != nil {
 			return err
 		}
 		dstField.Set(d)
 		return nil
-	case srcField.Elem().Kind() == reflect.Slice && srcField.Elem().Type().Name() == BinaryTypeName:
+	case IsBinaryType(srcField.Elem().Type()):
 		if !util.IsNilOrInvalidValue(dstField) {
 			s, d := srcField.Interface(), dstField.Interface()
 			if diff := cmp.Diff(s, d); !fieldOverwriteEnabled(opts) && diff != "" {
@@ -811,7 +1543,8 @@ func copyInterfaceField(dstField, srcField reflect.Value, opts ...MergeOpt) erro
 // are populated, and have non-overlapping keys, they are merged. If the same
 // key is populated in srcField and dstField, their contents are merged if they
 // do not overlap, otherwise an error is returned.
-func copyMapField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
+// The depth of the struct containing the fields is specified by depth.
+func copyMapField(dstField, srcField reflect.Value, depth int, opts ...MergeOpt) error {
 	if !util.IsValueMap(srcField) {
 		return fmt.Errorf("received a non-map type in src map field: %v", srcField.Kind())
 	}
@@ -842,20 +1575,74 @@ func copyMapField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
 		dstKeys[k.Interface()] = true
 	}
 
+	// Lists whose members are stored by value are merged using copies of
+	// their members, which are then stored in dstField.
+	valueMembers := util.IsTypeStruct(m.value)
 	for _, k := range srcField.MapKeys() {
-		v := srcField.MapIndex(k)
+		v := util.ListMemberPtr(srcField.MapIndex(k))
 		d := reflect.New(v.Elem().Type())
 		if _, ok := dstKeys[k.Interface()]; ok {
-			d = dstField.MapIndex(k)
+			d = util.ListMemberPtr(dstField.MapIndex(k))
 		}
-		if err := copyStruct(d.Elem(), v.Elem(), opts...); err != nil {
+		if err := copyStruct(d.Elem(), v.Elem(), depth+1, opts...); err != nil {
 			return err
 		}
+		if valueMembers {
+			d = d.Elem()
+		}
 		dstField.SetMapIndex(k, d)
 	}
 	return nil
 }
 
+// copyOrderedListField copies srcField into dstField. Both srcField and
+// dstField are reflect.Value structs which contain an ordered list. Members of
+// srcField whose keys are present in dstField are merged into the existing
+// members, with the same semantics as copyMapField, and other members are
+// appended to dstField in the order in which they are stored in srcField.
+// The depth of the struct containing the fields is specified by depth.
+func copyOrderedListField(dstField, srcField reflect.Value, depth int, opts ...MergeOpt) error {
+	if util.IsNilOrInvalidValue(srcField) {
+		return nil
+	}
+	src := srcField.Interface().(GoOrderedList)
+
+	// Skip cases where the source list is empty, unless the user wants an
+	// empty list to be merged as well.
+	if src.Len() == 0 && (!mergeEmptyMapsEnabled(opts) || !util.IsNilOrInvalidValue(dstField)) {
+		return nil
+	}
+
+	if util.IsNilOrInvalidValue(dstField) {
+		dstField.Set(reflect.New(srcField.Type().Elem()))
+	}
+
+	dst := dstField.Interface().(GoOrderedList)
+	dstMembers := map[interface{}]GoStruct{}
+	dstVals := dst.ΛValues()
+	for i, k := range dst.ΛKeys() {
+		dstMembers[k] = dstVals[i]
+	}
+
+	srcVals := src.ΛValues()
+	for i, k := range src.ΛKeys() {
+		v := reflect.ValueOf(srcVals[i])
+		d, ok := dstMembers[k]
+		if !ok {
+			d = reflect.New(v.Type().Elem()).Interface().(GoStruct)
+		}
+		if err := copyStruct(reflect.ValueOf(d).Elem(), v.Elem(), depth+1, opts...); err != nil {
+			return err
+		}
+		if !ok {
+			if err := util.AppendToOrderedList(dstField, reflect.ValueOf(d)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // mapTypes provides a specification of a map.
 type mapType struct {
 	key   reflect.Type // key is the type of the key of the map.
@@ -863,8 +1650,8 @@ type mapType struct {
 }
 
 // validateMap checks the srcField and dstField reflect.Value structs
-// to ensure that they are valid maps of struct pointers, and that their keys
-// types are the same. It returns a specification of the map type if the maps
+// to ensure that they are valid maps of struct pointers, or of struct values,
+// and that their keys types are the same. It returns a specification of the map type if the maps
 // match.
 func validateMap(srcField, dstField reflect.Value) (*mapType, error) {
 	if s := srcField.Kind(); s != reflect.Map {
@@ -881,8 +1668,8 @@ func validateMap(srcField, dstField reflect.Value) (*mapType, error) {
 		return nil, fmt.Errorf("invalid maps, src and dst value types are different, %v != %v", se, de)
 	}
 
-	if !util.IsTypeStructPtr(se) || !util.IsTypeStructPtr(de) {
-		return nil, fmt.Errorf("invalid maps, src or dst does not have a struct ptr element, src: %v, dst: %v", se.Kind(), de.Kind())
+	if !(util.IsTypeStructPtr(se) || util.IsTypeStruct(se)) || !(util.IsTypeStructPtr(de) || util.IsTypeStruct(de)) {
+		return nil, fmt.Errorf("invalid maps, src or dst does not have a struct or struct ptr element, src: %v, dst: %v", se.Kind(), de.Kind())
 	}
 
 	if sk, dk := st.Key(), dt.Key(); sk != dk {
@@ -895,7 +1682,8 @@ func validateMap(srcField, dstField reflect.Value) (*mapType, error) {
 // copySliceField copies srcField into dstField. Both srcField and dstField
 // must have a kind of reflect.Slice kind and contain pointers to structs. If
 // the slice in dstField is populated an error is returned.
-func copySliceField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
+// The depth of the struct containing the fields is specified by depth.
+func copySliceField(dstField, srcField reflect.Value, depth int, opts ...MergeOpt) error {
 	if dstField.Len() == 0 && srcField.Len() == 0 {
 		return nil
 	}
@@ -927,7 +1715,7 @@ func copySliceField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
 	for i := 0; i < srcField.Len(); i++ {
 		v := srcField.Index(i)
 		d := reflect.New(v.Type().Elem())
-		if err := copyStruct(d.Elem(), v.Elem(), opts...); err != nil {
+		if err := copyStruct(d.Elem(), v.Elem(), depth+1, opts...); err != nil {
 			return err
 		}
 		dstField.Set(reflect.Append(dstField, v))
@@ -935,6 +1723,28 @@ func copySliceField(dstField, srcField reflect.Value, opts ...MergeOpt) error {
 	return nil
 }
 
+// copyFixedArrayField copies the populated elements of the field sf of the
+// srcVal struct into the same field of the dstVal struct, where sf is a
+// leaf-list that is stored as a fixed-size array. The elements are merged in
+// the same manner as those of a leaf-list that is stored as a slice, and an
+// error is returned if the merged elements do not fit within the array.
+func copyFixedArrayField(dstVal, srcVal reflect.Value, sf reflect.StructField, depth int, opts ...MergeOpt) error {
+	srcElems, err := util.FixedArrayElems(srcVal, sf)
+	if err != nil {
+		return err
+	}
+	dstElems, err := util.FixedArrayElems(dstVal, sf)
+	if err != nil {
+		return err
+	}
+	merged := reflect.New(dstElems.Type()).Elem()
+	merged.Set(dstElems)
+	if err := copySliceField(merged, srcElems, depth, opts...); err != nil {
+		return err
+	}
+	return util.SetFixedArrayElems(dstVal, sf, merged)
+}
+
 // uniqueSlices takes two reflect.Values which must represent slices, and determines
 // whether a and b are disjoint. It returns true if the slices have unique
 // members, and false if not.
@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -93,6 +94,18 @@ func TestStructTagToLibPaths(t *testing.T) {
 		want: []*gnmiPath{{
 			stringSlicePath: []string{"foo", "bar"},
 		}},
+	}, {
+		name: "multi-element single tag with json tag example",
+		inField: reflect.StructField{
+			Name: "field",
+			Tag:  `path:"foo/bar" module:"mod/mod" json:"mod:bar,omitempty"`,
+		},
+		inParent: &gnmiPath{
+			stringSlicePath: []string{},
+		},
+		want: []*gnmiPath{{
+			stringSlicePath: []string{"foo", "bar"},
+		}},
 	}, {
 		name: "multi-element single tag with shadow-path example",
 		inField: reflect.StructField{
@@ -422,6 +435,93 @@ func TestEnumName(t *testing.T) {
 	}
 }
 
+// typedefEnumTest is a synthesised enumeration which represents an enumeration
+// defined within a typedef, where each value has the same defining module.
+type typedefEnumTest int64
+
+func (typedefEnumTest) IsYANGGoEnum() {}
+
+const (
+	TDUNSET     typedefEnumTest = 0
+	TDONE       typedefEnumTest = 1
+	TDNOMODULE  typedefEnumTest = 2
+	TDOUTOFSPEC typedefEnumTest = 42
+)
+
+func (typedefEnumTest) ΛMap() map[string]map[int64]EnumDefinition {
+	return map[string]map[int64]EnumDefinition{
+		"typedefEnumTest": {
+			1: EnumDefinition{Name: "TD_ONE", DefiningModule: "typedef-mod"},
+			2: EnumDefinition{Name: "TD_NO_MODULE"},
+		},
+	}
+}
+
+func (e typedefEnumTest) String() string {
+	return EnumLogString(e, int64(e), "typedefEnumTest")
+}
+
+func TestEnumNameQualified(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               GoEnum
+		want             string
+		wantErrSubstring string
+	}{{
+		name: "identityref-style enumeration",
+		in:   EONE,
+		want: "valone-mod:VAL_ONE",
+	}, {
+		name: "identityref-style enumeration with different defining module",
+		in:   ETWO,
+		want: "valtwo-mod:VAL_TWO",
+	}, {
+		name: "typedef-style enumeration",
+		in:   TDONE,
+		want: "typedef-mod:TD_ONE",
+	}, {
+		name: "enumeration without defining module",
+		in:   TDNOMODULE,
+		want: "TD_NO_MODULE",
+	}, {
+		name: "unset",
+		in:   TDUNSET,
+		want: "",
+	}, {
+		name:             "out of range value",
+		in:               TDOUTOFSPEC,
+		wantErrSubstring: "has unknown value 42",
+	}, {
+		name:             "bad enumeration",
+		in:               BONE,
+		wantErrSubstring: "cannot map enumerated value as type badEnumTest was unknown",
+	}}
+
+	for _, tt := range tests {
+		got, err := EnumNameQualified(tt.in)
+		if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+			t.Errorf("%s: EnumNameQualified(%v): did not get expected error, %s", tt.name, tt.in, diff)
+		}
+
+		if got != tt.want {
+			t.Errorf("%s: EnumNameQualified(%v): did not get expected value, got: %s, want: %s", tt.name, tt.in, got, tt.want)
+		}
+
+		// The unqualified name must be consistent with the qualified name.
+		if tt.wantErrSubstring != "" {
+			continue
+		}
+		bare, err := EnumName(tt.in)
+		if err != nil {
+			t.Errorf("%s: EnumName(%v): got unexpected error: %v", tt.name, tt.in, err)
+			continue
+		}
+		if !strings.HasSuffix(got, bare) {
+			t.Errorf("%s: EnumNameQualified(%v): got %s, which does not end with EnumName value %s", tt.name, tt.in, got, bare)
+		}
+	}
+}
+
 func TestEnumLogString(t *testing.T) {
 	tests := []struct {
 		desc           string
@@ -464,6 +564,49 @@ func TestEnumLogString(t *testing.T) {
 	}
 }
 
+func TestSetEnumStringFormatter(t *testing.T) {
+	defer SetEnumStringFormatter(nil)
+
+	var gotTypeName string
+	var gotVal int64
+	SetEnumStringFormatter(func(typeName string, val int64) string {
+		gotTypeName, gotVal = typeName, val
+		return "UNKNOWN"
+	})
+
+	if got, want := EnumLogString(EONE, 42, "enumTest"), "UNKNOWN"; got != want {
+		t.Errorf("EnumLogString with custom formatter: got %s, want %s", got, want)
+	}
+	if gotTypeName != "enumTest" || gotVal != 42 {
+		t.Errorf("EnumLogString with custom formatter: formatter called with (%s, %d), want (enumTest, 42)", gotTypeName, gotVal)
+	}
+	if got, want := EnumLogString(EONE, int64(EONE), "enumTest"), "VAL_ONE"; got != want {
+		t.Errorf("EnumLogString with custom formatter for valid value: got %s, want %s", got, want)
+	}
+
+	SetEnumStringFormatter(nil)
+	if got, want := EnumLogString(EONE, 42, "enumTest"), "out-of-range enumTest enum value: 42"; got != want {
+		t.Errorf("EnumLogString after restoring default formatter: got %s, want %s", got, want)
+	}
+}
+
+func TestSetEnumStringFormatterFromFormatter(t *testing.T) {
+	defer SetEnumStringFormatter(nil)
+
+	// A formatter that replaces itself must not deadlock when called.
+	SetEnumStringFormatter(func(typeName string, val int64) string {
+		SetEnumStringFormatter(func(string, int64) string { return "SECOND" })
+		return "FIRST"
+	})
+
+	if got, want := EnumLogString(EONE, 42, "enumTest"), "FIRST"; got != want {
+		t.Errorf("EnumLogString with self-replacing formatter: got %s, want %s", got, want)
+	}
+	if got, want := EnumLogString(EONE, 42, "enumTest"), "SECOND"; got != want {
+		t.Errorf("EnumLogString with replaced formatter: got %s, want %s", got, want)
+	}
+}
+
 // mapStructTestOne is the base struct used for the simple-schema test.
 type mapStructTestOne struct {
 	Child *mapStructTestOneChild `path:"child" module:"test-one"`
@@ -626,6 +769,67 @@ func (*mapStructNoPaths) ΛValidate(...ValidationOption) error     { return nil
 func (*mapStructNoPaths) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
 func (*mapStructNoPaths) ΛBelongingModule() string                { return "" }
 
+// mapStructTestMultiKey is a test struct containing a list with multiple
+// keys, used to test the ordering of list entries in emitted JSON.
+type mapStructTestMultiKey struct {
+	Entry map[mapStructTestMultiKeyKey]*mapStructTestMultiKeyEntry `path:"entries/entry"`
+}
+
+// IsYANGGoStruct makes sure that we implement the GoStruct interface.
+func (*mapStructTestMultiKey) IsYANGGoStruct() {}
+
+func (*mapStructTestMultiKey) ΛValidate(...ValidationOption) error {
+	return nil
+}
+
+func (*mapStructTestMultiKey) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*mapStructTestMultiKey) ΛBelongingModule() string                { return "" }
+
+// mapStructTestMultiKeyKey is the key of the list within mapStructTestMultiKey,
+// with fields in the order in which the keys are declared.
+type mapStructTestMultiKeyKey struct {
+	Name  string `path:"name"`
+	Index uint32 `path:"index"`
+}
+
+// mapStructTestMultiKeyEntry is an entry of the list within mapStructTestMultiKey.
+type mapStructTestMultiKeyEntry struct {
+	Name  *string `path:"config/name|name"`
+	Index *uint32 `path:"config/index|index"`
+}
+
+// IsYANGGoStruct makes sure that we implement the GoStruct interface.
+func (*mapStructTestMultiKeyEntry) IsYANGGoStruct() {}
+
+func (*mapStructTestMultiKeyEntry) ΛValidate(...ValidationOption) error {
+	return nil
+}
+
+func (*mapStructTestMultiKeyEntry) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*mapStructTestMultiKeyEntry) ΛBelongingModule() string                { return "" }
+
+// mapStructMetadata is an example of user-provided metadata that is embedded
+// within a GoStruct.
+type mapStructMetadata struct {
+	LastChange int64
+	Origin     string
+}
+
+// mapStructWithMetadata is a GoStruct which embeds a metadata field, which
+// is not a schema node.
+type mapStructWithMetadata struct {
+	ΛMetadata mapStructMetadata `ygotMetadata:"true"`
+	Name      *string           `path:"name"`
+}
+
+// IsYANGGoStruct implements the GoStruct interface.
+func (*mapStructWithMetadata) IsYANGGoStruct() {}
+
+// Validate implements the GoStruct interface.
+func (*mapStructWithMetadata) ΛValidate(...ValidationOption) error     { return nil }
+func (*mapStructWithMetadata) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*mapStructWithMetadata) ΛBelongingModule() string                { return "" }
+
 // TestEmitJSON validates that the EmitJSON function outputs the expected JSON
 // for a set of input structs and schema definitions.
 func TestEmitJSON(t *testing.T) {
@@ -656,6 +860,43 @@ func TestEmitJSON(t *testing.T) {
 			EscapeHTML: true,
 		},
 		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_1_html_safe.json-txt"),
+	}, {
+		name: "simple schema JSON output with multibyte string",
+		inStruct: &mapStructTestOne{
+			Child: &mapStructTestOneChild{
+				FieldOne: String("Größe 🚀"),
+				FieldTwo: Uint32(42),
+			},
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_1_unicode.json-txt"),
+	}, {
+		name: "simple schema JSON output with escaped unicode",
+		inStruct: &mapStructTestOne{
+			Child: &mapStructTestOneChild{
+				FieldOne: String("Größe 🚀"),
+				FieldTwo: Uint32(42),
+			},
+		},
+		inConfig: &EmitJSONConfig{
+			EscapeUnicode: true,
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_1_unicode_escaped.json-txt"),
+	}, {
+		name: "simple schema IETF JSON output with escaped unicode",
+		inStruct: &mapStructTestOne{
+			Child: &mapStructTestOneChild{
+				FieldOne: String("Größe 🚀"),
+				FieldTwo: Uint32(42),
+			},
+		},
+		inConfig: &EmitJSONConfig{
+			Format: RFC7951,
+			RFC7951Config: &RFC7951JSONConfig{
+				AppendModuleName: true,
+			},
+			EscapeUnicode: true,
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson1_unicode_escaped_ietf.json-txt"),
 	}, {
 		name: "schema with a list JSON output",
 		inStruct: &mapStructTestFour{
@@ -683,6 +924,70 @@ func TestEmitJSON(t *testing.T) {
 			Indent: "  ",
 		},
 		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson1_ietf.json-txt"),
+	}, {
+		name: "non-root struct IETF JSON output",
+		inStruct: &mapStructTestOneChild{
+			FieldOne:  String("bar"),
+			FieldTwo:  Uint32(84),
+			FieldFive: Uint64(42),
+		},
+		inConfig: &EmitJSONConfig{
+			Format: RFC7951,
+			RFC7951Config: &RFC7951JSONConfig{
+				AppendModuleName: true,
+			},
+			Indent: "  ",
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson1_child_ietf.json-txt"),
+	}, {
+		name: "non-root struct IETF JSON output using belonging module as parent",
+		inStruct: &mapStructTestOneChild{
+			FieldOne:  String("bar"),
+			FieldTwo:  Uint32(84),
+			FieldFive: Uint64(42),
+		},
+		inConfig: &EmitJSONConfig{
+			Format: RFC7951,
+			RFC7951Config: &RFC7951JSONConfig{
+				AppendModuleName:           true,
+				UseBelongingModuleAsParent: true,
+			},
+			Indent: "  ",
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson1_child_belonging_ietf.json-txt"),
+	}, {
+		name: "non-root struct IETF JSON output with root module override",
+		inStruct: &mapStructTestOneChild{
+			FieldOne:  String("bar"),
+			FieldTwo:  Uint32(84),
+			FieldFive: Uint64(42),
+		},
+		inConfig: &EmitJSONConfig{
+			Format: RFC7951,
+			RFC7951Config: &RFC7951JSONConfig{
+				AppendModuleName: true,
+			},
+			Indent:             "  ",
+			RootModuleOverride: "test-five",
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson1_child_override_ietf.json-txt"),
+	}, {
+		name: "non-root struct IETF JSON output with rewritten module",
+		inStruct: &mapStructTestOneChild{
+			FieldOne:  String("bar"),
+			FieldTwo:  Uint32(84),
+			FieldFive: Uint64(42),
+		},
+		inConfig: &EmitJSONConfig{
+			Format: RFC7951,
+			RFC7951Config: &RFC7951JSONConfig{
+				AppendModuleName:           true,
+				RewriteModuleNames:         map[string]string{"test-one": "test-five"},
+				UseBelongingModuleAsParent: true,
+			},
+			Indent: "  ",
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson1_child_rewrite_ietf.json-txt"),
 	}, {
 		name: "schema with list and enum IETF JSON",
 		inStruct: &mapStructTestFour{
@@ -704,6 +1009,162 @@ func TestEmitJSON(t *testing.T) {
 			Indent: "  ",
 		},
 		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson2_ietf.json-txt"),
+	}, {
+		name: "schema with list and enum OpenConfig compact JSON",
+		inStruct: &mapStructTestFour{
+			C: &mapStructTestFourC{
+				ACLSet: map[string]*mapStructTestFourCACLSet{
+					"n42": {Name: String("n42"), SecondValue: String("foo")},
+				},
+				OtherSet: map[ECTest]*mapStructTestFourCOtherSet{
+					ECTestVALONE: {Name: ECTestVALONE},
+					ECTestVALTWO: {Name: ECTestVALTWO},
+				},
+			},
+		},
+		inConfig: &EmitJSONConfig{
+			Format: OpenConfigCompact,
+			RFC7951Config: &RFC7951JSONConfig{
+				AppendModuleName: true,
+			},
+			Indent: "  ",
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson2_oc_compact.json-txt"),
+	}, {
+		name: "schema with list and enum IETF JSON, with key leaves always emitted",
+		inStruct: &mapStructTestFour{
+			C: &mapStructTestFourC{
+				ACLSet: map[string]*mapStructTestFourCACLSet{
+					"n42": {SecondValue: String("foo")},
+					"n84": {Name: String("n84")},
+				},
+				OtherSet: map[ECTest]*mapStructTestFourCOtherSet{
+					ECTestVALONE: {},
+					ECTestVALTWO: {Name: ECTestVALTWO},
+				},
+			},
+		},
+		inConfig: &EmitJSONConfig{
+			Format: RFC7951,
+			RFC7951Config: &RFC7951JSONConfig{
+				AppendModuleName: true,
+			},
+			Indent:                  "  ",
+			AlwaysEmitListKeyLeaves: true,
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson2_key_leaves_ietf.json-txt"),
+	}, {
+		name: "multi-keyed list IETF JSON, with key leaves always emitted",
+		inStruct: &mapStructTestMultiKey{
+			Entry: map[mapStructTestMultiKeyKey]*mapStructTestMultiKeyEntry{
+				{Name: "b", Index: 1}: {Name: String("b")},
+				{Name: "a", Index: 2}: {},
+			},
+		},
+		inConfig: &EmitJSONConfig{
+			Format:                  RFC7951,
+			Indent:                  "  ",
+			AlwaysEmitListKeyLeaves: true,
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_multikey_key_leaves_ietf.json-txt"),
+	}, {
+		name: "multi-keyed list IETF JSON",
+		inStruct: &mapStructTestMultiKey{
+			Entry: map[mapStructTestMultiKeyKey]*mapStructTestMultiKeyEntry{
+				{Name: "b", Index: 1}:  {Name: String("b"), Index: Uint32(1)},
+				{Name: "a", Index: 10}: {Name: String("a"), Index: Uint32(10)},
+				{Name: "a", Index: 2}:  {Name: String("a"), Index: Uint32(2)},
+			},
+		},
+		inConfig: &EmitJSONConfig{
+			Format: RFC7951,
+			Indent: "  ",
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_multikey_ietf.json-txt"),
+	}, {
+		name: "multi-keyed list IETF JSON sorted by key",
+		inStruct: &mapStructTestMultiKey{
+			Entry: map[mapStructTestMultiKeyKey]*mapStructTestMultiKeyEntry{
+				{Name: "b", Index: 1}:  {Name: String("b"), Index: Uint32(1)},
+				{Name: "a", Index: 10}: {Name: String("a"), Index: Uint32(10)},
+				{Name: "a", Index: 2}:  {Name: String("a"), Index: Uint32(2)},
+			},
+		},
+		inConfig: &EmitJSONConfig{
+			Format:         RFC7951,
+			Indent:         "  ",
+			SortListsByKey: true,
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_multikey_sorted_ietf.json-txt"),
+	}, {
+		name:     "fixed-size array IETF JSON",
+		inStruct: &fixedArrayStruct{Hops: [3]string{"a", "b", "unused"}, HopsLen: 2},
+		inConfig: &EmitJSONConfig{
+			Format: RFC7951,
+			Indent: "  ",
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_fixed_array_ietf.json-txt"),
+	}, {
+		name: "simple schema path value list output",
+		inStruct: &mapStructTestOne{
+			Child: &mapStructTestOneChild{
+				FieldOne:   String("abc -> def"),
+				FieldTwo:   Uint32(42),
+				FieldThree: Binary{42},
+				FieldFour:  []Binary{{1, 2}, {3}},
+			},
+		},
+		inConfig: &EmitJSONConfig{
+			Format: PathValueList,
+			Indent: "  ",
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_1_pathvaluelist.json-txt"),
+	}, {
+		name: "simple schema path value list output with PathElem paths",
+		inStruct: &mapStructTestOne{
+			Child: &mapStructTestOneChild{
+				FieldOne:  String("abc -> def"),
+				FieldTwo:  Uint32(42),
+				FieldFive: Uint64(84),
+			},
+		},
+		inConfig: &EmitJSONConfig{
+			Format:              PathValueList,
+			PathValueListConfig: &PathValueListConfig{UsePathElem: true},
+			Indent:              "  ",
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_1_pathvaluelist_pathelem.json-txt"),
+	}, {
+		name: "schema with a list path value list output",
+		inStruct: &mapStructTestFour{
+			C: &mapStructTestFourC{
+				ACLSet: map[string]*mapStructTestFourCACLSet{
+					"n42": {Name: String("n42"), SecondValue: String("val")},
+				},
+			},
+		},
+		inConfig: &EmitJSONConfig{
+			Format: PathValueList,
+			Indent: "  ",
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_2_pathvaluelist.json-txt"),
+	}, {
+		name: "struct with embedded metadata",
+		inStruct: &mapStructWithMetadata{
+			ΛMetadata: mapStructMetadata{LastChange: 42, Origin: "openconfig"},
+			Name:      String("pangolin"),
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_metadata.json-txt"),
+	}, {
+		name: "struct with embedded metadata IETF JSON",
+		inStruct: &mapStructWithMetadata{
+			ΛMetadata: mapStructMetadata{LastChange: 42, Origin: "openconfig"},
+			Name:      String("pangolin"),
+		},
+		inConfig: &EmitJSONConfig{
+			Format: RFC7951,
+		},
+		wantJSONPath: filepath.Join(TestRoot, "testdata/emitjson_metadata.json-txt"),
 	}, {
 		name:     "invalid struct contents",
 		inStruct: &mapStructInvalid{Name: String("aardvark")},
@@ -726,6 +1187,13 @@ func TestEmitJSON(t *testing.T) {
 			Format: RFC7951,
 		},
 		wantErr: "ConstructIETFJSON error: Name: field did not specify a path",
+	}, {
+		name:     "invalid OpenConfig compact JSON",
+		inStruct: &mapStructNoPaths{Name: String("lacewing")},
+		inConfig: &EmitJSONConfig{
+			Format: OpenConfigCompact,
+		},
+		wantErr: "OpenConfigCompact error: Name: field did not specify a path",
 	}}
 
 	for _, tt := range tests {
@@ -754,6 +1222,80 @@ func TestEmitJSON(t *testing.T) {
 	}
 }
 
+func TestEmitJSONMetadata(t *testing.T) {
+	withOrigin := func(origin string) *annotatedJSONTestStruct {
+		s := &annotatedJSONTestStruct{Field: String("russian-river")}
+		if err := AttachMetadata(s, "Field", "ietf-origin:origin", origin); err != nil {
+			t.Fatalf("cannot attach metadata: %v", err)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name     string
+		inStruct GoStruct
+		inConfig *EmitJSONConfig
+		wantJSON string
+		wantErr  string
+	}{{
+		name:     "metadata emitted as annotation array",
+		inStruct: withOrigin("ietf-origin:intended"),
+		inConfig: &EmitJSONConfig{Format: RFC7951, Indent: "  "},
+		wantJSON: `{
+  "@field": [
+    {
+      "ietf-origin:origin": "ietf-origin:intended"
+    }
+  ],
+  "field": "russian-river"
+}`,
+	}, {
+		name:     "metadata emitted as RFC7952 object",
+		inStruct: withOrigin("ietf-origin:intended"),
+		inConfig: &EmitJSONConfig{Format: RFC7951, Indent: "  ", EmitMetadata: true},
+		wantJSON: `{
+  "@field": {
+    "ietf-origin:origin": "ietf-origin:intended"
+  },
+  "field": "russian-river"
+}`,
+	}, {
+		name: "metadata merged from multiple annotations",
+		inStruct: &annotatedJSONTestStruct{
+			Field: String("russian-river"),
+			ΛField: []Annotation{
+				&MetadataAnnotation{Values: map[string]interface{}{"ietf-origin:origin": "ietf-origin:learned"}},
+				&testAnnotation{AnnotationFieldOne: "alexander-valley"},
+			},
+		},
+		inConfig: &EmitJSONConfig{Format: OpenConfigCompact, EmitMetadata: true},
+		wantJSON: `{"@field":{"field":"alexander-valley","ietf-origin:origin":"ietf-origin:learned"},"field":"russian-river"}`,
+	}, {
+		name: "duplicate metadata",
+		inStruct: &annotatedJSONTestStruct{
+			Field: String("russian-river"),
+			ΛField: []Annotation{
+				&MetadataAnnotation{Values: map[string]interface{}{"ietf-origin:origin": "ietf-origin:learned"}},
+				&MetadataAnnotation{Values: map[string]interface{}{"ietf-origin:origin": "ietf-origin:system"}},
+			},
+		},
+		inConfig: &EmitJSONConfig{Format: RFC7951, EmitMetadata: true},
+		wantErr:  "ConstructIETFJSON error: duplicate metadata annotation ietf-origin:origin",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EmitJSON(tt.inStruct, tt.inConfig)
+			if errToString(err) != tt.wantErr {
+				t.Fatalf("EmitJSON(%v, %v): did not get expected error, got: %v, want: %v", tt.inStruct, tt.inConfig, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantJSON, got); diff != "" {
+				t.Errorf("EmitJSON(%v, %v): did not get expected JSON, diff(-want, +got):\n%s", tt.inStruct, tt.inConfig, diff)
+			}
+		})
+	}
+}
+
 // emptyTreeTestOne is a test case for TestBuildEmptyTree.
 type emptyTreeTestOne struct {
 	ValOne   *string
@@ -961,13 +1503,103 @@ func TestPruneEmptyBranches(t *testing.T) {
 	}}
 
 	for _, tt := range tests {
-		PruneEmptyBranches(tt.inStruct)
+		if err := PruneEmptyBranches(tt.inStruct); err != nil {
+			t.Errorf("%s: PruneEmptyBranches(%#v): got unexpected error: %v", tt.name, tt.inStruct, err)
+			continue
+		}
 		if diff := pretty.Compare(tt.inStruct, tt.want); diff != "" {
 			t.Errorf("%s: PruneEmptyBranches(%#v): did not get expected output, diff(-got,+want):\n%s", tt.name, tt.inStruct, diff)
 		}
 	}
 }
 
+// deepStruct is a synthesised GoStruct that can be nested to an arbitrary
+// depth, for use in testing MaxTraversalDepth.
+type deepStruct struct {
+	Value *string     `path:"value"`
+	Child *deepStruct `path:"child"`
+}
+
+func (*deepStruct) IsYANGGoStruct() {}
+
+// newDeepStruct returns a deepStruct with a populated leaf at the supplied
+// depth below it.
+func newDeepStruct(depth int) *deepStruct {
+	if depth == 0 {
+		return &deepStruct{Value: String("leaf")}
+	}
+	return &deepStruct{Child: newDeepStruct(depth - 1)}
+}
+
+func TestMaxTraversalDepth(t *testing.T) {
+	defer func(d int) { MaxTraversalDepth = d }(MaxTraversalDepth)
+	MaxTraversalDepth = 10
+
+	walkers := map[string]func(*deepStruct) error{
+		"DeepCopy": func(s *deepStruct) error {
+			_, err := DeepCopy(s)
+			return err
+		},
+		"MergeStructs": func(s *deepStruct) error {
+			_, err := MergeStructs(&deepStruct{}, s)
+			return err
+		},
+		"PruneEmptyBranches": func(s *deepStruct) error {
+			return PruneEmptyBranches(s)
+		},
+		"ConstructIETFJSON": func(s *deepStruct) error {
+			_, err := ConstructIETFJSON(s, nil)
+			return err
+		},
+		"ConstructInternalJSON": func(s *deepStruct) error {
+			_, err := ConstructInternalJSON(s)
+			return err
+		},
+	}
+
+	cyclic := &deepStruct{}
+	cyclic.Child = cyclic
+
+	tests := []struct {
+		name    string
+		in      *deepStruct
+		wantErr string
+	}{{
+		name: "struct at maximum depth",
+		in:   newDeepStruct(10),
+	}, {
+		name:    "struct beyond maximum depth",
+		in:      newDeepStruct(11),
+		wantErr: "maximum traversal depth of 10 exceeded",
+	}, {
+		name:    "struct containing a cycle",
+		in:      cyclic,
+		wantErr: "maximum traversal depth of 10 exceeded",
+	}}
+
+	for _, tt := range tests {
+		for name, fn := range walkers {
+			t.Run(fmt.Sprintf("%s %s", tt.name, name), func(t *testing.T) {
+				if diff := errdiff.Substring(fn(tt.in), tt.wantErr); diff != "" {
+					t.Errorf("%s(%v): did not get expected error, %s", name, tt.in, diff)
+				}
+			})
+		}
+	}
+
+	t.Run("BuildEmptyTree", func(t *testing.T) {
+		s := &deepStruct{}
+		BuildEmptyTree(s)
+		var depth int
+		for c := s.Child; c != nil; c = c.Child {
+			depth++
+		}
+		if depth != MaxTraversalDepth {
+			t.Errorf("BuildEmptyTree(%T): did not get expected depth of initialised structs, got: %d, want: %d", s, depth, MaxTraversalDepth)
+		}
+	})
+}
+
 // initContainerTest is a synthesised GoStruct for use in
 // testing InitContainer.
 type initContainerTest struct {
@@ -1440,7 +2072,7 @@ func TestCopyStructError(t *testing.T) {
 	}}
 
 	for _, tt := range tests {
-		if err := copyStruct(tt.inA, tt.inB); err == nil {
+		if err := copyStruct(tt.inA, tt.inB, 0); err == nil {
 			t.Errorf("%s: copyStruct(%v, %v): did not get nil error, got: %v, want: nil", tt.name, tt.inA, tt.inB, err)
 		}
 	}
@@ -1806,9 +2438,9 @@ func TestCopyStruct(t *testing.T) {
 		inDst:   &errorCopyTest{},
 		wantErr: true,
 	}, {
-		name: "error, invalid struct in map",
+		name: "error, invalid field in struct value in map",
 		inSrc: &errorCopyTest{M: map[string]errorCopyTest{
-			"beaver-town-gamma-ray": {S: String("beaver-town-black-betty-ipa")},
+			"beaver-town-gamma-ray": {I: "beaver-town-black-betty-ipa"},
 		}},
 		inDst:   &errorCopyTest{},
 		wantErr: true,
@@ -1934,7 +2566,7 @@ func TestCopyStruct(t *testing.T) {
 			wantDst = reflect.ValueOf(tt.wantDst).Elem()
 		}
 
-		err := copyStruct(dst, src, tt.inOpts...)
+		err := copyStruct(dst, src, 0, tt.inOpts...)
 		if (err != nil) != tt.wantErr {
 			t.Fatalf("%s: copyStruct(%v, %v): did not get expected error, got: %v, wantErr: %v", tt.name, tt.inSrc, tt.inDst, err, tt.wantErr)
 		}
@@ -2407,6 +3039,42 @@ var mergeStructTests = []struct {
 	want: &validatedMergeTest{
 		UnionField: &copyUnionI{42},
 	},
+}, {
+	name: "value leaves, merge into zero values",
+	inA:  &valueLeafStruct{Ptr: Uint32(42)},
+	inB:  &valueLeafStruct{Str: "fourpure", Int: 42, Bool: true},
+	want: &valueLeafStruct{Str: "fourpure", Int: 42, Bool: true, Ptr: Uint32(42)},
+}, {
+	name: "value leaves, equal values",
+	inA:  &valueLeafStruct{Str: "fourpure", Int: 42},
+	inB:  &valueLeafStruct{Str: "fourpure"},
+	want: &valueLeafStruct{Str: "fourpure", Int: 42},
+}, {
+	name:    "value leaves, conflicting values",
+	inA:     &valueLeafStruct{Str: "fourpure"},
+	inB:     &valueLeafStruct{Str: "beartooth"},
+	wantErr: "destination value was set, but was not equal to source value when merging value field",
+}, {
+	name:   "value leaves, conflicting values with overwrite",
+	inA:    &valueLeafStruct{Str: "fourpure"},
+	inB:    &valueLeafStruct{Str: "beartooth"},
+	inOpts: []MergeOpt{&MergeOverwriteExistingFields{}},
+	want:   &valueLeafStruct{Str: "beartooth"},
+}, {
+	name: "fixed-size arrays, merge into empty array",
+	inA:  &fixedArrayStruct{},
+	inB:  &fixedArrayStruct{Hops: [3]string{"a", "unused"}, HopsLen: 1},
+	want: &fixedArrayStruct{Hops: [3]string{"a"}, HopsLen: 1},
+}, {
+	name: "fixed-size arrays, merge populated elements",
+	inA:  &fixedArrayStruct{Hops: [3]string{"a"}, HopsLen: 1},
+	inB:  &fixedArrayStruct{Hops: [3]string{"b", "c"}, HopsLen: 2},
+	want: &fixedArrayStruct{Hops: [3]string{"a", "b", "c"}, HopsLen: 3},
+}, {
+	name:    "fixed-size arrays, too many elements",
+	inA:     &fixedArrayStruct{Hops: [3]string{"a", "b"}, HopsLen: 2},
+	inB:     &fixedArrayStruct{Hops: [3]string{"c", "d"}, HopsLen: 2},
+	wantErr: "cannot set 4 elements of field Hops, which has 3 elements",
 }}
 
 func TestMergeStructs(t *testing.T) {
@@ -2491,10 +3159,18 @@ func TestValidateMap(t *testing.T) {
 		inDst:   reflect.ValueOf(map[string]uint32{}),
 		wantErr: "invalid maps, src and dst value types are different, string != uint32",
 	}, {
-		name:    "invalid src and dst field, not a struct ptr",
-		inSrc:   reflect.ValueOf(map[string]copyTest{}),
-		inDst:   reflect.ValueOf(map[string]copyTest{}),
-		wantErr: "invalid maps, src or dst does not have a struct ptr element, src: struct, dst: struct",
+		name:  "valid maps, struct values",
+		inSrc: reflect.ValueOf(map[string]copyTest{}),
+		inDst: reflect.ValueOf(map[string]copyTest{}),
+		wantMapType: &mapType{
+			key:   reflect.TypeOf(""),
+			value: reflect.TypeOf(copyTest{}),
+		},
+	}, {
+		name:    "invalid src and dst field, not a struct or struct ptr",
+		inSrc:   reflect.ValueOf(map[string]string{}),
+		inDst:   reflect.ValueOf(map[string]string{}),
+		wantErr: "invalid maps, src or dst does not have a struct or struct ptr element, src: string, dst: string",
 	}, {
 		name:    "invalid maps, src and dst key types differ",
 		inSrc:   reflect.ValueOf(map[string]*copyTest{}),
@@ -2530,7 +3206,7 @@ func TestCopyErrorCases(t *testing.T) {
 		{"bad dst", reflect.ValueOf(map[string]string{}), reflect.ValueOf(uint32(42)), "received a non-map type in dst map field: uint32"},
 	}
 	for _, tt := range mapErrs {
-		if err := copyMapField(tt.inDst, tt.inSrc); err == nil || err.Error() != tt.wantErr {
+		if err := copyMapField(tt.inDst, tt.inSrc, 0); err == nil || err.Error() != tt.wantErr {
 			t.Errorf("%s: copyMapField(%v, %v): did not get expected error, got: %v, want: %v", tt.name, tt.inSrc, tt.inDst, err, tt.wantErr)
 		}
 	}
@@ -2539,7 +3215,7 @@ func TestCopyErrorCases(t *testing.T) {
 		{"non-ptr", reflect.ValueOf(""), reflect.ValueOf(""), "received non-ptr type: string"},
 	}
 	for _, tt := range ptrErrs {
-		if err := copyPtrField(tt.inDst, tt.inSrc); err == nil || err.Error() != tt.wantErr {
+		if err := copyPtrField(tt.inDst, tt.inSrc, 0); err == nil || err.Error() != tt.wantErr {
 			t.Errorf("%s: copyPtrField(%v, %v): did not get expected error, got: %v, want: %v", tt.name, tt.inSrc, tt.inDst, err, tt.wantErr)
 		}
 	}
@@ -2627,6 +3303,469 @@ func TestDeepCopy(t *testing.T) {
 	}
 }
 
+// copyTestWithHooks is a GoStruct which stores validation hooks as embedded
+// metadata.
+type copyTestWithHooks struct {
+	StringField         *string
+	ΛValidateFieldHooks map[string]func(interface{}) error `ygotMetadata:"true"`
+}
+
+func (*copyTestWithHooks) IsYANGGoStruct()                         {}
+func (*copyTestWithHooks) ΛValidate(...ValidationOption) error     { return nil }
+func (*copyTestWithHooks) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*copyTestWithHooks) ΛBelongingModule() string                { return "" }
+
+func TestDeepCopyValidationHooks(t *testing.T) {
+	in := &copyTestWithHooks{
+		StringField: String("eth0"),
+		ΛValidateFieldHooks: map[string]func(interface{}) error{
+			"StringField": func(interface{}) error { return fmt.Errorf("rejected") },
+		},
+	}
+	got, err := DeepCopy(in)
+	if err != nil {
+		t.Fatalf("DeepCopy: got unexpected error: %v", err)
+	}
+	gotC := got.(*copyTestWithHooks)
+	if gotC.StringField == in.StringField || *gotC.StringField != "eth0" {
+		t.Errorf("DeepCopy: did not get expected copy of StringField, got: %v", gotC.StringField)
+	}
+	hook, ok := gotC.ΛValidateFieldHooks["StringField"]
+	if !ok {
+		t.Fatalf("DeepCopy: did not copy validation hooks, got: %v", gotC.ΛValidateFieldHooks)
+	}
+	if err := hook(*gotC.StringField); err == nil || err.Error() != "rejected" {
+		t.Errorf("DeepCopy: copied hook returned unexpected error, got: %v, want: rejected", err)
+	}
+}
+
+type copyTestWithPresence struct {
+	StringField *string
+	ΛPresent    map[string]bool `ygotMetadata:"true"`
+}
+
+func (*copyTestWithPresence) IsYANGGoStruct()                         {}
+func (*copyTestWithPresence) ΛValidate(...ValidationOption) error     { return nil }
+func (*copyTestWithPresence) ΛEnumTypeMap() map[string][]reflect.Type { return nil }
+func (*copyTestWithPresence) ΛBelongingModule() string                { return "" }
+
+func TestDeepCopyPresence(t *testing.T) {
+	in := &copyTestWithPresence{
+		StringField: String("eth0"),
+		ΛPresent:    map[string]bool{"StringField": true},
+	}
+	got, err := DeepCopy(in)
+	if err != nil {
+		t.Fatalf("DeepCopy: got unexpected error: %v", err)
+	}
+	gotC := got.(*copyTestWithPresence)
+	if !gotC.ΛPresent["StringField"] {
+		t.Fatalf("DeepCopy: did not copy presence map, got: %v", gotC.ΛPresent)
+	}
+	gotC.ΛPresent["OtherField"] = true
+	if in.ΛPresent["OtherField"] {
+		t.Errorf("DeepCopy: modifying copied presence map modified source, got: %v", in.ΛPresent)
+	}
+}
+
+func TestCopyOrderedList(t *testing.T) {
+	newStruct := func(keys ...string) *orderedListStruct {
+		s := &orderedListStruct{List: &orderedListMap{}}
+		for _, k := range keys {
+			s.List.append(k, "val-"+k)
+		}
+		return s
+	}
+
+	c, err := DeepCopy(newStruct("b", "a", "c"))
+	if err != nil {
+		t.Fatalf("DeepCopy: got unexpected error: %v", err)
+	}
+	got := c.(*orderedListStruct)
+	if diff := cmp.Diff(newStruct("b", "a", "c"), got, cmp.AllowUnexported(orderedListMap{})); diff != "" {
+		t.Errorf("DeepCopy: did not get expected copy, diff(-want, +got):\n%s", diff)
+	}
+
+	a := newStruct("b", "a")
+	b := newStruct("a", "d")
+	b.List.valueMap["a"].Value = nil
+	m, err := MergeStructs(a, b)
+	if err != nil {
+		t.Fatalf("MergeStructs: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(newStruct("b", "a", "d"), m, cmp.AllowUnexported(orderedListMap{})); diff != "" {
+		t.Errorf("MergeStructs: did not get expected struct, diff(-want, +got):\n%s", diff)
+	}
+	if m.(*orderedListStruct).List.valueMap["a"] == a.List.valueMap["a"] {
+		t.Errorf("MergeStructs: merged member is shared with the input")
+	}
+
+	b.List.valueMap["a"].Value = String("other")
+	if _, err := MergeStructs(a, b); err == nil {
+		t.Errorf("MergeStructs: did not get expected error for conflicting member")
+	}
+}
+
+func TestMergeStructsPresence(t *testing.T) {
+	a := &copyTestWithPresence{
+		StringField: String("eth0"),
+		ΛPresent:    map[string]bool{"StringField": true, "Description": true},
+	}
+	b := &copyTestWithPresence{
+		ΛPresent: map[string]bool{"Description": false, "Enabled": true},
+	}
+
+	got, err := MergeStructs(a, b)
+	if err != nil {
+		t.Fatalf("MergeStructs: got unexpected error: %v", err)
+	}
+	want := &copyTestWithPresence{
+		StringField: String("eth0"),
+		ΛPresent:    map[string]bool{"StringField": true, "Description": false, "Enabled": true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MergeStructs: did not get expected merged struct, diff(-want, +got):\n%s", diff)
+	}
+
+	got.(*copyTestWithPresence).ΛPresent["Other"] = true
+	if a.ΛPresent["Other"] || b.ΛPresent["Other"] {
+		t.Errorf("MergeStructs: modifying merged presence map modified inputs, got: %v, %v", a.ΛPresent, b.ΛPresent)
+	}
+
+	if err := MergeStructInto(a, b); err != nil {
+		t.Fatalf("MergeStructInto: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, a); diff != "" {
+		t.Errorf("MergeStructInto: did not get expected merged struct, diff(-want, +got):\n%s", diff)
+	}
+}
+
+func TestDeepCopyInto(t *testing.T) {
+	tests := []struct {
+		name             string
+		inDst            GoStruct
+		inSrc            GoStruct
+		wantErrSubstring string
+	}{{
+		name:  "populated struct into empty struct",
+		inDst: &copyTest{},
+		inSrc: populatedCopyTest(),
+	}, {
+		name: "populated struct into previously populated struct",
+		inDst: &copyTest{
+			StringField:   String("arthur"),
+			StructPointer: &copyTest{Uint32Field: Uint32(42), StringSlice: []string{"towel"}},
+			UnionField:    &copyUnionI{42},
+			StringSlice:   []string{"one", "two", "three", "four"},
+			StringMap: map[string]*copyTest{
+				"marvin": {Uint16Field: Uint16(1), StructPointer: &copyTest{}},
+				"zaphod": {StringField: String("beeblebrox")},
+			},
+			StructSlice: []*copyTest{{StringField: String("eddie")}, {StringField: String("deep-thought")}},
+		},
+		inSrc: populatedCopyTest(),
+	}, {
+		name:  "empty struct into populated struct",
+		inDst: populatedCopyTest(),
+		inSrc: &copyTest{},
+	}, {
+		name:             "nil dst",
+		inSrc:            &copyTest{},
+		wantErrSubstring: "got nil value",
+	}, {
+		name:             "mismatched types",
+		inDst:            &copyTest{},
+		inSrc:            &copyTestWithPresence{},
+		wantErrSubstring: "not of matching types",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DeepCopyInto(tt.inDst, tt.inSrc)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("DeepCopyInto: did not get expected error, %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.inSrc, tt.inDst); diff != "" {
+				t.Errorf("DeepCopyInto: did not get identical copy, diff(-src, +dst):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeepCopyAnnotationContainer(t *testing.T) {
+	c := &testAnnotationContainer{
+		annotations: []Annotation{&testAnnotation{AnnotationFieldOne: "alexander-valley"}},
+	}
+	in := &annotationContainerJSONTestStruct{
+		Field:  String("russian-river"),
+		ΛField: c,
+	}
+	got, err := DeepCopy(in)
+	if err != nil {
+		t.Fatalf("DeepCopy: got unexpected error: %v", err)
+	}
+	gotC := got.(*annotationContainerJSONTestStruct)
+	if gotC.Field == in.Field || *gotC.Field != "russian-river" {
+		t.Errorf("DeepCopy: did not get expected copy of Field, got: %v", gotC.Field)
+	}
+	if gotC.ΛField != c {
+		t.Errorf("DeepCopy: did not copy annotation container by reference, got: %v, want: %v", gotC.ΛField, c)
+	}
+
+	dst := &annotationContainerJSONTestStruct{ΛMetadata: &testAnnotationContainer{}}
+	if err := DeepCopyInto(dst, in); err != nil {
+		t.Fatalf("DeepCopyInto: got unexpected error: %v", err)
+	}
+	if dst.ΛField != c || dst.ΛMetadata != nil {
+		t.Errorf("DeepCopyInto: did not copy annotation containers by reference, got: %v", dst)
+	}
+
+	if err := Reset(dst, &ResetRecursive{}); err != nil {
+		t.Fatalf("Reset: got unexpected error: %v", err)
+	}
+	if dst.ΛField != nil {
+		t.Errorf("Reset: did not clear annotation container, got: %v", dst.ΛField)
+	}
+}
+
+func TestDeepCopyIntoIndependent(t *testing.T) {
+	src := populatedCopyTest()
+	src.UnionField = &copyUnionI{42}
+	dst := &copyTest{
+		StructPointer: &copyTest{StringField: String("arthur")},
+		StringMap: map[string]*copyTest{
+			"marvin": {StringField: String("robot")},
+		},
+		StructSlice: make([]*copyTest, 1, 4),
+	}
+	dst.StructSlice[0] = &copyTest{}
+	wantStructPtr, wantMapEntry, wantSliceElem := dst.StructPointer, dst.StringMap["marvin"], dst.StructSlice[0]
+
+	if err := DeepCopyInto(dst, src); err != nil {
+		t.Fatalf("DeepCopyInto: got unexpected error: %v", err)
+	}
+
+	// Check that the children that were allocated in dst are reused.
+	if dst.StructPointer != wantStructPtr {
+		t.Errorf("DeepCopyInto: did not reuse struct pointer of dst")
+	}
+	if dst.StringMap["marvin"] != wantMapEntry {
+		t.Errorf("DeepCopyInto: did not reuse map entry of dst")
+	}
+	if dst.StructSlice[0] != wantSliceElem {
+		t.Errorf("DeepCopyInto: did not reuse slice element of dst")
+	}
+
+	// Check that modifying dst does not modify src, and vice versa.
+	*dst.StringField = "ford"
+	dst.StructPointer.StringField = String("prefect")
+	dst.UnionField.(*copyUnionI).I = 84
+	dst.StringSlice[0] = "zero"
+	dst.StringMap["marvin"].StringField = String("depressed")
+	dst.StructMap[copyMapKey{A: "trillian"}].Uint32Field = Uint32(2)
+	dst.StructSlice[0].StringField = String("dent")
+	want := populatedCopyTest()
+	want.UnionField = &copyUnionI{42}
+	if diff := cmp.Diff(want, src); diff != "" {
+		t.Errorf("DeepCopyInto: modifying dst modified src, diff(-want, +got):\n%s", diff)
+	}
+
+	*src.Uint32Field = 1
+	src.StringMap["marvin"].StringField = String("android")
+	if *dst.Uint32Field != 42 || *dst.StringMap["marvin"].StringField != "depressed" {
+		t.Errorf("DeepCopyInto: modifying src modified dst, got: %v", dst)
+	}
+}
+
+func TestDeepCopyIntoOrderedList(t *testing.T) {
+	src := &orderedListStruct{List: &orderedListMap{}}
+	for _, k := range []string{"b", "a", "c"} {
+		src.List.append(k, "val-"+k)
+	}
+	dst := &orderedListStruct{List: &orderedListMap{}}
+	for _, k := range []string{"a", "d"} {
+		dst.List.append(k, "old-"+k)
+	}
+	wantMember := dst.List.valueMap["a"]
+
+	if err := DeepCopyInto(dst, src); err != nil {
+		t.Fatalf("DeepCopyInto: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(src, dst, cmp.AllowUnexported(orderedListMap{})); diff != "" {
+		t.Errorf("DeepCopyInto: did not get identical copy, diff(-src, +dst):\n%s", diff)
+	}
+	if dst.List.valueMap["a"] != wantMember {
+		t.Errorf("DeepCopyInto: did not reuse member of dst")
+	}
+	if dst.List == src.List || dst.List.valueMap["b"] == src.List.valueMap["b"] {
+		t.Errorf("DeepCopyInto: dst shares ordered list with src after copy")
+	}
+
+	// A shallow copy of src shares its ordered list, which is replaced.
+	shallow := &orderedListStruct{}
+	*shallow = *src
+	if err := DeepCopyInto(shallow, src); err != nil {
+		t.Fatalf("DeepCopyInto: got unexpected error for shared ordered list: %v", err)
+	}
+	if shallow.List == src.List || shallow.List.valueMap["a"] == src.List.valueMap["a"] {
+		t.Errorf("DeepCopyInto: shallow copy shares ordered list with src after copy")
+	}
+}
+
+func TestDeepCopyIntoSharedChildren(t *testing.T) {
+	src := populatedCopyTest()
+	// dst is a shallow copy of src, such that they share their children.
+	dst := &copyTest{}
+	*dst = *src
+
+	if err := DeepCopyInto(dst, src); err != nil {
+		t.Fatalf("DeepCopyInto: got unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(src, dst); diff != "" {
+		t.Errorf("DeepCopyInto: did not get identical copy, diff(-src, +dst):\n%s", diff)
+	}
+	if dst.StringField == src.StringField || dst.StructPointer == src.StructPointer || dst.StructSlice[0] == src.StructSlice[0] {
+		t.Errorf("DeepCopyInto: dst shares children with src after copy")
+	}
+	dst.StringSlice[0] = "zero"
+	dst.StringMap["arthur"] = &copyTest{}
+	if src.StringSlice[0] != "one" || src.StringMap["arthur"] != nil {
+		t.Errorf("DeepCopyInto: modifying dst modified src, got: %v", src)
+	}
+
+	if err := DeepCopyInto(src, src); err == nil {
+		t.Errorf("DeepCopyInto: did not get expected error when copying struct into itself")
+	}
+}
+
+// populatedCopyTest returns a copyTest struct with each of its fields
+// populated.
+func populatedCopyTest() *copyTest {
+	return &copyTest{
+		StringField:   String("zaphod"),
+		Uint32Field:   Uint32(42),
+		Uint16Field:   Uint16(16),
+		Float64Field:  Float64(4.2),
+		StructPointer: &copyTest{StringField: String("ford")},
+		EnumValue:     EnumTypeValue,
+		UnionField:    &copyUnionS{"arthur"},
+		StringSlice:   []string{"one", "two"},
+		StringMap: map[string]*copyTest{
+			"marvin": {StringField: String("paranoid")},
+		},
+		StructMap: map[copyMapKey]*copyTest{
+			{A: "trillian"}: {Uint32Field: Uint32(1)},
+		},
+		StructSlice: []*copyTest{{StringField: String("slartibartfast")}},
+	}
+}
+
+func TestReset(t *testing.T) {
+	tests := []struct {
+		name             string
+		in               GoStruct
+		inOpts           []ResetOpt
+		want             GoStruct
+		wantErrSubstring string
+	}{{
+		name: "populated struct",
+		in:   populatedCopyTest(),
+		want: &copyTest{},
+	}, {
+		name: "empty struct",
+		in:   &copyTest{},
+		want: &copyTest{},
+	}, {
+		name:   "populated struct, recursive",
+		in:     populatedCopyTest(),
+		inOpts: []ResetOpt{&ResetRecursive{}},
+		want:   &copyTest{},
+	}, {
+		name:   "empty struct, recursive",
+		in:     &copyTest{},
+		inOpts: []ResetOpt{&ResetRecursive{}},
+		want:   &copyTest{},
+	}, {
+		name:             "nil input",
+		in:               (*copyTest)(nil),
+		wantErrSubstring: "got nil value",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Reset(tt.in, tt.inOpts...)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("Reset(%#v): did not get expected error, %s", tt.in, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.in); diff != "" {
+				t.Errorf("Reset(%#v): did not get expected struct, diff(-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestResetRecursiveResetsChildren(t *testing.T) {
+	in := populatedCopyTest()
+	child, mapMember, sliceMember := in.StructPointer, in.StringMap["marvin"], in.StructSlice[0]
+
+	if err := Reset(in, &ResetRecursive{}); err != nil {
+		t.Fatalf("Reset(%#v): got unexpected error: %v", in, err)
+	}
+
+	for name, got := range map[string]*copyTest{
+		"struct pointer": child,
+		"map member":     mapMember,
+		"slice member":   sliceMember,
+	} {
+		if diff := cmp.Diff(&copyTest{}, got); diff != "" {
+			t.Errorf("Reset(%#v): %s was not reset in place, diff(-want, +got):\n%s", in, name, diff)
+		}
+	}
+}
+
+func TestResetEmitJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		inOpts []ResetOpt
+	}{{
+		name: "top-level",
+	}, {
+		name:   "recursive",
+		inOpts: []ResetOpt{&ResetRecursive{}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := &mapStructTestOne{
+				Child: &mapStructTestOneChild{
+					FieldOne:   String("hello"),
+					FieldThree: Binary{0x01, 0x02},
+					FieldFour:  []Binary{{0x03}, {0x04}},
+				},
+			}
+			if err := Reset(in, tt.inOpts...); err != nil {
+				t.Fatalf("Reset(%#v): got unexpected error: %v", in, err)
+			}
+
+			for _, format := range []JSONFormat{Internal, RFC7951} {
+				got, err := EmitJSON(in, &EmitJSONConfig{Format: format, SkipValidation: true})
+				if err != nil {
+					t.Fatalf("EmitJSON(%#v, %v): got unexpected error: %v", in, format, err)
+				}
+				if got != "{}" {
+					t.Errorf("EmitJSON(%#v, %v): did not get empty JSON after Reset, got: %s", in, format, got)
+				}
+			}
+		})
+	}
+}
+
 type buildEmptyTreeMergeTest struct {
 	Son      *buildEmptyTreeMergeTestChild
 	Daughter *buildEmptyTreeMergeTestChild
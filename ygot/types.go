@@ -15,6 +15,7 @@
 package ygot
 
 import (
+	"encoding/json"
 	"reflect"
 )
 
@@ -88,6 +89,40 @@ type KeyHelperGoStruct interface {
 	ΛListKeyMap() (map[string]interface{}, error)
 }
 
+// PopulatedChildrenGoStruct is an interface which can be implemented by Go
+// structs that are generated with a method that returns the schema paths of
+// their populated children without the use of reflection.
+type PopulatedChildrenGoStruct interface {
+	// GoStruct ensures that the interface for a standard GoStruct
+	// is embedded.
+	GoStruct
+	// ΛPopulatedChildren returns the schema paths, relative to the
+	// struct, of its populated children, as returned by
+	// PopulatedChildren.
+	ΛPopulatedChildren() []string
+}
+
+// GoOrderedList is an interface which is implemented by the types that are
+// generated to represent keyed YANG lists that are "ordered-by user", when
+// ordered list support is enabled in ygen. Such types store the members of
+// the list in the order in which they were appended, rather than in a map,
+// such that the order can be preserved when the list is emitted.
+type GoOrderedList interface {
+	// IsYANGOrderedList is a marker method that indicates that the type
+	// implements the GoOrderedList interface.
+	IsYANGOrderedList()
+	// Len returns the number of members of the list.
+	Len() int
+	// ΛKeys returns the keys of the members of the list, in order. Each
+	// key is of the type that is used to key the list.
+	ΛKeys() []interface{}
+	// ΛValues returns the members of the list, in order.
+	ΛValues() []GoStruct
+}
+
+// goOrderedListType is the reflect.Type of the GoOrderedList interface.
+var goOrderedListType = reflect.TypeOf((*GoOrderedList)(nil)).Elem()
+
 // GoEnum is an interface which can be implemented by derived types which
 // represent an enumerated value within a YANG schema. This allows handling
 // code that finds struct fields that implement this interface to do specific
@@ -106,6 +141,30 @@ type GoEnum interface {
 	String() string
 }
 
+// Base64Marshaler is an interface which is implemented by types, defined
+// outside of the generated code, that are used for YANG binary fields in
+// place of the generated type named by BinaryTypeName. Such types must be
+// defined as a []byte. The string returned by MarshalBase64 is used as the
+// JSON representation of the field, and hence must be the base64 encoding
+// of the value.
+type Base64Marshaler interface {
+	MarshalBase64() (string, error)
+}
+
+// base64MarshalerType is the reflect.Type of the Base64Marshaler interface.
+var base64MarshalerType = reflect.TypeOf((*Base64Marshaler)(nil)).Elem()
+
+// IsBinaryType reports whether t is a type that is used for YANG binary
+// fields, which is either the generated type named by BinaryTypeName, or a
+// type that implements Base64Marshaler. In both cases, the type must be
+// defined as a []byte.
+func IsBinaryType(t reflect.Type) bool {
+	if t == nil || t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Uint8 {
+		return false
+	}
+	return t.Name() == BinaryTypeName || t.Implements(base64MarshalerType)
+}
+
 // EnumDefinition is used to store the details of an enumerated value. All YANG
 // enumerated values (enumeration, identityref) has a Name which represents the
 // string name used for the enumerated value in the YANG module (which may not
@@ -148,3 +207,44 @@ type Annotation interface {
 	// the json.Unmarshaler interface is implemented.
 	UnmarshalJSON([]byte) error
 }
+
+// AnnotationContainer is an interface implemented by user-defined types that
+// are used in place of []Annotation for the annotation fields of generated
+// GoStructs, such that frameworks with richer annotation models can store
+// them alongside the data tree. The contents of an AnnotationContainer are
+// opaque to ygot - they are not walked as schema nodes, and are copied by
+// reference when a GoStruct is copied or merged.
+type AnnotationContainer interface {
+	// Annotations returns the annotations that are stored within the
+	// container, which are rendered when the GoStruct containing it is
+	// marshalled to JSON.
+	Annotations() []Annotation
+}
+
+// annotationContainerType is the reflect.Type of the AnnotationContainer
+// interface.
+var annotationContainerType = reflect.TypeOf((*AnnotationContainer)(nil)).Elem()
+
+// MetadataAnnotation is an Annotation that stores RFC7952 metadata for the
+// data node that it is attached to. Values is keyed by the name of each
+// metadata annotation, qualified by the name of the module that defines it,
+// e.g., "ietf-origin:origin". It is marshalled to a JSON object, which is
+// emitted as the "@"-prefixed sibling of the data node when the
+// EmitMetadata option of EmitJSON is set.
+type MetadataAnnotation struct {
+	Values map[string]interface{}
+}
+
+// MarshalJSON marshals the metadata stored within the MetadataAnnotation to
+// a JSON object.
+func (m *MetadataAnnotation) MarshalJSON() ([]byte, error) {
+	if m.Values == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m.Values)
+}
+
+// UnmarshalJSON unmarshals the JSON object b into the MetadataAnnotation.
+func (m *MetadataAnnotation) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &m.Values)
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// UnmarshalJSONStream decodes the RFC7951 JSON document read from r into the
+// GoStruct dst, which is described by the schema supplied. Unlike Unmarshal
+// functions that operate on a byte slice, the document is decoded token by
+// token, such that only the value of a single leaf or leaf-list is held in
+// memory at any one time, along with the list member that is being decoded.
+//
+// The schema is used to determine the types of the fields of dst, such that
+// enumerated, union, leaf-list and binary values are decoded according to
+// their RFC7951 encoding. Member names may optionally be qualified with the
+// name of their module. Metadata members, whose names are prefixed with "@",
+// are skipped. Values within the document overwrite those that are already
+// set within dst, and list members within the document are merged into any
+// existing members with the same key.
+func UnmarshalJSONStream(schema *yang.Entry, r io.Reader, dst GoStruct) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema supplied for struct %T", dst)
+	}
+	if util.IsValueNil(dst) {
+		return fmt.Errorf("nil destination struct supplied")
+	}
+	dv := reflect.ValueOf(dst)
+	if !util.IsValueStructPtr(dv) {
+		return fmt.Errorf("destination %T is not a struct pointer", dst)
+	}
+
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	if err := decodeJSONStreamObject(d, schema, dv, schema, nil); err != nil {
+		return err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data following JSON object")
+	}
+	return nil
+}
+
+// decodeJSONStreamObject decodes the JSON object that is the next value read
+// from d, and describes the data tree node described by nodeSchema, into the
+// struct pointer sv, which is described by schema. The path of the node
+// relative to sv is specified by prefix, which is non-empty where the node is
+// a container that is not represented by a struct, as is the case for
+// containers that are removed from compressed schemas.
+func decodeJSONStreamObject(d *json.Decoder, schema *yang.Entry, sv reflect.Value, nodeSchema *yang.Entry, prefix []*gnmipb.PathElem) error {
+	if err := expectJSONDelim(d, '{'); err != nil {
+		return fmt.Errorf("invalid value for %s: %v", nodeSchema.Name, err)
+	}
+
+	for d.More() {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		k, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("invalid member name %v in %s", tok, nodeSchema.Name)
+		}
+		if strings.HasPrefix(k, "@") {
+			if err := skipJSONValue(d); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := util.StripModulePrefix(k)
+		cs := util.FirstChild(nodeSchema, []string{name})
+		if cs == nil {
+			return fmt.Errorf("cannot find schema for %s in %s", name, nodeSchema.Name)
+		}
+		cp := appendPathElem(prefix, &gnmipb.PathElem{Name: name})
+
+		switch {
+		case isLeafSchema(cs):
+			err = decodeJSONStreamLeaf(d, schema, sv, cs, cp)
+		case cs.IsList():
+			err = decodeJSONStreamList(d, schema, sv, cs, cp)
+		default:
+			err = decodeJSONStreamContainer(d, schema, sv, cs, cp)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := d.Token()
+	return err
+}
+
+// decodeJSONStreamLeaf decodes the value of the leaf or leaf-list described
+// by leafSchema that is the next value read from d into the field of the
+// struct pointer sv, described by schema, at path.
+func decodeJSONStreamLeaf(d *json.Decoder, schema *yang.Entry, sv reflect.Value, leafSchema *yang.Entry, path []*gnmipb.PathElem) error {
+	var j interface{}
+	if err := d.Decode(&j); err != nil {
+		return err
+	}
+	vals, err := jsonLexical(j, isBinarySchema(leafSchema), leafSchema.IsLeafList())
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %v", leafSchema.Name, err)
+	}
+
+	ts, err := resolveNotificationPath(schema, sv, path, true)
+	if err != nil {
+		return err
+	}
+	if len(ts) != 1 {
+		return fmt.Errorf("path %s does not address a leaf", pathElemString(path))
+	}
+	if err := setNotificationLeaf(ts[0], vals, true); err != nil {
+		return fmt.Errorf("%s: %v", pathElemString(path), err)
+	}
+	return nil
+}
+
+// decodeJSONStreamContainer decodes the container described by cs that is
+// the next value read from d. If the container is represented by a field of
+// the struct pointer sv, described by schema, its contents are decoded into
+// the field, otherwise they are decoded into sv.
+func decodeJSONStreamContainer(d *json.Decoder, schema *yang.Entry, sv reflect.Value, cs *yang.Entry, path []*gnmipb.PathElem) error {
+	ts, err := resolveNotificationPath(schema, sv, path, true)
+	if err != nil {
+		return err
+	}
+	if len(ts) == 1 && util.IsTypeStructPtr(ts[0].field.Type()) && ts[0].schema.Path() == cs.Path() {
+		fv := ts[0].field
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		if err := decodeJSONStreamObject(d, cs, fv, cs, nil); err != nil {
+			return err
+		}
+		ts[0].store()
+		return nil
+	}
+	return decodeJSONStreamObject(d, schema, sv, cs, path)
+}
+
+// decodeJSONStreamList decodes the members of the list described by cs that
+// is the next value read from d into the field of the struct pointer sv,
+// described by schema, at path. Each member is decoded into a new struct
+// prior to being added to the list, since the keys of the member are not
+// known until it has been decoded.
+func decodeJSONStreamList(d *json.Decoder, schema *yang.Entry, sv reflect.Value, cs *yang.Entry, path []*gnmipb.PathElem) error {
+	ts, err := resolveNotificationPath(schema, sv, path, true)
+	if err != nil {
+		return err
+	}
+	if len(ts) != 1 {
+		return fmt.Errorf("path %s does not address a list", pathElemString(path))
+	}
+	fv := ts[0].field
+	ft := fv.Type()
+	if !util.IsTypeMap(ft) && !util.IsTypeSlicePtr(ft) {
+		return fmt.Errorf("field for list %s has invalid type %v", cs.Name, ft)
+	}
+
+	if err := expectJSONDelim(d, '['); err != nil {
+		return fmt.Errorf("invalid value for list %s: %v", cs.Name, err)
+	}
+	// The members of keyed lists may be stored by value within the map.
+	valueMembers := util.IsTypeMap(ft) && util.IsTypeStruct(ft.Elem())
+	for d.More() {
+		var nv reflect.Value
+		if util.IsTypeMap(ft) {
+			nv = reflect.New(listMemberType(ft))
+		} else {
+			nv = reflect.New(ft.Elem().Elem())
+		}
+		if err := decodeJSONStreamObject(d, cs, nv, cs, nil); err != nil {
+			return err
+		}
+
+		if util.IsTypeSlicePtr(ft) {
+			fv.Set(reflect.Append(fv, nv))
+			continue
+		}
+
+		k, err := notificationListKey(cs, ft.Key(), nv)
+		if err != nil {
+			return err
+		}
+		if fv.IsNil() {
+			fv.Set(reflect.MakeMap(ft))
+		}
+		if mv := fv.MapIndex(k); mv.IsValid() {
+			mv = util.ListMemberPtr(mv)
+			if err := copyStruct(mv.Elem(), nv.Elem(), 0, &MergeOverwriteExistingFields{}); err != nil {
+				return err
+			}
+			fv.SetMapIndex(k, listMemberValue(mv, valueMembers))
+			continue
+		}
+		fv.SetMapIndex(k, listMemberValue(nv, valueMembers))
+	}
+	ts[0].store()
+
+	_, err = d.Token()
+	return err
+}
+
+// expectJSONDelim reads the next token from d, and returns an error if it is
+// not the delimiter want.
+func expectJSONDelim(d *json.Decoder, want json.Delim) error {
+	tok, err := d.Token()
+	if err != nil {
+		return err
+	}
+	if got, ok := tok.(json.Delim); !ok || got != want {
+		return fmt.Errorf("got %v, want %v", tok, want)
+	}
+	return nil
+}
+
+// skipJSONValue reads and discards the next value from d.
+func skipJSONValue(d *json.Decoder) error {
+	var j json.RawMessage
+	return d.Decode(&j)
+}
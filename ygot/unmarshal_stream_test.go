@@ -0,0 +1,384 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func TestUnmarshalJSONStream(t *testing.T) {
+	testOneSchema := &yang.Entry{
+		Name: "test-one",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"child": {
+				Name: "child",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"config": {
+						Name: "config",
+						Kind: yang.DirectoryEntry,
+						Dir: map[string]*yang.Entry{
+							"field-one": {
+								Name: "field-one",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring},
+							},
+							"field-two": {
+								Name: "field-two",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yuint32},
+							},
+							"field-three": {
+								Name: "field-three",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ybinary},
+							},
+							"field-four": {
+								Name:     "field-four",
+								Kind:     yang.LeafEntry,
+								ListAttr: yang.NewDefaultListAttr(),
+								Type:     &yang.YangType{Kind: yang.Ybinary},
+							},
+							"field-five": {
+								Name: "field-five",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yuint64},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(testOneSchema)
+
+	testFourSchema := &yang.Entry{
+		Name: "test-four",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"c": {
+				Name: "c",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"acl-set": {
+						Name:     "acl-set",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "name",
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Ystring},
+							},
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name": {
+										Name: "name",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+									"second-value": {
+										Name: "second-value",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+								},
+							},
+						},
+					},
+					"other-set": {
+						Name:     "other-set",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "name",
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yenum},
+							},
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name": {
+										Name: "name",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Yenum},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(testFourSchema)
+
+	multiKeyEntryLeaves := func() map[string]*yang.Entry {
+		return map[string]*yang.Entry{
+			"name": {
+				Name: "name",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			"index": {
+				Name: "index",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Yuint32},
+			},
+		}
+	}
+	multiKeyEntry := &yang.Entry{
+		Name:     "entry",
+		Kind:     yang.DirectoryEntry,
+		ListAttr: yang.NewDefaultListAttr(),
+		Key:      "name index",
+		Dir:      multiKeyEntryLeaves(),
+	}
+	multiKeyEntry.Dir["config"] = &yang.Entry{
+		Name: "config",
+		Kind: yang.DirectoryEntry,
+		Dir:  multiKeyEntryLeaves(),
+	}
+	multiKeySchema := &yang.Entry{
+		Name: "multi-key",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"entries": {
+				Name: "entries",
+				Kind: yang.DirectoryEntry,
+				Dir:  map[string]*yang.Entry{"entry": multiKeyEntry},
+			},
+		},
+	}
+	addParents(multiKeySchema)
+
+	unionType := &yang.YangType{
+		Kind: yang.Yunion,
+		Type: []*yang.YangType{
+			{Kind: yang.Yenum},
+			{Kind: yang.Yuint32},
+		},
+	}
+	defaultsSchema := &yang.Entry{
+		Name: "root",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"enum": {
+				Name: "enum",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{Kind: yang.Yenum},
+			},
+			"union": {
+				Name: "union",
+				Kind: yang.LeafEntry,
+				Type: unionType,
+			},
+			"union-int": {
+				Name: "union-int",
+				Kind: yang.LeafEntry,
+				Type: unionType,
+			},
+		},
+	}
+	addParents(defaultsSchema)
+
+	tests := []struct {
+		name             string
+		inSchema         *yang.Entry
+		inJSONPath       string
+		inJSON           string
+		inStruct         GoStruct
+		want             GoStruct
+		wantErrSubstring string
+	}{{
+		name:       "simple schema JSON",
+		inSchema:   testOneSchema,
+		inJSONPath: filepath.Join(TestRoot, "testdata/emitjson_1.json-txt"),
+		inStruct:   &mapStructTestOne{},
+		want: &mapStructTestOne{
+			Child: &mapStructTestOneChild{
+				FieldOne: String("abc -> def"),
+				FieldTwo: Uint32(42),
+			},
+		},
+	}, {
+		name:       "simple schema IETF JSON with module names",
+		inSchema:   testOneSchema,
+		inJSONPath: filepath.Join(TestRoot, "testdata/emitjson1_ietf.json-txt"),
+		inStruct:   &mapStructTestOne{},
+		want: &mapStructTestOne{
+			Child: &mapStructTestOneChild{
+				FieldOne:  String("bar"),
+				FieldTwo:  Uint32(84),
+				FieldFive: Uint64(42),
+			},
+		},
+	}, {
+		name:       "schema with list and enum IETF JSON",
+		inSchema:   testFourSchema,
+		inJSONPath: filepath.Join(TestRoot, "testdata/emitjson2_ietf.json-txt"),
+		inStruct:   &mapStructTestFour{},
+		want: &mapStructTestFour{
+			C: &mapStructTestFourC{
+				ACLSet: map[string]*mapStructTestFourCACLSet{
+					"n42": {Name: String("n42"), SecondValue: String("foo")},
+				},
+				OtherSet: map[ECTest]*mapStructTestFourCOtherSet{
+					ECTestVALONE: {Name: ECTestVALONE},
+					ECTestVALTWO: {Name: ECTestVALTWO},
+				},
+			},
+		},
+	}, {
+		name:       "multi-keyed list IETF JSON",
+		inSchema:   multiKeySchema,
+		inJSONPath: filepath.Join(TestRoot, "testdata/emitjson_multikey_ietf.json-txt"),
+		inStruct:   &mapStructTestMultiKey{},
+		want: &mapStructTestMultiKey{
+			Entry: map[mapStructTestMultiKeyKey]*mapStructTestMultiKeyEntry{
+				{Name: "b", Index: 1}:  {Name: String("b"), Index: Uint32(1)},
+				{Name: "a", Index: 10}: {Name: String("a"), Index: Uint32(10)},
+				{Name: "a", Index: 2}:  {Name: String("a"), Index: Uint32(2)},
+			},
+		},
+	}, {
+		name:     "binary leaf and leaf-list",
+		inSchema: testOneSchema,
+		inJSON:   `{"test-one:child": {"config": {"field-three": "Kg==", "field-four": ["AQI=", "Aw=="]}}}`,
+		inStruct: &mapStructTestOne{},
+		want: &mapStructTestOne{
+			Child: &mapStructTestOneChild{
+				FieldThree: Binary{42},
+				FieldFour:  []Binary{{1, 2}, {3}},
+			},
+		},
+	}, {
+		name:     "list members merged with existing members",
+		inSchema: testFourSchema,
+		inJSON:   `{"c": {"acl-set": [{"config": {"second-value": "new"}, "name": "n42"}]}}`,
+		inStruct: &mapStructTestFour{
+			C: &mapStructTestFourC{
+				ACLSet: map[string]*mapStructTestFourCACLSet{
+					"n42": {Name: String("n42"), SecondValue: String("old")},
+					"n84": {Name: String("n84")},
+				},
+			},
+		},
+		want: &mapStructTestFour{
+			C: &mapStructTestFourC{
+				ACLSet: map[string]*mapStructTestFourCACLSet{
+					"n42": {Name: String("n42"), SecondValue: String("new")},
+					"n84": {Name: String("n84")},
+				},
+			},
+		},
+	}, {
+		name:     "enumerations and unions",
+		inSchema: defaultsSchema,
+		inJSON:   `{"enum": "mod:TWO", "union": "ONE", "union-int": 128, "@enum": {"ietf-origin:origin": "intended"}}`,
+		inStruct: &defaultsRoot{},
+		want: &defaultsRoot{
+			Enum:     2,
+			Union:    leaflistDefaultThree(1),
+			UnionInt: defaultsUnionUint32(128),
+		},
+	}, {
+		name:             "invalid enumeration value",
+		inSchema:         defaultsSchema,
+		inJSON:           `{"enum": "FORTY_TWO"}`,
+		inStruct:         &defaultsRoot{},
+		want:             &defaultsRoot{},
+		wantErrSubstring: `"FORTY_TWO" is not a valid value`,
+	}, {
+		name:             "list encoded as an object",
+		inSchema:         testFourSchema,
+		inJSONPath:       filepath.Join(TestRoot, "testdata/emitjson_2.json-txt"),
+		inStruct:         &mapStructTestFour{},
+		want:             &mapStructTestFour{C: &mapStructTestFourC{}},
+		wantErrSubstring: "invalid value for list acl-set",
+	}, {
+		name:             "list member missing key",
+		inSchema:         testFourSchema,
+		inJSON:           `{"c": {"acl-set": [{"config": {"second-value": "val"}}]}}`,
+		inStruct:         &mapStructTestFour{},
+		want:             &mapStructTestFour{C: &mapStructTestFourC{}},
+		wantErrSubstring: "missing key name for list acl-set",
+	}, {
+		name:             "unknown member",
+		inSchema:         testOneSchema,
+		inJSON:           `{"child": {"config": {"field-six": 6}}}`,
+		inStruct:         &mapStructTestOne{},
+		want:             &mapStructTestOne{Child: &mapStructTestOneChild{}},
+		wantErrSubstring: "cannot find schema for field-six in config",
+	}, {
+		name:             "trailing data",
+		inSchema:         testOneSchema,
+		inJSON:           `{} {}`,
+		inStruct:         &mapStructTestOne{},
+		want:             &mapStructTestOne{},
+		wantErrSubstring: "unexpected data following JSON object",
+	}, {
+		name:     "leaf-lists stored as fixed-size arrays",
+		inSchema: mustModuleSchema(t, "openconfig-bounded-leaflist"),
+		inJSON:   `{"openconfig-bounded-leaflist:routes": {"route": [{"prefix": "p", "config": {"prefix": "p", "next-hops": ["a", "b"], "weights": [10]}}]}}`,
+		inStruct: &mergeNotifBoundedDevice{},
+		want: &mergeNotifBoundedDevice{Route: map[string]*mergeNotifBoundedRoute{
+			"p": {Prefix: String("p"), NextHops: [2]string{"a", "b"}, NextHopsLen: 2, Weights: [4]uint32{10}, WeightsLen: 1},
+		}},
+	}, {
+		name:             "nil schema",
+		inJSON:           `{}`,
+		inStruct:         &mapStructTestOne{},
+		want:             &mapStructTestOne{},
+		wantErrSubstring: "nil schema supplied",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r io.Reader = strings.NewReader(tt.inJSON)
+			if tt.inJSONPath != "" {
+				f, err := os.Open(tt.inJSONPath)
+				if err != nil {
+					t.Fatalf("cannot open %s: %v", tt.inJSONPath, err)
+				}
+				defer f.Close()
+				r = f
+			}
+
+			err := UnmarshalJSONStream(tt.inSchema, r, tt.inStruct)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("UnmarshalJSONStream: %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, tt.inStruct); diff != "" {
+				t.Errorf("UnmarshalJSONStream: did not get expected struct, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/util"
+)
+
+// ValidateJSON checks that the JSON document data, which is in the supplied
+// format, is structurally valid against schema, which describes the root of
+// the document. It does so without unmarshalling the document into a
+// GoStruct. The document is valid if each member corresponds to a node within
+// the schema, each value has the JSON type used to encode the type of its
+// node in the format, and each member of a keyed list includes its keys. The
+// values of enumerations and identityrefs are checked against the values
+// defined within the schema, but other restrictions, such as ranges and
+// patterns, are not checked.
+//
+// The Internal, RFC7951 and OpenConfigCompact formats are supported. Member
+// names may be qualified with the name of their module, and RFC7951 metadata
+// members, whose names are prefixed with "@", are ignored. Where the document
+// is invalid, the error returned identifies the offending value using its
+// RFC6901 JSON pointer. The members of each object are checked in
+// lexicographical order of their names, such that the first error found is
+// deterministic.
+func ValidateJSON(schema *yang.Entry, data []byte, format JSONFormat) error {
+	if schema == nil {
+		return fmt.Errorf("nil schema supplied")
+	}
+	switch format {
+	case Internal, RFC7951, OpenConfigCompact:
+	default:
+		return fmt.Errorf("unsupported JSON format %v", format)
+	}
+
+	j, err := decodeJSONDocument(data)
+	if err != nil {
+		return err
+	}
+	return validateJSONObject(schema, j, "", format != Internal)
+}
+
+// decodeJSONDocument decodes data, which must contain a single JSON document.
+// Numbers are decoded as json.Number values such that they are not subject
+// to the loss of precision of float64 values.
+func decodeJSONDocument(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var j interface{}
+	if err := d.Decode(&j); err != nil {
+		return nil, fmt.Errorf("invalid JSON document: %v", err)
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data following JSON document")
+	}
+	return j, nil
+}
+
+// validateJSONObject checks that the JSON value j, at the JSON pointer ptr,
+// is an object that is valid for the container or list member described by
+// schema. rfc7951 specifies whether the document is RFC7951 encoded.
+func validateJSONObject(schema *yang.Entry, j interface{}, ptr string, rfc7951 bool) error {
+	obj, ok := j.(map[string]interface{})
+	if !ok {
+		return jsonPointerErrorf(ptr, "got %s, want object for %s", jsonTypeName(j), schema.Name)
+	}
+
+	names := make([]string, 0, len(obj))
+	for k := range obj {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		if rfc7951 && strings.HasPrefix(k, "@") {
+			continue
+		}
+		cp := jsonPointerAppend(ptr, k)
+		cs := util.FirstChild(schema, []string{util.StripModulePrefix(k)})
+		if cs == nil {
+			return jsonPointerErrorf(cp, "unknown member %s of %s", k, schema.Name)
+		}
+		if err := validateJSONNode(cs, obj[k], cp, rfc7951); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateJSONNode checks that the JSON value j, at the JSON pointer ptr, is
+// valid for the data tree node described by schema.
+func validateJSONNode(schema *yang.Entry, j interface{}, ptr string, rfc7951 bool) error {
+	switch {
+	case schema.Kind == yang.AnyDataEntry:
+		return nil
+	case schema.IsLeaf():
+		if err := validateJSONLeafValue(schema, schema.Type, j, rfc7951); err != nil {
+			return jsonPointerErrorf(ptr, "invalid value for %s: %v", schema.Name, err)
+		}
+		return nil
+	case schema.IsLeafList():
+		vals, ok := j.([]interface{})
+		if !ok {
+			return jsonPointerErrorf(ptr, "got %s, want array for leaf-list %s", jsonTypeName(j), schema.Name)
+		}
+		for i, v := range vals {
+			if err := validateJSONLeafValue(schema, schema.Type, v, rfc7951); err != nil {
+				return jsonPointerErrorf(jsonPointerAppend(ptr, strconv.Itoa(i)), "invalid value for %s: %v", schema.Name, err)
+			}
+		}
+		return nil
+	case schema.IsList():
+		return validateJSONList(schema, j, ptr, rfc7951)
+	default:
+		return validateJSONObject(schema, j, ptr, rfc7951)
+	}
+}
+
+// validateJSONList checks that the JSON value j, at the JSON pointer ptr, is
+// valid for the list described by schema. In RFC7951 JSON, and for keyless
+// lists, the list is encoded as an array of members. Otherwise, it is encoded
+// as an object keyed by the string representation of each member's key.
+func validateJSONList(schema *yang.Entry, j interface{}, ptr string, rfc7951 bool) error {
+	keys := strings.Fields(schema.Key)
+
+	members := map[string]interface{}{}
+	var order []string
+	switch v := j.(type) {
+	case []interface{}:
+		if !rfc7951 && len(keys) != 0 {
+			return jsonPointerErrorf(ptr, "got array, want object for keyed list %s", schema.Name)
+		}
+		for i, m := range v {
+			members[strconv.Itoa(i)] = m
+			order = append(order, strconv.Itoa(i))
+		}
+	case map[string]interface{}:
+		if rfc7951 || len(keys) == 0 {
+			return jsonPointerErrorf(ptr, "got object, want array for list %s", schema.Name)
+		}
+		for k, m := range v {
+			members[k] = m
+			order = append(order, k)
+		}
+		sort.Strings(order)
+	default:
+		return jsonPointerErrorf(ptr, "got %s, want array or object for list %s", jsonTypeName(j), schema.Name)
+	}
+
+	for _, k := range order {
+		mp := jsonPointerAppend(ptr, k)
+		if err := validateJSONObject(schema, members[k], mp, rfc7951); err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if !hasJSONMember(members[k].(map[string]interface{}), key) {
+				return jsonPointerErrorf(mp, "member of list %s is missing key %s", schema.Name, key)
+			}
+		}
+	}
+	return nil
+}
+
+// hasJSONMember returns true if obj contains a member with the supplied name,
+// which may be qualified with the name of its module.
+func hasJSONMember(obj map[string]interface{}, name string) bool {
+	for k := range obj {
+		if util.StripModulePrefix(k) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateJSONLeafValue checks that the JSON value j is a valid encoding of a
+// value of the YANG type t, which is the type of the leaf or leaf-list
+// described by schema.
+func validateJSONLeafValue(schema *yang.Entry, t *yang.YangType, j interface{}, rfc7951 bool) error {
+	if t == nil {
+		return fmt.Errorf("nil type in schema")
+	}
+
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		n, ok := j.(json.Number)
+		if !ok {
+			return fmt.Errorf("got %s, want number", jsonTypeName(j))
+		}
+		return validateJSONInteger(string(n), t.Kind)
+	case yang.Yint64, yang.Yuint64, yang.Ydecimal64:
+		var s string
+		switch v := j.(type) {
+		case string:
+			if !rfc7951 {
+				return fmt.Errorf("got string, want number")
+			}
+			s = v
+		case json.Number:
+			if rfc7951 {
+				return fmt.Errorf("got number, want string")
+			}
+			s = string(v)
+		default:
+			want := "number"
+			if rfc7951 {
+				want = "string"
+			}
+			return fmt.Errorf("got %s, want %s", jsonTypeName(j), want)
+		}
+		if t.Kind == yang.Ydecimal64 {
+			if _, err := strconv.ParseFloat(s, 64); err != nil {
+				return fmt.Errorf("%q is not a decimal64 value", s)
+			}
+			return nil
+		}
+		return validateJSONInteger(s, t.Kind)
+	case yang.Ystring, yang.YinstanceIdentifier:
+		if _, ok := j.(string); !ok {
+			return fmt.Errorf("got %s, want string", jsonTypeName(j))
+		}
+		return nil
+	case yang.Ybinary:
+		s, ok := j.(string)
+		if !ok {
+			return fmt.Errorf("got %s, want string", jsonTypeName(j))
+		}
+		if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+			return fmt.Errorf("%q is not base64 encoded: %v", s, err)
+		}
+		return nil
+	case yang.Ybool:
+		if _, ok := j.(bool); !ok {
+			return fmt.Errorf("got %s, want boolean", jsonTypeName(j))
+		}
+		return nil
+	case yang.Yempty:
+		if rfc7951 {
+			if v, ok := j.([]interface{}); !ok || len(v) != 1 || v[0] != nil {
+				return fmt.Errorf("got %v, want [null]", j)
+			}
+			return nil
+		}
+		if _, ok := j.(bool); !ok {
+			return fmt.Errorf("got %s, want boolean", jsonTypeName(j))
+		}
+		return nil
+	case yang.Yenum:
+		s, ok := j.(string)
+		if !ok {
+			return fmt.Errorf("got %s, want string", jsonTypeName(j))
+		}
+		if t.Enum == nil || !t.Enum.IsDefined(s) {
+			return fmt.Errorf("%q is not a value of the enumeration", s)
+		}
+		return nil
+	case yang.Yidentityref:
+		s, ok := j.(string)
+		if !ok {
+			return fmt.Errorf("got %s, want string", jsonTypeName(j))
+		}
+		if t.IdentityBase == nil {
+			return fmt.Errorf("identityref has nil base")
+		}
+		name := util.StripModulePrefix(s)
+		for _, v := range t.IdentityBase.Values {
+			if v.Name == name {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not derived from identity %s", s, t.IdentityBase.Name)
+	case yang.Yunion:
+		for _, st := range t.Type {
+			if err := validateJSONLeafValue(schema, st, j, rfc7951); err == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%v does not match any of the types of the union", j)
+	case yang.Yleafref:
+		target, err := util.FindLeafRefSchema(schema, t.Path)
+		if err != nil {
+			return err
+		}
+		return validateJSONLeafValue(target, target.Type, j, rfc7951)
+	}
+	return fmt.Errorf("unsupported type %v", t.Kind)
+}
+
+// validateJSONInteger checks that s is the decimal representation of an
+// integer within the range of the YANG integer type kind.
+func validateJSONInteger(s string, kind yang.TypeKind) error {
+	var err error
+	switch kind {
+	case yang.Yint8:
+		_, err = strconv.ParseInt(s, 10, 8)
+	case yang.Yint16:
+		_, err = strconv.ParseInt(s, 10, 16)
+	case yang.Yint32:
+		_, err = strconv.ParseInt(s, 10, 32)
+	case yang.Yint64:
+		_, err = strconv.ParseInt(s, 10, 64)
+	case yang.Yuint8:
+		_, err = strconv.ParseUint(s, 10, 8)
+	case yang.Yuint16:
+		_, err = strconv.ParseUint(s, 10, 16)
+	case yang.Yuint32:
+		_, err = strconv.ParseUint(s, 10, 32)
+	case yang.Yuint64:
+		_, err = strconv.ParseUint(s, 10, 64)
+	}
+	if err != nil {
+		return fmt.Errorf("%s is not a valid %v value", s, kind)
+	}
+	return nil
+}
+
+// jsonTypeName returns the name of the JSON type of the decoded value j.
+func jsonTypeName(j interface{}) string {
+	switch j.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", j)
+}
+
+// jsonPointerAppend returns the RFC6901 JSON pointer formed by appending the
+// reference token tok to ptr.
+func jsonPointerAppend(ptr, tok string) string {
+	return ptr + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(tok)
+}
+
+// jsonPointerErrorf returns an error for the value at the JSON pointer ptr,
+// with the message formatted according to format and args.
+func jsonPointerErrorf(ptr, format string, args ...interface{}) error {
+	return fmt.Errorf("invalid JSON at %q: %s", ptr, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ygot
+
+import (
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func TestValidateJSON(t *testing.T) {
+	intfType := yang.NewEnumType()
+	intfType.Set("ETHERNET", 0)
+	intfType.Set("LOOPBACK", 1)
+
+	autoType := yang.NewEnumType()
+	autoType.Set("AUTO", 0)
+
+	schema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"interfaces": {
+				Name: "interfaces",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"interface": {
+						Name:     "interface",
+						Kind:     yang.DirectoryEntry,
+						ListAttr: yang.NewDefaultListAttr(),
+						Key:      "name",
+						Dir: map[string]*yang.Entry{
+							"name": {
+								Name: "name",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yleafref, Path: "../config/name"},
+							},
+							"config": {
+								Name: "config",
+								Kind: yang.DirectoryEntry,
+								Dir: map[string]*yang.Entry{
+									"name": {
+										Name: "name",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+									"mtu": {
+										Name: "mtu",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Yuint16},
+									},
+									"enabled": {
+										Name: "enabled",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ybool},
+									},
+									"counter": {
+										Name: "counter",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Yuint64},
+									},
+									"type": {
+										Name: "type",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Yenum, Enum: intfType},
+									},
+									"loopback-mode": {
+										Name: "loopback-mode",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Yempty},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"tags": {
+				Name:     "tags",
+				Kind:     yang.LeafEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Type:     &yang.YangType{Kind: yang.Ystring},
+			},
+			"speed": {
+				Name: "speed",
+				Kind: yang.LeafEntry,
+				Type: &yang.YangType{
+					Kind: yang.Yunion,
+					Type: []*yang.YangType{
+						{Kind: yang.Yuint8},
+						{Kind: yang.Yenum, Enum: autoType},
+					},
+				},
+			},
+			"log": {
+				Name:     "log",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Dir: map[string]*yang.Entry{
+					"msg": {
+						Name: "msg",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+				},
+			},
+		},
+	}
+	addParents(schema)
+
+	tests := []struct {
+		desc             string
+		inJSON           string
+		inFormat         JSONFormat
+		wantErrSubstring string
+	}{{
+		desc:     "valid RFC7951 document",
+		inFormat: RFC7951,
+		inJSON: `{
+  "m:interfaces": {
+    "interface": [{
+      "name": "eth0",
+      "config": {
+        "name": "eth0",
+        "mtu": 1500,
+        "enabled": true,
+        "counter": "18446744073709551615",
+        "type": "ETHERNET",
+        "loopback-mode": [null]
+      },
+      "@config": {"m:last-modified": "today"}
+    }]
+  },
+  "m:tags": ["one", "two"],
+  "m:speed": "AUTO",
+  "m:log": [{"msg": "hello"}]
+}`,
+	}, {
+		desc:     "valid internal document",
+		inFormat: Internal,
+		inJSON: `{
+  "interfaces": {
+    "interface": {
+      "eth0": {
+        "name": "eth0",
+        "config": {
+          "name": "eth0",
+          "counter": 18446744073709551615,
+          "loopback-mode": true
+        }
+      }
+    }
+  },
+  "speed": 100,
+  "log": [{"msg": "hello"}]
+}`,
+	}, {
+		desc:             "unknown member",
+		inFormat:         RFC7951,
+		inJSON:           `{"interfaces": {"interface": [{"name": "eth0", "state": {}}]}}`,
+		wantErrSubstring: `invalid JSON at "/interfaces/interface/0/state": unknown member state of interface`,
+	}, {
+		desc:             "wrong JSON type for integer",
+		inFormat:         RFC7951,
+		inJSON:           `{"interfaces": {"interface": [{"name": "eth0", "config": {"mtu": "1500"}}]}}`,
+		wantErrSubstring: `invalid JSON at "/interfaces/interface/0/config/mtu": invalid value for mtu: got string, want number`,
+	}, {
+		desc:             "integer out of range",
+		inFormat:         RFC7951,
+		inJSON:           `{"interfaces": {"interface": [{"name": "eth0", "config": {"mtu": 65536}}]}}`,
+		wantErrSubstring: "65536 is not a valid uint16 value",
+	}, {
+		desc:             "64-bit integer as number in RFC7951",
+		inFormat:         RFC7951,
+		inJSON:           `{"interfaces": {"interface": [{"name": "eth0", "config": {"counter": 42}}]}}`,
+		wantErrSubstring: "got number, want string",
+	}, {
+		desc:             "undefined enumerated value",
+		inFormat:         RFC7951,
+		inJSON:           `{"interfaces": {"interface": [{"name": "eth0", "config": {"type": "TUNNEL"}}]}}`,
+		wantErrSubstring: `"TUNNEL" is not a value of the enumeration`,
+	}, {
+		desc:             "invalid empty value",
+		inFormat:         RFC7951,
+		inJSON:           `{"interfaces": {"interface": [{"name": "eth0", "config": {"loopback-mode": true}}]}}`,
+		wantErrSubstring: "want [null]",
+	}, {
+		desc:             "list member missing key",
+		inFormat:         RFC7951,
+		inJSON:           `{"interfaces": {"interface": [{"config": {"name": "eth0"}}]}}`,
+		wantErrSubstring: `invalid JSON at "/interfaces/interface/0": member of list interface is missing key name`,
+	}, {
+		desc:             "keyed list as array in internal format",
+		inFormat:         Internal,
+		inJSON:           `{"interfaces": {"interface": [{"name": "eth0"}]}}`,
+		wantErrSubstring: "got array, want object for keyed list interface",
+	}, {
+		desc:             "keyed list as object in RFC7951",
+		inFormat:         RFC7951,
+		inJSON:           `{"interfaces": {"interface": {"eth0": {"name": "eth0"}}}}`,
+		wantErrSubstring: "got object, want array for list interface",
+	}, {
+		desc:             "invalid leaf-list member",
+		inFormat:         RFC7951,
+		inJSON:           `{"tags": ["one", 2]}`,
+		wantErrSubstring: `invalid JSON at "/tags/1": invalid value for tags: got number, want string`,
+	}, {
+		desc:             "value matching no union type",
+		inFormat:         RFC7951,
+		inJSON:           `{"speed": "FAST"}`,
+		wantErrSubstring: "FAST does not match any of the types of the union",
+	}, {
+		desc:             "container that is not an object",
+		inFormat:         RFC7951,
+		inJSON:           `{"interfaces": []}`,
+		wantErrSubstring: `invalid JSON at "/interfaces": got array, want object for interfaces`,
+	}, {
+		desc:             "member name requiring escaping",
+		inFormat:         RFC7951,
+		inJSON:           `{"a/b~c": 1}`,
+		wantErrSubstring: `invalid JSON at "/a~1b~0c": unknown member`,
+	}, {
+		desc:             "malformed JSON",
+		inFormat:         RFC7951,
+		inJSON:           `{"tags": [`,
+		wantErrSubstring: "invalid JSON document",
+	}, {
+		desc:             "trailing data",
+		inFormat:         RFC7951,
+		inJSON:           `{} {}`,
+		wantErrSubstring: "unexpected data following JSON document",
+	}, {
+		desc:             "unsupported format",
+		inFormat:         PathValueList,
+		inJSON:           `[]`,
+		wantErrSubstring: "unsupported JSON format",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := ValidateJSON(schema, []byte(tt.inJSON), tt.inFormat)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("ValidateJSON(): did not get expected error, %s", diff)
+			}
+		})
+	}
+}
@@ -108,9 +108,10 @@ func validateBinarySchema(schema *yang.Entry) error {
 	return validateLengthSchema(schema)
 }
 
-// isBinaryType reports whether input t is a Binary type derived from []byte.
+// isBinaryType reports whether input t is a Binary type derived from []byte,
+// or a custom binary type that implements ygot.Base64Marshaler.
 func isBinaryType(t reflect.Type) bool {
-	return t != nil && t.Name() == ygot.BinaryTypeName && t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+	return ygot.IsBinaryType(t)
 }
 
 // isBinarySliceType reports whether input t is a []Binary type
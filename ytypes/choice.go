@@ -17,6 +17,8 @@ package ytypes
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
@@ -63,6 +65,9 @@ func IsCaseSelected(schema *yang.Entry, value interface{}) (selected []string, e
 	for i := 0; i < v.NumField(); i++ {
 		if !util.IsValueNilOrDefault(v.Field(i).Interface()) {
 			fieldType := v.Type().Field(i)
+			if util.IsYgotMetadata(fieldType) || util.IsYgotArrayLen(fieldType) {
+				continue
+			}
 			cs, err := util.ChildSchema(schema, fieldType)
 			if err != nil {
 				errors = util.AppendErr(errors, err)
@@ -86,3 +91,86 @@ func IsCaseSelected(schema *yang.Entry, value interface{}) (selected []string, e
 
 	return
 }
+
+// choiceInstance identifies a choice within a particular GoStruct in a data
+// tree.
+type choiceInstance struct {
+	// parent is the node of the GoStruct containing the choice's members.
+	parent *util.NodeInfo
+	// choice is the schema of the choice.
+	choice *yang.Entry
+}
+
+// validateChoiceCardinality validates that for each choice in the data tree
+// value, which has the supplied schema, populated data nodes are members of
+// at most one case. For each choice for which multiple cases are populated,
+// an error naming the data tree paths of the populated nodes is returned.
+func validateChoiceCardinality(schema *yang.Entry, value interface{}) util.Errors {
+	// selected stores the paths of the populated nodes of each choice, keyed
+	// by the name of the case that they belong to.
+	selected := map[choiceInstance]map[string][]string{}
+	var order []choiceInstance
+	errs := util.ForEachField(schema, value, nil, nil, func(ni *util.NodeInfo, in, out interface{}) util.Errors {
+		// Only fields of GoStructs are members of a case, the elements of
+		// lists and leaf-lists are represented by the field that holds them.
+		if ni.Parent == nil || ni.Schema == nil || !util.IsValueStructPtr(ni.Parent.FieldValue) {
+			return nil
+		}
+		if util.IsNilOrInvalidValue(ni.FieldValue) || util.IsValueNilOrDefault(ni.FieldValue.Interface()) {
+			return nil
+		}
+		path := dataTreePath(ni)
+		// Walk up through the choice and case ancestors of the node, such
+		// that membership of nested choices is also recorded.
+		child := ni.Schema
+		for e := ni.Schema.Parent; util.IsChoiceOrCase(e); child, e = e, e.Parent {
+			if !e.IsChoice() {
+				continue
+			}
+			ci := choiceInstance{parent: ni.Parent, choice: e}
+			if selected[ci] == nil {
+				selected[ci] = map[string][]string{}
+				order = append(order, ci)
+			}
+			selected[ci][child.Name] = append(selected[ci][child.Name], path)
+		}
+		return nil
+	})
+
+	for _, ci := range order {
+		cases := selected[ci]
+		if len(cases) < 2 {
+			continue
+		}
+		var names, paths []string
+		for name, p := range cases {
+			names = append(names, name)
+			paths = append(paths, p...)
+		}
+		sort.Strings(names)
+		sort.Strings(paths)
+		errs = util.AppendErr(errs, fmt.Errorf("multiple cases %v selected for choice %s, conflicting paths %v", names, ci.choice.Name, paths))
+	}
+	return errs
+}
+
+// dataTreePath returns the path of the node described by ni from the root of
+// the data tree being traversed. The keys of list members are appended to the
+// list name in square brackets.
+func dataTreePath(ni *util.NodeInfo) string {
+	var nodes []*util.NodeInfo
+	for n := ni; n != nil && n.Parent != nil; n = n.Parent {
+		nodes = append(nodes, n)
+	}
+	var elems []string
+	for i := len(nodes) - 1; i >= 0; i-- {
+		n := nodes[i]
+		switch {
+		case n.FieldKey.IsValid() && len(elems) > 0:
+			elems[len(elems)-1] += fmt.Sprintf("[%v]", n.FieldKey.Interface())
+		case util.IsValueStructPtr(n.Parent.FieldValue):
+			elems = append(elems, n.PathFromParent...)
+		}
+	}
+	return "/" + strings.Join(elems, "/")
+}
@@ -17,8 +17,10 @@ package ytypes
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/ygot"
@@ -241,6 +243,198 @@ func TestValidateChoice(t *testing.T) {
 	}
 }
 
+type ChoiceCardinalityStruct struct {
+	TcpPort *uint16                                 `path:"tcp-port"`
+	UdpPort *uint16                                 `path:"udp-port"`
+	Peer    map[string]*ChoiceCardinalityPeerStruct `path:"peer"`
+}
+
+func (*ChoiceCardinalityStruct) IsYANGGoStruct()                          {}
+func (*ChoiceCardinalityStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*ChoiceCardinalityStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*ChoiceCardinalityStruct) ΛBelongingModule() string                 { return "bar" }
+
+type ChoiceCardinalityPeerStruct struct {
+	Name     *string `path:"name"`
+	Password *string `path:"password"`
+	KeyId    *uint32 `path:"key-id"`
+	KeyChain *string `path:"key-chain"`
+}
+
+func (*ChoiceCardinalityPeerStruct) IsYANGGoStruct()                          {}
+func (*ChoiceCardinalityPeerStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*ChoiceCardinalityPeerStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*ChoiceCardinalityPeerStruct) ΛBelongingModule() string                 { return "bar" }
+
+func TestValidateChoiceCardinality(t *testing.T) {
+	schema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"transport": {
+				Name: "transport",
+				Kind: yang.ChoiceEntry,
+				Dir: map[string]*yang.Entry{
+					"tcp": {
+						Name: "tcp",
+						Kind: yang.CaseEntry,
+						Dir: map[string]*yang.Entry{
+							"tcp-port": {
+								Name: "tcp-port",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yuint16},
+							},
+						},
+					},
+					"udp": {
+						Name: "udp",
+						Kind: yang.CaseEntry,
+						Dir: map[string]*yang.Entry{
+							"udp-port": {
+								Name: "udp-port",
+								Kind: yang.LeafEntry,
+								Type: &yang.YangType{Kind: yang.Yuint16},
+							},
+						},
+					},
+				},
+			},
+			"peer": {
+				Name:     "peer",
+				Kind:     yang.DirectoryEntry,
+				ListAttr: yang.NewDefaultListAttr(),
+				Key:      "name",
+				Config:   yang.TSTrue,
+				Dir: map[string]*yang.Entry{
+					"name": {
+						Name: "name",
+						Kind: yang.LeafEntry,
+						Type: &yang.YangType{Kind: yang.Ystring},
+					},
+					"auth": {
+						Name: "auth",
+						Kind: yang.ChoiceEntry,
+						Dir: map[string]*yang.Entry{
+							"password": {
+								Name: "password",
+								Kind: yang.CaseEntry,
+								Dir: map[string]*yang.Entry{
+									"password": {
+										Name: "password",
+										Kind: yang.LeafEntry,
+										Type: &yang.YangType{Kind: yang.Ystring},
+									},
+								},
+							},
+							"key": {
+								Name: "key",
+								Kind: yang.CaseEntry,
+								Dir: map[string]*yang.Entry{
+									"key-source": {
+										Name: "key-source",
+										Kind: yang.ChoiceEntry,
+										Dir: map[string]*yang.Entry{
+											"key-id": {
+												Name: "key-id",
+												Kind: yang.CaseEntry,
+												Dir: map[string]*yang.Entry{
+													"key-id": {
+														Name: "key-id",
+														Kind: yang.LeafEntry,
+														Type: &yang.YangType{Kind: yang.Yuint32},
+													},
+												},
+											},
+											"key-chain": {
+												Name: "key-chain",
+												Kind: yang.CaseEntry,
+												Dir: map[string]*yang.Entry{
+													"key-chain": {
+														Name: "key-chain",
+														Kind: yang.LeafEntry,
+														Type: &yang.YangType{Kind: yang.Ystring},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	addParents(schema)
+
+	tests := []struct {
+		desc     string
+		val      *ChoiceCardinalityStruct
+		wantErrs []string
+	}{{
+		desc: "one case selected for each choice",
+		val: &ChoiceCardinalityStruct{
+			TcpPort: ygot.Uint16(179),
+			Peer: map[string]*ChoiceCardinalityPeerStruct{
+				"p1": {Name: ygot.String("p1"), KeyId: ygot.Uint32(1)},
+			},
+		},
+	}, {
+		desc: "two cases selected at the root",
+		val: &ChoiceCardinalityStruct{
+			TcpPort: ygot.Uint16(179),
+			UdpPort: ygot.Uint16(161),
+		},
+		wantErrs: []string{
+			"multiple cases [tcp udp] selected for choice transport, conflicting paths [/tcp-port /udp-port]",
+		},
+	}, {
+		desc: "two cases selected within a list member",
+		val: &ChoiceCardinalityStruct{
+			Peer: map[string]*ChoiceCardinalityPeerStruct{
+				"p1": {Name: ygot.String("p1"), Password: ygot.String("secret"), KeyId: ygot.Uint32(1)},
+				"p2": {Name: ygot.String("p2"), Password: ygot.String("secret")},
+			},
+		},
+		wantErrs: []string{
+			"multiple cases [key password] selected for choice auth, conflicting paths [/peer[p1]/key-id /peer[p1]/password]",
+		},
+	}, {
+		desc: "two cases selected within a nested choice",
+		val: &ChoiceCardinalityStruct{
+			Peer: map[string]*ChoiceCardinalityPeerStruct{
+				"p1": {Name: ygot.String("p1"), KeyId: ygot.Uint32(1), KeyChain: ygot.String("chain")},
+			},
+		},
+		wantErrs: []string{
+			"multiple cases [key-chain key-id] selected for choice key-source, conflicting paths [/peer[p1]/key-chain /peer[p1]/key-id]",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var gotErrs []string
+			for _, err := range Validate(schema, tt.val, &EnforceChoiceCardinality{}) {
+				if strings.Contains(err.Error(), "conflicting paths") {
+					gotErrs = append(gotErrs, err.Error())
+				}
+			}
+			if diff := cmp.Diff(tt.wantErrs, gotErrs); diff != "" {
+				t.Errorf("Validate(EnforceChoiceCardinality): did not get expected errors, (-want, +got):\n%s", diff)
+			}
+
+			// Without the option, no errors naming the conflicting paths
+			// are returned.
+			for _, err := range Validate(schema, tt.val) {
+				if strings.Contains(err.Error(), "conflicting paths") {
+					t.Errorf("Validate: got unexpected error %v", err)
+				}
+			}
+		})
+	}
+}
+
 func TestUnmarshalChoice(t *testing.T) {
 	containerWithChoiceSchema := &yang.Entry{
 		Name: "container-with-choice",
@@ -15,8 +15,10 @@
 package ytypes
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/kylelemons/godebug/pretty"
@@ -29,7 +31,7 @@ import (
 
 // validateContainer validates each of the values in the map, keyed by the list
 // Key value, against the given list schema.
-func validateContainer(schema *yang.Entry, value ygot.GoStruct) util.Errors {
+func validateContainer(ctx context.Context, schema *yang.Entry, value ygot.GoStruct) util.Errors {
 	var errors []error
 	if util.IsValueNil(value) {
 		return nil
@@ -56,9 +58,25 @@ func validateContainer(schema *yang.Entry, value ygot.GoStruct) util.Errors {
 			fieldType := structElems.Type().Field(i)
 			fieldName := fieldType.Name
 			fieldValue := structElems.Field(i).Interface()
+			if util.IsYgotValueLeaf(fieldType) {
+				// Leaves that are stored as values are always set, and
+				// are hence validated in the same manner as a populated
+				// pointer.
+				fieldValue = structElems.Field(i).Addr().Interface()
+			}
+			if _, ok := util.YgotArrayLenField(fieldType); ok {
+				// Leaf-lists that are stored as fixed-size arrays are
+				// validated as a slice of their populated elements.
+				fv, err := util.FixedArrayElems(structElems, fieldType)
+				if err != nil {
+					errors = util.AppendErr(errors, fmt.Errorf("%s: %v", fieldName, err))
+					continue
+				}
+				fieldValue = fv.Interface()
+			}
 
-			// Skip annotation fields when validating the schema.
-			if util.IsYgotAnnotation(fieldType) {
+			// Skip annotation and metadata fields when validating the schema.
+			if util.IsYgotAnnotation(fieldType) || util.IsYgotMetadata(fieldType) || util.IsYgotArrayLen(fieldType) {
 				continue
 			}
 
@@ -69,7 +87,7 @@ func validateContainer(schema *yang.Entry, value ygot.GoStruct) util.Errors {
 				continue
 			case cschema != nil:
 				// Regular named child.
-				if errs := Validate(cschema, fieldValue); errs != nil {
+				if errs := validate(ctx, cschema, fieldValue); errs != nil {
 					errors = util.AppendErrs(errors, util.PrefixErrors(errs, cschema.Path()))
 				}
 			case !util.IsValueNilOrDefault(structElems.Field(i).Interface()):
@@ -94,6 +112,8 @@ func validateContainer(schema *yang.Entry, value ygot.GoStruct) util.Errors {
 			}
 		}
 
+		errors = util.AppendErrs(errors, validateFieldHooks(structElems))
+
 	default:
 		errors = util.AppendErr(errors, fmt.Errorf("validateContainer expected struct type for %s (type %T), got %v", schema.Name, value, reflect.TypeOf(value).Kind()))
 	}
@@ -154,6 +174,12 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 		f := destv.Field(i)
 		ft := destv.Type().Field(i)
 
+		// Skip embedded metadata fields since they do not have a schema, and
+		// are not represented in the input JSON.
+		if util.IsYgotMetadata(ft) || util.IsYgotArrayLen(ft) {
+			continue
+		}
+
 		// Skip annotation fields since they do not have a schema.
 		// TODO(robjs): Implement unmarshalling annotations.
 		if util.IsYgotAnnotation(ft) {
@@ -241,19 +267,81 @@ func unmarshalStruct(schema *yang.Entry, parent interface{}, jsonTree map[string
 		}
 	}
 
-	// Only check for missing fields if the IgnoreExtraFields option isn't specified.
-	if !hasIgnoreExtraFields(opts) {
+	// Only check for missing fields if the IgnoreExtraFields option isn't
+	// specified, otherwise record the discarded fields if requested.
+	switch sink := unknownFieldsSink(opts); {
+	case !hasIgnoreExtraFields(opts):
 		// Go over all JSON fields to make sure that each one is covered
 		// by a data path in the struct.
 		if err := checkDataTreeAgainstPaths(jsonTree, allSchemaPaths); err != nil {
 			return fmt.Errorf("parent container %s (type %T): %s", schema.Name, parent, err)
 		}
+	case sink != nil:
+		unknown, _ := unknownDataTreeFields(jsonTree, allSchemaPaths)
+		prefix := strings.TrimSuffix(util.SchemaTreePathNoModule(schema), "/")
+		var paths []string
+		for _, u := range unknown {
+			paths = append(paths, fmt.Sprintf("%s/%s", prefix, strings.Join(u, "/")))
+		}
+		sort.Strings(paths)
+		*sink = append(*sink, paths...)
 	}
 
 	util.DbgPrint("container after unmarshal:\n%s\n", pretty.Sprint(destv.Interface()))
 	return nil
 }
 
+// validateFieldHooks calls the validation hooks that are stored in the
+// ValidateFieldHooksFieldName field of the struct structElems, if any, with
+// the values of the populated fields that they are specified for. Pointers to
+// scalar values are dereferenced before they are supplied to the hook. It
+// returns the errors returned by the hooks, along with an error for each hook
+// that is specified for a field that does not exist.
+func validateFieldHooks(structElems reflect.Value) util.Errors {
+	hf, ok := structElems.Type().FieldByName(ValidateFieldHooksFieldName)
+	if !ok {
+		return nil
+	}
+	hooks, ok := structElems.FieldByIndex(hf.Index).Interface().(map[string]func(interface{}) error)
+	if !ok {
+		return util.NewErrs(fmt.Errorf("field %s of %s has type %v, want map[string]func(interface{}) error", ValidateFieldHooksFieldName, structElems.Type(), hf.Type))
+	}
+
+	var names []string
+	for name := range hooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs util.Errors
+	for _, name := range names {
+		ft, ok := structElems.Type().FieldByName(name)
+		if !ok || util.IsYgotMetadata(ft) || util.IsYgotAnnotation(ft) || util.IsYgotArrayLen(ft) {
+			errs = util.AppendErr(errs, fmt.Errorf("validation hook specified for unknown field %s of %s", name, structElems.Type()))
+			continue
+		}
+		fv := structElems.FieldByIndex(ft.Index)
+		if _, ok := util.YgotArrayLenField(ft); ok {
+			v, err := util.FixedArrayElems(structElems, ft)
+			if err != nil {
+				errs = util.AppendErr(errs, fmt.Errorf("%s: %v", name, err))
+				continue
+			}
+			fv = v
+		}
+		if !util.IsYgotValueLeaf(ft) && util.IsValueNilOrDefault(fv.Interface()) {
+			continue
+		}
+		if fv.Kind() == reflect.Ptr && !util.IsValueStructPtr(fv) {
+			fv = fv.Elem()
+		}
+		if err := hooks[name](fv.Interface()); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("%s: %v", name, err))
+		}
+	}
+	return errs
+}
+
 // validateContainerSchema validates the given container type schema. This is a
 // quick check rather than a comprehensive validation against the RFC. It is
 // assumed that such a validation is done when the schema is parsed from source
@@ -262,7 +350,7 @@ func validateContainerSchema(schema *yang.Entry) error {
 	if schema == nil {
 		return fmt.Errorf("container schema is nil")
 	}
-	if !schema.IsContainer() {
+	if !schema.IsContainer() && !util.IsOperationPayload(schema) {
 		return fmt.Errorf("container schema %s is not a container type", schema.Name)
 	}
 
@@ -15,10 +15,15 @@
 package ytypes
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/ygot"
@@ -41,6 +46,7 @@ type ContainerStruct struct {
 	BadEnumLeafName  EnumType                    `path:"bad-leaf-enum"`
 	Annotation       *string                     `path:"@annotation" ygotAnnotation:"true"`
 	ChildList        map[string]*ContainerStruct `path:"child-list"`
+	ΛMetadata        int64                       `ygotMetadata:"true"`
 }
 
 func (*ContainerStruct) IsYANGGoStruct()                          {}
@@ -193,6 +199,14 @@ func TestValidateContainer(t *testing.T) {
 				Leaf2Name: ygot.String("Leaf2Value"),
 			},
 		},
+		{
+			desc:   "success with embedded metadata",
+			schema: containerSchema,
+			val: &ContainerStruct{
+				Leaf1Name: ygot.String("Leaf1Value"),
+				ΛMetadata: 42,
+			},
+		},
 		{
 			desc:   "bad field",
 			schema: containerSchema,
@@ -266,14 +280,264 @@ func TestValidateContainer(t *testing.T) {
 	}
 
 	// Additional tests through private API.
-	if err := validateContainer(nil, nil); err != nil {
+	if err := validateContainer(context.Background(), nil, nil); err != nil {
 		t.Errorf("nil value: got error: %v, want error: nil", err)
 	}
-	if err := validateContainer(nil, &ContainerStruct{}); err == nil {
+	if err := validateContainer(context.Background(), nil, &ContainerStruct{}); err == nil {
 		t.Errorf("nil schema: got error: nil, want nil schema error")
 	}
 }
 
+type ValueLeafContainerStruct struct {
+	Name *string `path:"name"`
+	Mtu  uint16  `path:"mtu" ygotValueLeaf:"true"`
+}
+
+func (*ValueLeafContainerStruct) IsYANGGoStruct()                          {}
+func (*ValueLeafContainerStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*ValueLeafContainerStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*ValueLeafContainerStruct) ΛBelongingModule() string                 { return "bar" }
+
+func TestValidateContainerValueLeaves(t *testing.T) {
+	containerSchema := &yang.Entry{
+		Name: "container-schema",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"name": {
+				Kind: yang.LeafEntry,
+				Name: "name",
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			"mtu": {
+				Kind: yang.LeafEntry,
+				Name: "mtu",
+				Type: &yang.YangType{
+					Kind:  yang.Yuint16,
+					Range: yang.YangRange{yang.YRange{Min: yang.FromInt(68), Max: yang.FromInt(9216)}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc    string
+		val     *ValueLeafContainerStruct
+		wantErr string
+	}{{
+		desc: "success",
+		val:  &ValueLeafContainerStruct{Mtu: 1500},
+	}, {
+		desc:    "zero value is validated",
+		val:     &ValueLeafContainerStruct{Name: ygot.String("eth0")},
+		wantErr: `/mtu: schema "mtu": unsigned integer value 0 is outside specified ranges`,
+	}, {
+		desc:    "out of range value",
+		val:     &ValueLeafContainerStruct{Mtu: 9217},
+		wantErr: `/mtu: schema "mtu": unsigned integer value 9217 is outside specified ranges`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := Validate(containerSchema, tt.val)
+			if got, want := errs.String(), tt.wantErr; got != want {
+				t.Errorf("%s: got error: %v, want error: %v", tt.desc, got, want)
+			}
+		})
+	}
+}
+
+type HookContainerStruct struct {
+	Name                *string                            `path:"name"`
+	Mtu                 uint16                             `path:"mtu" ygotValueLeaf:"true"`
+	ΛValidateFieldHooks map[string]func(interface{}) error `ygotMetadata:"true"`
+}
+
+func (*HookContainerStruct) IsYANGGoStruct()                          {}
+func (*HookContainerStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*HookContainerStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*HookContainerStruct) ΛBelongingModule() string                 { return "bar" }
+
+func TestValidateFieldHooks(t *testing.T) {
+	containerSchema := &yang.Entry{
+		Name: "container-schema",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"name": {
+				Kind: yang.LeafEntry,
+				Name: "name",
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			"mtu": {
+				Kind: yang.LeafEntry,
+				Name: "mtu",
+				Type: &yang.YangType{Kind: yang.Yuint16},
+			},
+		},
+	}
+
+	noEth := func(v interface{}) error {
+		if strings.HasPrefix(v.(string), "eth") {
+			return fmt.Errorf("name %q is reserved", v)
+		}
+		return nil
+	}
+	evenMtu := func(v interface{}) error {
+		if v.(uint16)%2 != 0 {
+			return fmt.Errorf("mtu %d is not even", v)
+		}
+		return nil
+	}
+
+	tests := []struct {
+		desc    string
+		val     *HookContainerStruct
+		wantErr string
+	}{{
+		desc: "no hooks",
+		val:  &HookContainerStruct{Name: ygot.String("eth0"), Mtu: 1501},
+	}, {
+		desc: "hooks accept values",
+		val: &HookContainerStruct{
+			Name:                ygot.String("mgmt0"),
+			Mtu:                 1500,
+			ΛValidateFieldHooks: map[string]func(interface{}) error{"Name": noEth, "Mtu": evenMtu},
+		},
+	}, {
+		desc: "hook rejects value",
+		val: &HookContainerStruct{
+			Name:                ygot.String("eth0"),
+			Mtu:                 1500,
+			ΛValidateFieldHooks: map[string]func(interface{}) error{"Name": noEth, "Mtu": evenMtu},
+		},
+		wantErr: `Name: name "eth0" is reserved`,
+	}, {
+		desc: "errors from hooks are aggregated",
+		val: &HookContainerStruct{
+			Name:                ygot.String("eth0"),
+			Mtu:                 1501,
+			ΛValidateFieldHooks: map[string]func(interface{}) error{"Name": noEth, "Mtu": evenMtu},
+		},
+		wantErr: `Mtu: mtu 1501 is not even, Name: name "eth0" is reserved`,
+	}, {
+		desc: "hook not called for unset field",
+		val: &HookContainerStruct{
+			Mtu:                 1500,
+			ΛValidateFieldHooks: map[string]func(interface{}) error{"Name": noEth},
+		},
+	}, {
+		desc: "hook for unknown field",
+		val: &HookContainerStruct{
+			ΛValidateFieldHooks: map[string]func(interface{}) error{"Description": noEth},
+		},
+		wantErr: "validation hook specified for unknown field Description of ytypes.HookContainerStruct",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// Errors are returned in no particular order.
+			var gotErrs []string
+			for _, err := range Validate(containerSchema, tt.val) {
+				gotErrs = append(gotErrs, err.Error())
+			}
+			sort.Strings(gotErrs)
+			if got, want := strings.Join(gotErrs, ", "), tt.wantErr; got != want {
+				t.Errorf("%s: got error: %v, want error: %v", tt.desc, got, want)
+			}
+		})
+	}
+}
+
+type FixedArrayContainerStruct struct {
+	Hops    [2]string `path:"hops" ygotArrayLen:"HopsLen"`
+	HopsLen int       `ygotArrayLenOf:"Hops"`
+}
+
+func (*FixedArrayContainerStruct) IsYANGGoStruct()                          {}
+func (*FixedArrayContainerStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*FixedArrayContainerStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*FixedArrayContainerStruct) ΛBelongingModule() string                 { return "bar" }
+
+func TestFixedArrayContainer(t *testing.T) {
+	containerSchema := &yang.Entry{
+		Name: "container-schema",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"hops": {
+				Kind:     yang.LeafEntry,
+				Name:     "hops",
+				ListAttr: &yang.ListAttr{MinElements: 1, MaxElements: 2},
+				Type: &yang.YangType{
+					Kind:    yang.Ystring,
+					Pattern: []string{"a.*"},
+				},
+			},
+		},
+	}
+
+	validateTests := []struct {
+		desc    string
+		val     *FixedArrayContainerStruct
+		wantErr string
+	}{{
+		desc: "success",
+		val:  &FixedArrayContainerStruct{Hops: [2]string{"a1", "a2"}, HopsLen: 2},
+	}, {
+		desc: "unpopulated elements are not validated",
+		val:  &FixedArrayContainerStruct{Hops: [2]string{"a1", "b2"}, HopsLen: 1},
+	}, {
+		desc:    "invalid element",
+		val:     &FixedArrayContainerStruct{Hops: [2]string{"b1"}, HopsLen: 1},
+		wantErr: `/hops: schema "hops": "b1" does not match regular expression pattern "^(a.*)$"`,
+	}, {
+		desc:    "length out of bounds",
+		val:     &FixedArrayContainerStruct{HopsLen: 3},
+		wantErr: `Hops: length 3 of field Hops is not within the bounds of its array of 2 elements`,
+	}}
+
+	for _, tt := range validateTests {
+		t.Run("validate "+tt.desc, func(t *testing.T) {
+			errs := Validate(containerSchema, tt.val)
+			if got, want := errs.String(), tt.wantErr; got != want {
+				t.Errorf("%s: got error: %v, want error: %v", tt.desc, got, want)
+			}
+		})
+	}
+
+	unmarshalTests := []struct {
+		desc    string
+		json    string
+		want    *FixedArrayContainerStruct
+		wantErr string
+	}{{
+		desc: "success",
+		json: `{ "hops": ["a1", "a2"] }`,
+		want: &FixedArrayContainerStruct{Hops: [2]string{"a1", "a2"}, HopsLen: 2},
+	}, {
+		desc:    "too many elements",
+		json:    `{ "hops": ["a1", "a2", "a3"] }`,
+		wantErr: `cannot append to field Hops, all 2 elements are populated`,
+	}}
+
+	for _, tt := range unmarshalTests {
+		t.Run("unmarshal "+tt.desc, func(t *testing.T) {
+			var jsonTree interface{}
+			if err := json.Unmarshal([]byte(tt.json), &jsonTree); err != nil {
+				t.Fatal(err)
+			}
+			got := &FixedArrayContainerStruct{}
+			err := Unmarshal(containerSchema, got, jsonTree)
+			if got, want := errToString(err), tt.wantErr; got != want {
+				t.Errorf("%s: got error: %v, want error: %v", tt.desc, got, want)
+			}
+			if err == nil {
+				if !areEqual(got, tt.want) {
+					t.Errorf("%s: got:\n%v\nwant:\n%v\n", tt.desc, pretty.Sprint(got), pretty.Sprint(tt.want))
+				}
+			}
+		})
+	}
+}
+
 func TestUnmarshalContainer(t *testing.T) {
 	innerContainerSchema := &yang.Entry{
 		Name: "container-field",
@@ -374,13 +638,14 @@ func TestUnmarshalContainer(t *testing.T) {
 	}
 
 	tests := []struct {
-		desc    string
-		schema  *yang.Entry
-		parent  interface{}
-		json    string
-		opts    []UnmarshalOpt
-		want    interface{}
-		wantErr string
+		desc              string
+		schema            *yang.Entry
+		parent            interface{}
+		json              string
+		opts              []UnmarshalOpt
+		want              interface{}
+		wantErr           string
+		wantUnknownFields []string
 	}{
 		{
 			desc:   "success nil value",
@@ -467,6 +732,32 @@ func TestUnmarshalContainer(t *testing.T) {
 			opts:   []UnmarshalOpt{&IgnoreExtraFields{}},
 			want:   &ParentContainerStruct{ContainerField: &ContainerStruct{}},
 		},
+		{
+			desc:   "unknown leaf with ignore unknown fields",
+			schema: containerSchema,
+			parent: &ParentContainerStruct{},
+			json:   `{"container-field": {"new-leaf": "a", "leaf2-field": 42 } }`,
+			opts:   []UnmarshalOpt{&IgnoreUnknownFields{}},
+			want:   &ParentContainerStruct{ContainerField: &ContainerStruct{Leaf2Field: ygot.Int32(42)}},
+		},
+		{
+			desc:              "unknown fields with ignore, collecting unknown fields",
+			schema:            containerSchema,
+			parent:            &ParentContainerStruct{},
+			json:              `{"new-container": {"x": 1}, "container-field": {"aug-field": 43, "leaf2-field": 42, "config": {"new-leaf": "a", "leaf1-field": 41 } } }`,
+			opts:              []UnmarshalOpt{&IgnoreUnknownFields{UnknownFields: &[]string{}}},
+			want:              &ParentContainerStruct{ContainerField: &ContainerStruct{ConfigLeaf1Field: ygot.Int32(41), Leaf2Field: ygot.Int32(42)}},
+			wantUnknownFields: []string{"/container-field/aug-field", "/container-field/config/new-leaf", "/new-container"},
+		},
+		{
+			desc:              "no unknown fields with ignore, collecting unknown fields",
+			schema:            containerSchema,
+			parent:            &ParentContainerStruct{},
+			json:              `{"container-field": {"leaf2-field": 42 } }`,
+			opts:              []UnmarshalOpt{&IgnoreUnknownFields{UnknownFields: &[]string{}}},
+			want:              &ParentContainerStruct{ContainerField: &ContainerStruct{Leaf2Field: ygot.Int32(42)}},
+			wantUnknownFields: []string{},
+		},
 		{
 			desc:   "success with prefer state code",
 			schema: containerSchema,
@@ -532,6 +823,11 @@ func TestUnmarshalContainer(t *testing.T) {
 					t.Errorf("%s: got:\n%v\nwant:\n%v\n", tt.desc, pretty.Sprint(got), pretty.Sprint(want))
 				}
 			}
+			if sink := unknownFieldsSink(tt.opts); sink != nil {
+				if diff := cmp.Diff(tt.wantUnknownFields, *sink); diff != "" {
+					t.Errorf("%s: did not get expected unknown fields, diff(-want, +got):\n%s", tt.desc, diff)
+				}
+			}
 		})
 	}
 
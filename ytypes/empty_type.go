@@ -25,7 +25,8 @@ import (
 // Refer to: https://tools.ietf.org/html/rfc6020#section-9.11.
 
 // validateEmpty validates value, which must be a derived type corresponding
-// to the ygot.EmptyTypeName, against the given schema.
+// to the ygot.EmptyTypeName, or a bool if the code was generated with empty
+// leaves represented as bools, against the given schema.
 func validateEmpty(schema *yang.Entry, value interface{}) error {
 	// Check that the schema itself is valid.
 	if err := validateEmptySchema(schema); err != nil {
@@ -33,7 +34,7 @@ func validateEmpty(schema *yang.Entry, value interface{}) error {
 	}
 
 	if schema.Type.Kind == yang.Yempty {
-		if reflect.TypeOf(value).Name() != ygot.EmptyTypeName {
+		if t := reflect.TypeOf(value); t.Name() != ygot.EmptyTypeName && t != reflect.TypeOf(false) {
 			return fmt.Errorf("non derived type %T with value %v for schema %s", value, value, schema.Name)
 		}
 	}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/testutil"
 )
 
 var validEmptySchema = &yang.Entry{Name: "empty-schema", Type: &yang.YangType{Kind: yang.Yempty}}
@@ -90,9 +91,14 @@ func TestValidateEmpty(t *testing.T) {
 			val:    YANGEmpty(true),
 		},
 		{
-			desc:    "invalid empty",
+			desc:   "valid empty represented as bool",
+			schema: validEmptySchema,
+			val:    true,
+		},
+		{
+			desc:    "invalid empty - derived bool type",
 			schema:  validEmptySchema,
-			val:     true,
+			val:     testutil.UnionBool(true),
 			wantErr: true,
 		},
 		{
@@ -0,0 +1,64 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package ytypes
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// Refer to: https://tools.ietf.org/html/rfc7950#section-9.13.
+
+// validateInstanceIdentifier validates value, which must be a string or a type
+// derived from string, such as ygot.InstanceIdentifier, against the given
+// schema. The value must be an absolute data tree path. Whether the node that
+// it identifies exists is not checked, regardless of the require-instance
+// statement of the schema.
+func validateInstanceIdentifier(schema *yang.Entry, value interface{}) error {
+	// Check that the schema itself is valid.
+	if err := validateInstanceIdentifierSchema(schema); err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.String {
+		return fmt.Errorf("non string type %T with value %v for schema %s", value, value, schema.Name)
+	}
+	if _, err := ygot.InstanceIdentifier(rv.String()).Path(); err != nil {
+		return fmt.Errorf("invalid instance identifier for schema %s: %v", schema.Name, err)
+	}
+
+	return nil
+}
+
+// validateInstanceIdentifierSchema validates the given instance-identifier
+// type schema. This is a quick check rather than a comprehensive validation
+// against the RFC. It is assumed that such a validation is done when the
+// schema is parsed from source YANG.
+func validateInstanceIdentifierSchema(schema *yang.Entry) error {
+	if schema == nil {
+		return fmt.Errorf("instance-identifier schema is nil")
+	}
+	if schema.Type == nil {
+		return fmt.Errorf("instance-identifier schema %s Type is nil", schema.Name)
+	}
+	if schema.Type.Kind != yang.YinstanceIdentifier {
+		return fmt.Errorf("instance-identifier schema %s has wrong type %v", schema.Name, schema.Type.Kind)
+	}
+
+	return nil
+}
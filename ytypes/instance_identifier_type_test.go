@@ -0,0 +1,115 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package ytypes
+
+import (
+	"testing"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var validInstanceIdentifierSchema = &yang.Entry{Name: "instance-identifier-schema", Type: &yang.YangType{Kind: yang.YinstanceIdentifier}}
+
+func TestValidateInstanceIdentifierSchema(t *testing.T) {
+	tests := []struct {
+		desc    string
+		schema  *yang.Entry
+		wantErr bool
+	}{
+		{
+			desc:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			desc:    "nil schema type",
+			schema:  &yang.Entry{Name: "nil-type-schema", Type: nil},
+			wantErr: true,
+		},
+		{
+			desc:    "bad schema type",
+			schema:  &yang.Entry{Name: "string-type-schema", Type: &yang.YangType{Kind: yang.Ystring}},
+			wantErr: true,
+		},
+		{
+			desc:   "instance-identifier schema",
+			schema: validInstanceIdentifierSchema,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := validateInstanceIdentifierSchema(tt.schema)
+			if got, want := (err != nil), tt.wantErr; got != want {
+				t.Errorf("%s: validateInstanceIdentifierSchema(%v) got error: %v, want error? %v", tt.desc, tt.schema, err, tt.wantErr)
+			}
+			testErrLog(t, tt.desc, err)
+		})
+	}
+}
+
+func TestValidateInstanceIdentifier(t *testing.T) {
+	tests := []struct {
+		desc    string
+		schema  *yang.Entry
+		val     interface{}
+		wantErr bool
+	}{
+		{
+			desc:   "success",
+			schema: validInstanceIdentifierSchema,
+			val:    ygot.InstanceIdentifier("/ex:system/ex:server[ex:ip='192.0.2.1']"),
+		},
+		{
+			desc:   "success with string value",
+			schema: validInstanceIdentifierSchema,
+			val:    "/a/b",
+		},
+		{
+			desc:    "bad schema",
+			schema:  nil,
+			val:     ygot.InstanceIdentifier("/a/b"),
+			wantErr: true,
+		},
+		{
+			desc:    "non string type",
+			schema:  validInstanceIdentifierSchema,
+			val:     int32(42),
+			wantErr: true,
+		},
+		{
+			desc:    "relative path",
+			schema:  validInstanceIdentifierSchema,
+			val:     ygot.InstanceIdentifier("a/b"),
+			wantErr: true,
+		},
+		{
+			desc:    "invalid predicate",
+			schema:  validInstanceIdentifierSchema,
+			val:     ygot.InstanceIdentifier("/a/b[c]"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := validateInstanceIdentifier(tt.schema, tt.val)
+			if got, want := (err != nil), tt.wantErr; got != want {
+				t.Errorf("%s: validateInstanceIdentifier(%v) got error: %v, want error? %v", tt.desc, tt.val, err, tt.wantErr)
+			}
+			testErrLog(t, tt.desc, err)
+		})
+	}
+}
@@ -91,6 +91,8 @@ func validateLeaf(inSchema *yang.Entry, value interface{}) util.Errors {
 		return util.NewErrs(validateEmpty(schema, rv))
 	case yang.Ystring:
 		return util.NewErrs(validateString(schema, rv))
+	case yang.YinstanceIdentifier:
+		return util.NewErrs(validateInstanceIdentifier(schema, rv))
 	case yang.Ydecimal64:
 		return util.NewErrs(validateDecimal(schema, rv))
 	case yang.Yenum, yang.Yidentityref:
@@ -252,7 +254,7 @@ func validateUnion(schema *yang.Entry, value interface{}) util.Errors {
 // during validation against each matching schema otherwise.
 func validateMatchingSchemas(schema *yang.Entry, value interface{}) util.Errors {
 	var errors []error
-	ss := findMatchingSchemasInUnion(schema.Type, value)
+	ss := findMatchingSchemasInUnion(schema, schema.Type, value)
 	var kk []yang.TypeKind
 	for _, s := range ss {
 		kk = append(kk, s.Type.Kind)
@@ -282,15 +284,33 @@ func validateMatchingSchemas(schema *yang.Entry, value interface{}) util.Errors
 
 // findMatchingSchemasInUnion returns all schemas in the given union type,
 // including those within nested unions, that match the Go type of value.
-// value must not be nil.
-func findMatchingSchemasInUnion(ytype *yang.YangType, value interface{}) []*yang.Entry {
+// The schema is the leaf that the union type belongs to, and is used to
+// resolve leafref subtypes - where the target of a leafref is itself a union,
+// the schemas within the target union are also checked. value must not be nil.
+func findMatchingSchemasInUnion(schema *yang.Entry, ytype *yang.YangType, value interface{}) []*yang.Entry {
 	var matches []*yang.Entry
 
 	util.DbgPrint("findMatchingSchemasInUnion for type %T, kind %s", value, reflect.TypeOf(value).Kind())
 	for _, t := range ytype.Type {
+		if t.Kind == yang.Yleafref {
+			target, err := util.FindLeafRefSchema(schema, t.Path)
+			if err == nil {
+				target, err = util.ResolveIfLeafRef(target)
+			}
+			if err != nil {
+				log.Warningf("cannot resolve leafref %s in union value %s: %v", t.Path, util.ValueStr(value), err)
+				continue
+			}
+			if target.Type.Kind == yang.Yunion {
+				matches = append(matches, findMatchingSchemasInUnion(target, target.Type, value)...)
+				continue
+			}
+			t = target.Type
+		}
+
 		if t.Kind == yang.Yunion {
 			// Recursively check all union types within this union.
-			matches = append(matches, findMatchingSchemasInUnion(t, value)...)
+			matches = append(matches, findMatchingSchemasInUnion(schema, t, value)...)
 			continue
 		}
 
@@ -708,6 +728,9 @@ func sanitizeJSON(parent interface{}, schema *yang.Entry, fieldName string, valu
 	case yang.Ystring:
 		return value.(string), nil
 
+	case yang.YinstanceIdentifier:
+		return ygot.InstanceIdentifier(value.(string)), nil
+
 	case yang.Ydecimal64:
 		floatV, err := strconv.ParseFloat(value.(string), 64)
 		if err != nil {
@@ -776,6 +799,8 @@ func sanitizeGNMI(parent interface{}, schema *yang.Entry, fieldName string, tv *
 		return tv.GetBoolVal(), nil
 	case yang.Ystring:
 		return tv.GetStringVal(), nil
+	case yang.YinstanceIdentifier:
+		return ygot.InstanceIdentifier(tv.GetStringVal()), nil
 	case yang.Yenum, yang.Yidentityref:
 		return enumStringToValue(parent, fieldName, tv.GetStringVal())
 	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
@@ -833,7 +858,7 @@ func gNMIToYANGTypeMatches(ykind yang.TypeKind, tv *gpb.TypedValue, jsonToleranc
 	switch ykind {
 	case yang.Ybool:
 		_, ok = tv.GetValue().(*gpb.TypedValue_BoolVal)
-	case yang.Ystring, yang.Yenum, yang.Yidentityref:
+	case yang.Ystring, yang.YinstanceIdentifier, yang.Yenum, yang.Yidentityref:
 		_, ok = tv.GetValue().(*gpb.TypedValue_StringVal)
 	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
 		_, ok = tv.GetValue().(*gpb.TypedValue_IntVal)
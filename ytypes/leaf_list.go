@@ -152,7 +152,8 @@ func unmarshalLeafList(schema *yang.Entry, parent interface{}, value interface{}
 }
 
 // clearSliceField sets updates a field called fieldName (which must exist, but may be
-// nil) in parentStruct, with value nil.
+// nil) in parentStruct, with value nil. If the field is a leaf-list that is stored as
+// a fixed-size array, its elements are cleared.
 func clearSliceField(parentStruct interface{}, fieldName string) error {
 	util.DbgPrint("clearSliceField field %s of parent type %T with value %v", fieldName, parentStruct)
 
@@ -170,6 +171,10 @@ func clearSliceField(parentStruct interface{}, fieldName string) error {
 		return fmt.Errorf("parent type %T does not have a field name %s", parentStruct, fieldName)
 	}
 
+	if _, ok := util.YgotArrayLenField(ft); ok {
+		return util.SetFixedArrayElems(pv.Elem(), ft, reflect.Zero(reflect.SliceOf(ft.Type.Elem())))
+	}
+
 	if ft.Type.Kind() != reflect.Slice {
 		return fmt.Errorf("field %s of parent type %T must be Slice type (%v)", fieldName, parentStruct, ft.Type.Kind())
 	}
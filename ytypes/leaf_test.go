@@ -280,6 +280,11 @@ func TestValidateLeaf(t *testing.T) {
 			schema: typeToLeafSchema("empty", yang.Yempty),
 			val:    YANGEmpty(true),
 		},
+		{
+			desc:   "empty success - bool",
+			schema: typeToLeafSchema("empty", yang.Yempty),
+			val:    ygot.Bool(true),
+		},
 		{
 			desc:    "empty bad type",
 			schema:  typeToLeafSchema("empty", yang.Yempty),
@@ -617,6 +622,77 @@ func TestValidateLeafUnion(t *testing.T) {
 	}
 }
 
+func TestValidateUnionWithLeafrefToUnion(t *testing.T) {
+	containerSchema := &yang.Entry{
+		Name: "container",
+		Kind: yang.DirectoryEntry,
+	}
+	containerSchema.Dir = map[string]*yang.Entry{
+		"value": {
+			Name:   "value",
+			Kind:   yang.LeafEntry,
+			Parent: containerSchema,
+			Type: &yang.YangType{
+				Kind: yang.Yunion,
+				Type: []*yang.YangType{{
+					Kind:         yang.Ystring,
+					Pattern:      []string{"a+"},
+					POSIXPattern: []string{"^a+$"},
+				}, {
+					Kind: yang.Yuint32,
+				}},
+			},
+		},
+		"reference": {
+			Name:   "reference",
+			Kind:   yang.LeafEntry,
+			Parent: containerSchema,
+			Type: &yang.YangType{
+				Kind: yang.Yunion,
+				Type: []*yang.YangType{{
+					Kind: yang.Yleafref,
+					Path: "../value",
+				}, {
+					Kind: yang.Yint8,
+				}},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc    string
+		val     interface{}
+		wantErr bool
+	}{{
+		desc: "string subtype of leafref target",
+		val:  testutil.UnionString("aaa"),
+	}, {
+		desc:    "string subtype of leafref target with bad value",
+		val:     testutil.UnionString("bbb"),
+		wantErr: true,
+	}, {
+		desc: "uint32 subtype of leafref target",
+		val:  testutil.UnionUint32(42),
+	}, {
+		desc: "direct subtype",
+		val:  testutil.UnionInt8(-1),
+	}, {
+		desc:    "type that is not a subtype",
+		val:     testutil.UnionFloat64(4.2),
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := validateLeaf(containerSchema.Dir["reference"], tt.val)
+			if got, want := (errs != nil), tt.wantErr; got != want {
+				t.Errorf("%s: got error: %v, want error? %v", tt.desc, errs, tt.wantErr)
+			}
+			testErrLog(t, tt.desc, errs)
+		})
+	}
+}
+
 type Leaf1Container struct {
 	Leaf1 *string `path:"container1/leaf1"`
 	Leaf2 *string `path:"container1/leaf2"`
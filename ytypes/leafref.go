@@ -189,8 +189,8 @@ func dataNodesAtPath(ni *util.NodeInfo, path *gpb.Path, pathQueryNode *util.Path
 			if root.Parent == nil {
 				return nil, fmt.Errorf("no parent for leafref path at %v, with remaining path %s", ni.Schema.Path(), path)
 			}
-			if (root.Parent.Schema.IsList() && util.IsValueMap(root.Parent.FieldValue)) || (root.Parent.Schema.IsLeafList() && util.IsValueSlice(root.Parent.FieldValue)) {
-				// YANG lists and YANG leaf-lists are represented as Go maps and slices respectively.
+			if (root.Parent.Schema.IsList() && (util.IsValueMap(root.Parent.FieldValue) || util.IsTypeOrderedList(root.Parent.FieldValue.Type()))) || (root.Parent.Schema.IsLeafList() && util.IsValueSlice(root.Parent.FieldValue)) {
+				// YANG lists and YANG leaf-lists are represented as Go maps (or ordered lists) and slices respectively.
 				// Despite these being a single level in the YANG hierarchy, util.ForEachField actually
 				// traverses these elements in two levels: first at the map/slice level, and then at the
 				// element level. Since it does this by creating a "fake", or extra NodeInfo for each
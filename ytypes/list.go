@@ -15,6 +15,7 @@
 package ytypes
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -22,13 +23,14 @@ import (
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
+	"github.com/openconfig/ygot/ygot"
 )
 
 // Refer to: https://tools.ietf.org/html/rfc6020#section-7.8.
 
 // validateList validates each of the values in the map, keyed by the list Key
 // value, against the given list schema.
-func validateList(schema *yang.Entry, value interface{}) util.Errors {
+func validateList(ctx context.Context, schema *yang.Entry, value interface{}) util.Errors {
 	var errors []error
 	if util.IsValueNil(value) {
 		return nil
@@ -41,6 +43,10 @@ func validateList(schema *yang.Entry, value interface{}) util.Errors {
 
 	util.DbgPrint("validateList with value %v, type %T, schema name %s", value, value, schema.Name)
 
+	if ol, ok := value.(ygot.GoOrderedList); ok {
+		return validateOrderedList(ctx, schema, ol)
+	}
+
 	kind := reflect.TypeOf(value).Kind()
 	if kind == reflect.Slice || kind == reflect.Map {
 		// Check list attributes: size constraints etc.
@@ -54,25 +60,25 @@ func validateList(schema *yang.Entry, value interface{}) util.Errors {
 		// List without key is a slice in the data tree.
 		sv := reflect.ValueOf(value)
 		for i := 0; i < sv.Len(); i++ {
-			errors = util.AppendErrs(errors, validateStructElems(schema, sv.Index(i).Interface()))
+			errors = util.AppendErrs(errors, validateStructElems(ctx, schema, sv.Index(i).Interface()))
 		}
 	case reflect.Map:
 		// List with key is a map in the data tree, with the key being the value
 		// of the key field(s) in the elements.
 		for _, key := range reflect.ValueOf(value).MapKeys() {
-			cv := reflect.ValueOf(value).MapIndex(key).Interface()
+			cv := util.ListMemberPtr(reflect.ValueOf(value).MapIndex(key)).Interface()
 			structElems := reflect.ValueOf(cv).Elem()
 			// Check that keys are present and have correct values.
 			errors = util.AppendErrs(errors, checkKeys(schema, structElems, key))
 
 			// Verify each elements's fields.
-			errors = util.AppendErrs(errors, validateStructElems(schema, cv))
+			errors = util.AppendErrs(errors, validateStructElems(ctx, schema, cv))
 		}
 	case reflect.Ptr:
 		// Validate was called on a list element rather than the whole list, or
 		// on a completely bogus struct. In either case, evaluate just the
 		// element against the list schema without considering list attributes.
-		errors = util.AppendErrs(errors, validateStructElems(schema, value))
+		errors = util.AppendErrs(errors, validateStructElems(ctx, schema, value))
 
 	default:
 		errors = util.AppendErr(errors, fmt.Errorf("validateList expected map/slice type for %s, got %T", schema.Name, value))
@@ -81,6 +87,27 @@ func validateList(schema *yang.Entry, value interface{}) util.Errors {
 	return errors
 }
 
+// validateOrderedList validates each of the members of the ordered list ol,
+// along with the keys with which they are stored, against the given list
+// schema.
+func validateOrderedList(ctx context.Context, schema *yang.Entry, ol ygot.GoOrderedList) util.Errors {
+	keys, values := ol.ΛKeys(), ol.ΛValues()
+	if len(keys) != len(values) {
+		return util.NewErrs(fmt.Errorf("ordered list %s has %d keys and %d values", schema.Name, len(keys), len(values)))
+	}
+
+	errors := validateListAttr(schema, values)
+	for i, v := range values {
+		if util.IsValueNil(v) {
+			errors = util.AppendErr(errors, fmt.Errorf("ordered list %s has nil member with key %v", schema.Name, keys[i]))
+			continue
+		}
+		errors = util.AppendErrs(errors, checkKeys(schema, reflect.ValueOf(v).Elem(), reflect.ValueOf(keys[i])))
+		errors = util.AppendErrs(errors, validateStructElems(ctx, schema, v))
+	}
+	return errors
+}
+
 // checkKeys checks that the map key value for the list equals the value of the
 // key field(s) in the elements for the map value.
 //   entry is the schema for the list.
@@ -166,7 +193,7 @@ func checkStructKeyValues(structElems reflect.Value, keyStruct reflect.Value) ut
 // validateStructElems validates each of the struct fields against the schema.
 // TODO(mostrowski): choice directly under list is not handled here.
 // Also, there's code duplication with a very similar operation in container.
-func validateStructElems(schema *yang.Entry, value interface{}) util.Errors {
+func validateStructElems(ctx context.Context, schema *yang.Entry, value interface{}) util.Errors {
 	var errors []error
 	structElems := reflect.ValueOf(value).Elem()
 	structTypes := structElems.Type()
@@ -178,14 +205,28 @@ func validateStructElems(schema *yang.Entry, value interface{}) util.Errors {
 	for i := 0; i < structElems.NumField(); i++ {
 		ft := structElems.Type().Field(i)
 
-		// If this is an annotation field, then skip it since it does not have
-		// a schema.
-		if util.IsYgotAnnotation(ft) {
+		// If this is an annotation or metadata field, then skip it since it
+		// does not have a schema.
+		if util.IsYgotAnnotation(ft) || util.IsYgotMetadata(ft) || util.IsYgotArrayLen(ft) {
 			continue
 		}
 
 		fieldName := ft.Name
 		fieldValue := structElems.Field(i).Interface()
+		if util.IsYgotValueLeaf(ft) {
+			// Leaves that are stored as values are always set.
+			fieldValue = structElems.Field(i).Addr().Interface()
+		}
+		if _, ok := util.YgotArrayLenField(ft); ok {
+			// Leaf-lists that are stored as fixed-size arrays are
+			// validated as a slice of their populated elements.
+			fv, err := util.FixedArrayElems(structElems, ft)
+			if err != nil {
+				errors = util.AppendErr(errors, fmt.Errorf("%s: %v", fieldName, err))
+				continue
+			}
+			fieldValue = fv.Interface()
+		}
 
 		cschema, err := util.ChildSchema(schema, structTypes.Field(i))
 		if err != nil {
@@ -195,7 +236,7 @@ func validateStructElems(schema *yang.Entry, value interface{}) util.Errors {
 		if cschema == nil {
 			errors = util.AppendErr(errors, fmt.Errorf("child schema not found for struct %s field %s", schema.Name, fieldName))
 		} else {
-			errors = util.AppendErrs(errors, Validate(cschema, fieldValue))
+			errors = util.AppendErrs(errors, validate(ctx, cschema, fieldValue))
 		}
 	}
 
@@ -239,6 +280,9 @@ func validateListSchema(schema *yang.Entry) error {
 // key field name.
 func schemaNameToFieldName(structElems reflect.Value, schemaKeyFieldName string) (string, error) {
 	for i := 0; i < structElems.NumField(); i++ {
+		if ft := structElems.Type().Field(i); util.IsYgotMetadata(ft) || util.IsYgotArrayLen(ft) {
+			continue
+		}
 		ps, err := util.RelativeSchemaPath(structElems.Type().Field(i))
 		if err != nil {
 			return "", err
@@ -270,7 +314,9 @@ func nameMatchesPath(fieldName string, path []string) (bool, error) {
 }
 
 // unmarshalList unmarshals a JSON array into a list parent, which must be a
-// map or slice ptr.
+// map, slice ptr or ordered list. The members of an ordered list are appended
+// in the order of the JSON array, and it is an error for a member with the
+// same key to already exist within the list.
 //   schema is the schema of the schema node corresponding to the struct being
 //     unmamshaled into
 //   jsonList is a JSON list
@@ -287,10 +333,11 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 
 	util.DbgPrint("unmarshalList jsonList %v, type %T, into parent type %T, schema name %s", util.ValueStrDebug(jsonList), jsonList, parent, schema.Name)
 
-	// Parent must be a map, slice ptr, or struct ptr.
+	// Parent must be a map, slice ptr, ordered list, or struct ptr.
 	t := reflect.TypeOf(parent)
+	orderedList := util.IsTypeOrderedList(t)
 
-	if util.IsTypeStructPtr(t) {
+	if util.IsTypeStructPtr(t) && !orderedList {
 		// May be trying to unmarshal a single list element rather than the
 		// whole list.
 		return unmarshalContainerWithListSchema(schema, parent, jsonList, opts...)
@@ -303,17 +350,27 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 			schema.Name, util.ValueStr(jsonList), jsonList)
 	}
 
-	if !(util.IsTypeMap(t) || util.IsTypeSlicePtr(t)) {
+	if !(util.IsTypeMap(t) || util.IsTypeSlicePtr(t) || orderedList) {
 		return fmt.Errorf("unmarshalList for %s got parent type %s, expect map, slice ptr or struct ptr", schema.Name, t.Kind())
 	}
 
 	listElementType := t.Elem()
-	if util.IsTypeSlicePtr(t) {
+	switch {
+	case orderedList:
+		listElementType = util.OrderedListMemberType(t)
+	case util.IsTypeSlicePtr(t):
 		listElementType = t.Elem().Elem()
 	}
-	if !util.IsTypeStructPtr(listElementType) {
+	// The members of keyed lists may be stored by value within the map
+	// that represents the list.
+	valueMembers := util.IsTypeMap(t) && util.IsTypeStruct(listElementType)
+	if !util.IsTypeStructPtr(listElementType) && !valueMembers {
 		return fmt.Errorf("unmarshalList for %s parent type %T, has bad field type %v", listElementType, parent, listElementType)
 	}
+	memberType := listElementType
+	if !valueMembers {
+		memberType = listElementType.Elem()
+	}
 
 	// Iterate over JSON list. Each JSON list element is a map with the field
 	// name as the key. The JSON values must be unmarshaled and inserted into
@@ -323,23 +380,39 @@ func unmarshalList(schema *yang.Entry, parent interface{}, jsonList interface{},
 	// types respectively.
 	// For a keyed list, the value(s) of the key are derived from the key fields
 	// in the new list element.
+	errOnDupKey := hasErrorOnDuplicateListKey(opts)
+	seenKeys := map[interface{}]bool{}
 	for _, le := range jl {
 		var err error
 		jt := le.(map[string]interface{})
-		newVal := reflect.New(listElementType.Elem())
+		newVal := reflect.New(memberType)
 		util.DbgPrint("creating a new list element val of type %v", newVal.Type())
 		if err := unmarshalStruct(schema, newVal.Interface(), jt, enc, opts...); err != nil {
 			return err
 		}
 
 		switch {
+		case orderedList:
+			if err := util.AppendToOrderedList(reflect.ValueOf(parent), newVal); err != nil {
+				return fmt.Errorf("unmarshalList for %s: %v", util.SchemaTreePathNoModule(schema), err)
+			}
 		case util.IsTypeMap(t):
 			var newKey reflect.Value
 			newKey, err = makeKeyForInsert(schema, parent, newVal)
 			if err != nil {
 				return err
 			}
-			err = util.InsertIntoMap(parent, newKey.Interface(), newVal.Interface())
+			if errOnDupKey {
+				if seenKeys[newKey.Interface()] {
+					return fmt.Errorf("unmarshalList for %s: duplicate key %v in JSON list", util.SchemaTreePathNoModule(schema), newKey.Interface())
+				}
+				seenKeys[newKey.Interface()] = true
+			}
+			member := newVal
+			if valueMembers {
+				member = newVal.Elem()
+			}
+			err = util.InsertIntoMap(parent, newKey.Interface(), member.Interface())
 		case util.IsTypeSlicePtr(t):
 			err = util.InsertIntoSlice(parent, newVal.Interface())
 		default:
@@ -372,15 +445,21 @@ func makeValForInsert(schema *yang.Entry, parent interface{}, keys map[string]st
 	}
 	// key is a non-pointer type
 	keyT := rt.Key()
-	// element is pointer type
+	// element is a pointer type, or a struct where the members of the list
+	// are stored by value.
 	elmT := rt.Elem()
 
-	if !util.IsTypeStructPtr(elmT) {
-		return reflect.ValueOf(nil), fmt.Errorf("%v is not a pointer to a struct", elmT)
+	switch {
+	case util.IsTypeStructPtr(elmT):
+		// Element is dereferenced as it is a pointer.
+		elmT = elmT.Elem()
+	case util.IsTypeStruct(elmT):
+	default:
+		return reflect.ValueOf(nil), fmt.Errorf("%v is not a struct or a pointer to a struct", elmT)
 	}
 
-	// Create an instance of map value type. Element is dereferenced as it is a pointer.
-	val := reflect.New(elmT.Elem())
+	// Create a pointer to an instance of the map value type.
+	val := reflect.New(elmT)
 	// Helper to update the field corresponding to the schema list's key.
 	setKey := func(keySchemaName string) error {
 		keyVal, ok := keys[keySchemaName]
@@ -524,6 +603,10 @@ func insertAndGetKey(schema *yang.Entry, root interface{}, keys map[string]strin
 	if err != nil {
 		return nil, fmt.Errorf("failed to create map key for insert, root %T, keys %v: %v", root, keys, err)
 	}
+	if util.IsTypeStruct(reflect.TypeOf(root).Elem()) {
+		// The members of the list are stored by value.
+		mapVal = mapVal.Elem()
+	}
 	err = util.InsertIntoMap(root, mapKey.Interface(), mapVal.Interface())
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert into map %T, keys %v: %v", root, keys, err)
@@ -559,6 +642,9 @@ func unmarshalContainerWithListSchema(schema *yang.Entry, parent interface{}, va
 func getKeyValue(structVal reflect.Value, key string) (interface{}, error) {
 	for i := 0; i < structVal.NumField(); i++ {
 		f := structVal.Type().Field(i)
+		if util.IsYgotMetadata(f) || util.IsYgotArrayLen(f) {
+			continue
+		}
 		p, err := util.RelativeSchemaPath(f)
 		if err != nil {
 			return nil, err
@@ -15,6 +15,7 @@
 package ytypes
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -123,19 +124,19 @@ func TestValidateListSchema(t *testing.T) {
 
 func TestValidateList(t *testing.T) {
 	// nil value
-	if got := validateList(nil, nil); got != nil {
+	if got := validateList(context.Background(), nil, nil); got != nil {
 		t.Errorf("nil value: Unmarshal got error: %v, want error: nil", got)
 	}
 
 	// nil schema
-	err := util.Errors(validateList(nil, &struct{}{})).Error()
+	err := util.Errors(validateList(context.Background(), nil, &struct{}{})).Error()
 	wantErr := `list schema is nil`
 	if got, want := err, wantErr; got != want {
 		t.Errorf("nil schema: Unmarshal got error: %v, want error: %v", got, want)
 	}
 
 	// bad value type
-	err = util.Errors(validateList(validListSchema, struct{}{})).Error()
+	err = util.Errors(validateList(context.Background(), validListSchema, struct{}{})).Error()
 	wantErr = `validateList expected map/slice type for valid-list-schema, got struct {}`
 	if got, want := err, wantErr; got != want {
 		t.Errorf("nil schema: Unmarshal got error: %v, want error: %v", got, want)
@@ -389,6 +390,219 @@ func TestValidateListStructKey(t *testing.T) {
 	}
 }
 
+// orderedListElem is a member of the orderedList ordered list.
+type orderedListElem struct {
+	KeyFieldName *string `path:"keyfield-name"`
+	LeafName     *string `path:"leaf-name"`
+}
+
+func (*orderedListElem) IsYANGGoStruct() {}
+
+// orderedList is an ordered list that implements the ygot.GoOrderedList
+// interface.
+type orderedList struct {
+	keys   []string
+	values []*orderedListElem
+}
+
+func (*orderedList) IsYANGOrderedList() {}
+func (o *orderedList) Len() int         { return len(o.keys) }
+
+func (o *orderedList) ΛKeys() []interface{} {
+	var keys []interface{}
+	for _, k := range o.keys {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (o *orderedList) ΛValues() []ygot.GoStruct {
+	var values []ygot.GoStruct
+	for _, v := range o.values {
+		values = append(values, v)
+	}
+	return values
+}
+
+func (o *orderedList) Append(v *orderedListElem) error {
+	if v.KeyFieldName == nil {
+		return fmt.Errorf("invalid nil key for KeyFieldName")
+	}
+	for _, k := range o.keys {
+		if k == *v.KeyFieldName {
+			return fmt.Errorf("duplicate key for list %s", k)
+		}
+	}
+	o.keys = append(o.keys, *v.KeyFieldName)
+	o.values = append(o.values, v)
+	return nil
+}
+
+func TestValidateOrderedList(t *testing.T) {
+	listSchema := &yang.Entry{
+		Name:     "list-schema",
+		Kind:     yang.DirectoryEntry,
+		ListAttr: &yang.ListAttr{MaxElements: 2},
+		Key:      "keyfield-name",
+		Config:   yang.TSTrue,
+		Dir: map[string]*yang.Entry{
+			"keyfield-name": {
+				Kind: yang.LeafEntry,
+				Name: "keyfield-name",
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			"leaf-name": {
+				Kind: yang.LeafEntry,
+				Name: "leaf-name",
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc             string
+		val              *orderedList
+		wantErrSubstring string
+	}{{
+		desc: "success",
+		val: &orderedList{
+			keys: []string{"b", "a"},
+			values: []*orderedListElem{
+				{KeyFieldName: ygot.String("b"), LeafName: ygot.String("leaf-b")},
+				{KeyFieldName: ygot.String("a")},
+			},
+		},
+	}, {
+		desc: "mismatched key",
+		val: &orderedList{
+			keys:   []string{"fish"},
+			values: []*orderedListElem{{KeyFieldName: ygot.String("chips")}},
+		},
+		wantErrSubstring: "element key chips != map key fish",
+	}, {
+		desc: "nil member",
+		val: &orderedList{
+			keys:   []string{"fish"},
+			values: []*orderedListElem{nil},
+		},
+		wantErrSubstring: "ordered list list-schema has nil member with key fish",
+	}, {
+		desc: "mismatched number of keys and values",
+		val: &orderedList{
+			keys:   []string{"fish", "chips"},
+			values: []*orderedListElem{{KeyFieldName: ygot.String("fish")}},
+		},
+		wantErrSubstring: "ordered list list-schema has 2 keys and 1 values",
+	}, {
+		desc: "too many elements",
+		val: &orderedList{
+			keys: []string{"a", "b", "c"},
+			values: []*orderedListElem{
+				{KeyFieldName: ygot.String("a")},
+				{KeyFieldName: ygot.String("b")},
+				{KeyFieldName: ygot.String("c")},
+			},
+		},
+		wantErrSubstring: "contains more than max allowed elements: 3 > 2",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var err error
+			if errs := Validate(listSchema, tt.val); errs != nil {
+				err = errs
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Errorf("Validate: %s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalOrderedList(t *testing.T) {
+	listSchema := &yang.Entry{
+		Name:     "list-schema",
+		Kind:     yang.DirectoryEntry,
+		ListAttr: yang.NewDefaultListAttr(),
+		Key:      "keyfield-name",
+		Config:   yang.TSTrue,
+		Dir: map[string]*yang.Entry{
+			"keyfield-name": {
+				Kind: yang.LeafEntry,
+				Name: "keyfield-name",
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+			"leaf-name": {
+				Kind: yang.LeafEntry,
+				Name: "leaf-name",
+				Type: &yang.YangType{Kind: yang.Ystring},
+			},
+		},
+	}
+	addParents(&yang.Entry{
+		Name: "container",
+		Kind: yang.DirectoryEntry,
+		Dir:  map[string]*yang.Entry{"list-schema": listSchema},
+	})
+
+	tests := []struct {
+		desc             string
+		in               *orderedList
+		json             string
+		want             *orderedList
+		wantErrSubstring string
+	}{{
+		desc: "members appended in order",
+		in:   &orderedList{},
+		json: `[{"keyfield-name": "b", "leaf-name": "leaf-b"}, {"keyfield-name": "a"}]`,
+		want: &orderedList{
+			keys: []string{"b", "a"},
+			values: []*orderedListElem{
+				{KeyFieldName: ygot.String("b"), LeafName: ygot.String("leaf-b")},
+				{KeyFieldName: ygot.String("a")},
+			},
+		},
+	}, {
+		desc: "members appended after existing members",
+		in: &orderedList{
+			keys:   []string{"c"},
+			values: []*orderedListElem{{KeyFieldName: ygot.String("c")}},
+		},
+		json: `[{"keyfield-name": "a"}]`,
+		want: &orderedList{
+			keys:   []string{"c", "a"},
+			values: []*orderedListElem{{KeyFieldName: ygot.String("c")}, {KeyFieldName: ygot.String("a")}},
+		},
+	}, {
+		desc: "existing key",
+		in: &orderedList{
+			keys:   []string{"a"},
+			values: []*orderedListElem{{KeyFieldName: ygot.String("a")}},
+		},
+		json:             `[{"keyfield-name": "a"}]`,
+		wantErrSubstring: "duplicate key for list a",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var jsonTree interface{}
+			if err := json.Unmarshal([]byte(tt.json), &jsonTree); err != nil {
+				t.Fatalf("json.Unmarshal: got unexpected error: %v", err)
+			}
+			err := unmarshalList(listSchema, tt.in, jsonTree, JSONEncoding)
+			if diff := errdiff.Substring(err, tt.wantErrSubstring); diff != "" {
+				t.Fatalf("unmarshalList: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, tt.in, cmp.AllowUnexported(orderedList{})); diff != "" {
+				t.Errorf("unmarshalList: did not get expected list, diff(-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestUnmarshalList(t *testing.T) {
 	// nil value
 	if got := unmarshalList(nil, nil, nil, JSONEncoding); got != nil {
@@ -674,6 +888,47 @@ func TestUnmarshalKeyedList(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:   "duplicate key overwrites earlier entry",
+			json:   `{ "key-list" : [ { "key" : "forty-two", "leaf-field" : 42}, { "key" : "forty-two", "leaf-field" : 43} ] }`,
+			schema: containerWithLeafListSchema,
+			parent: &ContainerStruct{},
+			want: &ContainerStruct{
+				KeyList: map[string]*ListElemStruct{
+					"forty-two": {
+						Key:       ygot.String("forty-two"),
+						LeafField: ygot.Int32(43),
+					},
+				},
+			},
+		},
+		{
+			desc:    "duplicate key with error on duplicate list key",
+			json:    `{ "key-list" : [ { "key" : "forty-two", "leaf-field" : 42}, { "key" : "forty-three", "leaf-field" : 43}, { "key" : "forty-two", "leaf-field" : 44} ] }`,
+			opts:    []UnmarshalOpt{&ErrorOnDuplicateListKey{}},
+			schema:  containerWithLeafListSchema,
+			parent:  &ContainerStruct{},
+			wantErr: `unmarshalList for /key-list: duplicate key forty-two in JSON list`,
+		},
+		{
+			desc:   "distinct keys with error on duplicate list key",
+			json:   `{ "key-list" : [ { "key" : "forty-two", "leaf-field" : 42}, { "key" : "forty-three", "leaf-field" : 43} ] }`,
+			opts:   []UnmarshalOpt{&ErrorOnDuplicateListKey{}},
+			schema: containerWithLeafListSchema,
+			parent: &ContainerStruct{},
+			want: &ContainerStruct{
+				KeyList: map[string]*ListElemStruct{
+					"forty-two": {
+						Key:       ygot.String("forty-two"),
+						LeafField: ygot.Int32(42),
+					},
+					"forty-three": {
+						Key:       ygot.String("forty-three"),
+						LeafField: ygot.Int32(43),
+					},
+				},
+			},
+		},
 	}
 
 	var jsonTree interface{}
@@ -1713,7 +1968,7 @@ func TestSimpleMapKeyValueCreation(t *testing.T) {
 		{
 			desc:         "map value is not pointer type",
 			container:    &simpleStruct{KeyList: map[string]string{}},
-			errSubstring: "string is not a pointer to a struct",
+			errSubstring: "string is not a struct or a pointer to a struct",
 		},
 		{
 			desc: "fail map value doesn't have the key with the tag specified in path",
@@ -63,6 +63,12 @@ type retrieveNodeArgs struct {
 	preferShadowPath bool
 }
 
+// modifiesTree returns true if a traversal using the args may modify the
+// nodes that it retrieves.
+func (a retrieveNodeArgs) modifiesTree() bool {
+	return a.delete || a.modifyRoot || a.initializeLeafs || a.val != nil
+}
+
 // retrieveNode is an internal function that retrieves the node specified by
 // the supplied path from the root which must have the schema supplied.
 // retrieveNodeArgs change the way retrieveNode works.
@@ -136,6 +142,11 @@ func retrieveNodeContainer(schema *yang.Entry, root interface{}, path *gpb.Path,
 	for i := 0; i < v.NumField(); i++ {
 		fv, ft := v.Field(i), v.Type().Field(i)
 
+		// Skip embedded metadata fields, since they do not have a schema.
+		if util.IsYgotMetadata(ft) || util.IsYgotArrayLen(ft) {
+			continue
+		}
+
 		childSchemaFn := util.ChildSchema
 		if args.preferShadowPath {
 			childSchemaFn = util.ChildSchemaPreferShadow
@@ -308,8 +319,21 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 
 	listKeyT := rv.Type().Key()
 	listElemT := rv.Type().Elem()
+
+	// The members of lists that are stored by value within the map are
+	// traversed using a copy, which is stored in the map again where the
+	// traversal may have modified it.
+	valueMembers := util.IsTypeStruct(listElemT)
+	retrieveMember := func(k, listElemV reflect.Value, traversedPath *gpb.Path) ([]*TreeNode, error) {
+		nodes, err := retrieveNode(schema, listElemV.Interface(), util.PopGNMIPath(path), traversedPath, args)
+		if valueMembers && args.modifiesTree() {
+			rv.SetMapIndex(k, listElemV.Elem())
+		}
+		return nodes, err
+	}
+
 	for _, k := range rv.MapKeys() {
-		listElemV := rv.MapIndex(k)
+		listElemV := util.ListMemberPtr(rv.MapIndex(k))
 
 		// Handle lists with a single key.
 		if !util.IsValueStruct(k) {
@@ -320,7 +344,7 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 				if err != nil {
 					return nil, status.Errorf(codes.Unknown, "could not get path keys at %v: %v", traversedPath, err)
 				}
-				nodes, err := retrieveNode(schema, listElemV.Interface(), util.PopGNMIPath(path), appendElem(traversedPath, &gpb.PathElem{Name: path.GetElem()[0].Name, Key: keys}), args)
+				nodes, err := retrieveMember(k, listElemV, appendElem(traversedPath, &gpb.PathElem{Name: path.GetElem()[0].Name, Key: keys}))
 				if err != nil {
 					return nil, err
 				}
@@ -351,7 +375,7 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 					rv.SetMapIndex(k, reflect.Value{})
 					return nil, nil
 				}
-				return retrieveNode(schema, listElemV.Interface(), remainingPath, appendElem(traversedPath, path.GetElem()[0]), args)
+				return retrieveMember(k, listElemV, appendElem(traversedPath, path.GetElem()[0]))
 			}
 			continue
 		}
@@ -364,7 +388,7 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 				return nil, status.Errorf(codes.InvalidArgument, "invalid field %s in %T", fieldName, k)
 			}
 
-			elemFieldT, ok := listElemT.Elem().FieldByName(fieldName)
+			elemFieldT, ok := listElemV.Elem().Type().FieldByName(fieldName)
 			if !ok {
 				return nil, status.Errorf(codes.NotFound, "element struct type %v does not contain key field %s", listElemT, fieldName)
 			}
@@ -405,7 +429,7 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 				rv.SetMapIndex(k, reflect.Value{})
 				return nil, nil
 			}
-			nodes, err := retrieveNode(schema, listElemV.Interface(), remainingPath, appendElem(traversedPath, &gpb.PathElem{Name: path.GetElem()[0].Name, Key: keys}), args)
+			nodes, err := retrieveMember(k, listElemV, appendElem(traversedPath, &gpb.PathElem{Name: path.GetElem()[0].Name, Key: keys}))
 			if err != nil {
 				return nil, err
 			}
@@ -421,7 +445,8 @@ func retrieveNodeList(schema *yang.Entry, root interface{}, path, traversedPath
 		if err != nil {
 			return nil, err
 		}
-		nodes, err := retrieveNode(schema, rv.MapIndex(reflect.ValueOf(key)).Interface(), util.PopGNMIPath(path), appendElem(traversedPath, path.GetElem()[0]), args)
+		kv := reflect.ValueOf(key)
+		nodes, err := retrieveMember(kv, util.ListMemberPtr(rv.MapIndex(kv)), appendElem(traversedPath, path.GetElem()[0]))
 		if err != nil {
 			return nil, err
 		}
@@ -39,6 +39,35 @@ type IgnoreExtraFields struct{}
 // IsUnmarshalOpt marks IgnoreExtraFields as a valid UnmarshalOpt.
 func (*IgnoreExtraFields) IsUnmarshalOpt() {}
 
+// IgnoreUnknownFields is an unmarshal option that behaves as
+// IgnoreExtraFields, such that JSON members that have no corresponding
+// field in the GoStruct are skipped rather than causing an error. It is
+// intended for decoding data produced from a newer revision of a schema
+// into structs generated from an older one.
+type IgnoreUnknownFields struct {
+	// UnknownFields, if non-nil, is used as a sink to which the paths of
+	// the JSON members that were skipped are appended, such that they
+	// can be logged by the caller. Each path is the schema path of the
+	// container (without module names) in which the member was found,
+	// followed by the member's path as it appears in the input JSON,
+	// e.g., /interfaces/interface/config/new-leaf.
+	UnknownFields *[]string
+}
+
+// IsUnmarshalOpt marks IgnoreUnknownFields as a valid UnmarshalOpt.
+func (*IgnoreUnknownFields) IsUnmarshalOpt() {}
+
+// ErrorOnDuplicateListKey is an unmarshal option that controls the behaviour
+// of the Unmarshal function when a JSON array representing a keyed list
+// contains more than one entry with the same key. By default, the entry that
+// appears last in the array overwrites the earlier entries, by specifying the
+// ErrorOnDuplicateListKey option to Unmarshal, an error identifying the list
+// and the duplicated key is returned instead.
+type ErrorOnDuplicateListKey struct{}
+
+// IsUnmarshalOpt marks ErrorOnDuplicateListKey as a valid UnmarshalOpt.
+func (*ErrorOnDuplicateListKey) IsUnmarshalOpt() {}
+
 // IsUnmarshalOpt marks PreferShadowPath as a valid UnmarshalOpt.
 // See PreferShadowPath's definition in node.go.
 func (*PreferShadowPath) IsUnmarshalOpt() {}
@@ -96,17 +125,41 @@ func unmarshalGeneric(schema *yang.Entry, parent interface{}, value interface{},
 		return unmarshalList(schema, parent, value, enc, opts...)
 	case schema.IsChoice():
 		return fmt.Errorf("cannot pass choice schema %s to Unmarshal", schema.Name)
-	case schema.IsContainer():
+	case schema.IsContainer(), util.IsOperationPayload(schema):
 		return unmarshalContainer(schema, parent, value, enc, opts...)
 	}
 	return fmt.Errorf("unknown schema type for type %T, value %v", value, value)
 }
 
 // hasIgnoreExtraFields determines whether the supplied slice of UnmarshalOpts contains
-// the IgnoreExtraFields option.
+// the IgnoreExtraFields or IgnoreUnknownFields option.
 func hasIgnoreExtraFields(opts []UnmarshalOpt) bool {
 	for _, o := range opts {
-		if _, ok := o.(*IgnoreExtraFields); ok {
+		switch o.(type) {
+		case *IgnoreExtraFields, *IgnoreUnknownFields:
+			return true
+		}
+	}
+	return false
+}
+
+// unknownFieldsSink returns the UnknownFields sink of the first
+// IgnoreUnknownFields option within the supplied slice of UnmarshalOpts, or
+// nil if there is no such option or it does not specify a sink.
+func unknownFieldsSink(opts []UnmarshalOpt) *[]string {
+	for _, o := range opts {
+		if iu, ok := o.(*IgnoreUnknownFields); ok {
+			return iu.UnknownFields
+		}
+	}
+	return nil
+}
+
+// hasErrorOnDuplicateListKey determines whether the supplied slice of
+// UnmarshalOpts contains the ErrorOnDuplicateListKey option.
+func hasErrorOnDuplicateListKey(opts []UnmarshalOpt) bool {
+	for _, o := range opts {
+		if _, ok := o.(*ErrorOnDuplicateListKey); ok {
 			return true
 		}
 	}
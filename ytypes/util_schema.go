@@ -224,6 +224,35 @@ func removeNonDataPathElements(parentSchema, schema *yang.Entry, paths [][]strin
 //
 // checkDataTreePaths returns an error if there are fields that are in the JSON that are not specified in the dataPaths.
 func checkDataTreeAgainstPaths(jsonTree map[string]interface{}, dataPaths [][]string) error {
+	unknown, unexpectedLeafNodes := unknownDataTreeFields(jsonTree, dataPaths)
+	var missingKeys []string
+	for _, u := range unknown {
+		missingKeys = append(missingKeys, util.StripModulePrefix(u[len(u)-1]))
+	}
+	switch len(missingKeys) {
+	case 0:
+	case 1:
+		// Retain backwards compatibility with previous implementation that reported
+		// only the first error key.
+		return fmt.Errorf("JSON contains unexpected field %s", missingKeys[0])
+	default:
+		sort.Strings(missingKeys)
+		return fmt.Errorf("JSON contains unexpected field %v", missingKeys)
+	}
+
+	if len(unexpectedLeafNodes) != 0 {
+		return fmt.Errorf("JSON contains unexpected leaf field(s) %v at non-leaf node", unexpectedLeafNodes)
+	}
+	return nil
+}
+
+// unknownDataTreeFields returns the paths of the fields within jsonTree that do
+// not match any of the paths supplied in dataPaths, using the same matching
+// rules as checkDataTreeAgainstPaths. Each returned path consists of the keys of
+// jsonTree that lead to the unmatched field. It also returns the names of the
+// fields that are leaves within jsonTree, but for which dataPaths specifies
+// that children are expected.
+func unknownDataTreeFields(jsonTree map[string]interface{}, dataPaths [][]string) ([][]string, []string) {
 	// Primarily, we build a trie that consists of all the valid paths that we were provided
 	// in the dataPaths tree.
 	tree := map[string]interface{}{}
@@ -239,16 +268,16 @@ func checkDataTreeAgainstPaths(jsonTree map[string]interface{}, dataPaths [][]st
 		parent[util.StripModulePrefix(ch[len(ch)-1])] = true
 	}
 
-	var missingKeys []string
+	var unknown [][]string
 	var unexpectedLeafNodes []string
 	// We have to define the function up-front so that we can recursively call the
 	// anonymous function.
-	var checkTree func(map[string]interface{}, map[string]interface{})
-	checkTree = func(jsonTree map[string]interface{}, keyTree map[string]interface{}) {
+	var checkTree func([]string, map[string]interface{}, map[string]interface{})
+	checkTree = func(prefix []string, jsonTree map[string]interface{}, keyTree map[string]interface{}) {
 		for key := range jsonTree {
 			shortKey := util.StripModulePrefix(key)
 			if _, ok := keyTree[shortKey]; !ok {
-				missingKeys = append(missingKeys, shortKey)
+				unknown = append(unknown, append(append([]string{}, prefix...), key))
 			}
 			if ct, ok := keyTree[shortKey].(map[string]interface{}); ok {
 				// If this is a non-leaf node for keyTree, then
@@ -256,29 +285,15 @@ func checkDataTreeAgainstPaths(jsonTree map[string]interface{}, dataPaths [][]st
 				// The converse is not true, since keyTree is
 				// just a partial path.
 				if jt, ok := jsonTree[key].(map[string]interface{}); ok {
-					checkTree(jt, ct)
+					checkTree(append(prefix, key), jt, ct)
 				} else {
 					unexpectedLeafNodes = append(unexpectedLeafNodes, shortKey)
 				}
 			}
 		}
 	}
-	checkTree(jsonTree, tree)
-	switch len(missingKeys) {
-	case 0:
-	case 1:
-		// Retain backwards compatibility with previous implementation that reported
-		// only the first error key.
-		return fmt.Errorf("JSON contains unexpected field %s", missingKeys[0])
-	default:
-		sort.Strings(missingKeys)
-		return fmt.Errorf("JSON contains unexpected field %v", missingKeys)
-	}
-
-	if len(unexpectedLeafNodes) != 0 {
-		return fmt.Errorf("JSON contains unexpected leaf field(s) %v at non-leaf node", unexpectedLeafNodes)
-	}
-	return nil
+	checkTree(nil, jsonTree, tree)
+	return unknown, unexpectedLeafNodes
 }
 
 // schemaToStructFieldName returns the string name of the field, which must be
@@ -306,6 +321,9 @@ func schemaToStructFieldName(schema *yang.Entry, parent interface{}, preferShado
 
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
+		if util.IsYgotMetadata(f) || util.IsYgotArrayLen(f) {
+			continue
+		}
 		fieldName := f.Name
 		relativeSchemaPathFn := util.RelativeSchemaPath
 		if preferShadowPath {
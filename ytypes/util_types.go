@@ -119,7 +119,7 @@ func castToOneEnumValue(ets []reflect.Type, value string) (interface{}, error) {
 // castToEnumValue returns value as the given type ft, if value is one of
 // the allowed values of ft, or nil, nil otherwise.
 func castToEnumValue(ft reflect.Type, value string) (interface{}, error) {
-	if ft.Kind() == reflect.Slice {
+	if ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array {
 		// leaf-list case
 		ft = ft.Elem()
 	}
@@ -260,6 +260,8 @@ func stringToKeyType(schema *yang.Entry, parent interface{}, fieldName string, v
 		return reflect.ValueOf([]byte(v)), nil
 	case yang.Ystring:
 		return reflect.ValueOf(value), nil
+	case yang.YinstanceIdentifier:
+		return reflect.ValueOf(ygot.InstanceIdentifier(value)), nil
 	case yang.Ybool:
 		switch value {
 		case "true":
@@ -372,6 +374,8 @@ func yangBuiltinTypeToGoType(t yang.TypeKind) interface{} {
 		return bool(false)
 	case yang.Ystring:
 		return string("")
+	case yang.YinstanceIdentifier:
+		return ygot.InstanceIdentifier("")
 	case yang.Ydecimal64:
 		return float64(0)
 	case yang.Ybinary:
@@ -392,7 +396,7 @@ func yangToJSONType(t yang.TypeKind) reflect.Type {
 	case yang.Yint8, yang.Yint16, yang.Yint32,
 		yang.Yuint8, yang.Yuint16, yang.Yuint32:
 		return reflect.TypeOf(float64(0))
-	case yang.Ybinary, yang.Ydecimal64, yang.Yenum, yang.Yidentityref, yang.Yint64, yang.Yuint64, yang.Ystring:
+	case yang.Ybinary, yang.Ydecimal64, yang.Yenum, yang.Yidentityref, yang.Yint64, yang.Yuint64, yang.Ystring, yang.YinstanceIdentifier:
 		return reflect.TypeOf(string(""))
 	case yang.Ybool:
 		return reflect.TypeOf(bool(false))
@@ -15,13 +15,25 @@
 package ytypes
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/util"
 	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
+// ValidateFieldHooksFieldName is the name of the field of a GoStruct that
+// stores user-supplied validation hooks for the other fields of the struct.
+// The field must be of type map[string]func(interface{}) error, keyed by the
+// name of the field that each hook validates, and be tagged as embedded
+// metadata such that it is not treated as a schema node. Each hook is called
+// with the value of its field when the struct is validated, if the field is
+// populated, and any error that it returns is reported as a validation error.
+const ValidateFieldHooksFieldName = "ΛValidateFieldHooks"
+
 // LeafrefOptions controls the behaviour of validation functions for leaf-ref
 // data types.
 type LeafrefOptions struct {
@@ -54,9 +66,61 @@ type CustomValidationOptions struct {
 // interface.
 func (*CustomValidationOptions) IsValidationOption() {}
 
+// EnforceChoiceCardinality specifies that validation should check that data
+// nodes from at most one case of each choice are populated throughout the
+// data tree being validated, and report the data tree paths of the nodes that
+// conflict for each choice where this is not the case. The choice groupings
+// are determined from the schema supplied to Validate.
+type EnforceChoiceCardinality struct{}
+
+// IsValidationOption ensures that EnforceChoiceCardinality implements the
+// ValidationOption interface.
+func (*EnforceChoiceCardinality) IsValidationOption() {}
+
 // Validate recursively validates the value of the given data tree struct
 // against the given schema.
 func Validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOption) util.Errors {
+	return validate(context.Background(), schema, value, opts...)
+}
+
+// ValidateContext recursively validates the value of the given data tree
+// struct against the given schema in the same manner as Validate. The supplied
+// context is checked as each node of the data tree is traversed, such that
+// validation stops if ctx is cancelled or its deadline expires, in which case
+// the only error returned is ctx.Err().
+func ValidateContext(ctx context.Context, schema *yang.Entry, value interface{}, opts ...ygot.ValidationOption) util.Errors {
+	errs := validate(ctx, schema, value, opts...)
+	if err := ctx.Err(); err != nil {
+		return util.NewErrs(err)
+	}
+	return errs
+}
+
+// ValidateAt validates only the subtree of the data tree struct root, which
+// must have the given schema, that is found at the supplied path. Where path
+// contains wildcards, each matching subtree is validated. An error is returned
+// if path cannot be found within root, whereas an unpopulated subtree is
+// considered valid. Since leafref validation is performed only when
+// the fake root is validated, leafrefs within the subtree are not resolved
+// unless path is empty and root is the fake root.
+func ValidateAt(schema *yang.Entry, root interface{}, path *gpb.Path, opts ...ygot.ValidationOption) util.Errors {
+	nodes, err := GetNode(schema, root, path, &GetHandleWildcards{})
+	if err != nil {
+		return util.NewErrs(fmt.Errorf("cannot find subtree to validate at path %v: %v", path, err))
+	}
+	var errs util.Errors
+	for _, n := range nodes {
+		errs = util.AppendErrs(errs, validate(context.Background(), n.Schema, n.Data, opts...))
+	}
+	return errs
+}
+
+// validate implements Validate and ValidateContext, returning ctx.Err() without
+// traversing value if ctx is done.
+func validate(ctx context.Context, schema *yang.Entry, value interface{}, opts ...ygot.ValidationOption) util.Errors {
+	if err := ctx.Err(); err != nil {
+		return util.NewErrs(err)
+	}
 	// Nil value means the field is unset.
 	if util.IsValueNil(value) {
 		return nil
@@ -72,12 +136,15 @@ func Validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOpti
 	// explicitly returning an error.
 	var leafrefOpt *LeafrefOptions
 	var customValidOpt *CustomValidationOptions
+	var choiceCardinalityOpt *EnforceChoiceCardinality
 	for _, o := range opts {
 		switch v := o.(type) {
 		case *LeafrefOptions:
 			leafrefOpt = v
 		case *CustomValidationOptions:
 			customValidOpt = v
+		case *EnforceChoiceCardinality:
+			choiceCardinalityOpt = v
 		}
 	}
 
@@ -96,21 +163,28 @@ func Validate(schema *yang.Entry, value interface{}, opts ...ygot.ValidationOpti
 		}
 	}
 
+	// Options are not passed to the recursive calls of validate, hence the
+	// choice cardinality check of the entire data tree is performed only once
+	// here.
+	if choiceCardinalityOpt != nil {
+		errs = util.AppendErrs(errs, validateChoiceCardinality(schema, value))
+	}
+
 	util.DbgPrint("Validate with value %v, type %T, schema name %s", util.ValueStr(value), value, schema.Name)
 
 	switch {
 	case schema.IsLeaf():
 		return util.AppendErrs(errs, validateLeaf(schema, value))
-	case schema.IsContainer():
+	case schema.IsContainer(), util.IsOperationPayload(schema):
 		gsv, ok := value.(ygot.GoStruct)
 		if !ok {
 			return util.AppendErr(errs, fmt.Errorf("type %T is not a GoStruct for schema %s", value, schema.Name))
 		}
-		return util.AppendErrs(errs, validateContainer(schema, gsv))
+		return util.AppendErrs(errs, validateContainer(ctx, schema, gsv))
 	case schema.IsLeafList():
 		return util.AppendErrs(errs, validateLeafList(schema, value))
 	case schema.IsList():
-		return util.AppendErrs(errs, validateList(schema, value))
+		return util.AppendErrs(errs, validateList(ctx, schema, value))
 	case schema.IsChoice():
 		return util.AppendErrs(errs, util.NewErrs(fmt.Errorf("cannot pass choice schema %s to Validate", schema.Name)))
 	}
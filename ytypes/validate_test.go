@@ -15,12 +15,18 @@
 package ytypes
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/openconfig/gnmi/errdiff"
 	"github.com/openconfig/goyang/pkg/yang"
 	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 )
 
 type Case1Leaf1ChoiceStruct struct {
@@ -305,3 +311,222 @@ func TestValidate(t *testing.T) {
 	}
 
 }
+
+// countdownContext is a context.Context that reports that it has been
+// cancelled once its Err method has been called more than n times.
+type countdownContext struct {
+	context.Context
+	n int
+}
+
+func (c *countdownContext) Err() error {
+	if c.n <= 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func TestValidateContext(t *testing.T) {
+	listSchema := &yang.Entry{
+		Name:     "list-schema",
+		Kind:     yang.DirectoryEntry,
+		ListAttr: yang.NewDefaultListAttr(),
+		Dir: map[string]*yang.Entry{
+			"leaf-name": {
+				Kind: yang.LeafEntry,
+				Name: "LeafName",
+				Type: &yang.YangType{
+					Kind:    yang.Ystring,
+					Pattern: []string{"^a.*"},
+				},
+			},
+		},
+	}
+
+	type StringListElemStruct struct {
+		LeafName *string `path:"leaf-name"`
+	}
+
+	// Each element of the list is invalid, such that errors are returned
+	// unless validation is cancelled before any element is validated.
+	var list []*StringListElemStruct
+	for i := 0; i < 10; i++ {
+		list = append(list, &StringListElemStruct{LeafName: ygot.String(fmt.Sprintf("b%d", i))})
+	}
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expiredCtx, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Minute))
+	defer cancelExpired()
+
+	tests := []struct {
+		desc       string
+		inCtx      context.Context
+		wantCtxErr error
+		wantErrLen int
+	}{{
+		desc:       "not cancelled",
+		inCtx:      context.Background(),
+		wantErrLen: 10,
+	}, {
+		desc:       "cancelled before validation",
+		inCtx:      cancelledCtx,
+		wantCtxErr: context.Canceled,
+	}, {
+		desc:       "deadline exceeded before validation",
+		inCtx:      expiredCtx,
+		wantCtxErr: context.DeadlineExceeded,
+	}, {
+		desc:       "cancelled partway through validation",
+		inCtx:      &countdownContext{Context: context.Background(), n: 3},
+		wantCtxErr: context.Canceled,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := ValidateContext(tt.inCtx, listSchema, list)
+			if tt.wantCtxErr != nil {
+				if len(errs) != 1 || !errors.Is(errs[0], tt.wantCtxErr) {
+					t.Fatalf("%s: ValidateContext did not get expected error, got: %v, want: %v", tt.desc, errs, tt.wantCtxErr)
+				}
+				return
+			}
+			if len(errs) != tt.wantErrLen {
+				t.Errorf("%s: ValidateContext did not get expected number of errors, got: %d (%v), want: %d", tt.desc, len(errs), errs, tt.wantErrLen)
+			}
+		})
+	}
+}
+
+type validateAtChildStruct struct {
+	One *string `path:"config/one"`
+}
+
+func (*validateAtChildStruct) IsYANGGoStruct()                          {}
+func (*validateAtChildStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*validateAtChildStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*validateAtChildStruct) ΛBelongingModule() string                 { return "openconfig-simple" }
+
+type validateAtParentStruct struct {
+	Child *validateAtChildStruct `path:"child"`
+	Peer  *validateAtChildStruct `path:"peer"`
+}
+
+func (*validateAtParentStruct) IsYANGGoStruct()                          {}
+func (*validateAtParentStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*validateAtParentStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*validateAtParentStruct) ΛBelongingModule() string                 { return "openconfig-simple" }
+
+type validateAtRootStruct struct {
+	Parent *validateAtParentStruct `path:"parent"`
+}
+
+func (*validateAtRootStruct) IsYANGGoStruct()                          {}
+func (*validateAtRootStruct) ΛValidate(...ygot.ValidationOption) error { return nil }
+func (*validateAtRootStruct) ΛEnumTypeMap() map[string][]reflect.Type  { return nil }
+func (*validateAtRootStruct) ΛBelongingModule() string                 { return "" }
+
+func TestValidateAt(t *testing.T) {
+	// The schema mirrors the /parent/child container of openconfig-simple,
+	// with a sibling peer container of the same type.
+	childSchema := func(name string) *yang.Entry {
+		return &yang.Entry{
+			Name: name,
+			Kind: yang.DirectoryEntry,
+			Dir: map[string]*yang.Entry{
+				"config": {
+					Name: "config",
+					Kind: yang.DirectoryEntry,
+					Dir: map[string]*yang.Entry{
+						"one": {
+							Name: "one",
+							Kind: yang.LeafEntry,
+							Type: &yang.YangType{Kind: yang.Ystring, Pattern: []string{"^a.*"}},
+						},
+					},
+				},
+			},
+		}
+	}
+	rootSchema := &yang.Entry{
+		Name: "device",
+		Kind: yang.DirectoryEntry,
+		Dir: map[string]*yang.Entry{
+			"parent": {
+				Name: "parent",
+				Kind: yang.DirectoryEntry,
+				Dir: map[string]*yang.Entry{
+					"child": childSchema("child"),
+					"peer":  childSchema("peer"),
+				},
+			},
+		},
+		Annotation: map[string]interface{}{"isFakeRoot": true},
+	}
+	addParents(rootSchema)
+
+	childPath := &gpb.Path{Elem: []*gpb.PathElem{{Name: "parent"}, {Name: "child"}}}
+
+	tests := []struct {
+		desc       string
+		inRoot     *validateAtRootStruct
+		inPath     *gpb.Path
+		wantErrLen int
+		wantErr    string
+	}{{
+		desc: "valid child subtree, invalid peer not validated",
+		inRoot: &validateAtRootStruct{Parent: &validateAtParentStruct{
+			Child: &validateAtChildStruct{One: ygot.String("alpha")},
+			Peer:  &validateAtChildStruct{One: ygot.String("bravo")},
+		}},
+		inPath: childPath,
+	}, {
+		desc: "invalid child subtree",
+		inRoot: &validateAtRootStruct{Parent: &validateAtParentStruct{
+			Child: &validateAtChildStruct{One: ygot.String("bravo")},
+		}},
+		inPath:     childPath,
+		wantErrLen: 1,
+	}, {
+		desc: "invalid leaf within child subtree",
+		inRoot: &validateAtRootStruct{Parent: &validateAtParentStruct{
+			Child: &validateAtChildStruct{One: ygot.String("bravo")},
+		}},
+		inPath:     &gpb.Path{Elem: []*gpb.PathElem{{Name: "parent"}, {Name: "child"}, {Name: "config"}, {Name: "one"}}},
+		wantErrLen: 1,
+	}, {
+		desc: "whole tree",
+		inRoot: &validateAtRootStruct{Parent: &validateAtParentStruct{
+			Child: &validateAtChildStruct{One: ygot.String("alpha")},
+			Peer:  &validateAtChildStruct{One: ygot.String("bravo")},
+		}},
+		inPath:     &gpb.Path{},
+		wantErrLen: 1,
+	}, {
+		desc:   "unpopulated subtree",
+		inRoot: &validateAtRootStruct{Parent: &validateAtParentStruct{}},
+		inPath: childPath,
+	}, {
+		desc:       "path not in schema",
+		inRoot:     &validateAtRootStruct{Parent: &validateAtParentStruct{}},
+		inPath:     &gpb.Path{Elem: []*gpb.PathElem{{Name: "parent"}, {Name: "sibling"}}},
+		wantErrLen: 1,
+		wantErr:    "cannot find subtree to validate",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := ValidateAt(rootSchema, tt.inRoot, tt.inPath)
+			if len(errs) != tt.wantErrLen {
+				t.Fatalf("%s: ValidateAt did not get expected number of errors, got: %d (%v), want: %d", tt.desc, len(errs), errs, tt.wantErrLen)
+			}
+			if tt.wantErr != "" {
+				if diff := errdiff.Substring(errs[0], tt.wantErr); diff != "" {
+					t.Errorf("%s: ValidateAt did not get expected error, %s", tt.desc, diff)
+				}
+			}
+		})
+	}
+}